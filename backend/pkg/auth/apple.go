@@ -0,0 +1,314 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	appleIssuer       = "https://appleid.apple.com"
+	appleAuthorizeURL = "https://appleid.apple.com/auth/authorize"
+	appleTokenURL     = "https://appleid.apple.com/auth/token"
+	appleKeysURL      = "https://appleid.apple.com/auth/keys"
+
+	appleKeysTTL         = 24 * time.Hour
+	appleClientSecretTTL = 5 * time.Minute
+)
+
+// ErrAppleNotConfigured is returned when Apple Sign-In is used without credentials
+var ErrAppleNotConfigured = errors.New("apple sign-in is not configured")
+
+// AppleConfig holds Apple Sign-In credentials
+type AppleConfig struct {
+	ClientID    string // Services ID
+	TeamID      string
+	KeyID       string
+	PrivateKey  string // PEM-encoded .p8 key
+	RedirectURL string
+}
+
+// AppleUserInfo holds the verified identity returned by Apple
+type AppleUserInfo struct {
+	ID            string
+	Email         string
+	EmailVerified bool
+}
+
+// AppleClaims holds the claims of an Apple identity token
+type AppleClaims struct {
+	Email          string      `json:"email"`
+	EmailVerified  interface{} `json:"email_verified"`
+	IsPrivateEmail interface{} `json:"is_private_email"`
+	jwt.RegisteredClaims
+}
+
+// appleProvider performs the Apple Sign-In flow
+type appleProvider struct {
+	config     AppleConfig
+	signingKey *ecdsa.PrivateKey
+	httpClient *http.Client
+
+	mu        sync.RWMutex
+	keys      map[string]*rsa.PublicKey
+	keysFetch time.Time
+}
+
+// EnableApple configures Apple Sign-In on the OAuth manager
+func (m *OAuthManager) EnableApple(cfg AppleConfig) error {
+	block, _ := pem.Decode([]byte(cfg.PrivateKey))
+	if block == nil {
+		return errors.New("apple private key: invalid PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("apple private key: %w", err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return errors.New("apple private key: not an ECDSA key")
+	}
+
+	m.apple = &appleProvider{
+		config:     cfg,
+		signingKey: ecKey,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+	return nil
+}
+
+// AppleEnabled reports whether Apple Sign-In is configured
+func (m *OAuthManager) AppleEnabled() bool {
+	return m.apple != nil
+}
+
+// GetAppleAuthURL returns the Apple authorization URL
+func (m *OAuthManager) GetAppleAuthURL(state string) (string, error) {
+	if m.apple == nil {
+		return "", ErrAppleNotConfigured
+	}
+
+	params := url.Values{}
+	params.Set("client_id", m.apple.config.ClientID)
+	params.Set("redirect_uri", m.apple.config.RedirectURL)
+	params.Set("response_type", "code")
+	params.Set("scope", "name email")
+	// Apple requires form_post when requesting name or email
+	params.Set("response_mode", "form_post")
+	params.Set("state", state)
+
+	return appleAuthorizeURL + "?" + params.Encode(), nil
+}
+
+// AuthenticateWithApple exchanges the code and verifies the returned identity token
+func (m *OAuthManager) AuthenticateWithApple(ctx context.Context, code string) (*AppleUserInfo, error) {
+	if m.apple == nil {
+		return nil, ErrAppleNotConfigured
+	}
+
+	idToken, err := m.apple.exchangeCode(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange code: %w", err)
+	}
+
+	claims, err := m.apple.verifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify identity token: %w", err)
+	}
+
+	return &AppleUserInfo{
+		ID:            claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: appleBool(claims.EmailVerified),
+	}, nil
+}
+
+// clientSecret builds the ES256-signed client secret Apple expects
+func (p *appleProvider) clientSecret() (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Issuer:    p.config.TeamID,
+		Subject:   p.config.ClientID,
+		Audience:  jwt.ClaimStrings{appleIssuer},
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(appleClientSecretTTL)),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	token.Header["kid"] = p.config.KeyID
+	return token.SignedString(p.signingKey)
+}
+
+// exchangeCode exchanges an authorization code for an identity token
+func (p *appleProvider) exchangeCode(ctx context.Context, code string) (string, error) {
+	secret, err := p.clientSecret()
+	if err != nil {
+		return "", err
+	}
+
+	form := url.Values{}
+	form.Set("client_id", p.config.ClientID)
+	form.Set("client_secret", secret)
+	form.Set("code", code)
+	form.Set("grant_type", "authorization_code")
+	form.Set("redirect_uri", p.config.RedirectURL)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, appleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return "", fmt.Errorf("status %d, body: %s", resp.StatusCode, string(body))
+	}
+
+	var tokenResp struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if tokenResp.IDToken == "" {
+		return "", errors.New("token response has no id_token")
+	}
+
+	return tokenResp.IDToken, nil
+}
+
+// verifyIDToken validates an identity token's signature and claims against Apple's JWKS
+func (p *appleProvider) verifyIDToken(ctx context.Context, idToken string) (*AppleClaims, error) {
+	token, err := jwt.ParseWithClaims(idToken, &AppleClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return p.publicKey(ctx, kid)
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg()}),
+		jwt.WithIssuer(appleIssuer),
+		jwt.WithAudience(p.config.ClientID),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*AppleClaims)
+	if !ok || !token.Valid || claims.Subject == "" {
+		return nil, errors.New("invalid token")
+	}
+
+	return claims, nil
+}
+
+// publicKey returns Apple's signing key for kid, refreshing the JWKS when stale or unknown
+func (p *appleProvider) publicKey(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	p.mu.RLock()
+	key, ok := p.keys[kid]
+	fresh := time.Since(p.keysFetch) < appleKeysTTL
+	p.mu.RUnlock()
+
+	if ok && fresh {
+		return key, nil
+	}
+
+	if err := p.fetchKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown apple signing key %q", kid)
+}
+
+// fetchKeys downloads and parses Apple's JWKS
+func (p *appleProvider) fetchKeys(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, appleKeysURL, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch apple keys: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to fetch apple keys: status %d", resp.StatusCode)
+	}
+
+	var jwks struct {
+		Keys []struct {
+			Kid string `json:"kid"`
+			Kty string `json:"kty"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&jwks); err != nil {
+		return fmt.Errorf("failed to decode apple keys: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(jwks.Keys))
+	for _, k := range jwks.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			continue
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			continue
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+
+	p.mu.Lock()
+	p.keys = keys
+	p.keysFetch = time.Now()
+	p.mu.Unlock()
+	return nil
+}
+
+// appleBool handles Apple sending boolean claims as either bools or strings
+func appleBool(v interface{}) bool {
+	switch b := v.(type) {
+	case bool:
+		return b
+	case string:
+		return b == "true"
+	default:
+		return false
+	}
+}
@@ -16,8 +16,9 @@ type JWTManager struct {
 
 // AccessClaims holds the claims for access tokens
 type AccessClaims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
+	UserID       string `json:"user_id"`
+	Email        string `json:"email"`
+	TokenVersion int    `json:"ver"`
 	jwt.RegisteredClaims
 }
 
@@ -46,8 +47,8 @@ func NewJWTManager(secretKey string, accessDuration, refreshDuration time.Durati
 }
 
 // GenerateTokenPair creates a new access and refresh token pair
-func (m *JWTManager) GenerateTokenPair(userID, email string) (*TokenPair, error) {
-	accessToken, expiresIn, err := m.GenerateAccessToken(userID, email)
+func (m *JWTManager) GenerateTokenPair(userID, email string, tokenVersion int) (*TokenPair, error) {
+	accessToken, expiresIn, err := m.GenerateAccessToken(userID, email, tokenVersion)
 	if err != nil {
 		return nil, err
 	}
@@ -66,13 +67,19 @@ func (m *JWTManager) GenerateTokenPair(userID, email string) (*TokenPair, error)
 }
 
 // GenerateAccessToken creates a new access token
-func (m *JWTManager) GenerateAccessToken(userID, email string) (string, int64, error) {
+func (m *JWTManager) GenerateAccessToken(userID, email string, tokenVersion int) (string, int64, error) {
+	return m.GenerateAccessTokenWithDuration(userID, email, tokenVersion, m.accessDuration)
+}
+
+// GenerateAccessTokenWithDuration creates a new access token with a custom lifetime
+func (m *JWTManager) GenerateAccessTokenWithDuration(userID, email string, tokenVersion int, duration time.Duration) (string, int64, error) {
 	now := time.Now()
-	expiresAt := now.Add(m.accessDuration)
+	expiresAt := now.Add(duration)
 
 	claims := &AccessClaims{
-		UserID: userID,
-		Email:  email,
+		UserID:       userID,
+		Email:        email,
+		TokenVersion: tokenVersion,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(now),
@@ -88,7 +95,7 @@ func (m *JWTManager) GenerateAccessToken(userID, email string) (string, int64, e
 		return "", 0, err
 	}
 
-	return signedToken, int64(m.accessDuration.Seconds()), nil
+	return signedToken, int64(duration.Seconds()), nil
 }
 
 // GenerateRefreshToken creates a new refresh token
@@ -159,11 +166,11 @@ func (m *JWTManager) ValidateRefreshToken(tokenString string) (*RefreshClaims, e
 }
 
 // RefreshAccessToken generates a new access token from a refresh token
-func (m *JWTManager) RefreshAccessToken(refreshToken, email string) (*TokenPair, error) {
+func (m *JWTManager) RefreshAccessToken(refreshToken, email string, tokenVersion int) (*TokenPair, error) {
 	claims, err := m.ValidateRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
 	}
 
-	return m.GenerateTokenPair(claims.UserID, email)
+	return m.GenerateTokenPair(claims.UserID, email, tokenVersion)
 }
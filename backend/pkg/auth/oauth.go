@@ -25,6 +25,7 @@ type GoogleUserInfo struct {
 // OAuthManager handles OAuth operations
 type OAuthManager struct {
 	googleConfig *oauth2.Config
+	apple        *appleProvider
 }
 
 // NewOAuthManager creates a new OAuth manager
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrTokenRevoked is returned when an access token predates the user's current token version
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// tokenVersionTTL bounds how long a cached version is kept. It only has to
+// outlive the longest-lived access token issued before a bump.
+const tokenVersionTTL = 24 * time.Hour
+
+// TokenVersionLoader loads the authoritative token version for a user
+type TokenVersionLoader func(ctx context.Context, userID string) (int, error)
+
+// TokenVersionStore caches per-user token versions in Redis so every service
+// can reject access tokens issued before a "logout everywhere"
+type TokenVersionStore struct {
+	redis  *redis.Client
+	loader TokenVersionLoader
+}
+
+// NewTokenVersionStore creates a token version store. The loader repopulates
+// the cache on a miss: the auth service reads the users table, other services
+// ask the auth service. It may only be nil in tests.
+func NewTokenVersionStore(redisClient *redis.Client, loader TokenVersionLoader) *TokenVersionStore {
+	return &TokenVersionStore{
+		redis:  redisClient,
+		loader: loader,
+	}
+}
+
+// Get returns the current token version for a user, loading and caching it on
+// a miss. Without a loader a miss is reported as version 0.
+func (s *TokenVersionStore) Get(ctx context.Context, userID string) (int, error) {
+	version, err := s.redis.Get(ctx, tokenVersionKey(userID)).Int()
+	if err == nil {
+		return version, nil
+	}
+	if !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	if s.loader == nil {
+		return 0, nil
+	}
+
+	version, err = s.loader(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	_ = s.Set(ctx, userID, version)
+	return version, nil
+}
+
+// Set stores the current token version for a user
+func (s *TokenVersionStore) Set(ctx context.Context, userID string, version int) error {
+	return s.redis.Set(ctx, tokenVersionKey(userID), version, tokenVersionTTL).Err()
+}
+
+// Check returns ErrTokenRevoked if the claims were issued before the user's current version
+func (s *TokenVersionStore) Check(ctx context.Context, claims *AccessClaims) error {
+	if s == nil {
+		return nil
+	}
+
+	current, err := s.Get(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	if claims.TokenVersion < current {
+		return ErrTokenRevoked
+	}
+	return nil
+}
+
+// tokenVersionKey is shared across services, so it is not namespaced by the per-service cache prefix
+func tokenVersionKey(userID string) string {
+	return fmt.Sprintf("auth:token_version:%s", userID)
+}
@@ -0,0 +1,33 @@
+package cache
+
+import (
+	"context"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// Flight collapses concurrent loads of the same key, such as callers missing
+// the cache for one price at the same time, into a single call
+type Flight[T any] struct {
+	group singleflight.Group
+}
+
+// Do returns the result of load for key, sharing one call of load among the
+// callers asking for key while it runs. load runs detached from ctx, so a
+// caller giving up doesn't fail the others. Each caller gets its own copy of
+// the value.
+func (f *Flight[T]) Do(ctx context.Context, key string, load func(ctx context.Context) (*T, error)) (*T, error) {
+	results := f.group.DoChan(key, func() (interface{}, error) {
+		return load(context.WithoutCancel(ctx))
+	})
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-results:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		value := *res.Val.(*T)
+		return &value, nil
+	}
+}
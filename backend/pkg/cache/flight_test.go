@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type quote struct {
+	Price float64
+}
+
+// blockingLoad counts its calls and blocks each one until release is closed
+type blockingLoad struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingLoad() *blockingLoad {
+	return &blockingLoad{started: make(chan struct{}, 100), release: make(chan struct{})}
+}
+
+func (l *blockingLoad) load(ctx context.Context) (*quote, error) {
+	l.calls.Add(1)
+	l.started <- struct{}{}
+	select {
+	case <-l.release:
+		return &quote{Price: 42}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runConcurrently calls fn from n goroutines that start together, releases
+// load once they are all waiting on it and waits for them to finish
+func runConcurrently(t *testing.T, n int, load *blockingLoad, fn func()) {
+	t.Helper()
+	var ready, done sync.WaitGroup
+	ready.Add(n)
+	done.Add(n)
+	for i := 0; i < n; i++ {
+		go func() {
+			defer done.Done()
+			ready.Done()
+			fn()
+		}()
+	}
+	ready.Wait()
+	<-load.started
+	// Let the remaining goroutines reach the flight before it lands
+	time.Sleep(50 * time.Millisecond)
+	close(load.release)
+	done.Wait()
+}
+
+func TestFlightSharesOneLoad(t *testing.T) {
+	var flight Flight[quote]
+	load := newBlockingLoad()
+
+	var mu sync.Mutex
+	var results []*quote
+	runConcurrently(t, 50, load, func() {
+		q, err := flight.Do(context.Background(), "BTC", load.load)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		mu.Lock()
+		results = append(results, q)
+		mu.Unlock()
+	})
+
+	if calls := load.calls.Load(); calls != 1 {
+		t.Fatalf("load called %d times, want 1", calls)
+	}
+	if len(results) != 50 {
+		t.Fatalf("%d callers got a result, want 50", len(results))
+	}
+
+	// Each caller has its own copy
+	results[0].Price = 0
+	for _, q := range results[1:] {
+		if q.Price != 42 {
+			t.Fatalf("shared result changed to %v", q.Price)
+		}
+	}
+}
+
+func TestFlightSeparateKeys(t *testing.T) {
+	var flight Flight[quote]
+	var calls atomic.Int32
+	load := func(ctx context.Context) (*quote, error) {
+		calls.Add(1)
+		return &quote{Price: 1}, nil
+	}
+
+	for _, key := range []string{"crypto:BTC", "crypto:ETH", "stock:BTC"} {
+		if _, err := flight.Do(context.Background(), key, load); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := calls.Load(); got != 3 {
+		t.Fatalf("load called %d times for 3 keys, want 3", got)
+	}
+}
+
+func TestFlightCallerGivingUp(t *testing.T) {
+	var flight Flight[quote]
+	load := newBlockingLoad()
+
+	impatient, cancel := context.WithCancel(context.Background())
+	impatientErr := make(chan error, 1)
+	go func() {
+		_, err := flight.Do(impatient, "BTC", load.load)
+		impatientErr <- err
+	}()
+	<-load.started
+
+	patient := make(chan *quote, 1)
+	go func() {
+		q, err := flight.Do(context.Background(), "BTC", load.load)
+		if err != nil {
+			t.Error(err)
+		}
+		patient <- q
+	}()
+
+	cancel()
+	if err := <-impatientErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled caller: got %v, want context.Canceled", err)
+	}
+
+	// The load carries on for the caller still waiting
+	time.Sleep(20 * time.Millisecond)
+	close(load.release)
+	if q := <-patient; q == nil || q.Price != 42 {
+		t.Fatalf("waiting caller got %v, want the loaded quote", q)
+	}
+	if calls := load.calls.Load(); calls != 1 {
+		t.Fatalf("load called %d times, want 1", calls)
+	}
+}
+
+func TestFlightSharesErrors(t *testing.T) {
+	var flight Flight[quote]
+	want := errors.New("provider down")
+	_, err := flight.Do(context.Background(), "BTC", func(ctx context.Context) (*quote, error) {
+		return nil, want
+	})
+	if !errors.Is(err, want) {
+		t.Fatalf("got %v, want the load error", err)
+	}
+}
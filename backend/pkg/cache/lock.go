@@ -0,0 +1,169 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrLockHeld is returned by TryLock when another holder has the lock
+var ErrLockHeld = errors.New("lock is held by another holder")
+
+// jobLockTTL bounds how long a crashed job holder blocks the others
+const jobLockTTL = time.Minute
+
+// Only the holder's token may extend or release a lock
+var (
+	renewLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+
+	releaseLockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+)
+
+// Lock is a distributed lock held in Redis
+type Lock struct {
+	cache *Cache
+	key   string
+	token string
+	ttl   time.Duration
+
+	stop     chan struct{}
+	lost     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// TryLock acquires the lock on key without waiting, returning ErrLockHeld
+// when someone else holds it. The lock is renewed in the background until
+// Unlock, so it outlives ttl while the holder is alive but expires within
+// ttl if the holder crashes.
+func (c *Cache) TryLock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(buf)
+
+	acquired, err := c.client.SetNX(ctx, c.key(key), token, ttl).Result()
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrLockHeld
+	}
+
+	l := &Lock{
+		cache: c,
+		key:   c.key(key),
+		token: token,
+		ttl:   ttl,
+		stop:  make(chan struct{}),
+		lost:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go l.renew()
+	return l, nil
+}
+
+// renew extends the lock every third of its TTL until it is released or lost
+func (l *Lock) renew() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), l.ttl/3)
+			held, err := renewLockScript.Run(ctx, l.cache.client, []string{l.key}, l.token, l.ttl.Milliseconds()).Int()
+			cancel()
+			// A failed renewal is retried on the next tick while the lock
+			// may still be ours; only a lock taken over is given up
+			if err == nil && held == 0 {
+				close(l.lost)
+				return
+			}
+		}
+	}
+}
+
+// Lost is closed when the lock expired or was taken over before Unlock, so
+// work done under it should stop
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+// Unlock stops renewing the lock and releases it if it is still held
+func (l *Lock) Unlock(ctx context.Context) error {
+	l.stopOnce.Do(func() { close(l.stop) })
+	<-l.done
+	return releaseLockScript.Run(ctx, l.cache.client, []string{l.key}, l.token).Err()
+}
+
+// RunSingleton runs fn on at most one replica at a time, and at most once
+// per run: a name for the tick, such as a date, so replicas whose timers
+// fire at different moments don't repeat work another has finished. The run
+// is remembered for period. fn's context is canceled if the lock is lost.
+// With a nil cache there is a single replica, and fn always runs. It reports
+// whether fn ran.
+func (c *Cache) RunSingleton(ctx context.Context, job, run string, period time.Duration, fn func(ctx context.Context) error) (bool, error) {
+	if c == nil {
+		return true, fn(ctx)
+	}
+
+	lock, err := c.TryLock(ctx, JobLockKey(job), jobLockTTL)
+	if err != nil {
+		if errors.Is(err, ErrLockHeld) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer func() {
+		// Release even when ctx was canceled by shutdown
+		releaseCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		if err := lock.Unlock(releaseCtx); err != nil {
+			log.Printf("Failed to release %s job lock: %v", job, err)
+		}
+	}()
+
+	if done, err := c.Exists(ctx, JobRunKey(job, run)); err != nil {
+		return false, err
+	} else if done {
+		return false, nil
+	}
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-lock.Lost():
+			cancel()
+		case <-jobCtx.Done():
+		}
+	}()
+
+	if err := fn(jobCtx); err != nil {
+		return true, err
+	}
+	if err := c.SetString(ctx, JobRunKey(job, run), time.Now().UTC().Format(time.RFC3339), period); err != nil {
+		log.Printf("Failed to record %s job run %s: %v", job, run, err)
+	}
+	return true, nil
+}
@@ -84,6 +84,58 @@ func (c *Cache) Get(ctx context.Context, key string, dest interface{}) error {
 	return json.Unmarshal(data, dest)
 }
 
+// MGet retrieves several values in one round trip. dest must point to a
+// map keyed by string; keys that are missing or can't be decoded are left
+// out, so callers fetch only what isn't in it.
+func (c *Cache) MGet(ctx context.Context, keys []string, dest interface{}) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	prefixedKeys := make([]string, len(keys))
+	for i, k := range keys {
+		prefixedKeys[i] = c.key(k)
+	}
+	values, err := c.client.MGet(ctx, prefixedKeys...).Result()
+	if err != nil {
+		return err
+	}
+
+	found := make(map[string]json.RawMessage, len(values))
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok || !json.Valid([]byte(data)) {
+			continue
+		}
+		found[keys[i]] = json.RawMessage(data)
+	}
+
+	// Decode through one object so dest gets the same JSON handling as Get
+	data, err := json.Marshal(found)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// MSet stores several values with the same expiration in one round trip
+func (c *Cache) MSet(ctx context.Context, items map[string]interface{}, expiration time.Duration) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	pipe := c.client.Pipeline()
+	for k, value := range items {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("failed to marshal value for %s: %w", k, err)
+		}
+		pipe.Set(ctx, c.key(k), data, expiration)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
 // GetString retrieves a string value from cache
 func (c *Cache) GetString(ctx context.Context, key string) (string, error) {
 	return c.client.Get(ctx, c.key(key)).Result()
@@ -143,13 +195,64 @@ func (c *Cache) HGetAll(ctx context.Context, key string) (map[string]string, err
 	return c.client.HGetAll(ctx, c.key(key)).Result()
 }
 
+// GetDelString gets a string value and deletes it in one step, so it can be
+// consumed at most once
+func (c *Cache) GetDelString(ctx context.Context, key string) (string, error) {
+	return c.client.GetDel(ctx, c.key(key)).Result()
+}
+
+// UserEventLogSize is how many recent events are kept per user for resumption
+const UserEventLogSize = 100
+
+const userEventLogTTL = 24 * time.Hour
+
+// NextUserEventID returns the next ID in the user's event sequence. IDs
+// increase per user, so consumers can resume after a given event.
+func (c *Cache) NextUserEventID(ctx context.Context, userID string) (int64, error) {
+	return c.client.Incr(ctx, UserEventSeqKey(userID)).Result()
+}
+
+// AppendUserEvent adds an encoded event to the user's bounded event log and
+// publishes it on the user's events channel. The log and channel are shared
+// between services, so unlike keys they are not prefixed.
+func (c *Cache) AppendUserEvent(ctx context.Context, userID string, event []byte) error {
+	logKey := UserEventLogKey(userID)
+	_, err := c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LPush(ctx, logKey, event)
+		pipe.LTrim(ctx, logKey, 0, UserEventLogSize-1)
+		pipe.Expire(ctx, logKey, userEventLogTTL)
+		pipe.Publish(ctx, UserEventsChannel(userID), event)
+		return nil
+	})
+	return err
+}
+
+// UserEventLog returns the user's logged events, newest first
+func (c *Cache) UserEventLog(ctx context.Context, userID string) ([]string, error) {
+	return c.client.LRange(ctx, UserEventLogKey(userID), 0, -1).Result()
+}
+
+// SubscribeUserEvents subscribes to the user's events channel
+func (c *Cache) SubscribeUserEvents(ctx context.Context, userID string) *redis.PubSub {
+	return c.client.Subscribe(ctx, UserEventsChannel(userID))
+}
+
 // Keys cache key constants
 const (
 	KeyAssetPrice    = "asset:price:%s"
+	KeyAssetHistory  = "asset:history:%s:%s:%s:%s"
 	KeyExchangeRate  = "currency:rate:%s:%s"
 	KeyExchangeRates = "currency:rates:%s"
+	KeyCurrencies    = "currency:list:%t"
 	KeyUserSession   = "session:%s"
 	KeyRateLimit     = "ratelimit:%s:%s"
+	KeyWSTicket      = "ws:ticket:%s"
+	KeyJobLock       = "lock:job:%s"
+	KeyJobRun        = "job:run:%s:%s"
+
+	KeyUserEventSeq   = "events:seq:%s"
+	KeyUserEventLog   = "events:log:%s"
+	ChannelUserEvents = "events:user:%s"
 )
 
 // AssetPriceKey generates cache key for asset price
@@ -157,6 +260,11 @@ func AssetPriceKey(symbol string) string {
 	return fmt.Sprintf(KeyAssetPrice, symbol)
 }
 
+// AssetHistoryKey generates cache key for an asset's price history over a date range
+func AssetHistoryKey(assetType, symbol, start, end string) string {
+	return fmt.Sprintf(KeyAssetHistory, assetType, symbol, start, end)
+}
+
 // ExchangeRateKey generates cache key for exchange rate
 func ExchangeRateKey(from, to string) string {
 	return fmt.Sprintf(KeyExchangeRate, from, to)
@@ -167,6 +275,11 @@ func ExchangeRatesKey(base string) string {
 	return fmt.Sprintf(KeyExchangeRates, base)
 }
 
+// CurrenciesKey generates cache key for the supported currency list
+func CurrenciesKey(includeCrypto bool) string {
+	return fmt.Sprintf(KeyCurrencies, includeCrypto)
+}
+
 // UserSessionKey generates cache key for user session
 func UserSessionKey(userID string) string {
 	return fmt.Sprintf(KeyUserSession, userID)
@@ -177,3 +290,32 @@ func RateLimitKey(identifier, endpoint string) string {
 	return fmt.Sprintf(KeyRateLimit, identifier, endpoint)
 }
 
+// WSTicketKey generates cache key for a WebSocket connection ticket
+func WSTicketKey(ticket string) string {
+	return fmt.Sprintf(KeyWSTicket, ticket)
+}
+
+// JobLockKey generates the lock key held while a singleton job runs
+func JobLockKey(job string) string {
+	return fmt.Sprintf(KeyJobLock, job)
+}
+
+// JobRunKey generates the key marking a singleton job's run as done
+func JobRunKey(job, run string) string {
+	return fmt.Sprintf(KeyJobRun, job, run)
+}
+
+// UserEventSeqKey generates the key holding a user's last event ID
+func UserEventSeqKey(userID string) string {
+	return fmt.Sprintf(KeyUserEventSeq, userID)
+}
+
+// UserEventLogKey generates the key holding a user's recent events
+func UserEventLogKey(userID string) string {
+	return fmt.Sprintf(KeyUserEventLog, userID)
+}
+
+// UserEventsChannel generates the pub/sub channel for a user's events
+func UserEventsChannel(userID string) string {
+	return fmt.Sprintf(ChannelUserEvents, userID)
+}
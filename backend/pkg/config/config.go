@@ -1,8 +1,8 @@
 package config
 
 import (
-	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -10,40 +10,109 @@ import (
 
 // Config holds all configuration for the application
 type Config struct {
+	// Service is the name passed to LoadForService, empty after Load
+	Service string
+
 	// Server
 	Environment string
 	Debug       bool
+	LogFormat   string
+	LogLevel    string
 
 	// Database
-	DatabaseURL string
+	DatabaseURL       string
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
 
 	// Redis
 	RedisURL string
 
+	// Tracing (OTLP collector URL; tracing is off when empty)
+	OTLPEndpoint string
+
+	// Admin
+	AdminToken string
+
+	// Service API keys ("name:sha256hex")
+	APIKeyHashes []string
+
 	// JWT
+	AuthValidationMode string // "local" (default) or "remote" via the auth service
 	JWTSecret          string
 	JWTAccessDuration  time.Duration
 	JWTRefreshDuration time.Duration
 
+	// Login lockout
+	LoginMaxAttempts        int
+	LoginLockoutDuration    time.Duration
+	LoginLockoutMaxDuration time.Duration
+
 	// Google OAuth
 	GoogleClientID     string
 	GoogleClientSecret string
 	GoogleRedirectURL  string
 
+	// Apple Sign-In
+	AppleClientID    string
+	AppleTeamID      string
+	AppleKeyID       string
+	ApplePrivateKey  string
+	AppleRedirectURL string
+
 	// External APIs
 	AlphaVantageAPIKey  string
 	AlphaVantagePremium bool
 	ExchangeRatesAPIKey string
 	CoinGeckoAPIURL     string
+	CoinGeckoRateLimit  int
+	CoinGeckoIDs        []string
+	PriceMaxStaleness   time.Duration
+	RateStaleAfter      time.Duration
+	RateBaseCurrencies  []string
 
 	// Telegram
-	TelegramBotToken string
-	TelegramWebhook  string
+	TelegramBotToken      string
+	TelegramWebhook       string
+	TelegramWebhookSecret string
+	TelegramDedupWindow   time.Duration
+	TelegramRateLimit     int
+	TelegramRateWindow    time.Duration
+	MiniAppURL            string
+
+	// Telegram WebApp init data older than this is rejected
+	TelegramInitDataMaxAge time.Duration
+
+	// Price alerts
+	PriceAlertInterval time.Duration
+	PriceAlertCooldown time.Duration
+
+	// Transaction export
+	ExportMaxSpan time.Duration
+
+	// Daily net worth snapshots (UTC time of day, "15:04")
+	SnapshotTime   string
+	SnapshotJitter time.Duration
+
+	// SMTP (email notifications)
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+
+	// Request bodies larger than MaxBodyBytes are rejected; upload
+	// endpoints such as transaction import allow up to MaxUploadBytes
+	MaxBodyBytes   int64
+	MaxUploadBytes int64
 
 	// Service Ports
 	GRPCPort string
 	HTTPPort string
 
+	// Shutdown
+	ShutdownGracePeriod time.Duration
+
 	// Service URLs (for inter-service communication)
 	AuthServiceURL         string
 	AccountsServiceURL     string
@@ -51,48 +120,125 @@ type Config struct {
 	AssetsServiceURL       string
 	CurrencyServiceURL     string
 	InsightsServiceURL     string
+
+	// CORS
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSAllowCredentials bool
 }
 
-// Load loads configuration from environment variables
+// Load loads configuration from environment variables, falling back to the
+// config file (CONFIG_FILE, or config.yaml when present) for unset ones
 func Load() (*Config, error) {
 	// Load .env file if it exists
 	_ = godotenv.Load()
 
+	values, err := loadConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	fileValues = values
+
 	cfg := &Config{
 		// Server
 		Environment: getEnv("ENVIRONMENT", "development"),
 		Debug:       getEnvBool("DEBUG", true),
+		LogFormat:   getEnv("LOG_FORMAT", "text"),
+		LogLevel:    getEnv("LOG_LEVEL", "info"),
 
 		// Database
-		DatabaseURL: getEnv("DATABASE_URL", ""),
+		DatabaseURL:       getEnv("DATABASE_URL", ""),
+		DBMaxOpenConns:    getEnvInt("DB_MAX_OPEN_CONNS", 100),
+		DBMaxIdleConns:    getEnvInt("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime: getEnvDuration("DB_CONN_MAX_LIFETIME", time.Hour),
 
 		// Redis
 		RedisURL: getEnv("REDIS_URL", "redis://localhost:6379"),
 
+		// Tracing
+		OTLPEndpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", ""),
+
+		// Admin
+		AdminToken: getEnv("ADMIN_TOKEN", ""),
+
+		// Service API keys
+		APIKeyHashes: getEnvSlice("API_KEY_HASHES", nil),
+
 		// JWT
-		JWTSecret:          getEnv("JWT_SECRET", "your-super-secret-key-change-in-production"),
+		AuthValidationMode: getEnv("AUTH_VALIDATION_MODE", "local"),
+		JWTSecret:          getEnv("JWT_SECRET", defaultJWTSecret),
 		JWTAccessDuration:  getEnvDuration("JWT_ACCESS_DURATION", 15*time.Minute),
 		JWTRefreshDuration: getEnvDuration("JWT_REFRESH_DURATION", 7*24*time.Hour),
 
+		// Login lockout (lockout doubles after each burst of failures)
+		LoginMaxAttempts:        getEnvInt("LOGIN_MAX_ATTEMPTS", 5),
+		LoginLockoutDuration:    getEnvDuration("LOGIN_LOCKOUT_DURATION", time.Minute),
+		LoginLockoutMaxDuration: getEnvDuration("LOGIN_LOCKOUT_MAX_DURATION", time.Hour),
+
 		// Google OAuth
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
 		GoogleRedirectURL:  getEnv("GOOGLE_REDIRECT_URL", "http://localhost:8080/api/v1/auth/google/callback"),
 
+		// Apple Sign-In
+		AppleClientID:    getEnv("APPLE_CLIENT_ID", ""),
+		AppleTeamID:      getEnv("APPLE_TEAM_ID", ""),
+		AppleKeyID:       getEnv("APPLE_KEY_ID", ""),
+		ApplePrivateKey:  getEnv("APPLE_PRIVATE_KEY", ""),
+		AppleRedirectURL: getEnv("APPLE_REDIRECT_URL", "http://localhost:8080/api/v1/auth/apple/callback"),
+
 		// External APIs
 		AlphaVantageAPIKey:  getEnv("ALPHA_VANTAGE_API_KEY", ""),
 		AlphaVantagePremium: getEnvBool("ALPHA_VANTAGE_PREMIUM", false),
 		ExchangeRatesAPIKey: getEnv("EXCHANGERATES_API_KEY", ""),
 		CoinGeckoAPIURL:     getEnv("COINGECKO_API_URL", "https://api.coingecko.com/api/v3"),
+		CoinGeckoRateLimit:  getEnvInt("COINGECKO_REQUESTS_PER_MINUTE", 30),
+		CoinGeckoIDs:        getEnvSlice("COINGECKO_ID_OVERRIDES", nil),
+		PriceMaxStaleness:   getEnvDuration("PRICE_MAX_STALENESS", 24*time.Hour),
+		RateStaleAfter:      getEnvDuration("RATE_STALE_AFTER", 3*time.Hour),
+		RateBaseCurrencies:  getEnvSlice("RATE_BASE_CURRENCIES", []string{"USD", "EUR"}),
 
 		// Telegram
-		TelegramBotToken: getEnv("TELEGRAM_BOT_TOKEN", ""),
-		TelegramWebhook:  getEnv("TELEGRAM_WEBHOOK_URL", ""),
+		TelegramBotToken:      getEnv("TELEGRAM_BOT_TOKEN", ""),
+		TelegramWebhook:       getEnv("TELEGRAM_WEBHOOK_URL", ""),
+		TelegramWebhookSecret: getEnv("TELEGRAM_WEBHOOK_SECRET", ""),
+		TelegramDedupWindow:   getEnvDuration("TELEGRAM_DEDUP_WINDOW", 10*time.Minute),
+		TelegramRateLimit:     getEnvInt("TELEGRAM_RATE_LIMIT", 20),
+		TelegramRateWindow:    getEnvDuration("TELEGRAM_RATE_WINDOW", time.Minute),
+		MiniAppURL:            getEnv("MINI_APP_URL", ""),
+
+		TelegramInitDataMaxAge: getEnvDuration("TELEGRAM_INITDATA_MAX_AGE", 24*time.Hour),
+
+		// Price alerts
+		PriceAlertInterval: getEnvDuration("PRICE_ALERT_INTERVAL", 5*time.Minute),
+		PriceAlertCooldown: getEnvDuration("PRICE_ALERT_COOLDOWN", time.Hour),
+
+		// Transaction export
+		ExportMaxSpan: getEnvDuration("EXPORT_MAX_SPAN", 5*365*24*time.Hour),
+
+		// Daily snapshots
+		SnapshotTime:   getEnv("SNAPSHOT_TIME", "00:05"),
+		SnapshotJitter: getEnvDuration("SNAPSHOT_JITTER", 30*time.Minute),
+
+		// SMTP
+		SMTPHost:     getEnv("SMTP_HOST", ""),
+		SMTPPort:     getEnvInt("SMTP_PORT", 587),
+		SMTPUsername: getEnv("SMTP_USERNAME", ""),
+		SMTPPassword: getEnv("SMTP_PASSWORD", ""),
+		SMTPFrom:     getEnv("SMTP_FROM", ""),
+
+		// Request bodies
+		MaxBodyBytes:   int64(getEnvInt("MAX_BODY_BYTES", 1<<20)),
+		MaxUploadBytes: int64(getEnvInt("MAX_UPLOAD_BYTES", 50<<20)),
 
 		// Service Ports
 		GRPCPort: getEnv("GRPC_PORT", "50051"),
 		HTTPPort: getEnv("HTTP_PORT", "8080"),
 
+		// Shutdown
+		ShutdownGracePeriod: getEnvDuration("SHUTDOWN_GRACE_PERIOD", 10*time.Second),
+
 		// Service URLs
 		AuthServiceURL:         getEnv("AUTH_SERVICE_URL", "localhost:50051"),
 		AccountsServiceURL:     getEnv("ACCOUNTS_SERVICE_URL", "localhost:50052"),
@@ -100,6 +246,12 @@ func Load() (*Config, error) {
 		AssetsServiceURL:       getEnv("ASSETS_SERVICE_URL", "localhost:50054"),
 		CurrencyServiceURL:     getEnv("CURRENCY_SERVICE_URL", "localhost:50055"),
 		InsightsServiceURL:     getEnv("INSIGHTS_SERVICE_URL", "localhost:50056"),
+
+		// CORS (empty lists keep the permissive defaults)
+		CORSAllowedOrigins:   getEnvSlice("CORS_ALLOWED_ORIGINS", nil),
+		CORSAllowedMethods:   getEnvSlice("CORS_ALLOWED_METHODS", nil),
+		CORSAllowedHeaders:   getEnvSlice("CORS_ALLOWED_HEADERS", nil),
+		CORSAllowCredentials: getEnvBool("CORS_ALLOW_CREDENTIALS", true),
 	}
 
 	return cfg, nil
@@ -113,6 +265,7 @@ func LoadForService(serviceName string) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	cfg.Service = serviceName
 
 	// Override database URL with service-specific one
 	dbURLKey := serviceName + "_DB_URL"
@@ -135,14 +288,14 @@ func LoadForService(serviceName string) (*Config, error) {
 }
 
 func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
 
 func getEnvBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if b, err := strconv.ParseBool(value); err == nil {
 			return b
 		}
@@ -151,7 +304,7 @@ func getEnvBool(key string, defaultValue bool) bool {
 }
 
 func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if i, err := strconv.Atoi(value); err == nil {
 			return i
 		}
@@ -159,8 +312,23 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+func getEnvSlice(key string, defaultValue []string) []string {
+	value := lookup(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
 func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
+	if value := lookup(key); value != "" {
 		if d, err := time.ParseDuration(value); err == nil {
 			return d
 		}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// testConfigYAML sets every value the tests read from the file
+const testConfigYAML = `
+log:
+  level: debug
+database:
+  url: postgres://file/shared
+grpc:
+  port: "6000"
+transactions:
+  db:
+    url: postgres://file/transactions
+  grpc:
+    port: "6003"
+cors:
+  allowed:
+    origins:
+      - https://a.example
+      - https://b.example
+`
+
+// inConfigDir runs the test in an empty directory holding a config.yaml with
+// contents, with the keys the tests use unset in the environment
+func inConfigDir(t *testing.T, contents string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	t.Chdir(dir)
+	if contents != "" {
+		if err := os.WriteFile(filepath.Join(dir, defaultConfigFile), []byte(contents), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// An empty variable counts as unset, so t.Setenv restores the real
+	// environment afterwards without it leaking into the test
+	for _, key := range []string{
+		"CONFIG_FILE", "LOG_LEVEL", "DATABASE_URL", "GRPC_PORT",
+		"TRANSACTIONS_DB_URL", "TRANSACTIONS_GRPC_PORT", "CORS_ALLOWED_ORIGINS",
+	} {
+		t.Setenv(key, "")
+	}
+	t.Cleanup(func() { fileValues = nil })
+	return dir
+}
+
+func TestLoadUsesFileWhenEnvUnset(t *testing.T) {
+	inConfigDir(t, testConfigYAML)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.LogLevel != "debug" {
+		t.Errorf("LogLevel = %q, want the file's debug", cfg.LogLevel)
+	}
+	if cfg.DatabaseURL != "postgres://file/shared" {
+		t.Errorf("DatabaseURL = %q, want the file's URL", cfg.DatabaseURL)
+	}
+	if cfg.GRPCPort != "6000" {
+		t.Errorf("GRPCPort = %q, want the file's 6000", cfg.GRPCPort)
+	}
+	wantOrigins := []string{"https://a.example", "https://b.example"}
+	if !reflect.DeepEqual(cfg.CORSAllowedOrigins, wantOrigins) {
+		t.Errorf("CORSAllowedOrigins = %v, want %v", cfg.CORSAllowedOrigins, wantOrigins)
+	}
+	// Keys missing from both fall back to the defaults
+	if cfg.HTTPPort != "8080" {
+		t.Errorf("HTTPPort = %q, want the default 8080", cfg.HTTPPort)
+	}
+}
+
+func TestLoadEnvOverridesFile(t *testing.T) {
+	inConfigDir(t, testConfigYAML)
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("DATABASE_URL", "postgres://env/shared")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.LogLevel != "warn" {
+		t.Errorf("LogLevel = %q, want the environment's warn", cfg.LogLevel)
+	}
+	if cfg.DatabaseURL != "postgres://env/shared" {
+		t.Errorf("DatabaseURL = %q, want the environment's URL", cfg.DatabaseURL)
+	}
+	// Keys set only in the file still come from it
+	if cfg.GRPCPort != "6000" {
+		t.Errorf("GRPCPort = %q, want the file's 6000", cfg.GRPCPort)
+	}
+}
+
+func TestLoadForServiceUsesFileWhenEnvUnset(t *testing.T) {
+	inConfigDir(t, testConfigYAML)
+
+	cfg, err := LoadForService(ServiceTransactions)
+	if err != nil {
+		t.Fatalf("LoadForService: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://file/transactions" {
+		t.Errorf("DatabaseURL = %q, want the file's TRANSACTIONS_DB_URL", cfg.DatabaseURL)
+	}
+	if cfg.GRPCPort != "6003" {
+		t.Errorf("GRPCPort = %q, want the file's TRANSACTIONS_GRPC_PORT", cfg.GRPCPort)
+	}
+}
+
+func TestLoadForServiceEnvOverridesFile(t *testing.T) {
+	inConfigDir(t, testConfigYAML)
+	t.Setenv("TRANSACTIONS_DB_URL", "postgres://env/transactions")
+	t.Setenv("TRANSACTIONS_GRPC_PORT", "7003")
+
+	cfg, err := LoadForService(ServiceTransactions)
+	if err != nil {
+		t.Fatalf("LoadForService: %v", err)
+	}
+
+	if cfg.DatabaseURL != "postgres://env/transactions" {
+		t.Errorf("DatabaseURL = %q, want the environment's TRANSACTIONS_DB_URL", cfg.DatabaseURL)
+	}
+	if cfg.GRPCPort != "7003" {
+		t.Errorf("GRPCPort = %q, want the environment's TRANSACTIONS_GRPC_PORT", cfg.GRPCPort)
+	}
+}
+
+func TestLoadForServicePrefixedFileBeatsSharedEnv(t *testing.T) {
+	inConfigDir(t, testConfigYAML)
+	// The service's own key wins over the shared one wherever each is set
+	t.Setenv("DATABASE_URL", "postgres://env/shared")
+
+	cfg, err := LoadForService(ServiceTransactions)
+	if err != nil {
+		t.Fatalf("LoadForService: %v", err)
+	}
+	if cfg.DatabaseURL != "postgres://file/transactions" {
+		t.Errorf("DatabaseURL = %q, want the file's TRANSACTIONS_DB_URL", cfg.DatabaseURL)
+	}
+}
+
+func TestLoadEnvFormatConfigFile(t *testing.T) {
+	dir := inConfigDir(t, "")
+	path := filepath.Join(dir, "settings.env")
+	if err := os.WriteFile(path, []byte("LOG_LEVEL=error\nTRANSACTIONS_GRPC_PORT=6103\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CONFIG_FILE", path)
+
+	cfg, err := LoadForService(ServiceTransactions)
+	if err != nil {
+		t.Fatalf("LoadForService: %v", err)
+	}
+	if cfg.LogLevel != "error" {
+		t.Errorf("LogLevel = %q, want the file's error", cfg.LogLevel)
+	}
+	if cfg.GRPCPort != "6103" {
+		t.Errorf("GRPCPort = %q, want the file's TRANSACTIONS_GRPC_PORT", cfg.GRPCPort)
+	}
+}
+
+func TestLoadMissingConfigFile(t *testing.T) {
+	dir := inConfigDir(t, "")
+
+	// No config.yaml is fine
+	if _, err := Load(); err != nil {
+		t.Fatalf("Load without config.yaml: %v", err)
+	}
+
+	// A CONFIG_FILE that doesn't exist is not
+	t.Setenv("CONFIG_FILE", filepath.Join(dir, "missing.yaml"))
+	if _, err := Load(); err == nil {
+		t.Fatal("Load with a missing CONFIG_FILE succeeded, want an error")
+	}
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is read when it exists and CONFIG_FILE isn't set
+const defaultConfigFile = "config.yaml"
+
+// fileValues holds the settings read from the config file. Environment
+// variables take precedence over them.
+var fileValues map[string]string
+
+// loadConfigFile reads the file named by CONFIG_FILE, or config.yaml when
+// present. A missing default file is not an error; a missing CONFIG_FILE is.
+func loadConfigFile() (map[string]string, error) {
+	path := os.Getenv("CONFIG_FILE")
+	explicit := path != ""
+	if !explicit {
+		path = defaultConfigFile
+	}
+
+	values, err := readConfigFile(path)
+	if err != nil {
+		if !explicit && errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// readConfigFile reads a YAML file, or a .env file for any other extension.
+// Keys are the environment variable names, so the file and the environment
+// are interchangeable.
+func readConfigFile(path string) (map[string]string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		var doc map[string]interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		values := make(map[string]string)
+		flattenYAML("", doc, values)
+		return values, nil
+	default:
+		return godotenv.Read(path)
+	}
+}
+
+// flattenYAML turns nested keys into environment variable names, so
+// `jwt: {secret: x}` sets JWT_SECRET. Lists become comma-separated values.
+func flattenYAML(prefix string, node map[string]interface{}, values map[string]string) {
+	for key, value := range node {
+		name := strings.ToUpper(key)
+		if prefix != "" {
+			name = prefix + "_" + name
+		}
+
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flattenYAML(name, v, values)
+		case []interface{}:
+			items := make([]string, 0, len(v))
+			for _, item := range v {
+				items = append(items, fmt.Sprint(item))
+			}
+			values[name] = strings.Join(items, ",")
+		case nil:
+			values[name] = ""
+		default:
+			values[name] = fmt.Sprint(v)
+		}
+	}
+}
+
+// lookup returns the environment variable, falling back to the config file
+func lookup(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fileValues[key]
+}
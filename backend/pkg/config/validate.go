@@ -0,0 +1,163 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultJWTSecret is the development fallback for JWT_SECRET
+const defaultJWTSecret = "your-super-secret-key-change-in-production"
+
+// Services whose settings Validate knows, as passed to LoadForService
+const (
+	ServiceAuth         = "AUTH"
+	ServiceAccounts     = "ACCOUNTS"
+	ServiceTransactions = "TRANSACTIONS"
+	ServiceAssets       = "ASSETS"
+	ServiceCurrency     = "CURRENCY"
+	ServiceInsights     = "INSIGHTS"
+	ServiceGateway      = "GATEWAY"
+	ServiceTelegram     = "TELEGRAM"
+)
+
+// Validate checks the settings the service needs to start, reporting every
+// problem at once rather than the first. Services call it right after
+// loading so a misconfigured deploy fails before it serves anything.
+func (c *Config) Validate() error {
+	var problems []error
+	problem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Errorf(format, args...))
+	}
+
+	usesDatabase := false
+	usesJWT := true
+	switch c.Service {
+	case ServiceAuth, ServiceAccounts, ServiceTransactions, ServiceAssets, ServiceCurrency, ServiceInsights:
+		usesDatabase = true
+	case ServiceTelegram:
+		usesJWT = false
+	}
+
+	if usesDatabase {
+		if err := validateDatabaseURL(c.DatabaseURL); err != nil {
+			problem("DATABASE_URL %v", err)
+		}
+		if c.DBMaxOpenConns <= 0 {
+			problem("DB_MAX_OPEN_CONNS must be positive")
+		}
+		if c.DBMaxIdleConns < 0 || c.DBMaxIdleConns > c.DBMaxOpenConns {
+			problem("DB_MAX_IDLE_CONNS must be between 0 and DB_MAX_OPEN_CONNS")
+		}
+	}
+
+	if usesJWT {
+		if !c.Debug && (c.JWTSecret == "" || c.JWTSecret == defaultJWTSecret) {
+			problem("JWT_SECRET must be set to a non-default value outside debug mode")
+		}
+		if c.AuthValidationMode != "local" && c.AuthValidationMode != "remote" {
+			problem("AUTH_VALIDATION_MODE must be local or remote, got %q", c.AuthValidationMode)
+		}
+	}
+
+	if c.Service == ServiceTelegram && c.TelegramBotToken == "" {
+		problem("TELEGRAM_BOT_TOKEN is required")
+	}
+
+	if c.Service == ServiceInsights {
+		if _, err := time.Parse("15:04", c.SnapshotTime); err != nil {
+			problem("SNAPSHOT_TIME must be a time of day like 00:05, got %q", c.SnapshotTime)
+		}
+	}
+
+	if !validPort(c.GRPCPort) {
+		problem("GRPC_PORT must be a port number, got %q", c.GRPCPort)
+	}
+	if !validPort(c.HTTPPort) {
+		problem("HTTP_PORT must be a port number, got %q", c.HTTPPort)
+	}
+	if c.SMTPHost != "" && (c.SMTPPort <= 0 || c.SMTPPort > 65535) {
+		problem("SMTP_PORT must be a port number, got %d", c.SMTPPort)
+	}
+	if c.SMTPHost != "" && c.SMTPFrom == "" {
+		problem("SMTP_FROM is required when SMTP_HOST is set")
+	}
+
+	if c.MaxBodyBytes <= 0 {
+		problem("MAX_BODY_BYTES must be positive, got %d", c.MaxBodyBytes)
+	}
+	if c.MaxUploadBytes < c.MaxBodyBytes {
+		problem("MAX_UPLOAD_BYTES must be at least MAX_BODY_BYTES")
+	}
+
+	durations := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"DB_CONN_MAX_LIFETIME", c.DBConnMaxLifetime},
+		{"JWT_ACCESS_DURATION", c.JWTAccessDuration},
+		{"JWT_REFRESH_DURATION", c.JWTRefreshDuration},
+		{"LOGIN_LOCKOUT_DURATION", c.LoginLockoutDuration},
+		{"LOGIN_LOCKOUT_MAX_DURATION", c.LoginLockoutMaxDuration},
+		{"PRICE_MAX_STALENESS", c.PriceMaxStaleness},
+		{"RATE_STALE_AFTER", c.RateStaleAfter},
+		{"TELEGRAM_DEDUP_WINDOW", c.TelegramDedupWindow},
+		{"TELEGRAM_RATE_WINDOW", c.TelegramRateWindow},
+		{"TELEGRAM_INITDATA_MAX_AGE", c.TelegramInitDataMaxAge},
+		{"PRICE_ALERT_INTERVAL", c.PriceAlertInterval},
+		{"PRICE_ALERT_COOLDOWN", c.PriceAlertCooldown},
+		{"EXPORT_MAX_SPAN", c.ExportMaxSpan},
+		{"SHUTDOWN_GRACE_PERIOD", c.ShutdownGracePeriod},
+	}
+	for _, d := range durations {
+		if d.value <= 0 {
+			problem("%s must be positive, got %s", d.name, d.value)
+		}
+	}
+	if c.SnapshotJitter < 0 {
+		problem("SNAPSHOT_JITTER must not be negative, got %s", c.SnapshotJitter)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	service := c.Service
+	if service == "" {
+		service = "service"
+	}
+	return fmt.Errorf("invalid %s configuration:\n%w", strings.ToLower(service), errors.Join(problems...))
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n <= 65535
+}
+
+// validateDatabaseURL accepts a postgres:// URL or a key=value DSN
+func validateDatabaseURL(dsn string) error {
+	if dsn == "" {
+		return errors.New("is required")
+	}
+	if !strings.Contains(dsn, "://") {
+		if !strings.Contains(dsn, "=") {
+			return errors.New("must be a postgres:// URL or a key=value DSN")
+		}
+		return nil
+	}
+
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return errors.New("is not a valid URL")
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return fmt.Errorf("must use the postgres scheme, got %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("has no host")
+	}
+	return nil
+}
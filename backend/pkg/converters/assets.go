@@ -14,6 +14,7 @@ var assetTypeAssetsMap = map[string]assetspb.AssetType{
 	"real_estate": assetspb.AssetType_ASSET_TYPE_REAL_ESTATE,
 	"cash":        assetspb.AssetType_ASSET_TYPE_CASH,
 	"bond":        assetspb.AssetType_ASSET_TYPE_BOND,
+	"commodity":   assetspb.AssetType_ASSET_TYPE_COMMODITY,
 	"other":       assetspb.AssetType_ASSET_TYPE_OTHER,
 }
 
@@ -24,3 +25,8 @@ func StringToAssetTypeAssets(s string) assetspb.AssetType {
 	return assetspb.AssetType_ASSET_TYPE_OTHER
 }
 
+// LookupAssetTypeAssets returns the proto asset type and whether the string is a known type
+func LookupAssetTypeAssets(s string) (assetspb.AssetType, bool) {
+	t, ok := assetTypeAssetsMap[strings.ToLower(s)]
+	return t, ok
+}
@@ -2,15 +2,22 @@ package database
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 	"gorm.io/gorm/logger"
 )
 
+// ErrConflict is returned when a row changed after it was loaded
+var ErrConflict = errors.New("record was modified by another request")
+
 // Database wraps gorm.DB with additional functionality
 type Database struct {
 	*gorm.DB
@@ -87,32 +94,90 @@ func (d *Database) Close() error {
 	return sqlDB.Close()
 }
 
+// Ping checks the database connection is alive
+func (d *Database) Ping(ctx context.Context) error {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.PingContext(ctx)
+}
+
+// Stats returns connection pool statistics
+func (d *Database) Stats() sql.DBStats {
+	sqlDB, err := d.DB.DB()
+	if err != nil {
+		return sql.DBStats{}
+	}
+	return sqlDB.Stats()
+}
+
 // Migrate runs auto-migration for the given models
 func (d *Database) Migrate(models ...interface{}) error {
 	return d.DB.AutoMigrate(models...)
 }
 
+// IsUniqueViolation reports whether err is a unique constraint violation, so
+// repositories can report a duplicate instead of a database error
+func IsUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 // WithTransaction executes a function within a transaction
 func (d *Database) WithTransaction(fn func(tx *gorm.DB) error) error {
 	return d.DB.Transaction(fn)
 }
 
+// Page is one page of a list. Offset pages report the total; cursor pages
+// skip the count and leave it zero. NextCursor is empty on the last page.
+type Page[T any] struct {
+	Items      []T
+	Total      int64
+	NextCursor string
+}
+
+// PageBounds normalizes page and pageSize and returns the offset and limit
+// they select
+func PageBounds(page, pageSize int) (offset, limit int) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize > 100 {
+		pageSize = 100
+	}
+	return (page - 1) * pageSize, pageSize
+}
+
 // Paginate returns a scoped query for pagination
 func Paginate(page, pageSize int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		if page <= 0 {
-			page = 1
-		}
-		if pageSize <= 0 {
-			pageSize = 10
-		}
-		if pageSize > 100 {
-			pageSize = 100
-		}
-
-		offset := (page - 1) * pageSize
-		return db.Offset(offset).Limit(pageSize)
+		offset, limit := PageBounds(page, pageSize)
+		return db.Offset(offset).Limit(limit)
+	}
+}
+
+// SaveVersioned writes every column of value, a model with a primary key,
+// only if its row is still at *version, and bumps *version. It returns
+// ErrConflict when another write got there first; the caller should reload
+// and reapply its change.
+func SaveVersioned(db *gorm.DB, value interface{}, version *int64) error {
+	loaded := *version
+	*version = loaded + 1
+
+	result := db.Model(value).Omit(clause.Associations).Where("version = ?", loaded).Select("*").Updates(value)
+	if result.Error != nil {
+		*version = loaded
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		*version = loaded
+		return ErrConflict
 	}
+	return nil
 }
 
 // BaseModel contains common columns for all models
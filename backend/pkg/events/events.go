@@ -0,0 +1,197 @@
+// Package events publishes per-user domain events over Redis pub/sub, so
+// services can tell live consumers such as the gateway what changed.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/cache"
+)
+
+// Event is the envelope every event is published in. Type tells consumers
+// how to decode Data; new fields may be added to payloads, but existing ones
+// keep their meaning.
+type Event struct {
+	ID     int64           `json:"id"`
+	Type   string          `json:"type"`
+	Source string          `json:"source,omitempty"`
+	Data   json.RawMessage `json:"data"`
+	At     time.Time       `json:"at"`
+}
+
+// Event types
+const (
+	TransactionCreated = "transaction.created"
+	TransactionUpdated = "transaction.updated"
+	TransactionDeleted = "transaction.deleted"
+
+	AccountCreated = "account.created"
+	AccountUpdated = "account.updated"
+	AccountDeleted = "account.deleted"
+
+	SubAccountCreated = "sub_account.created"
+	SubAccountUpdated = "sub_account.updated"
+	SubAccountDeleted = "sub_account.deleted"
+	BalanceUpdated    = "balance.updated"
+
+	AssetCreated    = "asset.created"
+	AssetUpdated    = "asset.updated"
+	AssetDeleted    = "asset.deleted"
+	PricesRefreshed = "prices.refreshed"
+
+	// Produced by the gateway for its own connections, never published
+	NetWorthUpdated = "net_worth.updated"
+	Resync          = "resync"
+)
+
+// TransactionData is the payload of transaction.created and transaction.updated
+type TransactionData struct {
+	ID           string    `json:"id"`
+	SubAccountID string    `json:"sub_account_id,omitempty"`
+	Amount       float64   `json:"amount"`
+	Currency     string    `json:"currency"`
+	Type         string    `json:"type"`
+	Category     string    `json:"category"`
+	Date         time.Time `json:"date"`
+}
+
+// AccountData is the payload of account.created and account.updated
+type AccountData struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Currency string  `json:"currency"`
+	Balance  float64 `json:"balance"`
+	Archived bool    `json:"archived"`
+}
+
+// SubAccountData is the payload of sub_account.created and sub_account.updated
+type SubAccountData struct {
+	ID        string  `json:"id"`
+	AccountID string  `json:"account_id"`
+	Name      string  `json:"name"`
+	Currency  string  `json:"currency"`
+	Balance   float64 `json:"balance"`
+}
+
+// BalanceData is the payload of balance.updated
+type BalanceData struct {
+	AccountID    string  `json:"account_id"`
+	SubAccountID string  `json:"sub_account_id"`
+	Balance      float64 `json:"balance"`
+	Currency     string  `json:"currency"`
+}
+
+// AssetData is the payload of asset.created and asset.updated
+type AssetData struct {
+	ID           string  `json:"id"`
+	Symbol       string  `json:"symbol"`
+	Type         string  `json:"type"`
+	Quantity     float64 `json:"quantity"`
+	CurrentPrice float64 `json:"current_price"`
+	Currency     string  `json:"currency"`
+}
+
+// PricesData is the payload of prices.refreshed
+type PricesData struct {
+	Prices         map[string]float64 `json:"prices"`
+	PortfolioValue float64            `json:"portfolio_value"`
+}
+
+// NetWorthData is the payload of net_worth.updated
+type NetWorthData struct {
+	TotalNetWorth float64 `json:"total_net_worth"`
+	Currency      string  `json:"currency"`
+	Change        float64 `json:"change"`
+}
+
+// DeletedData is the payload of every *.deleted event
+type DeletedData struct {
+	ID string `json:"id"`
+}
+
+// publishTimeout bounds how long a write waits on Redis to announce itself
+const publishTimeout = 2 * time.Second
+
+// Publisher publishes events on per-user channels
+type Publisher struct {
+	cache  *cache.Cache
+	source string
+}
+
+// NewPublisher creates a publisher for the named service. A nil cache gives
+// a publisher that drops events, for services running without Redis.
+func NewPublisher(c *cache.Cache, source string) *Publisher {
+	return &Publisher{cache: c, source: source}
+}
+
+// Publish publishes an event for the user. Events describe writes that have
+// already succeeded, so failures are logged rather than returned.
+func (p *Publisher) Publish(ctx context.Context, userID, eventType string, data interface{}) {
+	if p == nil || p.cache == nil {
+		return
+	}
+
+	// Don't lose the event because the request finished first
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), publishTimeout)
+	defer cancel()
+
+	if err := p.publish(ctx, userID, eventType, data); err != nil {
+		log.Printf("Failed to publish %s event: %v", eventType, err)
+	}
+}
+
+func (p *Publisher) publish(ctx context.Context, userID, eventType string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	id, err := p.cache.NextUserEventID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	event, err := json.Marshal(Event{ID: id, Type: eventType, Source: p.source, Data: payload, At: time.Now()})
+	if err != nil {
+		return err
+	}
+	return p.cache.AppendUserEvent(ctx, userID, event)
+}
+
+// Since returns the user's logged events after lastID, oldest first. complete
+// is false when the log no longer reaches back to lastID and events were missed.
+func Since(ctx context.Context, c *cache.Cache, userID string, lastID int64) (events []Event, complete bool, err error) {
+	raw, err := c.UserEventLog(ctx, userID)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(raw) == 0 {
+		return nil, true, nil
+	}
+
+	var newest int64
+	complete = lastID == 0
+	for i := len(raw) - 1; i >= 0; i-- {
+		var event Event
+		if err := json.Unmarshal([]byte(raw[i]), &event); err != nil {
+			continue
+		}
+		if event.ID <= lastID+1 {
+			complete = true
+		}
+		if event.ID > lastID {
+			events = append(events, event)
+		}
+		newest = event.ID
+	}
+
+	// An ID from the future can't be resumed from either
+	if newest < lastID {
+		complete = false
+	}
+	return events, complete, nil
+}
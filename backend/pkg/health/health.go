@@ -2,7 +2,9 @@ package health
 
 import (
 	"context"
+	"database/sql"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
@@ -121,6 +123,26 @@ func DatabaseCheck(pingFn func(ctx context.Context) error) Checker {
 	}
 }
 
+// DatabasePoolCheck creates a database health checker that also reports
+// connection pool usage
+func DatabasePoolCheck(pingFn func(ctx context.Context) error, statsFn func() sql.DBStats) Checker {
+	ping := DatabaseCheck(pingFn)
+	return func(ctx context.Context) Check {
+		check := ping(ctx)
+
+		stats := statsFn()
+		check.Details = map[string]interface{}{
+			"max_open":      stats.MaxOpenConnections,
+			"open":          stats.OpenConnections,
+			"in_use":        stats.InUse,
+			"idle":          stats.Idle,
+			"wait_count":    stats.WaitCount,
+			"wait_duration": stats.WaitDuration.String(),
+		}
+		return check
+	}
+}
+
 // RedisCheck creates a Redis health checker
 func RedisCheck(pingFn func(ctx context.Context) error) Checker {
 	return func(ctx context.Context) Check {
@@ -249,3 +271,38 @@ func (s *GRPCHealthServer) Register(srv *grpc.Server) {
 	grpc_health_v1.RegisterHealthServer(srv, s)
 }
 
+
+// GRPCServer serves the standard grpc_health_v1 service from a HealthChecker,
+// so gRPC probes see the same dependency checks as the HTTP health endpoints
+type GRPCServer struct {
+	grpc_health_v1.UnimplementedHealthServer
+	checker      *HealthChecker
+	shuttingDown atomic.Bool
+}
+
+// RegisterGRPCServer registers a gRPC health service backed by checker
+func RegisterGRPCServer(registrar grpc.ServiceRegistrar, checker *HealthChecker) *GRPCServer {
+	server := &GRPCServer{checker: checker}
+	grpc_health_v1.RegisterHealthServer(registrar, server)
+	return server
+}
+
+// Check reports SERVING while every registered check is up. The service name
+// is ignored; each process serves a single service.
+func (s *GRPCServer) Check(ctx context.Context, req *grpc_health_v1.HealthCheckRequest) (*grpc_health_v1.HealthCheckResponse, error) {
+	if s.shuttingDown.Load() {
+		return &grpc_health_v1.HealthCheckResponse{Status: grpc_health_v1.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
+	status := grpc_health_v1.HealthCheckResponse_SERVING
+	if s.checker.Check(ctx).Status != StatusUp {
+		status = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+	}
+	return &grpc_health_v1.HealthCheckResponse{Status: status}, nil
+}
+
+// Shutdown makes Check report NOT_SERVING from now on. Call it before
+// stopping the gRPC server so clients move to other replicas first.
+func (s *GRPCServer) Shutdown() {
+	s.shuttingDown.Store(true)
+}
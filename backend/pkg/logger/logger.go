@@ -0,0 +1,111 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/radmickey/money-control/backend/pkg/config"
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+)
+
+// Log formats
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Logger is a leveled structured logger. Records logged with a context,
+// e.g. InfoContext, carry the request and trace IDs found in it.
+type Logger struct {
+	*slog.Logger
+}
+
+// Config holds logger configuration
+type Config struct {
+	Service string // added to every record as "service" when set
+	Format  string // "text" (default) or "json"
+	Level   string // "debug", "info" (default), "warn" or "error"
+	Output  io.Writer
+}
+
+// NewConfig builds a logger configuration for a service from application config
+func NewConfig(cfg *config.Config, service string) Config {
+	return Config{
+		Service: service,
+		Format:  cfg.LogFormat,
+		Level:   cfg.LogLevel,
+	}
+}
+
+// New creates a logger writing to cfg.Output, or stdout if unset
+func New(cfg Config) *Logger {
+	out := cfg.Output
+	if out == nil {
+		out = os.Stdout
+	}
+
+	opts := &slog.HandlerOptions{Level: ParseLevel(cfg.Level)}
+	var handler slog.Handler
+	if cfg.Format == FormatJSON {
+		handler = slog.NewJSONHandler(out, opts)
+	} else {
+		handler = slog.NewTextHandler(out, opts)
+	}
+
+	l := slog.New(contextHandler{handler})
+	if cfg.Service != "" {
+		l = l.With(slog.String("service", cfg.Service))
+	}
+	return &Logger{Logger: l}
+}
+
+// Discard returns a logger that drops everything, for callers that don't
+// care about logs
+func Discard() *Logger {
+	return &Logger{Logger: slog.New(slog.NewTextHandler(io.Discard, nil))}
+}
+
+// With returns a logger that adds args to every record
+func (l *Logger) With(args ...any) *Logger {
+	return &Logger{Logger: l.Logger.With(args...)}
+}
+
+// ParseLevel converts a level name to a slog level, defaulting to info
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// contextHandler adds the request and trace IDs from the record's context
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h contextHandler) Handle(ctx context.Context, r slog.Record) error {
+	if requestID := middleware.RequestIDFromContext(ctx); requestID != "" {
+		r.AddAttrs(slog.String("request_id", requestID))
+	}
+	if traceID := middleware.TraceIDFromContext(ctx); traceID != "" {
+		r.AddAttrs(slog.String("trace_id", traceID))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -2,12 +2,15 @@ package middleware
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
+	"log"
 	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/pkg/utils"
 )
 
 const (
@@ -19,26 +22,36 @@ const (
 	UserIDKey = "userID"
 	// UserEmailKey is the context key for user email
 	UserEmailKey = "userEmail"
+	// AdminTokenHeader is the header key for the admin token
+	AdminTokenHeader = "X-Admin-Token"
 )
 
-// AuthMiddleware creates a JWT authentication middleware
-func AuthMiddleware(jwtManager *auth.JWTManager) gin.HandlerFunc {
+// AuthMiddleware creates an authentication middleware that verifies bearer tokens with the given validator
+func AuthMiddleware(validator TokenValidator) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token, err := extractToken(c)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": err.Error(),
-			})
+			utils.AbortWithError(c, http.StatusUnauthorized, utils.CodeUnauthorized, err.Error())
 			return
 		}
 
-		claims, err := jwtManager.ValidateAccessToken(token)
+		claims, err := validator.ValidateToken(c.Request.Context(), token)
 		if err != nil {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
-				"error":   "Unauthorized",
-				"message": "Invalid or expired token",
-			})
+			switch {
+			case errors.Is(err, auth.ErrTokenRevoked):
+				utils.AbortWithError(c, http.StatusUnauthorized, utils.CodeUnauthorized, "Token has been revoked")
+			case errors.Is(err, ErrInvalidAccessToken):
+				utils.AbortWithError(c, http.StatusUnauthorized, utils.CodeUnauthorized, "Invalid or expired token")
+			default:
+				log.Printf("Token validation failed: %v", err)
+				utils.AbortWithError(c, http.StatusServiceUnavailable, utils.CodeUnavailable, "Authentication service unavailable")
+			}
+			return
+		}
+
+		// A token without a subject can't identify the caller
+		if claims.UserID == "" {
+			utils.AbortWithError(c, http.StatusUnauthorized, utils.CodeUnauthorized, "Invalid or expired token")
 			return
 		}
 
@@ -60,7 +73,7 @@ func OptionalAuthMiddleware(jwtManager *auth.JWTManager) gin.HandlerFunc {
 		}
 
 		claims, err := jwtManager.ValidateAccessToken(token)
-		if err != nil {
+		if err != nil || claims.UserID == "" {
 			c.Next()
 			return
 		}
@@ -72,6 +85,20 @@ func OptionalAuthMiddleware(jwtManager *auth.JWTManager) gin.HandlerFunc {
 	}
 }
 
+// AdminTokenMiddleware restricts access to requests carrying the configured admin token.
+// All requests are rejected when no token is configured.
+func AdminTokenMiddleware(adminToken string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader(AdminTokenHeader)
+		if adminToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+			utils.AbortWithError(c, http.StatusForbidden, utils.CodeForbidden, "admin access required")
+			return
+		}
+
+		c.Next()
+	}
+}
+
 func extractToken(c *gin.Context) (string, error) {
 	header := c.GetHeader(AuthorizationHeader)
 	if header == "" {
@@ -90,14 +117,15 @@ func extractToken(c *gin.Context) (string, error) {
 	return token, nil
 }
 
-// GetUserID extracts user ID from Gin context
+// GetUserID extracts user ID from Gin context. Handlers should respond with
+// 401 when it reports false rather than assume the middleware ran.
 func GetUserID(c *gin.Context) (string, bool) {
 	userID, exists := c.Get(UserIDKey)
 	if !exists {
 		return "", false
 	}
 	id, ok := userID.(string)
-	return id, ok
+	return id, ok && id != ""
 }
 
 // GetUserEmail extracts user email from Gin context
@@ -110,7 +138,8 @@ func GetUserEmail(c *gin.Context) (string, bool) {
 	return e, ok
 }
 
-// MustGetUserID extracts user ID from Gin context or panics
+// MustGetUserID extracts user ID from Gin context or panics. Reserve it for
+// code that only ever runs behind AuthMiddleware; handlers use GetUserID.
 func MustGetUserID(c *gin.Context) string {
 	userID, ok := GetUserID(c)
 	if !ok {
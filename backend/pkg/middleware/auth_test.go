@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+const testJWTSecret = "test-secret"
+
+// newAuthRouter serves GET /me behind AuthMiddleware. The handler relies on
+// MustGetUserID, so a request the middleware wrongly lets through panics
+// and shows up as a 500.
+func newAuthRouter(validator TokenValidator) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(AuthMiddleware(validator))
+	router.GET("/me", func(c *gin.Context) {
+		c.String(http.StatusOK, MustGetUserID(c))
+	})
+	return router
+}
+
+func serveAuth(t *testing.T, router *gin.Engine, authorization string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/me", nil)
+	if authorization != "" {
+		req.Header.Set(AuthorizationHeader, authorization)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func errorCode(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var resp utils.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+	if resp.Error == nil {
+		t.Fatalf("no error in %q", w.Body.String())
+	}
+	return resp.Error.Code
+}
+
+func TestAuthMiddlewareRejectsBadTokens(t *testing.T) {
+	jwtManager := auth.NewJWTManager(testJWTSecret, time.Hour, 24*time.Hour)
+	router := newAuthRouter(NewLocalTokenValidator(jwtManager, nil))
+
+	expired, _, err := jwtManager.GenerateAccessTokenWithDuration("user-1", "a@example.com", 0, -time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherSecret, _, err := auth.NewJWTManager("other-secret", time.Hour, time.Hour).GenerateAccessToken("user-1", "a@example.com", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	noSubject, _, err := jwtManager.GenerateAccessToken("", "a@example.com", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		authorization string
+	}{
+		{"no token", ""},
+		{"not a bearer token", "Basic dXNlcjpwYXNz"},
+		{"empty bearer token", "Bearer "},
+		{"malformed token", "Bearer not.a.jwt"},
+		{"wrong signature", "Bearer " + otherSecret},
+		{"expired token", "Bearer " + expired},
+		{"token without subject", "Bearer " + noSubject},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := serveAuth(t, router, tt.authorization)
+			if w.Code != http.StatusUnauthorized {
+				t.Fatalf("status = %d, want 401; body %q", w.Code, w.Body.String())
+			}
+			if code := errorCode(t, w); code != utils.CodeUnauthorized {
+				t.Fatalf("code = %q, want %q", code, utils.CodeUnauthorized)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareAcceptsValidToken(t *testing.T) {
+	jwtManager := auth.NewJWTManager(testJWTSecret, time.Hour, 24*time.Hour)
+	router := newAuthRouter(NewLocalTokenValidator(jwtManager, nil))
+
+	token, _, err := jwtManager.GenerateAccessToken("user-1", "a@example.com", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := serveAuth(t, router, "Bearer "+token)
+	if w.Code != http.StatusOK || w.Body.String() != "user-1" {
+		t.Fatalf("got %d %q, want 200 user-1", w.Code, w.Body.String())
+	}
+}
+
+// failingValidator can't reach whatever verifies tokens
+type failingValidator struct{}
+
+func (failingValidator) ValidateToken(ctx context.Context, token string) (*auth.AccessClaims, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestAuthMiddlewareValidatorUnavailable(t *testing.T) {
+	w := serveAuth(t, newAuthRouter(failingValidator{}), "Bearer token")
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("status = %d, want 503", w.Code)
+	}
+}
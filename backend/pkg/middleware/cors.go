@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/config"
 )
 
 // CORSConfig holds CORS configuration
@@ -49,6 +51,23 @@ func DefaultCORSConfig() CORSConfig {
 	}
 }
 
+// NewCORSConfig builds a CORS configuration from application config,
+// keeping the defaults for anything that is not configured
+func NewCORSConfig(cfg *config.Config) CORSConfig {
+	corsConfig := DefaultCORSConfig()
+	if len(cfg.CORSAllowedOrigins) > 0 {
+		corsConfig.AllowOrigins = cfg.CORSAllowedOrigins
+	}
+	if len(cfg.CORSAllowedMethods) > 0 {
+		corsConfig.AllowMethods = cfg.CORSAllowedMethods
+	}
+	if len(cfg.CORSAllowedHeaders) > 0 {
+		corsConfig.AllowHeaders = cfg.CORSAllowedHeaders
+	}
+	corsConfig.AllowCredentials = cfg.CORSAllowCredentials
+	return corsConfig
+}
+
 // CORSMiddleware creates a CORS middleware with given configuration
 func CORSMiddleware(config CORSConfig) gin.HandlerFunc {
 	allowMethods := strings.Join(config.AllowMethods, ", ")
@@ -68,10 +87,12 @@ func CORSMiddleware(config CORSConfig) gin.HandlerFunc {
 		}
 
 		if isAllowed {
-			if len(config.AllowOrigins) == 1 && config.AllowOrigins[0] == "*" {
+			// Browsers reject "*" for credentialed requests, so echo the origin instead
+			if origin == "" || (!config.AllowCredentials && isWildcard(config.AllowOrigins)) {
 				c.Header("Access-Control-Allow-Origin", "*")
 			} else {
 				c.Header("Access-Control-Allow-Origin", origin)
+				c.Header("Vary", "Origin")
 			}
 		}
 
@@ -79,12 +100,12 @@ func CORSMiddleware(config CORSConfig) gin.HandlerFunc {
 		c.Header("Access-Control-Allow-Headers", allowHeaders)
 		c.Header("Access-Control-Expose-Headers", exposeHeaders)
 
-		if config.AllowCredentials {
+		if config.AllowCredentials && isAllowed {
 			c.Header("Access-Control-Allow-Credentials", "true")
 		}
 
 		if config.MaxAge > 0 {
-			c.Header("Access-Control-Max-Age", string(rune(config.MaxAge)))
+			c.Header("Access-Control-Max-Age", strconv.Itoa(config.MaxAge))
 		}
 
 		// Handle preflight requests
@@ -97,8 +118,12 @@ func CORSMiddleware(config CORSConfig) gin.HandlerFunc {
 	}
 }
 
-// CORS creates a CORS middleware with default configuration
-func CORS() gin.HandlerFunc {
-	return CORSMiddleware(DefaultCORSConfig())
+// CORS creates a CORS middleware with the given configuration
+func CORS(config CORSConfig) gin.HandlerFunc {
+	return CORSMiddleware(config)
+}
+
+func isWildcard(origins []string) bool {
+	return len(origins) == 1 && origins[0] == "*"
 }
 
@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+)
+
+// IdempotencyKeyHeader is the header clients use to make a request safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+const (
+	idempotencyPending = "pending"
+	idempotencyDone    = "done"
+)
+
+type idempotencyKeyContextKey struct{}
+
+// IdempotencyKeyFromContext returns the idempotency key of the request ctx
+// belongs to, or "" if the client sent none
+func IdempotencyKeyFromContext(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+// idempotentResponse is the stored result of the first request for a key
+type idempotentResponse struct {
+	State       string `json:"state"`
+	BodyHash    string `json:"body_hash"`
+	Status      int    `json:"status,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        []byte `json:"body,omitempty"`
+}
+
+// IdempotencyMiddleware replays the stored response for repeated requests with the
+// same Idempotency-Key. Keys are scoped per user (or client IP) and kept for ttl.
+// A repeat that arrives while the first request is still running gets 409, and
+// one with a different body gets 422. Requests without the header, or without
+// Redis, pass through unchanged.
+func IdempotencyMiddleware(redisClient *redis.Client, keyPrefix string, ttl time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		idempotencyKey := c.GetHeader(IdempotencyKeyHeader)
+		if idempotencyKey == "" || redisClient == nil {
+			c.Next()
+			return
+		}
+
+		identifier := c.ClientIP()
+		if userID, ok := c.Get(UserIDKey); ok {
+			if id, ok := userID.(string); ok && id != "" {
+				identifier = id
+			}
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				utils.AbortWithError(c, http.StatusRequestEntityTooLarge, utils.CodePayloadTooLarge,
+					fmt.Sprintf("Request body must not exceed %d bytes", tooLarge.Limit))
+				return
+			}
+			utils.AbortWithError(c, http.StatusBadRequest, utils.CodeBadRequest, "Failed to read request body")
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		bodyHash := sha256.Sum256(body)
+		hash := hex.EncodeToString(bodyHash[:])
+
+		key := keyPrefix + ":idempotency:" + identifier + ":" + c.Request.Method + ":" + c.FullPath() + ":" + idempotencyKey
+		ctx := c.Request.Context()
+
+		pending, _ := json.Marshal(idempotentResponse{State: idempotencyPending, BodyHash: hash})
+		acquired, err := redisClient.SetNX(ctx, key, pending, ttl).Result()
+		if err != nil {
+			// If Redis fails, process the request without idempotency
+			c.Next()
+			return
+		}
+
+		if !acquired {
+			replayIdempotentResponse(c, redisClient, key, hash)
+			return
+		}
+
+		// A panicking handler must not leave the key pending until it expires
+		defer func() {
+			if r := recover(); r != nil {
+				redisClient.Del(context.Background(), key)
+				panic(r)
+			}
+		}()
+
+		blw := &bodyLogWriter{body: bytes.NewBufferString(""), ResponseWriter: c.Writer}
+		c.Writer = blw
+
+		// Calls made for a request with a key may be retried
+		c.Request = c.Request.WithContext(context.WithValue(ctx, idempotencyKeyContextKey{}, idempotencyKey))
+
+		c.Next()
+
+		// Server errors are not stored so the client can retry
+		status := c.Writer.Status()
+		if status >= http.StatusInternalServerError {
+			redisClient.Del(context.Background(), key)
+			return
+		}
+
+		stored, _ := json.Marshal(idempotentResponse{
+			State:       idempotencyDone,
+			BodyHash:    hash,
+			Status:      status,
+			ContentType: c.Writer.Header().Get("Content-Type"),
+			Body:        blw.body.Bytes(),
+		})
+		if err := redisClient.Set(context.Background(), key, stored, ttl).Err(); err != nil {
+			log.Printf("Failed to store idempotent response: %v", err)
+		}
+	}
+}
+
+// replayIdempotentResponse answers a repeated key with the stored response, or
+// an error if the first request is still running or had a different body
+func replayIdempotentResponse(c *gin.Context, redisClient *redis.Client, key, bodyHash string) {
+	data, err := redisClient.Get(c.Request.Context(), key).Bytes()
+	if err != nil {
+		utils.AbortWithError(c, http.StatusConflict, utils.CodeConflict, "A request with this idempotency key is already being processed")
+		return
+	}
+
+	var stored idempotentResponse
+	if err := json.Unmarshal(data, &stored); err != nil {
+		utils.AbortWithError(c, http.StatusConflict, utils.CodeConflict, "A request with this idempotency key is already being processed")
+		return
+	}
+	if stored.BodyHash != bodyHash {
+		utils.AbortWithError(c, http.StatusUnprocessableEntity, utils.CodeIdempotencyKeyReused, "This idempotency key was already used with a different request body")
+		return
+	}
+	if stored.State != idempotencyDone {
+		utils.AbortWithError(c, http.StatusConflict, utils.CodeConflict, "A request with this idempotency key is already being processed")
+		return
+	}
+
+	c.Header("Idempotent-Replayed", "true")
+	c.Data(stored.Status, stored.ContentType, stored.Body)
+	c.Abort()
+}
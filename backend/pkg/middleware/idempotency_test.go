@@ -0,0 +1,150 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+)
+
+// newIdempotencyRouter serves POST /items behind IdempotencyMiddleware. The
+// handler echoes the body and counts its calls; a body of "panic" panics.
+func newIdempotencyRouter(mr *miniredis.Miniredis, calls *int) *gin.Engine {
+	router := gin.New()
+	router.Use(gin.CustomRecovery(func(c *gin.Context, recovered interface{}) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}))
+	router.Use(func(c *gin.Context) {
+		if userID := c.GetHeader("X-Test-User"); userID != "" {
+			c.Set(UserIDKey, userID)
+		}
+		c.Next()
+	})
+	router.Use(IdempotencyMiddleware(redis.NewClient(&redis.Options{Addr: mr.Addr()}), "test", time.Hour))
+	router.POST("/items", func(c *gin.Context) {
+		*calls++
+		body, err := c.GetRawData()
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+		if string(body) == "panic" {
+			panic("handler failed")
+		}
+		c.String(http.StatusCreated, "created "+string(body))
+	})
+	return router
+}
+
+func postIdempotent(router *gin.Engine, key, userID, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/items", strings.NewReader(body))
+	req.Header.Set(IdempotencyKeyHeader, key)
+	if userID != "" {
+		req.Header.Set("X-Test-User", userID)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyReplaysSameBody(t *testing.T) {
+	var calls int
+	router := newIdempotencyRouter(miniredis.RunT(t), &calls)
+
+	first := postIdempotent(router, "key-1", "user-1", `{"amount":10}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("first status = %d, want 201", first.Code)
+	}
+
+	replay := postIdempotent(router, "key-1", "user-1", `{"amount":10}`)
+	if replay.Code != http.StatusCreated || replay.Body.String() != first.Body.String() {
+		t.Fatalf("replay = %d %q, want %d %q", replay.Code, replay.Body.String(), first.Code, first.Body.String())
+	}
+	if replay.Header().Get("Idempotent-Replayed") != "true" {
+		t.Error("replay is missing Idempotent-Replayed")
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	var calls int
+	router := newIdempotencyRouter(miniredis.RunT(t), &calls)
+
+	postIdempotent(router, "key-1", "user-1", `{"amount":10}`)
+
+	w := postIdempotent(router, "key-1", "user-1", `{"amount":1000}`)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("status = %d, want 422; body %q", w.Code, w.Body.String())
+	}
+	if code := errorCode(t, w); code != utils.CodeIdempotencyKeyReused {
+		t.Errorf("code = %q, want %q", code, utils.CodeIdempotencyKeyReused)
+	}
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyPendingKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	var calls int
+	router := newIdempotencyRouter(mr, &calls)
+
+	// Claim the key as a request that is still running would
+	if w := postIdempotent(router, "key-1", "user-1", "panic"); w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+	key := "test:idempotency:user-1:POST:/items:key-1"
+	if mr.Exists(key) {
+		t.Fatal("a panicking handler left its key behind")
+	}
+
+	// The retry runs the handler again instead of being stuck on 409
+	if w := postIdempotent(router, "key-1", "user-1", "retry"); w.Code != http.StatusCreated {
+		t.Fatalf("retry status = %d, want 201; body %q", w.Code, w.Body.String())
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
+
+func TestIdempotencyInFlightConflict(t *testing.T) {
+	mr := miniredis.RunT(t)
+	var calls int
+	router := newIdempotencyRouter(mr, &calls)
+
+	// An empty body hashes to this; a pending entry stands in for a running request
+	const emptyBodyHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	if err := mr.Set("test:idempotency:user-1:POST:/items:key-1", `{"state":"pending","body_hash":"`+emptyBodyHash+`"}`); err != nil {
+		t.Fatal(err)
+	}
+
+	w := postIdempotent(router, "key-1", "user-1", "")
+	if w.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want 409", w.Code)
+	}
+	if calls != 0 {
+		t.Errorf("handler called %d times, want 0", calls)
+	}
+}
+
+func TestIdempotencyKeysAreScopedPerUser(t *testing.T) {
+	var calls int
+	router := newIdempotencyRouter(miniredis.RunT(t), &calls)
+
+	postIdempotent(router, "key-1", "user-1", "a")
+	w := postIdempotent(router, "key-1", "user-2", "b")
+	if w.Code != http.StatusCreated || w.Body.String() != "created b" {
+		t.Fatalf("got %d %q, want user-2's own response", w.Code, w.Body.String())
+	}
+	if calls != 2 {
+		t.Errorf("handler called %d times, want 2", calls)
+	}
+}
@@ -2,12 +2,20 @@ package middleware
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"log"
+	"log/slog"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/radmickey/money-control/backend/pkg/config"
+	"github.com/radmickey/money-control/backend/pkg/utils"
 )
 
 // RequestIDKey is the context key for request ID
@@ -16,20 +24,79 @@ const RequestIDKey = "requestID"
 // RequestIDMiddleware adds a unique request ID to each request
 func RequestIDMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		requestID := c.GetHeader("X-Request-ID")
+		requestID := c.GetHeader(RequestIDHeader)
 		if requestID == "" {
 			requestID = uuid.New().String()
 		}
 
 		c.Set(RequestIDKey, requestID)
-		c.Header("X-Request-ID", requestID)
+		c.Header(RequestIDHeader, requestID)
+
+		// Store on the request context too so it reaches outgoing gRPC calls
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), requestID))
 
 		c.Next()
 	}
 }
 
+// Log formats supported by LoggingMiddleware
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// LoggingConfig holds request logging configuration
+type LoggingConfig struct {
+	Format string // "text" (default) or "json"
+	Level  string // "debug", "info" (default), "warn" or "error"
+}
+
+// NewLoggingConfig builds a logging configuration from application config
+func NewLoggingConfig(cfg *config.Config) LoggingConfig {
+	return LoggingConfig{
+		Format: cfg.LogFormat,
+		Level:  cfg.LogLevel,
+	}
+}
+
+// jsonLogger returns a structured logger when the JSON format is selected
+func (config LoggingConfig) jsonLogger() *slog.Logger {
+	if config.Format != LogFormatJSON {
+		return nil
+	}
+	return slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: parseLogLevel(config.Level)}))
+}
+
+func parseLogLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// statusLevel maps a response status to the level it is logged at
+func statusLevel(status int) slog.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return slog.LevelError
+	case status >= http.StatusBadRequest:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
 // LoggingMiddleware logs HTTP requests
-func LoggingMiddleware() gin.HandlerFunc {
+func LoggingMiddleware(config LoggingConfig) gin.HandlerFunc {
+	minLevel := parseLogLevel(config.Level)
+	logger := config.jsonLogger()
+
 	return func(c *gin.Context) {
 		start := time.Now()
 		path := c.Request.URL.Path
@@ -48,6 +115,11 @@ func LoggingMiddleware() gin.HandlerFunc {
 		// Get status code
 		status := c.Writer.Status()
 
+		level := statusLevel(status)
+		if level < minLevel {
+			return
+		}
+
 		// Get client IP
 		clientIP := c.ClientIP()
 
@@ -62,7 +134,30 @@ func LoggingMiddleware() gin.HandlerFunc {
 			path = path + "?" + query
 		}
 
-		log.Printf("[%s] %s %s %d %s %s user=%s err=%s",
+		if logger != nil {
+			attrs := []slog.Attr{
+				slog.String("request_id", reqID),
+				slog.String("method", c.Request.Method),
+				slog.String("path", path),
+				slog.Int("status", status),
+				slog.Float64("latency_ms", float64(latency.Microseconds())/1000),
+				slog.String("client_ip", clientIP),
+				slog.Int("bytes", max(c.Writer.Size(), 0)),
+			}
+			if userID != "" {
+				attrs = append(attrs, slog.String("user_id", userID))
+			}
+			if traceID := TraceIDFromContext(c.Request.Context()); traceID != "" {
+				attrs = append(attrs, slog.String("trace_id", traceID))
+			}
+			if errs := c.Errors.ByType(gin.ErrorTypePrivate).String(); errs != "" {
+				attrs = append(attrs, slog.String("error", errs))
+			}
+			logger.LogAttrs(c.Request.Context(), level, "request", attrs...)
+			return
+		}
+
+		log.Printf("[%s] %s %s %d %s %s user=%s trace=%s err=%s",
 			reqID,
 			c.Request.Method,
 			path,
@@ -70,6 +165,7 @@ func LoggingMiddleware() gin.HandlerFunc {
 			latency,
 			clientIP,
 			userID,
+			TraceIDFromContext(c.Request.Context()),
 			c.Errors.ByType(gin.ErrorTypePrivate).String(),
 		)
 	}
@@ -131,20 +227,31 @@ Body: %s
 }
 
 // RecoveryMiddleware recovers from panics and logs the error
-func RecoveryMiddleware() gin.HandlerFunc {
+func RecoveryMiddleware(config LoggingConfig) gin.HandlerFunc {
+	logger := config.jsonLogger()
+
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
 				requestID, _ := c.Get(RequestIDKey)
 				reqID, _ := requestID.(string)
 
-				log.Printf("[%s] PANIC recovered: %v", reqID, err)
+				if logger != nil {
+					logger.LogAttrs(c.Request.Context(), slog.LevelError, "panic recovered",
+						slog.String("request_id", reqID),
+						slog.String("method", c.Request.Method),
+						slog.String("path", c.Request.URL.Path),
+						slog.String("error", fmt.Sprint(err)),
+						slog.String("stack", string(debug.Stack())),
+					)
+				} else {
+					log.Printf("[%s] PANIC recovered: %v\n%s", reqID, err, debug.Stack())
+				}
 
-				c.AbortWithStatusJSON(500, gin.H{
-					"error":      "Internal Server Error",
-					"message":    "An unexpected error occurred",
+				utils.ErrorWithDetails(c, http.StatusInternalServerError, utils.CodeInternal, "An unexpected error occurred", map[string]string{
 					"request_id": reqID,
 				})
+				c.Abort()
 			}
 		}()
 		c.Next()
@@ -4,10 +4,12 @@ import (
 	"context"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/pkg/utils"
 )
 
 // RateLimiter provides rate limiting functionality
@@ -20,6 +22,29 @@ type RateLimiter struct {
 type RateLimitConfig struct {
 	Requests int           // Number of requests allowed
 	Window   time.Duration // Time window
+
+	// Routes overrides the limit for matching routes. Keys are path prefixes,
+	// optionally preceded by an HTTP method (e.g. "POST /api/v1/auth/login").
+	// The longest matching prefix wins and gets its own bucket.
+	Routes map[string]RateLimitConfig
+}
+
+// routeLimit returns the config and bucket name for a request
+func (config RateLimitConfig) routeLimit(method, path string) (RateLimitConfig, string) {
+	matched, bucket := config, ""
+	for route, routeConfig := range config.Routes {
+		prefix := route
+		if m, p, ok := strings.Cut(route, " "); ok {
+			if m != method {
+				continue
+			}
+			prefix = p
+		}
+		if strings.HasPrefix(path, prefix) && len(route) > len(bucket) {
+			matched, bucket = routeConfig, route
+		}
+	}
+	return matched, bucket
 }
 
 // NewRateLimiter creates a new rate limiter
@@ -39,26 +64,24 @@ func RateLimitMiddleware(limiter *RateLimiter, config RateLimitConfig) gin.Handl
 			identifier = userID.(string)
 		}
 
+		routeConfig, bucket := config.routeLimit(c.Request.Method, c.Request.URL.Path)
+
 		key := limiter.keyPrefix + ":ratelimit:" + identifier
+		if bucket != "" {
+			key = limiter.keyPrefix + ":ratelimit:" + bucket + ":" + identifier
+		}
 
-		allowed, remaining, resetTime, err := limiter.Allow(c.Request.Context(), key, config)
+		allowed, remaining, resetTime, err := limiter.Allow(c.Request.Context(), key, routeConfig)
 		if err != nil {
 			// If Redis fails, allow the request but log the error
 			c.Next()
 			return
 		}
 
-		// Set rate limit headers
-		c.Header("X-RateLimit-Limit", strconv.Itoa(config.Requests))
-		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
-		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetTime, 10))
+		setRateLimitHeaders(c, routeConfig, remaining, resetTime)
 
 		if !allowed {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error":       "Too Many Requests",
-				"message":     "Rate limit exceeded",
-				"retry_after": resetTime - time.Now().Unix(),
-			})
+			abortRateLimited(c, resetTime, "Rate limit exceeded")
 			return
 		}
 
@@ -66,11 +89,31 @@ func RateLimitMiddleware(limiter *RateLimiter, config RateLimitConfig) gin.Handl
 	}
 }
 
-// Allow checks if a request is allowed and updates the counter
+// setRateLimitHeaders sets the X-RateLimit-* headers on the response
+func setRateLimitHeaders(c *gin.Context, config RateLimitConfig, remaining int, resetTime int64) {
+	c.Header("X-RateLimit-Limit", strconv.Itoa(config.Requests))
+	c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	c.Header("X-RateLimit-Reset", strconv.FormatInt(resetTime, 10))
+}
+
+// abortRateLimited rejects the request with 429 and a Retry-After header
+func abortRateLimited(c *gin.Context, resetTime int64, message string) {
+	retryAfter := resetTime - time.Now().Unix()
+	if retryAfter < 1 {
+		retryAfter = 1
+	}
+
+	c.Header("Retry-After", strconv.FormatInt(retryAfter, 10))
+	utils.ErrorWithDetails(c, http.StatusTooManyRequests, utils.CodeRateLimited, message, map[string]string{
+		"retry_after": strconv.FormatInt(retryAfter, 10),
+	})
+	c.Abort()
+}
+
+// Allow checks if a request is allowed and updates the counter.
+// The reset time is taken from the key's TTL so it matches the Redis window.
 func (r *RateLimiter) Allow(ctx context.Context, key string, config RateLimitConfig) (bool, int, int64, error) {
 	now := time.Now()
-	windowStart := now.Truncate(config.Window)
-	windowEnd := windowStart.Add(config.Window)
 
 	pipe := r.redis.Pipeline()
 
@@ -80,6 +123,9 @@ func (r *RateLimiter) Allow(ctx context.Context, key string, config RateLimitCon
 	// Set expiration only if key is new
 	pipe.ExpireNX(ctx, key, config.Window)
 
+	// Read back the remaining window
+	ttlCmd := pipe.PTTL(ctx, key)
+
 	_, err := pipe.Exec(ctx)
 	if err != nil {
 		return false, 0, 0, err
@@ -91,7 +137,11 @@ func (r *RateLimiter) Allow(ctx context.Context, key string, config RateLimitCon
 		remaining = 0
 	}
 
-	resetTime := windowEnd.Unix()
+	ttl := ttlCmd.Val()
+	if ttl <= 0 {
+		ttl = config.Window
+	}
+	resetTime := now.Add(ttl).Unix()
 
 	return count <= config.Requests, remaining, resetTime, nil
 }
@@ -123,16 +173,10 @@ func EndpointRateLimitMiddleware(limiter *RateLimiter, limits map[string]RateLim
 			return
 		}
 
-		c.Header("X-RateLimit-Limit", strconv.Itoa(config.Requests))
-		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
-		c.Header("X-RateLimit-Reset", strconv.FormatInt(resetTime, 10))
+		setRateLimitHeaders(c, config, remaining, resetTime)
 
 		if !allowed {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error":       "Too Many Requests",
-				"message":     "Rate limit exceeded for this endpoint",
-				"retry_after": resetTime - time.Now().Unix(),
-			})
+			abortRateLimited(c, resetTime, "Rate limit exceeded for this endpoint")
 			return
 		}
 
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+)
+
+// newRateLimitRouter serves GET and POST /login behind a limiter backed by
+// mr, returning 200 when a request gets through
+func newRateLimitRouter(mr *miniredis.Miniredis, config RateLimitConfig) *gin.Engine {
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	router := gin.New()
+	router.Use(RateLimitMiddleware(NewRateLimiter(client, "test"), config))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	router.GET("/login", ok)
+	router.POST("/login", ok)
+	return router
+}
+
+func serveRateLimited(router *gin.Engine, method string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(method, "/login", nil))
+	return w
+}
+
+// headerInt parses an integer response header, failing the test if it is missing
+func headerInt(t *testing.T, w *httptest.ResponseRecorder, name string) int64 {
+	t.Helper()
+	value := w.Header().Get(name)
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		t.Fatalf("%s = %q, want an integer", name, value)
+	}
+	return n
+}
+
+func TestRateLimitHeaders(t *testing.T) {
+	mr := miniredis.RunT(t)
+	router := newRateLimitRouter(mr, RateLimitConfig{Requests: 3, Window: time.Minute})
+	start := time.Now().Unix()
+
+	for i, wantRemaining := range []int64{2, 1, 0} {
+		w := serveRateLimited(router, http.MethodGet)
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200", i+1, w.Code)
+		}
+		if got := headerInt(t, w, "X-RateLimit-Limit"); got != 3 {
+			t.Errorf("request %d: limit = %d, want 3", i+1, got)
+		}
+		if got := headerInt(t, w, "X-RateLimit-Remaining"); got != wantRemaining {
+			t.Errorf("request %d: remaining = %d, want %d", i+1, got, wantRemaining)
+		}
+		if reset := headerInt(t, w, "X-RateLimit-Reset"); reset < start || reset > time.Now().Add(time.Minute).Unix() {
+			t.Errorf("request %d: reset = %d, want within the minute after %d", i+1, reset, start)
+		}
+		if retry := w.Header().Get("Retry-After"); retry != "" {
+			t.Errorf("request %d: Retry-After = %q on an allowed request", i+1, retry)
+		}
+	}
+
+	w := serveRateLimited(router, http.MethodGet)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("request over the limit: status = %d, want 429", w.Code)
+	}
+	if got := headerInt(t, w, "X-RateLimit-Remaining"); got != 0 {
+		t.Errorf("rejected request: remaining = %d, want 0", got)
+	}
+	if retry := headerInt(t, w, "Retry-After"); retry < 1 || retry > 60 {
+		t.Errorf("Retry-After = %d, want 1-60 seconds", retry)
+	}
+	if code := errorCode(t, w); code != utils.CodeRateLimited {
+		t.Errorf("error code = %q, want RATE_LIMITED", code)
+	}
+
+	// A new window starts with the full allowance
+	mr.FastForward(time.Minute)
+	w = serveRateLimited(router, http.MethodGet)
+	if w.Code != http.StatusOK {
+		t.Fatalf("after the window: status = %d, want 200", w.Code)
+	}
+	if got := headerInt(t, w, "X-RateLimit-Remaining"); got != 2 {
+		t.Errorf("after the window: remaining = %d, want 2", got)
+	}
+}
+
+func TestRateLimitRouteBucket(t *testing.T) {
+	mr := miniredis.RunT(t)
+	router := newRateLimitRouter(mr, RateLimitConfig{
+		Requests: 5,
+		Window:   time.Minute,
+		Routes: map[string]RateLimitConfig{
+			"POST /login": {Requests: 1, Window: time.Minute},
+		},
+	})
+
+	w := serveRateLimited(router, http.MethodPost)
+	if w.Code != http.StatusOK || headerInt(t, w, "X-RateLimit-Limit") != 1 {
+		t.Fatalf("first login: status %d, limit %s; want 200 with limit 1", w.Code, w.Header().Get("X-RateLimit-Limit"))
+	}
+	if w := serveRateLimited(router, http.MethodPost); w.Code != http.StatusTooManyRequests {
+		t.Fatalf("second login: status = %d, want 429", w.Code)
+	}
+
+	// Other routes count against the default bucket, untouched by logins
+	w = serveRateLimited(router, http.MethodGet)
+	if w.Code != http.StatusOK {
+		t.Fatalf("GET: status = %d, want 200", w.Code)
+	}
+	if got := headerInt(t, w, "X-RateLimit-Remaining"); got != 4 {
+		t.Errorf("GET: remaining = %d, want 4", got)
+	}
+}
+
+func TestRateLimitRedisDown(t *testing.T) {
+	mr := miniredis.RunT(t)
+	router := newRateLimitRouter(mr, RateLimitConfig{Requests: 1, Window: time.Minute})
+	mr.Close()
+
+	// Requests fail open without claiming a limit
+	w := serveRateLimited(router, http.MethodGet)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.Code)
+	}
+	if limit := w.Header().Get("X-RateLimit-Limit"); limit != "" {
+		t.Errorf("X-RateLimit-Limit = %q, want unset", limit)
+	}
+}
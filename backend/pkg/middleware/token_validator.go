@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/pkg/config"
+	authpb "github.com/radmickey/money-control/backend/proto/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// AuthValidationLocal verifies JWTs in-process with the shared secret
+	AuthValidationLocal = "local"
+	// AuthValidationRemote delegates verification to the auth service
+	AuthValidationRemote = "remote"
+
+	remoteValidationTimeout = 3 * time.Second
+	tokenVersionLoadTimeout = 3 * time.Second
+)
+
+// ErrInvalidAccessToken is returned when an access token fails verification
+var ErrInvalidAccessToken = errors.New("invalid or expired token")
+
+// TokenValidator verifies access tokens for AuthMiddleware
+type TokenValidator interface {
+	ValidateToken(ctx context.Context, token string) (*auth.AccessClaims, error)
+}
+
+// LocalTokenValidator verifies tokens with the JWT secret and, optionally, the token version cache
+type LocalTokenValidator struct {
+	jwtManager *auth.JWTManager
+	versions   *auth.TokenVersionStore
+}
+
+// NewLocalTokenValidator creates a local token validator. versions may be nil.
+func NewLocalTokenValidator(jwtManager *auth.JWTManager, versions *auth.TokenVersionStore) *LocalTokenValidator {
+	return &LocalTokenValidator{
+		jwtManager: jwtManager,
+		versions:   versions,
+	}
+}
+
+// ValidateToken verifies the signature, expiry and token version
+func (v *LocalTokenValidator) ValidateToken(ctx context.Context, token string) (*auth.AccessClaims, error) {
+	claims, err := v.jwtManager.ValidateAccessToken(token)
+	if err != nil {
+		return nil, ErrInvalidAccessToken
+	}
+
+	if err := v.versions.Check(ctx, claims); err != nil {
+		if errors.Is(err, auth.ErrTokenRevoked) {
+			return nil, err
+		}
+		// Fail open when the version cache is unavailable
+		log.Printf("Token version check failed: %v", err)
+	}
+
+	return claims, nil
+}
+
+// RemoteTokenValidator verifies tokens through the auth service's ValidateToken RPC,
+// so the signing secret only has to live in the auth service
+type RemoteTokenValidator struct {
+	client authpb.AuthServiceClient
+}
+
+// NewRemoteTokenValidator creates a token validator backed by the auth service
+func NewRemoteTokenValidator(client authpb.AuthServiceClient) *RemoteTokenValidator {
+	return &RemoteTokenValidator{client: client}
+}
+
+// ValidateToken asks the auth service to verify the token
+func (v *RemoteTokenValidator) ValidateToken(ctx context.Context, token string) (*auth.AccessClaims, error) {
+	ctx, cancel := context.WithTimeout(ctx, remoteValidationTimeout)
+	defer cancel()
+
+	resp, err := v.client.ValidateToken(ctx, &authpb.ValidateTokenRequest{AccessToken: token})
+	if err != nil {
+		return nil, fmt.Errorf("auth service: %w", err)
+	}
+
+	if !resp.Valid {
+		return nil, ErrInvalidAccessToken
+	}
+
+	return &auth.AccessClaims{
+		UserID: resp.UserId,
+		Email:  resp.Email,
+	}, nil
+}
+
+// NewRemoteTokenVersionLoader loads token versions through the auth service's
+// GetTokenVersion RPC, so services without the users table can repopulate
+// their token version cache. A user that no longer exists is reported as
+// revoked.
+func NewRemoteTokenVersionLoader(client authpb.AuthServiceClient) auth.TokenVersionLoader {
+	return func(ctx context.Context, userID string) (int, error) {
+		ctx, cancel := context.WithTimeout(ctx, tokenVersionLoadTimeout)
+		defer cancel()
+
+		resp, err := client.GetTokenVersion(ctx, &authpb.GetTokenVersionRequest{UserId: userID})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return 0, auth.ErrTokenRevoked
+			}
+			return 0, fmt.Errorf("auth service: %w", err)
+		}
+		return int(resp.TokenVersion), nil
+	}
+}
+
+// NewTokenVersionStore creates a token version store that falls back to the
+// auth service on a cache miss
+func NewTokenVersionStore(cfg *config.Config, redisClient *redis.Client) (*auth.TokenVersionStore, error) {
+	conn, err := dialAuthService(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return auth.NewTokenVersionStore(redisClient, NewRemoteTokenVersionLoader(authpb.NewAuthServiceClient(conn))), nil
+}
+
+// NewTokenValidator returns the validator selected by AUTH_VALIDATION_MODE.
+// Local validation is the default; remote mode dials the auth service.
+func NewTokenValidator(cfg *config.Config, jwtManager *auth.JWTManager, versions *auth.TokenVersionStore) (TokenValidator, error) {
+	switch cfg.AuthValidationMode {
+	case "", AuthValidationLocal:
+		return NewLocalTokenValidator(jwtManager, versions), nil
+	case AuthValidationRemote:
+		conn, err := dialAuthService(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return NewRemoteTokenValidator(authpb.NewAuthServiceClient(conn)), nil
+	default:
+		return nil, fmt.Errorf("unknown auth validation mode %q", cfg.AuthValidationMode)
+	}
+}
+
+// dialAuthService connects to the auth service's gRPC endpoint
+func dialAuthService(cfg *config.Config) (*grpc.ClientConn, error) {
+	conn, err := grpc.Dial(cfg.AuthServiceURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(UnaryClientRequestIDInterceptor()),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/pkg/auth"
+	authpb "github.com/radmickey/money-control/backend/proto/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAuthClient serves token versions the way the auth service would
+type fakeAuthClient struct {
+	authpb.AuthServiceClient
+	versions map[string]int32
+	err      error
+	calls    int
+}
+
+func (c *fakeAuthClient) GetTokenVersion(ctx context.Context, req *authpb.GetTokenVersionRequest, opts ...grpc.CallOption) (*authpb.GetTokenVersionResponse, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	version, ok := c.versions[req.UserId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "user not found")
+	}
+	return &authpb.GetTokenVersionResponse{TokenVersion: version}, nil
+}
+
+// newVersionedValidator returns a local validator whose version cache starts
+// empty and falls back to client
+func newVersionedValidator(t *testing.T, client authpb.AuthServiceClient) (*LocalTokenValidator, *auth.JWTManager) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	versions := auth.NewTokenVersionStore(redis.NewClient(&redis.Options{Addr: mr.Addr()}), NewRemoteTokenVersionLoader(client))
+	jwtManager := auth.NewJWTManager(testJWTSecret, time.Hour, 24*time.Hour)
+	return NewLocalTokenValidator(jwtManager, versions), jwtManager
+}
+
+func TestLocalTokenValidatorLoadsVersionOnCacheMiss(t *testing.T) {
+	client := &fakeAuthClient{versions: map[string]int32{"user-1": 2}}
+	validator, jwtManager := newVersionedValidator(t, client)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		version int
+		wantErr error
+	}{
+		{name: "token from before the bump", version: 1, wantErr: auth.ErrTokenRevoked},
+		{name: "current token", version: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, _, err := jwtManager.GenerateAccessToken("user-1", "a@example.com", tt.version)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := validator.ValidateToken(ctx, token); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	// The first miss is cached, so auth is only asked once
+	if client.calls != 1 {
+		t.Errorf("GetTokenVersion called %d times, want 1", client.calls)
+	}
+}
+
+func TestLocalTokenValidatorRejectsDeletedUser(t *testing.T) {
+	validator, jwtManager := newVersionedValidator(t, &fakeAuthClient{})
+
+	token, _, err := jwtManager.GenerateAccessToken("deleted-user", "a@example.com", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validator.ValidateToken(context.Background(), token); !errors.Is(err, auth.ErrTokenRevoked) {
+		t.Fatalf("err = %v, want ErrTokenRevoked", err)
+	}
+}
+
+func TestLocalTokenValidatorAuthUnavailable(t *testing.T) {
+	// Like a Redis outage, an unreachable auth service fails open
+	validator, jwtManager := newVersionedValidator(t, &fakeAuthClient{err: status.Error(codes.Unavailable, "connection refused")})
+
+	token, _, err := jwtManager.GenerateAccessToken("user-1", "a@example.com", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := validator.ValidateToken(context.Background(), token); err != nil {
+		t.Fatalf("err = %v, want the token accepted", err)
+	}
+}
@@ -34,6 +34,7 @@ var (
 	ErrCircuitOpen    = errors.New("circuit breaker is open")
 	ErrTooManyFails   = errors.New("too many failures")
 	ErrRequestTimeout = errors.New("request timeout")
+	ErrUnknownCircuit = errors.New("circuit breaker not found")
 )
 
 // CircuitBreakerConfig holds circuit breaker configuration
@@ -194,6 +195,13 @@ func (cb *CircuitBreaker) toClosed() {
 	cb.halfOpenRequests = 0
 }
 
+// Reset forces the circuit breaker back to the closed state
+func (cb *CircuitBreaker) Reset() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.toClosed()
+}
+
 // GetState returns current circuit breaker state
 func (cb *CircuitBreaker) GetState() State {
 	cb.mu.RLock()
@@ -270,6 +278,20 @@ func (m *CircuitBreakerManager) GetWithConfig(config CircuitBreakerConfig) *Circ
 	return cb
 }
 
+// Reset closes the named circuit breaker and returns it
+func (m *CircuitBreakerManager) Reset(name string) (*CircuitBreaker, error) {
+	m.mu.RLock()
+	cb, exists := m.breakers[name]
+	m.mu.RUnlock()
+
+	if !exists {
+		return nil, ErrUnknownCircuit
+	}
+
+	cb.Reset()
+	return cb, nil
+}
+
 // AllStats returns stats for all circuit breakers
 func (m *CircuitBreakerManager) AllStats() map[string]map[string]interface{} {
 	m.mu.RLock()
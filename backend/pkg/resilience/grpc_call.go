@@ -11,11 +11,15 @@ import (
 // DefaultCallTimeout is the default timeout for gRPC calls
 const DefaultCallTimeout = 10 * time.Second
 
+// errCircuitOpen is returned by calls rejected by an open circuit breaker
+var errCircuitOpen = status.Error(codes.Unavailable, "service temporarily unavailable (circuit open)")
+
 // CallOptions holds options for a resilient gRPC call
 type CallOptions struct {
-	Timeout      time.Duration
-	ServiceName  string
-	UseBreaker   bool
+	Timeout     time.Duration
+	ServiceName string
+	UseBreaker  bool
+	Retry       RetryPolicy // a zero policy makes a single attempt
 }
 
 // DefaultCallOptions returns default call options
@@ -27,6 +31,14 @@ func DefaultCallOptions(serviceName string) CallOptions {
 	}
 }
 
+// ReadCallOptions returns default call options plus retries, for idempotent
+// reads
+func ReadCallOptions(serviceName string) CallOptions {
+	opts := DefaultCallOptions(serviceName)
+	opts.Retry = DefaultRetryPolicy()
+	return opts
+}
+
 // CallWithTimeout executes a gRPC call with timeout
 func CallWithTimeout[T any](ctx context.Context, timeout time.Duration, fn func(context.Context) (T, error)) (T, error) {
 	ctx, cancel := context.WithTimeout(ctx, timeout)
@@ -54,24 +66,28 @@ func CallWithBreaker[T any](ctx context.Context, serviceName string, fn func(con
 	})
 
 	if err == ErrCircuitOpen {
-		return result, status.Error(codes.Unavailable, "service temporarily unavailable (circuit open)")
+		return result, errCircuitOpen
 	}
 
 	return result, callErr
 }
 
-// Call executes a gRPC call with both timeout and circuit breaker
+// Call executes a gRPC call with timeout, circuit breaker and retries. The
+// timeout covers all attempts; each attempt goes through the breaker.
 func Call[T any](ctx context.Context, opts CallOptions, fn func(context.Context) (T, error)) (T, error) {
 	// Apply timeout
 	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
 	defer cancel()
 
 	// Apply circuit breaker if enabled
+	call := fn
 	if opts.UseBreaker {
-		return CallWithBreaker(ctx, opts.ServiceName, fn)
+		call = func(ctx context.Context) (T, error) {
+			return CallWithBreaker(ctx, opts.ServiceName, fn)
+		}
 	}
 
-	return fn(ctx)
+	return Retry(ctx, opts.Retry, call)
 }
 
 // isRetryableError checks if an error should trigger circuit breaker
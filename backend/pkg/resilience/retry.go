@@ -0,0 +1,120 @@
+package resilience
+
+import (
+	"context"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy controls how Retry retries a failing call
+type RetryPolicy struct {
+	MaxAttempts    int // total attempts, including the first
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Retryable reports whether a failed attempt may be retried;
+	// IsTransientError when nil
+	Retryable func(error) bool
+}
+
+// DefaultRetryPolicy makes up to 3 attempts, backing off from 100ms up to 1s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     2,
+		Retryable:      IsTransientError,
+	}
+}
+
+// idempotentMethodPrefixes are the RPC name prefixes of read-only calls,
+// which are always safe to retry
+var idempotentMethodPrefixes = []string{"Get", "List", "Search", "Convert", "Validate", "Project", "Detect"}
+
+// IsTransientError reports whether err is a gRPC error that may succeed if
+// the call is repeated. An open circuit is not transient: retrying would
+// only fail again without reaching the service.
+func IsTransientError(err error) bool {
+	if err == nil || err == errCircuitOpen {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Retry calls fn until it succeeds, fails with an error the policy doesn't
+// retry, or MaxAttempts is reached. Between attempts it waits a random time
+// up to an exponentially growing backoff, so many clients retrying at once
+// spread out. It gives up early, returning the last error, when ctx is done.
+func Retry[T any](ctx context.Context, policy RetryPolicy, fn func(context.Context) (T, error)) (T, error) {
+	retryable := policy.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+
+	backoff := policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		result, err := fn(ctx)
+		if err == nil || attempt >= policy.MaxAttempts || !retryable(err) {
+			return result, err
+		}
+
+		timer := time.NewTimer(time.Duration(rand.Int63n(int64(backoff) + 1)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, err
+		case <-timer.C:
+		}
+
+		backoff = time.Duration(float64(backoff) * policy.Multiplier)
+		if policy.MaxBackoff > 0 && backoff > policy.MaxBackoff {
+			backoff = policy.MaxBackoff
+		}
+	}
+}
+
+// IsIdempotentMethod reports whether a full gRPC method name, e.g.
+// /accounts.AccountsService/GetAccount, is a read-only call
+func IsIdempotentMethod(fullMethod string) bool {
+	name := fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+	for _, prefix := range idempotentMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// UnaryClientInterceptor sends every call to serviceName through its circuit
+// breaker and retries transient failures under policy. Only reads are
+// retried, plus mutations whose client sent an idempotency key; other
+// mutations get a single attempt since they may have been applied even
+// though the call failed.
+func UnaryClientInterceptor(serviceName string, policy RetryPolicy) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		call := func(ctx context.Context) (struct{}, error) {
+			return CallWithBreaker(ctx, serviceName, func(ctx context.Context) (struct{}, error) {
+				return struct{}{}, invoker(ctx, method, req, reply, cc, opts...)
+			})
+		}
+
+		if !IsIdempotentMethod(method) && middleware.IdempotencyKeyFromContext(ctx) == "" {
+			_, err := call(ctx)
+			return err
+		}
+		_, err := Retry(ctx, policy, call)
+		return err
+	}
+}
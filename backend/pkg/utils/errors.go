@@ -0,0 +1,149 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Error codes sent in ErrorInfo.Code. Clients can rely on them; messages are
+// for humans and may change.
+const (
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeValidation           = "VALIDATION_ERROR"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeForbidden            = "FORBIDDEN"
+	CodeNotFound             = "NOT_FOUND"
+	CodeConflict             = "CONFLICT"
+	CodeAccountLocked        = "ACCOUNT_LOCKED"
+	CodeReauthRequired       = "REAUTH_REQUIRED"
+	CodePreconditionFailed   = "PRECONDITION_FAILED"
+	CodeInsufficientHistory  = "INSUFFICIENT_HISTORY"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeRateLimited          = "RATE_LIMITED"
+	CodeIdempotencyKeyReused = "IDEMPOTENCY_KEY_REUSED"
+	CodeInternal             = "INTERNAL_ERROR"
+	CodeUnavailable          = "SERVICE_UNAVAILABLE"
+)
+
+// APIError is an error that carries the response it should be reported with
+type APIError struct {
+	Status  int
+	Code    string
+	Message string
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+// NewBadRequestError creates a 400 error
+func NewBadRequestError(message string) *APIError {
+	return &APIError{Status: http.StatusBadRequest, Code: CodeBadRequest, Message: message}
+}
+
+// NewUnauthorizedError creates a 401 error
+func NewUnauthorizedError(message string) *APIError {
+	return &APIError{Status: http.StatusUnauthorized, Code: CodeUnauthorized, Message: message}
+}
+
+// NewForbiddenError creates a 403 error
+func NewForbiddenError(message string) *APIError {
+	return &APIError{Status: http.StatusForbidden, Code: CodeForbidden, Message: message}
+}
+
+// NewNotFoundError creates a 404 error
+func NewNotFoundError(message string) *APIError {
+	return &APIError{Status: http.StatusNotFound, Code: CodeNotFound, Message: message}
+}
+
+// NewConflictError creates a 409 error
+func NewConflictError(message string) *APIError {
+	return &APIError{Status: http.StatusConflict, Code: CodeConflict, Message: message}
+}
+
+// NewRateLimitedError creates a 429 error
+func NewRateLimitedError(message string) *APIError {
+	return &APIError{Status: http.StatusTooManyRequests, Code: CodeRateLimited, Message: message}
+}
+
+// NewUnavailableError creates a 503 error
+func NewUnavailableError(message string) *APIError {
+	return &APIError{Status: http.StatusServiceUnavailable, Code: CodeUnavailable, Message: message}
+}
+
+// ErrorMapping says how a sentinel error, or any error wrapping it, is
+// reported. An empty Message reports the error's own text.
+type ErrorMapping struct {
+	Err     error
+	Status  int
+	Code    string
+	Message string
+}
+
+// ErrorMap maps a service's sentinel errors to responses, so each error is
+// reported the same way by every handler
+type ErrorMap []ErrorMapping
+
+// Respond writes err as an error response. APIErrors and mapped errors keep
+// their status and code, gRPC errors are translated by their status code,
+// and anything else is an internal error.
+func (m ErrorMap) Respond(c *gin.Context, err error) {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		Error(c, apiErr.Status, apiErr.Code, apiErr.Message)
+		return
+	}
+
+	for _, mapping := range m {
+		if errors.Is(err, mapping.Err) {
+			message := mapping.Message
+			if message == "" {
+				message = err.Error()
+			}
+			Error(c, mapping.Status, mapping.Code, message)
+			return
+		}
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown {
+		httpStatus, code := grpcErrorCode(st.Code())
+		Error(c, httpStatus, code, st.Message())
+		return
+	}
+
+	InternalError(c, err.Error())
+}
+
+// RespondError writes err as an error response without service mappings
+func RespondError(c *gin.Context, err error) {
+	ErrorMap(nil).Respond(c, err)
+}
+
+// grpcErrorCode returns the HTTP status and error code for a gRPC status code
+func grpcErrorCode(code codes.Code) (int, string) {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest, CodeBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized, CodeUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden, CodeForbidden
+	case codes.NotFound:
+		return http.StatusNotFound, CodeNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict, CodeConflict
+	case codes.FailedPrecondition:
+		return http.StatusUnprocessableEntity, CodePreconditionFailed
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests, CodeRateLimited
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return http.StatusServiceUnavailable, CodeUnavailable
+	default:
+		return http.StatusInternalServerError, CodeInternal
+	}
+}
@@ -19,15 +19,17 @@ type ErrorInfo struct {
 	Code    string            `json:"code"`
 	Message string            `json:"message"`
 	Details map[string]string `json:"details,omitempty"`
+	Fields  []FieldError      `json:"fields,omitempty"`
 }
 
 // Meta holds pagination and other metadata
 type Meta struct {
-	Page       int   `json:"page,omitempty"`
-	PageSize   int   `json:"page_size,omitempty"`
-	Total      int   `json:"total,omitempty"`
-	TotalPages int   `json:"total_pages,omitempty"`
-	Timestamp  int64 `json:"timestamp,omitempty"`
+	Page       int    `json:"page,omitempty"`
+	PageSize   int    `json:"page_size,omitempty"`
+	Total      int    `json:"total,omitempty"`
+	TotalPages int    `json:"total_pages,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Timestamp  int64  `json:"timestamp,omitempty"`
 }
 
 // Success sends a success response
@@ -71,6 +73,13 @@ func Error(c *gin.Context, status int, code, message string) {
 	})
 }
 
+// AbortWithError sends an error response and stops the handler chain, for
+// middleware
+func AbortWithError(c *gin.Context, status int, code, message string) {
+	Error(c, status, code, message)
+	c.Abort()
+}
+
 // ErrorWithDetails sends an error response with details
 func ErrorWithDetails(c *gin.Context, status int, code, message string, details map[string]string) {
 	c.JSON(status, Response{
@@ -85,37 +94,57 @@ func ErrorWithDetails(c *gin.Context, status int, code, message string, details
 
 // BadRequest sends a 400 bad request response
 func BadRequest(c *gin.Context, message string) {
-	Error(c, http.StatusBadRequest, "BAD_REQUEST", message)
+	Error(c, http.StatusBadRequest, CodeBadRequest, message)
 }
 
 // Unauthorized sends a 401 unauthorized response
 func Unauthorized(c *gin.Context, message string) {
-	Error(c, http.StatusUnauthorized, "UNAUTHORIZED", message)
+	Error(c, http.StatusUnauthorized, CodeUnauthorized, message)
 }
 
 // Forbidden sends a 403 forbidden response
 func Forbidden(c *gin.Context, message string) {
-	Error(c, http.StatusForbidden, "FORBIDDEN", message)
+	Error(c, http.StatusForbidden, CodeForbidden, message)
 }
 
 // NotFound sends a 404 not found response
 func NotFound(c *gin.Context, message string) {
-	Error(c, http.StatusNotFound, "NOT_FOUND", message)
+	Error(c, http.StatusNotFound, CodeNotFound, message)
 }
 
 // Conflict sends a 409 conflict response
 func Conflict(c *gin.Context, message string) {
-	Error(c, http.StatusConflict, "CONFLICT", message)
+	Error(c, http.StatusConflict, CodeConflict, message)
+}
+
+// Locked sends a 423 locked response
+func Locked(c *gin.Context, message string) {
+	Error(c, http.StatusLocked, CodeAccountLocked, message)
+}
+
+// PayloadTooLarge sends a 413 payload too large response
+func PayloadTooLarge(c *gin.Context, message string) {
+	Error(c, http.StatusRequestEntityTooLarge, CodePayloadTooLarge, message)
+}
+
+// TooManyRequests sends a 429 rate limited response
+func TooManyRequests(c *gin.Context, message string) {
+	Error(c, http.StatusTooManyRequests, CodeRateLimited, message)
+}
+
+// ServiceUnavailable sends a 503 service unavailable response
+func ServiceUnavailable(c *gin.Context, message string) {
+	Error(c, http.StatusServiceUnavailable, CodeUnavailable, message)
 }
 
 // InternalError sends a 500 internal server error response
 func InternalError(c *gin.Context, message string) {
-	Error(c, http.StatusInternalServerError, "INTERNAL_ERROR", message)
+	Error(c, http.StatusInternalServerError, CodeInternal, message)
 }
 
 // ValidationError sends a 422 validation error response
 func ValidationError(c *gin.Context, details map[string]string) {
-	ErrorWithDetails(c, http.StatusUnprocessableEntity, "VALIDATION_ERROR", "Validation failed", details)
+	ErrorWithDetails(c, http.StatusUnprocessableEntity, CodeValidation, "Validation failed", details)
 }
 
 // PaginationMeta creates pagination metadata
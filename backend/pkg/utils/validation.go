@@ -0,0 +1,151 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError describes why a single request field failed validation
+type FieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+func init() {
+	// Report fields by the names clients send rather than Go field names
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterTagNameFunc(requestFieldName)
+	}
+}
+
+// requestFieldName returns the JSON or form name of a struct field
+func requestFieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name := strings.Split(field.Tag.Get(tag), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// ValidationErrors converts validator errors into one FieldError per failed
+// field. It returns nil when err isn't a validation error.
+func ValidationErrors(err error) []FieldError {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		return nil
+	}
+
+	fields := make([]FieldError, 0, len(errs))
+	for _, fe := range errs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(fe),
+			Rule:    fe.Tag(),
+			Message: validationMessage(fe),
+		})
+	}
+	return fields
+}
+
+// fieldPath returns the field's path below the request struct, such as
+// "items[0].amount"
+func fieldPath(fe validator.FieldError) string {
+	namespace := fe.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return fe.Field()
+}
+
+// validationMessage describes a failed rule in plain words
+func validationMessage(fe validator.FieldError) string {
+	param := fe.Param()
+	isString := fe.Kind() == reflect.String
+	isList := fe.Kind() == reflect.Slice || fe.Kind() == reflect.Map || fe.Kind() == reflect.Array
+
+	switch fe.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be a valid email address"
+	case "url":
+		return "must be a valid URL"
+	case "uuid", "uuid4":
+		return "must be a valid UUID"
+	case "oneof":
+		return "must be one of: " + strings.Join(strings.Fields(param), ", ")
+	case "len":
+		switch {
+		case isString:
+			return fmt.Sprintf("must be exactly %s characters long", param)
+		case isList:
+			return fmt.Sprintf("must contain exactly %s items", param)
+		}
+		return "must be " + param
+	case "min":
+		switch {
+		case isString:
+			return fmt.Sprintf("must be at least %s characters long", param)
+		case isList:
+			return fmt.Sprintf("must contain at least %s items", param)
+		}
+		return "must be at least " + param
+	case "max":
+		switch {
+		case isString:
+			return fmt.Sprintf("must be at most %s characters long", param)
+		case isList:
+			return fmt.Sprintf("must contain at most %s items", param)
+		}
+		return "must be at most " + param
+	case "gt":
+		return "must be greater than " + param
+	case "gte":
+		return "must be at least " + param
+	case "lt":
+		return "must be less than " + param
+	case "lte":
+		return "must be at most " + param
+	default:
+		return fmt.Sprintf("failed the %s rule", fe.Tag())
+	}
+}
+
+// BindingError reports a failed ShouldBind call. Validation failures are
+// listed per field so clients can show them next to the right input; other
+// errors, such as malformed JSON, are reported as a bad request, and bodies
+// cut off by a size limit as too large.
+func BindingError(c *gin.Context, err error) {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		PayloadTooLarge(c, fmt.Sprintf("Request body must not exceed %d bytes", tooLarge.Limit))
+		return
+	}
+
+	fields := ValidationErrors(err)
+	if fields == nil {
+		BadRequest(c, err.Error())
+		return
+	}
+
+	c.JSON(http.StatusUnprocessableEntity, Response{
+		Success: false,
+		Error: &ErrorInfo{
+			Code:    CodeValidation,
+			Message: "Validation failed",
+			Fields:  fields,
+		},
+	})
+}
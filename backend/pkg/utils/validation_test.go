@@ -0,0 +1,126 @@
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+// signupRequest exercises the rules the conversion describes
+type signupRequest struct {
+	Email    string `json:"email" binding:"required,email"`
+	Password string `json:"password" binding:"required,min=8"`
+	Name     string `json:"name" binding:"required"`
+}
+
+// bindSignup posts body to a handler binding a signupRequest and returns the
+// recorded response
+func bindSignup(t *testing.T, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	router := gin.New()
+	router.POST("/signup", func(c *gin.Context) {
+		var req signupRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			BindingError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/signup", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestBindingErrorFields(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want []FieldError
+	}{
+		{
+			name: "missing required",
+			body: `{"email":"a@example.com","password":"longenough"}`,
+			want: []FieldError{{Field: "name", Rule: "required", Message: "is required"}},
+		},
+		{
+			name: "bad email",
+			body: `{"email":"not-an-email","password":"longenough","name":"A"}`,
+			want: []FieldError{{Field: "email", Rule: "email", Message: "must be a valid email address"}},
+		},
+		{
+			name: "short password",
+			body: `{"email":"a@example.com","password":"short","name":"A"}`,
+			want: []FieldError{{Field: "password", Rule: "min", Message: "must be at least 8 characters long"}},
+		},
+		{
+			name: "every field wrong",
+			body: `{"email":"nope","password":"short"}`,
+			want: []FieldError{
+				{Field: "email", Rule: "email", Message: "must be a valid email address"},
+				{Field: "password", Rule: "min", Message: "must be at least 8 characters long"},
+				{Field: "name", Rule: "required", Message: "is required"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := bindSignup(t, tt.body)
+			if w.Code != http.StatusUnprocessableEntity {
+				t.Fatalf("status = %d, want 422; body %s", w.Code, w.Body)
+			}
+
+			var resp Response
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response: %v", err)
+			}
+			if resp.Error == nil || resp.Error.Code != CodeValidation {
+				t.Fatalf("error = %+v, want code %s", resp.Error, CodeValidation)
+			}
+			if !reflect.DeepEqual(resp.Error.Fields, tt.want) {
+				t.Errorf("fields = %+v, want %+v", resp.Error.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindingErrorValidRequest(t *testing.T) {
+	w := bindSignup(t, `{"email":"a@example.com","password":"longenough","name":"A"}`)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, want 204; body %s", w.Code, w.Body)
+	}
+}
+
+func TestBindingErrorMalformedJSON(t *testing.T) {
+	w := bindSignup(t, `{"email":`)
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400; body %s", w.Code, w.Body)
+	}
+
+	var resp Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if resp.Error == nil || len(resp.Error.Fields) != 0 {
+		t.Errorf("error = %+v, want no field errors", resp.Error)
+	}
+}
+
+func TestValidationErrorsIgnoresOtherErrors(t *testing.T) {
+	if fields := ValidationErrors(http.ErrBodyNotAllowed); fields != nil {
+		t.Errorf("ValidationErrors = %+v, want nil", fields)
+	}
+}
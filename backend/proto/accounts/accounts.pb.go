@@ -152,18 +152,25 @@ func (AssetType) EnumDescriptor() ([]byte, []int) {
 }
 
 type Account struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Type          AccountType            `protobuf:"varint,4,opt,name=type,proto3,enum=accounts.AccountType" json:"type,omitempty"`
-	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
-	TotalBalance  float64                `protobuf:"fixed64,6,opt,name=total_balance,json=totalBalance,proto3" json:"total_balance,omitempty"`
-	Description   string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
-	Icon          string                 `protobuf:"bytes,8,opt,name=icon,proto3" json:"icon,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	SubAccounts   []*SubAccount          `protobuf:"bytes,11,rep,name=sub_accounts,json=subAccounts,proto3" json:"sub_accounts,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId       string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name         string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Type         AccountType            `protobuf:"varint,4,opt,name=type,proto3,enum=accounts.AccountType" json:"type,omitempty"`
+	Currency     string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	TotalBalance float64                `protobuf:"fixed64,6,opt,name=total_balance,json=totalBalance,proto3" json:"total_balance,omitempty"`
+	Description  string                 `protobuf:"bytes,7,opt,name=description,proto3" json:"description,omitempty"`
+	Icon         string                 `protobuf:"bytes,8,opt,name=icon,proto3" json:"icon,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	SubAccounts  []*SubAccount          `protobuf:"bytes,11,rep,name=sub_accounts,json=subAccounts,proto3" json:"sub_accounts,omitempty"`
+	Archived     bool                   `protobuf:"varint,12,opt,name=archived,proto3" json:"archived,omitempty"`
+	ArchivedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	Version      int64                  `protobuf:"varint,14,opt,name=version,proto3" json:"version,omitempty"`
+	// Liabilities, such as loans and credit cards, hold negative balances for
+	// the amount owed
+	IsLiability   bool  `protobuf:"varint,15,opt,name=is_liability,json=isLiability,proto3" json:"is_liability,omitempty"`
+	DisplayOrder  int32 `protobuf:"varint,16,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -275,6 +282,41 @@ func (x *Account) GetSubAccounts() []*SubAccount {
 	return nil
 }
 
+func (x *Account) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
+func (x *Account) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Account) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Account) GetIsLiability() bool {
+	if x != nil {
+		return x.IsLiability
+	}
+	return false
+}
+
+func (x *Account) GetDisplayOrder() int32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
 type SubAccount struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -288,6 +330,8 @@ type SubAccount struct {
 	Description   string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Version       int64                  `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`
+	DisplayOrder  int32                  `protobuf:"varint,13,opt,name=display_order,json=displayOrder,proto3" json:"display_order,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -399,6 +443,20 @@ func (x *SubAccount) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *SubAccount) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SubAccount) GetDisplayOrder() int32 {
+	if x != nil {
+		return x.DisplayOrder
+	}
+	return 0
+}
+
 type CreateAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -407,6 +465,7 @@ type CreateAccountRequest struct {
 	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
 	Description   string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
 	Icon          string                 `protobuf:"bytes,6,opt,name=icon,proto3" json:"icon,omitempty"`
+	IsLiability   bool                   `protobuf:"varint,7,opt,name=is_liability,json=isLiability,proto3" json:"is_liability,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -483,6 +542,13 @@ func (x *CreateAccountRequest) GetIcon() string {
 	return ""
 }
 
+func (x *CreateAccountRequest) GetIsLiability() bool {
+	if x != nil {
+		return x.IsLiability
+	}
+	return false
+}
+
 type GetAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -536,13 +602,14 @@ func (x *GetAccountRequest) GetUserId() string {
 }
 
 type ListAccountsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Type          AccountType            `protobuf:"varint,2,opt,name=type,proto3,enum=accounts.AccountType" json:"type,omitempty"`
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
-	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	UserId          string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Type            AccountType            `protobuf:"varint,2,opt,name=type,proto3,enum=accounts.AccountType" json:"type,omitempty"`
+	Page            int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize        int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	IncludeArchived bool                   `protobuf:"varint,5,opt,name=include_archived,json=includeArchived,proto3" json:"include_archived,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ListAccountsRequest) Reset() {
@@ -603,6 +670,13 @@ func (x *ListAccountsRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListAccountsRequest) GetIncludeArchived() bool {
+	if x != nil {
+		return x.IncludeArchived
+	}
+	return false
+}
+
 type ListAccountsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accounts      []*Account             `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
@@ -672,13 +746,16 @@ func (x *ListAccountsResponse) GetPageSize() int32 {
 }
 
 type UpdateAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Icon          string                 `protobuf:"bytes,5,opt,name=icon,proto3" json:"icon,omitempty"`
-	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Icon        string                 `protobuf:"bytes,5,opt,name=icon,proto3" json:"icon,omitempty"`
+	Currency    string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	// version is the version last read; the update fails with ABORTED if the
+	// account has changed since. Zero skips the check.
+	Version       int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -755,6 +832,13 @@ func (x *UpdateAccountRequest) GetCurrency() string {
 	return ""
 }
 
+func (x *UpdateAccountRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type DeleteAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -807,6 +891,166 @@ func (x *DeleteAccountRequest) GetUserId() string {
 	return ""
 }
 
+type ArchiveAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveAccountRequest) Reset() {
+	*x = ArchiveAccountRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveAccountRequest) ProtoMessage() {}
+
+func (x *ArchiveAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveAccountRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ArchiveAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ArchiveAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// UndeleteAccountRequest restores an account deleted within the last 30 days
+// along with the sub-accounts and assets deleted with it
+type UndeleteAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteAccountRequest) Reset() {
+	*x = UndeleteAccountRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteAccountRequest) ProtoMessage() {}
+
+func (x *UndeleteAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteAccountRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteAccountRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *UndeleteAccountRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UndeleteAccountRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+// ReorderAccountsRequest sets the display order of the user's unarchived
+// accounts; ids must list each of them exactly once
+type ReorderAccountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReorderAccountsRequest) Reset() {
+	*x = ReorderAccountsRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReorderAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReorderAccountsRequest) ProtoMessage() {}
+
+func (x *ReorderAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReorderAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ReorderAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ReorderAccountsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReorderAccountsRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
 type CreateSubAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
@@ -824,7 +1068,7 @@ type CreateSubAccountRequest struct {
 
 func (x *CreateSubAccountRequest) Reset() {
 	*x = CreateSubAccountRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[8]
+	mi := &file_proto_accounts_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -836,7 +1080,7 @@ func (x *CreateSubAccountRequest) String() string {
 func (*CreateSubAccountRequest) ProtoMessage() {}
 
 func (x *CreateSubAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[8]
+	mi := &file_proto_accounts_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -849,7 +1093,7 @@ func (x *CreateSubAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSubAccountRequest.ProtoReflect.Descriptor instead.
 func (*CreateSubAccountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{8}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateSubAccountRequest) GetAccountId() string {
@@ -925,7 +1169,7 @@ type GetSubAccountRequest struct {
 
 func (x *GetSubAccountRequest) Reset() {
 	*x = GetSubAccountRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[9]
+	mi := &file_proto_accounts_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -937,7 +1181,7 @@ func (x *GetSubAccountRequest) String() string {
 func (*GetSubAccountRequest) ProtoMessage() {}
 
 func (x *GetSubAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[9]
+	mi := &file_proto_accounts_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,7 +1194,7 @@ func (x *GetSubAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSubAccountRequest.ProtoReflect.Descriptor instead.
 func (*GetSubAccountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{9}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetSubAccountRequest) GetId() string {
@@ -978,7 +1222,7 @@ type ListSubAccountsRequest struct {
 
 func (x *ListSubAccountsRequest) Reset() {
 	*x = ListSubAccountsRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[10]
+	mi := &file_proto_accounts_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -990,7 +1234,7 @@ func (x *ListSubAccountsRequest) String() string {
 func (*ListSubAccountsRequest) ProtoMessage() {}
 
 func (x *ListSubAccountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[10]
+	mi := &file_proto_accounts_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1003,7 +1247,7 @@ func (x *ListSubAccountsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSubAccountsRequest.ProtoReflect.Descriptor instead.
 func (*ListSubAccountsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{10}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListSubAccountsRequest) GetAccountId() string {
@@ -1036,7 +1280,7 @@ type ListSubAccountsResponse struct {
 
 func (x *ListSubAccountsResponse) Reset() {
 	*x = ListSubAccountsResponse{}
-	mi := &file_proto_accounts_proto_msgTypes[11]
+	mi := &file_proto_accounts_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1048,7 +1292,7 @@ func (x *ListSubAccountsResponse) String() string {
 func (*ListSubAccountsResponse) ProtoMessage() {}
 
 func (x *ListSubAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[11]
+	mi := &file_proto_accounts_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1061,7 +1305,7 @@ func (x *ListSubAccountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSubAccountsResponse.ProtoReflect.Descriptor instead.
 func (*ListSubAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{11}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListSubAccountsResponse) GetSubAccounts() []*SubAccount {
@@ -1072,20 +1316,22 @@ func (x *ListSubAccountsResponse) GetSubAccounts() []*SubAccount {
 }
 
 type UpdateSubAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Balance       float64                `protobuf:"fixed64,5,opt,name=balance,proto3" json:"balance,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId      string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Balance     float64                `protobuf:"fixed64,5,opt,name=balance,proto3" json:"balance,omitempty"`
+	Quantity    float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// version works as in UpdateAccountRequest
+	Version       int64 `protobuf:"varint,7,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateSubAccountRequest) Reset() {
 	*x = UpdateSubAccountRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[12]
+	mi := &file_proto_accounts_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1097,7 +1343,7 @@ func (x *UpdateSubAccountRequest) String() string {
 func (*UpdateSubAccountRequest) ProtoMessage() {}
 
 func (x *UpdateSubAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[12]
+	mi := &file_proto_accounts_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1110,7 +1356,7 @@ func (x *UpdateSubAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateSubAccountRequest.ProtoReflect.Descriptor instead.
 func (*UpdateSubAccountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{12}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *UpdateSubAccountRequest) GetId() string {
@@ -1155,6 +1401,13 @@ func (x *UpdateSubAccountRequest) GetQuantity() float64 {
 	return 0
 }
 
+func (x *UpdateSubAccountRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type DeleteSubAccountRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1165,7 +1418,7 @@ type DeleteSubAccountRequest struct {
 
 func (x *DeleteSubAccountRequest) Reset() {
 	*x = DeleteSubAccountRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[13]
+	mi := &file_proto_accounts_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1177,7 +1430,7 @@ func (x *DeleteSubAccountRequest) String() string {
 func (*DeleteSubAccountRequest) ProtoMessage() {}
 
 func (x *DeleteSubAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[13]
+	mi := &file_proto_accounts_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1190,7 +1443,7 @@ func (x *DeleteSubAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteSubAccountRequest.ProtoReflect.Descriptor instead.
 func (*DeleteSubAccountRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{13}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *DeleteSubAccountRequest) GetId() string {
@@ -1207,31 +1460,32 @@ func (x *DeleteSubAccountRequest) GetUserId() string {
 	return ""
 }
 
-type UpdateSubAccountBalanceRequest struct {
+// ReorderSubAccountsRequest sets the display order of an account's
+// sub-accounts; ids must list each of them exactly once
+type ReorderSubAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Balance       float64                `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
-	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Ids           []string               `protobuf:"bytes,3,rep,name=ids,proto3" json:"ids,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateSubAccountBalanceRequest) Reset() {
-	*x = UpdateSubAccountBalanceRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[14]
+func (x *ReorderSubAccountsRequest) Reset() {
+	*x = ReorderSubAccountsRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateSubAccountBalanceRequest) String() string {
+func (x *ReorderSubAccountsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateSubAccountBalanceRequest) ProtoMessage() {}
+func (*ReorderSubAccountsRequest) ProtoMessage() {}
 
-func (x *UpdateSubAccountBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[14]
+func (x *ReorderSubAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1242,30 +1496,91 @@ func (x *UpdateSubAccountBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateSubAccountBalanceRequest.ProtoReflect.Descriptor instead.
-func (*UpdateSubAccountBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use ReorderSubAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ReorderSubAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *UpdateSubAccountBalanceRequest) GetId() string {
+func (x *ReorderSubAccountsRequest) GetAccountId() string {
 	if x != nil {
-		return x.Id
+		return x.AccountId
 	}
 	return ""
 }
 
-func (x *UpdateSubAccountBalanceRequest) GetUserId() string {
+func (x *ReorderSubAccountsRequest) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-func (x *UpdateSubAccountBalanceRequest) GetBalance() float64 {
+func (x *ReorderSubAccountsRequest) GetIds() []string {
 	if x != nil {
-		return x.Balance
+		return x.Ids
 	}
-	return 0
+	return nil
+}
+
+type UpdateSubAccountBalanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Balance       float64                `protobuf:"fixed64,3,opt,name=balance,proto3" json:"balance,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,4,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateSubAccountBalanceRequest) Reset() {
+	*x = UpdateSubAccountBalanceRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateSubAccountBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateSubAccountBalanceRequest) ProtoMessage() {}
+
+func (x *UpdateSubAccountBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateSubAccountBalanceRequest.ProtoReflect.Descriptor instead.
+func (*UpdateSubAccountBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *UpdateSubAccountBalanceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateSubAccountBalanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UpdateSubAccountBalanceRequest) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
 }
 
 func (x *UpdateSubAccountBalanceRequest) GetQuantity() float64 {
@@ -1275,6 +1590,504 @@ func (x *UpdateSubAccountBalanceRequest) GetQuantity() float64 {
 	return 0
 }
 
+// AdjustSubAccountBalanceRequest adds delta to a sub-account balance in one
+// statement, so concurrent adjustments don't overwrite each other
+type AdjustSubAccountBalanceRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Delta  float64                `protobuf:"fixed64,3,opt,name=delta,proto3" json:"delta,omitempty"`
+	// idempotency_key makes retries safe: an adjustment whose key was already
+	// applied is not applied again
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AdjustSubAccountBalanceRequest) Reset() {
+	*x = AdjustSubAccountBalanceRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustSubAccountBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustSubAccountBalanceRequest) ProtoMessage() {}
+
+func (x *AdjustSubAccountBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustSubAccountBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AdjustSubAccountBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *AdjustSubAccountBalanceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AdjustSubAccountBalanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AdjustSubAccountBalanceRequest) GetDelta() float64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *AdjustSubAccountBalanceRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+// BulkUpdateSubAccountBalancesRequest sets many sub-account balances in one
+// transaction; each update succeeds or fails on its own
+type BulkUpdateSubAccountBalancesRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	UserId        string                     `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Updates       []*SubAccountBalanceUpdate `protobuf:"bytes,2,rep,name=updates,proto3" json:"updates,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateSubAccountBalancesRequest) Reset() {
+	*x = BulkUpdateSubAccountBalancesRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateSubAccountBalancesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateSubAccountBalancesRequest) ProtoMessage() {}
+
+func (x *BulkUpdateSubAccountBalancesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateSubAccountBalancesRequest.ProtoReflect.Descriptor instead.
+func (*BulkUpdateSubAccountBalancesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BulkUpdateSubAccountBalancesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *BulkUpdateSubAccountBalancesRequest) GetUpdates() []*SubAccountBalanceUpdate {
+	if x != nil {
+		return x.Updates
+	}
+	return nil
+}
+
+type SubAccountBalanceUpdate struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Balance       float64                `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Version       int64                  `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubAccountBalanceUpdate) Reset() {
+	*x = SubAccountBalanceUpdate{}
+	mi := &file_proto_accounts_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubAccountBalanceUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubAccountBalanceUpdate) ProtoMessage() {}
+
+func (x *SubAccountBalanceUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubAccountBalanceUpdate.ProtoReflect.Descriptor instead.
+func (*SubAccountBalanceUpdate) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SubAccountBalanceUpdate) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubAccountBalanceUpdate) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *SubAccountBalanceUpdate) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SubAccountBalanceUpdate) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type BulkUpdateSubAccountBalancesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One result per update, in request order
+	Results       []*SubAccountBalanceResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Updated       int32                      `protobuf:"varint,2,opt,name=updated,proto3" json:"updated,omitempty"`
+	Failed        int32                      `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkUpdateSubAccountBalancesResponse) Reset() {
+	*x = BulkUpdateSubAccountBalancesResponse{}
+	mi := &file_proto_accounts_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkUpdateSubAccountBalancesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkUpdateSubAccountBalancesResponse) ProtoMessage() {}
+
+func (x *BulkUpdateSubAccountBalancesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkUpdateSubAccountBalancesResponse.ProtoReflect.Descriptor instead.
+func (*BulkUpdateSubAccountBalancesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BulkUpdateSubAccountBalancesResponse) GetResults() []*SubAccountBalanceResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkUpdateSubAccountBalancesResponse) GetUpdated() int32 {
+	if x != nil {
+		return x.Updated
+	}
+	return 0
+}
+
+func (x *BulkUpdateSubAccountBalancesResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+type SubAccountBalanceResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	SubAccount    *SubAccount            `protobuf:"bytes,4,opt,name=sub_account,json=subAccount,proto3" json:"sub_account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubAccountBalanceResult) Reset() {
+	*x = SubAccountBalanceResult{}
+	mi := &file_proto_accounts_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubAccountBalanceResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubAccountBalanceResult) ProtoMessage() {}
+
+func (x *SubAccountBalanceResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubAccountBalanceResult.ProtoReflect.Descriptor instead.
+func (*SubAccountBalanceResult) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *SubAccountBalanceResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SubAccountBalanceResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubAccountBalanceResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SubAccountBalanceResult) GetSubAccount() *SubAccount {
+	if x != nil {
+		return x.SubAccount
+	}
+	return nil
+}
+
+// GetBalanceHistoryRequest asks for a sub-account's daily closing balances.
+// Unset dates leave the range open; limit caps the points returned by
+// downsampling, with 0 meaning the server maximum.
+type GetBalanceHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceHistoryRequest) Reset() {
+	*x = GetBalanceHistoryRequest{}
+	mi := &file_proto_accounts_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceHistoryRequest) ProtoMessage() {}
+
+func (x *GetBalanceHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBalanceHistoryRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetBalanceHistoryRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetBalanceHistoryRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetBalanceHistoryRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetBalanceHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BalanceHistoryPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Balance       float64                `protobuf:"fixed64,2,opt,name=balance,proto3" json:"balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceHistoryPoint) Reset() {
+	*x = BalanceHistoryPoint{}
+	mi := &file_proto_accounts_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceHistoryPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceHistoryPoint) ProtoMessage() {}
+
+func (x *BalanceHistoryPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceHistoryPoint.ProtoReflect.Descriptor instead.
+func (*BalanceHistoryPoint) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *BalanceHistoryPoint) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *BalanceHistoryPoint) GetBalance() float64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+type BalanceHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Points        []*BalanceHistoryPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BalanceHistoryResponse) Reset() {
+	*x = BalanceHistoryResponse{}
+	mi := &file_proto_accounts_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BalanceHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BalanceHistoryResponse) ProtoMessage() {}
+
+func (x *BalanceHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_accounts_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BalanceHistoryResponse.ProtoReflect.Descriptor instead.
+func (*BalanceHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_accounts_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *BalanceHistoryResponse) GetPoints() []*BalanceHistoryPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
 type GetUserNetWorthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -1285,7 +2098,7 @@ type GetUserNetWorthRequest struct {
 
 func (x *GetUserNetWorthRequest) Reset() {
 	*x = GetUserNetWorthRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[15]
+	mi := &file_proto_accounts_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1297,7 +2110,7 @@ func (x *GetUserNetWorthRequest) String() string {
 func (*GetUserNetWorthRequest) ProtoMessage() {}
 
 func (x *GetUserNetWorthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[15]
+	mi := &file_proto_accounts_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1310,7 +2123,7 @@ func (x *GetUserNetWorthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserNetWorthRequest.ProtoReflect.Descriptor instead.
 func (*GetUserNetWorthRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{15}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetUserNetWorthRequest) GetUserId() string {
@@ -1334,13 +2147,23 @@ type NetWorthResponse struct {
 	ByAccountType map[string]float64     `protobuf:"bytes,3,rep,name=by_account_type,json=byAccountType,proto3" json:"by_account_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	ByAssetType   map[string]float64     `protobuf:"bytes,4,rep,name=by_asset_type,json=byAssetType,proto3" json:"by_asset_type,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	CalculatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=calculated_at,json=calculatedAt,proto3" json:"calculated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// Balances in their own currencies
+	ByCurrency map[string]float64 `protobuf:"bytes,6,rep,name=by_currency,json=byCurrency,proto3" json:"by_currency,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// The same balances converted to currency
+	ConvertedByCurrency map[string]float64 `protobuf:"bytes,7,rep,name=converted_by_currency,json=convertedByCurrency,proto3" json:"converted_by_currency,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
+	// Currencies with no rate to currency, left out of total_net_worth
+	UnconvertedCurrencies []string `protobuf:"bytes,8,rep,name=unconverted_currencies,json=unconvertedCurrencies,proto3" json:"unconverted_currencies,omitempty"`
+	// Asset accounts and the amount owed on liability accounts, in currency;
+	// total_net_worth is total_assets minus total_liabilities
+	TotalAssets      float64 `protobuf:"fixed64,9,opt,name=total_assets,json=totalAssets,proto3" json:"total_assets,omitempty"`
+	TotalLiabilities float64 `protobuf:"fixed64,10,opt,name=total_liabilities,json=totalLiabilities,proto3" json:"total_liabilities,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *NetWorthResponse) Reset() {
 	*x = NetWorthResponse{}
-	mi := &file_proto_accounts_proto_msgTypes[16]
+	mi := &file_proto_accounts_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1352,7 +2175,7 @@ func (x *NetWorthResponse) String() string {
 func (*NetWorthResponse) ProtoMessage() {}
 
 func (x *NetWorthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[16]
+	mi := &file_proto_accounts_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1365,7 +2188,7 @@ func (x *NetWorthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use NetWorthResponse.ProtoReflect.Descriptor instead.
 func (*NetWorthResponse) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{16}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *NetWorthResponse) GetTotalNetWorth() float64 {
@@ -1403,6 +2226,41 @@ func (x *NetWorthResponse) GetCalculatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *NetWorthResponse) GetByCurrency() map[string]float64 {
+	if x != nil {
+		return x.ByCurrency
+	}
+	return nil
+}
+
+func (x *NetWorthResponse) GetConvertedByCurrency() map[string]float64 {
+	if x != nil {
+		return x.ConvertedByCurrency
+	}
+	return nil
+}
+
+func (x *NetWorthResponse) GetUnconvertedCurrencies() []string {
+	if x != nil {
+		return x.UnconvertedCurrencies
+	}
+	return nil
+}
+
+func (x *NetWorthResponse) GetTotalAssets() float64 {
+	if x != nil {
+		return x.TotalAssets
+	}
+	return 0
+}
+
+func (x *NetWorthResponse) GetTotalLiabilities() float64 {
+	if x != nil {
+		return x.TotalLiabilities
+	}
+	return 0
+}
+
 type GetAccountsSummaryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -1412,7 +2270,7 @@ type GetAccountsSummaryRequest struct {
 
 func (x *GetAccountsSummaryRequest) Reset() {
 	*x = GetAccountsSummaryRequest{}
-	mi := &file_proto_accounts_proto_msgTypes[17]
+	mi := &file_proto_accounts_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1424,7 +2282,7 @@ func (x *GetAccountsSummaryRequest) String() string {
 func (*GetAccountsSummaryRequest) ProtoMessage() {}
 
 func (x *GetAccountsSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[17]
+	mi := &file_proto_accounts_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1437,7 +2295,7 @@ func (x *GetAccountsSummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAccountsSummaryRequest.ProtoReflect.Descriptor instead.
 func (*GetAccountsSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{17}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetAccountsSummaryRequest) GetUserId() string {
@@ -1458,7 +2316,7 @@ type AccountsSummaryResponse struct {
 
 func (x *AccountsSummaryResponse) Reset() {
 	*x = AccountsSummaryResponse{}
-	mi := &file_proto_accounts_proto_msgTypes[18]
+	mi := &file_proto_accounts_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1470,7 +2328,7 @@ func (x *AccountsSummaryResponse) String() string {
 func (*AccountsSummaryResponse) ProtoMessage() {}
 
 func (x *AccountsSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[18]
+	mi := &file_proto_accounts_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1483,7 +2341,7 @@ func (x *AccountsSummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountsSummaryResponse.ProtoReflect.Descriptor instead.
 func (*AccountsSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{18}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *AccountsSummaryResponse) GetTotalAccounts() int32 {
@@ -1519,7 +2377,7 @@ type AccountTypeSummary struct {
 
 func (x *AccountTypeSummary) Reset() {
 	*x = AccountTypeSummary{}
-	mi := &file_proto_accounts_proto_msgTypes[19]
+	mi := &file_proto_accounts_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1531,7 +2389,7 @@ func (x *AccountTypeSummary) String() string {
 func (*AccountTypeSummary) ProtoMessage() {}
 
 func (x *AccountTypeSummary) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_accounts_proto_msgTypes[19]
+	mi := &file_proto_accounts_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1544,7 +2402,7 @@ func (x *AccountTypeSummary) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AccountTypeSummary.ProtoReflect.Descriptor instead.
 func (*AccountTypeSummary) Descriptor() ([]byte, []int) {
-	return file_proto_accounts_proto_rawDescGZIP(), []int{19}
+	return file_proto_accounts_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *AccountTypeSummary) GetType() AccountType {
@@ -1579,7 +2437,7 @@ var File_proto_accounts_proto protoreflect.FileDescriptor
 
 const file_proto_accounts_proto_rawDesc = "" +
 	"\n" +
-	"\x14proto/accounts.proto\x12\baccounts\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x97\x03\n" +
+	"\x14proto/accounts.proto\x12\baccounts\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xd2\x04\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
@@ -1594,7 +2452,13 @@ const file_proto_accounts_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
-	"\fsub_accounts\x18\v \x03(\v2\x14.accounts.SubAccountR\vsubAccounts\"\x85\x03\n" +
+	"\fsub_accounts\x18\v \x03(\v2\x14.accounts.SubAccountR\vsubAccounts\x12\x1a\n" +
+	"\barchived\x18\f \x01(\bR\barchived\x12;\n" +
+	"\varchived_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x12\x18\n" +
+	"\aversion\x18\x0e \x01(\x03R\aversion\x12!\n" +
+	"\fis_liability\x18\x0f \x01(\bR\visLiability\x12#\n" +
+	"\rdisplay_order\x18\x10 \x01(\x05R\fdisplayOrder\"\xc4\x03\n" +
 	"\n" +
 	"SubAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
@@ -1612,37 +2476,51 @@ const file_proto_accounts_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc0\x01\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x18\n" +
+	"\aversion\x18\f \x01(\x03R\aversion\x12#\n" +
+	"\rdisplay_order\x18\r \x01(\x05R\fdisplayOrder\"\xe3\x01\n" +
 	"\x14CreateAccountRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12)\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x15.accounts.AccountTypeR\x04type\x12\x1a\n" +
 	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12\x12\n" +
-	"\x04icon\x18\x06 \x01(\tR\x04icon\"<\n" +
+	"\x04icon\x18\x06 \x01(\tR\x04icon\x12!\n" +
+	"\fis_liability\x18\a \x01(\bR\visLiability\"<\n" +
 	"\x11GetAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x8a\x01\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xb5\x01\n" +
 	"\x13ListAccountsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12)\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x15.accounts.AccountTypeR\x04type\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x8c\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12)\n" +
+	"\x10include_archived\x18\x05 \x01(\bR\x0fincludeArchived\"\x8c\x01\n" +
 	"\x14ListAccountsResponse\x12-\n" +
 	"\baccounts\x18\x01 \x03(\v2\x11.accounts.AccountR\baccounts\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
 	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xa5\x01\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\xbf\x01\n" +
 	"\x14UpdateAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x12\n" +
 	"\x04icon\x18\x05 \x01(\tR\x04icon\x12\x1a\n" +
-	"\bcurrency\x18\x06 \x01(\tR\bcurrency\"?\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\"?\n" +
 	"\x14DeleteAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xa5\x02\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"@\n" +
+	"\x15ArchiveAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"A\n" +
+	"\x16UndeleteAccountRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"C\n" +
+	"\x16ReorderAccountsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"\xa5\x02\n" +
 	"\x17CreateSubAccountRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x17\n" +
@@ -1665,36 +2543,90 @@ const file_proto_accounts_proto_rawDesc = "" +
 	"\n" +
 	"asset_type\x18\x03 \x01(\x0e2\x13.accounts.AssetTypeR\tassetType\"R\n" +
 	"\x17ListSubAccountsResponse\x127\n" +
-	"\fsub_accounts\x18\x01 \x03(\v2\x14.accounts.SubAccountR\vsubAccounts\"\xae\x01\n" +
+	"\fsub_accounts\x18\x01 \x03(\v2\x14.accounts.SubAccountR\vsubAccounts\"\xc8\x01\n" +
 	"\x17UpdateSubAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
 	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x18\n" +
 	"\abalance\x18\x05 \x01(\x01R\abalance\x12\x1a\n" +
-	"\bquantity\x18\x06 \x01(\x01R\bquantity\"B\n" +
+	"\bquantity\x18\x06 \x01(\x01R\bquantity\x12\x18\n" +
+	"\aversion\x18\a \x01(\x03R\aversion\"B\n" +
 	"\x17DeleteSubAccountRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x7f\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"e\n" +
+	"\x19ReorderSubAccountsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x10\n" +
+	"\x03ids\x18\x03 \x03(\tR\x03ids\"\x7f\n" +
 	"\x1eUpdateSubAccountBalanceRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
 	"\abalance\x18\x03 \x01(\x01R\abalance\x12\x1a\n" +
-	"\bquantity\x18\x04 \x01(\x01R\bquantity\"V\n" +
+	"\bquantity\x18\x04 \x01(\x01R\bquantity\"\x88\x01\n" +
+	"\x1eAdjustSubAccountBalanceRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x01R\x05delta\x12'\n" +
+	"\x0fidempotency_key\x18\x04 \x01(\tR\x0eidempotencyKey\"{\n" +
+	"#BulkUpdateSubAccountBalancesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12;\n" +
+	"\aupdates\x18\x02 \x03(\v2!.accounts.SubAccountBalanceUpdateR\aupdates\"y\n" +
+	"\x17SubAccountBalanceUpdate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x01R\abalance\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x01R\bquantity\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"\x95\x01\n" +
+	"$BulkUpdateSubAccountBalancesResponse\x12;\n" +
+	"\aresults\x18\x01 \x03(\v2!.accounts.SubAccountBalanceResultR\aresults\x12\x18\n" +
+	"\aupdated\x18\x02 \x01(\x05R\aupdated\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\x90\x01\n" +
+	"\x17SubAccountBalanceResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x125\n" +
+	"\vsub_account\x18\x04 \x01(\v2\x14.accounts.SubAccountR\n" +
+	"subAccount\"\xcb\x01\n" +
+	"\x18GetBalanceHistoryRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\"_\n" +
+	"\x13BalanceHistoryPoint\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x18\n" +
+	"\abalance\x18\x02 \x01(\x01R\abalance\"O\n" +
+	"\x16BalanceHistoryResponse\x125\n" +
+	"\x06points\x18\x01 \x03(\v2\x1d.accounts.BalanceHistoryPointR\x06points\"V\n" +
 	"\x16GetUserNetWorthRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
-	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\"\xc1\x03\n" +
+	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\"\x85\a\n" +
 	"\x10NetWorthResponse\x12&\n" +
 	"\x0ftotal_net_worth\x18\x01 \x01(\x01R\rtotalNetWorth\x12\x1a\n" +
 	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12U\n" +
 	"\x0fby_account_type\x18\x03 \x03(\v2-.accounts.NetWorthResponse.ByAccountTypeEntryR\rbyAccountType\x12O\n" +
 	"\rby_asset_type\x18\x04 \x03(\v2+.accounts.NetWorthResponse.ByAssetTypeEntryR\vbyAssetType\x12?\n" +
-	"\rcalculated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\x1a@\n" +
+	"\rcalculated_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\x12K\n" +
+	"\vby_currency\x18\x06 \x03(\v2*.accounts.NetWorthResponse.ByCurrencyEntryR\n" +
+	"byCurrency\x12g\n" +
+	"\x15converted_by_currency\x18\a \x03(\v23.accounts.NetWorthResponse.ConvertedByCurrencyEntryR\x13convertedByCurrency\x125\n" +
+	"\x16unconverted_currencies\x18\b \x03(\tR\x15unconvertedCurrencies\x12!\n" +
+	"\ftotal_assets\x18\t \x01(\x01R\vtotalAssets\x12+\n" +
+	"\x11total_liabilities\x18\n" +
+	" \x01(\x01R\x10totalLiabilities\x1a@\n" +
 	"\x12ByAccountTypeEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a>\n" +
 	"\x10ByAssetTypeEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a=\n" +
+	"\x0fByCurrencyEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1aF\n" +
+	"\x18ConvertedByCurrencyEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"4\n" +
 	"\x19GetAccountsSummaryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa5\x01\n" +
@@ -1724,20 +2656,28 @@ const file_proto_accounts_proto_rawDesc = "" +
 	"\x0eASSET_TYPE_ETF\x10\x05\x12\x1a\n" +
 	"\x16ASSET_TYPE_REAL_ESTATE\x10\x06\x12\x14\n" +
 	"\x10ASSET_TYPE_BONDS\x10\a\x12\x14\n" +
-	"\x10ASSET_TYPE_OTHER\x10\b2\x81\b\n" +
+	"\x10ASSET_TYPE_OTHER\x10\b2\xb6\r\n" +
 	"\x0fAccountsService\x12B\n" +
 	"\rCreateAccount\x12\x1e.accounts.CreateAccountRequest\x1a\x11.accounts.Account\x12<\n" +
 	"\n" +
 	"GetAccount\x12\x1b.accounts.GetAccountRequest\x1a\x11.accounts.Account\x12M\n" +
 	"\fListAccounts\x12\x1d.accounts.ListAccountsRequest\x1a\x1e.accounts.ListAccountsResponse\x12B\n" +
 	"\rUpdateAccount\x12\x1e.accounts.UpdateAccountRequest\x1a\x11.accounts.Account\x12G\n" +
-	"\rDeleteAccount\x12\x1e.accounts.DeleteAccountRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
+	"\rDeleteAccount\x12\x1e.accounts.DeleteAccountRequest\x1a\x16.google.protobuf.Empty\x12D\n" +
+	"\x0eArchiveAccount\x12\x1f.accounts.ArchiveAccountRequest\x1a\x11.accounts.Account\x12F\n" +
+	"\x10UnarchiveAccount\x12\x1f.accounts.ArchiveAccountRequest\x1a\x11.accounts.Account\x12F\n" +
+	"\x0fUndeleteAccount\x12 .accounts.UndeleteAccountRequest\x1a\x11.accounts.Account\x12K\n" +
+	"\x0fReorderAccounts\x12 .accounts.ReorderAccountsRequest\x1a\x16.google.protobuf.Empty\x12K\n" +
 	"\x10CreateSubAccount\x12!.accounts.CreateSubAccountRequest\x1a\x14.accounts.SubAccount\x12E\n" +
 	"\rGetSubAccount\x12\x1e.accounts.GetSubAccountRequest\x1a\x14.accounts.SubAccount\x12V\n" +
 	"\x0fListSubAccounts\x12 .accounts.ListSubAccountsRequest\x1a!.accounts.ListSubAccountsResponse\x12K\n" +
 	"\x10UpdateSubAccount\x12!.accounts.UpdateSubAccountRequest\x1a\x14.accounts.SubAccount\x12M\n" +
-	"\x10DeleteSubAccount\x12!.accounts.DeleteSubAccountRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
-	"\x17UpdateSubAccountBalance\x12(.accounts.UpdateSubAccountBalanceRequest\x1a\x14.accounts.SubAccount\x12O\n" +
+	"\x10DeleteSubAccount\x12!.accounts.DeleteSubAccountRequest\x1a\x16.google.protobuf.Empty\x12Q\n" +
+	"\x12ReorderSubAccounts\x12#.accounts.ReorderSubAccountsRequest\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x17UpdateSubAccountBalance\x12(.accounts.UpdateSubAccountBalanceRequest\x1a\x14.accounts.SubAccount\x12Y\n" +
+	"\x17AdjustSubAccountBalance\x12(.accounts.AdjustSubAccountBalanceRequest\x1a\x14.accounts.SubAccount\x12}\n" +
+	"\x1cBulkUpdateSubAccountBalances\x12-.accounts.BulkUpdateSubAccountBalancesRequest\x1a..accounts.BulkUpdateSubAccountBalancesResponse\x12c\n" +
+	"\x1bGetSubAccountBalanceHistory\x12\".accounts.GetBalanceHistoryRequest\x1a .accounts.BalanceHistoryResponse\x12O\n" +
 	"\x0fGetUserNetWorth\x12 .accounts.GetUserNetWorthRequest\x1a\x1a.accounts.NetWorthResponse\x12\\\n" +
 	"\x12GetAccountsSummary\x12#.accounts.GetAccountsSummaryRequest\x1a!.accounts.AccountsSummaryResponseB;Z9github.com/radmickey/money-control/backend/proto/accountsb\x06proto3"
 
@@ -1754,85 +2694,125 @@ func file_proto_accounts_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_accounts_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_proto_accounts_proto_msgTypes = make([]protoimpl.MessageInfo, 36)
 var file_proto_accounts_proto_goTypes = []any{
-	(AccountType)(0),                       // 0: accounts.AccountType
-	(AssetType)(0),                         // 1: accounts.AssetType
-	(*Account)(nil),                        // 2: accounts.Account
-	(*SubAccount)(nil),                     // 3: accounts.SubAccount
-	(*CreateAccountRequest)(nil),           // 4: accounts.CreateAccountRequest
-	(*GetAccountRequest)(nil),              // 5: accounts.GetAccountRequest
-	(*ListAccountsRequest)(nil),            // 6: accounts.ListAccountsRequest
-	(*ListAccountsResponse)(nil),           // 7: accounts.ListAccountsResponse
-	(*UpdateAccountRequest)(nil),           // 8: accounts.UpdateAccountRequest
-	(*DeleteAccountRequest)(nil),           // 9: accounts.DeleteAccountRequest
-	(*CreateSubAccountRequest)(nil),        // 10: accounts.CreateSubAccountRequest
-	(*GetSubAccountRequest)(nil),           // 11: accounts.GetSubAccountRequest
-	(*ListSubAccountsRequest)(nil),         // 12: accounts.ListSubAccountsRequest
-	(*ListSubAccountsResponse)(nil),        // 13: accounts.ListSubAccountsResponse
-	(*UpdateSubAccountRequest)(nil),        // 14: accounts.UpdateSubAccountRequest
-	(*DeleteSubAccountRequest)(nil),        // 15: accounts.DeleteSubAccountRequest
-	(*UpdateSubAccountBalanceRequest)(nil), // 16: accounts.UpdateSubAccountBalanceRequest
-	(*GetUserNetWorthRequest)(nil),         // 17: accounts.GetUserNetWorthRequest
-	(*NetWorthResponse)(nil),               // 18: accounts.NetWorthResponse
-	(*GetAccountsSummaryRequest)(nil),      // 19: accounts.GetAccountsSummaryRequest
-	(*AccountsSummaryResponse)(nil),        // 20: accounts.AccountsSummaryResponse
-	(*AccountTypeSummary)(nil),             // 21: accounts.AccountTypeSummary
-	nil,                                    // 22: accounts.NetWorthResponse.ByAccountTypeEntry
-	nil,                                    // 23: accounts.NetWorthResponse.ByAssetTypeEntry
-	(*timestamppb.Timestamp)(nil),          // 24: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                  // 25: google.protobuf.Empty
+	(AccountType)(0),                             // 0: accounts.AccountType
+	(AssetType)(0),                               // 1: accounts.AssetType
+	(*Account)(nil),                              // 2: accounts.Account
+	(*SubAccount)(nil),                           // 3: accounts.SubAccount
+	(*CreateAccountRequest)(nil),                 // 4: accounts.CreateAccountRequest
+	(*GetAccountRequest)(nil),                    // 5: accounts.GetAccountRequest
+	(*ListAccountsRequest)(nil),                  // 6: accounts.ListAccountsRequest
+	(*ListAccountsResponse)(nil),                 // 7: accounts.ListAccountsResponse
+	(*UpdateAccountRequest)(nil),                 // 8: accounts.UpdateAccountRequest
+	(*DeleteAccountRequest)(nil),                 // 9: accounts.DeleteAccountRequest
+	(*ArchiveAccountRequest)(nil),                // 10: accounts.ArchiveAccountRequest
+	(*UndeleteAccountRequest)(nil),               // 11: accounts.UndeleteAccountRequest
+	(*ReorderAccountsRequest)(nil),               // 12: accounts.ReorderAccountsRequest
+	(*CreateSubAccountRequest)(nil),              // 13: accounts.CreateSubAccountRequest
+	(*GetSubAccountRequest)(nil),                 // 14: accounts.GetSubAccountRequest
+	(*ListSubAccountsRequest)(nil),               // 15: accounts.ListSubAccountsRequest
+	(*ListSubAccountsResponse)(nil),              // 16: accounts.ListSubAccountsResponse
+	(*UpdateSubAccountRequest)(nil),              // 17: accounts.UpdateSubAccountRequest
+	(*DeleteSubAccountRequest)(nil),              // 18: accounts.DeleteSubAccountRequest
+	(*ReorderSubAccountsRequest)(nil),            // 19: accounts.ReorderSubAccountsRequest
+	(*UpdateSubAccountBalanceRequest)(nil),       // 20: accounts.UpdateSubAccountBalanceRequest
+	(*AdjustSubAccountBalanceRequest)(nil),       // 21: accounts.AdjustSubAccountBalanceRequest
+	(*BulkUpdateSubAccountBalancesRequest)(nil),  // 22: accounts.BulkUpdateSubAccountBalancesRequest
+	(*SubAccountBalanceUpdate)(nil),              // 23: accounts.SubAccountBalanceUpdate
+	(*BulkUpdateSubAccountBalancesResponse)(nil), // 24: accounts.BulkUpdateSubAccountBalancesResponse
+	(*SubAccountBalanceResult)(nil),              // 25: accounts.SubAccountBalanceResult
+	(*GetBalanceHistoryRequest)(nil),             // 26: accounts.GetBalanceHistoryRequest
+	(*BalanceHistoryPoint)(nil),                  // 27: accounts.BalanceHistoryPoint
+	(*BalanceHistoryResponse)(nil),               // 28: accounts.BalanceHistoryResponse
+	(*GetUserNetWorthRequest)(nil),               // 29: accounts.GetUserNetWorthRequest
+	(*NetWorthResponse)(nil),                     // 30: accounts.NetWorthResponse
+	(*GetAccountsSummaryRequest)(nil),            // 31: accounts.GetAccountsSummaryRequest
+	(*AccountsSummaryResponse)(nil),              // 32: accounts.AccountsSummaryResponse
+	(*AccountTypeSummary)(nil),                   // 33: accounts.AccountTypeSummary
+	nil,                                          // 34: accounts.NetWorthResponse.ByAccountTypeEntry
+	nil,                                          // 35: accounts.NetWorthResponse.ByAssetTypeEntry
+	nil,                                          // 36: accounts.NetWorthResponse.ByCurrencyEntry
+	nil,                                          // 37: accounts.NetWorthResponse.ConvertedByCurrencyEntry
+	(*timestamppb.Timestamp)(nil),                // 38: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                        // 39: google.protobuf.Empty
 }
 var file_proto_accounts_proto_depIdxs = []int32{
 	0,  // 0: accounts.Account.type:type_name -> accounts.AccountType
-	24, // 1: accounts.Account.created_at:type_name -> google.protobuf.Timestamp
-	24, // 2: accounts.Account.updated_at:type_name -> google.protobuf.Timestamp
+	38, // 1: accounts.Account.created_at:type_name -> google.protobuf.Timestamp
+	38, // 2: accounts.Account.updated_at:type_name -> google.protobuf.Timestamp
 	3,  // 3: accounts.Account.sub_accounts:type_name -> accounts.SubAccount
-	1,  // 4: accounts.SubAccount.asset_type:type_name -> accounts.AssetType
-	24, // 5: accounts.SubAccount.created_at:type_name -> google.protobuf.Timestamp
-	24, // 6: accounts.SubAccount.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 7: accounts.CreateAccountRequest.type:type_name -> accounts.AccountType
-	0,  // 8: accounts.ListAccountsRequest.type:type_name -> accounts.AccountType
-	2,  // 9: accounts.ListAccountsResponse.accounts:type_name -> accounts.Account
-	1,  // 10: accounts.CreateSubAccountRequest.asset_type:type_name -> accounts.AssetType
-	1,  // 11: accounts.ListSubAccountsRequest.asset_type:type_name -> accounts.AssetType
-	3,  // 12: accounts.ListSubAccountsResponse.sub_accounts:type_name -> accounts.SubAccount
-	22, // 13: accounts.NetWorthResponse.by_account_type:type_name -> accounts.NetWorthResponse.ByAccountTypeEntry
-	23, // 14: accounts.NetWorthResponse.by_asset_type:type_name -> accounts.NetWorthResponse.ByAssetTypeEntry
-	24, // 15: accounts.NetWorthResponse.calculated_at:type_name -> google.protobuf.Timestamp
-	21, // 16: accounts.AccountsSummaryResponse.by_type:type_name -> accounts.AccountTypeSummary
-	0,  // 17: accounts.AccountTypeSummary.type:type_name -> accounts.AccountType
-	4,  // 18: accounts.AccountsService.CreateAccount:input_type -> accounts.CreateAccountRequest
-	5,  // 19: accounts.AccountsService.GetAccount:input_type -> accounts.GetAccountRequest
-	6,  // 20: accounts.AccountsService.ListAccounts:input_type -> accounts.ListAccountsRequest
-	8,  // 21: accounts.AccountsService.UpdateAccount:input_type -> accounts.UpdateAccountRequest
-	9,  // 22: accounts.AccountsService.DeleteAccount:input_type -> accounts.DeleteAccountRequest
-	10, // 23: accounts.AccountsService.CreateSubAccount:input_type -> accounts.CreateSubAccountRequest
-	11, // 24: accounts.AccountsService.GetSubAccount:input_type -> accounts.GetSubAccountRequest
-	12, // 25: accounts.AccountsService.ListSubAccounts:input_type -> accounts.ListSubAccountsRequest
-	14, // 26: accounts.AccountsService.UpdateSubAccount:input_type -> accounts.UpdateSubAccountRequest
-	15, // 27: accounts.AccountsService.DeleteSubAccount:input_type -> accounts.DeleteSubAccountRequest
-	16, // 28: accounts.AccountsService.UpdateSubAccountBalance:input_type -> accounts.UpdateSubAccountBalanceRequest
-	17, // 29: accounts.AccountsService.GetUserNetWorth:input_type -> accounts.GetUserNetWorthRequest
-	19, // 30: accounts.AccountsService.GetAccountsSummary:input_type -> accounts.GetAccountsSummaryRequest
-	2,  // 31: accounts.AccountsService.CreateAccount:output_type -> accounts.Account
-	2,  // 32: accounts.AccountsService.GetAccount:output_type -> accounts.Account
-	7,  // 33: accounts.AccountsService.ListAccounts:output_type -> accounts.ListAccountsResponse
-	2,  // 34: accounts.AccountsService.UpdateAccount:output_type -> accounts.Account
-	25, // 35: accounts.AccountsService.DeleteAccount:output_type -> google.protobuf.Empty
-	3,  // 36: accounts.AccountsService.CreateSubAccount:output_type -> accounts.SubAccount
-	3,  // 37: accounts.AccountsService.GetSubAccount:output_type -> accounts.SubAccount
-	13, // 38: accounts.AccountsService.ListSubAccounts:output_type -> accounts.ListSubAccountsResponse
-	3,  // 39: accounts.AccountsService.UpdateSubAccount:output_type -> accounts.SubAccount
-	25, // 40: accounts.AccountsService.DeleteSubAccount:output_type -> google.protobuf.Empty
-	3,  // 41: accounts.AccountsService.UpdateSubAccountBalance:output_type -> accounts.SubAccount
-	18, // 42: accounts.AccountsService.GetUserNetWorth:output_type -> accounts.NetWorthResponse
-	20, // 43: accounts.AccountsService.GetAccountsSummary:output_type -> accounts.AccountsSummaryResponse
-	31, // [31:44] is the sub-list for method output_type
-	18, // [18:31] is the sub-list for method input_type
-	18, // [18:18] is the sub-list for extension type_name
-	18, // [18:18] is the sub-list for extension extendee
-	0,  // [0:18] is the sub-list for field type_name
+	38, // 4: accounts.Account.archived_at:type_name -> google.protobuf.Timestamp
+	1,  // 5: accounts.SubAccount.asset_type:type_name -> accounts.AssetType
+	38, // 6: accounts.SubAccount.created_at:type_name -> google.protobuf.Timestamp
+	38, // 7: accounts.SubAccount.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 8: accounts.CreateAccountRequest.type:type_name -> accounts.AccountType
+	0,  // 9: accounts.ListAccountsRequest.type:type_name -> accounts.AccountType
+	2,  // 10: accounts.ListAccountsResponse.accounts:type_name -> accounts.Account
+	1,  // 11: accounts.CreateSubAccountRequest.asset_type:type_name -> accounts.AssetType
+	1,  // 12: accounts.ListSubAccountsRequest.asset_type:type_name -> accounts.AssetType
+	3,  // 13: accounts.ListSubAccountsResponse.sub_accounts:type_name -> accounts.SubAccount
+	23, // 14: accounts.BulkUpdateSubAccountBalancesRequest.updates:type_name -> accounts.SubAccountBalanceUpdate
+	25, // 15: accounts.BulkUpdateSubAccountBalancesResponse.results:type_name -> accounts.SubAccountBalanceResult
+	3,  // 16: accounts.SubAccountBalanceResult.sub_account:type_name -> accounts.SubAccount
+	38, // 17: accounts.GetBalanceHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	38, // 18: accounts.GetBalanceHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	38, // 19: accounts.BalanceHistoryPoint.date:type_name -> google.protobuf.Timestamp
+	27, // 20: accounts.BalanceHistoryResponse.points:type_name -> accounts.BalanceHistoryPoint
+	34, // 21: accounts.NetWorthResponse.by_account_type:type_name -> accounts.NetWorthResponse.ByAccountTypeEntry
+	35, // 22: accounts.NetWorthResponse.by_asset_type:type_name -> accounts.NetWorthResponse.ByAssetTypeEntry
+	38, // 23: accounts.NetWorthResponse.calculated_at:type_name -> google.protobuf.Timestamp
+	36, // 24: accounts.NetWorthResponse.by_currency:type_name -> accounts.NetWorthResponse.ByCurrencyEntry
+	37, // 25: accounts.NetWorthResponse.converted_by_currency:type_name -> accounts.NetWorthResponse.ConvertedByCurrencyEntry
+	33, // 26: accounts.AccountsSummaryResponse.by_type:type_name -> accounts.AccountTypeSummary
+	0,  // 27: accounts.AccountTypeSummary.type:type_name -> accounts.AccountType
+	4,  // 28: accounts.AccountsService.CreateAccount:input_type -> accounts.CreateAccountRequest
+	5,  // 29: accounts.AccountsService.GetAccount:input_type -> accounts.GetAccountRequest
+	6,  // 30: accounts.AccountsService.ListAccounts:input_type -> accounts.ListAccountsRequest
+	8,  // 31: accounts.AccountsService.UpdateAccount:input_type -> accounts.UpdateAccountRequest
+	9,  // 32: accounts.AccountsService.DeleteAccount:input_type -> accounts.DeleteAccountRequest
+	10, // 33: accounts.AccountsService.ArchiveAccount:input_type -> accounts.ArchiveAccountRequest
+	10, // 34: accounts.AccountsService.UnarchiveAccount:input_type -> accounts.ArchiveAccountRequest
+	11, // 35: accounts.AccountsService.UndeleteAccount:input_type -> accounts.UndeleteAccountRequest
+	12, // 36: accounts.AccountsService.ReorderAccounts:input_type -> accounts.ReorderAccountsRequest
+	13, // 37: accounts.AccountsService.CreateSubAccount:input_type -> accounts.CreateSubAccountRequest
+	14, // 38: accounts.AccountsService.GetSubAccount:input_type -> accounts.GetSubAccountRequest
+	15, // 39: accounts.AccountsService.ListSubAccounts:input_type -> accounts.ListSubAccountsRequest
+	17, // 40: accounts.AccountsService.UpdateSubAccount:input_type -> accounts.UpdateSubAccountRequest
+	18, // 41: accounts.AccountsService.DeleteSubAccount:input_type -> accounts.DeleteSubAccountRequest
+	19, // 42: accounts.AccountsService.ReorderSubAccounts:input_type -> accounts.ReorderSubAccountsRequest
+	20, // 43: accounts.AccountsService.UpdateSubAccountBalance:input_type -> accounts.UpdateSubAccountBalanceRequest
+	21, // 44: accounts.AccountsService.AdjustSubAccountBalance:input_type -> accounts.AdjustSubAccountBalanceRequest
+	22, // 45: accounts.AccountsService.BulkUpdateSubAccountBalances:input_type -> accounts.BulkUpdateSubAccountBalancesRequest
+	26, // 46: accounts.AccountsService.GetSubAccountBalanceHistory:input_type -> accounts.GetBalanceHistoryRequest
+	29, // 47: accounts.AccountsService.GetUserNetWorth:input_type -> accounts.GetUserNetWorthRequest
+	31, // 48: accounts.AccountsService.GetAccountsSummary:input_type -> accounts.GetAccountsSummaryRequest
+	2,  // 49: accounts.AccountsService.CreateAccount:output_type -> accounts.Account
+	2,  // 50: accounts.AccountsService.GetAccount:output_type -> accounts.Account
+	7,  // 51: accounts.AccountsService.ListAccounts:output_type -> accounts.ListAccountsResponse
+	2,  // 52: accounts.AccountsService.UpdateAccount:output_type -> accounts.Account
+	39, // 53: accounts.AccountsService.DeleteAccount:output_type -> google.protobuf.Empty
+	2,  // 54: accounts.AccountsService.ArchiveAccount:output_type -> accounts.Account
+	2,  // 55: accounts.AccountsService.UnarchiveAccount:output_type -> accounts.Account
+	2,  // 56: accounts.AccountsService.UndeleteAccount:output_type -> accounts.Account
+	39, // 57: accounts.AccountsService.ReorderAccounts:output_type -> google.protobuf.Empty
+	3,  // 58: accounts.AccountsService.CreateSubAccount:output_type -> accounts.SubAccount
+	3,  // 59: accounts.AccountsService.GetSubAccount:output_type -> accounts.SubAccount
+	16, // 60: accounts.AccountsService.ListSubAccounts:output_type -> accounts.ListSubAccountsResponse
+	3,  // 61: accounts.AccountsService.UpdateSubAccount:output_type -> accounts.SubAccount
+	39, // 62: accounts.AccountsService.DeleteSubAccount:output_type -> google.protobuf.Empty
+	39, // 63: accounts.AccountsService.ReorderSubAccounts:output_type -> google.protobuf.Empty
+	3,  // 64: accounts.AccountsService.UpdateSubAccountBalance:output_type -> accounts.SubAccount
+	3,  // 65: accounts.AccountsService.AdjustSubAccountBalance:output_type -> accounts.SubAccount
+	24, // 66: accounts.AccountsService.BulkUpdateSubAccountBalances:output_type -> accounts.BulkUpdateSubAccountBalancesResponse
+	28, // 67: accounts.AccountsService.GetSubAccountBalanceHistory:output_type -> accounts.BalanceHistoryResponse
+	30, // 68: accounts.AccountsService.GetUserNetWorth:output_type -> accounts.NetWorthResponse
+	32, // 69: accounts.AccountsService.GetAccountsSummary:output_type -> accounts.AccountsSummaryResponse
+	49, // [49:70] is the sub-list for method output_type
+	28, // [28:49] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_accounts_proto_init() }
@@ -1846,7 +2826,7 @@ func file_proto_accounts_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_accounts_proto_rawDesc), len(file_proto_accounts_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   22,
+			NumMessages:   36,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,19 +20,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AccountsService_CreateAccount_FullMethodName           = "/accounts.AccountsService/CreateAccount"
-	AccountsService_GetAccount_FullMethodName              = "/accounts.AccountsService/GetAccount"
-	AccountsService_ListAccounts_FullMethodName            = "/accounts.AccountsService/ListAccounts"
-	AccountsService_UpdateAccount_FullMethodName           = "/accounts.AccountsService/UpdateAccount"
-	AccountsService_DeleteAccount_FullMethodName           = "/accounts.AccountsService/DeleteAccount"
-	AccountsService_CreateSubAccount_FullMethodName        = "/accounts.AccountsService/CreateSubAccount"
-	AccountsService_GetSubAccount_FullMethodName           = "/accounts.AccountsService/GetSubAccount"
-	AccountsService_ListSubAccounts_FullMethodName         = "/accounts.AccountsService/ListSubAccounts"
-	AccountsService_UpdateSubAccount_FullMethodName        = "/accounts.AccountsService/UpdateSubAccount"
-	AccountsService_DeleteSubAccount_FullMethodName        = "/accounts.AccountsService/DeleteSubAccount"
-	AccountsService_UpdateSubAccountBalance_FullMethodName = "/accounts.AccountsService/UpdateSubAccountBalance"
-	AccountsService_GetUserNetWorth_FullMethodName         = "/accounts.AccountsService/GetUserNetWorth"
-	AccountsService_GetAccountsSummary_FullMethodName      = "/accounts.AccountsService/GetAccountsSummary"
+	AccountsService_CreateAccount_FullMethodName                = "/accounts.AccountsService/CreateAccount"
+	AccountsService_GetAccount_FullMethodName                   = "/accounts.AccountsService/GetAccount"
+	AccountsService_ListAccounts_FullMethodName                 = "/accounts.AccountsService/ListAccounts"
+	AccountsService_UpdateAccount_FullMethodName                = "/accounts.AccountsService/UpdateAccount"
+	AccountsService_DeleteAccount_FullMethodName                = "/accounts.AccountsService/DeleteAccount"
+	AccountsService_ArchiveAccount_FullMethodName               = "/accounts.AccountsService/ArchiveAccount"
+	AccountsService_UnarchiveAccount_FullMethodName             = "/accounts.AccountsService/UnarchiveAccount"
+	AccountsService_UndeleteAccount_FullMethodName              = "/accounts.AccountsService/UndeleteAccount"
+	AccountsService_ReorderAccounts_FullMethodName              = "/accounts.AccountsService/ReorderAccounts"
+	AccountsService_CreateSubAccount_FullMethodName             = "/accounts.AccountsService/CreateSubAccount"
+	AccountsService_GetSubAccount_FullMethodName                = "/accounts.AccountsService/GetSubAccount"
+	AccountsService_ListSubAccounts_FullMethodName              = "/accounts.AccountsService/ListSubAccounts"
+	AccountsService_UpdateSubAccount_FullMethodName             = "/accounts.AccountsService/UpdateSubAccount"
+	AccountsService_DeleteSubAccount_FullMethodName             = "/accounts.AccountsService/DeleteSubAccount"
+	AccountsService_ReorderSubAccounts_FullMethodName           = "/accounts.AccountsService/ReorderSubAccounts"
+	AccountsService_UpdateSubAccountBalance_FullMethodName      = "/accounts.AccountsService/UpdateSubAccountBalance"
+	AccountsService_AdjustSubAccountBalance_FullMethodName      = "/accounts.AccountsService/AdjustSubAccountBalance"
+	AccountsService_BulkUpdateSubAccountBalances_FullMethodName = "/accounts.AccountsService/BulkUpdateSubAccountBalances"
+	AccountsService_GetSubAccountBalanceHistory_FullMethodName  = "/accounts.AccountsService/GetSubAccountBalanceHistory"
+	AccountsService_GetUserNetWorth_FullMethodName              = "/accounts.AccountsService/GetUserNetWorth"
+	AccountsService_GetAccountsSummary_FullMethodName           = "/accounts.AccountsService/GetAccountsSummary"
 )
 
 // AccountsServiceClient is the client API for AccountsService service.
@@ -44,12 +52,20 @@ type AccountsServiceClient interface {
 	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
 	UpdateAccount(ctx context.Context, in *UpdateAccountRequest, opts ...grpc.CallOption) (*Account, error)
 	DeleteAccount(ctx context.Context, in *DeleteAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ArchiveAccount(ctx context.Context, in *ArchiveAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	UnarchiveAccount(ctx context.Context, in *ArchiveAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	UndeleteAccount(ctx context.Context, in *UndeleteAccountRequest, opts ...grpc.CallOption) (*Account, error)
+	ReorderAccounts(ctx context.Context, in *ReorderAccountsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateSubAccount(ctx context.Context, in *CreateSubAccountRequest, opts ...grpc.CallOption) (*SubAccount, error)
 	GetSubAccount(ctx context.Context, in *GetSubAccountRequest, opts ...grpc.CallOption) (*SubAccount, error)
 	ListSubAccounts(ctx context.Context, in *ListSubAccountsRequest, opts ...grpc.CallOption) (*ListSubAccountsResponse, error)
 	UpdateSubAccount(ctx context.Context, in *UpdateSubAccountRequest, opts ...grpc.CallOption) (*SubAccount, error)
 	DeleteSubAccount(ctx context.Context, in *DeleteSubAccountRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ReorderSubAccounts(ctx context.Context, in *ReorderSubAccountsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	UpdateSubAccountBalance(ctx context.Context, in *UpdateSubAccountBalanceRequest, opts ...grpc.CallOption) (*SubAccount, error)
+	AdjustSubAccountBalance(ctx context.Context, in *AdjustSubAccountBalanceRequest, opts ...grpc.CallOption) (*SubAccount, error)
+	BulkUpdateSubAccountBalances(ctx context.Context, in *BulkUpdateSubAccountBalancesRequest, opts ...grpc.CallOption) (*BulkUpdateSubAccountBalancesResponse, error)
+	GetSubAccountBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*BalanceHistoryResponse, error)
 	GetUserNetWorth(ctx context.Context, in *GetUserNetWorthRequest, opts ...grpc.CallOption) (*NetWorthResponse, error)
 	GetAccountsSummary(ctx context.Context, in *GetAccountsSummaryRequest, opts ...grpc.CallOption) (*AccountsSummaryResponse, error)
 }
@@ -112,6 +128,46 @@ func (c *accountsServiceClient) DeleteAccount(ctx context.Context, in *DeleteAcc
 	return out, nil
 }
 
+func (c *accountsServiceClient) ArchiveAccount(ctx context.Context, in *ArchiveAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AccountsService_ArchiveAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) UnarchiveAccount(ctx context.Context, in *ArchiveAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AccountsService_UnarchiveAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) UndeleteAccount(ctx context.Context, in *UndeleteAccountRequest, opts ...grpc.CallOption) (*Account, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Account)
+	err := c.cc.Invoke(ctx, AccountsService_UndeleteAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) ReorderAccounts(ctx context.Context, in *ReorderAccountsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccountsService_ReorderAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountsServiceClient) CreateSubAccount(ctx context.Context, in *CreateSubAccountRequest, opts ...grpc.CallOption) (*SubAccount, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubAccount)
@@ -162,6 +218,16 @@ func (c *accountsServiceClient) DeleteSubAccount(ctx context.Context, in *Delete
 	return out, nil
 }
 
+func (c *accountsServiceClient) ReorderSubAccounts(ctx context.Context, in *ReorderSubAccountsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AccountsService_ReorderSubAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountsServiceClient) UpdateSubAccountBalance(ctx context.Context, in *UpdateSubAccountBalanceRequest, opts ...grpc.CallOption) (*SubAccount, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SubAccount)
@@ -172,6 +238,36 @@ func (c *accountsServiceClient) UpdateSubAccountBalance(ctx context.Context, in
 	return out, nil
 }
 
+func (c *accountsServiceClient) AdjustSubAccountBalance(ctx context.Context, in *AdjustSubAccountBalanceRequest, opts ...grpc.CallOption) (*SubAccount, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubAccount)
+	err := c.cc.Invoke(ctx, AccountsService_AdjustSubAccountBalance_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) BulkUpdateSubAccountBalances(ctx context.Context, in *BulkUpdateSubAccountBalancesRequest, opts ...grpc.CallOption) (*BulkUpdateSubAccountBalancesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BulkUpdateSubAccountBalancesResponse)
+	err := c.cc.Invoke(ctx, AccountsService_BulkUpdateSubAccountBalances_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountsServiceClient) GetSubAccountBalanceHistory(ctx context.Context, in *GetBalanceHistoryRequest, opts ...grpc.CallOption) (*BalanceHistoryResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BalanceHistoryResponse)
+	err := c.cc.Invoke(ctx, AccountsService_GetSubAccountBalanceHistory_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountsServiceClient) GetUserNetWorth(ctx context.Context, in *GetUserNetWorthRequest, opts ...grpc.CallOption) (*NetWorthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(NetWorthResponse)
@@ -201,12 +297,20 @@ type AccountsServiceServer interface {
 	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
 	UpdateAccount(context.Context, *UpdateAccountRequest) (*Account, error)
 	DeleteAccount(context.Context, *DeleteAccountRequest) (*emptypb.Empty, error)
+	ArchiveAccount(context.Context, *ArchiveAccountRequest) (*Account, error)
+	UnarchiveAccount(context.Context, *ArchiveAccountRequest) (*Account, error)
+	UndeleteAccount(context.Context, *UndeleteAccountRequest) (*Account, error)
+	ReorderAccounts(context.Context, *ReorderAccountsRequest) (*emptypb.Empty, error)
 	CreateSubAccount(context.Context, *CreateSubAccountRequest) (*SubAccount, error)
 	GetSubAccount(context.Context, *GetSubAccountRequest) (*SubAccount, error)
 	ListSubAccounts(context.Context, *ListSubAccountsRequest) (*ListSubAccountsResponse, error)
 	UpdateSubAccount(context.Context, *UpdateSubAccountRequest) (*SubAccount, error)
 	DeleteSubAccount(context.Context, *DeleteSubAccountRequest) (*emptypb.Empty, error)
+	ReorderSubAccounts(context.Context, *ReorderSubAccountsRequest) (*emptypb.Empty, error)
 	UpdateSubAccountBalance(context.Context, *UpdateSubAccountBalanceRequest) (*SubAccount, error)
+	AdjustSubAccountBalance(context.Context, *AdjustSubAccountBalanceRequest) (*SubAccount, error)
+	BulkUpdateSubAccountBalances(context.Context, *BulkUpdateSubAccountBalancesRequest) (*BulkUpdateSubAccountBalancesResponse, error)
+	GetSubAccountBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*BalanceHistoryResponse, error)
 	GetUserNetWorth(context.Context, *GetUserNetWorthRequest) (*NetWorthResponse, error)
 	GetAccountsSummary(context.Context, *GetAccountsSummaryRequest) (*AccountsSummaryResponse, error)
 	mustEmbedUnimplementedAccountsServiceServer()
@@ -234,6 +338,18 @@ func (UnimplementedAccountsServiceServer) UpdateAccount(context.Context, *Update
 func (UnimplementedAccountsServiceServer) DeleteAccount(context.Context, *DeleteAccountRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteAccount not implemented")
 }
+func (UnimplementedAccountsServiceServer) ArchiveAccount(context.Context, *ArchiveAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method ArchiveAccount not implemented")
+}
+func (UnimplementedAccountsServiceServer) UnarchiveAccount(context.Context, *ArchiveAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnarchiveAccount not implemented")
+}
+func (UnimplementedAccountsServiceServer) UndeleteAccount(context.Context, *UndeleteAccountRequest) (*Account, error) {
+	return nil, status.Error(codes.Unimplemented, "method UndeleteAccount not implemented")
+}
+func (UnimplementedAccountsServiceServer) ReorderAccounts(context.Context, *ReorderAccountsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderAccounts not implemented")
+}
 func (UnimplementedAccountsServiceServer) CreateSubAccount(context.Context, *CreateSubAccountRequest) (*SubAccount, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateSubAccount not implemented")
 }
@@ -249,9 +365,21 @@ func (UnimplementedAccountsServiceServer) UpdateSubAccount(context.Context, *Upd
 func (UnimplementedAccountsServiceServer) DeleteSubAccount(context.Context, *DeleteSubAccountRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteSubAccount not implemented")
 }
+func (UnimplementedAccountsServiceServer) ReorderSubAccounts(context.Context, *ReorderSubAccountsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReorderSubAccounts not implemented")
+}
 func (UnimplementedAccountsServiceServer) UpdateSubAccountBalance(context.Context, *UpdateSubAccountBalanceRequest) (*SubAccount, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateSubAccountBalance not implemented")
 }
+func (UnimplementedAccountsServiceServer) AdjustSubAccountBalance(context.Context, *AdjustSubAccountBalanceRequest) (*SubAccount, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustSubAccountBalance not implemented")
+}
+func (UnimplementedAccountsServiceServer) BulkUpdateSubAccountBalances(context.Context, *BulkUpdateSubAccountBalancesRequest) (*BulkUpdateSubAccountBalancesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BulkUpdateSubAccountBalances not implemented")
+}
+func (UnimplementedAccountsServiceServer) GetSubAccountBalanceHistory(context.Context, *GetBalanceHistoryRequest) (*BalanceHistoryResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSubAccountBalanceHistory not implemented")
+}
 func (UnimplementedAccountsServiceServer) GetUserNetWorth(context.Context, *GetUserNetWorthRequest) (*NetWorthResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetUserNetWorth not implemented")
 }
@@ -369,6 +497,78 @@ func _AccountsService_DeleteAccount_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountsService_ArchiveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).ArchiveAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_ArchiveAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).ArchiveAccount(ctx, req.(*ArchiveAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_UnarchiveAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).UnarchiveAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_UnarchiveAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).UnarchiveAccount(ctx, req.(*ArchiveAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_UndeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UndeleteAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).UndeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_UndeleteAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).UndeleteAccount(ctx, req.(*UndeleteAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_ReorderAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).ReorderAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_ReorderAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).ReorderAccounts(ctx, req.(*ReorderAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AccountsService_CreateSubAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateSubAccountRequest)
 	if err := dec(in); err != nil {
@@ -459,6 +659,24 @@ func _AccountsService_DeleteSubAccount_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountsService_ReorderSubAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReorderSubAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).ReorderSubAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_ReorderSubAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).ReorderSubAccounts(ctx, req.(*ReorderSubAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AccountsService_UpdateSubAccountBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(UpdateSubAccountBalanceRequest)
 	if err := dec(in); err != nil {
@@ -477,6 +695,60 @@ func _AccountsService_UpdateSubAccountBalance_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountsService_AdjustSubAccountBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustSubAccountBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).AdjustSubAccountBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_AdjustSubAccountBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).AdjustSubAccountBalance(ctx, req.(*AdjustSubAccountBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_BulkUpdateSubAccountBalances_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkUpdateSubAccountBalancesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).BulkUpdateSubAccountBalances(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_BulkUpdateSubAccountBalances_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).BulkUpdateSubAccountBalances(ctx, req.(*BulkUpdateSubAccountBalancesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountsService_GetSubAccountBalanceHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountsServiceServer).GetSubAccountBalanceHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountsService_GetSubAccountBalanceHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountsServiceServer).GetSubAccountBalanceHistory(ctx, req.(*GetBalanceHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AccountsService_GetUserNetWorth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetUserNetWorthRequest)
 	if err := dec(in); err != nil {
@@ -540,6 +812,22 @@ var AccountsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAccount",
 			Handler:    _AccountsService_DeleteAccount_Handler,
 		},
+		{
+			MethodName: "ArchiveAccount",
+			Handler:    _AccountsService_ArchiveAccount_Handler,
+		},
+		{
+			MethodName: "UnarchiveAccount",
+			Handler:    _AccountsService_UnarchiveAccount_Handler,
+		},
+		{
+			MethodName: "UndeleteAccount",
+			Handler:    _AccountsService_UndeleteAccount_Handler,
+		},
+		{
+			MethodName: "ReorderAccounts",
+			Handler:    _AccountsService_ReorderAccounts_Handler,
+		},
 		{
 			MethodName: "CreateSubAccount",
 			Handler:    _AccountsService_CreateSubAccount_Handler,
@@ -560,10 +848,26 @@ var AccountsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteSubAccount",
 			Handler:    _AccountsService_DeleteSubAccount_Handler,
 		},
+		{
+			MethodName: "ReorderSubAccounts",
+			Handler:    _AccountsService_ReorderSubAccounts_Handler,
+		},
 		{
 			MethodName: "UpdateSubAccountBalance",
 			Handler:    _AccountsService_UpdateSubAccountBalance_Handler,
 		},
+		{
+			MethodName: "AdjustSubAccountBalance",
+			Handler:    _AccountsService_AdjustSubAccountBalance_Handler,
+		},
+		{
+			MethodName: "BulkUpdateSubAccountBalances",
+			Handler:    _AccountsService_BulkUpdateSubAccountBalances_Handler,
+		},
+		{
+			MethodName: "GetSubAccountBalanceHistory",
+			Handler:    _AccountsService_GetSubAccountBalanceHistory_Handler,
+		},
 		{
 			MethodName: "GetUserNetWorth",
 			Handler:    _AccountsService_GetUserNetWorth_Handler,
@@ -110,6 +110,7 @@ type Asset struct {
 	CreatedAt         *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Metadata          map[string]string      `protobuf:"bytes,18,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Version           int64                  `protobuf:"varint,19,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -270,6 +271,13 @@ func (x *Asset) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *Asset) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type CreateAssetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -607,6 +615,9 @@ type UpdateAssetRequest struct {
 	CurrentPrice  float64                `protobuf:"fixed64,5,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
 	Name          string                 `protobuf:"bytes,6,opt,name=name,proto3" json:"name,omitempty"`
 	Metadata      map[string]string      `protobuf:"bytes,7,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// version is the version last read; the update fails with ABORTED if the
+	// asset has changed since. Zero skips the check.
+	Version       int64 `protobuf:"varint,8,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -690,6 +701,13 @@ func (x *UpdateAssetRequest) GetMetadata() map[string]string {
 	return nil
 }
 
+func (x *UpdateAssetRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
 type DeleteAssetRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -742,28 +760,32 @@ func (x *DeleteAssetRequest) GetUserId() string {
 	return ""
 }
 
-type GetAssetPriceRequest struct {
+// SubAccountAssetsRequest selects the assets held in deleted sub-accounts.
+// Deleting marks them with deleted_at; restoring brings back only assets
+// marked with that same time.
+type SubAccountAssetsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SubAccountIds []string               `protobuf:"bytes,2,rep,name=sub_account_ids,json=subAccountIds,proto3" json:"sub_account_ids,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAssetPriceRequest) Reset() {
-	*x = GetAssetPriceRequest{}
+func (x *SubAccountAssetsRequest) Reset() {
+	*x = SubAccountAssetsRequest{}
 	mi := &file_proto_assets_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAssetPriceRequest) String() string {
+func (x *SubAccountAssetsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssetPriceRequest) ProtoMessage() {}
+func (*SubAccountAssetsRequest) ProtoMessage() {}
 
-func (x *GetAssetPriceRequest) ProtoReflect() protoreflect.Message {
+func (x *SubAccountAssetsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_assets_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -775,55 +797,53 @@ func (x *GetAssetPriceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssetPriceRequest.ProtoReflect.Descriptor instead.
-func (*GetAssetPriceRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubAccountAssetsRequest.ProtoReflect.Descriptor instead.
+func (*SubAccountAssetsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_assets_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *GetAssetPriceRequest) GetSymbol() string {
+func (x *SubAccountAssetsRequest) GetUserId() string {
 	if x != nil {
-		return x.Symbol
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *GetAssetPriceRequest) GetType() AssetType {
+func (x *SubAccountAssetsRequest) GetSubAccountIds() []string {
 	if x != nil {
-		return x.Type
+		return x.SubAccountIds
 	}
-	return AssetType_ASSET_TYPE_UNSPECIFIED
+	return nil
 }
 
-type AssetPriceResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	Symbol            string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Price             float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
-	Currency          string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
-	Change_24H        float64                `protobuf:"fixed64,4,opt,name=change_24h,json=change24h,proto3" json:"change_24h,omitempty"`
-	ChangePercent_24H float64                `protobuf:"fixed64,5,opt,name=change_percent_24h,json=changePercent24h,proto3" json:"change_percent_24h,omitempty"`
-	High_24H          float64                `protobuf:"fixed64,6,opt,name=high_24h,json=high24h,proto3" json:"high_24h,omitempty"`
-	Low_24H           float64                `protobuf:"fixed64,7,opt,name=low_24h,json=low24h,proto3" json:"low_24h,omitempty"`
-	Volume_24H        float64                `protobuf:"fixed64,8,opt,name=volume_24h,json=volume24h,proto3" json:"volume_24h,omitempty"`
-	MarketCap         float64                `protobuf:"fixed64,9,opt,name=market_cap,json=marketCap,proto3" json:"market_cap,omitempty"`
-	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+func (x *SubAccountAssetsRequest) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
 }
 
-func (x *AssetPriceResponse) Reset() {
-	*x = AssetPriceResponse{}
+type SubAccountAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Count         int64                  `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubAccountAssetsResponse) Reset() {
+	*x = SubAccountAssetsResponse{}
 	mi := &file_proto_assets_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssetPriceResponse) String() string {
+func (x *SubAccountAssetsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssetPriceResponse) ProtoMessage() {}
+func (*SubAccountAssetsResponse) ProtoMessage() {}
 
-func (x *AssetPriceResponse) ProtoReflect() protoreflect.Message {
+func (x *SubAccountAssetsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_assets_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -835,103 +855,209 @@ func (x *AssetPriceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssetPriceResponse.ProtoReflect.Descriptor instead.
-func (*AssetPriceResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SubAccountAssetsResponse.ProtoReflect.Descriptor instead.
+func (*SubAccountAssetsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_assets_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *AssetPriceResponse) GetSymbol() string {
+func (x *SubAccountAssetsResponse) GetCount() int64 {
 	if x != nil {
-		return x.Symbol
+		return x.Count
+	}
+	return 0
+}
+
+type SellAssetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SellAssetRequest) Reset() {
+	*x = SellAssetRequest{}
+	mi := &file_proto_assets_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SellAssetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SellAssetRequest) ProtoMessage() {}
+
+func (x *SellAssetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SellAssetRequest.ProtoReflect.Descriptor instead.
+func (*SellAssetRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SellAssetRequest) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AssetPriceResponse) GetPrice() float64 {
+func (x *SellAssetRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SellAssetRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *SellAssetRequest) GetPrice() float64 {
 	if x != nil {
 		return x.Price
 	}
 	return 0
 }
 
-func (x *AssetPriceResponse) GetCurrency() string {
+type SellAssetResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Asset            *Asset                 `protobuf:"bytes,1,opt,name=asset,proto3" json:"asset,omitempty"`
+	Quantity         float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	SalePrice        float64                `protobuf:"fixed64,3,opt,name=sale_price,json=salePrice,proto3" json:"sale_price,omitempty"`
+	Proceeds         float64                `protobuf:"fixed64,4,opt,name=proceeds,proto3" json:"proceeds,omitempty"`
+	CostBasis        float64                `protobuf:"fixed64,5,opt,name=cost_basis,json=costBasis,proto3" json:"cost_basis,omitempty"`
+	RealizedGainLoss float64                `protobuf:"fixed64,6,opt,name=realized_gain_loss,json=realizedGainLoss,proto3" json:"realized_gain_loss,omitempty"`
+	SoldAt           *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=sold_at,json=soldAt,proto3" json:"sold_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *SellAssetResponse) Reset() {
+	*x = SellAssetResponse{}
+	mi := &file_proto_assets_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SellAssetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SellAssetResponse) ProtoMessage() {}
+
+func (x *SellAssetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[10]
 	if x != nil {
-		return x.Currency
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-func (x *AssetPriceResponse) GetChange_24H() float64 {
+// Deprecated: Use SellAssetResponse.ProtoReflect.Descriptor instead.
+func (*SellAssetResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SellAssetResponse) GetAsset() *Asset {
 	if x != nil {
-		return x.Change_24H
+		return x.Asset
 	}
-	return 0
+	return nil
 }
 
-func (x *AssetPriceResponse) GetChangePercent_24H() float64 {
+func (x *SellAssetResponse) GetQuantity() float64 {
 	if x != nil {
-		return x.ChangePercent_24H
+		return x.Quantity
 	}
 	return 0
 }
 
-func (x *AssetPriceResponse) GetHigh_24H() float64 {
+func (x *SellAssetResponse) GetSalePrice() float64 {
 	if x != nil {
-		return x.High_24H
+		return x.SalePrice
 	}
 	return 0
 }
 
-func (x *AssetPriceResponse) GetLow_24H() float64 {
+func (x *SellAssetResponse) GetProceeds() float64 {
 	if x != nil {
-		return x.Low_24H
+		return x.Proceeds
 	}
 	return 0
 }
 
-func (x *AssetPriceResponse) GetVolume_24H() float64 {
+func (x *SellAssetResponse) GetCostBasis() float64 {
 	if x != nil {
-		return x.Volume_24H
+		return x.CostBasis
 	}
 	return 0
 }
 
-func (x *AssetPriceResponse) GetMarketCap() float64 {
+func (x *SellAssetResponse) GetRealizedGainLoss() float64 {
 	if x != nil {
-		return x.MarketCap
+		return x.RealizedGainLoss
 	}
 	return 0
 }
 
-func (x *AssetPriceResponse) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *SellAssetResponse) GetSoldAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UpdatedAt
+		return x.SoldAt
 	}
 	return nil
 }
 
-type GetMultipleAssetPricesRequest struct {
+type Dividend struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Queries       []*AssetPriceQuery     `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AssetId       string                 `protobuf:"bytes,2,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,4,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Amount        float64                `protobuf:"fixed64,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,6,opt,name=currency,proto3" json:"currency,omitempty"`
+	ExDate        *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=ex_date,json=exDate,proto3" json:"ex_date,omitempty"`
+	PayDate       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=pay_date,json=payDate,proto3" json:"pay_date,omitempty"`
+	Notes         string                 `protobuf:"bytes,9,opt,name=notes,proto3" json:"notes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMultipleAssetPricesRequest) Reset() {
-	*x = GetMultipleAssetPricesRequest{}
-	mi := &file_proto_assets_proto_msgTypes[9]
+func (x *Dividend) Reset() {
+	*x = Dividend{}
+	mi := &file_proto_assets_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMultipleAssetPricesRequest) String() string {
+func (x *Dividend) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMultipleAssetPricesRequest) ProtoMessage() {}
+func (*Dividend) ProtoMessage() {}
 
-func (x *GetMultipleAssetPricesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[9]
+func (x *Dividend) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -942,93 +1068,109 @@ func (x *GetMultipleAssetPricesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMultipleAssetPricesRequest.ProtoReflect.Descriptor instead.
-func (*GetMultipleAssetPricesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use Dividend.ProtoReflect.Descriptor instead.
+func (*Dividend) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetMultipleAssetPricesRequest) GetQueries() []*AssetPriceQuery {
+func (x *Dividend) GetId() string {
 	if x != nil {
-		return x.Queries
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type AssetPriceQuery struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Dividend) GetAssetId() string {
+	if x != nil {
+		return x.AssetId
+	}
+	return ""
 }
 
-func (x *AssetPriceQuery) Reset() {
-	*x = AssetPriceQuery{}
-	mi := &file_proto_assets_proto_msgTypes[10]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Dividend) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
 }
 
-func (x *AssetPriceQuery) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Dividend) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
 }
 
-func (*AssetPriceQuery) ProtoMessage() {}
+func (x *Dividend) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
 
-func (x *AssetPriceQuery) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[10]
+func (x *Dividend) GetCurrency() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Currency
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use AssetPriceQuery.ProtoReflect.Descriptor instead.
-func (*AssetPriceQuery) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{10}
+func (x *Dividend) GetExDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExDate
+	}
+	return nil
 }
 
-func (x *AssetPriceQuery) GetSymbol() string {
+func (x *Dividend) GetPayDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Symbol
+		return x.PayDate
+	}
+	return nil
+}
+
+func (x *Dividend) GetNotes() string {
+	if x != nil {
+		return x.Notes
 	}
 	return ""
 }
 
-func (x *AssetPriceQuery) GetType() AssetType {
+func (x *Dividend) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Type
+		return x.CreatedAt
 	}
-	return AssetType_ASSET_TYPE_UNSPECIFIED
+	return nil
 }
 
-type GetMultipleAssetPricesResponse struct {
-	state         protoimpl.MessageState         `protogen:"open.v1"`
-	Prices        map[string]*AssetPriceResponse `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	FailedSymbols []string                       `protobuf:"bytes,2,rep,name=failed_symbols,json=failedSymbols,proto3" json:"failed_symbols,omitempty"`
+type RecordDividendRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Amount        float64                `protobuf:"fixed64,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	ExDate        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=ex_date,json=exDate,proto3" json:"ex_date,omitempty"`
+	PayDate       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=pay_date,json=payDate,proto3" json:"pay_date,omitempty"`
+	Notes         string                 `protobuf:"bytes,7,opt,name=notes,proto3" json:"notes,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetMultipleAssetPricesResponse) Reset() {
-	*x = GetMultipleAssetPricesResponse{}
-	mi := &file_proto_assets_proto_msgTypes[11]
+func (x *RecordDividendRequest) Reset() {
+	*x = RecordDividendRequest{}
+	mi := &file_proto_assets_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetMultipleAssetPricesResponse) String() string {
+func (x *RecordDividendRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetMultipleAssetPricesResponse) ProtoMessage() {}
+func (*RecordDividendRequest) ProtoMessage() {}
 
-func (x *GetMultipleAssetPricesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[11]
+func (x *RecordDividendRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1039,99 +1181,82 @@ func (x *GetMultipleAssetPricesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetMultipleAssetPricesResponse.ProtoReflect.Descriptor instead.
-func (*GetMultipleAssetPricesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use RecordDividendRequest.ProtoReflect.Descriptor instead.
+func (*RecordDividendRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetMultipleAssetPricesResponse) GetPrices() map[string]*AssetPriceResponse {
+func (x *RecordDividendRequest) GetAssetId() string {
 	if x != nil {
-		return x.Prices
+		return x.AssetId
 	}
-	return nil
+	return ""
 }
 
-func (x *GetMultipleAssetPricesResponse) GetFailedSymbols() []string {
+func (x *RecordDividendRequest) GetUserId() string {
 	if x != nil {
-		return x.FailedSymbols
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type RefreshAssetPricesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	AssetIds      []string               `protobuf:"bytes,2,rep,name=asset_ids,json=assetIds,proto3" json:"asset_ids,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *RefreshAssetPricesRequest) Reset() {
-	*x = RefreshAssetPricesRequest{}
-	mi := &file_proto_assets_proto_msgTypes[12]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
-}
-
-func (x *RefreshAssetPricesRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RecordDividendRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
 }
 
-func (*RefreshAssetPricesRequest) ProtoMessage() {}
-
-func (x *RefreshAssetPricesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[12]
+func (x *RecordDividendRequest) GetCurrency() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Currency
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use RefreshAssetPricesRequest.ProtoReflect.Descriptor instead.
-func (*RefreshAssetPricesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{12}
+func (x *RecordDividendRequest) GetExDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExDate
+	}
+	return nil
 }
 
-func (x *RefreshAssetPricesRequest) GetUserId() string {
+func (x *RecordDividendRequest) GetPayDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.UserId
+		return x.PayDate
 	}
-	return ""
+	return nil
 }
 
-func (x *RefreshAssetPricesRequest) GetAssetIds() []string {
+func (x *RecordDividendRequest) GetNotes() string {
 	if x != nil {
-		return x.AssetIds
+		return x.Notes
 	}
-	return nil
+	return ""
 }
 
-type RefreshAssetPricesResponse struct {
+type ListDividendsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
-	FailedIds     []string               `protobuf:"bytes,2,rep,name=failed_ids,json=failedIds,proto3" json:"failed_ids,omitempty"`
+	AssetId       string                 `protobuf:"bytes,1,opt,name=asset_id,json=assetId,proto3" json:"asset_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RefreshAssetPricesResponse) Reset() {
-	*x = RefreshAssetPricesResponse{}
+func (x *ListDividendsRequest) Reset() {
+	*x = ListDividendsRequest{}
 	mi := &file_proto_assets_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RefreshAssetPricesResponse) String() string {
+func (x *ListDividendsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RefreshAssetPricesResponse) ProtoMessage() {}
+func (*ListDividendsRequest) ProtoMessage() {}
 
-func (x *RefreshAssetPricesResponse) ProtoReflect() protoreflect.Message {
+func (x *ListDividendsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_assets_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1143,50 +1268,46 @@ func (x *RefreshAssetPricesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RefreshAssetPricesResponse.ProtoReflect.Descriptor instead.
-func (*RefreshAssetPricesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDividendsRequest.ProtoReflect.Descriptor instead.
+func (*ListDividendsRequest) Descriptor() ([]byte, []int) {
 	return file_proto_assets_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *RefreshAssetPricesResponse) GetUpdatedCount() int32 {
+func (x *ListDividendsRequest) GetAssetId() string {
 	if x != nil {
-		return x.UpdatedCount
+		return x.AssetId
 	}
-	return 0
+	return ""
 }
 
-func (x *RefreshAssetPricesResponse) GetFailedIds() []string {
+func (x *ListDividendsRequest) GetUserId() string {
 	if x != nil {
-		return x.FailedIds
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type GetAssetHistoryRequest struct {
+type ListDividendsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
-	Interval      string                 `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Dividends     []*Dividend            `protobuf:"bytes,1,rep,name=dividends,proto3" json:"dividends,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetAssetHistoryRequest) Reset() {
-	*x = GetAssetHistoryRequest{}
+func (x *ListDividendsResponse) Reset() {
+	*x = ListDividendsResponse{}
 	mi := &file_proto_assets_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetAssetHistoryRequest) String() string {
+func (x *ListDividendsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetAssetHistoryRequest) ProtoMessage() {}
+func (*ListDividendsResponse) ProtoMessage() {}
 
-func (x *GetAssetHistoryRequest) ProtoReflect() protoreflect.Message {
+func (x *ListDividendsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_assets_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1198,68 +1319,41 @@ func (x *GetAssetHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetAssetHistoryRequest.ProtoReflect.Descriptor instead.
-func (*GetAssetHistoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListDividendsResponse.ProtoReflect.Descriptor instead.
+func (*ListDividendsResponse) Descriptor() ([]byte, []int) {
 	return file_proto_assets_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetAssetHistoryRequest) GetSymbol() string {
-	if x != nil {
-		return x.Symbol
-	}
-	return ""
-}
-
-func (x *GetAssetHistoryRequest) GetType() AssetType {
-	if x != nil {
-		return x.Type
-	}
-	return AssetType_ASSET_TYPE_UNSPECIFIED
-}
-
-func (x *GetAssetHistoryRequest) GetInterval() string {
-	if x != nil {
-		return x.Interval
-	}
-	return ""
-}
-
-func (x *GetAssetHistoryRequest) GetStartDate() *timestamppb.Timestamp {
-	if x != nil {
-		return x.StartDate
-	}
-	return nil
-}
-
-func (x *GetAssetHistoryRequest) GetEndDate() *timestamppb.Timestamp {
+func (x *ListDividendsResponse) GetDividends() []*Dividend {
 	if x != nil {
-		return x.EndDate
+		return x.Dividends
 	}
 	return nil
 }
 
-type AssetHistoryResponse struct {
+type GetDividendIncomeRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	History       []*PricePoint          `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssetHistoryResponse) Reset() {
-	*x = AssetHistoryResponse{}
+func (x *GetDividendIncomeRequest) Reset() {
+	*x = GetDividendIncomeRequest{}
 	mi := &file_proto_assets_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssetHistoryResponse) String() string {
+func (x *GetDividendIncomeRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssetHistoryResponse) ProtoMessage() {}
+func (*GetDividendIncomeRequest) ProtoMessage() {}
 
-func (x *AssetHistoryResponse) ProtoReflect() protoreflect.Message {
+func (x *GetDividendIncomeRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_assets_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1271,51 +1365,54 @@ func (x *AssetHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssetHistoryResponse.ProtoReflect.Descriptor instead.
-func (*AssetHistoryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDividendIncomeRequest.ProtoReflect.Descriptor instead.
+func (*GetDividendIncomeRequest) Descriptor() ([]byte, []int) {
 	return file_proto_assets_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *AssetHistoryResponse) GetSymbol() string {
+func (x *GetDividendIncomeRequest) GetUserId() string {
 	if x != nil {
-		return x.Symbol
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *AssetHistoryResponse) GetHistory() []*PricePoint {
+func (x *GetDividendIncomeRequest) GetStartDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.History
+		return x.StartDate
 	}
 	return nil
 }
 
-type PricePoint struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
-	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
-	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
-	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
-	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
-	Volume        float64                `protobuf:"fixed64,6,opt,name=volume,proto3" json:"volume,omitempty"`
+func (x *GetDividendIncomeRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type GetDividendIncomeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Totals keyed by currency code
+	ByCurrency    map[string]float64 `protobuf:"bytes,1,rep,name=by_currency,json=byCurrency,proto3" json:"by_currency,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PricePoint) Reset() {
-	*x = PricePoint{}
+func (x *GetDividendIncomeResponse) Reset() {
+	*x = GetDividendIncomeResponse{}
 	mi := &file_proto_assets_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PricePoint) String() string {
+func (x *GetDividendIncomeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PricePoint) ProtoMessage() {}
+func (*GetDividendIncomeResponse) ProtoMessage() {}
 
-func (x *PricePoint) ProtoReflect() protoreflect.Message {
+func (x *GetDividendIncomeResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_assets_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1327,78 +1424,102 @@ func (x *PricePoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PricePoint.ProtoReflect.Descriptor instead.
-func (*PricePoint) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetDividendIncomeResponse.ProtoReflect.Descriptor instead.
+func (*GetDividendIncomeResponse) Descriptor() ([]byte, []int) {
 	return file_proto_assets_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *PricePoint) GetTimestamp() *timestamppb.Timestamp {
+func (x *GetDividendIncomeResponse) GetByCurrency() map[string]float64 {
 	if x != nil {
-		return x.Timestamp
+		return x.ByCurrency
 	}
 	return nil
 }
 
-func (x *PricePoint) GetOpen() float64 {
-	if x != nil {
-		return x.Open
-	}
-	return 0
+type GetAssetPriceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PricePoint) GetHigh() float64 {
-	if x != nil {
-		return x.High
-	}
-	return 0
+func (x *GetAssetPriceRequest) Reset() {
+	*x = GetAssetPriceRequest{}
+	mi := &file_proto_assets_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *PricePoint) GetLow() float64 {
+func (x *GetAssetPriceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetPriceRequest) ProtoMessage() {}
+
+func (x *GetAssetPriceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[17]
 	if x != nil {
-		return x.Low
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
 }
 
-func (x *PricePoint) GetClose() float64 {
+// Deprecated: Use GetAssetPriceRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetPriceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetAssetPriceRequest) GetSymbol() string {
 	if x != nil {
-		return x.Close
+		return x.Symbol
 	}
-	return 0
+	return ""
 }
 
-func (x *PricePoint) GetVolume() float64 {
+func (x *GetAssetPriceRequest) GetType() AssetType {
 	if x != nil {
-		return x.Volume
+		return x.Type
 	}
-	return 0
+	return AssetType_ASSET_TYPE_UNSPECIFIED
 }
 
-type GetPortfolioPerformanceRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BaseCurrency  string                 `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type AssetPriceResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Symbol            string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price             float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Currency          string                 `protobuf:"bytes,3,opt,name=currency,proto3" json:"currency,omitempty"`
+	Change_24H        float64                `protobuf:"fixed64,4,opt,name=change_24h,json=change24h,proto3" json:"change_24h,omitempty"`
+	ChangePercent_24H float64                `protobuf:"fixed64,5,opt,name=change_percent_24h,json=changePercent24h,proto3" json:"change_percent_24h,omitempty"`
+	High_24H          float64                `protobuf:"fixed64,6,opt,name=high_24h,json=high24h,proto3" json:"high_24h,omitempty"`
+	Low_24H           float64                `protobuf:"fixed64,7,opt,name=low_24h,json=low24h,proto3" json:"low_24h,omitempty"`
+	Volume_24H        float64                `protobuf:"fixed64,8,opt,name=volume_24h,json=volume24h,proto3" json:"volume_24h,omitempty"`
+	MarketCap         float64                `protobuf:"fixed64,9,opt,name=market_cap,json=marketCap,proto3" json:"market_cap,omitempty"`
+	UpdatedAt         *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Stale             bool                   `protobuf:"varint,11,opt,name=stale,proto3" json:"stale,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *GetPortfolioPerformanceRequest) Reset() {
-	*x = GetPortfolioPerformanceRequest{}
-	mi := &file_proto_assets_proto_msgTypes[17]
+func (x *AssetPriceResponse) Reset() {
+	*x = AssetPriceResponse{}
+	mi := &file_proto_assets_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetPortfolioPerformanceRequest) String() string {
+func (x *AssetPriceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetPortfolioPerformanceRequest) ProtoMessage() {}
+func (*AssetPriceResponse) ProtoMessage() {}
 
-func (x *GetPortfolioPerformanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[17]
+func (x *AssetPriceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1409,67 +1530,1208 @@ func (x *GetPortfolioPerformanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetPortfolioPerformanceRequest.ProtoReflect.Descriptor instead.
-func (*GetPortfolioPerformanceRequest) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use AssetPriceResponse.ProtoReflect.Descriptor instead.
+func (*AssetPriceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *GetPortfolioPerformanceRequest) GetUserId() string {
+func (x *AssetPriceResponse) GetSymbol() string {
 	if x != nil {
-		return x.UserId
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *GetPortfolioPerformanceRequest) GetBaseCurrency() string {
+func (x *AssetPriceResponse) GetPrice() float64 {
 	if x != nil {
-		return x.BaseCurrency
+		return x.Price
 	}
-	return ""
+	return 0
+}
+
+func (x *AssetPriceResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AssetPriceResponse) GetChange_24H() float64 {
+	if x != nil {
+		return x.Change_24H
+	}
+	return 0
+}
+
+func (x *AssetPriceResponse) GetChangePercent_24H() float64 {
+	if x != nil {
+		return x.ChangePercent_24H
+	}
+	return 0
+}
+
+func (x *AssetPriceResponse) GetHigh_24H() float64 {
+	if x != nil {
+		return x.High_24H
+	}
+	return 0
+}
+
+func (x *AssetPriceResponse) GetLow_24H() float64 {
+	if x != nil {
+		return x.Low_24H
+	}
+	return 0
+}
+
+func (x *AssetPriceResponse) GetVolume_24H() float64 {
+	if x != nil {
+		return x.Volume_24H
+	}
+	return 0
+}
+
+func (x *AssetPriceResponse) GetMarketCap() float64 {
+	if x != nil {
+		return x.MarketCap
+	}
+	return 0
+}
+
+func (x *AssetPriceResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *AssetPriceResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+type GetMultipleAssetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Queries       []*AssetPriceQuery     `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMultipleAssetPricesRequest) Reset() {
+	*x = GetMultipleAssetPricesRequest{}
+	mi := &file_proto_assets_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMultipleAssetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipleAssetPricesRequest) ProtoMessage() {}
+
+func (x *GetMultipleAssetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMultipleAssetPricesRequest.ProtoReflect.Descriptor instead.
+func (*GetMultipleAssetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetMultipleAssetPricesRequest) GetQueries() []*AssetPriceQuery {
+	if x != nil {
+		return x.Queries
+	}
+	return nil
+}
+
+type AssetPriceQuery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetPriceQuery) Reset() {
+	*x = AssetPriceQuery{}
+	mi := &file_proto_assets_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetPriceQuery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetPriceQuery) ProtoMessage() {}
+
+func (x *AssetPriceQuery) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetPriceQuery.ProtoReflect.Descriptor instead.
+func (*AssetPriceQuery) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *AssetPriceQuery) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AssetPriceQuery) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+type GetMultipleAssetPricesResponse struct {
+	state         protoimpl.MessageState         `protogen:"open.v1"`
+	Prices        map[string]*AssetPriceResponse `protobuf:"bytes,1,rep,name=prices,proto3" json:"prices,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	FailedSymbols []string                       `protobuf:"bytes,2,rep,name=failed_symbols,json=failedSymbols,proto3" json:"failed_symbols,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMultipleAssetPricesResponse) Reset() {
+	*x = GetMultipleAssetPricesResponse{}
+	mi := &file_proto_assets_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMultipleAssetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMultipleAssetPricesResponse) ProtoMessage() {}
+
+func (x *GetMultipleAssetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMultipleAssetPricesResponse.ProtoReflect.Descriptor instead.
+func (*GetMultipleAssetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetMultipleAssetPricesResponse) GetPrices() map[string]*AssetPriceResponse {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+func (x *GetMultipleAssetPricesResponse) GetFailedSymbols() []string {
+	if x != nil {
+		return x.FailedSymbols
+	}
+	return nil
+}
+
+type RefreshAssetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	AssetIds      []string               `protobuf:"bytes,2,rep,name=asset_ids,json=assetIds,proto3" json:"asset_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshAssetPricesRequest) Reset() {
+	*x = RefreshAssetPricesRequest{}
+	mi := &file_proto_assets_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshAssetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshAssetPricesRequest) ProtoMessage() {}
+
+func (x *RefreshAssetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshAssetPricesRequest.ProtoReflect.Descriptor instead.
+func (*RefreshAssetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *RefreshAssetPricesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RefreshAssetPricesRequest) GetAssetIds() []string {
+	if x != nil {
+		return x.AssetIds
+	}
+	return nil
+}
+
+type RefreshAssetPricesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UpdatedCount  int32                  `protobuf:"varint,1,opt,name=updated_count,json=updatedCount,proto3" json:"updated_count,omitempty"`
+	FailedIds     []string               `protobuf:"bytes,2,rep,name=failed_ids,json=failedIds,proto3" json:"failed_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshAssetPricesResponse) Reset() {
+	*x = RefreshAssetPricesResponse{}
+	mi := &file_proto_assets_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshAssetPricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshAssetPricesResponse) ProtoMessage() {}
+
+func (x *RefreshAssetPricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshAssetPricesResponse.ProtoReflect.Descriptor instead.
+func (*RefreshAssetPricesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RefreshAssetPricesResponse) GetUpdatedCount() int32 {
+	if x != nil {
+		return x.UpdatedCount
+	}
+	return 0
+}
+
+func (x *RefreshAssetPricesResponse) GetFailedIds() []string {
+	if x != nil {
+		return x.FailedIds
+	}
+	return nil
+}
+
+type GetAssetHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	Interval      string                 `protobuf:"bytes,3,opt,name=interval,proto3" json:"interval,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAssetHistoryRequest) Reset() {
+	*x = GetAssetHistoryRequest{}
+	mi := &file_proto_assets_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAssetHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAssetHistoryRequest) ProtoMessage() {}
+
+func (x *GetAssetHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAssetHistoryRequest.ProtoReflect.Descriptor instead.
+func (*GetAssetHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetAssetHistoryRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *GetAssetHistoryRequest) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *GetAssetHistoryRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *GetAssetHistoryRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetAssetHistoryRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type AssetHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	History       []*PricePoint          `protobuf:"bytes,2,rep,name=history,proto3" json:"history,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetHistoryResponse) Reset() {
+	*x = AssetHistoryResponse{}
+	mi := &file_proto_assets_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetHistoryResponse) ProtoMessage() {}
+
+func (x *AssetHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetHistoryResponse.ProtoReflect.Descriptor instead.
+func (*AssetHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *AssetHistoryResponse) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AssetHistoryResponse) GetHistory() []*PricePoint {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type PricePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Open          float64                `protobuf:"fixed64,2,opt,name=open,proto3" json:"open,omitempty"`
+	High          float64                `protobuf:"fixed64,3,opt,name=high,proto3" json:"high,omitempty"`
+	Low           float64                `protobuf:"fixed64,4,opt,name=low,proto3" json:"low,omitempty"`
+	Close         float64                `protobuf:"fixed64,5,opt,name=close,proto3" json:"close,omitempty"`
+	Volume        float64                `protobuf:"fixed64,6,opt,name=volume,proto3" json:"volume,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PricePoint) Reset() {
+	*x = PricePoint{}
+	mi := &file_proto_assets_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PricePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PricePoint) ProtoMessage() {}
+
+func (x *PricePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PricePoint.ProtoReflect.Descriptor instead.
+func (*PricePoint) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *PricePoint) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *PricePoint) GetOpen() float64 {
+	if x != nil {
+		return x.Open
+	}
+	return 0
+}
+
+func (x *PricePoint) GetHigh() float64 {
+	if x != nil {
+		return x.High
+	}
+	return 0
+}
+
+func (x *PricePoint) GetLow() float64 {
+	if x != nil {
+		return x.Low
+	}
+	return 0
+}
+
+func (x *PricePoint) GetClose() float64 {
+	if x != nil {
+		return x.Close
+	}
+	return 0
+}
+
+func (x *PricePoint) GetVolume() float64 {
+	if x != nil {
+		return x.Volume
+	}
+	return 0
+}
+
+type GetPortfolioPerformanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BaseCurrency  string                 `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPortfolioPerformanceRequest) Reset() {
+	*x = GetPortfolioPerformanceRequest{}
+	mi := &file_proto_assets_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPortfolioPerformanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPortfolioPerformanceRequest) ProtoMessage() {}
+
+func (x *GetPortfolioPerformanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPortfolioPerformanceRequest.ProtoReflect.Descriptor instead.
+func (*GetPortfolioPerformanceRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetPortfolioPerformanceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPortfolioPerformanceRequest) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
 }
 
 func (x *GetPortfolioPerformanceRequest) GetStartDate() *timestamppb.Timestamp {
 	if x != nil {
-		return x.StartDate
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetPortfolioPerformanceRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+type PortfolioPerformanceResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	TotalValue           float64                `protobuf:"fixed64,1,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	TotalInvested        float64                `protobuf:"fixed64,2,opt,name=total_invested,json=totalInvested,proto3" json:"total_invested,omitempty"`
+	TotalProfitLoss      float64                `protobuf:"fixed64,3,opt,name=total_profit_loss,json=totalProfitLoss,proto3" json:"total_profit_loss,omitempty"`
+	ProfitLossPercent    float64                `protobuf:"fixed64,4,opt,name=profit_loss_percent,json=profitLossPercent,proto3" json:"profit_loss_percent,omitempty"`
+	Currency             string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Allocation           []*AssetAllocation     `protobuf:"bytes,6,rep,name=allocation,proto3" json:"allocation,omitempty"`
+	History              []*PerformancePoint    `protobuf:"bytes,7,rep,name=history,proto3" json:"history,omitempty"`
+	RealizedProfitLoss   float64                `protobuf:"fixed64,8,opt,name=realized_profit_loss,json=realizedProfitLoss,proto3" json:"realized_profit_loss,omitempty"`
+	UnrealizedProfitLoss float64                `protobuf:"fixed64,9,opt,name=unrealized_profit_loss,json=unrealizedProfitLoss,proto3" json:"unrealized_profit_loss,omitempty"`
+	DividendIncomeTtm    float64                `protobuf:"fixed64,10,opt,name=dividend_income_ttm,json=dividendIncomeTtm,proto3" json:"dividend_income_ttm,omitempty"`
+	DividendYield        float64                `protobuf:"fixed64,11,opt,name=dividend_yield,json=dividendYield,proto3" json:"dividend_yield,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *PortfolioPerformanceResponse) Reset() {
+	*x = PortfolioPerformanceResponse{}
+	mi := &file_proto_assets_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PortfolioPerformanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PortfolioPerformanceResponse) ProtoMessage() {}
+
+func (x *PortfolioPerformanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PortfolioPerformanceResponse.ProtoReflect.Descriptor instead.
+func (*PortfolioPerformanceResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *PortfolioPerformanceResponse) GetTotalValue() float64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetTotalInvested() float64 {
+	if x != nil {
+		return x.TotalInvested
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetTotalProfitLoss() float64 {
+	if x != nil {
+		return x.TotalProfitLoss
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetProfitLossPercent() float64 {
+	if x != nil {
+		return x.ProfitLossPercent
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PortfolioPerformanceResponse) GetAllocation() []*AssetAllocation {
+	if x != nil {
+		return x.Allocation
+	}
+	return nil
+}
+
+func (x *PortfolioPerformanceResponse) GetHistory() []*PerformancePoint {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *PortfolioPerformanceResponse) GetRealizedProfitLoss() float64 {
+	if x != nil {
+		return x.RealizedProfitLoss
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetUnrealizedProfitLoss() float64 {
+	if x != nil {
+		return x.UnrealizedProfitLoss
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetDividendIncomeTtm() float64 {
+	if x != nil {
+		return x.DividendIncomeTtm
+	}
+	return 0
+}
+
+func (x *PortfolioPerformanceResponse) GetDividendYield() float64 {
+	if x != nil {
+		return x.DividendYield
+	}
+	return 0
+}
+
+type AssetAllocation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          AssetType              `protobuf:"varint,1,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetAllocation) Reset() {
+	*x = AssetAllocation{}
+	mi := &file_proto_assets_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssetAllocation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssetAllocation) ProtoMessage() {}
+
+func (x *AssetAllocation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssetAllocation.ProtoReflect.Descriptor instead.
+func (*AssetAllocation) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *AssetAllocation) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *AssetAllocation) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *AssetAllocation) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+type PerformancePoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PerformancePoint) Reset() {
+	*x = PerformancePoint{}
+	mi := &file_proto_assets_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PerformancePoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PerformancePoint) ProtoMessage() {}
+
+func (x *PerformancePoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PerformancePoint.ProtoReflect.Descriptor instead.
+func (*PerformancePoint) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *PerformancePoint) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *PerformancePoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+type GetHoldingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHoldingsRequest) Reset() {
+	*x = GetHoldingsRequest{}
+	mi := &file_proto_assets_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHoldingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHoldingsRequest) ProtoMessage() {}
+
+func (x *GetHoldingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHoldingsRequest.ProtoReflect.Descriptor instead.
+func (*GetHoldingsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetHoldingsRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type Holding struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Symbol               string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name                 string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type                 AssetType              `protobuf:"varint,3,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	Currency             string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	Quantity             float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	AveragePurchasePrice float64                `protobuf:"fixed64,6,opt,name=average_purchase_price,json=averagePurchasePrice,proto3" json:"average_purchase_price,omitempty"`
+	CurrentPrice         float64                `protobuf:"fixed64,7,opt,name=current_price,json=currentPrice,proto3" json:"current_price,omitempty"`
+	TotalValue           float64                `protobuf:"fixed64,8,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
+	TotalInvested        float64                `protobuf:"fixed64,9,opt,name=total_invested,json=totalInvested,proto3" json:"total_invested,omitempty"`
+	ProfitLoss           float64                `protobuf:"fixed64,10,opt,name=profit_loss,json=profitLoss,proto3" json:"profit_loss,omitempty"`
+	ProfitLossPercent    float64                `protobuf:"fixed64,11,opt,name=profit_loss_percent,json=profitLossPercent,proto3" json:"profit_loss_percent,omitempty"`
+	AssetIds             []string               `protobuf:"bytes,12,rep,name=asset_ids,json=assetIds,proto3" json:"asset_ids,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *Holding) Reset() {
+	*x = Holding{}
+	mi := &file_proto_assets_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Holding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Holding) ProtoMessage() {}
+
+func (x *Holding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Holding.ProtoReflect.Descriptor instead.
+func (*Holding) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *Holding) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Holding) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Holding) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *Holding) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Holding) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Holding) GetAveragePurchasePrice() float64 {
+	if x != nil {
+		return x.AveragePurchasePrice
+	}
+	return 0
+}
+
+func (x *Holding) GetCurrentPrice() float64 {
+	if x != nil {
+		return x.CurrentPrice
+	}
+	return 0
+}
+
+func (x *Holding) GetTotalValue() float64 {
+	if x != nil {
+		return x.TotalValue
+	}
+	return 0
+}
+
+func (x *Holding) GetTotalInvested() float64 {
+	if x != nil {
+		return x.TotalInvested
+	}
+	return 0
+}
+
+func (x *Holding) GetProfitLoss() float64 {
+	if x != nil {
+		return x.ProfitLoss
+	}
+	return 0
+}
+
+func (x *Holding) GetProfitLossPercent() float64 {
+	if x != nil {
+		return x.ProfitLossPercent
+	}
+	return 0
+}
+
+func (x *Holding) GetAssetIds() []string {
+	if x != nil {
+		return x.AssetIds
 	}
 	return nil
 }
 
-func (x *GetPortfolioPerformanceRequest) GetEndDate() *timestamppb.Timestamp {
+type GetHoldingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Holdings      []*Holding             `protobuf:"bytes,1,rep,name=holdings,proto3" json:"holdings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetHoldingsResponse) Reset() {
+	*x = GetHoldingsResponse{}
+	mi := &file_proto_assets_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetHoldingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetHoldingsResponse) ProtoMessage() {}
+
+func (x *GetHoldingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[33]
 	if x != nil {
-		return x.EndDate
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetHoldingsResponse.ProtoReflect.Descriptor instead.
+func (*GetHoldingsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetHoldingsResponse) GetHoldings() []*Holding {
+	if x != nil {
+		return x.Holdings
 	}
 	return nil
 }
 
-type PortfolioPerformanceResponse struct {
-	state             protoimpl.MessageState `protogen:"open.v1"`
-	TotalValue        float64                `protobuf:"fixed64,1,opt,name=total_value,json=totalValue,proto3" json:"total_value,omitempty"`
-	TotalInvested     float64                `protobuf:"fixed64,2,opt,name=total_invested,json=totalInvested,proto3" json:"total_invested,omitempty"`
-	TotalProfitLoss   float64                `protobuf:"fixed64,3,opt,name=total_profit_loss,json=totalProfitLoss,proto3" json:"total_profit_loss,omitempty"`
-	ProfitLossPercent float64                `protobuf:"fixed64,4,opt,name=profit_loss_percent,json=profitLossPercent,proto3" json:"profit_loss_percent,omitempty"`
-	Currency          string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
-	Allocation        []*AssetAllocation     `protobuf:"bytes,6,rep,name=allocation,proto3" json:"allocation,omitempty"`
-	History           []*PerformancePoint    `protobuf:"bytes,7,rep,name=history,proto3" json:"history,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+type SearchAssetsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PortfolioPerformanceResponse) Reset() {
-	*x = PortfolioPerformanceResponse{}
-	mi := &file_proto_assets_proto_msgTypes[18]
+func (x *SearchAssetsRequest) Reset() {
+	*x = SearchAssetsRequest{}
+	mi := &file_proto_assets_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAssetsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAssetsRequest) ProtoMessage() {}
+
+func (x *SearchAssetsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAssetsRequest.ProtoReflect.Descriptor instead.
+func (*SearchAssetsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SearchAssetsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchAssetsRequest) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
+}
+
+func (x *SearchAssetsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchAssetsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*AssetSearchResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchAssetsResponse) Reset() {
+	*x = SearchAssetsResponse{}
+	mi := &file_proto_assets_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchAssetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchAssetsResponse) ProtoMessage() {}
+
+func (x *SearchAssetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchAssetsResponse.ProtoReflect.Descriptor instead.
+func (*SearchAssetsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SearchAssetsResponse) GetResults() []*AssetSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+type AssetSearchResult struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Symbol   string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Type     AssetType              `protobuf:"varint,3,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	Exchange string                 `protobuf:"bytes,4,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	Currency string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	// Asset type of the provider that returned the result
+	Source        string `protobuf:"bytes,6,opt,name=source,proto3" json:"source,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AssetSearchResult) Reset() {
+	*x = AssetSearchResult{}
+	mi := &file_proto_assets_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PortfolioPerformanceResponse) String() string {
+func (x *AssetSearchResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PortfolioPerformanceResponse) ProtoMessage() {}
+func (*AssetSearchResult) ProtoMessage() {}
 
-func (x *PortfolioPerformanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[18]
+func (x *AssetSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1480,84 +2742,85 @@ func (x *PortfolioPerformanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PortfolioPerformanceResponse.ProtoReflect.Descriptor instead.
-func (*PortfolioPerformanceResponse) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use AssetSearchResult.ProtoReflect.Descriptor instead.
+func (*AssetSearchResult) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *PortfolioPerformanceResponse) GetTotalValue() float64 {
+func (x *AssetSearchResult) GetSymbol() string {
 	if x != nil {
-		return x.TotalValue
+		return x.Symbol
 	}
-	return 0
+	return ""
 }
 
-func (x *PortfolioPerformanceResponse) GetTotalInvested() float64 {
+func (x *AssetSearchResult) GetName() string {
 	if x != nil {
-		return x.TotalInvested
+		return x.Name
 	}
-	return 0
+	return ""
 }
 
-func (x *PortfolioPerformanceResponse) GetTotalProfitLoss() float64 {
+func (x *AssetSearchResult) GetType() AssetType {
 	if x != nil {
-		return x.TotalProfitLoss
+		return x.Type
 	}
-	return 0
+	return AssetType_ASSET_TYPE_UNSPECIFIED
 }
 
-func (x *PortfolioPerformanceResponse) GetProfitLossPercent() float64 {
+func (x *AssetSearchResult) GetExchange() string {
 	if x != nil {
-		return x.ProfitLossPercent
+		return x.Exchange
 	}
-	return 0
+	return ""
 }
 
-func (x *PortfolioPerformanceResponse) GetCurrency() string {
+func (x *AssetSearchResult) GetCurrency() string {
 	if x != nil {
 		return x.Currency
 	}
 	return ""
 }
 
-func (x *PortfolioPerformanceResponse) GetAllocation() []*AssetAllocation {
-	if x != nil {
-		return x.Allocation
-	}
-	return nil
-}
-
-func (x *PortfolioPerformanceResponse) GetHistory() []*PerformancePoint {
+func (x *AssetSearchResult) GetSource() string {
 	if x != nil {
-		return x.History
+		return x.Source
 	}
-	return nil
+	return ""
 }
 
-type AssetAllocation struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Type          AssetType              `protobuf:"varint,1,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
-	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
-	Percentage    float64                `protobuf:"fixed64,3,opt,name=percentage,proto3" json:"percentage,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+type PriceAlert struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Id     string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type   AssetType              `protobuf:"varint,4,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	// "above" or "below"
+	Direction       string                 `protobuf:"bytes,5,opt,name=direction,proto3" json:"direction,omitempty"`
+	Threshold       float64                `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	OneShot         bool                   `protobuf:"varint,7,opt,name=one_shot,json=oneShot,proto3" json:"one_shot,omitempty"`
+	Active          bool                   `protobuf:"varint,8,opt,name=active,proto3" json:"active,omitempty"`
+	LastTriggeredAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_triggered_at,json=lastTriggeredAt,proto3" json:"last_triggered_at,omitempty"`
+	CreatedAt       *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *AssetAllocation) Reset() {
-	*x = AssetAllocation{}
-	mi := &file_proto_assets_proto_msgTypes[19]
+func (x *PriceAlert) Reset() {
+	*x = PriceAlert{}
+	mi := &file_proto_assets_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssetAllocation) String() string {
+func (x *PriceAlert) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssetAllocation) ProtoMessage() {}
+func (*PriceAlert) ProtoMessage() {}
 
-func (x *AssetAllocation) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[19]
+func (x *PriceAlert) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1568,108 +2831,108 @@ func (x *AssetAllocation) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssetAllocation.ProtoReflect.Descriptor instead.
-func (*AssetAllocation) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use PriceAlert.ProtoReflect.Descriptor instead.
+func (*PriceAlert) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *AssetAllocation) GetType() AssetType {
+func (x *PriceAlert) GetId() string {
 	if x != nil {
-		return x.Type
+		return x.Id
 	}
-	return AssetType_ASSET_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *AssetAllocation) GetValue() float64 {
+func (x *PriceAlert) GetUserId() string {
 	if x != nil {
-		return x.Value
+		return x.UserId
 	}
-	return 0
+	return ""
 }
 
-func (x *AssetAllocation) GetPercentage() float64 {
+func (x *PriceAlert) GetSymbol() string {
 	if x != nil {
-		return x.Percentage
+		return x.Symbol
 	}
-	return 0
+	return ""
 }
 
-type PerformancePoint struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
-	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *PriceAlert) GetType() AssetType {
+	if x != nil {
+		return x.Type
+	}
+	return AssetType_ASSET_TYPE_UNSPECIFIED
 }
 
-func (x *PerformancePoint) Reset() {
-	*x = PerformancePoint{}
-	mi := &file_proto_assets_proto_msgTypes[20]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *PriceAlert) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
 }
 
-func (x *PerformancePoint) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *PriceAlert) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
 }
 
-func (*PerformancePoint) ProtoMessage() {}
-
-func (x *PerformancePoint) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[20]
+func (x *PriceAlert) GetOneShot() bool {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.OneShot
 	}
-	return mi.MessageOf(x)
+	return false
 }
 
-// Deprecated: Use PerformancePoint.ProtoReflect.Descriptor instead.
-func (*PerformancePoint) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{20}
+func (x *PriceAlert) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
 }
 
-func (x *PerformancePoint) GetDate() *timestamppb.Timestamp {
+func (x *PriceAlert) GetLastTriggeredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Date
+		return x.LastTriggeredAt
 	}
 	return nil
 }
 
-func (x *PerformancePoint) GetValue() float64 {
+func (x *PriceAlert) GetCreatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Value
+		return x.CreatedAt
 	}
-	return 0
+	return nil
 }
 
-type SearchAssetsRequest struct {
+type CreatePriceAlertRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Query         string                 `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
-	Type          AssetType              `protobuf:"varint,2,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
-	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Type          AssetType              `protobuf:"varint,3,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
+	Direction     string                 `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	Threshold     float64                `protobuf:"fixed64,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	OneShot       bool                   `protobuf:"varint,6,opt,name=one_shot,json=oneShot,proto3" json:"one_shot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchAssetsRequest) Reset() {
-	*x = SearchAssetsRequest{}
-	mi := &file_proto_assets_proto_msgTypes[21]
+func (x *CreatePriceAlertRequest) Reset() {
+	*x = CreatePriceAlertRequest{}
+	mi := &file_proto_assets_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchAssetsRequest) String() string {
+func (x *CreatePriceAlertRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchAssetsRequest) ProtoMessage() {}
+func (*CreatePriceAlertRequest) ProtoMessage() {}
 
-func (x *SearchAssetsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[21]
+func (x *CreatePriceAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1680,54 +2943,75 @@ func (x *SearchAssetsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchAssetsRequest.ProtoReflect.Descriptor instead.
-func (*SearchAssetsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use CreatePriceAlertRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceAlertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *SearchAssetsRequest) GetQuery() string {
+func (x *CreatePriceAlertRequest) GetUserId() string {
 	if x != nil {
-		return x.Query
+		return x.UserId
 	}
 	return ""
 }
 
-func (x *SearchAssetsRequest) GetType() AssetType {
+func (x *CreatePriceAlertRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *CreatePriceAlertRequest) GetType() AssetType {
 	if x != nil {
 		return x.Type
 	}
 	return AssetType_ASSET_TYPE_UNSPECIFIED
 }
 
-func (x *SearchAssetsRequest) GetLimit() int32 {
+func (x *CreatePriceAlertRequest) GetDirection() string {
 	if x != nil {
-		return x.Limit
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *CreatePriceAlertRequest) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
 	}
 	return 0
 }
 
-type SearchAssetsResponse struct {
+func (x *CreatePriceAlertRequest) GetOneShot() bool {
+	if x != nil {
+		return x.OneShot
+	}
+	return false
+}
+
+type ListPriceAlertsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Results       []*AssetSearchResult   `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SearchAssetsResponse) Reset() {
-	*x = SearchAssetsResponse{}
-	mi := &file_proto_assets_proto_msgTypes[22]
+func (x *ListPriceAlertsRequest) Reset() {
+	*x = ListPriceAlertsRequest{}
+	mi := &file_proto_assets_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SearchAssetsResponse) String() string {
+func (x *ListPriceAlertsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SearchAssetsResponse) ProtoMessage() {}
+func (*ListPriceAlertsRequest) ProtoMessage() {}
 
-func (x *SearchAssetsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[22]
+func (x *ListPriceAlertsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1738,44 +3022,40 @@ func (x *SearchAssetsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SearchAssetsResponse.ProtoReflect.Descriptor instead.
-func (*SearchAssetsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use ListPriceAlertsRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceAlertsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *SearchAssetsResponse) GetResults() []*AssetSearchResult {
+func (x *ListPriceAlertsRequest) GetUserId() string {
 	if x != nil {
-		return x.Results
+		return x.UserId
 	}
-	return nil
+	return ""
 }
 
-type AssetSearchResult struct {
+type ListPriceAlertsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Type          AssetType              `protobuf:"varint,3,opt,name=type,proto3,enum=assets.AssetType" json:"type,omitempty"`
-	Exchange      string                 `protobuf:"bytes,4,opt,name=exchange,proto3" json:"exchange,omitempty"`
-	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Alerts        []*PriceAlert          `protobuf:"bytes,1,rep,name=alerts,proto3" json:"alerts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssetSearchResult) Reset() {
-	*x = AssetSearchResult{}
-	mi := &file_proto_assets_proto_msgTypes[23]
+func (x *ListPriceAlertsResponse) Reset() {
+	*x = ListPriceAlertsResponse{}
+	mi := &file_proto_assets_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AssetSearchResult) String() string {
+func (x *ListPriceAlertsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AssetSearchResult) ProtoMessage() {}
+func (*ListPriceAlertsResponse) ProtoMessage() {}
 
-func (x *AssetSearchResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_assets_proto_msgTypes[23]
+func (x *ListPriceAlertsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1786,42 +3066,66 @@ func (x *AssetSearchResult) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AssetSearchResult.ProtoReflect.Descriptor instead.
-func (*AssetSearchResult) Descriptor() ([]byte, []int) {
-	return file_proto_assets_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use ListPriceAlertsResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceAlertsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *AssetSearchResult) GetSymbol() string {
+func (x *ListPriceAlertsResponse) GetAlerts() []*PriceAlert {
 	if x != nil {
-		return x.Symbol
+		return x.Alerts
 	}
-	return ""
+	return nil
 }
 
-func (x *AssetSearchResult) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+type DeletePriceAlertRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AssetSearchResult) GetType() AssetType {
+func (x *DeletePriceAlertRequest) Reset() {
+	*x = DeletePriceAlertRequest{}
+	mi := &file_proto_assets_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePriceAlertRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePriceAlertRequest) ProtoMessage() {}
+
+func (x *DeletePriceAlertRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_assets_proto_msgTypes[41]
 	if x != nil {
-		return x.Type
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return AssetType_ASSET_TYPE_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *AssetSearchResult) GetExchange() string {
+// Deprecated: Use DeletePriceAlertRequest.ProtoReflect.Descriptor instead.
+func (*DeletePriceAlertRequest) Descriptor() ([]byte, []int) {
+	return file_proto_assets_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *DeletePriceAlertRequest) GetId() string {
 	if x != nil {
-		return x.Exchange
+		return x.Id
 	}
 	return ""
 }
 
-func (x *AssetSearchResult) GetCurrency() string {
+func (x *DeletePriceAlertRequest) GetUserId() string {
 	if x != nil {
-		return x.Currency
+		return x.UserId
 	}
 	return ""
 }
@@ -1830,7 +3134,7 @@ var File_proto_assets_proto protoreflect.FileDescriptor
 
 const file_proto_assets_proto_rawDesc = "" +
 	"\n" +
-	"\x12proto/assets.proto\x12\x06assets\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x92\x06\n" +
+	"\x12proto/assets.proto\x12\x06assets\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xac\x06\n" +
 	"\x05Asset\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
@@ -1854,7 +3158,8 @@ const file_proto_assets_proto_rawDesc = "" +
 	"created_at\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x127\n" +
-	"\bmetadata\x18\x12 \x03(\v2\x1b.assets.Asset.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\x12 \x03(\v2\x1b.assets.Asset.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\aversion\x18\x13 \x01(\x03R\aversion\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc9\x03\n" +
@@ -1889,7 +3194,7 @@ const file_proto_assets_proto_rawDesc = "" +
 	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
 	"\vtotal_value\x18\x05 \x01(\x01R\n" +
 	"totalValue\x12*\n" +
-	"\x11total_profit_loss\x18\x06 \x01(\x01R\x0ftotalProfitLoss\"\xbc\x02\n" +
+	"\x11total_profit_loss\x18\x06 \x01(\x01R\x0ftotalProfitLoss\"\xd6\x02\n" +
 	"\x12UpdateAssetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
@@ -1897,16 +3202,76 @@ const file_proto_assets_proto_rawDesc = "" +
 	"\x0epurchase_price\x18\x04 \x01(\x01R\rpurchasePrice\x12#\n" +
 	"\rcurrent_price\x18\x05 \x01(\x01R\fcurrentPrice\x12\x12\n" +
 	"\x04name\x18\x06 \x01(\tR\x04name\x12D\n" +
-	"\bmetadata\x18\a \x03(\v2(.assets.UpdateAssetRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\bmetadata\x18\a \x03(\v2(.assets.UpdateAssetRequest.MetadataEntryR\bmetadata\x12\x18\n" +
+	"\aversion\x18\b \x01(\x03R\aversion\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"=\n" +
 	"\x12DeleteAssetRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"U\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x95\x01\n" +
+	"\x17SubAccountAssetsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12&\n" +
+	"\x0fsub_account_ids\x18\x02 \x03(\tR\rsubAccountIds\x129\n" +
+	"\n" +
+	"deleted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\"0\n" +
+	"\x18SubAccountAssetsResponse\x12\x14\n" +
+	"\x05count\x18\x01 \x01(\x03R\x05count\"m\n" +
+	"\x10SellAssetRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x01R\bquantity\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\"\x91\x02\n" +
+	"\x11SellAssetResponse\x12#\n" +
+	"\x05asset\x18\x01 \x01(\v2\r.assets.AssetR\x05asset\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x1d\n" +
+	"\n" +
+	"sale_price\x18\x03 \x01(\x01R\tsalePrice\x12\x1a\n" +
+	"\bproceeds\x18\x04 \x01(\x01R\bproceeds\x12\x1d\n" +
+	"\n" +
+	"cost_basis\x18\x05 \x01(\x01R\tcostBasis\x12,\n" +
+	"\x12realized_gain_loss\x18\x06 \x01(\x01R\x10realizedGainLoss\x123\n" +
+	"\asold_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06soldAt\"\xd7\x02\n" +
+	"\bDividend\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\basset_id\x18\x02 \x01(\tR\aassetId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x04 \x01(\tR\x06symbol\x12\x16\n" +
+	"\x06amount\x18\x05 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x06 \x01(\tR\bcurrency\x123\n" +
+	"\aex_date\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x06exDate\x125\n" +
+	"\bpay_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\apayDate\x12\x14\n" +
+	"\x05notes\x18\t \x01(\tR\x05notes\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x81\x02\n" +
+	"\x15RecordDividendRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x123\n" +
+	"\aex_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06exDate\x125\n" +
+	"\bpay_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\apayDate\x12\x14\n" +
+	"\x05notes\x18\a \x01(\tR\x05notes\"J\n" +
+	"\x14ListDividendsRequest\x12\x19\n" +
+	"\basset_id\x18\x01 \x01(\tR\aassetId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"G\n" +
+	"\x15ListDividendsResponse\x12.\n" +
+	"\tdividends\x18\x01 \x03(\v2\x10.assets.DividendR\tdividends\"\xa5\x01\n" +
+	"\x18GetDividendIncomeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xae\x01\n" +
+	"\x19GetDividendIncomeResponse\x12R\n" +
+	"\vby_currency\x18\x01 \x03(\v21.assets.GetDividendIncomeResponse.ByCurrencyEntryR\n" +
+	"byCurrency\x1a=\n" +
+	"\x0fByCurrencyEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"U\n" +
 	"\x14GetAssetPriceRequest\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12%\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x11.assets.AssetTypeR\x04type\"\xd8\x02\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x11.assets.AssetTypeR\x04type\"\xee\x02\n" +
 	"\x12AssetPriceResponse\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x14\n" +
 	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1a\n" +
@@ -1922,7 +3287,8 @@ const file_proto_assets_proto_rawDesc = "" +
 	"market_cap\x18\t \x01(\x01R\tmarketCap\x129\n" +
 	"\n" +
 	"updated_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"R\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05stale\x18\v \x01(\bR\x05stale\"R\n" +
 	"\x1dGetMultipleAssetPricesRequest\x121\n" +
 	"\aqueries\x18\x01 \x03(\v2\x17.assets.AssetPriceQueryR\aqueries\"P\n" +
 	"\x0fAssetPriceQuery\x12\x16\n" +
@@ -1964,7 +3330,7 @@ const file_proto_assets_proto_rawDesc = "" +
 	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\x129\n" +
 	"\n" +
 	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\xcb\x02\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"\x8a\x04\n" +
 	"\x1cPortfolioPerformanceResponse\x12\x1f\n" +
 	"\vtotal_value\x18\x01 \x01(\x01R\n" +
 	"totalValue\x12%\n" +
@@ -1975,7 +3341,12 @@ const file_proto_assets_proto_rawDesc = "" +
 	"\n" +
 	"allocation\x18\x06 \x03(\v2\x17.assets.AssetAllocationR\n" +
 	"allocation\x122\n" +
-	"\ahistory\x18\a \x03(\v2\x18.assets.PerformancePointR\ahistory\"n\n" +
+	"\ahistory\x18\a \x03(\v2\x18.assets.PerformancePointR\ahistory\x120\n" +
+	"\x14realized_profit_loss\x18\b \x01(\x01R\x12realizedProfitLoss\x124\n" +
+	"\x16unrealized_profit_loss\x18\t \x01(\x01R\x14unrealizedProfitLoss\x12.\n" +
+	"\x13dividend_income_ttm\x18\n" +
+	" \x01(\x01R\x11dividendIncomeTtm\x12%\n" +
+	"\x0edividend_yield\x18\v \x01(\x01R\rdividendYield\"n\n" +
 	"\x0fAssetAllocation\x12%\n" +
 	"\x04type\x18\x01 \x01(\x0e2\x11.assets.AssetTypeR\x04type\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\x01R\x05value\x12\x1e\n" +
@@ -1984,19 +3355,68 @@ const file_proto_assets_proto_rawDesc = "" +
 	"percentage\"X\n" +
 	"\x10PerformancePoint\x12.\n" +
 	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value\"h\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"-\n" +
+	"\x12GetHoldingsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xa5\x03\n" +
+	"\aHolding\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x11.assets.AssetTypeR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x01R\bquantity\x124\n" +
+	"\x16average_purchase_price\x18\x06 \x01(\x01R\x14averagePurchasePrice\x12#\n" +
+	"\rcurrent_price\x18\a \x01(\x01R\fcurrentPrice\x12\x1f\n" +
+	"\vtotal_value\x18\b \x01(\x01R\n" +
+	"totalValue\x12%\n" +
+	"\x0etotal_invested\x18\t \x01(\x01R\rtotalInvested\x12\x1f\n" +
+	"\vprofit_loss\x18\n" +
+	" \x01(\x01R\n" +
+	"profitLoss\x12.\n" +
+	"\x13profit_loss_percent\x18\v \x01(\x01R\x11profitLossPercent\x12\x1b\n" +
+	"\tasset_ids\x18\f \x03(\tR\bassetIds\"B\n" +
+	"\x13GetHoldingsResponse\x12+\n" +
+	"\bholdings\x18\x01 \x03(\v2\x0f.assets.HoldingR\bholdings\"h\n" +
 	"\x13SearchAssetsRequest\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\x12%\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x11.assets.AssetTypeR\x04type\x12\x14\n" +
 	"\x05limit\x18\x03 \x01(\x05R\x05limit\"K\n" +
 	"\x14SearchAssetsResponse\x123\n" +
-	"\aresults\x18\x01 \x03(\v2\x19.assets.AssetSearchResultR\aresults\"\x9e\x01\n" +
+	"\aresults\x18\x01 \x03(\v2\x19.assets.AssetSearchResultR\aresults\"\xb6\x01\n" +
 	"\x11AssetSearchResult\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
 	"\x04type\x18\x03 \x01(\x0e2\x11.assets.AssetTypeR\x04type\x12\x1a\n" +
 	"\bexchange\x18\x04 \x01(\tR\bexchange\x12\x1a\n" +
-	"\bcurrency\x18\x05 \x01(\tR\bcurrency*\xde\x01\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x16\n" +
+	"\x06source\x18\x06 \x01(\tR\x06source\"\xe6\x02\n" +
+	"\n" +
+	"PriceAlert\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12%\n" +
+	"\x04type\x18\x04 \x01(\x0e2\x11.assets.AssetTypeR\x04type\x12\x1c\n" +
+	"\tdirection\x18\x05 \x01(\tR\tdirection\x12\x1c\n" +
+	"\tthreshold\x18\x06 \x01(\x01R\tthreshold\x12\x19\n" +
+	"\bone_shot\x18\a \x01(\bR\aoneShot\x12\x16\n" +
+	"\x06active\x18\b \x01(\bR\x06active\x12F\n" +
+	"\x11last_triggered_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x0flastTriggeredAt\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\xc8\x01\n" +
+	"\x17CreatePriceAlertRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12%\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x11.assets.AssetTypeR\x04type\x12\x1c\n" +
+	"\tdirection\x18\x04 \x01(\tR\tdirection\x12\x1c\n" +
+	"\tthreshold\x18\x05 \x01(\x01R\tthreshold\x12\x19\n" +
+	"\bone_shot\x18\x06 \x01(\bR\aoneShot\"1\n" +
+	"\x16ListPriceAlertsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"E\n" +
+	"\x17ListPriceAlertsResponse\x12*\n" +
+	"\x06alerts\x18\x01 \x03(\v2\x12.assets.PriceAlertR\x06alerts\"B\n" +
+	"\x17DeletePriceAlertRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId*\xde\x01\n" +
 	"\tAssetType\x12\x1a\n" +
 	"\x16ASSET_TYPE_UNSPECIFIED\x10\x00\x12\x14\n" +
 	"\x10ASSET_TYPE_STOCK\x10\x01\x12\x15\n" +
@@ -2006,20 +3426,30 @@ const file_proto_assets_proto_rawDesc = "" +
 	"\x0fASSET_TYPE_CASH\x10\x05\x12\x13\n" +
 	"\x0fASSET_TYPE_BOND\x10\x06\x12\x18\n" +
 	"\x14ASSET_TYPE_COMMODITY\x10\a\x12\x14\n" +
-	"\x10ASSET_TYPE_OTHER\x10\b2\xd5\x06\n" +
+	"\x10ASSET_TYPE_OTHER\x10\b2\xef\f\n" +
 	"\rAssetsService\x128\n" +
 	"\vCreateAsset\x12\x1a.assets.CreateAssetRequest\x1a\r.assets.Asset\x122\n" +
 	"\bGetAsset\x12\x17.assets.GetAssetRequest\x1a\r.assets.Asset\x12C\n" +
 	"\n" +
 	"ListAssets\x12\x19.assets.ListAssetsRequest\x1a\x1a.assets.ListAssetsResponse\x128\n" +
 	"\vUpdateAsset\x12\x1a.assets.UpdateAssetRequest\x1a\r.assets.Asset\x12A\n" +
-	"\vDeleteAsset\x12\x1a.assets.DeleteAssetRequest\x1a\x16.google.protobuf.Empty\x12I\n" +
+	"\vDeleteAsset\x12\x1a.assets.DeleteAssetRequest\x1a\x16.google.protobuf.Empty\x12[\n" +
+	"\x16DeleteSubAccountAssets\x12\x1f.assets.SubAccountAssetsRequest\x1a .assets.SubAccountAssetsResponse\x12\\\n" +
+	"\x17RestoreSubAccountAssets\x12\x1f.assets.SubAccountAssetsRequest\x1a .assets.SubAccountAssetsResponse\x12@\n" +
+	"\tSellAsset\x12\x18.assets.SellAssetRequest\x1a\x19.assets.SellAssetResponse\x12A\n" +
+	"\x0eRecordDividend\x12\x1d.assets.RecordDividendRequest\x1a\x10.assets.Dividend\x12L\n" +
+	"\rListDividends\x12\x1c.assets.ListDividendsRequest\x1a\x1d.assets.ListDividendsResponse\x12X\n" +
+	"\x11GetDividendIncome\x12 .assets.GetDividendIncomeRequest\x1a!.assets.GetDividendIncomeResponse\x12I\n" +
 	"\rGetAssetPrice\x12\x1c.assets.GetAssetPriceRequest\x1a\x1a.assets.AssetPriceResponse\x12g\n" +
 	"\x16GetMultipleAssetPrices\x12%.assets.GetMultipleAssetPricesRequest\x1a&.assets.GetMultipleAssetPricesResponse\x12[\n" +
 	"\x12RefreshAssetPrices\x12!.assets.RefreshAssetPricesRequest\x1a\".assets.RefreshAssetPricesResponse\x12O\n" +
 	"\x0fGetAssetHistory\x12\x1e.assets.GetAssetHistoryRequest\x1a\x1c.assets.AssetHistoryResponse\x12g\n" +
-	"\x17GetPortfolioPerformance\x12&.assets.GetPortfolioPerformanceRequest\x1a$.assets.PortfolioPerformanceResponse\x12I\n" +
-	"\fSearchAssets\x12\x1b.assets.SearchAssetsRequest\x1a\x1c.assets.SearchAssetsResponseB9Z7github.com/radmickey/money-control/backend/proto/assetsb\x06proto3"
+	"\x17GetPortfolioPerformance\x12&.assets.GetPortfolioPerformanceRequest\x1a$.assets.PortfolioPerformanceResponse\x12F\n" +
+	"\vGetHoldings\x12\x1a.assets.GetHoldingsRequest\x1a\x1b.assets.GetHoldingsResponse\x12I\n" +
+	"\fSearchAssets\x12\x1b.assets.SearchAssetsRequest\x1a\x1c.assets.SearchAssetsResponse\x12G\n" +
+	"\x10CreatePriceAlert\x12\x1f.assets.CreatePriceAlertRequest\x1a\x12.assets.PriceAlert\x12R\n" +
+	"\x0fListPriceAlerts\x12\x1e.assets.ListPriceAlertsRequest\x1a\x1f.assets.ListPriceAlertsResponse\x12K\n" +
+	"\x10DeletePriceAlert\x12\x1f.assets.DeletePriceAlertRequest\x1a\x16.google.protobuf.EmptyB9Z7github.com/radmickey/money-control/backend/proto/assetsb\x06proto3"
 
 var (
 	file_proto_assets_proto_rawDescOnce sync.Once
@@ -2034,7 +3464,7 @@ func file_proto_assets_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_assets_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_assets_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_proto_assets_proto_msgTypes = make([]protoimpl.MessageInfo, 47)
 var file_proto_assets_proto_goTypes = []any{
 	(AssetType)(0),                         // 0: assets.AssetType
 	(*Asset)(nil),                          // 1: assets.Asset
@@ -2044,90 +3474,148 @@ var file_proto_assets_proto_goTypes = []any{
 	(*ListAssetsResponse)(nil),             // 5: assets.ListAssetsResponse
 	(*UpdateAssetRequest)(nil),             // 6: assets.UpdateAssetRequest
 	(*DeleteAssetRequest)(nil),             // 7: assets.DeleteAssetRequest
-	(*GetAssetPriceRequest)(nil),           // 8: assets.GetAssetPriceRequest
-	(*AssetPriceResponse)(nil),             // 9: assets.AssetPriceResponse
-	(*GetMultipleAssetPricesRequest)(nil),  // 10: assets.GetMultipleAssetPricesRequest
-	(*AssetPriceQuery)(nil),                // 11: assets.AssetPriceQuery
-	(*GetMultipleAssetPricesResponse)(nil), // 12: assets.GetMultipleAssetPricesResponse
-	(*RefreshAssetPricesRequest)(nil),      // 13: assets.RefreshAssetPricesRequest
-	(*RefreshAssetPricesResponse)(nil),     // 14: assets.RefreshAssetPricesResponse
-	(*GetAssetHistoryRequest)(nil),         // 15: assets.GetAssetHistoryRequest
-	(*AssetHistoryResponse)(nil),           // 16: assets.AssetHistoryResponse
-	(*PricePoint)(nil),                     // 17: assets.PricePoint
-	(*GetPortfolioPerformanceRequest)(nil), // 18: assets.GetPortfolioPerformanceRequest
-	(*PortfolioPerformanceResponse)(nil),   // 19: assets.PortfolioPerformanceResponse
-	(*AssetAllocation)(nil),                // 20: assets.AssetAllocation
-	(*PerformancePoint)(nil),               // 21: assets.PerformancePoint
-	(*SearchAssetsRequest)(nil),            // 22: assets.SearchAssetsRequest
-	(*SearchAssetsResponse)(nil),           // 23: assets.SearchAssetsResponse
-	(*AssetSearchResult)(nil),              // 24: assets.AssetSearchResult
-	nil,                                    // 25: assets.Asset.MetadataEntry
-	nil,                                    // 26: assets.CreateAssetRequest.MetadataEntry
-	nil,                                    // 27: assets.UpdateAssetRequest.MetadataEntry
-	nil,                                    // 28: assets.GetMultipleAssetPricesResponse.PricesEntry
-	(*timestamppb.Timestamp)(nil),          // 29: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                  // 30: google.protobuf.Empty
+	(*SubAccountAssetsRequest)(nil),        // 8: assets.SubAccountAssetsRequest
+	(*SubAccountAssetsResponse)(nil),       // 9: assets.SubAccountAssetsResponse
+	(*SellAssetRequest)(nil),               // 10: assets.SellAssetRequest
+	(*SellAssetResponse)(nil),              // 11: assets.SellAssetResponse
+	(*Dividend)(nil),                       // 12: assets.Dividend
+	(*RecordDividendRequest)(nil),          // 13: assets.RecordDividendRequest
+	(*ListDividendsRequest)(nil),           // 14: assets.ListDividendsRequest
+	(*ListDividendsResponse)(nil),          // 15: assets.ListDividendsResponse
+	(*GetDividendIncomeRequest)(nil),       // 16: assets.GetDividendIncomeRequest
+	(*GetDividendIncomeResponse)(nil),      // 17: assets.GetDividendIncomeResponse
+	(*GetAssetPriceRequest)(nil),           // 18: assets.GetAssetPriceRequest
+	(*AssetPriceResponse)(nil),             // 19: assets.AssetPriceResponse
+	(*GetMultipleAssetPricesRequest)(nil),  // 20: assets.GetMultipleAssetPricesRequest
+	(*AssetPriceQuery)(nil),                // 21: assets.AssetPriceQuery
+	(*GetMultipleAssetPricesResponse)(nil), // 22: assets.GetMultipleAssetPricesResponse
+	(*RefreshAssetPricesRequest)(nil),      // 23: assets.RefreshAssetPricesRequest
+	(*RefreshAssetPricesResponse)(nil),     // 24: assets.RefreshAssetPricesResponse
+	(*GetAssetHistoryRequest)(nil),         // 25: assets.GetAssetHistoryRequest
+	(*AssetHistoryResponse)(nil),           // 26: assets.AssetHistoryResponse
+	(*PricePoint)(nil),                     // 27: assets.PricePoint
+	(*GetPortfolioPerformanceRequest)(nil), // 28: assets.GetPortfolioPerformanceRequest
+	(*PortfolioPerformanceResponse)(nil),   // 29: assets.PortfolioPerformanceResponse
+	(*AssetAllocation)(nil),                // 30: assets.AssetAllocation
+	(*PerformancePoint)(nil),               // 31: assets.PerformancePoint
+	(*GetHoldingsRequest)(nil),             // 32: assets.GetHoldingsRequest
+	(*Holding)(nil),                        // 33: assets.Holding
+	(*GetHoldingsResponse)(nil),            // 34: assets.GetHoldingsResponse
+	(*SearchAssetsRequest)(nil),            // 35: assets.SearchAssetsRequest
+	(*SearchAssetsResponse)(nil),           // 36: assets.SearchAssetsResponse
+	(*AssetSearchResult)(nil),              // 37: assets.AssetSearchResult
+	(*PriceAlert)(nil),                     // 38: assets.PriceAlert
+	(*CreatePriceAlertRequest)(nil),        // 39: assets.CreatePriceAlertRequest
+	(*ListPriceAlertsRequest)(nil),         // 40: assets.ListPriceAlertsRequest
+	(*ListPriceAlertsResponse)(nil),        // 41: assets.ListPriceAlertsResponse
+	(*DeletePriceAlertRequest)(nil),        // 42: assets.DeletePriceAlertRequest
+	nil,                                    // 43: assets.Asset.MetadataEntry
+	nil,                                    // 44: assets.CreateAssetRequest.MetadataEntry
+	nil,                                    // 45: assets.UpdateAssetRequest.MetadataEntry
+	nil,                                    // 46: assets.GetDividendIncomeResponse.ByCurrencyEntry
+	nil,                                    // 47: assets.GetMultipleAssetPricesResponse.PricesEntry
+	(*timestamppb.Timestamp)(nil),          // 48: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                  // 49: google.protobuf.Empty
 }
 var file_proto_assets_proto_depIdxs = []int32{
 	0,  // 0: assets.Asset.type:type_name -> assets.AssetType
-	29, // 1: assets.Asset.purchase_date:type_name -> google.protobuf.Timestamp
-	29, // 2: assets.Asset.price_updated_at:type_name -> google.protobuf.Timestamp
-	29, // 3: assets.Asset.created_at:type_name -> google.protobuf.Timestamp
-	29, // 4: assets.Asset.updated_at:type_name -> google.protobuf.Timestamp
-	25, // 5: assets.Asset.metadata:type_name -> assets.Asset.MetadataEntry
+	48, // 1: assets.Asset.purchase_date:type_name -> google.protobuf.Timestamp
+	48, // 2: assets.Asset.price_updated_at:type_name -> google.protobuf.Timestamp
+	48, // 3: assets.Asset.created_at:type_name -> google.protobuf.Timestamp
+	48, // 4: assets.Asset.updated_at:type_name -> google.protobuf.Timestamp
+	43, // 5: assets.Asset.metadata:type_name -> assets.Asset.MetadataEntry
 	0,  // 6: assets.CreateAssetRequest.type:type_name -> assets.AssetType
-	29, // 7: assets.CreateAssetRequest.purchase_date:type_name -> google.protobuf.Timestamp
-	26, // 8: assets.CreateAssetRequest.metadata:type_name -> assets.CreateAssetRequest.MetadataEntry
+	48, // 7: assets.CreateAssetRequest.purchase_date:type_name -> google.protobuf.Timestamp
+	44, // 8: assets.CreateAssetRequest.metadata:type_name -> assets.CreateAssetRequest.MetadataEntry
 	0,  // 9: assets.ListAssetsRequest.type:type_name -> assets.AssetType
 	1,  // 10: assets.ListAssetsResponse.assets:type_name -> assets.Asset
-	27, // 11: assets.UpdateAssetRequest.metadata:type_name -> assets.UpdateAssetRequest.MetadataEntry
-	0,  // 12: assets.GetAssetPriceRequest.type:type_name -> assets.AssetType
-	29, // 13: assets.AssetPriceResponse.updated_at:type_name -> google.protobuf.Timestamp
-	11, // 14: assets.GetMultipleAssetPricesRequest.queries:type_name -> assets.AssetPriceQuery
-	0,  // 15: assets.AssetPriceQuery.type:type_name -> assets.AssetType
-	28, // 16: assets.GetMultipleAssetPricesResponse.prices:type_name -> assets.GetMultipleAssetPricesResponse.PricesEntry
-	0,  // 17: assets.GetAssetHistoryRequest.type:type_name -> assets.AssetType
-	29, // 18: assets.GetAssetHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
-	29, // 19: assets.GetAssetHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
-	17, // 20: assets.AssetHistoryResponse.history:type_name -> assets.PricePoint
-	29, // 21: assets.PricePoint.timestamp:type_name -> google.protobuf.Timestamp
-	29, // 22: assets.GetPortfolioPerformanceRequest.start_date:type_name -> google.protobuf.Timestamp
-	29, // 23: assets.GetPortfolioPerformanceRequest.end_date:type_name -> google.protobuf.Timestamp
-	20, // 24: assets.PortfolioPerformanceResponse.allocation:type_name -> assets.AssetAllocation
-	21, // 25: assets.PortfolioPerformanceResponse.history:type_name -> assets.PerformancePoint
-	0,  // 26: assets.AssetAllocation.type:type_name -> assets.AssetType
-	29, // 27: assets.PerformancePoint.date:type_name -> google.protobuf.Timestamp
-	0,  // 28: assets.SearchAssetsRequest.type:type_name -> assets.AssetType
-	24, // 29: assets.SearchAssetsResponse.results:type_name -> assets.AssetSearchResult
-	0,  // 30: assets.AssetSearchResult.type:type_name -> assets.AssetType
-	9,  // 31: assets.GetMultipleAssetPricesResponse.PricesEntry.value:type_name -> assets.AssetPriceResponse
-	2,  // 32: assets.AssetsService.CreateAsset:input_type -> assets.CreateAssetRequest
-	3,  // 33: assets.AssetsService.GetAsset:input_type -> assets.GetAssetRequest
-	4,  // 34: assets.AssetsService.ListAssets:input_type -> assets.ListAssetsRequest
-	6,  // 35: assets.AssetsService.UpdateAsset:input_type -> assets.UpdateAssetRequest
-	7,  // 36: assets.AssetsService.DeleteAsset:input_type -> assets.DeleteAssetRequest
-	8,  // 37: assets.AssetsService.GetAssetPrice:input_type -> assets.GetAssetPriceRequest
-	10, // 38: assets.AssetsService.GetMultipleAssetPrices:input_type -> assets.GetMultipleAssetPricesRequest
-	13, // 39: assets.AssetsService.RefreshAssetPrices:input_type -> assets.RefreshAssetPricesRequest
-	15, // 40: assets.AssetsService.GetAssetHistory:input_type -> assets.GetAssetHistoryRequest
-	18, // 41: assets.AssetsService.GetPortfolioPerformance:input_type -> assets.GetPortfolioPerformanceRequest
-	22, // 42: assets.AssetsService.SearchAssets:input_type -> assets.SearchAssetsRequest
-	1,  // 43: assets.AssetsService.CreateAsset:output_type -> assets.Asset
-	1,  // 44: assets.AssetsService.GetAsset:output_type -> assets.Asset
-	5,  // 45: assets.AssetsService.ListAssets:output_type -> assets.ListAssetsResponse
-	1,  // 46: assets.AssetsService.UpdateAsset:output_type -> assets.Asset
-	30, // 47: assets.AssetsService.DeleteAsset:output_type -> google.protobuf.Empty
-	9,  // 48: assets.AssetsService.GetAssetPrice:output_type -> assets.AssetPriceResponse
-	12, // 49: assets.AssetsService.GetMultipleAssetPrices:output_type -> assets.GetMultipleAssetPricesResponse
-	14, // 50: assets.AssetsService.RefreshAssetPrices:output_type -> assets.RefreshAssetPricesResponse
-	16, // 51: assets.AssetsService.GetAssetHistory:output_type -> assets.AssetHistoryResponse
-	19, // 52: assets.AssetsService.GetPortfolioPerformance:output_type -> assets.PortfolioPerformanceResponse
-	23, // 53: assets.AssetsService.SearchAssets:output_type -> assets.SearchAssetsResponse
-	43, // [43:54] is the sub-list for method output_type
-	32, // [32:43] is the sub-list for method input_type
-	32, // [32:32] is the sub-list for extension type_name
-	32, // [32:32] is the sub-list for extension extendee
-	0,  // [0:32] is the sub-list for field type_name
+	45, // 11: assets.UpdateAssetRequest.metadata:type_name -> assets.UpdateAssetRequest.MetadataEntry
+	48, // 12: assets.SubAccountAssetsRequest.deleted_at:type_name -> google.protobuf.Timestamp
+	1,  // 13: assets.SellAssetResponse.asset:type_name -> assets.Asset
+	48, // 14: assets.SellAssetResponse.sold_at:type_name -> google.protobuf.Timestamp
+	48, // 15: assets.Dividend.ex_date:type_name -> google.protobuf.Timestamp
+	48, // 16: assets.Dividend.pay_date:type_name -> google.protobuf.Timestamp
+	48, // 17: assets.Dividend.created_at:type_name -> google.protobuf.Timestamp
+	48, // 18: assets.RecordDividendRequest.ex_date:type_name -> google.protobuf.Timestamp
+	48, // 19: assets.RecordDividendRequest.pay_date:type_name -> google.protobuf.Timestamp
+	12, // 20: assets.ListDividendsResponse.dividends:type_name -> assets.Dividend
+	48, // 21: assets.GetDividendIncomeRequest.start_date:type_name -> google.protobuf.Timestamp
+	48, // 22: assets.GetDividendIncomeRequest.end_date:type_name -> google.protobuf.Timestamp
+	46, // 23: assets.GetDividendIncomeResponse.by_currency:type_name -> assets.GetDividendIncomeResponse.ByCurrencyEntry
+	0,  // 24: assets.GetAssetPriceRequest.type:type_name -> assets.AssetType
+	48, // 25: assets.AssetPriceResponse.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 26: assets.GetMultipleAssetPricesRequest.queries:type_name -> assets.AssetPriceQuery
+	0,  // 27: assets.AssetPriceQuery.type:type_name -> assets.AssetType
+	47, // 28: assets.GetMultipleAssetPricesResponse.prices:type_name -> assets.GetMultipleAssetPricesResponse.PricesEntry
+	0,  // 29: assets.GetAssetHistoryRequest.type:type_name -> assets.AssetType
+	48, // 30: assets.GetAssetHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	48, // 31: assets.GetAssetHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	27, // 32: assets.AssetHistoryResponse.history:type_name -> assets.PricePoint
+	48, // 33: assets.PricePoint.timestamp:type_name -> google.protobuf.Timestamp
+	48, // 34: assets.GetPortfolioPerformanceRequest.start_date:type_name -> google.protobuf.Timestamp
+	48, // 35: assets.GetPortfolioPerformanceRequest.end_date:type_name -> google.protobuf.Timestamp
+	30, // 36: assets.PortfolioPerformanceResponse.allocation:type_name -> assets.AssetAllocation
+	31, // 37: assets.PortfolioPerformanceResponse.history:type_name -> assets.PerformancePoint
+	0,  // 38: assets.AssetAllocation.type:type_name -> assets.AssetType
+	48, // 39: assets.PerformancePoint.date:type_name -> google.protobuf.Timestamp
+	0,  // 40: assets.Holding.type:type_name -> assets.AssetType
+	33, // 41: assets.GetHoldingsResponse.holdings:type_name -> assets.Holding
+	0,  // 42: assets.SearchAssetsRequest.type:type_name -> assets.AssetType
+	37, // 43: assets.SearchAssetsResponse.results:type_name -> assets.AssetSearchResult
+	0,  // 44: assets.AssetSearchResult.type:type_name -> assets.AssetType
+	0,  // 45: assets.PriceAlert.type:type_name -> assets.AssetType
+	48, // 46: assets.PriceAlert.last_triggered_at:type_name -> google.protobuf.Timestamp
+	48, // 47: assets.PriceAlert.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 48: assets.CreatePriceAlertRequest.type:type_name -> assets.AssetType
+	38, // 49: assets.ListPriceAlertsResponse.alerts:type_name -> assets.PriceAlert
+	19, // 50: assets.GetMultipleAssetPricesResponse.PricesEntry.value:type_name -> assets.AssetPriceResponse
+	2,  // 51: assets.AssetsService.CreateAsset:input_type -> assets.CreateAssetRequest
+	3,  // 52: assets.AssetsService.GetAsset:input_type -> assets.GetAssetRequest
+	4,  // 53: assets.AssetsService.ListAssets:input_type -> assets.ListAssetsRequest
+	6,  // 54: assets.AssetsService.UpdateAsset:input_type -> assets.UpdateAssetRequest
+	7,  // 55: assets.AssetsService.DeleteAsset:input_type -> assets.DeleteAssetRequest
+	8,  // 56: assets.AssetsService.DeleteSubAccountAssets:input_type -> assets.SubAccountAssetsRequest
+	8,  // 57: assets.AssetsService.RestoreSubAccountAssets:input_type -> assets.SubAccountAssetsRequest
+	10, // 58: assets.AssetsService.SellAsset:input_type -> assets.SellAssetRequest
+	13, // 59: assets.AssetsService.RecordDividend:input_type -> assets.RecordDividendRequest
+	14, // 60: assets.AssetsService.ListDividends:input_type -> assets.ListDividendsRequest
+	16, // 61: assets.AssetsService.GetDividendIncome:input_type -> assets.GetDividendIncomeRequest
+	18, // 62: assets.AssetsService.GetAssetPrice:input_type -> assets.GetAssetPriceRequest
+	20, // 63: assets.AssetsService.GetMultipleAssetPrices:input_type -> assets.GetMultipleAssetPricesRequest
+	23, // 64: assets.AssetsService.RefreshAssetPrices:input_type -> assets.RefreshAssetPricesRequest
+	25, // 65: assets.AssetsService.GetAssetHistory:input_type -> assets.GetAssetHistoryRequest
+	28, // 66: assets.AssetsService.GetPortfolioPerformance:input_type -> assets.GetPortfolioPerformanceRequest
+	32, // 67: assets.AssetsService.GetHoldings:input_type -> assets.GetHoldingsRequest
+	35, // 68: assets.AssetsService.SearchAssets:input_type -> assets.SearchAssetsRequest
+	39, // 69: assets.AssetsService.CreatePriceAlert:input_type -> assets.CreatePriceAlertRequest
+	40, // 70: assets.AssetsService.ListPriceAlerts:input_type -> assets.ListPriceAlertsRequest
+	42, // 71: assets.AssetsService.DeletePriceAlert:input_type -> assets.DeletePriceAlertRequest
+	1,  // 72: assets.AssetsService.CreateAsset:output_type -> assets.Asset
+	1,  // 73: assets.AssetsService.GetAsset:output_type -> assets.Asset
+	5,  // 74: assets.AssetsService.ListAssets:output_type -> assets.ListAssetsResponse
+	1,  // 75: assets.AssetsService.UpdateAsset:output_type -> assets.Asset
+	49, // 76: assets.AssetsService.DeleteAsset:output_type -> google.protobuf.Empty
+	9,  // 77: assets.AssetsService.DeleteSubAccountAssets:output_type -> assets.SubAccountAssetsResponse
+	9,  // 78: assets.AssetsService.RestoreSubAccountAssets:output_type -> assets.SubAccountAssetsResponse
+	11, // 79: assets.AssetsService.SellAsset:output_type -> assets.SellAssetResponse
+	12, // 80: assets.AssetsService.RecordDividend:output_type -> assets.Dividend
+	15, // 81: assets.AssetsService.ListDividends:output_type -> assets.ListDividendsResponse
+	17, // 82: assets.AssetsService.GetDividendIncome:output_type -> assets.GetDividendIncomeResponse
+	19, // 83: assets.AssetsService.GetAssetPrice:output_type -> assets.AssetPriceResponse
+	22, // 84: assets.AssetsService.GetMultipleAssetPrices:output_type -> assets.GetMultipleAssetPricesResponse
+	24, // 85: assets.AssetsService.RefreshAssetPrices:output_type -> assets.RefreshAssetPricesResponse
+	26, // 86: assets.AssetsService.GetAssetHistory:output_type -> assets.AssetHistoryResponse
+	29, // 87: assets.AssetsService.GetPortfolioPerformance:output_type -> assets.PortfolioPerformanceResponse
+	34, // 88: assets.AssetsService.GetHoldings:output_type -> assets.GetHoldingsResponse
+	36, // 89: assets.AssetsService.SearchAssets:output_type -> assets.SearchAssetsResponse
+	38, // 90: assets.AssetsService.CreatePriceAlert:output_type -> assets.PriceAlert
+	41, // 91: assets.AssetsService.ListPriceAlerts:output_type -> assets.ListPriceAlertsResponse
+	49, // 92: assets.AssetsService.DeletePriceAlert:output_type -> google.protobuf.Empty
+	72, // [72:93] is the sub-list for method output_type
+	51, // [51:72] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_proto_assets_proto_init() }
@@ -2141,7 +3629,7 @@ func file_proto_assets_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_assets_proto_rawDesc), len(file_proto_assets_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   28,
+			NumMessages:   47,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -25,12 +25,22 @@ const (
 	AssetsService_ListAssets_FullMethodName              = "/assets.AssetsService/ListAssets"
 	AssetsService_UpdateAsset_FullMethodName             = "/assets.AssetsService/UpdateAsset"
 	AssetsService_DeleteAsset_FullMethodName             = "/assets.AssetsService/DeleteAsset"
+	AssetsService_DeleteSubAccountAssets_FullMethodName  = "/assets.AssetsService/DeleteSubAccountAssets"
+	AssetsService_RestoreSubAccountAssets_FullMethodName = "/assets.AssetsService/RestoreSubAccountAssets"
+	AssetsService_SellAsset_FullMethodName               = "/assets.AssetsService/SellAsset"
+	AssetsService_RecordDividend_FullMethodName          = "/assets.AssetsService/RecordDividend"
+	AssetsService_ListDividends_FullMethodName           = "/assets.AssetsService/ListDividends"
+	AssetsService_GetDividendIncome_FullMethodName       = "/assets.AssetsService/GetDividendIncome"
 	AssetsService_GetAssetPrice_FullMethodName           = "/assets.AssetsService/GetAssetPrice"
 	AssetsService_GetMultipleAssetPrices_FullMethodName  = "/assets.AssetsService/GetMultipleAssetPrices"
 	AssetsService_RefreshAssetPrices_FullMethodName      = "/assets.AssetsService/RefreshAssetPrices"
 	AssetsService_GetAssetHistory_FullMethodName         = "/assets.AssetsService/GetAssetHistory"
 	AssetsService_GetPortfolioPerformance_FullMethodName = "/assets.AssetsService/GetPortfolioPerformance"
+	AssetsService_GetHoldings_FullMethodName             = "/assets.AssetsService/GetHoldings"
 	AssetsService_SearchAssets_FullMethodName            = "/assets.AssetsService/SearchAssets"
+	AssetsService_CreatePriceAlert_FullMethodName        = "/assets.AssetsService/CreatePriceAlert"
+	AssetsService_ListPriceAlerts_FullMethodName         = "/assets.AssetsService/ListPriceAlerts"
+	AssetsService_DeletePriceAlert_FullMethodName        = "/assets.AssetsService/DeletePriceAlert"
 )
 
 // AssetsServiceClient is the client API for AssetsService service.
@@ -42,12 +52,22 @@ type AssetsServiceClient interface {
 	ListAssets(ctx context.Context, in *ListAssetsRequest, opts ...grpc.CallOption) (*ListAssetsResponse, error)
 	UpdateAsset(ctx context.Context, in *UpdateAssetRequest, opts ...grpc.CallOption) (*Asset, error)
 	DeleteAsset(ctx context.Context, in *DeleteAssetRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	DeleteSubAccountAssets(ctx context.Context, in *SubAccountAssetsRequest, opts ...grpc.CallOption) (*SubAccountAssetsResponse, error)
+	RestoreSubAccountAssets(ctx context.Context, in *SubAccountAssetsRequest, opts ...grpc.CallOption) (*SubAccountAssetsResponse, error)
+	SellAsset(ctx context.Context, in *SellAssetRequest, opts ...grpc.CallOption) (*SellAssetResponse, error)
+	RecordDividend(ctx context.Context, in *RecordDividendRequest, opts ...grpc.CallOption) (*Dividend, error)
+	ListDividends(ctx context.Context, in *ListDividendsRequest, opts ...grpc.CallOption) (*ListDividendsResponse, error)
+	GetDividendIncome(ctx context.Context, in *GetDividendIncomeRequest, opts ...grpc.CallOption) (*GetDividendIncomeResponse, error)
 	GetAssetPrice(ctx context.Context, in *GetAssetPriceRequest, opts ...grpc.CallOption) (*AssetPriceResponse, error)
 	GetMultipleAssetPrices(ctx context.Context, in *GetMultipleAssetPricesRequest, opts ...grpc.CallOption) (*GetMultipleAssetPricesResponse, error)
 	RefreshAssetPrices(ctx context.Context, in *RefreshAssetPricesRequest, opts ...grpc.CallOption) (*RefreshAssetPricesResponse, error)
 	GetAssetHistory(ctx context.Context, in *GetAssetHistoryRequest, opts ...grpc.CallOption) (*AssetHistoryResponse, error)
 	GetPortfolioPerformance(ctx context.Context, in *GetPortfolioPerformanceRequest, opts ...grpc.CallOption) (*PortfolioPerformanceResponse, error)
+	GetHoldings(ctx context.Context, in *GetHoldingsRequest, opts ...grpc.CallOption) (*GetHoldingsResponse, error)
 	SearchAssets(ctx context.Context, in *SearchAssetsRequest, opts ...grpc.CallOption) (*SearchAssetsResponse, error)
+	CreatePriceAlert(ctx context.Context, in *CreatePriceAlertRequest, opts ...grpc.CallOption) (*PriceAlert, error)
+	ListPriceAlerts(ctx context.Context, in *ListPriceAlertsRequest, opts ...grpc.CallOption) (*ListPriceAlertsResponse, error)
+	DeletePriceAlert(ctx context.Context, in *DeletePriceAlertRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
 type assetsServiceClient struct {
@@ -108,6 +128,66 @@ func (c *assetsServiceClient) DeleteAsset(ctx context.Context, in *DeleteAssetRe
 	return out, nil
 }
 
+func (c *assetsServiceClient) DeleteSubAccountAssets(ctx context.Context, in *SubAccountAssetsRequest, opts ...grpc.CallOption) (*SubAccountAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubAccountAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetsService_DeleteSubAccountAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) RestoreSubAccountAssets(ctx context.Context, in *SubAccountAssetsRequest, opts ...grpc.CallOption) (*SubAccountAssetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubAccountAssetsResponse)
+	err := c.cc.Invoke(ctx, AssetsService_RestoreSubAccountAssets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) SellAsset(ctx context.Context, in *SellAssetRequest, opts ...grpc.CallOption) (*SellAssetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SellAssetResponse)
+	err := c.cc.Invoke(ctx, AssetsService_SellAsset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) RecordDividend(ctx context.Context, in *RecordDividendRequest, opts ...grpc.CallOption) (*Dividend, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Dividend)
+	err := c.cc.Invoke(ctx, AssetsService_RecordDividend_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) ListDividends(ctx context.Context, in *ListDividendsRequest, opts ...grpc.CallOption) (*ListDividendsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDividendsResponse)
+	err := c.cc.Invoke(ctx, AssetsService_ListDividends_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) GetDividendIncome(ctx context.Context, in *GetDividendIncomeRequest, opts ...grpc.CallOption) (*GetDividendIncomeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDividendIncomeResponse)
+	err := c.cc.Invoke(ctx, AssetsService_GetDividendIncome_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *assetsServiceClient) GetAssetPrice(ctx context.Context, in *GetAssetPriceRequest, opts ...grpc.CallOption) (*AssetPriceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AssetPriceResponse)
@@ -158,6 +238,16 @@ func (c *assetsServiceClient) GetPortfolioPerformance(ctx context.Context, in *G
 	return out, nil
 }
 
+func (c *assetsServiceClient) GetHoldings(ctx context.Context, in *GetHoldingsRequest, opts ...grpc.CallOption) (*GetHoldingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetHoldingsResponse)
+	err := c.cc.Invoke(ctx, AssetsService_GetHoldings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *assetsServiceClient) SearchAssets(ctx context.Context, in *SearchAssetsRequest, opts ...grpc.CallOption) (*SearchAssetsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(SearchAssetsResponse)
@@ -168,6 +258,36 @@ func (c *assetsServiceClient) SearchAssets(ctx context.Context, in *SearchAssets
 	return out, nil
 }
 
+func (c *assetsServiceClient) CreatePriceAlert(ctx context.Context, in *CreatePriceAlertRequest, opts ...grpc.CallOption) (*PriceAlert, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceAlert)
+	err := c.cc.Invoke(ctx, AssetsService_CreatePriceAlert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) ListPriceAlerts(ctx context.Context, in *ListPriceAlertsRequest, opts ...grpc.CallOption) (*ListPriceAlertsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPriceAlertsResponse)
+	err := c.cc.Invoke(ctx, AssetsService_ListPriceAlerts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *assetsServiceClient) DeletePriceAlert(ctx context.Context, in *DeletePriceAlertRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AssetsService_DeletePriceAlert_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AssetsServiceServer is the server API for AssetsService service.
 // All implementations must embed UnimplementedAssetsServiceServer
 // for forward compatibility.
@@ -177,12 +297,22 @@ type AssetsServiceServer interface {
 	ListAssets(context.Context, *ListAssetsRequest) (*ListAssetsResponse, error)
 	UpdateAsset(context.Context, *UpdateAssetRequest) (*Asset, error)
 	DeleteAsset(context.Context, *DeleteAssetRequest) (*emptypb.Empty, error)
+	DeleteSubAccountAssets(context.Context, *SubAccountAssetsRequest) (*SubAccountAssetsResponse, error)
+	RestoreSubAccountAssets(context.Context, *SubAccountAssetsRequest) (*SubAccountAssetsResponse, error)
+	SellAsset(context.Context, *SellAssetRequest) (*SellAssetResponse, error)
+	RecordDividend(context.Context, *RecordDividendRequest) (*Dividend, error)
+	ListDividends(context.Context, *ListDividendsRequest) (*ListDividendsResponse, error)
+	GetDividendIncome(context.Context, *GetDividendIncomeRequest) (*GetDividendIncomeResponse, error)
 	GetAssetPrice(context.Context, *GetAssetPriceRequest) (*AssetPriceResponse, error)
 	GetMultipleAssetPrices(context.Context, *GetMultipleAssetPricesRequest) (*GetMultipleAssetPricesResponse, error)
 	RefreshAssetPrices(context.Context, *RefreshAssetPricesRequest) (*RefreshAssetPricesResponse, error)
 	GetAssetHistory(context.Context, *GetAssetHistoryRequest) (*AssetHistoryResponse, error)
 	GetPortfolioPerformance(context.Context, *GetPortfolioPerformanceRequest) (*PortfolioPerformanceResponse, error)
+	GetHoldings(context.Context, *GetHoldingsRequest) (*GetHoldingsResponse, error)
 	SearchAssets(context.Context, *SearchAssetsRequest) (*SearchAssetsResponse, error)
+	CreatePriceAlert(context.Context, *CreatePriceAlertRequest) (*PriceAlert, error)
+	ListPriceAlerts(context.Context, *ListPriceAlertsRequest) (*ListPriceAlertsResponse, error)
+	DeletePriceAlert(context.Context, *DeletePriceAlertRequest) (*emptypb.Empty, error)
 	mustEmbedUnimplementedAssetsServiceServer()
 }
 
@@ -208,6 +338,24 @@ func (UnimplementedAssetsServiceServer) UpdateAsset(context.Context, *UpdateAsse
 func (UnimplementedAssetsServiceServer) DeleteAsset(context.Context, *DeleteAssetRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteAsset not implemented")
 }
+func (UnimplementedAssetsServiceServer) DeleteSubAccountAssets(context.Context, *SubAccountAssetsRequest) (*SubAccountAssetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteSubAccountAssets not implemented")
+}
+func (UnimplementedAssetsServiceServer) RestoreSubAccountAssets(context.Context, *SubAccountAssetsRequest) (*SubAccountAssetsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RestoreSubAccountAssets not implemented")
+}
+func (UnimplementedAssetsServiceServer) SellAsset(context.Context, *SellAssetRequest) (*SellAssetResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SellAsset not implemented")
+}
+func (UnimplementedAssetsServiceServer) RecordDividend(context.Context, *RecordDividendRequest) (*Dividend, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordDividend not implemented")
+}
+func (UnimplementedAssetsServiceServer) ListDividends(context.Context, *ListDividendsRequest) (*ListDividendsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListDividends not implemented")
+}
+func (UnimplementedAssetsServiceServer) GetDividendIncome(context.Context, *GetDividendIncomeRequest) (*GetDividendIncomeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDividendIncome not implemented")
+}
 func (UnimplementedAssetsServiceServer) GetAssetPrice(context.Context, *GetAssetPriceRequest) (*AssetPriceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAssetPrice not implemented")
 }
@@ -223,9 +371,21 @@ func (UnimplementedAssetsServiceServer) GetAssetHistory(context.Context, *GetAss
 func (UnimplementedAssetsServiceServer) GetPortfolioPerformance(context.Context, *GetPortfolioPerformanceRequest) (*PortfolioPerformanceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPortfolioPerformance not implemented")
 }
+func (UnimplementedAssetsServiceServer) GetHoldings(context.Context, *GetHoldingsRequest) (*GetHoldingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetHoldings not implemented")
+}
 func (UnimplementedAssetsServiceServer) SearchAssets(context.Context, *SearchAssetsRequest) (*SearchAssetsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SearchAssets not implemented")
 }
+func (UnimplementedAssetsServiceServer) CreatePriceAlert(context.Context, *CreatePriceAlertRequest) (*PriceAlert, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePriceAlert not implemented")
+}
+func (UnimplementedAssetsServiceServer) ListPriceAlerts(context.Context, *ListPriceAlertsRequest) (*ListPriceAlertsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPriceAlerts not implemented")
+}
+func (UnimplementedAssetsServiceServer) DeletePriceAlert(context.Context, *DeletePriceAlertRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePriceAlert not implemented")
+}
 func (UnimplementedAssetsServiceServer) mustEmbedUnimplementedAssetsServiceServer() {}
 func (UnimplementedAssetsServiceServer) testEmbeddedByValue()                       {}
 
@@ -337,6 +497,114 @@ func _AssetsService_DeleteAsset_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AssetsService_DeleteSubAccountAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubAccountAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).DeleteSubAccountAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_DeleteSubAccountAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).DeleteSubAccountAssets(ctx, req.(*SubAccountAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_RestoreSubAccountAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubAccountAssetsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).RestoreSubAccountAssets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_RestoreSubAccountAssets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).RestoreSubAccountAssets(ctx, req.(*SubAccountAssetsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_SellAsset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SellAssetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).SellAsset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_SellAsset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).SellAsset(ctx, req.(*SellAssetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_RecordDividend_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDividendRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).RecordDividend(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_RecordDividend_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).RecordDividend(ctx, req.(*RecordDividendRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_ListDividends_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDividendsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).ListDividends(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_ListDividends_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).ListDividends(ctx, req.(*ListDividendsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_GetDividendIncome_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDividendIncomeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).GetDividendIncome(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_GetDividendIncome_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).GetDividendIncome(ctx, req.(*GetDividendIncomeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AssetsService_GetAssetPrice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAssetPriceRequest)
 	if err := dec(in); err != nil {
@@ -427,6 +695,24 @@ func _AssetsService_GetPortfolioPerformance_Handler(srv interface{}, ctx context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AssetsService_GetHoldings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetHoldingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).GetHoldings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_GetHoldings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).GetHoldings(ctx, req.(*GetHoldingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AssetsService_SearchAssets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SearchAssetsRequest)
 	if err := dec(in); err != nil {
@@ -445,6 +731,60 @@ func _AssetsService_SearchAssets_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AssetsService_CreatePriceAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePriceAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).CreatePriceAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_CreatePriceAlert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).CreatePriceAlert(ctx, req.(*CreatePriceAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_ListPriceAlerts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceAlertsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).ListPriceAlerts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_ListPriceAlerts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).ListPriceAlerts(ctx, req.(*ListPriceAlertsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AssetsService_DeletePriceAlert_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePriceAlertRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AssetsServiceServer).DeletePriceAlert(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AssetsService_DeletePriceAlert_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AssetsServiceServer).DeletePriceAlert(ctx, req.(*DeletePriceAlertRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AssetsService_ServiceDesc is the grpc.ServiceDesc for AssetsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -472,6 +812,30 @@ var AssetsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteAsset",
 			Handler:    _AssetsService_DeleteAsset_Handler,
 		},
+		{
+			MethodName: "DeleteSubAccountAssets",
+			Handler:    _AssetsService_DeleteSubAccountAssets_Handler,
+		},
+		{
+			MethodName: "RestoreSubAccountAssets",
+			Handler:    _AssetsService_RestoreSubAccountAssets_Handler,
+		},
+		{
+			MethodName: "SellAsset",
+			Handler:    _AssetsService_SellAsset_Handler,
+		},
+		{
+			MethodName: "RecordDividend",
+			Handler:    _AssetsService_RecordDividend_Handler,
+		},
+		{
+			MethodName: "ListDividends",
+			Handler:    _AssetsService_ListDividends_Handler,
+		},
+		{
+			MethodName: "GetDividendIncome",
+			Handler:    _AssetsService_GetDividendIncome_Handler,
+		},
 		{
 			MethodName: "GetAssetPrice",
 			Handler:    _AssetsService_GetAssetPrice_Handler,
@@ -492,10 +856,26 @@ var AssetsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetPortfolioPerformance",
 			Handler:    _AssetsService_GetPortfolioPerformance_Handler,
 		},
+		{
+			MethodName: "GetHoldings",
+			Handler:    _AssetsService_GetHoldings_Handler,
+		},
 		{
 			MethodName: "SearchAssets",
 			Handler:    _AssetsService_SearchAssets_Handler,
 		},
+		{
+			MethodName: "CreatePriceAlert",
+			Handler:    _AssetsService_CreatePriceAlert_Handler,
+		},
+		{
+			MethodName: "ListPriceAlerts",
+			Handler:    _AssetsService_ListPriceAlerts_Handler,
+		},
+		{
+			MethodName: "DeletePriceAlert",
+			Handler:    _AssetsService_DeletePriceAlert_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/assets.proto",
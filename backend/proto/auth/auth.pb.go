@@ -318,6 +318,138 @@ func (x *GoogleAuthRequest) GetRedirectUri() string {
 	return ""
 }
 
+type GetAppleAuthURLRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppleAuthURLRequest) Reset() {
+	*x = GetAppleAuthURLRequest{}
+	mi := &file_proto_auth_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppleAuthURLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppleAuthURLRequest) ProtoMessage() {}
+
+func (x *GetAppleAuthURLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppleAuthURLRequest.ProtoReflect.Descriptor instead.
+func (*GetAppleAuthURLRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{4}
+}
+
+type GetAppleAuthURLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAppleAuthURLResponse) Reset() {
+	*x = GetAppleAuthURLResponse{}
+	mi := &file_proto_auth_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAppleAuthURLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAppleAuthURLResponse) ProtoMessage() {}
+
+func (x *GetAppleAuthURLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAppleAuthURLResponse.ProtoReflect.Descriptor instead.
+func (*GetAppleAuthURLResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAppleAuthURLResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type AppleAuthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	State         string                 `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppleAuthRequest) Reset() {
+	*x = AppleAuthRequest{}
+	mi := &file_proto_auth_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppleAuthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppleAuthRequest) ProtoMessage() {}
+
+func (x *AppleAuthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppleAuthRequest.ProtoReflect.Descriptor instead.
+func (*AppleAuthRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AppleAuthRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *AppleAuthRequest) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
 type TelegramAuthRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	InitData      string                 `protobuf:"bytes,1,opt,name=init_data,json=initData,proto3" json:"init_data,omitempty"`
@@ -327,7 +459,7 @@ type TelegramAuthRequest struct {
 
 func (x *TelegramAuthRequest) Reset() {
 	*x = TelegramAuthRequest{}
-	mi := &file_proto_auth_proto_msgTypes[4]
+	mi := &file_proto_auth_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -339,7 +471,7 @@ func (x *TelegramAuthRequest) String() string {
 func (*TelegramAuthRequest) ProtoMessage() {}
 
 func (x *TelegramAuthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[4]
+	mi := &file_proto_auth_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -352,7 +484,7 @@ func (x *TelegramAuthRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TelegramAuthRequest.ProtoReflect.Descriptor instead.
 func (*TelegramAuthRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{4}
+	return file_proto_auth_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TelegramAuthRequest) GetInitData() string {
@@ -374,7 +506,7 @@ type AuthResponse struct {
 
 func (x *AuthResponse) Reset() {
 	*x = AuthResponse{}
-	mi := &file_proto_auth_proto_msgTypes[5]
+	mi := &file_proto_auth_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -386,7 +518,7 @@ func (x *AuthResponse) String() string {
 func (*AuthResponse) ProtoMessage() {}
 
 func (x *AuthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[5]
+	mi := &file_proto_auth_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -399,7 +531,7 @@ func (x *AuthResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AuthResponse.ProtoReflect.Descriptor instead.
 func (*AuthResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{5}
+	return file_proto_auth_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *AuthResponse) GetUser() *User {
@@ -439,7 +571,7 @@ type RefreshTokenRequest struct {
 
 func (x *RefreshTokenRequest) Reset() {
 	*x = RefreshTokenRequest{}
-	mi := &file_proto_auth_proto_msgTypes[6]
+	mi := &file_proto_auth_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -451,7 +583,7 @@ func (x *RefreshTokenRequest) String() string {
 func (*RefreshTokenRequest) ProtoMessage() {}
 
 func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[6]
+	mi := &file_proto_auth_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -464,7 +596,7 @@ func (x *RefreshTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshTokenRequest.ProtoReflect.Descriptor instead.
 func (*RefreshTokenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{6}
+	return file_proto_auth_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *RefreshTokenRequest) GetRefreshToken() string {
@@ -483,7 +615,7 @@ type GetProfileRequest struct {
 
 func (x *GetProfileRequest) Reset() {
 	*x = GetProfileRequest{}
-	mi := &file_proto_auth_proto_msgTypes[7]
+	mi := &file_proto_auth_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -495,7 +627,7 @@ func (x *GetProfileRequest) String() string {
 func (*GetProfileRequest) ProtoMessage() {}
 
 func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[7]
+	mi := &file_proto_auth_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -508,7 +640,7 @@ func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
 func (*GetProfileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{7}
+	return file_proto_auth_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *GetProfileRequest) GetUserId() string {
@@ -530,7 +662,7 @@ type UpdateProfileRequest struct {
 
 func (x *UpdateProfileRequest) Reset() {
 	*x = UpdateProfileRequest{}
-	mi := &file_proto_auth_proto_msgTypes[8]
+	mi := &file_proto_auth_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -542,7 +674,7 @@ func (x *UpdateProfileRequest) String() string {
 func (*UpdateProfileRequest) ProtoMessage() {}
 
 func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[8]
+	mi := &file_proto_auth_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -555,7 +687,7 @@ func (x *UpdateProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateProfileRequest.ProtoReflect.Descriptor instead.
 func (*UpdateProfileRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{8}
+	return file_proto_auth_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *UpdateProfileRequest) GetUserId() string {
@@ -595,7 +727,7 @@ type ValidateTokenRequest struct {
 
 func (x *ValidateTokenRequest) Reset() {
 	*x = ValidateTokenRequest{}
-	mi := &file_proto_auth_proto_msgTypes[9]
+	mi := &file_proto_auth_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -607,7 +739,7 @@ func (x *ValidateTokenRequest) String() string {
 func (*ValidateTokenRequest) ProtoMessage() {}
 
 func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[9]
+	mi := &file_proto_auth_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -620,7 +752,7 @@ func (x *ValidateTokenRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateTokenRequest.ProtoReflect.Descriptor instead.
 func (*ValidateTokenRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{9}
+	return file_proto_auth_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ValidateTokenRequest) GetAccessToken() string {
@@ -641,7 +773,7 @@ type ValidateTokenResponse struct {
 
 func (x *ValidateTokenResponse) Reset() {
 	*x = ValidateTokenResponse{}
-	mi := &file_proto_auth_proto_msgTypes[10]
+	mi := &file_proto_auth_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -653,7 +785,7 @@ func (x *ValidateTokenResponse) String() string {
 func (*ValidateTokenResponse) ProtoMessage() {}
 
 func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[10]
+	mi := &file_proto_auth_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -666,7 +798,7 @@ func (x *ValidateTokenResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ValidateTokenResponse.ProtoReflect.Descriptor instead.
 func (*ValidateTokenResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{10}
+	return file_proto_auth_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ValidateTokenResponse) GetValid() bool {
@@ -690,6 +822,94 @@ func (x *ValidateTokenResponse) GetEmail() string {
 	return ""
 }
 
+type GetTokenVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenVersionRequest) Reset() {
+	*x = GetTokenVersionRequest{}
+	mi := &file_proto_auth_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenVersionRequest) ProtoMessage() {}
+
+func (x *GetTokenVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetTokenVersionRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTokenVersionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetTokenVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TokenVersion  int32                  `protobuf:"varint,1,opt,name=token_version,json=tokenVersion,proto3" json:"token_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTokenVersionResponse) Reset() {
+	*x = GetTokenVersionResponse{}
+	mi := &file_proto_auth_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTokenVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTokenVersionResponse) ProtoMessage() {}
+
+func (x *GetTokenVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTokenVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetTokenVersionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetTokenVersionResponse) GetTokenVersion() int32 {
+	if x != nil {
+		return x.TokenVersion
+	}
+	return 0
+}
+
 type LogoutRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -700,7 +920,7 @@ type LogoutRequest struct {
 
 func (x *LogoutRequest) Reset() {
 	*x = LogoutRequest{}
-	mi := &file_proto_auth_proto_msgTypes[11]
+	mi := &file_proto_auth_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -712,7 +932,7 @@ func (x *LogoutRequest) String() string {
 func (*LogoutRequest) ProtoMessage() {}
 
 func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[11]
+	mi := &file_proto_auth_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -725,7 +945,7 @@ func (x *LogoutRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutRequest.ProtoReflect.Descriptor instead.
 func (*LogoutRequest) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{11}
+	return file_proto_auth_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *LogoutRequest) GetUserId() string {
@@ -751,7 +971,7 @@ type LogoutResponse struct {
 
 func (x *LogoutResponse) Reset() {
 	*x = LogoutResponse{}
-	mi := &file_proto_auth_proto_msgTypes[12]
+	mi := &file_proto_auth_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -763,7 +983,7 @@ func (x *LogoutResponse) String() string {
 func (*LogoutResponse) ProtoMessage() {}
 
 func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_auth_proto_msgTypes[12]
+	mi := &file_proto_auth_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -776,7 +996,7 @@ func (x *LogoutResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogoutResponse.ProtoReflect.Descriptor instead.
 func (*LogoutResponse) Descriptor() ([]byte, []int) {
-	return file_proto_auth_proto_rawDescGZIP(), []int{12}
+	return file_proto_auth_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *LogoutResponse) GetSuccess() bool {
@@ -786,6 +1006,380 @@ func (x *LogoutResponse) GetSuccess() bool {
 	return false
 }
 
+type RequestTelegramLinkRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Email            string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	TelegramId       int64                  `protobuf:"varint,2,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	TelegramUsername string                 `protobuf:"bytes,3,opt,name=telegram_username,json=telegramUsername,proto3" json:"telegram_username,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RequestTelegramLinkRequest) Reset() {
+	*x = RequestTelegramLinkRequest{}
+	mi := &file_proto_auth_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTelegramLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTelegramLinkRequest) ProtoMessage() {}
+
+func (x *RequestTelegramLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTelegramLinkRequest.ProtoReflect.Descriptor instead.
+func (*RequestTelegramLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RequestTelegramLinkRequest) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *RequestTelegramLinkRequest) GetTelegramId() int64 {
+	if x != nil {
+		return x.TelegramId
+	}
+	return 0
+}
+
+func (x *RequestTelegramLinkRequest) GetTelegramUsername() string {
+	if x != nil {
+		return x.TelegramUsername
+	}
+	return ""
+}
+
+type RequestTelegramLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CodeSent      bool                   `protobuf:"varint,1,opt,name=code_sent,json=codeSent,proto3" json:"code_sent,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestTelegramLinkResponse) Reset() {
+	*x = RequestTelegramLinkResponse{}
+	mi := &file_proto_auth_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestTelegramLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestTelegramLinkResponse) ProtoMessage() {}
+
+func (x *RequestTelegramLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestTelegramLinkResponse.ProtoReflect.Descriptor instead.
+func (*RequestTelegramLinkResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RequestTelegramLinkResponse) GetCodeSent() bool {
+	if x != nil {
+		return x.CodeSent
+	}
+	return false
+}
+
+func (x *RequestTelegramLinkResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ConfirmTelegramLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TelegramId    int64                  `protobuf:"varint,1,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTelegramLinkRequest) Reset() {
+	*x = ConfirmTelegramLinkRequest{}
+	mi := &file_proto_auth_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTelegramLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTelegramLinkRequest) ProtoMessage() {}
+
+func (x *ConfirmTelegramLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTelegramLinkRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTelegramLinkRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConfirmTelegramLinkRequest) GetTelegramId() int64 {
+	if x != nil {
+		return x.TelegramId
+	}
+	return 0
+}
+
+func (x *ConfirmTelegramLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type IssueTelegramLoginTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TelegramId    int64                  `protobuf:"varint,2,opt,name=telegram_id,json=telegramId,proto3" json:"telegram_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTelegramLoginTokenRequest) Reset() {
+	*x = IssueTelegramLoginTokenRequest{}
+	mi := &file_proto_auth_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTelegramLoginTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTelegramLoginTokenRequest) ProtoMessage() {}
+
+func (x *IssueTelegramLoginTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTelegramLoginTokenRequest.ProtoReflect.Descriptor instead.
+func (*IssueTelegramLoginTokenRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *IssueTelegramLoginTokenRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *IssueTelegramLoginTokenRequest) GetTelegramId() int64 {
+	if x != nil {
+		return x.TelegramId
+	}
+	return 0
+}
+
+type IssueTelegramLoginTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueTelegramLoginTokenResponse) Reset() {
+	*x = IssueTelegramLoginTokenResponse{}
+	mi := &file_proto_auth_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueTelegramLoginTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueTelegramLoginTokenResponse) ProtoMessage() {}
+
+func (x *IssueTelegramLoginTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueTelegramLoginTokenResponse.ProtoReflect.Descriptor instead.
+func (*IssueTelegramLoginTokenResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *IssueTelegramLoginTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *IssueTelegramLoginTokenResponse) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type ListUsersRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PageSize int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from a previous response; empty for the first page
+	PageToken     string `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersRequest) Reset() {
+	*x = ListUsersRequest{}
+	mi := &file_proto_auth_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersRequest) ProtoMessage() {}
+
+func (x *ListUsersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersRequest.ProtoReflect.Descriptor instead.
+func (*ListUsersRequest) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListUsersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListUsersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListUsersResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Users []*User                `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	// Empty when there are no more users
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListUsersResponse) Reset() {
+	*x = ListUsersResponse{}
+	mi := &file_proto_auth_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListUsersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListUsersResponse) ProtoMessage() {}
+
+func (x *ListUsersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_auth_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListUsersResponse.ProtoReflect.Descriptor instead.
+func (*ListUsersResponse) Descriptor() ([]byte, []int) {
+	return file_proto_auth_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListUsersResponse) GetUsers() []*User {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+func (x *ListUsersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 var File_proto_auth_proto protoreflect.FileDescriptor
 
 const file_proto_auth_proto_rawDesc = "" +
@@ -819,7 +1413,13 @@ const file_proto_auth_proto_rawDesc = "" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"J\n" +
 	"\x11GoogleAuthRequest\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12!\n" +
-	"\fredirect_uri\x18\x02 \x01(\tR\vredirectUri\"2\n" +
+	"\fredirect_uri\x18\x02 \x01(\tR\vredirectUri\"\x18\n" +
+	"\x16GetAppleAuthURLRequest\"+\n" +
+	"\x17GetAppleAuthURLResponse\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\"<\n" +
+	"\x10AppleAuthRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
+	"\x05state\x18\x02 \x01(\tR\x05state\"2\n" +
 	"\x13TelegramAuthRequest\x12\x1b\n" +
 	"\tinit_data\x18\x01 \x01(\tR\binitData\"\x95\x01\n" +
 	"\fAuthResponse\x12\x1e\n" +
@@ -844,17 +1444,52 @@ const file_proto_auth_proto_rawDesc = "" +
 	"\x15ValidateTokenResponse\x12\x14\n" +
 	"\x05valid\x18\x01 \x01(\bR\x05valid\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05email\x18\x03 \x01(\tR\x05email\"M\n" +
+	"\x05email\x18\x03 \x01(\tR\x05email\"1\n" +
+	"\x16GetTokenVersionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\">\n" +
+	"\x17GetTokenVersionResponse\x12#\n" +
+	"\rtoken_version\x18\x01 \x01(\x05R\ftokenVersion\"M\n" +
 	"\rLogoutRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
 	"\rrefresh_token\x18\x02 \x01(\tR\frefreshToken\"*\n" +
 	"\x0eLogoutResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess2\x99\x04\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x80\x01\n" +
+	"\x1aRequestTelegramLinkRequest\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1f\n" +
+	"\vtelegram_id\x18\x02 \x01(\x03R\n" +
+	"telegramId\x12+\n" +
+	"\x11telegram_username\x18\x03 \x01(\tR\x10telegramUsername\"u\n" +
+	"\x1bRequestTelegramLinkResponse\x12\x1b\n" +
+	"\tcode_sent\x18\x01 \x01(\bR\bcodeSent\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"Q\n" +
+	"\x1aConfirmTelegramLinkRequest\x12\x1f\n" +
+	"\vtelegram_id\x18\x01 \x01(\x03R\n" +
+	"telegramId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"Z\n" +
+	"\x1eIssueTelegramLoginTokenRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1f\n" +
+	"\vtelegram_id\x18\x02 \x01(\x03R\n" +
+	"telegramId\"r\n" +
+	"\x1fIssueTelegramLoginTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x129\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"N\n" +
+	"\x10ListUsersRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\"]\n" +
+	"\x11ListUsersResponse\x12 \n" +
+	"\x05users\x18\x01 \x03(\v2\n" +
+	".auth.UserR\x05users\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken2\xb9\b\n" +
 	"\vAuthService\x125\n" +
 	"\bRegister\x12\x15.auth.RegisterRequest\x1a\x12.auth.AuthResponse\x12/\n" +
 	"\x05Login\x12\x12.auth.LoginRequest\x1a\x12.auth.AuthResponse\x129\n" +
 	"\n" +
-	"GoogleAuth\x12\x17.auth.GoogleAuthRequest\x1a\x12.auth.AuthResponse\x12=\n" +
+	"GoogleAuth\x12\x17.auth.GoogleAuthRequest\x1a\x12.auth.AuthResponse\x12N\n" +
+	"\x0fGetAppleAuthURL\x12\x1c.auth.GetAppleAuthURLRequest\x1a\x1d.auth.GetAppleAuthURLResponse\x127\n" +
+	"\tAppleAuth\x12\x16.auth.AppleAuthRequest\x1a\x12.auth.AuthResponse\x12=\n" +
 	"\fTelegramAuth\x12\x19.auth.TelegramAuthRequest\x1a\x12.auth.AuthResponse\x12=\n" +
 	"\fRefreshToken\x12\x19.auth.RefreshTokenRequest\x1a\x12.auth.AuthResponse\x121\n" +
 	"\n" +
@@ -862,8 +1497,14 @@ const file_proto_auth_proto_rawDesc = "" +
 	".auth.User\x127\n" +
 	"\rUpdateProfile\x12\x1a.auth.UpdateProfileRequest\x1a\n" +
 	".auth.User\x12H\n" +
-	"\rValidateToken\x12\x1a.auth.ValidateTokenRequest\x1a\x1b.auth.ValidateTokenResponse\x123\n" +
-	"\x06Logout\x12\x13.auth.LogoutRequest\x1a\x14.auth.LogoutResponseB7Z5github.com/radmickey/money-control/backend/proto/authb\x06proto3"
+	"\rValidateToken\x12\x1a.auth.ValidateTokenRequest\x1a\x1b.auth.ValidateTokenResponse\x12N\n" +
+	"\x0fGetTokenVersion\x12\x1c.auth.GetTokenVersionRequest\x1a\x1d.auth.GetTokenVersionResponse\x123\n" +
+	"\x06Logout\x12\x13.auth.LogoutRequest\x1a\x14.auth.LogoutResponse\x12Z\n" +
+	"\x13RequestTelegramLink\x12 .auth.RequestTelegramLinkRequest\x1a!.auth.RequestTelegramLinkResponse\x12C\n" +
+	"\x13ConfirmTelegramLink\x12 .auth.ConfirmTelegramLinkRequest\x1a\n" +
+	".auth.User\x12f\n" +
+	"\x17IssueTelegramLoginToken\x12$.auth.IssueTelegramLoginTokenRequest\x1a%.auth.IssueTelegramLoginTokenResponse\x12<\n" +
+	"\tListUsers\x12\x16.auth.ListUsersRequest\x1a\x17.auth.ListUsersResponseB7Z5github.com/radmickey/money-control/backend/proto/authb\x06proto3"
 
 var (
 	file_proto_auth_proto_rawDescOnce sync.Once
@@ -877,50 +1518,79 @@ func file_proto_auth_proto_rawDescGZIP() []byte {
 	return file_proto_auth_proto_rawDescData
 }
 
-var file_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_auth_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_proto_auth_proto_goTypes = []any{
-	(*User)(nil),                  // 0: auth.User
-	(*RegisterRequest)(nil),       // 1: auth.RegisterRequest
-	(*LoginRequest)(nil),          // 2: auth.LoginRequest
-	(*GoogleAuthRequest)(nil),     // 3: auth.GoogleAuthRequest
-	(*TelegramAuthRequest)(nil),   // 4: auth.TelegramAuthRequest
-	(*AuthResponse)(nil),          // 5: auth.AuthResponse
-	(*RefreshTokenRequest)(nil),   // 6: auth.RefreshTokenRequest
-	(*GetProfileRequest)(nil),     // 7: auth.GetProfileRequest
-	(*UpdateProfileRequest)(nil),  // 8: auth.UpdateProfileRequest
-	(*ValidateTokenRequest)(nil),  // 9: auth.ValidateTokenRequest
-	(*ValidateTokenResponse)(nil), // 10: auth.ValidateTokenResponse
-	(*LogoutRequest)(nil),         // 11: auth.LogoutRequest
-	(*LogoutResponse)(nil),        // 12: auth.LogoutResponse
-	(*timestamppb.Timestamp)(nil), // 13: google.protobuf.Timestamp
+	(*User)(nil),                            // 0: auth.User
+	(*RegisterRequest)(nil),                 // 1: auth.RegisterRequest
+	(*LoginRequest)(nil),                    // 2: auth.LoginRequest
+	(*GoogleAuthRequest)(nil),               // 3: auth.GoogleAuthRequest
+	(*GetAppleAuthURLRequest)(nil),          // 4: auth.GetAppleAuthURLRequest
+	(*GetAppleAuthURLResponse)(nil),         // 5: auth.GetAppleAuthURLResponse
+	(*AppleAuthRequest)(nil),                // 6: auth.AppleAuthRequest
+	(*TelegramAuthRequest)(nil),             // 7: auth.TelegramAuthRequest
+	(*AuthResponse)(nil),                    // 8: auth.AuthResponse
+	(*RefreshTokenRequest)(nil),             // 9: auth.RefreshTokenRequest
+	(*GetProfileRequest)(nil),               // 10: auth.GetProfileRequest
+	(*UpdateProfileRequest)(nil),            // 11: auth.UpdateProfileRequest
+	(*ValidateTokenRequest)(nil),            // 12: auth.ValidateTokenRequest
+	(*ValidateTokenResponse)(nil),           // 13: auth.ValidateTokenResponse
+	(*GetTokenVersionRequest)(nil),          // 14: auth.GetTokenVersionRequest
+	(*GetTokenVersionResponse)(nil),         // 15: auth.GetTokenVersionResponse
+	(*LogoutRequest)(nil),                   // 16: auth.LogoutRequest
+	(*LogoutResponse)(nil),                  // 17: auth.LogoutResponse
+	(*RequestTelegramLinkRequest)(nil),      // 18: auth.RequestTelegramLinkRequest
+	(*RequestTelegramLinkResponse)(nil),     // 19: auth.RequestTelegramLinkResponse
+	(*ConfirmTelegramLinkRequest)(nil),      // 20: auth.ConfirmTelegramLinkRequest
+	(*IssueTelegramLoginTokenRequest)(nil),  // 21: auth.IssueTelegramLoginTokenRequest
+	(*IssueTelegramLoginTokenResponse)(nil), // 22: auth.IssueTelegramLoginTokenResponse
+	(*ListUsersRequest)(nil),                // 23: auth.ListUsersRequest
+	(*ListUsersResponse)(nil),               // 24: auth.ListUsersResponse
+	(*timestamppb.Timestamp)(nil),           // 25: google.protobuf.Timestamp
 }
 var file_proto_auth_proto_depIdxs = []int32{
-	13, // 0: auth.User.created_at:type_name -> google.protobuf.Timestamp
-	13, // 1: auth.User.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 0: auth.User.created_at:type_name -> google.protobuf.Timestamp
+	25, // 1: auth.User.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 2: auth.AuthResponse.user:type_name -> auth.User
-	1,  // 3: auth.AuthService.Register:input_type -> auth.RegisterRequest
-	2,  // 4: auth.AuthService.Login:input_type -> auth.LoginRequest
-	3,  // 5: auth.AuthService.GoogleAuth:input_type -> auth.GoogleAuthRequest
-	4,  // 6: auth.AuthService.TelegramAuth:input_type -> auth.TelegramAuthRequest
-	6,  // 7: auth.AuthService.RefreshToken:input_type -> auth.RefreshTokenRequest
-	7,  // 8: auth.AuthService.GetProfile:input_type -> auth.GetProfileRequest
-	8,  // 9: auth.AuthService.UpdateProfile:input_type -> auth.UpdateProfileRequest
-	9,  // 10: auth.AuthService.ValidateToken:input_type -> auth.ValidateTokenRequest
-	11, // 11: auth.AuthService.Logout:input_type -> auth.LogoutRequest
-	5,  // 12: auth.AuthService.Register:output_type -> auth.AuthResponse
-	5,  // 13: auth.AuthService.Login:output_type -> auth.AuthResponse
-	5,  // 14: auth.AuthService.GoogleAuth:output_type -> auth.AuthResponse
-	5,  // 15: auth.AuthService.TelegramAuth:output_type -> auth.AuthResponse
-	5,  // 16: auth.AuthService.RefreshToken:output_type -> auth.AuthResponse
-	0,  // 17: auth.AuthService.GetProfile:output_type -> auth.User
-	0,  // 18: auth.AuthService.UpdateProfile:output_type -> auth.User
-	10, // 19: auth.AuthService.ValidateToken:output_type -> auth.ValidateTokenResponse
-	12, // 20: auth.AuthService.Logout:output_type -> auth.LogoutResponse
-	12, // [12:21] is the sub-list for method output_type
-	3,  // [3:12] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	25, // 3: auth.RequestTelegramLinkResponse.expires_at:type_name -> google.protobuf.Timestamp
+	25, // 4: auth.IssueTelegramLoginTokenResponse.expires_at:type_name -> google.protobuf.Timestamp
+	0,  // 5: auth.ListUsersResponse.users:type_name -> auth.User
+	1,  // 6: auth.AuthService.Register:input_type -> auth.RegisterRequest
+	2,  // 7: auth.AuthService.Login:input_type -> auth.LoginRequest
+	3,  // 8: auth.AuthService.GoogleAuth:input_type -> auth.GoogleAuthRequest
+	4,  // 9: auth.AuthService.GetAppleAuthURL:input_type -> auth.GetAppleAuthURLRequest
+	6,  // 10: auth.AuthService.AppleAuth:input_type -> auth.AppleAuthRequest
+	7,  // 11: auth.AuthService.TelegramAuth:input_type -> auth.TelegramAuthRequest
+	9,  // 12: auth.AuthService.RefreshToken:input_type -> auth.RefreshTokenRequest
+	10, // 13: auth.AuthService.GetProfile:input_type -> auth.GetProfileRequest
+	11, // 14: auth.AuthService.UpdateProfile:input_type -> auth.UpdateProfileRequest
+	12, // 15: auth.AuthService.ValidateToken:input_type -> auth.ValidateTokenRequest
+	14, // 16: auth.AuthService.GetTokenVersion:input_type -> auth.GetTokenVersionRequest
+	16, // 17: auth.AuthService.Logout:input_type -> auth.LogoutRequest
+	18, // 18: auth.AuthService.RequestTelegramLink:input_type -> auth.RequestTelegramLinkRequest
+	20, // 19: auth.AuthService.ConfirmTelegramLink:input_type -> auth.ConfirmTelegramLinkRequest
+	21, // 20: auth.AuthService.IssueTelegramLoginToken:input_type -> auth.IssueTelegramLoginTokenRequest
+	23, // 21: auth.AuthService.ListUsers:input_type -> auth.ListUsersRequest
+	8,  // 22: auth.AuthService.Register:output_type -> auth.AuthResponse
+	8,  // 23: auth.AuthService.Login:output_type -> auth.AuthResponse
+	8,  // 24: auth.AuthService.GoogleAuth:output_type -> auth.AuthResponse
+	5,  // 25: auth.AuthService.GetAppleAuthURL:output_type -> auth.GetAppleAuthURLResponse
+	8,  // 26: auth.AuthService.AppleAuth:output_type -> auth.AuthResponse
+	8,  // 27: auth.AuthService.TelegramAuth:output_type -> auth.AuthResponse
+	8,  // 28: auth.AuthService.RefreshToken:output_type -> auth.AuthResponse
+	0,  // 29: auth.AuthService.GetProfile:output_type -> auth.User
+	0,  // 30: auth.AuthService.UpdateProfile:output_type -> auth.User
+	13, // 31: auth.AuthService.ValidateToken:output_type -> auth.ValidateTokenResponse
+	15, // 32: auth.AuthService.GetTokenVersion:output_type -> auth.GetTokenVersionResponse
+	17, // 33: auth.AuthService.Logout:output_type -> auth.LogoutResponse
+	19, // 34: auth.AuthService.RequestTelegramLink:output_type -> auth.RequestTelegramLinkResponse
+	0,  // 35: auth.AuthService.ConfirmTelegramLink:output_type -> auth.User
+	22, // 36: auth.AuthService.IssueTelegramLoginToken:output_type -> auth.IssueTelegramLoginTokenResponse
+	24, // 37: auth.AuthService.ListUsers:output_type -> auth.ListUsersResponse
+	22, // [22:38] is the sub-list for method output_type
+	6,  // [6:22] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_proto_auth_proto_init() }
@@ -934,7 +1604,7 @@ func file_proto_auth_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_auth_proto_rawDesc), len(file_proto_auth_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   13,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,15 +19,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthService_Register_FullMethodName      = "/auth.AuthService/Register"
-	AuthService_Login_FullMethodName         = "/auth.AuthService/Login"
-	AuthService_GoogleAuth_FullMethodName    = "/auth.AuthService/GoogleAuth"
-	AuthService_TelegramAuth_FullMethodName  = "/auth.AuthService/TelegramAuth"
-	AuthService_RefreshToken_FullMethodName  = "/auth.AuthService/RefreshToken"
-	AuthService_GetProfile_FullMethodName    = "/auth.AuthService/GetProfile"
-	AuthService_UpdateProfile_FullMethodName = "/auth.AuthService/UpdateProfile"
-	AuthService_ValidateToken_FullMethodName = "/auth.AuthService/ValidateToken"
-	AuthService_Logout_FullMethodName        = "/auth.AuthService/Logout"
+	AuthService_Register_FullMethodName                = "/auth.AuthService/Register"
+	AuthService_Login_FullMethodName                   = "/auth.AuthService/Login"
+	AuthService_GoogleAuth_FullMethodName              = "/auth.AuthService/GoogleAuth"
+	AuthService_GetAppleAuthURL_FullMethodName         = "/auth.AuthService/GetAppleAuthURL"
+	AuthService_AppleAuth_FullMethodName               = "/auth.AuthService/AppleAuth"
+	AuthService_TelegramAuth_FullMethodName            = "/auth.AuthService/TelegramAuth"
+	AuthService_RefreshToken_FullMethodName            = "/auth.AuthService/RefreshToken"
+	AuthService_GetProfile_FullMethodName              = "/auth.AuthService/GetProfile"
+	AuthService_UpdateProfile_FullMethodName           = "/auth.AuthService/UpdateProfile"
+	AuthService_ValidateToken_FullMethodName           = "/auth.AuthService/ValidateToken"
+	AuthService_GetTokenVersion_FullMethodName         = "/auth.AuthService/GetTokenVersion"
+	AuthService_Logout_FullMethodName                  = "/auth.AuthService/Logout"
+	AuthService_RequestTelegramLink_FullMethodName     = "/auth.AuthService/RequestTelegramLink"
+	AuthService_ConfirmTelegramLink_FullMethodName     = "/auth.AuthService/ConfirmTelegramLink"
+	AuthService_IssueTelegramLoginToken_FullMethodName = "/auth.AuthService/IssueTelegramLoginToken"
+	AuthService_ListUsers_FullMethodName               = "/auth.AuthService/ListUsers"
 )
 
 // AuthServiceClient is the client API for AuthService service.
@@ -37,12 +44,21 @@ type AuthServiceClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	GoogleAuth(ctx context.Context, in *GoogleAuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
+	GetAppleAuthURL(ctx context.Context, in *GetAppleAuthURLRequest, opts ...grpc.CallOption) (*GetAppleAuthURLResponse, error)
+	AppleAuth(ctx context.Context, in *AppleAuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	TelegramAuth(ctx context.Context, in *TelegramAuthRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	RefreshToken(ctx context.Context, in *RefreshTokenRequest, opts ...grpc.CallOption) (*AuthResponse, error)
 	GetProfile(ctx context.Context, in *GetProfileRequest, opts ...grpc.CallOption) (*User, error)
 	UpdateProfile(ctx context.Context, in *UpdateProfileRequest, opts ...grpc.CallOption) (*User, error)
 	ValidateToken(ctx context.Context, in *ValidateTokenRequest, opts ...grpc.CallOption) (*ValidateTokenResponse, error)
+	// GetTokenVersion lets other services repopulate their token version cache
+	GetTokenVersion(ctx context.Context, in *GetTokenVersionRequest, opts ...grpc.CallOption) (*GetTokenVersionResponse, error)
 	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	RequestTelegramLink(ctx context.Context, in *RequestTelegramLinkRequest, opts ...grpc.CallOption) (*RequestTelegramLinkResponse, error)
+	ConfirmTelegramLink(ctx context.Context, in *ConfirmTelegramLinkRequest, opts ...grpc.CallOption) (*User, error)
+	IssueTelegramLoginToken(ctx context.Context, in *IssueTelegramLoginTokenRequest, opts ...grpc.CallOption) (*IssueTelegramLoginTokenResponse, error)
+	// ListUsers pages through active users for internal batch jobs
+	ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error)
 }
 
 type authServiceClient struct {
@@ -83,6 +99,26 @@ func (c *authServiceClient) GoogleAuth(ctx context.Context, in *GoogleAuthReques
 	return out, nil
 }
 
+func (c *authServiceClient) GetAppleAuthURL(ctx context.Context, in *GetAppleAuthURLRequest, opts ...grpc.CallOption) (*GetAppleAuthURLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAppleAuthURLResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetAppleAuthURL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) AppleAuth(ctx context.Context, in *AppleAuthRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AuthResponse)
+	err := c.cc.Invoke(ctx, AuthService_AppleAuth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) TelegramAuth(ctx context.Context, in *TelegramAuthRequest, opts ...grpc.CallOption) (*AuthResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AuthResponse)
@@ -133,6 +169,16 @@ func (c *authServiceClient) ValidateToken(ctx context.Context, in *ValidateToken
 	return out, nil
 }
 
+func (c *authServiceClient) GetTokenVersion(ctx context.Context, in *GetTokenVersionRequest, opts ...grpc.CallOption) (*GetTokenVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetTokenVersionResponse)
+	err := c.cc.Invoke(ctx, AuthService_GetTokenVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *authServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LogoutResponse)
@@ -143,6 +189,46 @@ func (c *authServiceClient) Logout(ctx context.Context, in *LogoutRequest, opts
 	return out, nil
 }
 
+func (c *authServiceClient) RequestTelegramLink(ctx context.Context, in *RequestTelegramLinkRequest, opts ...grpc.CallOption) (*RequestTelegramLinkResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestTelegramLinkResponse)
+	err := c.cc.Invoke(ctx, AuthService_RequestTelegramLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ConfirmTelegramLink(ctx context.Context, in *ConfirmTelegramLinkRequest, opts ...grpc.CallOption) (*User, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(User)
+	err := c.cc.Invoke(ctx, AuthService_ConfirmTelegramLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) IssueTelegramLoginToken(ctx context.Context, in *IssueTelegramLoginTokenRequest, opts ...grpc.CallOption) (*IssueTelegramLoginTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueTelegramLoginTokenResponse)
+	err := c.cc.Invoke(ctx, AuthService_IssueTelegramLoginToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ListUsers(ctx context.Context, in *ListUsersRequest, opts ...grpc.CallOption) (*ListUsersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListUsersResponse)
+	err := c.cc.Invoke(ctx, AuthService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthServiceServer is the server API for AuthService service.
 // All implementations must embed UnimplementedAuthServiceServer
 // for forward compatibility.
@@ -150,12 +236,21 @@ type AuthServiceServer interface {
 	Register(context.Context, *RegisterRequest) (*AuthResponse, error)
 	Login(context.Context, *LoginRequest) (*AuthResponse, error)
 	GoogleAuth(context.Context, *GoogleAuthRequest) (*AuthResponse, error)
+	GetAppleAuthURL(context.Context, *GetAppleAuthURLRequest) (*GetAppleAuthURLResponse, error)
+	AppleAuth(context.Context, *AppleAuthRequest) (*AuthResponse, error)
 	TelegramAuth(context.Context, *TelegramAuthRequest) (*AuthResponse, error)
 	RefreshToken(context.Context, *RefreshTokenRequest) (*AuthResponse, error)
 	GetProfile(context.Context, *GetProfileRequest) (*User, error)
 	UpdateProfile(context.Context, *UpdateProfileRequest) (*User, error)
 	ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error)
+	// GetTokenVersion lets other services repopulate their token version cache
+	GetTokenVersion(context.Context, *GetTokenVersionRequest) (*GetTokenVersionResponse, error)
 	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	RequestTelegramLink(context.Context, *RequestTelegramLinkRequest) (*RequestTelegramLinkResponse, error)
+	ConfirmTelegramLink(context.Context, *ConfirmTelegramLinkRequest) (*User, error)
+	IssueTelegramLoginToken(context.Context, *IssueTelegramLoginTokenRequest) (*IssueTelegramLoginTokenResponse, error)
+	// ListUsers pages through active users for internal batch jobs
+	ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error)
 	mustEmbedUnimplementedAuthServiceServer()
 }
 
@@ -175,6 +270,12 @@ func (UnimplementedAuthServiceServer) Login(context.Context, *LoginRequest) (*Au
 func (UnimplementedAuthServiceServer) GoogleAuth(context.Context, *GoogleAuthRequest) (*AuthResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GoogleAuth not implemented")
 }
+func (UnimplementedAuthServiceServer) GetAppleAuthURL(context.Context, *GetAppleAuthURLRequest) (*GetAppleAuthURLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAppleAuthURL not implemented")
+}
+func (UnimplementedAuthServiceServer) AppleAuth(context.Context, *AppleAuthRequest) (*AuthResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AppleAuth not implemented")
+}
 func (UnimplementedAuthServiceServer) TelegramAuth(context.Context, *TelegramAuthRequest) (*AuthResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method TelegramAuth not implemented")
 }
@@ -190,9 +291,24 @@ func (UnimplementedAuthServiceServer) UpdateProfile(context.Context, *UpdateProf
 func (UnimplementedAuthServiceServer) ValidateToken(context.Context, *ValidateTokenRequest) (*ValidateTokenResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ValidateToken not implemented")
 }
+func (UnimplementedAuthServiceServer) GetTokenVersion(context.Context, *GetTokenVersionRequest) (*GetTokenVersionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetTokenVersion not implemented")
+}
 func (UnimplementedAuthServiceServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method Logout not implemented")
 }
+func (UnimplementedAuthServiceServer) RequestTelegramLink(context.Context, *RequestTelegramLinkRequest) (*RequestTelegramLinkResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestTelegramLink not implemented")
+}
+func (UnimplementedAuthServiceServer) ConfirmTelegramLink(context.Context, *ConfirmTelegramLinkRequest) (*User, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmTelegramLink not implemented")
+}
+func (UnimplementedAuthServiceServer) IssueTelegramLoginToken(context.Context, *IssueTelegramLoginTokenRequest) (*IssueTelegramLoginTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IssueTelegramLoginToken not implemented")
+}
+func (UnimplementedAuthServiceServer) ListUsers(context.Context, *ListUsersRequest) (*ListUsersResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListUsers not implemented")
+}
 func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
 func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
@@ -268,6 +384,42 @@ func _AuthService_GoogleAuth_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_GetAppleAuthURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAppleAuthURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetAppleAuthURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetAppleAuthURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetAppleAuthURL(ctx, req.(*GetAppleAuthURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_AppleAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AppleAuthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).AppleAuth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_AppleAuth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).AppleAuth(ctx, req.(*AppleAuthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_TelegramAuth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(TelegramAuthRequest)
 	if err := dec(in); err != nil {
@@ -358,6 +510,24 @@ func _AuthService_ValidateToken_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_GetTokenVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTokenVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetTokenVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetTokenVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetTokenVersion(ctx, req.(*GetTokenVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(LogoutRequest)
 	if err := dec(in); err != nil {
@@ -376,6 +546,78 @@ func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthService_RequestTelegramLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestTelegramLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestTelegramLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestTelegramLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestTelegramLink(ctx, req.(*RequestTelegramLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ConfirmTelegramLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTelegramLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ConfirmTelegramLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ConfirmTelegramLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ConfirmTelegramLink(ctx, req.(*ConfirmTelegramLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_IssueTelegramLoginToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueTelegramLoginTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).IssueTelegramLoginToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_IssueTelegramLoginToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).IssueTelegramLoginToken(ctx, req.(*IssueTelegramLoginTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListUsersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListUsers(ctx, req.(*ListUsersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -395,6 +637,14 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GoogleAuth",
 			Handler:    _AuthService_GoogleAuth_Handler,
 		},
+		{
+			MethodName: "GetAppleAuthURL",
+			Handler:    _AuthService_GetAppleAuthURL_Handler,
+		},
+		{
+			MethodName: "AppleAuth",
+			Handler:    _AuthService_AppleAuth_Handler,
+		},
 		{
 			MethodName: "TelegramAuth",
 			Handler:    _AuthService_TelegramAuth_Handler,
@@ -415,10 +665,30 @@ var AuthService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ValidateToken",
 			Handler:    _AuthService_ValidateToken_Handler,
 		},
+		{
+			MethodName: "GetTokenVersion",
+			Handler:    _AuthService_GetTokenVersion_Handler,
+		},
 		{
 			MethodName: "Logout",
 			Handler:    _AuthService_Logout_Handler,
 		},
+		{
+			MethodName: "RequestTelegramLink",
+			Handler:    _AuthService_RequestTelegramLink_Handler,
+		},
+		{
+			MethodName: "ConfirmTelegramLink",
+			Handler:    _AuthService_ConfirmTelegramLink_Handler,
+		},
+		{
+			MethodName: "IssueTelegramLoginToken",
+			Handler:    _AuthService_IssueTelegramLoginToken_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _AuthService_ListUsers_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/auth.proto",
@@ -442,6 +442,182 @@ func (x *ConvertAmountResponse) GetRateTimestamp() *timestamppb.Timestamp {
 	return nil
 }
 
+type ConvertDetailedResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	OriginalAmount  float64                `protobuf:"fixed64,1,opt,name=original_amount,json=originalAmount,proto3" json:"original_amount,omitempty"`
+	FromCurrency    string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ConvertedAmount float64                `protobuf:"fixed64,3,opt,name=converted_amount,json=convertedAmount,proto3" json:"converted_amount,omitempty"`
+	ToCurrency      string                 `protobuf:"bytes,4,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Rate            float64                `protobuf:"fixed64,5,opt,name=rate,proto3" json:"rate,omitempty"`
+	InverseRate     float64                `protobuf:"fixed64,6,opt,name=inverse_rate,json=inverseRate,proto3" json:"inverse_rate,omitempty"`
+	UpdatedAt       *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Stale           bool                   `protobuf:"varint,8,opt,name=stale,proto3" json:"stale,omitempty"`
+	Provider        string                 `protobuf:"bytes,9,opt,name=provider,proto3" json:"provider,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConvertDetailedResponse) Reset() {
+	*x = ConvertDetailedResponse{}
+	mi := &file_proto_currency_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertDetailedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertDetailedResponse) ProtoMessage() {}
+
+func (x *ConvertDetailedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_currency_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertDetailedResponse.ProtoReflect.Descriptor instead.
+func (*ConvertDetailedResponse) Descriptor() ([]byte, []int) {
+	return file_proto_currency_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ConvertDetailedResponse) GetOriginalAmount() float64 {
+	if x != nil {
+		return x.OriginalAmount
+	}
+	return 0
+}
+
+func (x *ConvertDetailedResponse) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConvertDetailedResponse) GetConvertedAmount() float64 {
+	if x != nil {
+		return x.ConvertedAmount
+	}
+	return 0
+}
+
+func (x *ConvertDetailedResponse) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConvertDetailedResponse) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *ConvertDetailedResponse) GetInverseRate() float64 {
+	if x != nil {
+		return x.InverseRate
+	}
+	return 0
+}
+
+func (x *ConvertDetailedResponse) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ConvertDetailedResponse) GetStale() bool {
+	if x != nil {
+		return x.Stale
+	}
+	return false
+}
+
+func (x *ConvertDetailedResponse) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+type ConvertAsOfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Amount        float64                `protobuf:"fixed64,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	FromCurrency  string                 `protobuf:"bytes,2,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
+	ToCurrency    string                 `protobuf:"bytes,3,opt,name=to_currency,json=toCurrency,proto3" json:"to_currency,omitempty"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=date,proto3" json:"date,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConvertAsOfRequest) Reset() {
+	*x = ConvertAsOfRequest{}
+	mi := &file_proto_currency_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConvertAsOfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConvertAsOfRequest) ProtoMessage() {}
+
+func (x *ConvertAsOfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_currency_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConvertAsOfRequest.ProtoReflect.Descriptor instead.
+func (*ConvertAsOfRequest) Descriptor() ([]byte, []int) {
+	return file_proto_currency_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ConvertAsOfRequest) GetAmount() float64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ConvertAsOfRequest) GetFromCurrency() string {
+	if x != nil {
+		return x.FromCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAsOfRequest) GetToCurrency() string {
+	if x != nil {
+		return x.ToCurrency
+	}
+	return ""
+}
+
+func (x *ConvertAsOfRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
 type ConvertMultipleAmountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Amounts       []*AmountToConvert     `protobuf:"bytes,1,rep,name=amounts,proto3" json:"amounts,omitempty"`
@@ -452,7 +628,7 @@ type ConvertMultipleAmountsRequest struct {
 
 func (x *ConvertMultipleAmountsRequest) Reset() {
 	*x = ConvertMultipleAmountsRequest{}
-	mi := &file_proto_currency_proto_msgTypes[7]
+	mi := &file_proto_currency_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -464,7 +640,7 @@ func (x *ConvertMultipleAmountsRequest) String() string {
 func (*ConvertMultipleAmountsRequest) ProtoMessage() {}
 
 func (x *ConvertMultipleAmountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[7]
+	mi := &file_proto_currency_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -477,7 +653,7 @@ func (x *ConvertMultipleAmountsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConvertMultipleAmountsRequest.ProtoReflect.Descriptor instead.
 func (*ConvertMultipleAmountsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{7}
+	return file_proto_currency_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ConvertMultipleAmountsRequest) GetAmounts() []*AmountToConvert {
@@ -505,7 +681,7 @@ type AmountToConvert struct {
 
 func (x *AmountToConvert) Reset() {
 	*x = AmountToConvert{}
-	mi := &file_proto_currency_proto_msgTypes[8]
+	mi := &file_proto_currency_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -517,7 +693,7 @@ func (x *AmountToConvert) String() string {
 func (*AmountToConvert) ProtoMessage() {}
 
 func (x *AmountToConvert) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[8]
+	mi := &file_proto_currency_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -530,7 +706,7 @@ func (x *AmountToConvert) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AmountToConvert.ProtoReflect.Descriptor instead.
 func (*AmountToConvert) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{8}
+	return file_proto_currency_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AmountToConvert) GetId() string {
@@ -565,7 +741,7 @@ type ConvertMultipleAmountsResponse struct {
 
 func (x *ConvertMultipleAmountsResponse) Reset() {
 	*x = ConvertMultipleAmountsResponse{}
-	mi := &file_proto_currency_proto_msgTypes[9]
+	mi := &file_proto_currency_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -577,7 +753,7 @@ func (x *ConvertMultipleAmountsResponse) String() string {
 func (*ConvertMultipleAmountsResponse) ProtoMessage() {}
 
 func (x *ConvertMultipleAmountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[9]
+	mi := &file_proto_currency_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -590,7 +766,7 @@ func (x *ConvertMultipleAmountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConvertMultipleAmountsResponse.ProtoReflect.Descriptor instead.
 func (*ConvertMultipleAmountsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{9}
+	return file_proto_currency_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ConvertMultipleAmountsResponse) GetConverted() []*ConvertedAmount {
@@ -621,13 +797,14 @@ type ConvertedAmount struct {
 	FromCurrency    string                 `protobuf:"bytes,3,opt,name=from_currency,json=fromCurrency,proto3" json:"from_currency,omitempty"`
 	ConvertedAmount float64                `protobuf:"fixed64,4,opt,name=converted_amount,json=convertedAmount,proto3" json:"converted_amount,omitempty"`
 	RateUsed        float64                `protobuf:"fixed64,5,opt,name=rate_used,json=rateUsed,proto3" json:"rate_used,omitempty"`
+	Error           string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
 
 func (x *ConvertedAmount) Reset() {
 	*x = ConvertedAmount{}
-	mi := &file_proto_currency_proto_msgTypes[10]
+	mi := &file_proto_currency_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -639,7 +816,7 @@ func (x *ConvertedAmount) String() string {
 func (*ConvertedAmount) ProtoMessage() {}
 
 func (x *ConvertedAmount) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[10]
+	mi := &file_proto_currency_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -652,7 +829,7 @@ func (x *ConvertedAmount) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConvertedAmount.ProtoReflect.Descriptor instead.
 func (*ConvertedAmount) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{10}
+	return file_proto_currency_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ConvertedAmount) GetId() string {
@@ -690,6 +867,13 @@ func (x *ConvertedAmount) GetRateUsed() float64 {
 	return 0
 }
 
+func (x *ConvertedAmount) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 type Currency struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
@@ -703,7 +887,7 @@ type Currency struct {
 
 func (x *Currency) Reset() {
 	*x = Currency{}
-	mi := &file_proto_currency_proto_msgTypes[11]
+	mi := &file_proto_currency_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -715,7 +899,7 @@ func (x *Currency) String() string {
 func (*Currency) ProtoMessage() {}
 
 func (x *Currency) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[11]
+	mi := &file_proto_currency_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -728,7 +912,7 @@ func (x *Currency) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Currency.ProtoReflect.Descriptor instead.
 func (*Currency) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{11}
+	return file_proto_currency_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *Currency) GetCode() string {
@@ -775,7 +959,7 @@ type ListSupportedCurrenciesRequest struct {
 
 func (x *ListSupportedCurrenciesRequest) Reset() {
 	*x = ListSupportedCurrenciesRequest{}
-	mi := &file_proto_currency_proto_msgTypes[12]
+	mi := &file_proto_currency_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -787,7 +971,7 @@ func (x *ListSupportedCurrenciesRequest) String() string {
 func (*ListSupportedCurrenciesRequest) ProtoMessage() {}
 
 func (x *ListSupportedCurrenciesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[12]
+	mi := &file_proto_currency_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,7 +984,7 @@ func (x *ListSupportedCurrenciesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSupportedCurrenciesRequest.ProtoReflect.Descriptor instead.
 func (*ListSupportedCurrenciesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{12}
+	return file_proto_currency_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ListSupportedCurrenciesRequest) GetIncludeCrypto() bool {
@@ -819,7 +1003,7 @@ type ListSupportedCurrenciesResponse struct {
 
 func (x *ListSupportedCurrenciesResponse) Reset() {
 	*x = ListSupportedCurrenciesResponse{}
-	mi := &file_proto_currency_proto_msgTypes[13]
+	mi := &file_proto_currency_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -831,7 +1015,7 @@ func (x *ListSupportedCurrenciesResponse) String() string {
 func (*ListSupportedCurrenciesResponse) ProtoMessage() {}
 
 func (x *ListSupportedCurrenciesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[13]
+	mi := &file_proto_currency_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -844,7 +1028,7 @@ func (x *ListSupportedCurrenciesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListSupportedCurrenciesResponse.ProtoReflect.Descriptor instead.
 func (*ListSupportedCurrenciesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{13}
+	return file_proto_currency_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *ListSupportedCurrenciesResponse) GetCurrencies() []*Currency {
@@ -866,7 +1050,7 @@ type GetRateHistoryRequest struct {
 
 func (x *GetRateHistoryRequest) Reset() {
 	*x = GetRateHistoryRequest{}
-	mi := &file_proto_currency_proto_msgTypes[14]
+	mi := &file_proto_currency_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -878,7 +1062,7 @@ func (x *GetRateHistoryRequest) String() string {
 func (*GetRateHistoryRequest) ProtoMessage() {}
 
 func (x *GetRateHistoryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[14]
+	mi := &file_proto_currency_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -891,7 +1075,7 @@ func (x *GetRateHistoryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRateHistoryRequest.ProtoReflect.Descriptor instead.
 func (*GetRateHistoryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{14}
+	return file_proto_currency_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetRateHistoryRequest) GetFromCurrency() string {
@@ -933,7 +1117,7 @@ type RateHistoryResponse struct {
 
 func (x *RateHistoryResponse) Reset() {
 	*x = RateHistoryResponse{}
-	mi := &file_proto_currency_proto_msgTypes[15]
+	mi := &file_proto_currency_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -945,7 +1129,7 @@ func (x *RateHistoryResponse) String() string {
 func (*RateHistoryResponse) ProtoMessage() {}
 
 func (x *RateHistoryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[15]
+	mi := &file_proto_currency_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -958,7 +1142,7 @@ func (x *RateHistoryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateHistoryResponse.ProtoReflect.Descriptor instead.
 func (*RateHistoryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{15}
+	return file_proto_currency_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RateHistoryResponse) GetFromCurrency() string {
@@ -992,7 +1176,7 @@ type RatePoint struct {
 
 func (x *RatePoint) Reset() {
 	*x = RatePoint{}
-	mi := &file_proto_currency_proto_msgTypes[16]
+	mi := &file_proto_currency_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1004,7 +1188,7 @@ func (x *RatePoint) String() string {
 func (*RatePoint) ProtoMessage() {}
 
 func (x *RatePoint) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[16]
+	mi := &file_proto_currency_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1017,7 +1201,7 @@ func (x *RatePoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RatePoint.ProtoReflect.Descriptor instead.
 func (*RatePoint) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{16}
+	return file_proto_currency_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *RatePoint) GetDate() *timestamppb.Timestamp {
@@ -1043,7 +1227,7 @@ type RefreshRatesRequest struct {
 
 func (x *RefreshRatesRequest) Reset() {
 	*x = RefreshRatesRequest{}
-	mi := &file_proto_currency_proto_msgTypes[17]
+	mi := &file_proto_currency_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1055,7 +1239,7 @@ func (x *RefreshRatesRequest) String() string {
 func (*RefreshRatesRequest) ProtoMessage() {}
 
 func (x *RefreshRatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[17]
+	mi := &file_proto_currency_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1068,7 +1252,7 @@ func (x *RefreshRatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshRatesRequest.ProtoReflect.Descriptor instead.
 func (*RefreshRatesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{17}
+	return file_proto_currency_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *RefreshRatesRequest) GetBaseCurrency() string {
@@ -1089,7 +1273,7 @@ type RefreshRatesResponse struct {
 
 func (x *RefreshRatesResponse) Reset() {
 	*x = RefreshRatesResponse{}
-	mi := &file_proto_currency_proto_msgTypes[18]
+	mi := &file_proto_currency_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1101,7 +1285,7 @@ func (x *RefreshRatesResponse) String() string {
 func (*RefreshRatesResponse) ProtoMessage() {}
 
 func (x *RefreshRatesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[18]
+	mi := &file_proto_currency_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1114,7 +1298,7 @@ func (x *RefreshRatesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RefreshRatesResponse.ProtoReflect.Descriptor instead.
 func (*RefreshRatesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{18}
+	return file_proto_currency_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RefreshRatesResponse) GetSuccess() bool {
@@ -1146,7 +1330,7 @@ type GetLastUpdateTimeRequest struct {
 
 func (x *GetLastUpdateTimeRequest) Reset() {
 	*x = GetLastUpdateTimeRequest{}
-	mi := &file_proto_currency_proto_msgTypes[19]
+	mi := &file_proto_currency_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1158,7 +1342,7 @@ func (x *GetLastUpdateTimeRequest) String() string {
 func (*GetLastUpdateTimeRequest) ProtoMessage() {}
 
 func (x *GetLastUpdateTimeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[19]
+	mi := &file_proto_currency_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1171,7 +1355,7 @@ func (x *GetLastUpdateTimeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLastUpdateTimeRequest.ProtoReflect.Descriptor instead.
 func (*GetLastUpdateTimeRequest) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{19}
+	return file_proto_currency_proto_rawDescGZIP(), []int{21}
 }
 
 type GetLastUpdateTimeResponse struct {
@@ -1184,7 +1368,7 @@ type GetLastUpdateTimeResponse struct {
 
 func (x *GetLastUpdateTimeResponse) Reset() {
 	*x = GetLastUpdateTimeResponse{}
-	mi := &file_proto_currency_proto_msgTypes[20]
+	mi := &file_proto_currency_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1196,7 +1380,7 @@ func (x *GetLastUpdateTimeResponse) String() string {
 func (*GetLastUpdateTimeResponse) ProtoMessage() {}
 
 func (x *GetLastUpdateTimeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_currency_proto_msgTypes[20]
+	mi := &file_proto_currency_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1209,7 +1393,7 @@ func (x *GetLastUpdateTimeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLastUpdateTimeResponse.ProtoReflect.Descriptor instead.
 func (*GetLastUpdateTimeResponse) Descriptor() ([]byte, []int) {
-	return file_proto_currency_proto_rawDescGZIP(), []int{20}
+	return file_proto_currency_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetLastUpdateTimeResponse) GetLastUpdate() *timestamppb.Timestamp {
@@ -1268,7 +1452,25 @@ const file_proto_currency_proto_rawDesc = "" +
 	"\vto_currency\x18\x04 \x01(\tR\n" +
 	"toCurrency\x12\x1b\n" +
 	"\trate_used\x18\x05 \x01(\x01R\brateUsed\x12A\n" +
-	"\x0erate_timestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rrateTimestamp\"u\n" +
+	"\x0erate_timestamp\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rrateTimestamp\"\xd7\x02\n" +
+	"\x17ConvertDetailedResponse\x12'\n" +
+	"\x0foriginal_amount\x18\x01 \x01(\x01R\x0eoriginalAmount\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12)\n" +
+	"\x10converted_amount\x18\x03 \x01(\x01R\x0fconvertedAmount\x12\x1f\n" +
+	"\vto_currency\x18\x04 \x01(\tR\n" +
+	"toCurrency\x12\x12\n" +
+	"\x04rate\x18\x05 \x01(\x01R\x04rate\x12!\n" +
+	"\finverse_rate\x18\x06 \x01(\x01R\vinverseRate\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x14\n" +
+	"\x05stale\x18\b \x01(\bR\x05stale\x12\x1a\n" +
+	"\bprovider\x18\t \x01(\tR\bprovider\"\xa2\x01\n" +
+	"\x12ConvertAsOfRequest\x12\x16\n" +
+	"\x06amount\x18\x01 \x01(\x01R\x06amount\x12#\n" +
+	"\rfrom_currency\x18\x02 \x01(\tR\ffromCurrency\x12\x1f\n" +
+	"\vto_currency\x18\x03 \x01(\tR\n" +
+	"toCurrency\x12.\n" +
+	"\x04date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\"u\n" +
 	"\x1dConvertMultipleAmountsRequest\x123\n" +
 	"\aamounts\x18\x01 \x03(\v2\x19.currency.AmountToConvertR\aamounts\x12\x1f\n" +
 	"\vto_currency\x18\x02 \x01(\tR\n" +
@@ -1281,13 +1483,14 @@ const file_proto_currency_proto_rawDesc = "" +
 	"\tconverted\x18\x01 \x03(\v2\x19.currency.ConvertedAmountR\tconverted\x12\x14\n" +
 	"\x05total\x18\x02 \x01(\x01R\x05total\x12\x1f\n" +
 	"\vto_currency\x18\x03 \x01(\tR\n" +
-	"toCurrency\"\xb7\x01\n" +
+	"toCurrency\"\xcd\x01\n" +
 	"\x0fConvertedAmount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12'\n" +
 	"\x0foriginal_amount\x18\x02 \x01(\x01R\x0eoriginalAmount\x12#\n" +
 	"\rfrom_currency\x18\x03 \x01(\tR\ffromCurrency\x12)\n" +
 	"\x10converted_amount\x18\x04 \x01(\x01R\x0fconvertedAmount\x12\x1b\n" +
-	"\trate_used\x18\x05 \x01(\x01R\brateUsed\"\x8e\x01\n" +
+	"\trate_used\x18\x05 \x01(\x01R\brateUsed\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\"\x8e\x01\n" +
 	"\bCurrency\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
@@ -1326,12 +1529,14 @@ const file_proto_currency_proto_rawDesc = "" +
 	"\x19GetLastUpdateTimeResponse\x12;\n" +
 	"\vlast_update\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"lastUpdate\x12#\n" +
-	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency2\x87\x06\n" +
+	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency2\xab\a\n" +
 	"\x0fCurrencyService\x12S\n" +
 	"\x0fGetExchangeRate\x12 .currency.GetExchangeRateRequest\x1a\x1e.currency.ExchangeRateResponse\x12q\n" +
 	"\x18GetMultipleExchangeRates\x12).currency.GetMultipleExchangeRatesRequest\x1a*.currency.GetMultipleExchangeRatesResponse\x12P\n" +
 	"\rConvertAmount\x12\x1e.currency.ConvertAmountRequest\x1a\x1f.currency.ConvertAmountResponse\x12k\n" +
-	"\x16ConvertMultipleAmounts\x12'.currency.ConvertMultipleAmountsRequest\x1a(.currency.ConvertMultipleAmountsResponse\x12n\n" +
+	"\x16ConvertMultipleAmounts\x12'.currency.ConvertMultipleAmountsRequest\x1a(.currency.ConvertMultipleAmountsResponse\x12L\n" +
+	"\vConvertAsOf\x12\x1c.currency.ConvertAsOfRequest\x1a\x1f.currency.ConvertAmountResponse\x12T\n" +
+	"\x0fConvertDetailed\x12\x1e.currency.ConvertAmountRequest\x1a!.currency.ConvertDetailedResponse\x12n\n" +
 	"\x17ListSupportedCurrencies\x12(.currency.ListSupportedCurrenciesRequest\x1a).currency.ListSupportedCurrenciesResponse\x12P\n" +
 	"\x0eGetRateHistory\x12\x1f.currency.GetRateHistoryRequest\x1a\x1d.currency.RateHistoryResponse\x12M\n" +
 	"\fRefreshRates\x12\x1d.currency.RefreshRatesRequest\x1a\x1e.currency.RefreshRatesResponse\x12\\\n" +
@@ -1349,7 +1554,7 @@ func file_proto_currency_proto_rawDescGZIP() []byte {
 	return file_proto_currency_proto_rawDescData
 }
 
-var file_proto_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_proto_currency_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_currency_proto_goTypes = []any{
 	(*ExchangeRate)(nil),                     // 0: currency.ExchangeRate
 	(*GetExchangeRateRequest)(nil),           // 1: currency.GetExchangeRateRequest
@@ -1358,59 +1563,67 @@ var file_proto_currency_proto_goTypes = []any{
 	(*GetMultipleExchangeRatesResponse)(nil), // 4: currency.GetMultipleExchangeRatesResponse
 	(*ConvertAmountRequest)(nil),             // 5: currency.ConvertAmountRequest
 	(*ConvertAmountResponse)(nil),            // 6: currency.ConvertAmountResponse
-	(*ConvertMultipleAmountsRequest)(nil),    // 7: currency.ConvertMultipleAmountsRequest
-	(*AmountToConvert)(nil),                  // 8: currency.AmountToConvert
-	(*ConvertMultipleAmountsResponse)(nil),   // 9: currency.ConvertMultipleAmountsResponse
-	(*ConvertedAmount)(nil),                  // 10: currency.ConvertedAmount
-	(*Currency)(nil),                         // 11: currency.Currency
-	(*ListSupportedCurrenciesRequest)(nil),   // 12: currency.ListSupportedCurrenciesRequest
-	(*ListSupportedCurrenciesResponse)(nil),  // 13: currency.ListSupportedCurrenciesResponse
-	(*GetRateHistoryRequest)(nil),            // 14: currency.GetRateHistoryRequest
-	(*RateHistoryResponse)(nil),              // 15: currency.RateHistoryResponse
-	(*RatePoint)(nil),                        // 16: currency.RatePoint
-	(*RefreshRatesRequest)(nil),              // 17: currency.RefreshRatesRequest
-	(*RefreshRatesResponse)(nil),             // 18: currency.RefreshRatesResponse
-	(*GetLastUpdateTimeRequest)(nil),         // 19: currency.GetLastUpdateTimeRequest
-	(*GetLastUpdateTimeResponse)(nil),        // 20: currency.GetLastUpdateTimeResponse
-	nil,                                      // 21: currency.GetMultipleExchangeRatesResponse.RatesEntry
-	(*timestamppb.Timestamp)(nil),            // 22: google.protobuf.Timestamp
+	(*ConvertDetailedResponse)(nil),          // 7: currency.ConvertDetailedResponse
+	(*ConvertAsOfRequest)(nil),               // 8: currency.ConvertAsOfRequest
+	(*ConvertMultipleAmountsRequest)(nil),    // 9: currency.ConvertMultipleAmountsRequest
+	(*AmountToConvert)(nil),                  // 10: currency.AmountToConvert
+	(*ConvertMultipleAmountsResponse)(nil),   // 11: currency.ConvertMultipleAmountsResponse
+	(*ConvertedAmount)(nil),                  // 12: currency.ConvertedAmount
+	(*Currency)(nil),                         // 13: currency.Currency
+	(*ListSupportedCurrenciesRequest)(nil),   // 14: currency.ListSupportedCurrenciesRequest
+	(*ListSupportedCurrenciesResponse)(nil),  // 15: currency.ListSupportedCurrenciesResponse
+	(*GetRateHistoryRequest)(nil),            // 16: currency.GetRateHistoryRequest
+	(*RateHistoryResponse)(nil),              // 17: currency.RateHistoryResponse
+	(*RatePoint)(nil),                        // 18: currency.RatePoint
+	(*RefreshRatesRequest)(nil),              // 19: currency.RefreshRatesRequest
+	(*RefreshRatesResponse)(nil),             // 20: currency.RefreshRatesResponse
+	(*GetLastUpdateTimeRequest)(nil),         // 21: currency.GetLastUpdateTimeRequest
+	(*GetLastUpdateTimeResponse)(nil),        // 22: currency.GetLastUpdateTimeResponse
+	nil,                                      // 23: currency.GetMultipleExchangeRatesResponse.RatesEntry
+	(*timestamppb.Timestamp)(nil),            // 24: google.protobuf.Timestamp
 }
 var file_proto_currency_proto_depIdxs = []int32{
-	22, // 0: currency.ExchangeRate.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 0: currency.ExchangeRate.updated_at:type_name -> google.protobuf.Timestamp
 	0,  // 1: currency.ExchangeRateResponse.rate:type_name -> currency.ExchangeRate
-	21, // 2: currency.GetMultipleExchangeRatesResponse.rates:type_name -> currency.GetMultipleExchangeRatesResponse.RatesEntry
-	22, // 3: currency.GetMultipleExchangeRatesResponse.updated_at:type_name -> google.protobuf.Timestamp
-	22, // 4: currency.ConvertAmountResponse.rate_timestamp:type_name -> google.protobuf.Timestamp
-	8,  // 5: currency.ConvertMultipleAmountsRequest.amounts:type_name -> currency.AmountToConvert
-	10, // 6: currency.ConvertMultipleAmountsResponse.converted:type_name -> currency.ConvertedAmount
-	11, // 7: currency.ListSupportedCurrenciesResponse.currencies:type_name -> currency.Currency
-	22, // 8: currency.GetRateHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
-	22, // 9: currency.GetRateHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
-	16, // 10: currency.RateHistoryResponse.history:type_name -> currency.RatePoint
-	22, // 11: currency.RatePoint.date:type_name -> google.protobuf.Timestamp
-	22, // 12: currency.RefreshRatesResponse.updated_at:type_name -> google.protobuf.Timestamp
-	22, // 13: currency.GetLastUpdateTimeResponse.last_update:type_name -> google.protobuf.Timestamp
-	1,  // 14: currency.CurrencyService.GetExchangeRate:input_type -> currency.GetExchangeRateRequest
-	3,  // 15: currency.CurrencyService.GetMultipleExchangeRates:input_type -> currency.GetMultipleExchangeRatesRequest
-	5,  // 16: currency.CurrencyService.ConvertAmount:input_type -> currency.ConvertAmountRequest
-	7,  // 17: currency.CurrencyService.ConvertMultipleAmounts:input_type -> currency.ConvertMultipleAmountsRequest
-	12, // 18: currency.CurrencyService.ListSupportedCurrencies:input_type -> currency.ListSupportedCurrenciesRequest
-	14, // 19: currency.CurrencyService.GetRateHistory:input_type -> currency.GetRateHistoryRequest
-	17, // 20: currency.CurrencyService.RefreshRates:input_type -> currency.RefreshRatesRequest
-	19, // 21: currency.CurrencyService.GetLastUpdateTime:input_type -> currency.GetLastUpdateTimeRequest
-	2,  // 22: currency.CurrencyService.GetExchangeRate:output_type -> currency.ExchangeRateResponse
-	4,  // 23: currency.CurrencyService.GetMultipleExchangeRates:output_type -> currency.GetMultipleExchangeRatesResponse
-	6,  // 24: currency.CurrencyService.ConvertAmount:output_type -> currency.ConvertAmountResponse
-	9,  // 25: currency.CurrencyService.ConvertMultipleAmounts:output_type -> currency.ConvertMultipleAmountsResponse
-	13, // 26: currency.CurrencyService.ListSupportedCurrencies:output_type -> currency.ListSupportedCurrenciesResponse
-	15, // 27: currency.CurrencyService.GetRateHistory:output_type -> currency.RateHistoryResponse
-	18, // 28: currency.CurrencyService.RefreshRates:output_type -> currency.RefreshRatesResponse
-	20, // 29: currency.CurrencyService.GetLastUpdateTime:output_type -> currency.GetLastUpdateTimeResponse
-	22, // [22:30] is the sub-list for method output_type
-	14, // [14:22] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	23, // 2: currency.GetMultipleExchangeRatesResponse.rates:type_name -> currency.GetMultipleExchangeRatesResponse.RatesEntry
+	24, // 3: currency.GetMultipleExchangeRatesResponse.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 4: currency.ConvertAmountResponse.rate_timestamp:type_name -> google.protobuf.Timestamp
+	24, // 5: currency.ConvertDetailedResponse.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 6: currency.ConvertAsOfRequest.date:type_name -> google.protobuf.Timestamp
+	10, // 7: currency.ConvertMultipleAmountsRequest.amounts:type_name -> currency.AmountToConvert
+	12, // 8: currency.ConvertMultipleAmountsResponse.converted:type_name -> currency.ConvertedAmount
+	13, // 9: currency.ListSupportedCurrenciesResponse.currencies:type_name -> currency.Currency
+	24, // 10: currency.GetRateHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	24, // 11: currency.GetRateHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	18, // 12: currency.RateHistoryResponse.history:type_name -> currency.RatePoint
+	24, // 13: currency.RatePoint.date:type_name -> google.protobuf.Timestamp
+	24, // 14: currency.RefreshRatesResponse.updated_at:type_name -> google.protobuf.Timestamp
+	24, // 15: currency.GetLastUpdateTimeResponse.last_update:type_name -> google.protobuf.Timestamp
+	1,  // 16: currency.CurrencyService.GetExchangeRate:input_type -> currency.GetExchangeRateRequest
+	3,  // 17: currency.CurrencyService.GetMultipleExchangeRates:input_type -> currency.GetMultipleExchangeRatesRequest
+	5,  // 18: currency.CurrencyService.ConvertAmount:input_type -> currency.ConvertAmountRequest
+	9,  // 19: currency.CurrencyService.ConvertMultipleAmounts:input_type -> currency.ConvertMultipleAmountsRequest
+	8,  // 20: currency.CurrencyService.ConvertAsOf:input_type -> currency.ConvertAsOfRequest
+	5,  // 21: currency.CurrencyService.ConvertDetailed:input_type -> currency.ConvertAmountRequest
+	14, // 22: currency.CurrencyService.ListSupportedCurrencies:input_type -> currency.ListSupportedCurrenciesRequest
+	16, // 23: currency.CurrencyService.GetRateHistory:input_type -> currency.GetRateHistoryRequest
+	19, // 24: currency.CurrencyService.RefreshRates:input_type -> currency.RefreshRatesRequest
+	21, // 25: currency.CurrencyService.GetLastUpdateTime:input_type -> currency.GetLastUpdateTimeRequest
+	2,  // 26: currency.CurrencyService.GetExchangeRate:output_type -> currency.ExchangeRateResponse
+	4,  // 27: currency.CurrencyService.GetMultipleExchangeRates:output_type -> currency.GetMultipleExchangeRatesResponse
+	6,  // 28: currency.CurrencyService.ConvertAmount:output_type -> currency.ConvertAmountResponse
+	11, // 29: currency.CurrencyService.ConvertMultipleAmounts:output_type -> currency.ConvertMultipleAmountsResponse
+	6,  // 30: currency.CurrencyService.ConvertAsOf:output_type -> currency.ConvertAmountResponse
+	7,  // 31: currency.CurrencyService.ConvertDetailed:output_type -> currency.ConvertDetailedResponse
+	15, // 32: currency.CurrencyService.ListSupportedCurrencies:output_type -> currency.ListSupportedCurrenciesResponse
+	17, // 33: currency.CurrencyService.GetRateHistory:output_type -> currency.RateHistoryResponse
+	20, // 34: currency.CurrencyService.RefreshRates:output_type -> currency.RefreshRatesResponse
+	22, // 35: currency.CurrencyService.GetLastUpdateTime:output_type -> currency.GetLastUpdateTimeResponse
+	26, // [26:36] is the sub-list for method output_type
+	16, // [16:26] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_proto_currency_proto_init() }
@@ -1424,7 +1637,7 @@ func file_proto_currency_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_currency_proto_rawDesc), len(file_proto_currency_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   22,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
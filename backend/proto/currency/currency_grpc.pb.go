@@ -23,6 +23,8 @@ const (
 	CurrencyService_GetMultipleExchangeRates_FullMethodName = "/currency.CurrencyService/GetMultipleExchangeRates"
 	CurrencyService_ConvertAmount_FullMethodName            = "/currency.CurrencyService/ConvertAmount"
 	CurrencyService_ConvertMultipleAmounts_FullMethodName   = "/currency.CurrencyService/ConvertMultipleAmounts"
+	CurrencyService_ConvertAsOf_FullMethodName              = "/currency.CurrencyService/ConvertAsOf"
+	CurrencyService_ConvertDetailed_FullMethodName          = "/currency.CurrencyService/ConvertDetailed"
 	CurrencyService_ListSupportedCurrencies_FullMethodName  = "/currency.CurrencyService/ListSupportedCurrencies"
 	CurrencyService_GetRateHistory_FullMethodName           = "/currency.CurrencyService/GetRateHistory"
 	CurrencyService_RefreshRates_FullMethodName             = "/currency.CurrencyService/RefreshRates"
@@ -37,6 +39,8 @@ type CurrencyServiceClient interface {
 	GetMultipleExchangeRates(ctx context.Context, in *GetMultipleExchangeRatesRequest, opts ...grpc.CallOption) (*GetMultipleExchangeRatesResponse, error)
 	ConvertAmount(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error)
 	ConvertMultipleAmounts(ctx context.Context, in *ConvertMultipleAmountsRequest, opts ...grpc.CallOption) (*ConvertMultipleAmountsResponse, error)
+	ConvertAsOf(ctx context.Context, in *ConvertAsOfRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error)
+	ConvertDetailed(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertDetailedResponse, error)
 	ListSupportedCurrencies(ctx context.Context, in *ListSupportedCurrenciesRequest, opts ...grpc.CallOption) (*ListSupportedCurrenciesResponse, error)
 	GetRateHistory(ctx context.Context, in *GetRateHistoryRequest, opts ...grpc.CallOption) (*RateHistoryResponse, error)
 	RefreshRates(ctx context.Context, in *RefreshRatesRequest, opts ...grpc.CallOption) (*RefreshRatesResponse, error)
@@ -91,6 +95,26 @@ func (c *currencyServiceClient) ConvertMultipleAmounts(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *currencyServiceClient) ConvertAsOf(ctx context.Context, in *ConvertAsOfRequest, opts ...grpc.CallOption) (*ConvertAmountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertAmountResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_ConvertAsOf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *currencyServiceClient) ConvertDetailed(ctx context.Context, in *ConvertAmountRequest, opts ...grpc.CallOption) (*ConvertDetailedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConvertDetailedResponse)
+	err := c.cc.Invoke(ctx, CurrencyService_ConvertDetailed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *currencyServiceClient) ListSupportedCurrencies(ctx context.Context, in *ListSupportedCurrenciesRequest, opts ...grpc.CallOption) (*ListSupportedCurrenciesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListSupportedCurrenciesResponse)
@@ -139,6 +163,8 @@ type CurrencyServiceServer interface {
 	GetMultipleExchangeRates(context.Context, *GetMultipleExchangeRatesRequest) (*GetMultipleExchangeRatesResponse, error)
 	ConvertAmount(context.Context, *ConvertAmountRequest) (*ConvertAmountResponse, error)
 	ConvertMultipleAmounts(context.Context, *ConvertMultipleAmountsRequest) (*ConvertMultipleAmountsResponse, error)
+	ConvertAsOf(context.Context, *ConvertAsOfRequest) (*ConvertAmountResponse, error)
+	ConvertDetailed(context.Context, *ConvertAmountRequest) (*ConvertDetailedResponse, error)
 	ListSupportedCurrencies(context.Context, *ListSupportedCurrenciesRequest) (*ListSupportedCurrenciesResponse, error)
 	GetRateHistory(context.Context, *GetRateHistoryRequest) (*RateHistoryResponse, error)
 	RefreshRates(context.Context, *RefreshRatesRequest) (*RefreshRatesResponse, error)
@@ -165,6 +191,12 @@ func (UnimplementedCurrencyServiceServer) ConvertAmount(context.Context, *Conver
 func (UnimplementedCurrencyServiceServer) ConvertMultipleAmounts(context.Context, *ConvertMultipleAmountsRequest) (*ConvertMultipleAmountsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ConvertMultipleAmounts not implemented")
 }
+func (UnimplementedCurrencyServiceServer) ConvertAsOf(context.Context, *ConvertAsOfRequest) (*ConvertAmountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConvertAsOf not implemented")
+}
+func (UnimplementedCurrencyServiceServer) ConvertDetailed(context.Context, *ConvertAmountRequest) (*ConvertDetailedResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConvertDetailed not implemented")
+}
 func (UnimplementedCurrencyServiceServer) ListSupportedCurrencies(context.Context, *ListSupportedCurrenciesRequest) (*ListSupportedCurrenciesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListSupportedCurrencies not implemented")
 }
@@ -270,6 +302,42 @@ func _CurrencyService_ConvertMultipleAmounts_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CurrencyService_ConvertAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ConvertAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_ConvertAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ConvertAsOf(ctx, req.(*ConvertAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CurrencyService_ConvertDetailed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConvertAmountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CurrencyServiceServer).ConvertDetailed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CurrencyService_ConvertDetailed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CurrencyServiceServer).ConvertDetailed(ctx, req.(*ConvertAmountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _CurrencyService_ListSupportedCurrencies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListSupportedCurrenciesRequest)
 	if err := dec(in); err != nil {
@@ -365,6 +433,14 @@ var CurrencyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ConvertMultipleAmounts",
 			Handler:    _CurrencyService_ConvertMultipleAmounts_Handler,
 		},
+		{
+			MethodName: "ConvertAsOf",
+			Handler:    _CurrencyService_ConvertAsOf_Handler,
+		},
+		{
+			MethodName: "ConvertDetailed",
+			Handler:    _CurrencyService_ConvertDetailed_Handler,
+		},
 		{
 			MethodName: "ListSupportedCurrencies",
 			Handler:    _CurrencyService_ListSupportedCurrencies_Handler,
@@ -177,6 +177,8 @@ type NetWorthResponse struct {
 	Change_30D        float64                `protobuf:"fixed64,7,opt,name=change_30d,json=change30d,proto3" json:"change_30d,omitempty"`
 	ChangePercent_30D float64                `protobuf:"fixed64,8,opt,name=change_percent_30d,json=changePercent30d,proto3" json:"change_percent_30d,omitempty"`
 	CalculatedAt      *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=calculated_at,json=calculatedAt,proto3" json:"calculated_at,omitempty"`
+	TotalAssets       float64                `protobuf:"fixed64,10,opt,name=total_assets,json=totalAssets,proto3" json:"total_assets,omitempty"`
+	TotalLiabilities  float64                `protobuf:"fixed64,11,opt,name=total_liabilities,json=totalLiabilities,proto3" json:"total_liabilities,omitempty"`
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
@@ -274,13 +276,29 @@ func (x *NetWorthResponse) GetCalculatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *NetWorthResponse) GetTotalAssets() float64 {
+	if x != nil {
+		return x.TotalAssets
+	}
+	return 0
+}
+
+func (x *NetWorthResponse) GetTotalLiabilities() float64 {
+	if x != nil {
+		return x.TotalLiabilities
+	}
+	return 0
+}
+
 type GetNetWorthHistoryRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	BaseCurrency  string                 `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
-	Period        string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	BaseCurrency string                 `protobuf:"bytes,2,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	Period       string                 `protobuf:"bytes,3,opt,name=period,proto3" json:"period,omitempty"`
+	StartDate    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	// Return stored snapshots as-is, without gap filling or bucketing
+	Raw           bool `protobuf:"varint,6,opt,name=raw,proto3" json:"raw,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -350,6 +368,13 @@ func (x *GetNetWorthHistoryRequest) GetEndDate() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *GetNetWorthHistoryRequest) GetRaw() bool {
+	if x != nil {
+		return x.Raw
+	}
+	return false
+}
+
 type NetWorthHistoryResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	History       []*NetWorthPoint       `protobuf:"bytes,1,rep,name=history,proto3" json:"history,omitempty"`
@@ -454,6 +479,221 @@ func (x *NetWorthPoint) GetValue() float64 {
 	return 0
 }
 
+type ProjectNetWorthRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Months to project ahead
+	HorizonMonths int32 `protobuf:"varint,2,opt,name=horizon_months,json=horizonMonths,proto3" json:"horizon_months,omitempty"`
+	// "linear" (default) or "cagr"
+	Method        string `protobuf:"bytes,3,opt,name=method,proto3" json:"method,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectNetWorthRequest) Reset() {
+	*x = ProjectNetWorthRequest{}
+	mi := &file_proto_insights_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectNetWorthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectNetWorthRequest) ProtoMessage() {}
+
+func (x *ProjectNetWorthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_insights_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectNetWorthRequest.ProtoReflect.Descriptor instead.
+func (*ProjectNetWorthRequest) Descriptor() ([]byte, []int) {
+	return file_proto_insights_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ProjectNetWorthRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ProjectNetWorthRequest) GetHorizonMonths() int32 {
+	if x != nil {
+		return x.HorizonMonths
+	}
+	return 0
+}
+
+func (x *ProjectNetWorthRequest) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+type NetWorthProjectionResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Method           string                 `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Currency         string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	Points           []*ProjectionPoint     `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	SnapshotsUsed    int32                  `protobuf:"varint,4,opt,name=snapshots_used,json=snapshotsUsed,proto3" json:"snapshots_used,omitempty"`
+	MonthlyChange    float64                `protobuf:"fixed64,5,opt,name=monthly_change,json=monthlyChange,proto3" json:"monthly_change,omitempty"`
+	AnnualGrowthRate float64                `protobuf:"fixed64,6,opt,name=annual_growth_rate,json=annualGrowthRate,proto3" json:"annual_growth_rate,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *NetWorthProjectionResponse) Reset() {
+	*x = NetWorthProjectionResponse{}
+	mi := &file_proto_insights_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NetWorthProjectionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NetWorthProjectionResponse) ProtoMessage() {}
+
+func (x *NetWorthProjectionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_insights_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NetWorthProjectionResponse.ProtoReflect.Descriptor instead.
+func (*NetWorthProjectionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_insights_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NetWorthProjectionResponse) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *NetWorthProjectionResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *NetWorthProjectionResponse) GetPoints() []*ProjectionPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *NetWorthProjectionResponse) GetSnapshotsUsed() int32 {
+	if x != nil {
+		return x.SnapshotsUsed
+	}
+	return 0
+}
+
+func (x *NetWorthProjectionResponse) GetMonthlyChange() float64 {
+	if x != nil {
+		return x.MonthlyChange
+	}
+	return 0
+}
+
+func (x *NetWorthProjectionResponse) GetAnnualGrowthRate() float64 {
+	if x != nil {
+		return x.AnnualGrowthRate
+	}
+	return 0
+}
+
+// A projected value with its 95% confidence band
+type ProjectionPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Date          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=date,proto3" json:"date,omitempty"`
+	Value         float64                `protobuf:"fixed64,2,opt,name=value,proto3" json:"value,omitempty"`
+	Lower         float64                `protobuf:"fixed64,3,opt,name=lower,proto3" json:"lower,omitempty"`
+	Upper         float64                `protobuf:"fixed64,4,opt,name=upper,proto3" json:"upper,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProjectionPoint) Reset() {
+	*x = ProjectionPoint{}
+	mi := &file_proto_insights_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProjectionPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProjectionPoint) ProtoMessage() {}
+
+func (x *ProjectionPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_insights_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProjectionPoint.ProtoReflect.Descriptor instead.
+func (*ProjectionPoint) Descriptor() ([]byte, []int) {
+	return file_proto_insights_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ProjectionPoint) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *ProjectionPoint) GetValue() float64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *ProjectionPoint) GetLower() float64 {
+	if x != nil {
+		return x.Lower
+	}
+	return 0
+}
+
+func (x *ProjectionPoint) GetUpper() float64 {
+	if x != nil {
+		return x.Upper
+	}
+	return 0
+}
+
 type GetAllocationRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -465,7 +705,7 @@ type GetAllocationRequest struct {
 
 func (x *GetAllocationRequest) Reset() {
 	*x = GetAllocationRequest{}
-	mi := &file_proto_insights_proto_msgTypes[6]
+	mi := &file_proto_insights_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -477,7 +717,7 @@ func (x *GetAllocationRequest) String() string {
 func (*GetAllocationRequest) ProtoMessage() {}
 
 func (x *GetAllocationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[6]
+	mi := &file_proto_insights_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,7 +730,7 @@ func (x *GetAllocationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAllocationRequest.ProtoReflect.Descriptor instead.
 func (*GetAllocationRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{6}
+	return file_proto_insights_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *GetAllocationRequest) GetUserId() string {
@@ -525,7 +765,7 @@ type AllocationResponse struct {
 
 func (x *AllocationResponse) Reset() {
 	*x = AllocationResponse{}
-	mi := &file_proto_insights_proto_msgTypes[7]
+	mi := &file_proto_insights_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -537,7 +777,7 @@ func (x *AllocationResponse) String() string {
 func (*AllocationResponse) ProtoMessage() {}
 
 func (x *AllocationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[7]
+	mi := &file_proto_insights_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +790,7 @@ func (x *AllocationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllocationResponse.ProtoReflect.Descriptor instead.
 func (*AllocationResponse) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{7}
+	return file_proto_insights_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *AllocationResponse) GetAllocations() []*AllocationItem {
@@ -587,7 +827,7 @@ type AllocationItem struct {
 
 func (x *AllocationItem) Reset() {
 	*x = AllocationItem{}
-	mi := &file_proto_insights_proto_msgTypes[8]
+	mi := &file_proto_insights_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -599,7 +839,7 @@ func (x *AllocationItem) String() string {
 func (*AllocationItem) ProtoMessage() {}
 
 func (x *AllocationItem) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[8]
+	mi := &file_proto_insights_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -612,7 +852,7 @@ func (x *AllocationItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AllocationItem.ProtoReflect.Descriptor instead.
 func (*AllocationItem) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{8}
+	return file_proto_insights_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *AllocationItem) GetCategory() string {
@@ -663,7 +903,7 @@ type GetBalanceChangesRequest struct {
 
 func (x *GetBalanceChangesRequest) Reset() {
 	*x = GetBalanceChangesRequest{}
-	mi := &file_proto_insights_proto_msgTypes[9]
+	mi := &file_proto_insights_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -675,7 +915,7 @@ func (x *GetBalanceChangesRequest) String() string {
 func (*GetBalanceChangesRequest) ProtoMessage() {}
 
 func (x *GetBalanceChangesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[9]
+	mi := &file_proto_insights_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -688,7 +928,7 @@ func (x *GetBalanceChangesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetBalanceChangesRequest.ProtoReflect.Descriptor instead.
 func (*GetBalanceChangesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{9}
+	return file_proto_insights_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *GetBalanceChangesRequest) GetUserId() string {
@@ -738,7 +978,7 @@ type BalanceChangesResponse struct {
 
 func (x *BalanceChangesResponse) Reset() {
 	*x = BalanceChangesResponse{}
-	mi := &file_proto_insights_proto_msgTypes[10]
+	mi := &file_proto_insights_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -750,7 +990,7 @@ func (x *BalanceChangesResponse) String() string {
 func (*BalanceChangesResponse) ProtoMessage() {}
 
 func (x *BalanceChangesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[10]
+	mi := &file_proto_insights_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -763,7 +1003,7 @@ func (x *BalanceChangesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BalanceChangesResponse.ProtoReflect.Descriptor instead.
 func (*BalanceChangesResponse) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{10}
+	return file_proto_insights_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BalanceChangesResponse) GetChanges() []*BalanceChange {
@@ -808,7 +1048,7 @@ type BalanceChange struct {
 
 func (x *BalanceChange) Reset() {
 	*x = BalanceChange{}
-	mi := &file_proto_insights_proto_msgTypes[11]
+	mi := &file_proto_insights_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -820,7 +1060,7 @@ func (x *BalanceChange) String() string {
 func (*BalanceChange) ProtoMessage() {}
 
 func (x *BalanceChange) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[11]
+	mi := &file_proto_insights_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +1073,7 @@ func (x *BalanceChange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BalanceChange.ProtoReflect.Descriptor instead.
 func (*BalanceChange) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{11}
+	return file_proto_insights_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *BalanceChange) GetAccountId() string {
@@ -890,7 +1130,7 @@ type GetTrendsRequest struct {
 
 func (x *GetTrendsRequest) Reset() {
 	*x = GetTrendsRequest{}
-	mi := &file_proto_insights_proto_msgTypes[12]
+	mi := &file_proto_insights_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -902,7 +1142,7 @@ func (x *GetTrendsRequest) String() string {
 func (*GetTrendsRequest) ProtoMessage() {}
 
 func (x *GetTrendsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[12]
+	mi := &file_proto_insights_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -915,7 +1155,7 @@ func (x *GetTrendsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetTrendsRequest.ProtoReflect.Descriptor instead.
 func (*GetTrendsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{12}
+	return file_proto_insights_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetTrendsRequest) GetUserId() string {
@@ -961,7 +1201,7 @@ type TrendsResponse struct {
 
 func (x *TrendsResponse) Reset() {
 	*x = TrendsResponse{}
-	mi := &file_proto_insights_proto_msgTypes[13]
+	mi := &file_proto_insights_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -973,7 +1213,7 @@ func (x *TrendsResponse) String() string {
 func (*TrendsResponse) ProtoMessage() {}
 
 func (x *TrendsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[13]
+	mi := &file_proto_insights_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -986,7 +1226,7 @@ func (x *TrendsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrendsResponse.ProtoReflect.Descriptor instead.
 func (*TrendsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{13}
+	return file_proto_insights_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *TrendsResponse) GetMetric() string {
@@ -1049,7 +1289,7 @@ type TrendPoint struct {
 
 func (x *TrendPoint) Reset() {
 	*x = TrendPoint{}
-	mi := &file_proto_insights_proto_msgTypes[14]
+	mi := &file_proto_insights_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1061,7 +1301,7 @@ func (x *TrendPoint) String() string {
 func (*TrendPoint) ProtoMessage() {}
 
 func (x *TrendPoint) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[14]
+	mi := &file_proto_insights_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1074,7 +1314,7 @@ func (x *TrendPoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TrendPoint.ProtoReflect.Descriptor instead.
 func (*TrendPoint) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{14}
+	return file_proto_insights_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *TrendPoint) GetDate() *timestamppb.Timestamp {
@@ -1108,7 +1348,7 @@ type GetDashboardSummaryRequest struct {
 
 func (x *GetDashboardSummaryRequest) Reset() {
 	*x = GetDashboardSummaryRequest{}
-	mi := &file_proto_insights_proto_msgTypes[15]
+	mi := &file_proto_insights_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1120,7 +1360,7 @@ func (x *GetDashboardSummaryRequest) String() string {
 func (*GetDashboardSummaryRequest) ProtoMessage() {}
 
 func (x *GetDashboardSummaryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[15]
+	mi := &file_proto_insights_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1133,7 +1373,7 @@ func (x *GetDashboardSummaryRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDashboardSummaryRequest.ProtoReflect.Descriptor instead.
 func (*GetDashboardSummaryRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{15}
+	return file_proto_insights_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *GetDashboardSummaryRequest) GetUserId() string {
@@ -1172,7 +1412,7 @@ type DashboardSummaryResponse struct {
 
 func (x *DashboardSummaryResponse) Reset() {
 	*x = DashboardSummaryResponse{}
-	mi := &file_proto_insights_proto_msgTypes[16]
+	mi := &file_proto_insights_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1184,7 +1424,7 @@ func (x *DashboardSummaryResponse) String() string {
 func (*DashboardSummaryResponse) ProtoMessage() {}
 
 func (x *DashboardSummaryResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[16]
+	mi := &file_proto_insights_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1197,7 +1437,7 @@ func (x *DashboardSummaryResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DashboardSummaryResponse.ProtoReflect.Descriptor instead.
 func (*DashboardSummaryResponse) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{16}
+	return file_proto_insights_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *DashboardSummaryResponse) GetNetWorth() float64 {
@@ -1312,7 +1552,7 @@ type RecentTransaction struct {
 
 func (x *RecentTransaction) Reset() {
 	*x = RecentTransaction{}
-	mi := &file_proto_insights_proto_msgTypes[17]
+	mi := &file_proto_insights_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1324,7 +1564,7 @@ func (x *RecentTransaction) String() string {
 func (*RecentTransaction) ProtoMessage() {}
 
 func (x *RecentTransaction) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[17]
+	mi := &file_proto_insights_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1337,7 +1577,7 @@ func (x *RecentTransaction) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RecentTransaction.ProtoReflect.Descriptor instead.
 func (*RecentTransaction) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{17}
+	return file_proto_insights_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RecentTransaction) GetId() string {
@@ -1395,7 +1635,7 @@ type TopPerformer struct {
 
 func (x *TopPerformer) Reset() {
 	*x = TopPerformer{}
-	mi := &file_proto_insights_proto_msgTypes[18]
+	mi := &file_proto_insights_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1407,7 +1647,7 @@ func (x *TopPerformer) String() string {
 func (*TopPerformer) ProtoMessage() {}
 
 func (x *TopPerformer) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[18]
+	mi := &file_proto_insights_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,7 +1660,7 @@ func (x *TopPerformer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TopPerformer.ProtoReflect.Descriptor instead.
 func (*TopPerformer) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{18}
+	return file_proto_insights_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *TopPerformer) GetSymbol() string {
@@ -1471,7 +1711,7 @@ type GetCashFlowRequest struct {
 
 func (x *GetCashFlowRequest) Reset() {
 	*x = GetCashFlowRequest{}
-	mi := &file_proto_insights_proto_msgTypes[19]
+	mi := &file_proto_insights_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1483,7 +1723,7 @@ func (x *GetCashFlowRequest) String() string {
 func (*GetCashFlowRequest) ProtoMessage() {}
 
 func (x *GetCashFlowRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[19]
+	mi := &file_proto_insights_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1496,7 +1736,7 @@ func (x *GetCashFlowRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCashFlowRequest.ProtoReflect.Descriptor instead.
 func (*GetCashFlowRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{19}
+	return file_proto_insights_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetCashFlowRequest) GetUserId() string {
@@ -1547,7 +1787,7 @@ type CashFlowResponse struct {
 
 func (x *CashFlowResponse) Reset() {
 	*x = CashFlowResponse{}
-	mi := &file_proto_insights_proto_msgTypes[20]
+	mi := &file_proto_insights_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1559,7 +1799,7 @@ func (x *CashFlowResponse) String() string {
 func (*CashFlowResponse) ProtoMessage() {}
 
 func (x *CashFlowResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[20]
+	mi := &file_proto_insights_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1572,7 +1812,7 @@ func (x *CashFlowResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CashFlowResponse.ProtoReflect.Descriptor instead.
 func (*CashFlowResponse) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{20}
+	return file_proto_insights_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *CashFlowResponse) GetPeriods() []*CashFlowPeriod {
@@ -1611,20 +1851,21 @@ func (x *CashFlowResponse) GetCurrency() string {
 }
 
 type CashFlowPeriod struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
-	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
-	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
-	Income        float64                `protobuf:"fixed64,4,opt,name=income,proto3" json:"income,omitempty"`
-	Expenses      float64                `protobuf:"fixed64,5,opt,name=expenses,proto3" json:"expenses,omitempty"`
-	Net           float64                `protobuf:"fixed64,6,opt,name=net,proto3" json:"net,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Label            string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	StartDate        *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Income           float64                `protobuf:"fixed64,4,opt,name=income,proto3" json:"income,omitempty"`
+	Expenses         float64                `protobuf:"fixed64,5,opt,name=expenses,proto3" json:"expenses,omitempty"`
+	Net              float64                `protobuf:"fixed64,6,opt,name=net,proto3" json:"net,omitempty"`
+	InvestmentIncome float64                `protobuf:"fixed64,7,opt,name=investment_income,json=investmentIncome,proto3" json:"investment_income,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *CashFlowPeriod) Reset() {
 	*x = CashFlowPeriod{}
-	mi := &file_proto_insights_proto_msgTypes[21]
+	mi := &file_proto_insights_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1636,7 +1877,7 @@ func (x *CashFlowPeriod) String() string {
 func (*CashFlowPeriod) ProtoMessage() {}
 
 func (x *CashFlowPeriod) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[21]
+	mi := &file_proto_insights_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1649,7 +1890,7 @@ func (x *CashFlowPeriod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CashFlowPeriod.ProtoReflect.Descriptor instead.
 func (*CashFlowPeriod) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{21}
+	return file_proto_insights_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *CashFlowPeriod) GetLabel() string {
@@ -1694,6 +1935,13 @@ func (x *CashFlowPeriod) GetNet() float64 {
 	return 0
 }
 
+func (x *CashFlowPeriod) GetInvestmentIncome() float64 {
+	if x != nil {
+		return x.InvestmentIncome
+	}
+	return 0
+}
+
 type CreateSnapshotRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -1704,7 +1952,7 @@ type CreateSnapshotRequest struct {
 
 func (x *CreateSnapshotRequest) Reset() {
 	*x = CreateSnapshotRequest{}
-	mi := &file_proto_insights_proto_msgTypes[22]
+	mi := &file_proto_insights_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1716,7 +1964,7 @@ func (x *CreateSnapshotRequest) String() string {
 func (*CreateSnapshotRequest) ProtoMessage() {}
 
 func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[22]
+	mi := &file_proto_insights_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1729,7 +1977,7 @@ func (x *CreateSnapshotRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateSnapshotRequest.ProtoReflect.Descriptor instead.
 func (*CreateSnapshotRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{22}
+	return file_proto_insights_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CreateSnapshotRequest) GetUserId() string {
@@ -1758,7 +2006,7 @@ type GetSnapshotsRequest struct {
 
 func (x *GetSnapshotsRequest) Reset() {
 	*x = GetSnapshotsRequest{}
-	mi := &file_proto_insights_proto_msgTypes[23]
+	mi := &file_proto_insights_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1770,7 +2018,7 @@ func (x *GetSnapshotsRequest) String() string {
 func (*GetSnapshotsRequest) ProtoMessage() {}
 
 func (x *GetSnapshotsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[23]
+	mi := &file_proto_insights_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1783,7 +2031,7 @@ func (x *GetSnapshotsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSnapshotsRequest.ProtoReflect.Descriptor instead.
 func (*GetSnapshotsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{23}
+	return file_proto_insights_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetSnapshotsRequest) GetUserId() string {
@@ -1823,7 +2071,7 @@ type GetSnapshotsResponse struct {
 
 func (x *GetSnapshotsResponse) Reset() {
 	*x = GetSnapshotsResponse{}
-	mi := &file_proto_insights_proto_msgTypes[24]
+	mi := &file_proto_insights_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1835,7 +2083,7 @@ func (x *GetSnapshotsResponse) String() string {
 func (*GetSnapshotsResponse) ProtoMessage() {}
 
 func (x *GetSnapshotsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_insights_proto_msgTypes[24]
+	mi := &file_proto_insights_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1848,7 +2096,7 @@ func (x *GetSnapshotsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSnapshotsResponse.ProtoReflect.Descriptor instead.
 func (*GetSnapshotsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_insights_proto_rawDescGZIP(), []int{24}
+	return file_proto_insights_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetSnapshotsResponse) GetSnapshots() []*Snapshot {
@@ -1874,7 +2122,7 @@ const file_proto_insights_proto_rawDesc = "" +
 	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"R\n" +
 	"\x12GetNetWorthRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
-	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\"\xfa\x02\n" +
+	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\"\xca\x03\n" +
 	"\x10NetWorthResponse\x12&\n" +
 	"\x0ftotal_net_worth\x18\x01 \x01(\x01R\rtotalNetWorth\x12\x1a\n" +
 	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x12\x1d\n" +
@@ -1886,20 +2134,40 @@ const file_proto_insights_proto_rawDesc = "" +
 	"\n" +
 	"change_30d\x18\a \x01(\x01R\tchange30d\x12,\n" +
 	"\x12change_percent_30d\x18\b \x01(\x01R\x10changePercent30d\x12?\n" +
-	"\rcalculated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\"\xe3\x01\n" +
+	"\rcalculated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fcalculatedAt\x12!\n" +
+	"\ftotal_assets\x18\n" +
+	" \x01(\x01R\vtotalAssets\x12+\n" +
+	"\x11total_liabilities\x18\v \x01(\x01R\x10totalLiabilities\"\xf5\x01\n" +
 	"\x19GetNetWorthHistoryRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
 	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\x12\x16\n" +
 	"\x06period\x18\x03 \x01(\tR\x06period\x129\n" +
 	"\n" +
 	"start_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\"h\n" +
+	"\bend_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x10\n" +
+	"\x03raw\x18\x06 \x01(\bR\x03raw\"h\n" +
 	"\x17NetWorthHistoryResponse\x121\n" +
 	"\ahistory\x18\x01 \x03(\v2\x17.insights.NetWorthPointR\ahistory\x12\x1a\n" +
 	"\bcurrency\x18\x02 \x01(\tR\bcurrency\"U\n" +
 	"\rNetWorthPoint\x12.\n" +
 	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value\"o\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\"p\n" +
+	"\x16ProjectNetWorthRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12%\n" +
+	"\x0ehorizon_months\x18\x02 \x01(\x05R\rhorizonMonths\x12\x16\n" +
+	"\x06method\x18\x03 \x01(\tR\x06method\"\xff\x01\n" +
+	"\x1aNetWorthProjectionResponse\x12\x16\n" +
+	"\x06method\x18\x01 \x01(\tR\x06method\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x121\n" +
+	"\x06points\x18\x03 \x03(\v2\x19.insights.ProjectionPointR\x06points\x12%\n" +
+	"\x0esnapshots_used\x18\x04 \x01(\x05R\rsnapshotsUsed\x12%\n" +
+	"\x0emonthly_change\x18\x05 \x01(\x01R\rmonthlyChange\x12,\n" +
+	"\x12annual_growth_rate\x18\x06 \x01(\x01R\x10annualGrowthRate\"\x83\x01\n" +
+	"\x0fProjectionPoint\x12.\n" +
+	"\x04date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value\x12\x14\n" +
+	"\x05lower\x18\x03 \x01(\x01R\x05lower\x12\x14\n" +
+	"\x05upper\x18\x04 \x01(\x01R\x05upper\"o\n" +
 	"\x14GetAllocationRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
 	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\x12\x19\n" +
@@ -1999,7 +2267,7 @@ const file_proto_insights_proto_rawDesc = "" +
 	"\ftotal_income\x18\x02 \x01(\x01R\vtotalIncome\x12%\n" +
 	"\x0etotal_expenses\x18\x03 \x01(\x01R\rtotalExpenses\x12\"\n" +
 	"\rnet_cash_flow\x18\x04 \x01(\x01R\vnetCashFlow\x12\x1a\n" +
-	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"\xde\x01\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\"\x8b\x02\n" +
 	"\x0eCashFlowPeriod\x12\x14\n" +
 	"\x05label\x18\x01 \x01(\tR\x05label\x129\n" +
 	"\n" +
@@ -2007,7 +2275,8 @@ const file_proto_insights_proto_rawDesc = "" +
 	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x16\n" +
 	"\x06income\x18\x04 \x01(\x01R\x06income\x12\x1a\n" +
 	"\bexpenses\x18\x05 \x01(\x01R\bexpenses\x12\x10\n" +
-	"\x03net\x18\x06 \x01(\x01R\x03net\"U\n" +
+	"\x03net\x18\x06 \x01(\x01R\x03net\x12+\n" +
+	"\x11investment_income\x18\a \x01(\x01R\x10investmentIncome\"U\n" +
 	"\x15CreateSnapshotRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12#\n" +
 	"\rbase_currency\x18\x02 \x01(\tR\fbaseCurrency\"\xb6\x01\n" +
@@ -2018,10 +2287,11 @@ const file_proto_insights_proto_rawDesc = "" +
 	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x14\n" +
 	"\x05limit\x18\x04 \x01(\x05R\x05limit\"H\n" +
 	"\x14GetSnapshotsResponse\x120\n" +
-	"\tsnapshots\x18\x01 \x03(\v2\x12.insights.SnapshotR\tsnapshots2\xe5\x05\n" +
+	"\tsnapshots\x18\x01 \x03(\v2\x12.insights.SnapshotR\tsnapshots2\xc0\x06\n" +
 	"\x0fInsightsService\x12G\n" +
 	"\vGetNetWorth\x12\x1c.insights.GetNetWorthRequest\x1a\x1a.insights.NetWorthResponse\x12\\\n" +
-	"\x12GetNetWorthHistory\x12#.insights.GetNetWorthHistoryRequest\x1a!.insights.NetWorthHistoryResponse\x12M\n" +
+	"\x12GetNetWorthHistory\x12#.insights.GetNetWorthHistoryRequest\x1a!.insights.NetWorthHistoryResponse\x12Y\n" +
+	"\x0fProjectNetWorth\x12 .insights.ProjectNetWorthRequest\x1a$.insights.NetWorthProjectionResponse\x12M\n" +
 	"\rGetAllocation\x12\x1e.insights.GetAllocationRequest\x1a\x1c.insights.AllocationResponse\x12Y\n" +
 	"\x11GetBalanceChanges\x12\".insights.GetBalanceChangesRequest\x1a .insights.BalanceChangesResponse\x12A\n" +
 	"\tGetTrends\x12\x1a.insights.GetTrendsRequest\x1a\x18.insights.TrendsResponse\x12_\n" +
@@ -2042,7 +2312,7 @@ func file_proto_insights_proto_rawDescGZIP() []byte {
 	return file_proto_insights_proto_rawDescData
 }
 
-var file_proto_insights_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_proto_insights_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
 var file_proto_insights_proto_goTypes = []any{
 	(*Snapshot)(nil),                   // 0: insights.Snapshot
 	(*GetNetWorthRequest)(nil),         // 1: insights.GetNetWorthRequest
@@ -2050,77 +2320,84 @@ var file_proto_insights_proto_goTypes = []any{
 	(*GetNetWorthHistoryRequest)(nil),  // 3: insights.GetNetWorthHistoryRequest
 	(*NetWorthHistoryResponse)(nil),    // 4: insights.NetWorthHistoryResponse
 	(*NetWorthPoint)(nil),              // 5: insights.NetWorthPoint
-	(*GetAllocationRequest)(nil),       // 6: insights.GetAllocationRequest
-	(*AllocationResponse)(nil),         // 7: insights.AllocationResponse
-	(*AllocationItem)(nil),             // 8: insights.AllocationItem
-	(*GetBalanceChangesRequest)(nil),   // 9: insights.GetBalanceChangesRequest
-	(*BalanceChangesResponse)(nil),     // 10: insights.BalanceChangesResponse
-	(*BalanceChange)(nil),              // 11: insights.BalanceChange
-	(*GetTrendsRequest)(nil),           // 12: insights.GetTrendsRequest
-	(*TrendsResponse)(nil),             // 13: insights.TrendsResponse
-	(*TrendPoint)(nil),                 // 14: insights.TrendPoint
-	(*GetDashboardSummaryRequest)(nil), // 15: insights.GetDashboardSummaryRequest
-	(*DashboardSummaryResponse)(nil),   // 16: insights.DashboardSummaryResponse
-	(*RecentTransaction)(nil),          // 17: insights.RecentTransaction
-	(*TopPerformer)(nil),               // 18: insights.TopPerformer
-	(*GetCashFlowRequest)(nil),         // 19: insights.GetCashFlowRequest
-	(*CashFlowResponse)(nil),           // 20: insights.CashFlowResponse
-	(*CashFlowPeriod)(nil),             // 21: insights.CashFlowPeriod
-	(*CreateSnapshotRequest)(nil),      // 22: insights.CreateSnapshotRequest
-	(*GetSnapshotsRequest)(nil),        // 23: insights.GetSnapshotsRequest
-	(*GetSnapshotsResponse)(nil),       // 24: insights.GetSnapshotsResponse
-	(*timestamppb.Timestamp)(nil),      // 25: google.protobuf.Timestamp
+	(*ProjectNetWorthRequest)(nil),     // 6: insights.ProjectNetWorthRequest
+	(*NetWorthProjectionResponse)(nil), // 7: insights.NetWorthProjectionResponse
+	(*ProjectionPoint)(nil),            // 8: insights.ProjectionPoint
+	(*GetAllocationRequest)(nil),       // 9: insights.GetAllocationRequest
+	(*AllocationResponse)(nil),         // 10: insights.AllocationResponse
+	(*AllocationItem)(nil),             // 11: insights.AllocationItem
+	(*GetBalanceChangesRequest)(nil),   // 12: insights.GetBalanceChangesRequest
+	(*BalanceChangesResponse)(nil),     // 13: insights.BalanceChangesResponse
+	(*BalanceChange)(nil),              // 14: insights.BalanceChange
+	(*GetTrendsRequest)(nil),           // 15: insights.GetTrendsRequest
+	(*TrendsResponse)(nil),             // 16: insights.TrendsResponse
+	(*TrendPoint)(nil),                 // 17: insights.TrendPoint
+	(*GetDashboardSummaryRequest)(nil), // 18: insights.GetDashboardSummaryRequest
+	(*DashboardSummaryResponse)(nil),   // 19: insights.DashboardSummaryResponse
+	(*RecentTransaction)(nil),          // 20: insights.RecentTransaction
+	(*TopPerformer)(nil),               // 21: insights.TopPerformer
+	(*GetCashFlowRequest)(nil),         // 22: insights.GetCashFlowRequest
+	(*CashFlowResponse)(nil),           // 23: insights.CashFlowResponse
+	(*CashFlowPeriod)(nil),             // 24: insights.CashFlowPeriod
+	(*CreateSnapshotRequest)(nil),      // 25: insights.CreateSnapshotRequest
+	(*GetSnapshotsRequest)(nil),        // 26: insights.GetSnapshotsRequest
+	(*GetSnapshotsResponse)(nil),       // 27: insights.GetSnapshotsResponse
+	(*timestamppb.Timestamp)(nil),      // 28: google.protobuf.Timestamp
 }
 var file_proto_insights_proto_depIdxs = []int32{
-	25, // 0: insights.Snapshot.date:type_name -> google.protobuf.Timestamp
-	25, // 1: insights.Snapshot.created_at:type_name -> google.protobuf.Timestamp
-	25, // 2: insights.NetWorthResponse.calculated_at:type_name -> google.protobuf.Timestamp
-	25, // 3: insights.GetNetWorthHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
-	25, // 4: insights.GetNetWorthHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	28, // 0: insights.Snapshot.date:type_name -> google.protobuf.Timestamp
+	28, // 1: insights.Snapshot.created_at:type_name -> google.protobuf.Timestamp
+	28, // 2: insights.NetWorthResponse.calculated_at:type_name -> google.protobuf.Timestamp
+	28, // 3: insights.GetNetWorthHistoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 4: insights.GetNetWorthHistoryRequest.end_date:type_name -> google.protobuf.Timestamp
 	5,  // 5: insights.NetWorthHistoryResponse.history:type_name -> insights.NetWorthPoint
-	25, // 6: insights.NetWorthPoint.date:type_name -> google.protobuf.Timestamp
-	8,  // 7: insights.AllocationResponse.allocations:type_name -> insights.AllocationItem
-	25, // 8: insights.GetBalanceChangesRequest.start_date:type_name -> google.protobuf.Timestamp
-	25, // 9: insights.GetBalanceChangesRequest.end_date:type_name -> google.protobuf.Timestamp
-	11, // 10: insights.BalanceChangesResponse.changes:type_name -> insights.BalanceChange
-	14, // 11: insights.TrendsResponse.data:type_name -> insights.TrendPoint
-	25, // 12: insights.TrendPoint.date:type_name -> google.protobuf.Timestamp
-	8,  // 13: insights.DashboardSummaryResponse.top_allocations:type_name -> insights.AllocationItem
-	17, // 14: insights.DashboardSummaryResponse.recent_transactions:type_name -> insights.RecentTransaction
-	18, // 15: insights.DashboardSummaryResponse.top_performers:type_name -> insights.TopPerformer
-	25, // 16: insights.DashboardSummaryResponse.calculated_at:type_name -> google.protobuf.Timestamp
-	25, // 17: insights.RecentTransaction.date:type_name -> google.protobuf.Timestamp
-	25, // 18: insights.GetCashFlowRequest.start_date:type_name -> google.protobuf.Timestamp
-	25, // 19: insights.GetCashFlowRequest.end_date:type_name -> google.protobuf.Timestamp
-	21, // 20: insights.CashFlowResponse.periods:type_name -> insights.CashFlowPeriod
-	25, // 21: insights.CashFlowPeriod.start_date:type_name -> google.protobuf.Timestamp
-	25, // 22: insights.CashFlowPeriod.end_date:type_name -> google.protobuf.Timestamp
-	25, // 23: insights.GetSnapshotsRequest.start_date:type_name -> google.protobuf.Timestamp
-	25, // 24: insights.GetSnapshotsRequest.end_date:type_name -> google.protobuf.Timestamp
-	0,  // 25: insights.GetSnapshotsResponse.snapshots:type_name -> insights.Snapshot
-	1,  // 26: insights.InsightsService.GetNetWorth:input_type -> insights.GetNetWorthRequest
-	3,  // 27: insights.InsightsService.GetNetWorthHistory:input_type -> insights.GetNetWorthHistoryRequest
-	6,  // 28: insights.InsightsService.GetAllocation:input_type -> insights.GetAllocationRequest
-	9,  // 29: insights.InsightsService.GetBalanceChanges:input_type -> insights.GetBalanceChangesRequest
-	12, // 30: insights.InsightsService.GetTrends:input_type -> insights.GetTrendsRequest
-	15, // 31: insights.InsightsService.GetDashboardSummary:input_type -> insights.GetDashboardSummaryRequest
-	19, // 32: insights.InsightsService.GetCashFlow:input_type -> insights.GetCashFlowRequest
-	22, // 33: insights.InsightsService.CreateSnapshot:input_type -> insights.CreateSnapshotRequest
-	23, // 34: insights.InsightsService.GetSnapshots:input_type -> insights.GetSnapshotsRequest
-	2,  // 35: insights.InsightsService.GetNetWorth:output_type -> insights.NetWorthResponse
-	4,  // 36: insights.InsightsService.GetNetWorthHistory:output_type -> insights.NetWorthHistoryResponse
-	7,  // 37: insights.InsightsService.GetAllocation:output_type -> insights.AllocationResponse
-	10, // 38: insights.InsightsService.GetBalanceChanges:output_type -> insights.BalanceChangesResponse
-	13, // 39: insights.InsightsService.GetTrends:output_type -> insights.TrendsResponse
-	16, // 40: insights.InsightsService.GetDashboardSummary:output_type -> insights.DashboardSummaryResponse
-	20, // 41: insights.InsightsService.GetCashFlow:output_type -> insights.CashFlowResponse
-	0,  // 42: insights.InsightsService.CreateSnapshot:output_type -> insights.Snapshot
-	24, // 43: insights.InsightsService.GetSnapshots:output_type -> insights.GetSnapshotsResponse
-	35, // [35:44] is the sub-list for method output_type
-	26, // [26:35] is the sub-list for method input_type
-	26, // [26:26] is the sub-list for extension type_name
-	26, // [26:26] is the sub-list for extension extendee
-	0,  // [0:26] is the sub-list for field type_name
+	28, // 6: insights.NetWorthPoint.date:type_name -> google.protobuf.Timestamp
+	8,  // 7: insights.NetWorthProjectionResponse.points:type_name -> insights.ProjectionPoint
+	28, // 8: insights.ProjectionPoint.date:type_name -> google.protobuf.Timestamp
+	11, // 9: insights.AllocationResponse.allocations:type_name -> insights.AllocationItem
+	28, // 10: insights.GetBalanceChangesRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 11: insights.GetBalanceChangesRequest.end_date:type_name -> google.protobuf.Timestamp
+	14, // 12: insights.BalanceChangesResponse.changes:type_name -> insights.BalanceChange
+	17, // 13: insights.TrendsResponse.data:type_name -> insights.TrendPoint
+	28, // 14: insights.TrendPoint.date:type_name -> google.protobuf.Timestamp
+	11, // 15: insights.DashboardSummaryResponse.top_allocations:type_name -> insights.AllocationItem
+	20, // 16: insights.DashboardSummaryResponse.recent_transactions:type_name -> insights.RecentTransaction
+	21, // 17: insights.DashboardSummaryResponse.top_performers:type_name -> insights.TopPerformer
+	28, // 18: insights.DashboardSummaryResponse.calculated_at:type_name -> google.protobuf.Timestamp
+	28, // 19: insights.RecentTransaction.date:type_name -> google.protobuf.Timestamp
+	28, // 20: insights.GetCashFlowRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 21: insights.GetCashFlowRequest.end_date:type_name -> google.protobuf.Timestamp
+	24, // 22: insights.CashFlowResponse.periods:type_name -> insights.CashFlowPeriod
+	28, // 23: insights.CashFlowPeriod.start_date:type_name -> google.protobuf.Timestamp
+	28, // 24: insights.CashFlowPeriod.end_date:type_name -> google.protobuf.Timestamp
+	28, // 25: insights.GetSnapshotsRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 26: insights.GetSnapshotsRequest.end_date:type_name -> google.protobuf.Timestamp
+	0,  // 27: insights.GetSnapshotsResponse.snapshots:type_name -> insights.Snapshot
+	1,  // 28: insights.InsightsService.GetNetWorth:input_type -> insights.GetNetWorthRequest
+	3,  // 29: insights.InsightsService.GetNetWorthHistory:input_type -> insights.GetNetWorthHistoryRequest
+	6,  // 30: insights.InsightsService.ProjectNetWorth:input_type -> insights.ProjectNetWorthRequest
+	9,  // 31: insights.InsightsService.GetAllocation:input_type -> insights.GetAllocationRequest
+	12, // 32: insights.InsightsService.GetBalanceChanges:input_type -> insights.GetBalanceChangesRequest
+	15, // 33: insights.InsightsService.GetTrends:input_type -> insights.GetTrendsRequest
+	18, // 34: insights.InsightsService.GetDashboardSummary:input_type -> insights.GetDashboardSummaryRequest
+	22, // 35: insights.InsightsService.GetCashFlow:input_type -> insights.GetCashFlowRequest
+	25, // 36: insights.InsightsService.CreateSnapshot:input_type -> insights.CreateSnapshotRequest
+	26, // 37: insights.InsightsService.GetSnapshots:input_type -> insights.GetSnapshotsRequest
+	2,  // 38: insights.InsightsService.GetNetWorth:output_type -> insights.NetWorthResponse
+	4,  // 39: insights.InsightsService.GetNetWorthHistory:output_type -> insights.NetWorthHistoryResponse
+	7,  // 40: insights.InsightsService.ProjectNetWorth:output_type -> insights.NetWorthProjectionResponse
+	10, // 41: insights.InsightsService.GetAllocation:output_type -> insights.AllocationResponse
+	13, // 42: insights.InsightsService.GetBalanceChanges:output_type -> insights.BalanceChangesResponse
+	16, // 43: insights.InsightsService.GetTrends:output_type -> insights.TrendsResponse
+	19, // 44: insights.InsightsService.GetDashboardSummary:output_type -> insights.DashboardSummaryResponse
+	23, // 45: insights.InsightsService.GetCashFlow:output_type -> insights.CashFlowResponse
+	0,  // 46: insights.InsightsService.CreateSnapshot:output_type -> insights.Snapshot
+	27, // 47: insights.InsightsService.GetSnapshots:output_type -> insights.GetSnapshotsResponse
+	38, // [38:48] is the sub-list for method output_type
+	28, // [28:38] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
 }
 
 func init() { file_proto_insights_proto_init() }
@@ -2134,7 +2411,7 @@ func file_proto_insights_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_insights_proto_rawDesc), len(file_proto_insights_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   25,
+			NumMessages:   28,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
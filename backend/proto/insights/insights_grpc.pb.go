@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	InsightsService_GetNetWorth_FullMethodName         = "/insights.InsightsService/GetNetWorth"
 	InsightsService_GetNetWorthHistory_FullMethodName  = "/insights.InsightsService/GetNetWorthHistory"
+	InsightsService_ProjectNetWorth_FullMethodName     = "/insights.InsightsService/ProjectNetWorth"
 	InsightsService_GetAllocation_FullMethodName       = "/insights.InsightsService/GetAllocation"
 	InsightsService_GetBalanceChanges_FullMethodName   = "/insights.InsightsService/GetBalanceChanges"
 	InsightsService_GetTrends_FullMethodName           = "/insights.InsightsService/GetTrends"
@@ -36,6 +37,7 @@ const (
 type InsightsServiceClient interface {
 	GetNetWorth(ctx context.Context, in *GetNetWorthRequest, opts ...grpc.CallOption) (*NetWorthResponse, error)
 	GetNetWorthHistory(ctx context.Context, in *GetNetWorthHistoryRequest, opts ...grpc.CallOption) (*NetWorthHistoryResponse, error)
+	ProjectNetWorth(ctx context.Context, in *ProjectNetWorthRequest, opts ...grpc.CallOption) (*NetWorthProjectionResponse, error)
 	GetAllocation(ctx context.Context, in *GetAllocationRequest, opts ...grpc.CallOption) (*AllocationResponse, error)
 	GetBalanceChanges(ctx context.Context, in *GetBalanceChangesRequest, opts ...grpc.CallOption) (*BalanceChangesResponse, error)
 	GetTrends(ctx context.Context, in *GetTrendsRequest, opts ...grpc.CallOption) (*TrendsResponse, error)
@@ -73,6 +75,16 @@ func (c *insightsServiceClient) GetNetWorthHistory(ctx context.Context, in *GetN
 	return out, nil
 }
 
+func (c *insightsServiceClient) ProjectNetWorth(ctx context.Context, in *ProjectNetWorthRequest, opts ...grpc.CallOption) (*NetWorthProjectionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NetWorthProjectionResponse)
+	err := c.cc.Invoke(ctx, InsightsService_ProjectNetWorth_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *insightsServiceClient) GetAllocation(ctx context.Context, in *GetAllocationRequest, opts ...grpc.CallOption) (*AllocationResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AllocationResponse)
@@ -149,6 +161,7 @@ func (c *insightsServiceClient) GetSnapshots(ctx context.Context, in *GetSnapsho
 type InsightsServiceServer interface {
 	GetNetWorth(context.Context, *GetNetWorthRequest) (*NetWorthResponse, error)
 	GetNetWorthHistory(context.Context, *GetNetWorthHistoryRequest) (*NetWorthHistoryResponse, error)
+	ProjectNetWorth(context.Context, *ProjectNetWorthRequest) (*NetWorthProjectionResponse, error)
 	GetAllocation(context.Context, *GetAllocationRequest) (*AllocationResponse, error)
 	GetBalanceChanges(context.Context, *GetBalanceChangesRequest) (*BalanceChangesResponse, error)
 	GetTrends(context.Context, *GetTrendsRequest) (*TrendsResponse, error)
@@ -172,6 +185,9 @@ func (UnimplementedInsightsServiceServer) GetNetWorth(context.Context, *GetNetWo
 func (UnimplementedInsightsServiceServer) GetNetWorthHistory(context.Context, *GetNetWorthHistoryRequest) (*NetWorthHistoryResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetNetWorthHistory not implemented")
 }
+func (UnimplementedInsightsServiceServer) ProjectNetWorth(context.Context, *ProjectNetWorthRequest) (*NetWorthProjectionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ProjectNetWorth not implemented")
+}
 func (UnimplementedInsightsServiceServer) GetAllocation(context.Context, *GetAllocationRequest) (*AllocationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAllocation not implemented")
 }
@@ -250,6 +266,24 @@ func _InsightsService_GetNetWorthHistory_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InsightsService_ProjectNetWorth_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProjectNetWorthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InsightsServiceServer).ProjectNetWorth(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: InsightsService_ProjectNetWorth_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InsightsServiceServer).ProjectNetWorth(ctx, req.(*ProjectNetWorthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _InsightsService_GetAllocation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetAllocationRequest)
 	if err := dec(in); err != nil {
@@ -391,6 +425,10 @@ var InsightsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetNetWorthHistory",
 			Handler:    _InsightsService_GetNetWorthHistory_Handler,
 		},
+		{
+			MethodName: "ProjectNetWorth",
+			Handler:    _InsightsService_ProjectNetWorth_Handler,
+		},
 		{
 			MethodName: "GetAllocation",
 			Handler:    _InsightsService_GetAllocation_Handler,
@@ -183,8 +183,17 @@ type Transaction struct {
 	UpdatedAt              *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	Metadata               map[string]string      `protobuf:"bytes,14,rep,name=metadata,proto3" json:"metadata,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	TransferToSubAccountId string                 `protobuf:"bytes,15,opt,name=transfer_to_sub_account_id,json=transferToSubAccountId,proto3" json:"transfer_to_sub_account_id,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// Set when the transaction was converted into its sub-account's currency;
+	// original_amount is only meaningful when original_currency is set
+	OriginalAmount   float64 `protobuf:"fixed64,16,opt,name=original_amount,json=originalAmount,proto3" json:"original_amount,omitempty"`
+	OriginalCurrency string  `protobuf:"bytes,17,opt,name=original_currency,json=originalCurrency,proto3" json:"original_currency,omitempty"`
+	// Set when a transfer moves money into a sub-account in another currency:
+	// the amount credited there and the rate it was converted at
+	TransferAmount   float64 `protobuf:"fixed64,18,opt,name=transfer_amount,json=transferAmount,proto3" json:"transfer_amount,omitempty"`
+	TransferCurrency string  `protobuf:"bytes,19,opt,name=transfer_currency,json=transferCurrency,proto3" json:"transfer_currency,omitempty"`
+	TransferRate     float64 `protobuf:"fixed64,20,opt,name=transfer_rate,json=transferRate,proto3" json:"transfer_rate,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Transaction) Reset() {
@@ -322,6 +331,41 @@ func (x *Transaction) GetTransferToSubAccountId() string {
 	return ""
 }
 
+func (x *Transaction) GetOriginalAmount() float64 {
+	if x != nil {
+		return x.OriginalAmount
+	}
+	return 0
+}
+
+func (x *Transaction) GetOriginalCurrency() string {
+	if x != nil {
+		return x.OriginalCurrency
+	}
+	return ""
+}
+
+func (x *Transaction) GetTransferAmount() float64 {
+	if x != nil {
+		return x.TransferAmount
+	}
+	return 0
+}
+
+func (x *Transaction) GetTransferCurrency() string {
+	if x != nil {
+		return x.TransferCurrency
+	}
+	return ""
+}
+
+func (x *Transaction) GetTransferRate() float64 {
+	if x != nil {
+		return x.TransferRate
+	}
+	return 0
+}
+
 type CreateTransactionRequest struct {
 	state                  protoimpl.MessageState `protogen:"open.v1"`
 	UserId                 string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -514,6 +558,7 @@ type ListTransactionsRequest struct {
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
 	SortBy        string                 `protobuf:"bytes,5,opt,name=sort_by,json=sortBy,proto3" json:"sort_by,omitempty"`
 	SortDesc      bool                   `protobuf:"varint,6,opt,name=sort_desc,json=sortDesc,proto3" json:"sort_desc,omitempty"`
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -590,12 +635,20 @@ func (x *ListTransactionsRequest) GetSortDesc() bool {
 	return false
 }
 
+func (x *ListTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type ListTransactionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
 	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
 	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,5,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -658,6 +711,13 @@ func (x *ListTransactionsResponse) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ListTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 type UpdateTransactionRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -834,6 +894,7 @@ type GetTransactionsByDateRangeRequest struct {
 	SubAccountId  string                 `protobuf:"bytes,4,opt,name=sub_account_id,json=subAccountId,proto3" json:"sub_account_id,omitempty"`
 	Page          int32                  `protobuf:"varint,5,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,6,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -910,6 +971,13 @@ func (x *GetTransactionsByDateRangeRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *GetTransactionsByDateRangeRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
 type GetTransactionsByCategoryRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -1326,150 +1394,1005 @@ func (x *BulkCategorizeResponse) GetFailedIds() []string {
 	return nil
 }
 
-var File_proto_transactions_proto protoreflect.FileDescriptor
+type DetectRecurringRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-const file_proto_transactions_proto_rawDesc = "" +
-	"\n" +
-	"\x18proto/transactions.proto\x12\ftransactions\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\xcd\x05\n" +
-	"\vTransaction\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
-	"\x0esub_account_id\x18\x03 \x01(\tR\fsubAccountId\x12\x16\n" +
-	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x121\n" +
-	"\x04type\x18\x06 \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12=\n" +
-	"\bcategory\x18\a \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
-	"\x0fcustom_category\x18\b \x01(\tR\x0ecustomCategory\x12 \n" +
-	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1a\n" +
-	"\bmerchant\x18\n" +
-	" \x01(\tR\bmerchant\x12.\n" +
-	"\x04date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x129\n" +
-	"\n" +
-	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
-	"\n" +
-	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12C\n" +
-	"\bmetadata\x18\x0e \x03(\v2'.transactions.Transaction.MetadataEntryR\bmetadata\x12:\n" +
-	"\x1atransfer_to_sub_account_id\x18\x0f \x01(\tR\x16transferToSubAccountId\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe1\x04\n" +
-	"\x18CreateTransactionRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
-	"\x0esub_account_id\x18\x02 \x01(\tR\fsubAccountId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
-	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x121\n" +
-	"\x04type\x18\x05 \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12=\n" +
-	"\bcategory\x18\x06 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
-	"\x0fcustom_category\x18\a \x01(\tR\x0ecustomCategory\x12 \n" +
-	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1a\n" +
-	"\bmerchant\x18\t \x01(\tR\bmerchant\x12.\n" +
-	"\x04date\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12:\n" +
-	"\x1atransfer_to_sub_account_id\x18\v \x01(\tR\x16transferToSubAccountId\x12P\n" +
-	"\bmetadata\x18\f \x03(\v24.transactions.CreateTransactionRequest.MetadataEntryR\bmetadata\x1a;\n" +
-	"\rMetadataEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
-	"\x15GetTransactionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xbf\x01\n" +
-	"\x17ListTransactionsRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
-	"\x0esub_account_id\x18\x02 \x01(\tR\fsubAccountId\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x17\n" +
-	"\asort_by\x18\x05 \x01(\tR\x06sortBy\x12\x1b\n" +
-	"\tsort_desc\x18\x06 \x01(\bR\bsortDesc\"\xa0\x01\n" +
-	"\x18ListTransactionsResponse\x12=\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x19.transactions.TransactionR\ftransactions\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x80\x03\n" +
-	"\x18UpdateTransactionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
-	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12=\n" +
-	"\bcategory\x18\x04 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
-	"\x0fcustom_category\x18\x05 \x01(\tR\x0ecustomCategory\x12 \n" +
-	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1a\n" +
-	"\bmerchant\x18\a \x01(\tR\bmerchant\x12.\n" +
-	"\x04date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x121\n" +
-	"\x04type\x18\t \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12\x1a\n" +
-	"\bcurrency\x18\n" +
-	" \x01(\tR\bcurrency\"C\n" +
-	"\x18DeleteTransactionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x85\x02\n" +
-	"!GetTransactionsByDateRangeRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
-	"\n" +
-	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12$\n" +
-	"\x0esub_account_id\x18\x04 \x01(\tR\fsubAccountId\x12\x12\n" +
-	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\x9d\x02\n" +
-	" GetTransactionsByCategoryRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
-	"\bcategory\x18\x02 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x129\n" +
-	"\n" +
-	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x12\n" +
-	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\xcf\x01\n" +
-	"\x1dGetTransactionsSummaryRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
-	"\n" +
-	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
-	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12#\n" +
-	"\rbase_currency\x18\x04 \x01(\tR\fbaseCurrency\"\xe6\x02\n" +
-	"\x1bTransactionsSummaryResponse\x12!\n" +
-	"\ftotal_income\x18\x01 \x01(\x01R\vtotalIncome\x12%\n" +
-	"\x0etotal_expenses\x18\x02 \x01(\x01R\rtotalExpenses\x12\x19\n" +
-	"\bnet_flow\x18\x03 \x01(\x01R\anetFlow\x12\x1a\n" +
-	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12Z\n" +
-	"\vby_category\x18\x05 \x03(\v29.transactions.TransactionsSummaryResponse.ByCategoryEntryR\n" +
-	"byCategory\x12+\n" +
-	"\x11transaction_count\x18\x06 \x01(\x05R\x10transactionCount\x1a=\n" +
-	"\x0fByCategoryEntry\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xaf\x01\n" +
-	"\x1cCategorizeTransactionRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
-	"\auser_id\x18\x02 \x01(\tR\x06userId\x12=\n" +
-	"\bcategory\x18\x03 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
-	"\x0fcustom_category\x18\x04 \x01(\tR\x0ecustomCategory\"\x98\x01\n" +
-	"\x15BulkCategorizeRequest\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
-	"\x0ftransaction_ids\x18\x02 \x03(\tR\x0etransactionIds\x12=\n" +
-	"\bcategory\x18\x03 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\"\\\n" +
-	"\x16BulkCategorizeResponse\x12#\n" +
-	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\x12\x1d\n" +
-	"\n" +
-	"failed_ids\x18\x02 \x03(\tR\tfailedIds*\x8d\x01\n" +
-	"\x0fTransactionType\x12 \n" +
-	"\x1cTRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
-	"\x17TRANSACTION_TYPE_INCOME\x10\x01\x12\x1c\n" +
-	"\x18TRANSACTION_TYPE_EXPENSE\x10\x02\x12\x1d\n" +
-	"\x19TRANSACTION_TYPE_TRANSFER\x10\x03*\xed\x04\n" +
-	"\x13TransactionCategory\x12$\n" +
-	" TRANSACTION_CATEGORY_UNSPECIFIED\x10\x00\x12\x1f\n" +
-	"\x1bTRANSACTION_CATEGORY_SALARY\x10\x01\x12*\n" +
-	"&TRANSACTION_CATEGORY_INVESTMENT_INCOME\x10\x02\x12\x1d\n" +
-	"\x19TRANSACTION_CATEGORY_FOOD\x10\x03\x12\"\n" +
-	"\x1eTRANSACTION_CATEGORY_TRANSPORT\x10\x04\x12\"\n" +
-	"\x1eTRANSACTION_CATEGORY_UTILITIES\x10\x05\x12&\n" +
-	"\"TRANSACTION_CATEGORY_ENTERTAINMENT\x10\x06\x12!\n" +
-	"\x1dTRANSACTION_CATEGORY_SHOPPING\x10\a\x12#\n" +
-	"\x1fTRANSACTION_CATEGORY_HEALTHCARE\x10\b\x12\"\n" +
-	"\x1eTRANSACTION_CATEGORY_EDUCATION\x10\t\x12\x1f\n" +
-	"\x1bTRANSACTION_CATEGORY_TRAVEL\x10\n" +
-	"\x12 \n" +
-	"\x1cTRANSACTION_CATEGORY_HOUSING\x10\v\x12\"\n" +
-	"\x1eTRANSACTION_CATEGORY_INSURANCE\x10\f\x12\x1e\n" +
-	"\x1aTRANSACTION_CATEGORY_TAXES\x10\r\x12\x1e\n" +
-	"\x1aTRANSACTION_CATEGORY_GIFTS\x10\x0e\x12!\n" +
-	"\x1dTRANSACTION_CATEGORY_TRANSFER\x10\x0f\x12\x1e\n" +
-	"\x1aTRANSACTION_CATEGORY_OTHER\x10\x102\xea\a\n" +
+func (x *DetectRecurringRequest) Reset() {
+	*x = DetectRecurringRequest{}
+	mi := &file_proto_transactions_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectRecurringRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectRecurringRequest) ProtoMessage() {}
+
+func (x *DetectRecurringRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectRecurringRequest.ProtoReflect.Descriptor instead.
+func (*DetectRecurringRequest) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *DetectRecurringRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RecurringTransaction struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Merchant string                 `protobuf:"bytes,1,opt,name=merchant,proto3" json:"merchant,omitempty"`
+	Category TransactionCategory    `protobuf:"varint,2,opt,name=category,proto3,enum=transactions.TransactionCategory" json:"category,omitempty"`
+	Type     TransactionType        `protobuf:"varint,3,opt,name=type,proto3,enum=transactions.TransactionType" json:"type,omitempty"`
+	Currency string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	// weekly, biweekly, monthly, quarterly or yearly
+	Cadence        string                 `protobuf:"bytes,5,opt,name=cadence,proto3" json:"cadence,omitempty"`
+	AverageAmount  float64                `protobuf:"fixed64,6,opt,name=average_amount,json=averageAmount,proto3" json:"average_amount,omitempty"`
+	LastAmount     float64                `protobuf:"fixed64,7,opt,name=last_amount,json=lastAmount,proto3" json:"last_amount,omitempty"`
+	LastDate       *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=last_date,json=lastDate,proto3" json:"last_date,omitempty"`
+	NextDate       *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=next_date,json=nextDate,proto3" json:"next_date,omitempty"`
+	Occurrences    int32                  `protobuf:"varint,10,opt,name=occurrences,proto3" json:"occurrences,omitempty"`
+	Confidence     float64                `protobuf:"fixed64,11,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	TransactionIds []string               `protobuf:"bytes,12,rep,name=transaction_ids,json=transactionIds,proto3" json:"transaction_ids,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RecurringTransaction) Reset() {
+	*x = RecurringTransaction{}
+	mi := &file_proto_transactions_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecurringTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecurringTransaction) ProtoMessage() {}
+
+func (x *RecurringTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecurringTransaction.ProtoReflect.Descriptor instead.
+func (*RecurringTransaction) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RecurringTransaction) GetMerchant() string {
+	if x != nil {
+		return x.Merchant
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetCategory() TransactionCategory {
+	if x != nil {
+		return x.Category
+	}
+	return TransactionCategory_TRANSACTION_CATEGORY_UNSPECIFIED
+}
+
+func (x *RecurringTransaction) GetType() TransactionType {
+	if x != nil {
+		return x.Type
+	}
+	return TransactionType_TRANSACTION_TYPE_UNSPECIFIED
+}
+
+func (x *RecurringTransaction) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetCadence() string {
+	if x != nil {
+		return x.Cadence
+	}
+	return ""
+}
+
+func (x *RecurringTransaction) GetAverageAmount() float64 {
+	if x != nil {
+		return x.AverageAmount
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetLastAmount() float64 {
+	if x != nil {
+		return x.LastAmount
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetLastDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDate
+	}
+	return nil
+}
+
+func (x *RecurringTransaction) GetNextDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextDate
+	}
+	return nil
+}
+
+func (x *RecurringTransaction) GetOccurrences() int32 {
+	if x != nil {
+		return x.Occurrences
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *RecurringTransaction) GetTransactionIds() []string {
+	if x != nil {
+		return x.TransactionIds
+	}
+	return nil
+}
+
+type DetectRecurringResponse struct {
+	state         protoimpl.MessageState  `protogen:"open.v1"`
+	Recurring     []*RecurringTransaction `protobuf:"bytes,1,rep,name=recurring,proto3" json:"recurring,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DetectRecurringResponse) Reset() {
+	*x = DetectRecurringResponse{}
+	mi := &file_proto_transactions_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetectRecurringResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetectRecurringResponse) ProtoMessage() {}
+
+func (x *DetectRecurringResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetectRecurringResponse.ProtoReflect.Descriptor instead.
+func (*DetectRecurringResponse) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DetectRecurringResponse) GetRecurring() []*RecurringTransaction {
+	if x != nil {
+		return x.Recurring
+	}
+	return nil
+}
+
+type GetSpendingReportRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// weekly, monthly (default) or yearly
+	Period string `protobuf:"bytes,2,opt,name=period,proto3" json:"period,omitempty"`
+	// Any time within the period to report on; defaults to now
+	Date          *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=date,proto3" json:"date,omitempty"`
+	BaseCurrency  string                 `protobuf:"bytes,4,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSpendingReportRequest) Reset() {
+	*x = GetSpendingReportRequest{}
+	mi := &file_proto_transactions_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSpendingReportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSpendingReportRequest) ProtoMessage() {}
+
+func (x *GetSpendingReportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSpendingReportRequest.ProtoReflect.Descriptor instead.
+func (*GetSpendingReportRequest) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetSpendingReportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSpendingReportRequest) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *GetSpendingReportRequest) GetDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Date
+	}
+	return nil
+}
+
+func (x *GetSpendingReportRequest) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+// Percentages are only meaningful when the matching has_ flag is set; they are
+// left unset when the base is zero, e.g. for a user's first period.
+type CategorySpendChange struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Category         string                 `protobuf:"bytes,1,opt,name=category,proto3" json:"category,omitempty"`
+	Current          float64                `protobuf:"fixed64,2,opt,name=current,proto3" json:"current,omitempty"`
+	Previous         float64                `protobuf:"fixed64,3,opt,name=previous,proto3" json:"previous,omitempty"`
+	Change           float64                `protobuf:"fixed64,4,opt,name=change,proto3" json:"change,omitempty"`
+	PercentChange    float64                `protobuf:"fixed64,5,opt,name=percent_change,json=percentChange,proto3" json:"percent_change,omitempty"`
+	HasPercentChange bool                   `protobuf:"varint,6,opt,name=has_percent_change,json=hasPercentChange,proto3" json:"has_percent_change,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CategorySpendChange) Reset() {
+	*x = CategorySpendChange{}
+	mi := &file_proto_transactions_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategorySpendChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategorySpendChange) ProtoMessage() {}
+
+func (x *CategorySpendChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategorySpendChange.ProtoReflect.Descriptor instead.
+func (*CategorySpendChange) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CategorySpendChange) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *CategorySpendChange) GetCurrent() float64 {
+	if x != nil {
+		return x.Current
+	}
+	return 0
+}
+
+func (x *CategorySpendChange) GetPrevious() float64 {
+	if x != nil {
+		return x.Previous
+	}
+	return 0
+}
+
+func (x *CategorySpendChange) GetChange() float64 {
+	if x != nil {
+		return x.Change
+	}
+	return 0
+}
+
+func (x *CategorySpendChange) GetPercentChange() float64 {
+	if x != nil {
+		return x.PercentChange
+	}
+	return 0
+}
+
+func (x *CategorySpendChange) GetHasPercentChange() bool {
+	if x != nil {
+		return x.HasPercentChange
+	}
+	return false
+}
+
+type SpendingReport struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	Period                 string                 `protobuf:"bytes,1,opt,name=period,proto3" json:"period,omitempty"`
+	Currency               string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	StartDate              *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate                *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	PreviousStartDate      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=previous_start_date,json=previousStartDate,proto3" json:"previous_start_date,omitempty"`
+	PreviousEndDate        *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=previous_end_date,json=previousEndDate,proto3" json:"previous_end_date,omitempty"`
+	TotalSpent             float64                `protobuf:"fixed64,7,opt,name=total_spent,json=totalSpent,proto3" json:"total_spent,omitempty"`
+	PreviousTotalSpent     float64                `protobuf:"fixed64,8,opt,name=previous_total_spent,json=previousTotalSpent,proto3" json:"previous_total_spent,omitempty"`
+	PercentChange          float64                `protobuf:"fixed64,9,opt,name=percent_change,json=percentChange,proto3" json:"percent_change,omitempty"`
+	HasPercentChange       bool                   `protobuf:"varint,10,opt,name=has_percent_change,json=hasPercentChange,proto3" json:"has_percent_change,omitempty"`
+	Income                 float64                `protobuf:"fixed64,11,opt,name=income,proto3" json:"income,omitempty"`
+	PreviousIncome         float64                `protobuf:"fixed64,12,opt,name=previous_income,json=previousIncome,proto3" json:"previous_income,omitempty"`
+	SavingsRate            float64                `protobuf:"fixed64,13,opt,name=savings_rate,json=savingsRate,proto3" json:"savings_rate,omitempty"`
+	HasSavingsRate         bool                   `protobuf:"varint,14,opt,name=has_savings_rate,json=hasSavingsRate,proto3" json:"has_savings_rate,omitempty"`
+	PreviousSavingsRate    float64                `protobuf:"fixed64,15,opt,name=previous_savings_rate,json=previousSavingsRate,proto3" json:"previous_savings_rate,omitempty"`
+	HasPreviousSavingsRate bool                   `protobuf:"varint,16,opt,name=has_previous_savings_rate,json=hasPreviousSavingsRate,proto3" json:"has_previous_savings_rate,omitempty"`
+	SavingsRateChange      float64                `protobuf:"fixed64,17,opt,name=savings_rate_change,json=savingsRateChange,proto3" json:"savings_rate_change,omitempty"`
+	HasSavingsRateChange   bool                   `protobuf:"varint,18,opt,name=has_savings_rate_change,json=hasSavingsRateChange,proto3" json:"has_savings_rate_change,omitempty"`
+	Categories             []*CategorySpendChange `protobuf:"bytes,19,rep,name=categories,proto3" json:"categories,omitempty"`
+	TopIncreases           []*CategorySpendChange `protobuf:"bytes,20,rep,name=top_increases,json=topIncreases,proto3" json:"top_increases,omitempty"`
+	TopDecreases           []*CategorySpendChange `protobuf:"bytes,21,rep,name=top_decreases,json=topDecreases,proto3" json:"top_decreases,omitempty"`
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *SpendingReport) Reset() {
+	*x = SpendingReport{}
+	mi := &file_proto_transactions_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpendingReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpendingReport) ProtoMessage() {}
+
+func (x *SpendingReport) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpendingReport.ProtoReflect.Descriptor instead.
+func (*SpendingReport) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SpendingReport) GetPeriod() string {
+	if x != nil {
+		return x.Period
+	}
+	return ""
+}
+
+func (x *SpendingReport) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *SpendingReport) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *SpendingReport) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *SpendingReport) GetPreviousStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousStartDate
+	}
+	return nil
+}
+
+func (x *SpendingReport) GetPreviousEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PreviousEndDate
+	}
+	return nil
+}
+
+func (x *SpendingReport) GetTotalSpent() float64 {
+	if x != nil {
+		return x.TotalSpent
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetPreviousTotalSpent() float64 {
+	if x != nil {
+		return x.PreviousTotalSpent
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetPercentChange() float64 {
+	if x != nil {
+		return x.PercentChange
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetHasPercentChange() bool {
+	if x != nil {
+		return x.HasPercentChange
+	}
+	return false
+}
+
+func (x *SpendingReport) GetIncome() float64 {
+	if x != nil {
+		return x.Income
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetPreviousIncome() float64 {
+	if x != nil {
+		return x.PreviousIncome
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetSavingsRate() float64 {
+	if x != nil {
+		return x.SavingsRate
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetHasSavingsRate() bool {
+	if x != nil {
+		return x.HasSavingsRate
+	}
+	return false
+}
+
+func (x *SpendingReport) GetPreviousSavingsRate() float64 {
+	if x != nil {
+		return x.PreviousSavingsRate
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetHasPreviousSavingsRate() bool {
+	if x != nil {
+		return x.HasPreviousSavingsRate
+	}
+	return false
+}
+
+func (x *SpendingReport) GetSavingsRateChange() float64 {
+	if x != nil {
+		return x.SavingsRateChange
+	}
+	return 0
+}
+
+func (x *SpendingReport) GetHasSavingsRateChange() bool {
+	if x != nil {
+		return x.HasSavingsRateChange
+	}
+	return false
+}
+
+func (x *SpendingReport) GetCategories() []*CategorySpendChange {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *SpendingReport) GetTopIncreases() []*CategorySpendChange {
+	if x != nil {
+		return x.TopIncreases
+	}
+	return nil
+}
+
+func (x *SpendingReport) GetTopDecreases() []*CategorySpendChange {
+	if x != nil {
+		return x.TopDecreases
+	}
+	return nil
+}
+
+type GetMonthlyCashFlowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	BaseCurrency  string                 `protobuf:"bytes,4,opt,name=base_currency,json=baseCurrency,proto3" json:"base_currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMonthlyCashFlowRequest) Reset() {
+	*x = GetMonthlyCashFlowRequest{}
+	mi := &file_proto_transactions_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMonthlyCashFlowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMonthlyCashFlowRequest) ProtoMessage() {}
+
+func (x *GetMonthlyCashFlowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMonthlyCashFlowRequest.ProtoReflect.Descriptor instead.
+func (*GetMonthlyCashFlowRequest) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *GetMonthlyCashFlowRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetMonthlyCashFlowRequest) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *GetMonthlyCashFlowRequest) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *GetMonthlyCashFlowRequest) GetBaseCurrency() string {
+	if x != nil {
+		return x.BaseCurrency
+	}
+	return ""
+}
+
+// One month-long bucket counted from the start date; the last may be shorter
+type CashFlowBucket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartDate     *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=start_date,json=startDate,proto3" json:"start_date,omitempty"`
+	EndDate       *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=end_date,json=endDate,proto3" json:"end_date,omitempty"`
+	Income        float64                `protobuf:"fixed64,3,opt,name=income,proto3" json:"income,omitempty"`
+	Expenses      float64                `protobuf:"fixed64,4,opt,name=expenses,proto3" json:"expenses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CashFlowBucket) Reset() {
+	*x = CashFlowBucket{}
+	mi := &file_proto_transactions_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CashFlowBucket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CashFlowBucket) ProtoMessage() {}
+
+func (x *CashFlowBucket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CashFlowBucket.ProtoReflect.Descriptor instead.
+func (*CashFlowBucket) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *CashFlowBucket) GetStartDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartDate
+	}
+	return nil
+}
+
+func (x *CashFlowBucket) GetEndDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EndDate
+	}
+	return nil
+}
+
+func (x *CashFlowBucket) GetIncome() float64 {
+	if x != nil {
+		return x.Income
+	}
+	return 0
+}
+
+func (x *CashFlowBucket) GetExpenses() float64 {
+	if x != nil {
+		return x.Expenses
+	}
+	return 0
+}
+
+type MonthlyCashFlowResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Buckets       []*CashFlowBucket      `protobuf:"bytes,1,rep,name=buckets,proto3" json:"buckets,omitempty"`
+	Currency      string                 `protobuf:"bytes,2,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MonthlyCashFlowResponse) Reset() {
+	*x = MonthlyCashFlowResponse{}
+	mi := &file_proto_transactions_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MonthlyCashFlowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MonthlyCashFlowResponse) ProtoMessage() {}
+
+func (x *MonthlyCashFlowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transactions_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MonthlyCashFlowResponse.ProtoReflect.Descriptor instead.
+func (*MonthlyCashFlowResponse) Descriptor() ([]byte, []int) {
+	return file_proto_transactions_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *MonthlyCashFlowResponse) GetBuckets() []*CashFlowBucket {
+	if x != nil {
+		return x.Buckets
+	}
+	return nil
+}
+
+func (x *MonthlyCashFlowResponse) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+var File_proto_transactions_proto protoreflect.FileDescriptor
+
+const file_proto_transactions_proto_rawDesc = "" +
+	"\n" +
+	"\x18proto/transactions.proto\x12\ftransactions\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1bgoogle/protobuf/empty.proto\"\x9e\a\n" +
+	"\vTransaction\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
+	"\x0esub_account_id\x18\x03 \x01(\tR\fsubAccountId\x12\x16\n" +
+	"\x06amount\x18\x04 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x121\n" +
+	"\x04type\x18\x06 \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12=\n" +
+	"\bcategory\x18\a \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
+	"\x0fcustom_category\x18\b \x01(\tR\x0ecustomCategory\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1a\n" +
+	"\bmerchant\x18\n" +
+	" \x01(\tR\bmerchant\x12.\n" +
+	"\x04date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x129\n" +
+	"\n" +
+	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12C\n" +
+	"\bmetadata\x18\x0e \x03(\v2'.transactions.Transaction.MetadataEntryR\bmetadata\x12:\n" +
+	"\x1atransfer_to_sub_account_id\x18\x0f \x01(\tR\x16transferToSubAccountId\x12'\n" +
+	"\x0foriginal_amount\x18\x10 \x01(\x01R\x0eoriginalAmount\x12+\n" +
+	"\x11original_currency\x18\x11 \x01(\tR\x10originalCurrency\x12'\n" +
+	"\x0ftransfer_amount\x18\x12 \x01(\x01R\x0etransferAmount\x12+\n" +
+	"\x11transfer_currency\x18\x13 \x01(\tR\x10transferCurrency\x12#\n" +
+	"\rtransfer_rate\x18\x14 \x01(\x01R\ftransferRate\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xe1\x04\n" +
+	"\x18CreateTransactionRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0esub_account_id\x18\x02 \x01(\tR\fsubAccountId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x121\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12=\n" +
+	"\bcategory\x18\x06 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
+	"\x0fcustom_category\x18\a \x01(\tR\x0ecustomCategory\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1a\n" +
+	"\bmerchant\x18\t \x01(\tR\bmerchant\x12.\n" +
+	"\x04date\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12:\n" +
+	"\x1atransfer_to_sub_account_id\x18\v \x01(\tR\x16transferToSubAccountId\x12P\n" +
+	"\bmetadata\x18\f \x03(\v24.transactions.CreateTransactionRequest.MetadataEntryR\bmetadata\x1a;\n" +
+	"\rMetadataEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"@\n" +
+	"\x15GetTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\xd7\x01\n" +
+	"\x17ListTransactionsRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x0esub_account_id\x18\x02 \x01(\tR\fsubAccountId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x17\n" +
+	"\asort_by\x18\x05 \x01(\tR\x06sortBy\x12\x1b\n" +
+	"\tsort_desc\x18\x06 \x01(\bR\bsortDesc\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"\xc1\x01\n" +
+	"\x18ListTransactionsResponse\x12=\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x19.transactions.TransactionR\ftransactions\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x1f\n" +
+	"\vnext_cursor\x18\x05 \x01(\tR\n" +
+	"nextCursor\"\x80\x03\n" +
+	"\x18UpdateTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x01R\x06amount\x12=\n" +
+	"\bcategory\x18\x04 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
+	"\x0fcustom_category\x18\x05 \x01(\tR\x0ecustomCategory\x12 \n" +
+	"\vdescription\x18\x06 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bmerchant\x18\a \x01(\tR\bmerchant\x12.\n" +
+	"\x04date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x121\n" +
+	"\x04type\x18\t \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\n" +
+	" \x01(\tR\bcurrency\"C\n" +
+	"\x18DeleteTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x9d\x02\n" +
+	"!GetTransactionsByDateRangeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12$\n" +
+	"\x0esub_account_id\x18\x04 \x01(\tR\fsubAccountId\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursor\"\x9d\x02\n" +
+	" GetTransactionsByCategoryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
+	"\bcategory\x18\x02 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x12\n" +
+	"\x04page\x18\x05 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x06 \x01(\x05R\bpageSize\"\xcf\x01\n" +
+	"\x1dGetTransactionsSummaryRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12#\n" +
+	"\rbase_currency\x18\x04 \x01(\tR\fbaseCurrency\"\xe6\x02\n" +
+	"\x1bTransactionsSummaryResponse\x12!\n" +
+	"\ftotal_income\x18\x01 \x01(\x01R\vtotalIncome\x12%\n" +
+	"\x0etotal_expenses\x18\x02 \x01(\x01R\rtotalExpenses\x12\x19\n" +
+	"\bnet_flow\x18\x03 \x01(\x01R\anetFlow\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12Z\n" +
+	"\vby_category\x18\x05 \x03(\v29.transactions.TransactionsSummaryResponse.ByCategoryEntryR\n" +
+	"byCategory\x12+\n" +
+	"\x11transaction_count\x18\x06 \x01(\x05R\x10transactionCount\x1a=\n" +
+	"\x0fByCategoryEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"\xaf\x01\n" +
+	"\x1cCategorizeTransactionRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12=\n" +
+	"\bcategory\x18\x03 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x12'\n" +
+	"\x0fcustom_category\x18\x04 \x01(\tR\x0ecustomCategory\"\x98\x01\n" +
+	"\x15BulkCategorizeRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12'\n" +
+	"\x0ftransaction_ids\x18\x02 \x03(\tR\x0etransactionIds\x12=\n" +
+	"\bcategory\x18\x03 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\"\\\n" +
+	"\x16BulkCategorizeResponse\x12#\n" +
+	"\rupdated_count\x18\x01 \x01(\x05R\fupdatedCount\x12\x1d\n" +
+	"\n" +
+	"failed_ids\x18\x02 \x03(\tR\tfailedIds\"1\n" +
+	"\x16DetectRecurringRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\xff\x03\n" +
+	"\x14RecurringTransaction\x12\x1a\n" +
+	"\bmerchant\x18\x01 \x01(\tR\bmerchant\x12=\n" +
+	"\bcategory\x18\x02 \x01(\x0e2!.transactions.TransactionCategoryR\bcategory\x121\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x1d.transactions.TransactionTypeR\x04type\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\x12\x18\n" +
+	"\acadence\x18\x05 \x01(\tR\acadence\x12%\n" +
+	"\x0eaverage_amount\x18\x06 \x01(\x01R\raverageAmount\x12\x1f\n" +
+	"\vlast_amount\x18\a \x01(\x01R\n" +
+	"lastAmount\x127\n" +
+	"\tlast_date\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\blastDate\x127\n" +
+	"\tnext_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\bnextDate\x12 \n" +
+	"\voccurrences\x18\n" +
+	" \x01(\x05R\voccurrences\x12\x1e\n" +
+	"\n" +
+	"confidence\x18\v \x01(\x01R\n" +
+	"confidence\x12'\n" +
+	"\x0ftransaction_ids\x18\f \x03(\tR\x0etransactionIds\"[\n" +
+	"\x17DetectRecurringResponse\x12@\n" +
+	"\trecurring\x18\x01 \x03(\v2\".transactions.RecurringTransactionR\trecurring\"\xa0\x01\n" +
+	"\x18GetSpendingReportRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06period\x18\x02 \x01(\tR\x06period\x12.\n" +
+	"\x04date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x04date\x12#\n" +
+	"\rbase_currency\x18\x04 \x01(\tR\fbaseCurrency\"\xd4\x01\n" +
+	"\x13CategorySpendChange\x12\x1a\n" +
+	"\bcategory\x18\x01 \x01(\tR\bcategory\x12\x18\n" +
+	"\acurrent\x18\x02 \x01(\x01R\acurrent\x12\x1a\n" +
+	"\bprevious\x18\x03 \x01(\x01R\bprevious\x12\x16\n" +
+	"\x06change\x18\x04 \x01(\x01R\x06change\x12%\n" +
+	"\x0epercent_change\x18\x05 \x01(\x01R\rpercentChange\x12,\n" +
+	"\x12has_percent_change\x18\x06 \x01(\bR\x10hasPercentChange\"\xa9\b\n" +
+	"\x0eSpendingReport\x12\x16\n" +
+	"\x06period\x18\x01 \x01(\tR\x06period\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency\x129\n" +
+	"\n" +
+	"start_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12J\n" +
+	"\x13previous_start_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x11previousStartDate\x12F\n" +
+	"\x11previous_end_date\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x0fpreviousEndDate\x12\x1f\n" +
+	"\vtotal_spent\x18\a \x01(\x01R\n" +
+	"totalSpent\x120\n" +
+	"\x14previous_total_spent\x18\b \x01(\x01R\x12previousTotalSpent\x12%\n" +
+	"\x0epercent_change\x18\t \x01(\x01R\rpercentChange\x12,\n" +
+	"\x12has_percent_change\x18\n" +
+	" \x01(\bR\x10hasPercentChange\x12\x16\n" +
+	"\x06income\x18\v \x01(\x01R\x06income\x12'\n" +
+	"\x0fprevious_income\x18\f \x01(\x01R\x0epreviousIncome\x12!\n" +
+	"\fsavings_rate\x18\r \x01(\x01R\vsavingsRate\x12(\n" +
+	"\x10has_savings_rate\x18\x0e \x01(\bR\x0ehasSavingsRate\x122\n" +
+	"\x15previous_savings_rate\x18\x0f \x01(\x01R\x13previousSavingsRate\x129\n" +
+	"\x19has_previous_savings_rate\x18\x10 \x01(\bR\x16hasPreviousSavingsRate\x12.\n" +
+	"\x13savings_rate_change\x18\x11 \x01(\x01R\x11savingsRateChange\x125\n" +
+	"\x17has_savings_rate_change\x18\x12 \x01(\bR\x14hasSavingsRateChange\x12A\n" +
+	"\n" +
+	"categories\x18\x13 \x03(\v2!.transactions.CategorySpendChangeR\n" +
+	"categories\x12F\n" +
+	"\rtop_increases\x18\x14 \x03(\v2!.transactions.CategorySpendChangeR\ftopIncreases\x12F\n" +
+	"\rtop_decreases\x18\x15 \x03(\v2!.transactions.CategorySpendChangeR\ftopDecreases\"\xcb\x01\n" +
+	"\x19GetMonthlyCashFlowRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"start_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12#\n" +
+	"\rbase_currency\x18\x04 \x01(\tR\fbaseCurrency\"\xb6\x01\n" +
+	"\x0eCashFlowBucket\x129\n" +
+	"\n" +
+	"start_date\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\tstartDate\x125\n" +
+	"\bend_date\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\aendDate\x12\x16\n" +
+	"\x06income\x18\x03 \x01(\x01R\x06income\x12\x1a\n" +
+	"\bexpenses\x18\x04 \x01(\x01R\bexpenses\"m\n" +
+	"\x17MonthlyCashFlowResponse\x126\n" +
+	"\abuckets\x18\x01 \x03(\v2\x1c.transactions.CashFlowBucketR\abuckets\x12\x1a\n" +
+	"\bcurrency\x18\x02 \x01(\tR\bcurrency*\x8d\x01\n" +
+	"\x0fTransactionType\x12 \n" +
+	"\x1cTRANSACTION_TYPE_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TRANSACTION_TYPE_INCOME\x10\x01\x12\x1c\n" +
+	"\x18TRANSACTION_TYPE_EXPENSE\x10\x02\x12\x1d\n" +
+	"\x19TRANSACTION_TYPE_TRANSFER\x10\x03*\xed\x04\n" +
+	"\x13TransactionCategory\x12$\n" +
+	" TRANSACTION_CATEGORY_UNSPECIFIED\x10\x00\x12\x1f\n" +
+	"\x1bTRANSACTION_CATEGORY_SALARY\x10\x01\x12*\n" +
+	"&TRANSACTION_CATEGORY_INVESTMENT_INCOME\x10\x02\x12\x1d\n" +
+	"\x19TRANSACTION_CATEGORY_FOOD\x10\x03\x12\"\n" +
+	"\x1eTRANSACTION_CATEGORY_TRANSPORT\x10\x04\x12\"\n" +
+	"\x1eTRANSACTION_CATEGORY_UTILITIES\x10\x05\x12&\n" +
+	"\"TRANSACTION_CATEGORY_ENTERTAINMENT\x10\x06\x12!\n" +
+	"\x1dTRANSACTION_CATEGORY_SHOPPING\x10\a\x12#\n" +
+	"\x1fTRANSACTION_CATEGORY_HEALTHCARE\x10\b\x12\"\n" +
+	"\x1eTRANSACTION_CATEGORY_EDUCATION\x10\t\x12\x1f\n" +
+	"\x1bTRANSACTION_CATEGORY_TRAVEL\x10\n" +
+	"\x12 \n" +
+	"\x1cTRANSACTION_CATEGORY_HOUSING\x10\v\x12\"\n" +
+	"\x1eTRANSACTION_CATEGORY_INSURANCE\x10\f\x12\x1e\n" +
+	"\x1aTRANSACTION_CATEGORY_TAXES\x10\r\x12\x1e\n" +
+	"\x1aTRANSACTION_CATEGORY_GIFTS\x10\x0e\x12!\n" +
+	"\x1dTRANSACTION_CATEGORY_TRANSFER\x10\x0f\x12\x1e\n" +
+	"\x1aTRANSACTION_CATEGORY_OTHER\x10\x102\x8b\n" +
+	"\n" +
 	"\x13TransactionsService\x12V\n" +
 	"\x11CreateTransaction\x12&.transactions.CreateTransactionRequest\x1a\x19.transactions.Transaction\x12P\n" +
 	"\x0eGetTransaction\x12#.transactions.GetTransactionRequest\x1a\x19.transactions.Transaction\x12a\n" +
@@ -1480,7 +2403,10 @@ const file_proto_transactions_proto_rawDesc = "" +
 	"\x19GetTransactionsByCategory\x12..transactions.GetTransactionsByCategoryRequest\x1a&.transactions.ListTransactionsResponse\x12p\n" +
 	"\x16GetTransactionsSummary\x12+.transactions.GetTransactionsSummaryRequest\x1a).transactions.TransactionsSummaryResponse\x12^\n" +
 	"\x15CategorizeTransaction\x12*.transactions.CategorizeTransactionRequest\x1a\x19.transactions.Transaction\x12[\n" +
-	"\x0eBulkCategorize\x12#.transactions.BulkCategorizeRequest\x1a$.transactions.BulkCategorizeResponseB?Z=github.com/radmickey/money-control/backend/proto/transactionsb\x06proto3"
+	"\x0eBulkCategorize\x12#.transactions.BulkCategorizeRequest\x1a$.transactions.BulkCategorizeResponse\x12^\n" +
+	"\x0fDetectRecurring\x12$.transactions.DetectRecurringRequest\x1a%.transactions.DetectRecurringResponse\x12Y\n" +
+	"\x11GetSpendingReport\x12&.transactions.GetSpendingReportRequest\x1a\x1c.transactions.SpendingReport\x12d\n" +
+	"\x12GetMonthlyCashFlow\x12'.transactions.GetMonthlyCashFlowRequest\x1a%.transactions.MonthlyCashFlowResponseB?Z=github.com/radmickey/money-control/backend/proto/transactionsb\x06proto3"
 
 var (
 	file_proto_transactions_proto_rawDescOnce sync.Once
@@ -1495,7 +2421,7 @@ func file_proto_transactions_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_transactions_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_transactions_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_transactions_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_proto_transactions_proto_goTypes = []any{
 	(TransactionType)(0),                      // 0: transactions.TransactionType
 	(TransactionCategory)(0),                  // 1: transactions.TransactionCategory
@@ -1513,62 +2439,95 @@ var file_proto_transactions_proto_goTypes = []any{
 	(*CategorizeTransactionRequest)(nil),      // 13: transactions.CategorizeTransactionRequest
 	(*BulkCategorizeRequest)(nil),             // 14: transactions.BulkCategorizeRequest
 	(*BulkCategorizeResponse)(nil),            // 15: transactions.BulkCategorizeResponse
-	nil,                                       // 16: transactions.Transaction.MetadataEntry
-	nil,                                       // 17: transactions.CreateTransactionRequest.MetadataEntry
-	nil,                                       // 18: transactions.TransactionsSummaryResponse.ByCategoryEntry
-	(*timestamppb.Timestamp)(nil),             // 19: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),                     // 20: google.protobuf.Empty
+	(*DetectRecurringRequest)(nil),            // 16: transactions.DetectRecurringRequest
+	(*RecurringTransaction)(nil),              // 17: transactions.RecurringTransaction
+	(*DetectRecurringResponse)(nil),           // 18: transactions.DetectRecurringResponse
+	(*GetSpendingReportRequest)(nil),          // 19: transactions.GetSpendingReportRequest
+	(*CategorySpendChange)(nil),               // 20: transactions.CategorySpendChange
+	(*SpendingReport)(nil),                    // 21: transactions.SpendingReport
+	(*GetMonthlyCashFlowRequest)(nil),         // 22: transactions.GetMonthlyCashFlowRequest
+	(*CashFlowBucket)(nil),                    // 23: transactions.CashFlowBucket
+	(*MonthlyCashFlowResponse)(nil),           // 24: transactions.MonthlyCashFlowResponse
+	nil,                                       // 25: transactions.Transaction.MetadataEntry
+	nil,                                       // 26: transactions.CreateTransactionRequest.MetadataEntry
+	nil,                                       // 27: transactions.TransactionsSummaryResponse.ByCategoryEntry
+	(*timestamppb.Timestamp)(nil),             // 28: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                     // 29: google.protobuf.Empty
 }
 var file_proto_transactions_proto_depIdxs = []int32{
 	0,  // 0: transactions.Transaction.type:type_name -> transactions.TransactionType
 	1,  // 1: transactions.Transaction.category:type_name -> transactions.TransactionCategory
-	19, // 2: transactions.Transaction.date:type_name -> google.protobuf.Timestamp
-	19, // 3: transactions.Transaction.created_at:type_name -> google.protobuf.Timestamp
-	19, // 4: transactions.Transaction.updated_at:type_name -> google.protobuf.Timestamp
-	16, // 5: transactions.Transaction.metadata:type_name -> transactions.Transaction.MetadataEntry
+	28, // 2: transactions.Transaction.date:type_name -> google.protobuf.Timestamp
+	28, // 3: transactions.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	28, // 4: transactions.Transaction.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 5: transactions.Transaction.metadata:type_name -> transactions.Transaction.MetadataEntry
 	0,  // 6: transactions.CreateTransactionRequest.type:type_name -> transactions.TransactionType
 	1,  // 7: transactions.CreateTransactionRequest.category:type_name -> transactions.TransactionCategory
-	19, // 8: transactions.CreateTransactionRequest.date:type_name -> google.protobuf.Timestamp
-	17, // 9: transactions.CreateTransactionRequest.metadata:type_name -> transactions.CreateTransactionRequest.MetadataEntry
+	28, // 8: transactions.CreateTransactionRequest.date:type_name -> google.protobuf.Timestamp
+	26, // 9: transactions.CreateTransactionRequest.metadata:type_name -> transactions.CreateTransactionRequest.MetadataEntry
 	2,  // 10: transactions.ListTransactionsResponse.transactions:type_name -> transactions.Transaction
 	1,  // 11: transactions.UpdateTransactionRequest.category:type_name -> transactions.TransactionCategory
-	19, // 12: transactions.UpdateTransactionRequest.date:type_name -> google.protobuf.Timestamp
+	28, // 12: transactions.UpdateTransactionRequest.date:type_name -> google.protobuf.Timestamp
 	0,  // 13: transactions.UpdateTransactionRequest.type:type_name -> transactions.TransactionType
-	19, // 14: transactions.GetTransactionsByDateRangeRequest.start_date:type_name -> google.protobuf.Timestamp
-	19, // 15: transactions.GetTransactionsByDateRangeRequest.end_date:type_name -> google.protobuf.Timestamp
+	28, // 14: transactions.GetTransactionsByDateRangeRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 15: transactions.GetTransactionsByDateRangeRequest.end_date:type_name -> google.protobuf.Timestamp
 	1,  // 16: transactions.GetTransactionsByCategoryRequest.category:type_name -> transactions.TransactionCategory
-	19, // 17: transactions.GetTransactionsByCategoryRequest.start_date:type_name -> google.protobuf.Timestamp
-	19, // 18: transactions.GetTransactionsByCategoryRequest.end_date:type_name -> google.protobuf.Timestamp
-	19, // 19: transactions.GetTransactionsSummaryRequest.start_date:type_name -> google.protobuf.Timestamp
-	19, // 20: transactions.GetTransactionsSummaryRequest.end_date:type_name -> google.protobuf.Timestamp
-	18, // 21: transactions.TransactionsSummaryResponse.by_category:type_name -> transactions.TransactionsSummaryResponse.ByCategoryEntry
+	28, // 17: transactions.GetTransactionsByCategoryRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 18: transactions.GetTransactionsByCategoryRequest.end_date:type_name -> google.protobuf.Timestamp
+	28, // 19: transactions.GetTransactionsSummaryRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 20: transactions.GetTransactionsSummaryRequest.end_date:type_name -> google.protobuf.Timestamp
+	27, // 21: transactions.TransactionsSummaryResponse.by_category:type_name -> transactions.TransactionsSummaryResponse.ByCategoryEntry
 	1,  // 22: transactions.CategorizeTransactionRequest.category:type_name -> transactions.TransactionCategory
 	1,  // 23: transactions.BulkCategorizeRequest.category:type_name -> transactions.TransactionCategory
-	3,  // 24: transactions.TransactionsService.CreateTransaction:input_type -> transactions.CreateTransactionRequest
-	4,  // 25: transactions.TransactionsService.GetTransaction:input_type -> transactions.GetTransactionRequest
-	5,  // 26: transactions.TransactionsService.ListTransactions:input_type -> transactions.ListTransactionsRequest
-	7,  // 27: transactions.TransactionsService.UpdateTransaction:input_type -> transactions.UpdateTransactionRequest
-	8,  // 28: transactions.TransactionsService.DeleteTransaction:input_type -> transactions.DeleteTransactionRequest
-	9,  // 29: transactions.TransactionsService.GetTransactionsByDateRange:input_type -> transactions.GetTransactionsByDateRangeRequest
-	10, // 30: transactions.TransactionsService.GetTransactionsByCategory:input_type -> transactions.GetTransactionsByCategoryRequest
-	11, // 31: transactions.TransactionsService.GetTransactionsSummary:input_type -> transactions.GetTransactionsSummaryRequest
-	13, // 32: transactions.TransactionsService.CategorizeTransaction:input_type -> transactions.CategorizeTransactionRequest
-	14, // 33: transactions.TransactionsService.BulkCategorize:input_type -> transactions.BulkCategorizeRequest
-	2,  // 34: transactions.TransactionsService.CreateTransaction:output_type -> transactions.Transaction
-	2,  // 35: transactions.TransactionsService.GetTransaction:output_type -> transactions.Transaction
-	6,  // 36: transactions.TransactionsService.ListTransactions:output_type -> transactions.ListTransactionsResponse
-	2,  // 37: transactions.TransactionsService.UpdateTransaction:output_type -> transactions.Transaction
-	20, // 38: transactions.TransactionsService.DeleteTransaction:output_type -> google.protobuf.Empty
-	6,  // 39: transactions.TransactionsService.GetTransactionsByDateRange:output_type -> transactions.ListTransactionsResponse
-	6,  // 40: transactions.TransactionsService.GetTransactionsByCategory:output_type -> transactions.ListTransactionsResponse
-	12, // 41: transactions.TransactionsService.GetTransactionsSummary:output_type -> transactions.TransactionsSummaryResponse
-	2,  // 42: transactions.TransactionsService.CategorizeTransaction:output_type -> transactions.Transaction
-	15, // 43: transactions.TransactionsService.BulkCategorize:output_type -> transactions.BulkCategorizeResponse
-	34, // [34:44] is the sub-list for method output_type
-	24, // [24:34] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	1,  // 24: transactions.RecurringTransaction.category:type_name -> transactions.TransactionCategory
+	0,  // 25: transactions.RecurringTransaction.type:type_name -> transactions.TransactionType
+	28, // 26: transactions.RecurringTransaction.last_date:type_name -> google.protobuf.Timestamp
+	28, // 27: transactions.RecurringTransaction.next_date:type_name -> google.protobuf.Timestamp
+	17, // 28: transactions.DetectRecurringResponse.recurring:type_name -> transactions.RecurringTransaction
+	28, // 29: transactions.GetSpendingReportRequest.date:type_name -> google.protobuf.Timestamp
+	28, // 30: transactions.SpendingReport.start_date:type_name -> google.protobuf.Timestamp
+	28, // 31: transactions.SpendingReport.end_date:type_name -> google.protobuf.Timestamp
+	28, // 32: transactions.SpendingReport.previous_start_date:type_name -> google.protobuf.Timestamp
+	28, // 33: transactions.SpendingReport.previous_end_date:type_name -> google.protobuf.Timestamp
+	20, // 34: transactions.SpendingReport.categories:type_name -> transactions.CategorySpendChange
+	20, // 35: transactions.SpendingReport.top_increases:type_name -> transactions.CategorySpendChange
+	20, // 36: transactions.SpendingReport.top_decreases:type_name -> transactions.CategorySpendChange
+	28, // 37: transactions.GetMonthlyCashFlowRequest.start_date:type_name -> google.protobuf.Timestamp
+	28, // 38: transactions.GetMonthlyCashFlowRequest.end_date:type_name -> google.protobuf.Timestamp
+	28, // 39: transactions.CashFlowBucket.start_date:type_name -> google.protobuf.Timestamp
+	28, // 40: transactions.CashFlowBucket.end_date:type_name -> google.protobuf.Timestamp
+	23, // 41: transactions.MonthlyCashFlowResponse.buckets:type_name -> transactions.CashFlowBucket
+	3,  // 42: transactions.TransactionsService.CreateTransaction:input_type -> transactions.CreateTransactionRequest
+	4,  // 43: transactions.TransactionsService.GetTransaction:input_type -> transactions.GetTransactionRequest
+	5,  // 44: transactions.TransactionsService.ListTransactions:input_type -> transactions.ListTransactionsRequest
+	7,  // 45: transactions.TransactionsService.UpdateTransaction:input_type -> transactions.UpdateTransactionRequest
+	8,  // 46: transactions.TransactionsService.DeleteTransaction:input_type -> transactions.DeleteTransactionRequest
+	9,  // 47: transactions.TransactionsService.GetTransactionsByDateRange:input_type -> transactions.GetTransactionsByDateRangeRequest
+	10, // 48: transactions.TransactionsService.GetTransactionsByCategory:input_type -> transactions.GetTransactionsByCategoryRequest
+	11, // 49: transactions.TransactionsService.GetTransactionsSummary:input_type -> transactions.GetTransactionsSummaryRequest
+	13, // 50: transactions.TransactionsService.CategorizeTransaction:input_type -> transactions.CategorizeTransactionRequest
+	14, // 51: transactions.TransactionsService.BulkCategorize:input_type -> transactions.BulkCategorizeRequest
+	16, // 52: transactions.TransactionsService.DetectRecurring:input_type -> transactions.DetectRecurringRequest
+	19, // 53: transactions.TransactionsService.GetSpendingReport:input_type -> transactions.GetSpendingReportRequest
+	22, // 54: transactions.TransactionsService.GetMonthlyCashFlow:input_type -> transactions.GetMonthlyCashFlowRequest
+	2,  // 55: transactions.TransactionsService.CreateTransaction:output_type -> transactions.Transaction
+	2,  // 56: transactions.TransactionsService.GetTransaction:output_type -> transactions.Transaction
+	6,  // 57: transactions.TransactionsService.ListTransactions:output_type -> transactions.ListTransactionsResponse
+	2,  // 58: transactions.TransactionsService.UpdateTransaction:output_type -> transactions.Transaction
+	29, // 59: transactions.TransactionsService.DeleteTransaction:output_type -> google.protobuf.Empty
+	6,  // 60: transactions.TransactionsService.GetTransactionsByDateRange:output_type -> transactions.ListTransactionsResponse
+	6,  // 61: transactions.TransactionsService.GetTransactionsByCategory:output_type -> transactions.ListTransactionsResponse
+	12, // 62: transactions.TransactionsService.GetTransactionsSummary:output_type -> transactions.TransactionsSummaryResponse
+	2,  // 63: transactions.TransactionsService.CategorizeTransaction:output_type -> transactions.Transaction
+	15, // 64: transactions.TransactionsService.BulkCategorize:output_type -> transactions.BulkCategorizeResponse
+	18, // 65: transactions.TransactionsService.DetectRecurring:output_type -> transactions.DetectRecurringResponse
+	21, // 66: transactions.TransactionsService.GetSpendingReport:output_type -> transactions.SpendingReport
+	24, // 67: transactions.TransactionsService.GetMonthlyCashFlow:output_type -> transactions.MonthlyCashFlowResponse
+	55, // [55:68] is the sub-list for method output_type
+	42, // [42:55] is the sub-list for method input_type
+	42, // [42:42] is the sub-list for extension type_name
+	42, // [42:42] is the sub-list for extension extendee
+	0,  // [0:42] is the sub-list for field type_name
 }
 
 func init() { file_proto_transactions_proto_init() }
@@ -1582,7 +2541,7 @@ func file_proto_transactions_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_transactions_proto_rawDesc), len(file_proto_transactions_proto_rawDesc)),
 			NumEnums:      2,
-			NumMessages:   17,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
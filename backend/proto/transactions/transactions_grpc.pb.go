@@ -30,6 +30,9 @@ const (
 	TransactionsService_GetTransactionsSummary_FullMethodName     = "/transactions.TransactionsService/GetTransactionsSummary"
 	TransactionsService_CategorizeTransaction_FullMethodName      = "/transactions.TransactionsService/CategorizeTransaction"
 	TransactionsService_BulkCategorize_FullMethodName             = "/transactions.TransactionsService/BulkCategorize"
+	TransactionsService_DetectRecurring_FullMethodName            = "/transactions.TransactionsService/DetectRecurring"
+	TransactionsService_GetSpendingReport_FullMethodName          = "/transactions.TransactionsService/GetSpendingReport"
+	TransactionsService_GetMonthlyCashFlow_FullMethodName         = "/transactions.TransactionsService/GetMonthlyCashFlow"
 )
 
 // TransactionsServiceClient is the client API for TransactionsService service.
@@ -46,6 +49,9 @@ type TransactionsServiceClient interface {
 	GetTransactionsSummary(ctx context.Context, in *GetTransactionsSummaryRequest, opts ...grpc.CallOption) (*TransactionsSummaryResponse, error)
 	CategorizeTransaction(ctx context.Context, in *CategorizeTransactionRequest, opts ...grpc.CallOption) (*Transaction, error)
 	BulkCategorize(ctx context.Context, in *BulkCategorizeRequest, opts ...grpc.CallOption) (*BulkCategorizeResponse, error)
+	DetectRecurring(ctx context.Context, in *DetectRecurringRequest, opts ...grpc.CallOption) (*DetectRecurringResponse, error)
+	GetSpendingReport(ctx context.Context, in *GetSpendingReportRequest, opts ...grpc.CallOption) (*SpendingReport, error)
+	GetMonthlyCashFlow(ctx context.Context, in *GetMonthlyCashFlowRequest, opts ...grpc.CallOption) (*MonthlyCashFlowResponse, error)
 }
 
 type transactionsServiceClient struct {
@@ -156,6 +162,36 @@ func (c *transactionsServiceClient) BulkCategorize(ctx context.Context, in *Bulk
 	return out, nil
 }
 
+func (c *transactionsServiceClient) DetectRecurring(ctx context.Context, in *DetectRecurringRequest, opts ...grpc.CallOption) (*DetectRecurringResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetectRecurringResponse)
+	err := c.cc.Invoke(ctx, TransactionsService_DetectRecurring_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionsServiceClient) GetSpendingReport(ctx context.Context, in *GetSpendingReportRequest, opts ...grpc.CallOption) (*SpendingReport, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SpendingReport)
+	err := c.cc.Invoke(ctx, TransactionsService_GetSpendingReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *transactionsServiceClient) GetMonthlyCashFlow(ctx context.Context, in *GetMonthlyCashFlowRequest, opts ...grpc.CallOption) (*MonthlyCashFlowResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MonthlyCashFlowResponse)
+	err := c.cc.Invoke(ctx, TransactionsService_GetMonthlyCashFlow_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionsServiceServer is the server API for TransactionsService service.
 // All implementations must embed UnimplementedTransactionsServiceServer
 // for forward compatibility.
@@ -170,6 +206,9 @@ type TransactionsServiceServer interface {
 	GetTransactionsSummary(context.Context, *GetTransactionsSummaryRequest) (*TransactionsSummaryResponse, error)
 	CategorizeTransaction(context.Context, *CategorizeTransactionRequest) (*Transaction, error)
 	BulkCategorize(context.Context, *BulkCategorizeRequest) (*BulkCategorizeResponse, error)
+	DetectRecurring(context.Context, *DetectRecurringRequest) (*DetectRecurringResponse, error)
+	GetSpendingReport(context.Context, *GetSpendingReportRequest) (*SpendingReport, error)
+	GetMonthlyCashFlow(context.Context, *GetMonthlyCashFlowRequest) (*MonthlyCashFlowResponse, error)
 	mustEmbedUnimplementedTransactionsServiceServer()
 }
 
@@ -210,6 +249,15 @@ func (UnimplementedTransactionsServiceServer) CategorizeTransaction(context.Cont
 func (UnimplementedTransactionsServiceServer) BulkCategorize(context.Context, *BulkCategorizeRequest) (*BulkCategorizeResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method BulkCategorize not implemented")
 }
+func (UnimplementedTransactionsServiceServer) DetectRecurring(context.Context, *DetectRecurringRequest) (*DetectRecurringResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DetectRecurring not implemented")
+}
+func (UnimplementedTransactionsServiceServer) GetSpendingReport(context.Context, *GetSpendingReportRequest) (*SpendingReport, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSpendingReport not implemented")
+}
+func (UnimplementedTransactionsServiceServer) GetMonthlyCashFlow(context.Context, *GetMonthlyCashFlowRequest) (*MonthlyCashFlowResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMonthlyCashFlow not implemented")
+}
 func (UnimplementedTransactionsServiceServer) mustEmbedUnimplementedTransactionsServiceServer() {}
 func (UnimplementedTransactionsServiceServer) testEmbeddedByValue()                             {}
 
@@ -411,6 +459,60 @@ func _TransactionsService_BulkCategorize_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionsService_DetectRecurring_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetectRecurringRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionsServiceServer).DetectRecurring(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionsService_DetectRecurring_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionsServiceServer).DetectRecurring(ctx, req.(*DetectRecurringRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionsService_GetSpendingReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSpendingReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionsServiceServer).GetSpendingReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionsService_GetSpendingReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionsServiceServer).GetSpendingReport(ctx, req.(*GetSpendingReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TransactionsService_GetMonthlyCashFlow_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMonthlyCashFlowRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionsServiceServer).GetMonthlyCashFlow(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionsService_GetMonthlyCashFlow_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionsServiceServer).GetMonthlyCashFlow(ctx, req.(*GetMonthlyCashFlowRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionsService_ServiceDesc is the grpc.ServiceDesc for TransactionsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -458,6 +560,18 @@ var TransactionsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "BulkCategorize",
 			Handler:    _TransactionsService_BulkCategorize_Handler,
 		},
+		{
+			MethodName: "DetectRecurring",
+			Handler:    _TransactionsService_DetectRecurring_Handler,
+		},
+		{
+			MethodName: "GetSpendingReport",
+			Handler:    _TransactionsService_GetSpendingReport_Handler,
+		},
+		{
+			MethodName: "GetMonthlyCashFlow",
+			Handler:    _TransactionsService_GetMonthlyCashFlow_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/transactions.proto",
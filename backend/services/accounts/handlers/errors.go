@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	"github.com/radmickey/money-control/backend/services/accounts/repository"
+	"github.com/radmickey/money-control/backend/services/accounts/service"
+)
+
+// errorMap maps the accounts service's errors to HTTP responses
+var errorMap = utils.ErrorMap{
+	{Err: repository.ErrAccountNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Account not found"},
+	{Err: repository.ErrSubAccountNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Sub-account not found"},
+	{Err: repository.ErrConflict, Status: http.StatusConflict, Code: utils.CodeConflict, Message: "Record was modified by another request; reload and retry"},
+	{Err: repository.ErrInvalidOrder, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrTooManyUpdates, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidDateRange, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrCascadeFailed, Status: http.StatusServiceUnavailable, Code: utils.CodeUnavailable, Message: "Could not update the assets held in the account; try again"},
+}
+
+// respondError writes err as an HTTP error response
+func respondError(c *gin.Context, err error) {
+	errorMap.Respond(c, err)
+}
@@ -2,9 +2,12 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	pb "github.com/radmickey/money-control/backend/proto/accounts"
 	"github.com/radmickey/money-control/backend/services/accounts/models"
+	"github.com/radmickey/money-control/backend/services/accounts/repository"
 	"github.com/radmickey/money-control/backend/services/accounts/service"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -34,6 +37,7 @@ func (h *GRPCHandler) CreateAccount(ctx context.Context, req *pb.CreateAccountRe
 		Currency:    req.Currency,
 		Description: req.Description,
 		Icon:        req.Icon,
+		IsLiability: req.IsLiability,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create account: %v", err)
@@ -59,7 +63,7 @@ func (h *GRPCHandler) ListAccounts(ctx context.Context, req *pb.ListAccountsRequ
 	if req.Type != pb.AccountType_ACCOUNT_TYPE_UNSPECIFIED {
 		accountType = protoToAccountType(req.Type)
 	}
-	accounts, total, err := h.accountService.ListAccounts(ctx, req.UserId, accountType, int(req.Page), int(req.PageSize))
+	accounts, total, err := h.accountService.ListAccounts(ctx, req.UserId, accountType, req.IncludeArchived, int(req.Page), int(req.PageSize))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list accounts: %v", err)
 	}
@@ -86,8 +90,12 @@ func (h *GRPCHandler) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRe
 		Description: req.Description,
 		Icon:        req.Icon,
 		Currency:    req.Currency,
+		Version:     req.Version,
 	})
 	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "account was modified by another request")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update account: %v", err)
 	}
 
@@ -98,12 +106,77 @@ func (h *GRPCHandler) UpdateAccount(ctx context.Context, req *pb.UpdateAccountRe
 func (h *GRPCHandler) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*emptypb.Empty, error) {
 	err := h.accountService.DeleteAccount(ctx, req.Id, req.UserId)
 	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+		if errors.Is(err, service.ErrCascadeFailed) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to delete account: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
+// ArchiveAccount archives an account
+func (h *GRPCHandler) ArchiveAccount(ctx context.Context, req *pb.ArchiveAccountRequest) (*pb.Account, error) {
+	account, err := h.accountService.ArchiveAccount(ctx, req.Id, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "account was modified by another request")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to archive account: %v", err)
+	}
+
+	return accountToProto(account), nil
+}
+
+// UnarchiveAccount restores an archived account
+func (h *GRPCHandler) UnarchiveAccount(ctx context.Context, req *pb.ArchiveAccountRequest) (*pb.Account, error) {
+	account, err := h.accountService.UnarchiveAccount(ctx, req.Id, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "account not found")
+		}
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "account was modified by another request")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to unarchive account: %v", err)
+	}
+
+	return accountToProto(account), nil
+}
+
+// UndeleteAccount restores a recently deleted account
+func (h *GRPCHandler) UndeleteAccount(ctx context.Context, req *pb.UndeleteAccountRequest) (*pb.Account, error) {
+	account, err := h.accountService.UndeleteAccount(ctx, req.Id, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "no deleted account to restore")
+		}
+		if errors.Is(err, service.ErrCascadeFailed) {
+			return nil, status.Error(codes.Unavailable, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to undelete account: %v", err)
+	}
+
+	return accountToProto(account), nil
+}
+
+// ReorderAccounts sets the display order of a user's accounts
+func (h *GRPCHandler) ReorderAccounts(ctx context.Context, req *pb.ReorderAccountsRequest) (*emptypb.Empty, error) {
+	if err := h.accountService.ReorderAccounts(ctx, req.UserId, req.Ids); err != nil {
+		if errors.Is(err, repository.ErrInvalidOrder) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reorder accounts: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // CreateSubAccount creates a new sub-account
 func (h *GRPCHandler) CreateSubAccount(ctx context.Context, req *pb.CreateSubAccountRequest) (*pb.SubAccount, error) {
 	subAccount, err := h.accountService.CreateSubAccount(ctx, service.CreateSubAccountInput{
@@ -161,8 +234,12 @@ func (h *GRPCHandler) UpdateSubAccount(ctx context.Context, req *pb.UpdateSubAcc
 		Description: req.Description,
 		Balance:     req.Balance,
 		Quantity:    req.Quantity,
+		Version:     req.Version,
 	})
 	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "sub-account was modified by another request")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update sub-account: %v", err)
 	}
 
@@ -179,28 +256,120 @@ func (h *GRPCHandler) DeleteSubAccount(ctx context.Context, req *pb.DeleteSubAcc
 	return &emptypb.Empty{}, nil
 }
 
+// ReorderSubAccounts sets the display order of an account's sub-accounts
+func (h *GRPCHandler) ReorderSubAccounts(ctx context.Context, req *pb.ReorderSubAccountsRequest) (*emptypb.Empty, error) {
+	if err := h.accountService.ReorderSubAccounts(ctx, req.AccountId, req.UserId, req.Ids); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAccountNotFound):
+			return nil, status.Error(codes.NotFound, "account not found")
+		case errors.Is(err, repository.ErrInvalidOrder):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to reorder sub-accounts: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // UpdateSubAccountBalance updates balance of a sub-account
 func (h *GRPCHandler) UpdateSubAccountBalance(ctx context.Context, req *pb.UpdateSubAccountBalanceRequest) (*pb.SubAccount, error) {
 	subAccount, err := h.accountService.UpdateSubAccountBalance(ctx, req.Id, req.UserId, req.Balance, req.Quantity)
 	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "sub-account was modified by another request")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update sub-account balance: %v", err)
 	}
 	return subAccountToProto(subAccount), nil
 }
 
-// GetUserNetWorth gets total net worth for a user
-func (h *GRPCHandler) GetUserNetWorth(ctx context.Context, req *pb.GetUserNetWorthRequest) (*pb.NetWorthResponse, error) {
-	netWorthByCurrency, err := h.accountService.GetUserNetWorth(ctx, req.UserId)
+// AdjustSubAccountBalance adds a delta to a sub-account balance
+func (h *GRPCHandler) AdjustSubAccountBalance(ctx context.Context, req *pb.AdjustSubAccountBalanceRequest) (*pb.SubAccount, error) {
+	subAccount, err := h.accountService.AdjustSubAccountBalance(ctx, req.Id, req.UserId, req.Delta, req.IdempotencyKey)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get net worth: %v", err)
+		if errors.Is(err, repository.ErrSubAccountNotFound) {
+			return nil, status.Error(codes.NotFound, "sub-account not found")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to adjust sub-account balance: %v", err)
+	}
+	return subAccountToProto(subAccount), nil
+}
+
+// BulkUpdateSubAccountBalances sets many sub-account balances at once
+func (h *GRPCHandler) BulkUpdateSubAccountBalances(ctx context.Context, req *pb.BulkUpdateSubAccountBalancesRequest) (*pb.BulkUpdateSubAccountBalancesResponse, error) {
+	updates := make([]repository.BalanceUpdate, len(req.Updates))
+	for i, u := range req.Updates {
+		updates[i] = repository.BalanceUpdate{
+			ID:       u.Id,
+			Balance:  u.Balance,
+			Quantity: u.Quantity,
+			Version:  u.Version,
+		}
+	}
+
+	results, err := h.accountService.BulkUpdateSubAccountBalances(ctx, req.UserId, updates)
+	if err != nil {
+		if errors.Is(err, service.ErrTooManyUpdates) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to update sub-account balances: %v", err)
 	}
 
-	totalNetWorth := netWorthByCurrency[req.BaseCurrency]
-	if totalNetWorth == 0 {
-		// Sum all if base currency not found
-		for _, v := range netWorthByCurrency {
-			totalNetWorth += v
+	resp := &pb.BulkUpdateSubAccountBalancesResponse{
+		Results: make([]*pb.SubAccountBalanceResult, len(results)),
+	}
+	for i, r := range results {
+		result := &pb.SubAccountBalanceResult{Id: updates[i].ID}
+		if r.Err != nil {
+			result.Error = balanceUpdateError(r.Err)
+			resp.Failed++
+		} else {
+			result.Success = true
+			result.SubAccount = subAccountToProto(r.SubAccount)
+			resp.Updated++
 		}
+		resp.Results[i] = result
+	}
+
+	return resp, nil
+}
+
+// GetSubAccountBalanceHistory gets a sub-account's daily balances for charting
+func (h *GRPCHandler) GetSubAccountBalanceHistory(ctx context.Context, req *pb.GetBalanceHistoryRequest) (*pb.BalanceHistoryResponse, error) {
+	var start, end time.Time
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+
+	points, err := h.accountService.GetSubAccountBalanceHistory(ctx, req.Id, req.UserId, start, end, int(req.Limit))
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrSubAccountNotFound):
+			return nil, status.Error(codes.NotFound, "sub-account not found")
+		case errors.Is(err, service.ErrInvalidDateRange):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get balance history: %v", err)
+	}
+
+	pbPoints := make([]*pb.BalanceHistoryPoint, len(points))
+	for i, p := range points {
+		pbPoints[i] = &pb.BalanceHistoryPoint{
+			Date:    timestamppb.New(p.Date),
+			Balance: p.Balance,
+		}
+	}
+
+	return &pb.BalanceHistoryResponse{Points: pbPoints}, nil
+}
+
+// GetUserNetWorth gets total net worth for a user
+func (h *GRPCHandler) GetUserNetWorth(ctx context.Context, req *pb.GetUserNetWorthRequest) (*pb.NetWorthResponse, error) {
+	netWorth, err := h.accountService.GetUserNetWorth(ctx, req.UserId, req.BaseCurrency)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get net worth: %v", err)
 	}
 
 	byAssetType, err := h.accountService.GetBalanceByAssetType(ctx, req.UserId)
@@ -214,16 +383,21 @@ func (h *GRPCHandler) GetUserNetWorth(ctx context.Context, req *pb.GetUserNetWor
 	}
 
 	return &pb.NetWorthResponse{
-		TotalNetWorth: totalNetWorth,
-		Currency:      req.BaseCurrency,
-		ByAssetType:   pbByAssetType,
-		CalculatedAt:  timestamppb.Now(),
+		TotalNetWorth:         netWorth.Total,
+		Currency:              netWorth.Currency,
+		ByAssetType:           pbByAssetType,
+		CalculatedAt:          timestamppb.Now(),
+		ByCurrency:            netWorth.ByCurrency,
+		ConvertedByCurrency:   netWorth.ConvertedByCurrency,
+		UnconvertedCurrencies: netWorth.Unconverted,
+		TotalAssets:           netWorth.TotalAssets,
+		TotalLiabilities:      netWorth.TotalLiabilities,
 	}, nil
 }
 
 // GetAccountsSummary gets accounts summary
 func (h *GRPCHandler) GetAccountsSummary(ctx context.Context, req *pb.GetAccountsSummaryRequest) (*pb.AccountsSummaryResponse, error) {
-	accounts, totalAccounts, err := h.accountService.ListAccounts(ctx, req.UserId, "", 1, 1000)
+	accounts, totalAccounts, err := h.accountService.ListAccounts(ctx, req.UserId, "", false, 1, 1000)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get accounts summary: %v", err)
 	}
@@ -257,12 +431,29 @@ func (h *GRPCHandler) GetAccountsSummary(ctx context.Context, req *pb.GetAccount
 }
 
 // Helper functions
+
+// balanceUpdateError describes why one update of a bulk balance update failed
+func balanceUpdateError(err error) string {
+	switch {
+	case errors.Is(err, repository.ErrSubAccountNotFound):
+		return "sub-account not found"
+	case errors.Is(err, repository.ErrConflict):
+		return "sub-account was modified by another request"
+	}
+	return err.Error()
+}
+
 func accountToProto(a *models.Account) *pb.Account {
 	pbSubAccounts := make([]*pb.SubAccount, len(a.SubAccounts))
 	for i, sa := range a.SubAccounts {
 		pbSubAccounts[i] = subAccountToProto(&sa)
 	}
 
+	var archivedAt *timestamppb.Timestamp
+	if a.ArchivedAt != nil {
+		archivedAt = timestamppb.New(*a.ArchivedAt)
+	}
+
 	return &pb.Account{
 		Id:          a.ID,
 		UserId:      a.UserID,
@@ -275,6 +466,11 @@ func accountToProto(a *models.Account) *pb.Account {
 		CreatedAt:   timestamppb.New(a.CreatedAt),
 		UpdatedAt:   timestamppb.New(a.UpdatedAt),
 		SubAccounts: pbSubAccounts,
+		Archived:    a.Archived,
+		ArchivedAt:  archivedAt,
+		Version:     a.Version,
+		IsLiability: a.IsLiability,
+		DisplayOrder: int32(a.DisplayOrder),
 	}
 }
 
@@ -291,6 +487,8 @@ func subAccountToProto(s *models.SubAccount) *pb.SubAccount {
 		Description: s.Description,
 		CreatedAt:   timestamppb.New(s.CreatedAt),
 		UpdatedAt:   timestamppb.New(s.UpdatedAt),
+		Version:     s.Version,
+		DisplayOrder: int32(s.DisplayOrder),
 	}
 }
 
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
@@ -19,21 +20,28 @@ func RegisterHTTPRoutes(r *gin.RouterGroup, accountService *service.AccountServi
 	{
 		accounts.POST("", h.CreateAccount)
 		accounts.GET("", h.ListAccounts)
+		accounts.PUT("/reorder", h.ReorderAccounts)
 		accounts.GET("/:id", h.GetAccount)
 		accounts.PUT("/:id", h.UpdateAccount)
 		accounts.DELETE("/:id", h.DeleteAccount)
+		accounts.POST("/:id/archive", h.ArchiveAccount)
+		accounts.POST("/:id/unarchive", h.UnarchiveAccount)
+		accounts.POST("/:id/undelete", h.UndeleteAccount)
 
 		// Sub-accounts
 		accounts.POST("/:id/sub-accounts", h.CreateSubAccount)
 		accounts.GET("/:id/sub-accounts", h.ListSubAccounts)
+		accounts.PUT("/:id/sub-accounts/reorder", h.ReorderSubAccounts)
 	}
 
 	subAccounts := r.Group("/sub-accounts")
 	{
+		subAccounts.POST("/bulk-balance", h.BulkUpdateSubAccountBalances)
 		subAccounts.GET("/:id", h.GetSubAccount)
 		subAccounts.PUT("/:id", h.UpdateSubAccount)
 		subAccounts.DELETE("/:id", h.DeleteSubAccount)
 		subAccounts.PATCH("/:id/balance", h.UpdateSubAccountBalance)
+		subAccounts.GET("/:id/balance-history", h.GetSubAccountBalanceHistory)
 	}
 
 	// Net worth and summary
@@ -53,15 +61,20 @@ type CreateAccountRequest struct {
 	Currency    string `json:"currency"`
 	Description string `json:"description"`
 	Icon        string `json:"icon"`
+	IsLiability bool   `json:"is_liability"`
 }
 
 // CreateAccount creates a new account
 func (h *HTTPHandler) CreateAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req CreateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -72,11 +85,12 @@ func (h *HTTPHandler) CreateAccount(c *gin.Context) {
 		Currency:    req.Currency,
 		Description: req.Description,
 		Icon:        req.Icon,
+		IsLiability: req.IsLiability,
 	}
 
 	account, err := h.accountService.CreateAccount(c.Request.Context(), input)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -85,15 +99,19 @@ func (h *HTTPHandler) CreateAccount(c *gin.Context) {
 
 // ListAccounts lists accounts
 func (h *HTTPHandler) ListAccounts(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	accountType := models.AccountType(c.Query("type"))
 	page := utils.CoalesceInt(parseIntParam(c, "page"), 1)
 	pageSize := utils.CoalesceInt(parseIntParam(c, "page_size"), 20)
 
-	accounts, total, err := h.accountService.ListAccounts(c.Request.Context(), userID, accountType, page, pageSize)
+	accounts, total, err := h.accountService.ListAccounts(c.Request.Context(), userID, accountType, c.Query("include_archived") == "true", page, pageSize)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -102,16 +120,16 @@ func (h *HTTPHandler) ListAccounts(c *gin.Context) {
 
 // GetAccount gets an account by ID
 func (h *HTTPHandler) GetAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	account, err := h.accountService.GetAccount(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrAccountNotFound {
-			utils.NotFound(c, "Account not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -124,16 +142,22 @@ type UpdateAccountRequest struct {
 	Description string `json:"description"`
 	Icon        string `json:"icon"`
 	Currency    string `json:"currency"`
+	// Version is the version last read; a stale one gets 409 Conflict
+	Version int64 `json:"version"`
 }
 
 // UpdateAccount updates an account
 func (h *HTTPHandler) UpdateAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req UpdateAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -144,15 +168,12 @@ func (h *HTTPHandler) UpdateAccount(c *gin.Context) {
 		Description: req.Description,
 		Icon:        req.Icon,
 		Currency:    req.Currency,
+		Version:     req.Version,
 	}
 
 	account, err := h.accountService.UpdateAccount(c.Request.Context(), input)
 	if err != nil {
-		if err == repository.ErrAccountNotFound {
-			utils.NotFound(c, "Account not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -161,20 +182,102 @@ func (h *HTTPHandler) UpdateAccount(c *gin.Context) {
 
 // DeleteAccount deletes an account
 func (h *HTTPHandler) DeleteAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	err := h.accountService.DeleteAccount(c.Request.Context(), id, userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// ReorderRequest lists IDs in their new display order
+type ReorderRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+// ReorderAccounts sets the display order of the user's accounts
+func (h *HTTPHandler) ReorderAccounts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	if err := h.accountService.ReorderAccounts(c.Request.Context(), userID, req.IDs); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// ArchiveAccount archives an account
+func (h *HTTPHandler) ArchiveAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	account, err := h.accountService.ArchiveAccount(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, account)
+}
+
+// UnarchiveAccount restores an archived account
+func (h *HTTPHandler) UnarchiveAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	account, err := h.accountService.UnarchiveAccount(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, account)
+}
+
+// UndeleteAccount restores an account deleted within the restore window
+func (h *HTTPHandler) UndeleteAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	account, err := h.accountService.UndeleteAccount(c.Request.Context(), c.Param("id"), userID)
 	if err != nil {
 		if err == repository.ErrAccountNotFound {
-			utils.NotFound(c, "Account not found")
+			utils.NotFound(c, "No deleted account to restore")
 			return
 		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
-	utils.NoContent(c)
+	utils.Success(c, account)
 }
 
 // CreateSubAccountRequest represents create sub-account request
@@ -190,12 +293,16 @@ type CreateSubAccountRequest struct {
 
 // CreateSubAccount creates a new sub-account
 func (h *HTTPHandler) CreateSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	accountID := c.Param("id")
 
 	var req CreateSubAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -213,11 +320,7 @@ func (h *HTTPHandler) CreateSubAccount(c *gin.Context) {
 
 	subAccount, err := h.accountService.CreateSubAccount(c.Request.Context(), input)
 	if err != nil {
-		if err == repository.ErrAccountNotFound {
-			utils.NotFound(c, "Account not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -226,31 +329,58 @@ func (h *HTTPHandler) CreateSubAccount(c *gin.Context) {
 
 // ListSubAccounts lists sub-accounts for an account
 func (h *HTTPHandler) ListSubAccounts(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	accountID := c.Param("id")
 	assetType := models.AssetType(c.Query("asset_type"))
 
 	subAccounts, err := h.accountService.ListSubAccounts(c.Request.Context(), accountID, userID, assetType)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.Success(c, subAccounts)
 }
 
+// ReorderSubAccounts sets the display order of an account's sub-accounts
+func (h *HTTPHandler) ReorderSubAccounts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	accountID := c.Param("id")
+
+	var req ReorderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	if err := h.accountService.ReorderSubAccounts(c.Request.Context(), accountID, userID, req.IDs); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
 // GetSubAccount gets a sub-account by ID
 func (h *HTTPHandler) GetSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	subAccount, err := h.accountService.GetSubAccount(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrSubAccountNotFound {
-			utils.NotFound(c, "Sub-account not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -263,16 +393,22 @@ type UpdateSubAccountRequest struct {
 	Description string  `json:"description"`
 	Balance     float64 `json:"balance"`
 	Quantity    float64 `json:"quantity"`
+	// Version works as in UpdateAccountRequest
+	Version int64 `json:"version"`
 }
 
 // UpdateSubAccount updates a sub-account
 func (h *HTTPHandler) UpdateSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req UpdateSubAccountRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -283,15 +419,12 @@ func (h *HTTPHandler) UpdateSubAccount(c *gin.Context) {
 		Description: req.Description,
 		Balance:     req.Balance,
 		Quantity:    req.Quantity,
+		Version:     req.Version,
 	}
 
 	subAccount, err := h.accountService.UpdateSubAccount(c.Request.Context(), input)
 	if err != nil {
-		if err == repository.ErrSubAccountNotFound {
-			utils.NotFound(c, "Sub-account not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -300,16 +433,16 @@ func (h *HTTPHandler) UpdateSubAccount(c *gin.Context) {
 
 // DeleteSubAccount deletes a sub-account
 func (h *HTTPHandler) DeleteSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	err := h.accountService.DeleteSubAccount(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrSubAccountNotFound {
-			utils.NotFound(c, "Sub-account not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -324,57 +457,175 @@ type UpdateBalanceRequest struct {
 
 // UpdateSubAccountBalance updates sub-account balance
 func (h *HTTPHandler) UpdateSubAccountBalance(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req UpdateBalanceRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	subAccount, err := h.accountService.UpdateSubAccountBalance(c.Request.Context(), id, userID, req.Balance, req.Quantity)
 	if err != nil {
-		if err == repository.ErrSubAccountNotFound {
-			utils.NotFound(c, "Sub-account not found")
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, subAccount)
+}
+
+// BulkBalanceUpdateRequest represents a bulk sub-account balance update request
+type BulkBalanceUpdateRequest struct {
+	Updates []struct {
+		ID       string  `json:"id" binding:"required"`
+		Balance  float64 `json:"balance"`
+		Quantity float64 `json:"quantity"`
+		Version  int64   `json:"version"`
+	} `json:"updates" binding:"required,dive"`
+}
+
+// BulkBalanceUpdateResult is the outcome of one update in a bulk request
+type BulkBalanceUpdateResult struct {
+	ID         string             `json:"id"`
+	Success    bool               `json:"success"`
+	Error      string             `json:"error,omitempty"`
+	SubAccount *models.SubAccount `json:"sub_account,omitempty"`
+}
+
+// BulkUpdateSubAccountBalances sets many sub-account balances at once
+func (h *HTTPHandler) BulkUpdateSubAccountBalances(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req BulkBalanceUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	updates := make([]repository.BalanceUpdate, len(req.Updates))
+	for i, u := range req.Updates {
+		updates[i] = repository.BalanceUpdate{
+			ID:       u.ID,
+			Balance:  u.Balance,
+			Quantity: u.Quantity,
+			Version:  u.Version,
+		}
+	}
+
+	results, err := h.accountService.BulkUpdateSubAccountBalances(c.Request.Context(), userID, updates)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	var updated, failed int
+	items := make([]BulkBalanceUpdateResult, len(results))
+	for i, r := range results {
+		items[i] = BulkBalanceUpdateResult{ID: updates[i].ID, SubAccount: r.SubAccount}
+		if r.Err != nil {
+			items[i].Error = balanceUpdateError(r.Err)
+			failed++
+		} else {
+			items[i].Success = true
+			updated++
+		}
+	}
+
+	utils.Success(c, gin.H{
+		"results": items,
+		"updated": updated,
+		"failed":  failed,
+	})
+}
+
+// GetSubAccountBalanceHistory gets a sub-account's daily balances for charting
+func (h *HTTPHandler) GetSubAccountBalanceHistory(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	var startDate, endDate time.Time
+	if start := c.Query("start_date"); start != "" {
+		t, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			utils.BadRequest(c, "Invalid start_date format (use YYYY-MM-DD)")
+			return
+		}
+		startDate = t
+	}
+	if end := c.Query("end_date"); end != "" {
+		t, err := time.Parse("2006-01-02", end)
+		if err != nil {
+			utils.BadRequest(c, "Invalid end_date format (use YYYY-MM-DD)")
 			return
 		}
-		utils.InternalError(c, err.Error())
+		endDate = t
+	}
+
+	points, err := h.accountService.GetSubAccountBalanceHistory(c.Request.Context(), id, userID, startDate, endDate, parseIntParam(c, "limit"))
+	if err != nil {
+		respondError(c, err)
 		return
 	}
 
-	utils.Success(c, subAccount)
+	utils.Success(c, points)
 }
 
 // GetNetWorth gets user's net worth
 func (h *HTTPHandler) GetNetWorth(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
-	balances, err := h.accountService.GetUserNetWorth(c.Request.Context(), userID)
+	netWorth, err := h.accountService.GetUserNetWorth(c.Request.Context(), userID, c.Query("currency"))
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	assetBalances, err := h.accountService.GetBalanceByAssetType(c.Request.Context(), userID)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.Success(c, gin.H{
-		"by_currency":   balances,
-		"by_asset_type": assetBalances,
+		"total":                 netWorth.Total,
+		"currency":              netWorth.Currency,
+		"total_assets":          netWorth.TotalAssets,
+		"total_liabilities":     netWorth.TotalLiabilities,
+		"by_currency":           netWorth.ByCurrency,
+		"converted_by_currency": netWorth.ConvertedByCurrency,
+		"unconverted":           netWorth.Unconverted,
+		"by_asset_type":         assetBalances,
 	})
 }
 
 // GetAccountsSummary gets accounts summary
 func (h *HTTPHandler) GetAccountsSummary(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	summary, err := h.accountService.GetAccountsSummary(c.Request.Context(), userID)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -12,15 +12,23 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/auth"
+	pkgcache "github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/events"
+	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
 	pb "github.com/radmickey/money-control/backend/proto/accounts"
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	"github.com/radmickey/money-control/backend/services/accounts/handlers"
 	"github.com/radmickey/money-control/backend/services/accounts/models"
 	"github.com/radmickey/money-control/backend/services/accounts/repository"
 	"github.com/radmickey/money-control/backend/services/accounts/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -30,11 +38,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "accounts-service",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := database.New(database.Config{
-		URL:   cfg.DatabaseURL,
-		Debug: cfg.Debug,
+		URL:             cfg.DatabaseURL,
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		Debug:           cfg.Debug,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -42,7 +66,7 @@ func main() {
 	defer db.Close()
 
 	// Run migrations
-	if err := db.Migrate(&models.Account{}, &models.SubAccount{}, &models.BalanceHistory{}, &models.RecurringIncome{}); err != nil {
+	if err := db.Migrate(&models.Account{}, &models.SubAccount{}, &models.BalanceHistory{}, &models.BalanceAdjustment{}, &models.RecurringIncome{}); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -51,8 +75,57 @@ func main() {
 	subAccountRepo := repository.NewSubAccountRepository(db.DB)
 	balanceHistoryRepo := repository.NewBalanceHistoryRepository(db.DB)
 
+	go cleanupBalanceAdjustments(subAccountRepo)
+
+	// Connect to Assets service so deleting an account removes its assets
+	var assetsClient assetspb.AssetsServiceClient
+	if cfg.AssetsServiceURL != "" {
+		conn, err := grpc.Dial(cfg.AssetsServiceURL,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+			tracing.DialOption(),
+		)
+		if err != nil {
+			log.Printf("Failed to connect to assets service: %v", err)
+		} else {
+			assetsClient = assetspb.NewAssetsServiceClient(conn)
+		}
+	}
+
+	// Connect to Currency service to convert net worth to a base currency
+	var currencyClient currencypb.CurrencyServiceClient
+	if cfg.CurrencyServiceURL != "" {
+		conn, err := grpc.Dial(cfg.CurrencyServiceURL,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+			tracing.DialOption(),
+		)
+		if err != nil {
+			log.Printf("Failed to connect to currency service: %v", err)
+		} else {
+			currencyClient = currencypb.NewCurrencyServiceClient(conn)
+		}
+	}
+
+	// Connect to Redis for publishing change events (optional)
+	var redisCache *pkgcache.Cache
+	if cfg.RedisURL != "" {
+		redisCache, err = pkgcache.New(pkgcache.Config{
+			URL:    cfg.RedisURL,
+			Prefix: "accounts",
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to connect to Redis (change events disabled): %v", err)
+		} else {
+			defer redisCache.Close()
+		}
+	}
+
 	// Initialize service
-	accountService := service.NewAccountService(accountRepo, subAccountRepo, balanceHistoryRepo)
+	accountService := service.NewAccountService(
+		accountRepo, subAccountRepo, balanceHistoryRepo, assetsClient, currencyClient,
+		events.NewPublisher(redisCache, "accounts-service"),
+	)
 
 	// Initialize JWT manager for auth middleware
 	jwtManager := auth.NewJWTManager(
@@ -62,11 +135,19 @@ func main() {
 	)
 
 	// Start gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		middleware.UnaryServerRequestIDInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
 	grpcHandler := handlers.NewGRPCHandler(accountService)
 	pb.RegisterAccountsServiceServer(grpcServer, grpcHandler)
 	reflection.Register(grpcServer)
 
+	// Health checks, served over gRPC here and on /health/details below
+	healthChecker := health.NewHealthChecker("")
+	healthChecker.Register("database", health.DatabasePoolCheck(db.Ping, db.Stats))
+	grpcHealth := health.RegisterGRPCServer(grpcServer, healthChecker)
+
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
@@ -85,19 +166,34 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery())
+	logConfig := middleware.NewLoggingConfig(cfg)
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "accounts"})
 	})
 
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Detailed health check with connection pool stats
+	router.GET("/health/details", func(c *gin.Context) {
+		c.JSON(http.StatusOK, healthChecker.Check(c.Request.Context()))
+	})
+
 	// Protected routes
 	v1 := router.Group("/api/v1")
-	v1.Use(middleware.AuthMiddleware(jwtManager))
+	tokenValidator, err := middleware.NewTokenValidator(cfg, jwtManager, nil)
+	if err != nil {
+		log.Fatalf("Failed to create token validator: %v", err)
+	}
+	v1.Use(middleware.AuthMiddleware(tokenValidator))
 	handlers.RegisterHTTPRoutes(v1, accountService)
 
 	httpServer := &http.Server{
@@ -123,6 +219,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
@@ -131,3 +228,18 @@ func main() {
 	log.Println("Accounts service stopped")
 }
 
+// balanceAdjustmentRetention is how long adjustment idempotency keys are
+// kept; callers stop retrying an adjustment well before then
+const balanceAdjustmentRetention = 30 * 24 * time.Hour
+
+// cleanupBalanceAdjustments periodically forgets old adjustment keys
+func cleanupBalanceAdjustments(subAccountRepo *repository.SubAccountRepository) {
+	ticker := time.NewTicker(1 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := subAccountRepo.DeleteAdjustmentsBefore(context.Background(), time.Now().Add(-balanceAdjustmentRetention)); err != nil {
+			log.Printf("Failed to delete old balance adjustments: %v", err)
+		}
+	}
+}
@@ -32,7 +32,9 @@ const (
 	AssetTypeOther      AssetType = "other"
 )
 
-// Account represents a main financial account
+// Account represents a main financial account. Names aren't unique: a user
+// may keep two accounts with the same name, so there is no unique index that
+// a deleted account could hold on to, and its name is free to reuse.
 type Account struct {
 	ID           string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	UserID       string         `gorm:"type:uuid;not null;index" json:"user_id"`
@@ -44,6 +46,11 @@ type Account struct {
 	Icon         string         `gorm:"size:50" json:"icon,omitempty"`
 	Color        string         `gorm:"size:7" json:"color,omitempty"`
 	IsActive     bool           `gorm:"default:true" json:"is_active"`
+	Archived     bool           `gorm:"default:false;index" json:"archived"`
+	ArchivedAt   *time.Time     `json:"archived_at,omitempty"`
+	IsLiability  bool           `gorm:"default:false" json:"is_liability"` // balances are amounts owed, held as negatives
+	DisplayOrder int            `gorm:"not null;default:0" json:"display_order"`
+	Version      int64          `gorm:"not null;default:1" json:"version"`
 	CreatedAt    time.Time      `json:"created_at"`
 	UpdatedAt    time.Time      `json:"updated_at"`
 	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
@@ -56,22 +63,33 @@ func (Account) TableName() string {
 	return "accounts"
 }
 
+// OwedBalance takes a positive balance entered for a liability account as
+// the amount owed, which liabilities hold as a negative balance
+func (a *Account) OwedBalance(balance float64) float64 {
+	if a.IsLiability && balance > 0 {
+		return -balance
+	}
+	return balance
+}
+
 // SubAccount represents a sub-account within a main account
 type SubAccount struct {
-	ID          string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	AccountID   string         `gorm:"type:uuid;not null;index" json:"account_id"`
-	UserID      string         `gorm:"type:uuid;not null;index" json:"user_id"`
-	Name        string         `gorm:"size:255;not null" json:"name"`
-	AssetType   AssetType      `gorm:"size:50;not null" json:"asset_type"`
-	Currency    string         `gorm:"size:3;not null;default:'USD'" json:"currency"`
-	Balance     float64        `gorm:"type:decimal(20,8);default:0" json:"balance"`
-	Symbol      string         `gorm:"size:20" json:"symbol,omitempty"`
-	Quantity    float64        `gorm:"type:decimal(20,8);default:0" json:"quantity"`
-	Description string         `gorm:"size:500" json:"description,omitempty"`
-	IsActive    bool           `gorm:"default:true" json:"is_active"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID           string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AccountID    string         `gorm:"type:uuid;not null;index" json:"account_id"`
+	UserID       string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name         string         `gorm:"size:255;not null" json:"name"`
+	AssetType    AssetType      `gorm:"size:50;not null" json:"asset_type"`
+	Currency     string         `gorm:"size:3;not null;default:'USD'" json:"currency"`
+	Balance      float64        `gorm:"type:decimal(20,8);default:0" json:"balance"`
+	Symbol       string         `gorm:"size:20" json:"symbol,omitempty"`
+	Quantity     float64        `gorm:"type:decimal(20,8);default:0" json:"quantity"`
+	Description  string         `gorm:"size:500" json:"description,omitempty"`
+	IsActive     bool           `gorm:"default:true" json:"is_active"`
+	DisplayOrder int            `gorm:"not null;default:0" json:"display_order"`
+	Version      int64          `gorm:"not null;default:1" json:"version"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
 
 	Account Account `gorm:"foreignKey:AccountID" json:"-"`
 }
@@ -96,6 +114,20 @@ func (BalanceHistory) TableName() string {
 	return "balance_history"
 }
 
+// BalanceAdjustment records an applied balance adjustment by its
+// idempotency key, so a retried adjustment isn't applied twice
+type BalanceAdjustment struct {
+	Key          string    `gorm:"size:255;primary_key" json:"key"`
+	SubAccountID string    `gorm:"type:uuid;not null;index" json:"sub_account_id"`
+	Delta        float64   `gorm:"type:decimal(20,8);not null" json:"delta"`
+	CreatedAt    time.Time `gorm:"index" json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (BalanceAdjustment) TableName() string {
+	return "balance_adjustments"
+}
+
 // RecurringIncome represents a scheduled recurring income
 type RecurringIncome struct {
 	ID           string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -154,4 +186,3 @@ func (r *RecurringIncome) CalculateNextApplyDate() time.Time {
 
 	return nextDate
 }
-
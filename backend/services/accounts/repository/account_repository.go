@@ -3,18 +3,32 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/radmickey/money-control/backend/pkg/database"
 	"github.com/radmickey/money-control/backend/services/accounts/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
 	ErrAccountNotFound    = errors.New("account not found")
 	ErrSubAccountNotFound = errors.New("sub-account not found")
 	ErrUnauthorized       = errors.New("unauthorized access to resource")
+	ErrInvalidOrder       = errors.New("order must list each of the items being ordered exactly once")
+	// ErrConflict means the row changed since it was loaded
+	ErrConflict = database.ErrConflict
 )
 
+// displayOrder sorts accounts and sub-accounts by their custom order, with
+// ones never ordered (0) first and newest first within the same position
+const displayOrder = "display_order ASC, created_at DESC"
+
+// orderedSubAccounts preloads sub-accounts in display order
+func orderedSubAccounts(db *gorm.DB) *gorm.DB {
+	return db.Order(displayOrder)
+}
+
 // AccountRepository handles database operations for accounts
 type AccountRepository struct {
 	db *gorm.DB
@@ -33,7 +47,7 @@ func (r *AccountRepository) Create(ctx context.Context, account *models.Account)
 // GetByID finds an account by ID
 func (r *AccountRepository) GetByID(ctx context.Context, id, userID string) (*models.Account, error) {
 	var account models.Account
-	if err := r.db.WithContext(ctx).Preload("SubAccounts").Where("id = ? AND user_id = ?", id, userID).First(&account).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("SubAccounts", orderedSubAccounts).Where("id = ? AND user_id = ?", id, userID).First(&account).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrAccountNotFound
 		}
@@ -42,8 +56,9 @@ func (r *AccountRepository) GetByID(ctx context.Context, id, userID string) (*mo
 	return &account, nil
 }
 
-// ListByUserID lists all accounts for a user
-func (r *AccountRepository) ListByUserID(ctx context.Context, userID string, accountType models.AccountType, page, pageSize int) ([]models.Account, int64, error) {
+// ListByUserID lists all accounts for a user, leaving out archived ones
+// unless includeArchived is set
+func (r *AccountRepository) ListByUserID(ctx context.Context, userID string, accountType models.AccountType, includeArchived bool, page, pageSize int) ([]models.Account, int64, error) {
 	var accounts []models.Account
 	var total int64
 
@@ -52,45 +67,154 @@ func (r *AccountRepository) ListByUserID(ctx context.Context, userID string, acc
 	if accountType != "" {
 		query = query.Where("type = ?", accountType)
 	}
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
 
 	if err := query.Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
-	if err := query.Scopes(database.Paginate(page, pageSize)).Preload("SubAccounts").Order("created_at DESC").Find(&accounts).Error; err != nil {
+	if err := query.Scopes(database.Paginate(page, pageSize)).Preload("SubAccounts", orderedSubAccounts).Order(displayOrder).Find(&accounts).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return accounts, total, nil
 }
 
-// Update updates an account
+// Update updates an account if it is still at the version it was loaded at
 func (r *AccountRepository) Update(ctx context.Context, account *models.Account) error {
-	return r.db.WithContext(ctx).Save(account).Error
+	return database.SaveVersioned(r.db.WithContext(ctx), account, &account.Version)
 }
 
-// Delete soft-deletes an account
-func (r *AccountRepository) Delete(ctx context.Context, id, userID string) error {
-	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Account{})
-	if result.RowsAffected == 0 {
-		return ErrAccountNotFound
-	}
-	return result.Error
+// Reorder sets the display order of a user's unarchived accounts to the
+// order of ids, which must list each of them exactly once
+func (r *AccountRepository) Reorder(ctx context.Context, userID string, ids []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setDisplayOrder(tx, &models.Account{}, ids, "user_id = ? AND archived = ?", userID, false)
+	})
+}
+
+// Cascade changes records held outside this database for the sub-accounts
+// of an account being deleted or restored at the deletion time at
+type Cascade func(subAccountIDs []string, at time.Time) error
+
+// Delete soft-deletes an account together with its sub-accounts, all marked
+// with the same deletion time so Restore can bring back exactly this cascade.
+// cascade runs before the deletion commits, and an error from it rolls the
+// deletion back; it may be nil.
+func (r *AccountRepository) Delete(ctx context.Context, id, userID string, cascade Cascade) error {
+	// Postgres keeps microseconds; truncating lets the time be matched later
+	at := time.Now().Truncate(time.Microsecond)
+
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Account{}).Where("id = ? AND user_id = ?", id, userID).Update("deleted_at", at)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrAccountNotFound
+		}
+
+		var subAccountIDs []string
+		if err := tx.Model(&models.SubAccount{}).Where("account_id = ? AND user_id = ?", id, userID).Pluck("id", &subAccountIDs).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.SubAccount{}).Where("account_id = ? AND user_id = ?", id, userID).Update("deleted_at", at).Error; err != nil {
+			return err
+		}
+		if cascade != nil && len(subAccountIDs) > 0 {
+			return cascade(subAccountIDs, at)
+		}
+		return nil
+	})
+}
+
+// Restore undeletes an account deleted no earlier than since, along with the
+// sub-accounts deleted with it. cascade runs before the restore commits, and
+// an error from it rolls the restore back; it may be nil.
+func (r *AccountRepository) Restore(ctx context.Context, id, userID string, since time.Time, cascade Cascade) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var account models.Account
+		if err := tx.Unscoped().
+			Where("id = ? AND user_id = ? AND deleted_at IS NOT NULL AND deleted_at >= ?", id, userID, since).
+			First(&account).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAccountNotFound
+			}
+			return err
+		}
+		at := account.DeletedAt.Time
+
+		var subAccountIDs []string
+		cascadeQuery := tx.Unscoped().Model(&models.SubAccount{}).Where("account_id = ? AND deleted_at = ?", id, at).Session(&gorm.Session{})
+		if err := cascadeQuery.Pluck("id", &subAccountIDs).Error; err != nil {
+			return err
+		}
+		if err := cascadeQuery.Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Model(&account).Update("deleted_at", nil).Error; err != nil {
+			return err
+		}
+		if cascade != nil && len(subAccountIDs) > 0 {
+			return cascade(subAccountIDs, at)
+		}
+		return nil
+	})
 }
 
 // UpdateTotalBalance updates the total balance of an account
 func (r *AccountRepository) UpdateTotalBalance(ctx context.Context, accountID string) error {
-	return r.db.WithContext(ctx).Exec(`
+	return updateTotalBalance(r.db.WithContext(ctx), accountID)
+}
+
+func updateTotalBalance(db *gorm.DB, accountID string) error {
+	return db.Exec(`
 		UPDATE accounts
 		SET total_balance = (
 			SELECT COALESCE(SUM(balance), 0)
 			FROM sub_accounts
 			WHERE account_id = ? AND deleted_at IS NULL
-		)
+		), version = version + 1
 		WHERE id = ?
 	`, accountID, accountID).Error
 }
 
+// setDisplayOrder numbers the rows of model matching the where condition
+// from 1 in the order of ids. The rows are locked first so a concurrent
+// insert or reorder can't slip between the check and the updates.
+func setDisplayOrder(tx *gorm.DB, model interface{}, ids []string, where string, args ...interface{}) error {
+	var existing []string
+	if err := tx.Model(model).Clauses(clause.Locking{Strength: "UPDATE"}).Where(where, args...).Pluck("id", &existing).Error; err != nil {
+		return err
+	}
+	if len(ids) != len(existing) {
+		return ErrInvalidOrder
+	}
+
+	pending := make(map[string]bool, len(existing))
+	for _, id := range existing {
+		pending[id] = true
+	}
+	for _, id := range ids {
+		if !pending[id] {
+			return ErrInvalidOrder
+		}
+		delete(pending, id)
+	}
+
+	for i, id := range ids {
+		if err := tx.Model(model).Where("id = ?", id).Updates(map[string]interface{}{
+			"display_order": i + 1,
+			"version":       gorm.Expr("version + 1"),
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SubAccountRepository handles database operations for sub-accounts
 type SubAccountRepository struct {
 	db *gorm.DB
@@ -121,7 +245,7 @@ func (r *SubAccountRepository) GetByID(ctx context.Context, id, userID string) (
 // ListByAccountID lists all sub-accounts for an account
 func (r *SubAccountRepository) ListByAccountID(ctx context.Context, accountID, userID string) ([]models.SubAccount, error) {
 	var subAccounts []models.SubAccount
-	if err := r.db.WithContext(ctx).Where("account_id = ? AND user_id = ?", accountID, userID).Order("created_at DESC").Find(&subAccounts).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("account_id = ? AND user_id = ?", accountID, userID).Order(displayOrder).Find(&subAccounts).Error; err != nil {
 		return nil, err
 	}
 	return subAccounts, nil
@@ -136,15 +260,105 @@ func (r *SubAccountRepository) ListByUserID(ctx context.Context, userID string,
 		query = query.Where("asset_type = ?", assetType)
 	}
 
-	if err := query.Order("created_at DESC").Find(&subAccounts).Error; err != nil {
+	if err := query.Order(displayOrder).Find(&subAccounts).Error; err != nil {
 		return nil, err
 	}
 	return subAccounts, nil
 }
 
-// Update updates a sub-account
+// Update updates a sub-account if it is still at the version it was loaded at
 func (r *SubAccountRepository) Update(ctx context.Context, subAccount *models.SubAccount) error {
-	return r.db.WithContext(ctx).Save(subAccount).Error
+	return database.SaveVersioned(r.db.WithContext(ctx), subAccount, &subAccount.Version)
+}
+
+// Reorder sets the display order of an account's sub-accounts to the order
+// of ids, which must list each of them exactly once
+func (r *SubAccountRepository) Reorder(ctx context.Context, accountID, userID string, ids []string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return setDisplayOrder(tx, &models.SubAccount{}, ids, "account_id = ? AND user_id = ?", accountID, userID)
+	})
+}
+
+// BalanceUpdate sets a sub-account's balance, and its quantity when non-zero
+type BalanceUpdate struct {
+	ID       string
+	Balance  float64
+	Quantity float64
+	// Version is the version the client last read; zero skips the check
+	Version int64
+}
+
+// BalanceUpdateResult is the outcome of one BalanceUpdate: the updated
+// sub-account, or why it wasn't updated
+type BalanceUpdateResult struct {
+	SubAccount *models.SubAccount
+	Err        error
+}
+
+// BulkUpdateBalances applies balance updates to a user's sub-accounts in one
+// transaction. Each update runs in its own savepoint, so one that fails is
+// rolled back and reported in its result without undoing the others. Balance
+// history is recorded for each change, and the total of each affected
+// account is recomputed once at the end. A positive balance for a liability
+// is stored as the amount owed, as Account.OwedBalance does.
+func (r *SubAccountRepository) BulkUpdateBalances(ctx context.Context, userID string, updates []BalanceUpdate) ([]BalanceUpdateResult, error) {
+	results := make([]BalanceUpdateResult, len(updates))
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		accountIDs := make(map[string]bool)
+		now := time.Now()
+
+		for i, update := range updates {
+			var subAccount models.SubAccount
+			err := tx.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Preload("Account").
+					Where("id = ? AND user_id = ?", update.ID, userID).First(&subAccount).Error; err != nil {
+					if errors.Is(err, gorm.ErrRecordNotFound) {
+						return ErrSubAccountNotFound
+					}
+					return err
+				}
+				if update.Version != 0 && update.Version != subAccount.Version {
+					return ErrConflict
+				}
+
+				oldBalance := subAccount.Balance
+				subAccount.Balance = subAccount.Account.OwedBalance(update.Balance)
+				if update.Quantity > 0 {
+					subAccount.Quantity = update.Quantity
+				}
+				if err := database.SaveVersioned(tx, &subAccount, &subAccount.Version); err != nil {
+					return err
+				}
+
+				if subAccount.Balance == oldBalance {
+					return nil
+				}
+				return tx.Create(&models.BalanceHistory{
+					SubAccountID: subAccount.ID,
+					UserID:       userID,
+					Balance:      subAccount.Balance,
+					Date:         now,
+				}).Error
+			})
+			if err != nil {
+				results[i].Err = err
+				continue
+			}
+			results[i].SubAccount = &subAccount
+			accountIDs[subAccount.AccountID] = true
+		}
+
+		for accountID := range accountIDs {
+			if err := updateTotalBalance(tx, accountID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
 }
 
 // Delete soft-deletes a sub-account
@@ -160,6 +374,7 @@ func (r *SubAccountRepository) Delete(ctx context.Context, id, userID string) er
 func (r *SubAccountRepository) UpdateBalance(ctx context.Context, id string, balance, quantity float64) error {
 	updates := map[string]interface{}{
 		"balance": balance,
+		"version": gorm.Expr("version + 1"),
 	}
 	if quantity > 0 {
 		updates["quantity"] = quantity
@@ -167,8 +382,78 @@ func (r *SubAccountRepository) UpdateBalance(ctx context.Context, id string, bal
 	return r.db.WithContext(ctx).Model(&models.SubAccount{}).Where("id = ?", id).Updates(updates).Error
 }
 
-// GetTotalBalanceByUser gets total balance across all sub-accounts for a user
-func (r *SubAccountRepository) GetTotalBalanceByUser(ctx context.Context, userID string) (map[string]float64, error) {
+// AdjustBalance adds delta to a sub-account's balance atomically and returns
+// the updated sub-account. With an idempotency key, an adjustment already
+// applied under that key is skipped, and it reports false.
+func (r *SubAccountRepository) AdjustBalance(ctx context.Context, id, userID string, delta float64, idempotencyKey string) (*models.SubAccount, bool, error) {
+	var subAccount models.SubAccount
+	applied := true
+	err := r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if idempotencyKey != "" {
+			// The key is recorded in the same transaction as the change, so
+			// a retry finds it exactly when the change was committed
+			result := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.BalanceAdjustment{
+				Key:          idempotencyKey,
+				SubAccountID: id,
+				Delta:        delta,
+			})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				applied = false
+				return db.Where("id = ? AND user_id = ?", id, userID).First(&subAccount).Error
+			}
+		}
+
+		result := db.Model(&models.SubAccount{}).
+			Where("id = ? AND user_id = ?", id, userID).
+			Updates(map[string]interface{}{
+				"balance": gorm.Expr("balance + ?", delta),
+				"version": gorm.Expr("version + 1"),
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrSubAccountNotFound
+		}
+		return db.Where("id = ?", id).First(&subAccount).Error
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, ErrSubAccountNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return &subAccount, applied, nil
+}
+
+// DeleteAdjustmentsBefore forgets idempotency keys of adjustments applied
+// before cutoff, which can no longer be retried
+func (r *SubAccountRepository) DeleteAdjustmentsBefore(ctx context.Context, cutoff time.Time) error {
+	return r.db.WithContext(ctx).Where("created_at < ?", cutoff).Delete(&models.BalanceAdjustment{}).Error
+}
+
+// activeAccountIDs is a subquery for the IDs of a user's accounts that count
+// toward net worth: not archived and not deleted
+func (r *SubAccountRepository) activeAccountIDs(ctx context.Context, userID string) *gorm.DB {
+	return r.db.WithContext(ctx).Model(&models.Account{}).Select("id").Where("user_id = ? AND archived = ?", userID, false)
+}
+
+// GetTotalBalanceByUser gets the total balance per currency across a user's
+// sub-accounts, separately for asset and liability accounts
+func (r *SubAccountRepository) GetTotalBalanceByUser(ctx context.Context, userID string) (assets, liabilities map[string]float64, err error) {
+	if assets, err = r.balanceByCurrency(ctx, userID, false); err != nil {
+		return nil, nil, err
+	}
+	if liabilities, err = r.balanceByCurrency(ctx, userID, true); err != nil {
+		return nil, nil, err
+	}
+	return assets, liabilities, nil
+}
+
+func (r *SubAccountRepository) balanceByCurrency(ctx context.Context, userID string, liability bool) (map[string]float64, error) {
 	type Result struct {
 		Currency string
 		Total    float64
@@ -178,6 +463,7 @@ func (r *SubAccountRepository) GetTotalBalanceByUser(ctx context.Context, userID
 	if err := r.db.WithContext(ctx).Model(&models.SubAccount{}).
 		Select("currency, SUM(balance) as total").
 		Where("user_id = ?", userID).
+		Where("account_id IN (?)", r.activeAccountIDs(ctx, userID).Where("is_liability = ?", liability)).
 		Group("currency").
 		Scan(&results).Error; err != nil {
 		return nil, err
@@ -190,7 +476,8 @@ func (r *SubAccountRepository) GetTotalBalanceByUser(ctx context.Context, userID
 	return balances, nil
 }
 
-// GetBalanceByAssetType gets total balance grouped by asset type
+// GetBalanceByAssetType gets total balance of asset accounts grouped by
+// asset type; liabilities are left out
 func (r *SubAccountRepository) GetBalanceByAssetType(ctx context.Context, userID string) (map[models.AssetType]float64, error) {
 	type Result struct {
 		AssetType models.AssetType
@@ -201,6 +488,7 @@ func (r *SubAccountRepository) GetBalanceByAssetType(ctx context.Context, userID
 	if err := r.db.WithContext(ctx).Model(&models.SubAccount{}).
 		Select("asset_type, SUM(balance) as total").
 		Where("user_id = ?", userID).
+		Where("account_id IN (?)", r.activeAccountIDs(ctx, userID).Where("is_liability = ?", false)).
 		Group("asset_type").
 		Scan(&results).Error; err != nil {
 		return nil, err
@@ -228,10 +516,17 @@ func (r *BalanceHistoryRepository) Create(ctx context.Context, history *models.B
 	return r.db.WithContext(ctx).Create(history).Error
 }
 
-// GetBySubAccountID gets balance history for a sub-account
-func (r *BalanceHistoryRepository) GetBySubAccountID(ctx context.Context, subAccountID, userID string, limit int) ([]models.BalanceHistory, error) {
+// GetBySubAccountID gets balance history for a sub-account, newest first.
+// Zero start or end times leave that side of the range open.
+func (r *BalanceHistoryRepository) GetBySubAccountID(ctx context.Context, subAccountID, userID string, start, end time.Time, limit int) ([]models.BalanceHistory, error) {
 	var history []models.BalanceHistory
-	query := r.db.WithContext(ctx).Where("sub_account_id = ? AND user_id = ?", subAccountID, userID).Order("date DESC")
+	query := r.db.WithContext(ctx).Where("sub_account_id = ? AND user_id = ?", subAccountID, userID).Order("date DESC, created_at DESC")
+	if !start.IsZero() {
+		query = query.Where("date >= ?", start)
+	}
+	if !end.IsZero() {
+		query = query.Where("date <= ?", end)
+	}
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -240,4 +535,3 @@ func (r *BalanceHistoryRepository) GetBySubAccountID(ctx context.Context, subAcc
 	}
 	return history, nil
 }
-
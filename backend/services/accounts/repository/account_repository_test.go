@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	"github.com/radmickey/money-control/backend/services/accounts/models"
+)
+
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+func newTestSubAccount(t *testing.T, accountRepo *AccountRepository, subRepo *SubAccountRepository) *models.SubAccount {
+	t.Helper()
+	ctx := context.Background()
+	account := &models.Account{UserID: testUserID, Name: "Wallet", Type: models.AccountTypeCash, Currency: "USD"}
+	if err := accountRepo.Create(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+	sub := &models.SubAccount{AccountID: account.ID, UserID: testUserID, Name: "Cash", AssetType: models.AssetTypeCash, Currency: "USD"}
+	if err := subRepo.Create(ctx, sub); err != nil {
+		t.Fatal(err)
+	}
+	return sub
+}
+
+func TestAdjustBalanceIdempotencyKey(t *testing.T) {
+	db := databasetest.Open(t, &models.Account{}, &models.SubAccount{}, &models.BalanceAdjustment{})
+	subRepo := NewSubAccountRepository(db)
+	sub := newTestSubAccount(t, NewAccountRepository(db), subRepo)
+	ctx := context.Background()
+
+	updated, applied, err := subRepo.AdjustBalance(ctx, sub.ID, testUserID, 25, "tx-1")
+	if err != nil || !applied || updated.Balance != 25 {
+		t.Fatalf("first adjustment: balance %v, applied %v, err %v", updated, applied, err)
+	}
+
+	// A retry with the same key is skipped
+	updated, applied, err = subRepo.AdjustBalance(ctx, sub.ID, testUserID, 25, "tx-1")
+	if err != nil || applied || updated.Balance != 25 {
+		t.Fatalf("retried adjustment: balance %v, applied %v, err %v", updated, applied, err)
+	}
+
+	updated, applied, err = subRepo.AdjustBalance(ctx, sub.ID, testUserID, -10, "tx-2")
+	if err != nil || !applied || updated.Balance != 15 {
+		t.Fatalf("second adjustment: balance %v, applied %v, err %v", updated, applied, err)
+	}
+
+	// Without a key every call applies
+	for i := 0; i < 2; i++ {
+		if _, _, err := subRepo.AdjustBalance(ctx, sub.ID, testUserID, 1, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, _ := subRepo.GetByID(ctx, sub.ID, testUserID); got.Balance != 17 {
+		t.Fatalf("balance = %v, want 17", got.Balance)
+	}
+}
+
+func TestAdjustBalanceMissingSubAccountRecordsNoKey(t *testing.T) {
+	db := databasetest.Open(t, &models.Account{}, &models.SubAccount{}, &models.BalanceAdjustment{})
+	subRepo := NewSubAccountRepository(db)
+	ctx := context.Background()
+
+	missing := "00000000-0000-0000-0000-0000000000ff"
+	if _, _, err := subRepo.AdjustBalance(ctx, missing, testUserID, 5, "tx-1"); !errors.Is(err, ErrSubAccountNotFound) {
+		t.Fatalf("got %v, want ErrSubAccountNotFound", err)
+	}
+
+	// The failed adjustment didn't record its key, so the key still applies
+	sub := newTestSubAccount(t, NewAccountRepository(db), subRepo)
+	if _, applied, err := subRepo.AdjustBalance(ctx, sub.ID, testUserID, 5, "tx-1"); err != nil || !applied {
+		t.Fatalf("applied %v, err %v", applied, err)
+	}
+}
+
+func TestDeleteAccountRolledBackByCascade(t *testing.T) {
+	db := databasetest.Open(t, &models.Account{}, &models.SubAccount{})
+	accountRepo := NewAccountRepository(db)
+	sub := newTestSubAccount(t, accountRepo, NewSubAccountRepository(db))
+	ctx := context.Background()
+
+	cascadeErr := errors.New("assets service down")
+	err := accountRepo.Delete(ctx, sub.AccountID, testUserID, func([]string, time.Time) error { return cascadeErr })
+	if !errors.Is(err, cascadeErr) {
+		t.Fatalf("got %v, want the cascade error", err)
+	}
+	if _, err := accountRepo.GetByID(ctx, sub.AccountID, testUserID); err != nil {
+		t.Fatalf("account gone after a failed cascade: %v", err)
+	}
+
+	var cascaded []string
+	if err := accountRepo.Delete(ctx, sub.AccountID, testUserID, func(ids []string, at time.Time) error {
+		cascaded = ids
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if len(cascaded) != 1 || cascaded[0] != sub.ID {
+		t.Fatalf("cascaded %v, want [%s]", cascaded, sub.ID)
+	}
+
+	// A failed restore cascade leaves the account deleted
+	since := time.Now().Add(-time.Hour)
+	if err := accountRepo.Restore(ctx, sub.AccountID, testUserID, since, func([]string, time.Time) error { return cascadeErr }); !errors.Is(err, cascadeErr) {
+		t.Fatalf("got %v, want the cascade error", err)
+	}
+	if _, err := accountRepo.GetByID(ctx, sub.AccountID, testUserID); !errors.Is(err, ErrAccountNotFound) {
+		t.Fatalf("got %v, want ErrAccountNotFound", err)
+	}
+
+	if err := accountRepo.Restore(ctx, sub.AccountID, testUserID, since, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := accountRepo.GetByID(ctx, sub.AccountID, testUserID); err != nil {
+		t.Fatalf("account not restored: %v", err)
+	}
+}
+
+func TestRecreateDeletedAccountName(t *testing.T) {
+	db := databasetest.Open(t, &models.Account{}, &models.SubAccount{})
+	accountRepo := NewAccountRepository(db)
+	ctx := context.Background()
+
+	first := &models.Account{UserID: testUserID, Name: "Savings", Type: models.AccountTypeBank, Currency: "USD"}
+	if err := accountRepo.Create(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := accountRepo.Delete(ctx, first.ID, testUserID, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	second := &models.Account{UserID: testUserID, Name: "Savings", Type: models.AccountTypeBank, Currency: "USD"}
+	if err := accountRepo.Create(ctx, second); err != nil {
+		t.Fatalf("recreating a deleted account's name: %v", err)
+	}
+	if second.ID == first.ID {
+		t.Fatal("recreated account reused the deleted account's ID")
+	}
+
+	// The deleted account can still be restored next to its namesake
+	if err := accountRepo.Restore(ctx, first.ID, testUserID, time.Now().Add(-time.Hour), nil); err != nil {
+		t.Fatalf("restoring beside an account of the same name: %v", err)
+	}
+}
+
+// raceUpdates runs each update at the same moment and returns their errors
+func raceUpdates(updates ...func() error) []error {
+	errs := make([]error, len(updates))
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, update := range updates {
+		wg.Add(1)
+		go func(i int, update func() error) {
+			defer wg.Done()
+			<-start
+			errs[i] = update()
+		}(i, update)
+	}
+	close(start)
+	wg.Wait()
+	return errs
+}
+
+// checkOneWinner fails the test unless exactly one update succeeded and the
+// others got ErrConflict
+func checkOneWinner(t *testing.T, errs []error) int {
+	t.Helper()
+	winner := -1
+	for i, err := range errs {
+		switch {
+		case err == nil && winner == -1:
+			winner = i
+		case err == nil:
+			t.Fatalf("updates %d and %d both succeeded from the same version", winner, i)
+		case !errors.Is(err, ErrConflict):
+			t.Fatalf("update %d: err = %v, want ErrConflict", i, err)
+		}
+	}
+	if winner == -1 {
+		t.Fatal("no update succeeded")
+	}
+	return winner
+}
+
+func TestConcurrentAccountUpdatesConflict(t *testing.T) {
+	db := databasetest.Open(t, &models.Account{}, &models.SubAccount{})
+	repo := NewAccountRepository(db)
+	ctx := context.Background()
+
+	account := &models.Account{UserID: testUserID, Name: "Wallet", Type: models.AccountTypeCash, Currency: "USD"}
+	if err := repo.Create(ctx, account); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two clients load the same version and edit it at once
+	first, err := repo.GetByID(ctx, account.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.GetByID(ctx, account.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Name = "First"
+	second.Name = "Second"
+
+	errs := raceUpdates(
+		func() error { return repo.Update(ctx, first) },
+		func() error { return repo.Update(ctx, second) },
+	)
+	winner := []*models.Account{first, second}[checkOneWinner(t, errs)]
+
+	stored, err := repo.GetByID(ctx, account.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != winner.Name || stored.Version != winner.Version {
+		t.Fatalf("stored %q at version %d, want %q at %d", stored.Name, stored.Version, winner.Name, winner.Version)
+	}
+
+	// The loser reloads and retries
+	retry, err := repo.GetByID(ctx, account.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	retry.Name = "Retried"
+	if err := repo.Update(ctx, retry); err != nil {
+		t.Fatalf("retry after reload: %v", err)
+	}
+}
+
+func TestConcurrentSubAccountUpdatesConflict(t *testing.T) {
+	db := databasetest.Open(t, &models.Account{}, &models.SubAccount{})
+	subRepo := NewSubAccountRepository(db)
+	sub := newTestSubAccount(t, NewAccountRepository(db), subRepo)
+	ctx := context.Background()
+
+	first, err := subRepo.GetByID(ctx, sub.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := subRepo.GetByID(ctx, sub.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Balance = 100
+	second.Balance = 200
+
+	errs := raceUpdates(
+		func() error { return subRepo.Update(ctx, first) },
+		func() error { return subRepo.Update(ctx, second) },
+	)
+	winner := []*models.SubAccount{first, second}[checkOneWinner(t, errs)]
+
+	stored, err := subRepo.GetByID(ctx, sub.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Balance != winner.Balance || stored.Version != winner.Version {
+		t.Fatalf("stored balance %v at version %d, want %v at %d", stored.Balance, stored.Version, winner.Balance, winner.Version)
+	}
+}
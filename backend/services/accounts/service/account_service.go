@@ -2,29 +2,60 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
+	"sort"
 	"time"
 
+	"github.com/radmickey/money-control/backend/pkg/events"
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	"github.com/radmickey/money-control/backend/services/accounts/models"
 	"github.com/radmickey/money-control/backend/services/accounts/repository"
 )
 
+const (
+	// accountRestoreWindow is how long a deleted account can be undeleted
+	accountRestoreWindow = 30 * 24 * time.Hour
+	// maxBulkBalanceUpdates caps the updates in one bulk balance request
+	maxBulkBalanceUpdates = 500
+)
+
+var (
+	ErrTooManyUpdates = errors.New("too many balance updates in one request")
+	ErrCascadeFailed  = errors.New("could not update the assets held in the account")
+)
+
 // AccountService handles account business logic
 type AccountService struct {
 	accountRepo        *repository.AccountRepository
 	subAccountRepo     *repository.SubAccountRepository
 	balanceHistoryRepo *repository.BalanceHistoryRepository
+	assetsClient       assetspb.AssetsServiceClient
+	currencyClient     currencypb.CurrencyServiceClient
+	publisher          *events.Publisher
 }
 
-// NewAccountService creates a new account service
+// NewAccountService creates a new account service.
+// assetsClient may be nil, in which case deleting an account leaves the
+// assets in its sub-accounts alone. currencyClient may be nil, in which case
+// net worth only counts balances already in the base currency. Changes are
+// announced through publisher.
 func NewAccountService(
 	accountRepo *repository.AccountRepository,
 	subAccountRepo *repository.SubAccountRepository,
 	balanceHistoryRepo *repository.BalanceHistoryRepository,
+	assetsClient assetspb.AssetsServiceClient,
+	currencyClient currencypb.CurrencyServiceClient,
+	publisher *events.Publisher,
 ) *AccountService {
 	return &AccountService{
 		accountRepo:        accountRepo,
 		subAccountRepo:     subAccountRepo,
 		balanceHistoryRepo: balanceHistoryRepo,
+		assetsClient:       assetsClient,
+		currencyClient:     currencyClient,
+		publisher:          publisher,
 	}
 }
 
@@ -36,6 +67,7 @@ type CreateAccountInput struct {
 	Currency    string
 	Description string
 	Icon        string
+	IsLiability bool
 }
 
 // CreateAccount creates a new account
@@ -51,6 +83,7 @@ func (s *AccountService) CreateAccount(ctx context.Context, input CreateAccountI
 		Currency:    input.Currency,
 		Description: input.Description,
 		Icon:        input.Icon,
+		IsLiability: input.IsLiability,
 		IsActive:    true,
 	}
 
@@ -58,6 +91,7 @@ func (s *AccountService) CreateAccount(ctx context.Context, input CreateAccountI
 		return nil, err
 	}
 
+	s.publisher.Publish(ctx, account.UserID, events.AccountCreated, accountData(account))
 	return account, nil
 }
 
@@ -66,15 +100,16 @@ func (s *AccountService) GetAccount(ctx context.Context, id, userID string) (*mo
 	return s.accountRepo.GetByID(ctx, id, userID)
 }
 
-// ListAccounts lists accounts for a user
-func (s *AccountService) ListAccounts(ctx context.Context, userID string, accountType models.AccountType, page, pageSize int) ([]models.Account, int64, error) {
+// ListAccounts lists accounts for a user; archived accounts are only
+// included when asked for
+func (s *AccountService) ListAccounts(ctx context.Context, userID string, accountType models.AccountType, includeArchived bool, page, pageSize int) ([]models.Account, int64, error) {
 	if page <= 0 {
 		page = 1
 	}
 	if pageSize <= 0 {
 		pageSize = 20
 	}
-	return s.accountRepo.ListByUserID(ctx, userID, accountType, page, pageSize)
+	return s.accountRepo.ListByUserID(ctx, userID, accountType, includeArchived, page, pageSize)
 }
 
 // UpdateAccountInput holds input for updating an account
@@ -85,14 +120,21 @@ type UpdateAccountInput struct {
 	Description string
 	Icon        string
 	Currency    string
+	// Version is the version the client last read; zero skips the check
+	Version int64
 }
 
-// UpdateAccount updates an account
+// UpdateAccount updates an account. It fails with ErrConflict when the
+// account changed since the client read it at input.Version, or while this
+// update was in progress; the client should reload and retry.
 func (s *AccountService) UpdateAccount(ctx context.Context, input UpdateAccountInput) (*models.Account, error) {
 	account, err := s.accountRepo.GetByID(ctx, input.ID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if input.Version != 0 && input.Version != account.Version {
+		return nil, repository.ErrConflict
+	}
 
 	if input.Name != "" {
 		account.Name = input.Name
@@ -111,12 +153,97 @@ func (s *AccountService) UpdateAccount(ctx context.Context, input UpdateAccountI
 		return nil, err
 	}
 
+	s.publisher.Publish(ctx, account.UserID, events.AccountUpdated, accountData(account))
 	return account, nil
 }
 
-// DeleteAccount deletes an account
+// DeleteAccount deletes an account with its sub-accounts and the assets held
+// in them. It can be undone with UndeleteAccount for accountRestoreWindow.
+// If the assets can't be deleted, nothing is and ErrCascadeFailed is returned.
 func (s *AccountService) DeleteAccount(ctx context.Context, id, userID string) error {
-	return s.accountRepo.Delete(ctx, id, userID)
+	if s.assetsClient == nil {
+		if err := s.accountRepo.Delete(ctx, id, userID, nil); err != nil {
+			return err
+		}
+	} else {
+		cascade := newAssetCascade(userID, s.assetsClient.DeleteSubAccountAssets, s.assetsClient.RestoreSubAccountAssets)
+		if err := cascade.run(ctx, func(apply repository.Cascade) error {
+			return s.accountRepo.Delete(ctx, id, userID, apply)
+		}); err != nil {
+			return err
+		}
+	}
+
+	s.publisher.Publish(ctx, userID, events.AccountDeleted, events.DeletedData{ID: id})
+	return nil
+}
+
+// UndeleteAccount restores an account deleted within accountRestoreWindow,
+// together with the sub-accounts and assets deleted with it. If the assets
+// can't be restored, nothing is and ErrCascadeFailed is returned.
+func (s *AccountService) UndeleteAccount(ctx context.Context, id, userID string) (*models.Account, error) {
+	since := time.Now().Add(-accountRestoreWindow)
+	if s.assetsClient == nil {
+		if err := s.accountRepo.Restore(ctx, id, userID, since, nil); err != nil {
+			return nil, err
+		}
+	} else {
+		cascade := newAssetCascade(userID, s.assetsClient.RestoreSubAccountAssets, s.assetsClient.DeleteSubAccountAssets)
+		if err := cascade.run(ctx, func(apply repository.Cascade) error {
+			return s.accountRepo.Restore(ctx, id, userID, since, apply)
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publisher.Publish(ctx, userID, events.AccountCreated, accountData(account))
+	return account, nil
+}
+
+// ReorderAccounts sets the display order of a user's unarchived accounts.
+// ids must list each of them exactly once, otherwise ErrInvalidOrder.
+func (s *AccountService) ReorderAccounts(ctx context.Context, userID string, ids []string) error {
+	return s.accountRepo.Reorder(ctx, userID, ids)
+}
+
+// ArchiveAccount hides an account from lists, net worth and summaries while
+// keeping it and its history reachable by ID
+func (s *AccountService) ArchiveAccount(ctx context.Context, id, userID string) (*models.Account, error) {
+	return s.setArchived(ctx, id, userID, true)
+}
+
+// UnarchiveAccount restores an archived account
+func (s *AccountService) UnarchiveAccount(ctx context.Context, id, userID string) (*models.Account, error) {
+	return s.setArchived(ctx, id, userID, false)
+}
+
+func (s *AccountService) setArchived(ctx context.Context, id, userID string, archived bool) (*models.Account, error) {
+	account, err := s.accountRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Archived == archived {
+		return account, nil
+	}
+
+	account.Archived = archived
+	account.ArchivedAt = nil
+	if archived {
+		now := time.Now()
+		account.ArchivedAt = &now
+	}
+
+	if err := s.accountRepo.Update(ctx, account); err != nil {
+		return nil, err
+	}
+
+	s.publisher.Publish(ctx, account.UserID, events.AccountUpdated, accountData(account))
+	return account, nil
 }
 
 // CreateSubAccountInput holds input for creating a sub-account
@@ -135,7 +262,7 @@ type CreateSubAccountInput struct {
 // CreateSubAccount creates a new sub-account
 func (s *AccountService) CreateSubAccount(ctx context.Context, input CreateSubAccountInput) (*models.SubAccount, error) {
 	// Verify account exists and belongs to user
-	_, err := s.accountRepo.GetByID(ctx, input.AccountID, input.UserID)
+	account, err := s.accountRepo.GetByID(ctx, input.AccountID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -143,6 +270,7 @@ func (s *AccountService) CreateSubAccount(ctx context.Context, input CreateSubAc
 	if input.Currency == "" {
 		input.Currency = "USD"
 	}
+	input.Balance = account.OwedBalance(input.Balance)
 
 	subAccount := &models.SubAccount{
 		AccountID:   input.AccountID,
@@ -165,7 +293,7 @@ func (s *AccountService) CreateSubAccount(ctx context.Context, input CreateSubAc
 	_ = s.accountRepo.UpdateTotalBalance(ctx, input.AccountID)
 
 	// Record initial balance in history
-	if input.Balance > 0 {
+	if input.Balance != 0 {
 		_ = s.balanceHistoryRepo.Create(ctx, &models.BalanceHistory{
 			SubAccountID: subAccount.ID,
 			UserID:       input.UserID,
@@ -174,6 +302,7 @@ func (s *AccountService) CreateSubAccount(ctx context.Context, input CreateSubAc
 		})
 	}
 
+	s.publisher.Publish(ctx, input.UserID, events.SubAccountCreated, subAccountData(subAccount))
 	return subAccount, nil
 }
 
@@ -190,6 +319,15 @@ func (s *AccountService) ListSubAccounts(ctx context.Context, accountID, userID
 	return s.subAccountRepo.ListByUserID(ctx, userID, assetType)
 }
 
+// ReorderSubAccounts sets the display order of an account's sub-accounts,
+// which ids must list exactly once each
+func (s *AccountService) ReorderSubAccounts(ctx context.Context, accountID, userID string, ids []string) error {
+	if _, err := s.accountRepo.GetByID(ctx, accountID, userID); err != nil {
+		return err
+	}
+	return s.subAccountRepo.Reorder(ctx, accountID, userID, ids)
+}
+
 // UpdateSubAccountInput holds input for updating a sub-account
 type UpdateSubAccountInput struct {
 	ID          string
@@ -198,14 +336,20 @@ type UpdateSubAccountInput struct {
 	Description string
 	Balance     float64
 	Quantity    float64
+	// Version is the version the client last read; zero skips the check
+	Version int64
 }
 
-// UpdateSubAccount updates a sub-account
+// UpdateSubAccount updates a sub-account, failing with ErrConflict like
+// UpdateAccount
 func (s *AccountService) UpdateSubAccount(ctx context.Context, input UpdateSubAccountInput) (*models.SubAccount, error) {
 	subAccount, err := s.subAccountRepo.GetByID(ctx, input.ID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if input.Version != 0 && input.Version != subAccount.Version {
+		return nil, repository.ErrConflict
+	}
 
 	if input.Name != "" {
 		subAccount.Name = input.Name
@@ -216,7 +360,11 @@ func (s *AccountService) UpdateSubAccount(ctx context.Context, input UpdateSubAc
 
 	oldBalance := subAccount.Balance
 	if input.Balance != 0 {
-		subAccount.Balance = input.Balance
+		balance, err := s.subAccountBalance(ctx, subAccount, input.Balance)
+		if err != nil {
+			return nil, err
+		}
+		subAccount.Balance = balance
 	}
 	if input.Quantity != 0 {
 		subAccount.Quantity = input.Quantity
@@ -239,6 +387,10 @@ func (s *AccountService) UpdateSubAccount(ctx context.Context, input UpdateSubAc
 		})
 	}
 
+	s.publisher.Publish(ctx, input.UserID, events.SubAccountUpdated, subAccountData(subAccount))
+	if subAccount.Balance != oldBalance {
+		s.publisher.Publish(ctx, input.UserID, events.BalanceUpdated, balanceData(subAccount))
+	}
 	return subAccount, nil
 }
 
@@ -256,6 +408,7 @@ func (s *AccountService) DeleteSubAccount(ctx context.Context, id, userID string
 	// Update account total balance
 	_ = s.accountRepo.UpdateTotalBalance(ctx, subAccount.AccountID)
 
+	s.publisher.Publish(ctx, userID, events.SubAccountDeleted, events.DeletedData{ID: id})
 	return nil
 }
 
@@ -266,6 +419,11 @@ func (s *AccountService) UpdateSubAccountBalance(ctx context.Context, id, userID
 		return nil, err
 	}
 
+	balance, err = s.subAccountBalance(ctx, subAccount, balance)
+	if err != nil {
+		return nil, err
+	}
+
 	oldBalance := subAccount.Balance
 	subAccount.Balance = balance
 	if quantity > 0 {
@@ -287,24 +445,178 @@ func (s *AccountService) UpdateSubAccountBalance(ctx context.Context, id, userID
 			Balance:      balance,
 			Date:         time.Now(),
 		})
+		s.publisher.Publish(ctx, userID, events.BalanceUpdated, balanceData(subAccount))
+	}
+
+	return subAccount, nil
+}
+
+// subAccountBalance applies the sub-account's account's OwedBalance
+func (s *AccountService) subAccountBalance(ctx context.Context, subAccount *models.SubAccount, balance float64) (float64, error) {
+	account, err := s.accountRepo.GetByID(ctx, subAccount.AccountID, subAccount.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return account.OwedBalance(balance), nil
+}
+
+// BulkUpdateSubAccountBalances sets the balances of many of a user's
+// sub-accounts in one transaction, such as when reconciling a brokerage
+// statement. Each update succeeds or fails on its own; the results are in
+// the order of updates.
+func (s *AccountService) BulkUpdateSubAccountBalances(ctx context.Context, userID string, updates []repository.BalanceUpdate) ([]repository.BalanceUpdateResult, error) {
+	if len(updates) > maxBulkBalanceUpdates {
+		return nil, ErrTooManyUpdates
+	}
+	if len(updates) == 0 {
+		return []repository.BalanceUpdateResult{}, nil
+	}
+	results, err := s.subAccountRepo.BulkUpdateBalances(ctx, userID, updates)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, result := range results {
+		if result.Err == nil && result.SubAccount != nil {
+			s.publisher.Publish(ctx, userID, events.BalanceUpdated, balanceData(result.SubAccount))
+		}
+	}
+	return results, nil
+}
+
+// AdjustSubAccountBalance adds delta to a sub-account balance, such as the
+// amount of a transaction booked against it. A retry with the same
+// idempotency key returns the sub-account without adjusting it again.
+func (s *AccountService) AdjustSubAccountBalance(ctx context.Context, id, userID string, delta float64, idempotencyKey string) (*models.SubAccount, error) {
+	subAccount, applied, err := s.subAccountRepo.AdjustBalance(ctx, id, userID, delta, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	if !applied {
+		return subAccount, nil
+	}
+
+	// Update account total balance
+	_ = s.accountRepo.UpdateTotalBalance(ctx, subAccount.AccountID)
+
+	if delta != 0 {
+		_ = s.balanceHistoryRepo.Create(ctx, &models.BalanceHistory{
+			SubAccountID: subAccount.ID,
+			UserID:       userID,
+			Balance:      subAccount.Balance,
+			Date:         time.Now(),
+		})
+		s.publisher.Publish(ctx, userID, events.BalanceUpdated, balanceData(subAccount))
 	}
 
 	return subAccount, nil
 }
 
-// GetUserNetWorth calculates total net worth for a user
-func (s *AccountService) GetUserNetWorth(ctx context.Context, userID string) (map[string]float64, error) {
-	return s.subAccountRepo.GetTotalBalanceByUser(ctx, userID)
+// NetWorth is a user's net worth in a base currency
+type NetWorth struct {
+	Total    float64 `json:"total"`
+	Currency string  `json:"currency"`
+	// TotalAssets and TotalLiabilities split Total into what asset accounts
+	// hold and what is owed on liability accounts, as a positive amount
+	TotalAssets      float64 `json:"total_assets"`
+	TotalLiabilities float64 `json:"total_liabilities"`
+	// ByCurrency holds balances in their own currencies, liabilities subtracted
+	ByCurrency map[string]float64 `json:"by_currency"`
+	// ConvertedByCurrency holds the same balances in the base currency
+	ConvertedByCurrency map[string]float64 `json:"converted_by_currency"`
+	// Unconverted lists currencies without a rate to the base currency;
+	// their balances are left out of the totals
+	Unconverted []string `json:"unconverted"`
+}
+
+// GetUserNetWorth calculates a user's net worth in baseCurrency: asset
+// balances less the amount owed on liabilities, each currency converted at
+// the current rate. Balances that can't be converted are reported in
+// Unconverted instead of being added as if they were already in the base
+// currency.
+func (s *AccountService) GetUserNetWorth(ctx context.Context, userID, baseCurrency string) (*NetWorth, error) {
+	if baseCurrency == "" {
+		baseCurrency = "USD"
+	}
+
+	assets, liabilities, err := s.subAccountRepo.GetTotalBalanceByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	netWorth := &NetWorth{
+		Currency:            baseCurrency,
+		ByCurrency:          make(map[string]float64, len(assets)+len(liabilities)),
+		ConvertedByCurrency: make(map[string]float64, len(assets)+len(liabilities)),
+		Unconverted:         []string{},
+	}
+	for currency, balance := range assets {
+		netWorth.ByCurrency[currency] += balance
+	}
+	for currency, balance := range liabilities {
+		netWorth.ByCurrency[currency] += balance
+	}
+
+	rates := s.ratesTo(ctx, netWorth.ByCurrency, baseCurrency)
+	for currency, balance := range netWorth.ByCurrency {
+		rate, ok := rates[currency]
+		if !ok {
+			netWorth.Unconverted = append(netWorth.Unconverted, currency)
+			continue
+		}
+		netWorth.ConvertedByCurrency[currency] = balance * rate
+		netWorth.TotalAssets += assets[currency] * rate
+		netWorth.TotalLiabilities -= liabilities[currency] * rate
+	}
+	netWorth.Total = netWorth.TotalAssets - netWorth.TotalLiabilities
+	sort.Strings(netWorth.Unconverted)
+
+	return netWorth, nil
+}
+
+// ratesTo returns the current rate from each currency in balances to
+// baseCurrency. Currencies without a rate are missing from the result.
+func (s *AccountService) ratesTo(ctx context.Context, balances map[string]float64, baseCurrency string) map[string]float64 {
+	rates := map[string]float64{baseCurrency: 1}
+
+	amounts := make([]*currencypb.AmountToConvert, 0, len(balances))
+	for currency := range balances {
+		if currency != baseCurrency {
+			amounts = append(amounts, &currencypb.AmountToConvert{
+				Id:           currency,
+				Amount:       1,
+				FromCurrency: currency,
+			})
+		}
+	}
+	if len(amounts) == 0 || s.currencyClient == nil {
+		return rates
+	}
+
+	resp, err := s.currencyClient.ConvertMultipleAmounts(ctx, &currencypb.ConvertMultipleAmountsRequest{
+		Amounts:    amounts,
+		ToCurrency: baseCurrency,
+	})
+	if err != nil {
+		log.Printf("Failed to get rates to %s: %v", baseCurrency, err)
+		return rates
+	}
+	for _, c := range resp.Converted {
+		if c.Error == "" {
+			rates[c.Id] = c.RateUsed
+		}
+	}
+	return rates
 }
 
-// GetBalanceByAssetType gets balances grouped by asset type
+// GetBalanceByAssetType gets asset account balances grouped by asset type
 func (s *AccountService) GetBalanceByAssetType(ctx context.Context, userID string) (map[models.AssetType]float64, error) {
 	return s.subAccountRepo.GetBalanceByAssetType(ctx, userID)
 }
 
 // GetAccountsSummary gets summary of accounts for a user
 func (s *AccountService) GetAccountsSummary(ctx context.Context, userID string) (*AccountsSummary, error) {
-	accounts, total, err := s.accountRepo.ListByUserID(ctx, userID, "", 1, 1000)
+	accounts, total, err := s.accountRepo.ListByUserID(ctx, userID, "", false, 1, 1000)
 	if err != nil {
 		return nil, err
 	}
@@ -338,3 +650,35 @@ type TypeSummary struct {
 	TotalBalance float64
 }
 
+// accountData builds the event payload for an account
+func accountData(account *models.Account) events.AccountData {
+	return events.AccountData{
+		ID:       account.ID,
+		Name:     account.Name,
+		Type:     string(account.Type),
+		Currency: account.Currency,
+		Balance:  account.TotalBalance,
+		Archived: account.Archived,
+	}
+}
+
+// subAccountData builds the event payload for a sub-account
+func subAccountData(subAccount *models.SubAccount) events.SubAccountData {
+	return events.SubAccountData{
+		ID:        subAccount.ID,
+		AccountID: subAccount.AccountID,
+		Name:      subAccount.Name,
+		Currency:  subAccount.Currency,
+		Balance:   subAccount.Balance,
+	}
+}
+
+// balanceData builds the balance.updated payload for a sub-account
+func balanceData(subAccount *models.SubAccount) events.BalanceData {
+	return events.BalanceData{
+		AccountID:    subAccount.AccountID,
+		SubAccountID: subAccount.ID,
+		Balance:      subAccount.Balance,
+		Currency:     subAccount.Currency,
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+const (
+	// maxBalanceHistoryPoints caps how many points a history query returns
+	maxBalanceHistoryPoints = 1000
+	// balanceHistoryScanLimit bounds the entries read for one query
+	balanceHistoryScanLimit = 20000
+)
+
+var (
+	ErrInvalidDateRange = errors.New("end date must not be before start date")
+)
+
+// BalancePoint is a sub-account's balance at the end of a day
+type BalancePoint struct {
+	Date    time.Time `json:"date"`
+	Balance float64   `json:"balance"`
+}
+
+// GetSubAccountBalanceHistory returns a sub-account's closing balance for each
+// day it changed between start and end, oldest first. Zero times leave the
+// range open. When there are more than limit days the series is downsampled
+// to limit points, each the closing balance of an equal share of the days,
+// so the latest balance is always included. A zero limit means the maximum.
+func (s *AccountService) GetSubAccountBalanceHistory(ctx context.Context, id, userID string, start, end time.Time, limit int) ([]BalancePoint, error) {
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, ErrInvalidDateRange
+	}
+	if limit <= 0 || limit > maxBalanceHistoryPoints {
+		limit = maxBalanceHistoryPoints
+	}
+
+	// Checks the sub-account belongs to the user
+	if _, err := s.subAccountRepo.GetByID(ctx, id, userID); err != nil {
+		return nil, err
+	}
+
+	history, err := s.balanceHistoryRepo.GetBySubAccountID(ctx, id, userID, start, end, balanceHistoryScanLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	// History is newest first, so the first entry seen for a day closes it
+	daily := make([]BalancePoint, 0, len(history))
+	for _, entry := range history {
+		if n := len(daily); n > 0 && daily[n-1].Date.Equal(entry.Date) {
+			continue
+		}
+		daily = append(daily, BalancePoint{Date: entry.Date, Balance: entry.Balance})
+	}
+	for i, j := 0, len(daily)-1; i < j; i, j = i+1, j-1 {
+		daily[i], daily[j] = daily[j], daily[i]
+	}
+
+	return downsampleBalances(daily, limit), nil
+}
+
+// downsampleBalances splits points into limit consecutive groups and keeps
+// the last point of each
+func downsampleBalances(points []BalancePoint, limit int) []BalancePoint {
+	if len(points) <= limit {
+		return points
+	}
+	sampled := make([]BalancePoint, limit)
+	for i := range sampled {
+		sampled[i] = points[(i+1)*len(points)/limit-1]
+	}
+	return sampled
+}
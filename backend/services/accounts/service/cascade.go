@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	"github.com/radmickey/money-control/backend/services/accounts/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// assetsCall changes the assets held in a set of sub-accounts
+type assetsCall func(ctx context.Context, in *assetspb.SubAccountAssetsRequest, opts ...grpc.CallOption) (*assetspb.SubAccountAssetsResponse, error)
+
+// assetCascade carries an account deletion or restore over to the assets in
+// its sub-accounts. The assets call runs inside the database transaction, so
+// a failed call rolls the account change back; if the transaction then fails
+// to commit, the call is reversed.
+type assetCascade struct {
+	userID  string
+	apply   assetsCall
+	reverse assetsCall
+
+	// The request apply succeeded with, if it ran
+	applied *assetspb.SubAccountAssetsRequest
+}
+
+func newAssetCascade(userID string, apply, reverse assetsCall) *assetCascade {
+	return &assetCascade{userID: userID, apply: apply, reverse: reverse}
+}
+
+// run calls write with the cascade to hand to the repository
+func (c *assetCascade) run(ctx context.Context, write func(apply repository.Cascade) error) error {
+	err := write(func(subAccountIDs []string, at time.Time) error {
+		req := &assetspb.SubAccountAssetsRequest{
+			UserId:        c.userID,
+			SubAccountIds: subAccountIDs,
+			DeletedAt:     timestamppb.New(at),
+		}
+		if _, err := c.apply(ctx, req); err != nil {
+			return fmt.Errorf("%w: %v", ErrCascadeFailed, err)
+		}
+		c.applied = req
+		return nil
+	})
+	if err != nil && c.applied != nil {
+		// The account change didn't commit, so the assets go back too. This
+		// runs even if the request was cancelled.
+		if _, undoErr := c.reverse(context.WithoutCancel(ctx), c.applied); undoErr != nil {
+			log.Printf("Failed to undo asset changes for sub-accounts %v: %v", c.applied.SubAccountIds, undoErr)
+		}
+	}
+	return err
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	"github.com/radmickey/money-control/backend/services/accounts/repository"
+	"google.golang.org/grpc"
+)
+
+// recordingAssetsCall records the sub-accounts it was called with and
+// returns err
+type recordingAssetsCall struct {
+	calls [][]string
+	err   error
+}
+
+func (r *recordingAssetsCall) call(ctx context.Context, in *assetspb.SubAccountAssetsRequest, opts ...grpc.CallOption) (*assetspb.SubAccountAssetsResponse, error) {
+	r.calls = append(r.calls, in.SubAccountIds)
+	if r.err != nil {
+		return nil, r.err
+	}
+	return &assetspb.SubAccountAssetsResponse{}, nil
+}
+
+func TestAssetCascadeCommitted(t *testing.T) {
+	apply, reverse := &recordingAssetsCall{}, &recordingAssetsCall{}
+	cascade := newAssetCascade("user", apply.call, reverse.call)
+
+	err := cascade.run(context.Background(), func(fn repository.Cascade) error {
+		return fn([]string{"sub-1", "sub-2"}, time.Now())
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if len(apply.calls) != 1 || len(apply.calls[0]) != 2 {
+		t.Fatalf("apply calls = %v, want one for both sub-accounts", apply.calls)
+	}
+	if len(reverse.calls) != 0 {
+		t.Fatalf("reverse called %d times, want 0", len(reverse.calls))
+	}
+}
+
+func TestAssetCascadeFailedCallRollsBack(t *testing.T) {
+	apply, reverse := &recordingAssetsCall{err: errors.New("assets service down")}, &recordingAssetsCall{}
+	cascade := newAssetCascade("user", apply.call, reverse.call)
+
+	// The repository returns the cascade's error, rolling the account back
+	err := cascade.run(context.Background(), func(fn repository.Cascade) error {
+		return fn([]string{"sub-1"}, time.Now())
+	})
+	if !errors.Is(err, ErrCascadeFailed) {
+		t.Fatalf("got %v, want ErrCascadeFailed", err)
+	}
+	if len(reverse.calls) != 0 {
+		t.Fatalf("reverse called %d times, want 0 since nothing was applied", len(reverse.calls))
+	}
+}
+
+func TestAssetCascadeReversedWhenCommitFails(t *testing.T) {
+	apply, reverse := &recordingAssetsCall{}, &recordingAssetsCall{}
+	cascade := newAssetCascade("user", apply.call, reverse.call)
+
+	commitErr := errors.New("commit failed")
+	err := cascade.run(context.Background(), func(fn repository.Cascade) error {
+		if err := fn([]string{"sub-1"}, time.Now()); err != nil {
+			return err
+		}
+		return commitErr
+	})
+	if !errors.Is(err, commitErr) {
+		t.Fatalf("got %v, want the commit error", err)
+	}
+	if len(reverse.calls) != 1 || reverse.calls[0][0] != "sub-1" {
+		t.Fatalf("reverse calls = %v, want one for sub-1", reverse.calls)
+	}
+}
@@ -0,0 +1,27 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	"github.com/radmickey/money-control/backend/services/assets/repository"
+	"github.com/radmickey/money-control/backend/services/assets/service"
+)
+
+// errorMap maps the assets service's errors to HTTP responses
+var errorMap = utils.ErrorMap{
+	{Err: repository.ErrAssetNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Asset not found"},
+	{Err: repository.ErrAlertNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Price alert not found"},
+	{Err: repository.ErrConflict, Status: http.StatusConflict, Code: utils.CodeConflict, Message: "Asset was modified by another request; reload and retry"},
+	{Err: repository.ErrInsufficientQuantity, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidQuantity, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidAmount, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidDirection, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidThreshold, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+}
+
+// respondError writes err as an HTTP error response
+func respondError(c *gin.Context, err error) {
+	errorMap.Respond(c, err)
+}
@@ -2,10 +2,14 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	pb "github.com/radmickey/money-control/backend/proto/assets"
 	"github.com/radmickey/money-control/backend/services/assets/models"
+	"github.com/radmickey/money-control/backend/services/assets/providers"
+	"github.com/radmickey/money-control/backend/services/assets/repository"
 	"github.com/radmickey/money-control/backend/services/assets/service"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -17,12 +21,14 @@ import (
 type GRPCHandler struct {
 	pb.UnimplementedAssetsServiceServer
 	assetService *service.AssetService
+	alertService *service.AlertService
 }
 
 // NewGRPCHandler creates a new gRPC handler
-func NewGRPCHandler(assetService *service.AssetService) *GRPCHandler {
+func NewGRPCHandler(assetService *service.AssetService, alertService *service.AlertService) *GRPCHandler {
 	return &GRPCHandler{
 		assetService: assetService,
+		alertService: alertService,
 	}
 }
 
@@ -38,7 +44,7 @@ func (h *GRPCHandler) CreateAsset(ctx context.Context, req *pb.CreateAssetReques
 		SubAccountID:  subAccountID,
 		Symbol:        req.Symbol,
 		Name:          req.Name,
-		Type:          models.AssetType(req.Type.String()),
+		Type:          assetTypeFromProto(req.Type),
 		Quantity:      req.Quantity,
 		PurchasePrice: req.PurchasePrice,
 		Currency:      req.Currency,
@@ -62,7 +68,7 @@ func (h *GRPCHandler) GetAsset(ctx context.Context, req *pb.GetAssetRequest) (*p
 
 // ListAssets lists assets for a user
 func (h *GRPCHandler) ListAssets(ctx context.Context, req *pb.ListAssetsRequest) (*pb.ListAssetsResponse, error) {
-	assets, total, totalValue, totalProfitLoss, err := h.assetService.ListAssets(ctx, req.UserId, req.SubAccountId, models.AssetType(req.Type.String()), int(req.Page), int(req.PageSize))
+	assets, total, totalValue, totalProfitLoss, err := h.assetService.ListAssets(ctx, req.UserId, req.SubAccountId, assetTypeFromProto(req.Type), int(req.Page), int(req.PageSize))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list assets: %v", err)
 	}
@@ -92,8 +98,12 @@ func (h *GRPCHandler) UpdateAsset(ctx context.Context, req *pb.UpdateAssetReques
 		CurrentPrice:  req.CurrentPrice,
 		Name:          req.Name,
 		Metadata:      nil,
+		Version:       req.Version,
 	})
 	if err != nil {
+		if errors.Is(err, repository.ErrConflict) {
+			return nil, status.Error(codes.Aborted, "asset was modified by another request")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update asset: %v", err)
 	}
 
@@ -110,9 +120,125 @@ func (h *GRPCHandler) DeleteAsset(ctx context.Context, req *pb.DeleteAssetReques
 	return &emptypb.Empty{}, nil
 }
 
+// DeleteSubAccountAssets deletes the assets in sub-accounts whose account was deleted
+func (h *GRPCHandler) DeleteSubAccountAssets(ctx context.Context, req *pb.SubAccountAssetsRequest) (*pb.SubAccountAssetsResponse, error) {
+	if req.DeletedAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "deleted_at is required")
+	}
+	count, err := h.assetService.DeleteSubAccountAssets(ctx, req.UserId, req.SubAccountIds, req.DeletedAt.AsTime())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to delete sub-account assets: %v", err)
+	}
+	return &pb.SubAccountAssetsResponse{Count: count}, nil
+}
+
+// RestoreSubAccountAssets restores assets deleted with their sub-accounts
+func (h *GRPCHandler) RestoreSubAccountAssets(ctx context.Context, req *pb.SubAccountAssetsRequest) (*pb.SubAccountAssetsResponse, error) {
+	if req.DeletedAt == nil {
+		return nil, status.Error(codes.InvalidArgument, "deleted_at is required")
+	}
+	count, err := h.assetService.RestoreSubAccountAssets(ctx, req.UserId, req.SubAccountIds, req.DeletedAt.AsTime())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to restore sub-account assets: %v", err)
+	}
+	return &pb.SubAccountAssetsResponse{Count: count}, nil
+}
+
+// SellAsset sells part or all of an asset
+func (h *GRPCHandler) SellAsset(ctx context.Context, req *pb.SellAssetRequest) (*pb.SellAssetResponse, error) {
+	asset, gain, err := h.assetService.SellAsset(ctx, req.UserId, req.Id, req.Quantity, req.Price)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			return nil, status.Errorf(codes.NotFound, "asset not found: %v", err)
+		case errors.Is(err, repository.ErrInsufficientQuantity), errors.Is(err, service.ErrInvalidQuantity):
+			return nil, status.Errorf(codes.InvalidArgument, "failed to sell asset: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to sell asset: %v", err)
+	}
+
+	return &pb.SellAssetResponse{
+		Asset:            assetToProto(asset),
+		Quantity:         gain.Quantity,
+		SalePrice:        gain.SalePrice,
+		Proceeds:         gain.Proceeds,
+		CostBasis:        gain.CostBasis,
+		RealizedGainLoss: gain.GainLoss,
+		SoldAt:           timestamppb.New(gain.SoldAt),
+	}, nil
+}
+
+// RecordDividend records a dividend against an asset
+func (h *GRPCHandler) RecordDividend(ctx context.Context, req *pb.RecordDividendRequest) (*pb.Dividend, error) {
+	input := service.RecordDividendInput{
+		UserID:   req.UserId,
+		AssetID:  req.AssetId,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Notes:    req.Notes,
+	}
+	if req.ExDate != nil {
+		t := req.ExDate.AsTime()
+		input.ExDate = &t
+	}
+	if req.PayDate != nil {
+		input.PayDate = req.PayDate.AsTime()
+	}
+
+	dividend, err := h.assetService.RecordDividend(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrAssetNotFound):
+			return nil, status.Errorf(codes.NotFound, "asset not found: %v", err)
+		case errors.Is(err, service.ErrInvalidAmount):
+			return nil, status.Errorf(codes.InvalidArgument, "failed to record dividend: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to record dividend: %v", err)
+	}
+
+	return dividendToProto(dividend), nil
+}
+
+// ListDividends lists the dividends of an asset
+func (h *GRPCHandler) ListDividends(ctx context.Context, req *pb.ListDividendsRequest) (*pb.ListDividendsResponse, error) {
+	dividends, err := h.assetService.ListDividends(ctx, req.UserId, req.AssetId)
+	if err != nil {
+		if errors.Is(err, repository.ErrAssetNotFound) {
+			return nil, status.Errorf(codes.NotFound, "asset not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to list dividends: %v", err)
+	}
+
+	pbDividends := make([]*pb.Dividend, len(dividends))
+	for i := range dividends {
+		pbDividends[i] = dividendToProto(&dividends[i])
+	}
+
+	return &pb.ListDividendsResponse{Dividends: pbDividends}, nil
+}
+
+// GetDividendIncome sums a user's dividend income over a date range
+func (h *GRPCHandler) GetDividendIncome(ctx context.Context, req *pb.GetDividendIncomeRequest) (*pb.GetDividendIncomeResponse, error) {
+	end := time.Now()
+	if req.EndDate != nil {
+		end = req.EndDate.AsTime()
+	}
+	start := end.AddDate(-1, 0, 0)
+	if req.StartDate != nil {
+		start = req.StartDate.AsTime()
+	}
+
+	totals, err := h.assetService.GetDividendIncome(ctx, req.UserId, start, end)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get dividend income: %v", err)
+	}
+
+	return &pb.GetDividendIncomeResponse{ByCurrency: totals}, nil
+}
+
 // GetAssetPrice gets the current price of an asset
 func (h *GRPCHandler) GetAssetPrice(ctx context.Context, req *pb.GetAssetPriceRequest) (*pb.AssetPriceResponse, error) {
-	price, err := h.assetService.GetAssetPrice(ctx, req.Symbol, models.AssetType(req.Type.String()))
+	price, err := h.assetService.GetAssetPrice(ctx, req.Symbol, assetTypeFromProto(req.Type))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get price: %v", err)
 	}
@@ -123,7 +249,8 @@ func (h *GRPCHandler) GetAssetPrice(ctx context.Context, req *pb.GetAssetPriceRe
 		Currency:          price.Currency,
 		Change_24H:        price.Change24h,
 		ChangePercent_24H: price.ChangePercent24h,
-		UpdatedAt:         timestamppb.Now(),
+		UpdatedAt:         timestamppb.New(price.UpdatedAt),
+		Stale:             price.Stale,
 	}, nil
 }
 
@@ -147,7 +274,8 @@ func (h *GRPCHandler) GetMultipleAssetPrices(ctx context.Context, req *pb.GetMul
 			Currency:          price.Currency,
 			Change_24H:        price.Change24h,
 			ChangePercent_24H: price.ChangePercent24h,
-			UpdatedAt:         timestamppb.Now(),
+			UpdatedAt:         timestamppb.New(price.UpdatedAt),
+			Stale:             price.Stale,
 		}
 	}
 
@@ -180,7 +308,7 @@ func (h *GRPCHandler) GetAssetHistory(ctx context.Context, req *pb.GetAssetHisto
 		endDate = req.EndDate.AsTime()
 	}
 
-	history, err := h.assetService.GetAssetHistory(ctx, req.Symbol, models.AssetType(req.Type.String()), startDate, endDate)
+	history, err := h.assetService.GetAssetHistory(ctx, req.Symbol, assetTypeFromProto(req.Type), startDate, endDate)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get history: %v", err)
 	}
@@ -220,18 +348,50 @@ func (h *GRPCHandler) GetPortfolioPerformance(ctx context.Context, req *pb.GetPo
 	}
 
 	return &pb.PortfolioPerformanceResponse{
-		TotalValue:        perf.TotalValue,
-		TotalInvested:     perf.TotalInvested,
-		TotalProfitLoss:   perf.TotalProfitLoss,
-		ProfitLossPercent: perf.ProfitLossPercent,
-		Currency:          req.BaseCurrency,
-		Allocation:        allocations,
+		TotalValue:           perf.TotalValue,
+		TotalInvested:        perf.TotalInvested,
+		TotalProfitLoss:      perf.TotalProfitLoss,
+		RealizedProfitLoss:   perf.RealizedProfitLoss,
+		UnrealizedProfitLoss: perf.UnrealizedProfitLoss,
+		ProfitLossPercent:    perf.ProfitLossPercent,
+		DividendIncomeTtm:    perf.DividendIncomeTTM,
+		DividendYield:        perf.DividendYield,
+		Currency:             req.BaseCurrency,
+		Allocation:           allocations,
 	}, nil
 }
 
+// GetHoldings gets a user's holdings merged by symbol
+func (h *GRPCHandler) GetHoldings(ctx context.Context, req *pb.GetHoldingsRequest) (*pb.GetHoldingsResponse, error) {
+	holdings, err := h.assetService.GetAggregatedHoldings(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get holdings: %v", err)
+	}
+
+	pbHoldings := make([]*pb.Holding, len(holdings))
+	for i, hd := range holdings {
+		pbHoldings[i] = &pb.Holding{
+			Symbol:               hd.Symbol,
+			Name:                 hd.Name,
+			Type:                 assetTypeToProto(hd.Type),
+			Currency:             hd.Currency,
+			Quantity:             hd.Quantity,
+			AveragePurchasePrice: hd.AveragePurchasePrice,
+			CurrentPrice:         hd.CurrentPrice,
+			TotalValue:           hd.TotalValue,
+			TotalInvested:        hd.TotalInvested,
+			ProfitLoss:           hd.ProfitLoss,
+			ProfitLossPercent:    hd.ProfitLossPercent,
+			AssetIds:             hd.AssetIDs,
+		}
+	}
+
+	return &pb.GetHoldingsResponse{Holdings: pbHoldings}, nil
+}
+
 // SearchAssets searches for assets
 func (h *GRPCHandler) SearchAssets(ctx context.Context, req *pb.SearchAssetsRequest) (*pb.SearchAssetsResponse, error) {
-	results, err := h.assetService.SearchAssets(ctx, req.Query, models.AssetType(req.Type.String()), int(req.Limit))
+	results, err := h.assetService.SearchAssets(ctx, req.Query, assetTypeFromProto(req.Type), int(req.Limit))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to search assets: %v", err)
 	}
@@ -241,9 +401,10 @@ func (h *GRPCHandler) SearchAssets(ctx context.Context, req *pb.SearchAssetsRequ
 		pbResults[i] = &pb.AssetSearchResult{
 			Symbol:   r.Symbol,
 			Name:     r.Name,
-			Type:     assetTypeToProto(models.AssetType(r.Type)),
+			Type:     assetTypeToProto(searchResultType(r)),
 			Exchange: r.Exchange,
 			Currency: r.Currency,
+			Source:   r.Source,
 		}
 	}
 
@@ -252,7 +413,90 @@ func (h *GRPCHandler) SearchAssets(ctx context.Context, req *pb.SearchAssetsRequ
 	}, nil
 }
 
+// CreatePriceAlert creates a price alert
+func (h *GRPCHandler) CreatePriceAlert(ctx context.Context, req *pb.CreatePriceAlertRequest) (*pb.PriceAlert, error) {
+	alert, err := h.alertService.CreateAlert(ctx, service.CreateAlertInput{
+		UserID:    req.UserId,
+		Symbol:    req.Symbol,
+		AssetType: assetTypeFromProto(req.Type),
+		Direction: models.AlertDirection(req.Direction),
+		Threshold: req.Threshold,
+		OneShot:   req.OneShot,
+	})
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidDirection) || errors.Is(err, service.ErrInvalidThreshold) {
+			return nil, status.Errorf(codes.InvalidArgument, "failed to create price alert: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to create price alert: %v", err)
+	}
+
+	return alertToProto(alert), nil
+}
+
+// ListPriceAlerts lists a user's price alerts
+func (h *GRPCHandler) ListPriceAlerts(ctx context.Context, req *pb.ListPriceAlertsRequest) (*pb.ListPriceAlertsResponse, error) {
+	alerts, err := h.alertService.ListAlerts(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list price alerts: %v", err)
+	}
+
+	pbAlerts := make([]*pb.PriceAlert, len(alerts))
+	for i := range alerts {
+		pbAlerts[i] = alertToProto(&alerts[i])
+	}
+
+	return &pb.ListPriceAlertsResponse{Alerts: pbAlerts}, nil
+}
+
+// DeletePriceAlert deletes a price alert
+func (h *GRPCHandler) DeletePriceAlert(ctx context.Context, req *pb.DeletePriceAlertRequest) (*emptypb.Empty, error) {
+	if err := h.alertService.DeleteAlert(ctx, req.Id, req.UserId); err != nil {
+		if errors.Is(err, repository.ErrAlertNotFound) {
+			return nil, status.Errorf(codes.NotFound, "price alert not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to delete price alert: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
 // Helper functions
+func alertToProto(a *models.PriceAlert) *pb.PriceAlert {
+	alert := &pb.PriceAlert{
+		Id:        a.ID,
+		UserId:    a.UserID,
+		Symbol:    a.Symbol,
+		Type:      assetTypeToProto(a.AssetType),
+		Direction: string(a.Direction),
+		Threshold: a.Threshold,
+		OneShot:   a.OneShot,
+		Active:    a.Active,
+		CreatedAt: timestamppb.New(a.CreatedAt),
+	}
+	if a.LastTriggeredAt != nil {
+		alert.LastTriggeredAt = timestamppb.New(*a.LastTriggeredAt)
+	}
+	return alert
+}
+
+func dividendToProto(d *models.Dividend) *pb.Dividend {
+	dividend := &pb.Dividend{
+		Id:        d.ID,
+		AssetId:   d.AssetID,
+		UserId:    d.UserID,
+		Symbol:    d.Symbol,
+		Amount:    d.Amount,
+		Currency:  d.Currency,
+		PayDate:   timestamppb.New(d.PayDate),
+		Notes:     d.Notes,
+		CreatedAt: timestamppb.New(d.CreatedAt),
+	}
+	if d.ExDate != nil {
+		dividend.ExDate = timestamppb.New(*d.ExDate)
+	}
+	return dividend
+}
+
 func assetToProto(a *models.Asset) *pb.Asset {
 	subAccountID := ""
 	if a.SubAccountID != nil {
@@ -275,6 +519,7 @@ func assetToProto(a *models.Asset) *pb.Asset {
 		ProfitLossPercent: a.ProfitLossPercent,
 		CreatedAt:         timestamppb.New(a.CreatedAt),
 		UpdatedAt:         timestamppb.New(a.UpdatedAt),
+		Version:           a.Version,
 	}
 	if a.PurchaseDate != nil {
 		asset.PurchaseDate = timestamppb.New(*a.PurchaseDate)
@@ -299,7 +544,49 @@ func assetTypeToProto(t models.AssetType) pb.AssetType {
 		return pb.AssetType_ASSET_TYPE_CASH
 	case models.AssetTypeBond:
 		return pb.AssetType_ASSET_TYPE_BOND
+	case models.AssetTypeCommodity:
+		return pb.AssetType_ASSET_TYPE_COMMODITY
 	default:
 		return pb.AssetType_ASSET_TYPE_OTHER
 	}
 }
+
+// searchResultType maps a provider's result type onto an asset type,
+// falling back to the type of the provider that found it
+func searchResultType(r providers.SearchResult) models.AssetType {
+	switch strings.ToLower(r.Type) {
+	case "etf":
+		return models.AssetTypeETF
+	case "crypto":
+		return models.AssetTypeCrypto
+	case "equity", "stock":
+		return models.AssetTypeStock
+	}
+	if r.Source != "" {
+		return models.AssetType(r.Source)
+	}
+	return models.AssetType(r.Type)
+}
+
+func assetTypeFromProto(t pb.AssetType) models.AssetType {
+	switch t {
+	case pb.AssetType_ASSET_TYPE_STOCK:
+		return models.AssetTypeStock
+	case pb.AssetType_ASSET_TYPE_CRYPTO:
+		return models.AssetTypeCrypto
+	case pb.AssetType_ASSET_TYPE_ETF:
+		return models.AssetTypeETF
+	case pb.AssetType_ASSET_TYPE_REAL_ESTATE:
+		return models.AssetTypeRealEstate
+	case pb.AssetType_ASSET_TYPE_CASH:
+		return models.AssetTypeCash
+	case pb.AssetType_ASSET_TYPE_BOND:
+		return models.AssetTypeBond
+	case pb.AssetType_ASSET_TYPE_COMMODITY:
+		return models.AssetTypeCommodity
+	case pb.AssetType_ASSET_TYPE_OTHER:
+		return models.AssetTypeOther
+	default:
+		return ""
+	}
+}
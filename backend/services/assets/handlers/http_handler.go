@@ -8,13 +8,12 @@ import (
 	"github.com/radmickey/money-control/backend/pkg/middleware"
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	"github.com/radmickey/money-control/backend/services/assets/models"
-	"github.com/radmickey/money-control/backend/services/assets/repository"
 	"github.com/radmickey/money-control/backend/services/assets/service"
 )
 
 // RegisterHTTPRoutes registers HTTP routes for assets
-func RegisterHTTPRoutes(r *gin.RouterGroup, assetService *service.AssetService) {
-	h := &HTTPHandler{assetService: assetService}
+func RegisterHTTPRoutes(r *gin.RouterGroup, assetService *service.AssetService, alertService *service.AlertService) {
+	h := &HTTPHandler{assetService: assetService, alertService: alertService}
 
 	assets := r.Group("/assets")
 	{
@@ -23,6 +22,11 @@ func RegisterHTTPRoutes(r *gin.RouterGroup, assetService *service.AssetService)
 		assets.GET("/:id", h.GetAsset)
 		assets.PUT("/:id", h.UpdateAsset)
 		assets.DELETE("/:id", h.DeleteAsset)
+		assets.POST("/:id/sell", h.SellAsset)
+		assets.GET("/:id/lots", h.ListLots)
+		assets.GET("/:id/history", h.GetAssetHistory)
+		assets.POST("/:id/dividends", h.RecordDividend)
+		assets.GET("/:id/dividends", h.ListDividends)
 		assets.POST("/refresh-prices", h.RefreshPrices)
 	}
 
@@ -32,13 +36,22 @@ func RegisterHTTPRoutes(r *gin.RouterGroup, assetService *service.AssetService)
 		prices.POST("/multiple", h.GetMultiplePrices)
 	}
 
+	alerts := r.Group("/alerts")
+	{
+		alerts.POST("", h.CreateAlert)
+		alerts.GET("", h.ListAlerts)
+		alerts.DELETE("/:id", h.DeleteAlert)
+	}
+
 	r.GET("/portfolio", h.GetPortfolioPerformance)
+	r.GET("/holdings", h.GetHoldings)
 	r.GET("/search", h.SearchAssets)
 }
 
 // HTTPHandler handles HTTP requests
 type HTTPHandler struct {
 	assetService *service.AssetService
+	alertService *service.AlertService
 }
 
 // CreateAssetRequest represents create asset request
@@ -55,11 +68,15 @@ type CreateAssetRequest struct {
 
 // CreateAsset creates a new asset
 func (h *HTTPHandler) CreateAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req CreateAssetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -90,7 +107,7 @@ func (h *HTTPHandler) CreateAsset(c *gin.Context) {
 
 	asset, err := h.assetService.CreateAsset(c.Request.Context(), input)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -99,7 +116,11 @@ func (h *HTTPHandler) CreateAsset(c *gin.Context) {
 
 // ListAssets lists assets
 func (h *HTTPHandler) ListAssets(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	subAccountID := c.Query("sub_account_id")
 	assetType := models.AssetType(c.Query("type"))
@@ -110,7 +131,7 @@ func (h *HTTPHandler) ListAssets(c *gin.Context) {
 		c.Request.Context(), userID, subAccountID, assetType, page, pageSize,
 	)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -123,16 +144,16 @@ func (h *HTTPHandler) ListAssets(c *gin.Context) {
 
 // GetAsset gets an asset by ID
 func (h *HTTPHandler) GetAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	asset, err := h.assetService.GetAsset(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrAssetNotFound {
-			utils.NotFound(c, "Asset not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -145,16 +166,22 @@ type UpdateAssetRequest struct {
 	PurchasePrice float64 `json:"purchase_price"`
 	CurrentPrice  float64 `json:"current_price"`
 	Name          string  `json:"name"`
+	// Version is the version last read; a stale one gets 409 Conflict
+	Version int64 `json:"version"`
 }
 
 // UpdateAsset updates an asset
 func (h *HTTPHandler) UpdateAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req UpdateAssetRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -166,15 +193,12 @@ func (h *HTTPHandler) UpdateAsset(c *gin.Context) {
 		CurrentPrice:  req.CurrentPrice,
 		Name:          req.Name,
 		Metadata:      nil,
+		Version:       req.Version,
 	}
 
 	asset, err := h.assetService.UpdateAsset(c.Request.Context(), input)
 	if err != nil {
-		if err == repository.ErrAssetNotFound {
-			utils.NotFound(c, "Asset not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -183,16 +207,287 @@ func (h *HTTPHandler) UpdateAsset(c *gin.Context) {
 
 // DeleteAsset deletes an asset
 func (h *HTTPHandler) DeleteAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	err := h.assetService.DeleteAsset(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrAssetNotFound {
-			utils.NotFound(c, "Asset not found")
+		respondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// SellAssetRequest represents sell asset request
+type SellAssetRequest struct {
+	Quantity float64 `json:"quantity" binding:"required"`
+	Price    float64 `json:"price"`
+}
+
+// SellAsset sells part or all of an asset
+func (h *HTTPHandler) SellAsset(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	var req SellAssetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	asset, gain, err := h.assetService.SellAsset(c.Request.Context(), userID, id, req.Quantity, req.Price)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"asset":         asset,
+		"realized_gain": gain,
+	})
+}
+
+// ListLots lists the purchase lots of an asset
+func (h *HTTPHandler) ListLots(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	lots, err := h.assetService.ListLots(c.Request.Context(), userID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, lots)
+}
+
+// historyPeriods maps period shortcuts to how far back they reach
+var historyPeriods = map[string]func(time.Time) time.Time{
+	"1w": func(t time.Time) time.Time { return t.AddDate(0, 0, -7) },
+	"1m": func(t time.Time) time.Time { return t.AddDate(0, -1, 0) },
+	"3m": func(t time.Time) time.Time { return t.AddDate(0, -3, 0) },
+	"6m": func(t time.Time) time.Time { return t.AddDate(0, -6, 0) },
+	"1y": func(t time.Time) time.Time { return t.AddDate(-1, 0, 0) },
+	"5y": func(t time.Time) time.Time { return t.AddDate(-5, 0, 0) },
+}
+
+// GetAssetHistory gets OHLC price history for an asset
+func (h *HTTPHandler) GetAssetHistory(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	var startDate, endDate time.Time
+	if end := c.Query("end"); end != "" {
+		t, err := time.Parse("2006-01-02", end)
+		if err != nil {
+			utils.BadRequest(c, "Invalid end format (use YYYY-MM-DD)")
 			return
 		}
-		utils.InternalError(c, err.Error())
+		endDate = t
+	}
+
+	if period := c.Query("period"); period != "" {
+		back, ok := historyPeriods[period]
+		if !ok {
+			utils.BadRequest(c, "Invalid period (use 1w, 1m, 3m, 6m, 1y or 5y)")
+			return
+		}
+		if endDate.IsZero() {
+			endDate = time.Now()
+		}
+		startDate = back(endDate)
+	} else if start := c.Query("start"); start != "" {
+		t, err := time.Parse("2006-01-02", start)
+		if err != nil {
+			utils.BadRequest(c, "Invalid start format (use YYYY-MM-DD)")
+			return
+		}
+		startDate = t
+	}
+
+	if !startDate.IsZero() && !endDate.IsZero() && !startDate.Before(endDate) {
+		utils.BadRequest(c, "start must be before end")
+		return
+	}
+
+	asset, history, err := h.assetService.GetAssetHistoryByID(c.Request.Context(), id, userID, startDate, endDate)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"symbol": asset.Symbol,
+		"type":   asset.Type,
+		"prices": history,
+	})
+}
+
+// RecordDividendRequest represents record dividend request
+type RecordDividendRequest struct {
+	Amount   float64 `json:"amount" binding:"required"`
+	Currency string  `json:"currency"`
+	ExDate   string  `json:"ex_date"`
+	PayDate  string  `json:"pay_date"`
+	Notes    string  `json:"notes"`
+}
+
+// RecordDividend records a dividend against an asset
+func (h *HTTPHandler) RecordDividend(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	var req RecordDividendRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	input := service.RecordDividendInput{
+		UserID:   userID,
+		AssetID:  id,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+		Notes:    req.Notes,
+	}
+	if req.ExDate != "" {
+		t, err := time.Parse("2006-01-02", req.ExDate)
+		if err != nil {
+			utils.BadRequest(c, "Invalid ex_date format (use YYYY-MM-DD)")
+			return
+		}
+		input.ExDate = &t
+	}
+	if req.PayDate != "" {
+		t, err := time.Parse("2006-01-02", req.PayDate)
+		if err != nil {
+			utils.BadRequest(c, "Invalid pay_date format (use YYYY-MM-DD)")
+			return
+		}
+		input.PayDate = t
+	}
+
+	dividend, err := h.assetService.RecordDividend(c.Request.Context(), input)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Created(c, dividend)
+}
+
+// ListDividends lists the dividends of an asset
+func (h *HTTPHandler) ListDividends(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	dividends, err := h.assetService.ListDividends(c.Request.Context(), userID, id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, dividends)
+}
+
+// CreateAlertRequest represents create price alert request
+type CreateAlertRequest struct {
+	Symbol    string  `json:"symbol" binding:"required"`
+	Type      string  `json:"type"`
+	Direction string  `json:"direction" binding:"required"`
+	Threshold float64 `json:"threshold" binding:"required"`
+	OneShot   *bool   `json:"one_shot"`
+}
+
+// CreateAlert creates a price alert
+func (h *HTTPHandler) CreateAlert(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req CreateAlertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	// Alerts fire once unless explicitly made repeating
+	oneShot := true
+	if req.OneShot != nil {
+		oneShot = *req.OneShot
+	}
+
+	alert, err := h.alertService.CreateAlert(c.Request.Context(), service.CreateAlertInput{
+		UserID:    userID,
+		Symbol:    req.Symbol,
+		AssetType: models.AssetType(req.Type),
+		Direction: models.AlertDirection(req.Direction),
+		Threshold: req.Threshold,
+		OneShot:   oneShot,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Created(c, alert)
+}
+
+// ListAlerts lists the user's price alerts
+func (h *HTTPHandler) ListAlerts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	alerts, err := h.alertService.ListAlerts(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, alerts)
+}
+
+// DeleteAlert deletes a price alert
+func (h *HTTPHandler) DeleteAlert(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	if err := h.alertService.DeleteAlert(c.Request.Context(), id, userID); err != nil {
+		respondError(c, err)
 		return
 	}
 
@@ -206,7 +501,7 @@ func (h *HTTPHandler) GetPrice(c *gin.Context) {
 
 	price, err := h.assetService.GetAssetPrice(c.Request.Context(), symbol, assetType)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -225,7 +520,7 @@ type GetMultiplePricesRequest struct {
 func (h *HTTPHandler) GetMultiplePrices(c *gin.Context) {
 	var req GetMultiplePricesRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -236,7 +531,7 @@ func (h *HTTPHandler) GetMultiplePrices(c *gin.Context) {
 
 	prices, failedSymbols, err := h.assetService.GetMultipleAssetPrices(c.Request.Context(), queries)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -253,14 +548,18 @@ type RefreshPricesRequest struct {
 
 // RefreshPrices refreshes prices for assets
 func (h *HTTPHandler) RefreshPrices(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req RefreshPricesRequest
 	_ = c.ShouldBindJSON(&req)
 
 	updated, failed, err := h.assetService.RefreshAssetPrices(c.Request.Context(), userID, req.AssetIDs)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -272,17 +571,38 @@ func (h *HTTPHandler) RefreshPrices(c *gin.Context) {
 
 // GetPortfolioPerformance gets portfolio performance
 func (h *HTTPHandler) GetPortfolioPerformance(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	perf, err := h.assetService.GetPortfolioPerformance(c.Request.Context(), userID)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.Success(c, perf)
 }
 
+// GetHoldings gets holdings merged by symbol
+func (h *HTTPHandler) GetHoldings(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	holdings, err := h.assetService.GetAggregatedHoldings(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, holdings)
+}
+
 // SearchAssets searches for assets
 func (h *HTTPHandler) SearchAssets(c *gin.Context) {
 	query := c.Query("q")
@@ -296,7 +616,7 @@ func (h *HTTPHandler) SearchAssets(c *gin.Context) {
 
 	results, err := h.assetService.SearchAssets(c.Request.Context(), query, assetType, limit)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
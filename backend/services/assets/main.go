@@ -15,14 +15,21 @@ import (
 	pkgcache "github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/events"
+	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
 	pb "github.com/radmickey/money-control/backend/proto/assets"
+	authpb "github.com/radmickey/money-control/backend/proto/auth"
 	"github.com/radmickey/money-control/backend/services/assets/handlers"
 	"github.com/radmickey/money-control/backend/services/assets/models"
+	"github.com/radmickey/money-control/backend/services/assets/notifier"
 	"github.com/radmickey/money-control/backend/services/assets/providers"
 	"github.com/radmickey/money-control/backend/services/assets/repository"
 	"github.com/radmickey/money-control/backend/services/assets/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -32,11 +39,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "assets-service",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := database.New(database.Config{
-		URL:   cfg.DatabaseURL,
-		Debug: cfg.Debug,
+		URL:             cfg.DatabaseURL,
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		Debug:           cfg.Debug,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -44,7 +67,7 @@ func main() {
 	defer db.Close()
 
 	// Run migrations
-	if err := db.Migrate(&models.Asset{}, &models.PriceCache{}, &models.PriceHistory{}); err != nil {
+	if err := db.Migrate(&models.Asset{}, &models.Lot{}, &models.RealizedGain{}, &models.Dividend{}, &models.PriceAlert{}, &models.PriceCache{}, &models.PriceHistory{}); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -58,17 +81,42 @@ func main() {
 	}
 	defer redisCache.Close()
 
-	// Initialize price providers
+	// Initialize price providers (Stooq batch quotes first, Alpha Vantage as fallback)
 	alphaVantage := providers.NewAlphaVantageClient(cfg.AlphaVantageAPIKey, cfg.AlphaVantagePremium)
-	coinGecko := providers.NewCoinGeckoClient(cfg.CoinGeckoAPIURL)
-	priceManager := providers.NewPriceManager(alphaVantage, coinGecko)
+	stockProvider := providers.NewFallbackProvider(providers.NewStooqClient(), alphaVantage)
+	coinGecko := providers.NewCoinGeckoClient(cfg.CoinGeckoAPIURL,
+		providers.WithRequestsPerMinute(cfg.CoinGeckoRateLimit),
+		providers.WithCoinIDOverrides(providers.ParseCoinIDOverrides(cfg.CoinGeckoIDs)),
+	)
+	coinGecko.StartCoinListRefresher(24 * time.Hour)
+	defer coinGecko.Stop()
+	priceManager := providers.NewPriceManager(stockProvider, coinGecko)
 
 	// Initialize repositories
 	assetRepo := repository.NewAssetRepository(db.DB)
+	lotRepo := repository.NewLotRepository(db.DB)
+	dividendRepo := repository.NewDividendRepository(db.DB)
 	priceCacheRepo := repository.NewPriceCacheRepository(db.DB)
 
+	// Give assets created before lot tracking a single opening lot
+	if backfilled, err := lotRepo.BackfillLots(context.Background()); err != nil {
+		log.Printf("Warning: Failed to backfill asset lots: %v", err)
+	} else if backfilled > 0 {
+		log.Printf("Backfilled %d asset lots", backfilled)
+	}
+
 	// Initialize service
-	assetService := service.NewAssetService(assetRepo, priceCacheRepo, priceManager, redisCache)
+	assetService := service.NewAssetService(
+		assetRepo, lotRepo, dividendRepo, priceCacheRepo, priceManager, redisCache, cfg.PriceMaxStaleness,
+		events.NewPublisher(redisCache, "assets-service"),
+	)
+
+	// Evaluate price alerts in the background
+	alertService := service.NewAlertService(
+		repository.NewAlertRepository(db.DB), assetService, newAlertNotifier(cfg), cfg.PriceAlertCooldown,
+	)
+	alertService.StartEvaluator(cfg.PriceAlertInterval)
+	defer alertService.Stop()
 
 	// Initialize JWT manager for auth middleware
 	jwtManager := auth.NewJWTManager(
@@ -78,11 +126,19 @@ func main() {
 	)
 
 	// Start gRPC server
-	grpcServer := grpc.NewServer()
-	grpcHandler := handlers.NewGRPCHandler(assetService)
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		middleware.UnaryServerRequestIDInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
+	grpcHandler := handlers.NewGRPCHandler(assetService, alertService)
 	pb.RegisterAssetsServiceServer(grpcServer, grpcHandler)
 	reflection.Register(grpcServer)
 
+	// Health checks, served over gRPC here and on /health/details below
+	healthChecker := health.NewHealthChecker("")
+	healthChecker.Register("database", health.DatabasePoolCheck(db.Ping, db.Stats))
+	grpcHealth := health.RegisterGRPCServer(grpcServer, healthChecker)
+
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
@@ -101,20 +157,39 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery())
+	logConfig := middleware.NewLoggingConfig(cfg)
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "assets"})
 	})
 
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Detailed health check with connection pool stats
+	router.GET("/health/details", func(c *gin.Context) {
+		c.JSON(http.StatusOK, healthChecker.Check(c.Request.Context()))
+	})
+
 	// Protected routes
 	v1 := router.Group("/api/v1")
-	v1.Use(middleware.AuthMiddleware(jwtManager))
-	handlers.RegisterHTTPRoutes(v1, assetService)
+	tokenVersions, err := middleware.NewTokenVersionStore(cfg, redisCache.Client())
+	if err != nil {
+		log.Fatalf("Failed to create token version store: %v", err)
+	}
+	tokenValidator, err := middleware.NewTokenValidator(cfg, jwtManager, tokenVersions)
+	if err != nil {
+		log.Fatalf("Failed to create token validator: %v", err)
+	}
+	v1.Use(middleware.AuthMiddleware(tokenValidator))
+	handlers.RegisterHTTPRoutes(v1, assetService, alertService)
 
 	httpServer := &http.Server{
 		Addr:    ":" + cfg.HTTPPort,
@@ -139,6 +214,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
@@ -147,3 +223,36 @@ func main() {
 	log.Println("Assets service stopped")
 }
 
+// newAlertNotifier builds the price alert notifier from the configured
+// channels, falling back to logging when none are set up
+func newAlertNotifier(cfg *config.Config) notifier.Notifier {
+	if cfg.TelegramBotToken == "" && cfg.SMTPHost == "" {
+		return notifier.LogNotifier{}
+	}
+
+	conn, err := grpc.Dial(cfg.AuthServiceURL,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+		tracing.DialOption(),
+	)
+	if err != nil {
+		log.Printf("Failed to connect to auth service, price alerts will only be logged: %v", err)
+		return notifier.LogNotifier{}
+	}
+	resolver := notifier.NewAuthResolver(authpb.NewAuthServiceClient(conn))
+
+	var notifiers notifier.Multi
+	if cfg.TelegramBotToken != "" {
+		notifiers = append(notifiers, notifier.NewTelegramNotifier(cfg.TelegramBotToken, resolver))
+	}
+	if cfg.SMTPHost != "" {
+		notifiers = append(notifiers, notifier.NewEmailNotifier(notifier.EmailConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		}, resolver))
+	}
+	return notifiers
+}
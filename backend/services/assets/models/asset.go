@@ -38,6 +38,7 @@ type Asset struct {
 	PurchaseDate     *time.Time     `json:"purchase_date,omitempty"`
 	PriceUpdatedAt   *time.Time     `json:"price_updated_at,omitempty"`
 	Metadata         *string        `gorm:"type:jsonb" json:"metadata,omitempty"`
+	Version          int64          `gorm:"not null;default:1" json:"version"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
 	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
@@ -60,6 +61,107 @@ func (a *Asset) CalculateProfitLoss() {
 	}
 }
 
+// Lot is a single purchase of an asset; Quantity is what remains unsold
+type Lot struct {
+	ID               string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AssetID          string         `gorm:"type:uuid;not null;index" json:"asset_id"`
+	UserID           string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	OriginalQuantity float64        `gorm:"type:decimal(20,8);not null" json:"original_quantity"`
+	Quantity         float64        `gorm:"type:decimal(20,8);not null" json:"quantity"`
+	Price            float64        `gorm:"type:decimal(20,8)" json:"price"`
+	Date             time.Time      `gorm:"not null;index" json:"date"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (Lot) TableName() string {
+	return "asset_lots"
+}
+
+// RealizedGain records the profit or loss from selling part of an asset
+type RealizedGain struct {
+	ID        string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AssetID   string    `gorm:"type:uuid;not null;index" json:"asset_id"`
+	UserID    string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	Symbol    string    `gorm:"size:20;not null" json:"symbol"`
+	Quantity  float64   `gorm:"type:decimal(20,8);not null" json:"quantity"`
+	SalePrice float64   `gorm:"type:decimal(20,8);not null" json:"sale_price"`
+	Proceeds  float64   `gorm:"type:decimal(20,8)" json:"proceeds"`
+	CostBasis float64   `gorm:"type:decimal(20,8)" json:"cost_basis"`
+	GainLoss  float64   `gorm:"type:decimal(20,8)" json:"gain_loss"`
+	Currency  string    `gorm:"size:3;not null;default:'USD'" json:"currency"`
+	SoldAt    time.Time `gorm:"not null;index" json:"sold_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (RealizedGain) TableName() string {
+	return "realized_gains"
+}
+
+// Dividend records a dividend or coupon payment received for an asset
+type Dividend struct {
+	ID        string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	AssetID   string         `gorm:"type:uuid;not null;index" json:"asset_id"`
+	UserID    string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Symbol    string         `gorm:"size:20;not null" json:"symbol"`
+	Amount    float64        `gorm:"type:decimal(20,8);not null" json:"amount"`
+	Currency  string         `gorm:"size:3;not null;default:'USD'" json:"currency"`
+	ExDate    *time.Time     `gorm:"type:date" json:"ex_date,omitempty"`
+	PayDate   time.Time      `gorm:"type:date;not null;index" json:"pay_date"`
+	Notes     string         `gorm:"size:255" json:"notes,omitempty"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (Dividend) TableName() string {
+	return "dividends"
+}
+
+// AlertDirection is the side of the threshold that triggers an alert
+type AlertDirection string
+
+const (
+	AlertDirectionAbove AlertDirection = "above"
+	AlertDirectionBelow AlertDirection = "below"
+)
+
+// PriceAlert notifies a user when a symbol's price crosses a threshold
+type PriceAlert struct {
+	ID              string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID          string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Symbol          string         `gorm:"size:20;not null;index" json:"symbol"`
+	AssetType       AssetType      `gorm:"size:50;not null" json:"asset_type"`
+	Direction       AlertDirection `gorm:"size:10;not null" json:"direction"`
+	Threshold       float64        `gorm:"type:decimal(20,8);not null" json:"threshold"`
+	OneShot         bool           `gorm:"default:true" json:"one_shot"`
+	Active          bool           `gorm:"default:true;index" json:"active"`
+	LastTriggeredAt *time.Time     `json:"last_triggered_at,omitempty"`
+	LastPrice       float64        `gorm:"type:decimal(20,8)" json:"last_price"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (PriceAlert) TableName() string {
+	return "price_alerts"
+}
+
+// Triggered reports whether price is on the alerting side of the threshold
+func (a *PriceAlert) Triggered(price float64) bool {
+	switch a.Direction {
+	case AlertDirectionAbove:
+		return price >= a.Threshold
+	case AlertDirectionBelow:
+		return price <= a.Threshold
+	}
+	return false
+}
+
 // PriceCache stores cached prices for assets
 type PriceCache struct {
 	ID          string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
@@ -0,0 +1,57 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// EmailConfig holds SMTP settings
+type EmailConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// EmailNotifier sends messages by email over SMTP
+type EmailNotifier struct {
+	cfg      EmailConfig
+	resolver RecipientResolver
+}
+
+// NewEmailNotifier creates an email notifier
+func NewEmailNotifier(cfg EmailConfig, resolver RecipientResolver) *EmailNotifier {
+	return &EmailNotifier{cfg: cfg, resolver: resolver}
+}
+
+// Notify emails msg to the user's account address
+func (e *EmailNotifier) Notify(ctx context.Context, msg Message) error {
+	recipient, err := e.resolver.Resolve(ctx, msg.UserID)
+	if err != nil {
+		return err
+	}
+	if recipient.Email == "" {
+		return ErrNoRecipient
+	}
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "From: %s\r\n", e.cfg.From)
+	fmt.Fprintf(&body, "To: %s\r\n", recipient.Email)
+	fmt.Fprintf(&body, "Subject: %s\r\n", msg.Subject)
+	body.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	body.WriteString(msg.Body)
+	body.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if e.cfg.Username != "" {
+		auth = smtp.PlainAuth("", e.cfg.Username, e.cfg.Password, e.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(e.cfg.Host, strconv.Itoa(e.cfg.Port))
+	return smtp.SendMail(addr, auth, e.cfg.From, []string{recipient.Email}, []byte(body.String()))
+}
@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	authpb "github.com/radmickey/money-control/backend/proto/auth"
+)
+
+// ErrNoRecipient is returned when a user has no address for a channel
+var ErrNoRecipient = errors.New("user has no recipient for this channel")
+
+// Message is a notification addressed to a user
+type Message struct {
+	UserID  string
+	Subject string
+	Body    string
+}
+
+// Notifier delivers messages to users
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Recipient holds the contact details of a user
+type Recipient struct {
+	Email      string
+	TelegramID int64
+}
+
+// RecipientResolver looks up how a user can be reached
+type RecipientResolver interface {
+	Resolve(ctx context.Context, userID string) (*Recipient, error)
+}
+
+// AuthResolver resolves recipients from the auth service profile
+type AuthResolver struct {
+	client authpb.AuthServiceClient
+}
+
+// NewAuthResolver creates a resolver backed by the auth service
+func NewAuthResolver(client authpb.AuthServiceClient) *AuthResolver {
+	return &AuthResolver{client: client}
+}
+
+// Resolve fetches the user's email and linked Telegram chat
+func (r *AuthResolver) Resolve(ctx context.Context, userID string) (*Recipient, error) {
+	user, err := r.client.GetProfile(ctx, &authpb.GetProfileRequest{UserId: userID})
+	if err != nil {
+		return nil, err
+	}
+	return &Recipient{
+		Email:      user.Email,
+		TelegramID: user.TelegramId,
+	}, nil
+}
+
+// Multi fans a message out to several notifiers. It succeeds if any of them
+// delivers the message.
+type Multi []Notifier
+
+// Notify sends msg through every notifier
+func (m Multi) Notify(ctx context.Context, msg Message) error {
+	var errs []error
+	delivered := false
+	for _, n := range m {
+		if err := n.Notify(ctx, msg); err != nil {
+			if !errors.Is(err, ErrNoRecipient) {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		delivered = true
+	}
+	if delivered {
+		return nil
+	}
+	if len(errs) == 0 {
+		return ErrNoRecipient
+	}
+	return errors.Join(errs...)
+}
+
+// LogNotifier writes messages to the log; used when no channel is configured
+type LogNotifier struct{}
+
+// Notify logs msg
+func (LogNotifier) Notify(ctx context.Context, msg Message) error {
+	log.Printf("Notification for user %s: %s - %s", msg.UserID, msg.Subject, msg.Body)
+	return nil
+}
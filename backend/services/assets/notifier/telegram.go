@@ -0,0 +1,67 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"net/http"
+	"time"
+)
+
+// TelegramNotifier sends messages to a user's linked Telegram chat
+type TelegramNotifier struct {
+	token      string
+	resolver   RecipientResolver
+	httpClient *http.Client
+}
+
+// NewTelegramNotifier creates a Telegram notifier using the bot token
+func NewTelegramNotifier(token string, resolver RecipientResolver) *TelegramNotifier {
+	return &TelegramNotifier{
+		token:    token,
+		resolver: resolver,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// Notify sends msg via the Bot API sendMessage method
+func (t *TelegramNotifier) Notify(ctx context.Context, msg Message) error {
+	recipient, err := t.resolver.Resolve(ctx, msg.UserID)
+	if err != nil {
+		return err
+	}
+	if recipient.TelegramID == 0 {
+		return ErrNoRecipient
+	}
+
+	data, err := json.Marshal(map[string]interface{}{
+		"chat_id":    recipient.TelegramID,
+		"text":       fmt.Sprintf("<b>%s</b>\n%s", html.EscapeString(msg.Subject), html.EscapeString(msg.Body)),
+		"parse_mode": "HTML",
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram api returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -13,25 +14,83 @@ import (
 
 const (
 	coinGeckoBaseURL = "https://api.coingecko.com/api/v3"
+
+	// Public API allowance is roughly 30 calls per minute
+	defaultCoinGeckoRequestsPerMinute = 30
+	// How long GetPrice waits to collect concurrent lookups into one request
+	defaultCoinGeckoCoalesceWindow = 50 * time.Millisecond
 )
 
 // CoinGeckoClient provides cryptocurrency price data from CoinGecko
 type CoinGeckoClient struct {
-	httpClient *http.Client
-	baseURL    string
+	httpClient     *http.Client
+	baseURL        string
+	limiter        *rateLimiter
+	coalesceWindow time.Duration
+
+	// Pending GetPrice calls waiting for the next batch request
+	batchMu sync.Mutex
+	pending map[string][]chan priceResult
+
+	// Symbol→ID index built from /coins/list, plus configured overrides
+	coinIndexMu sync.RWMutex
+	coinIndex   map[string]string
+	idOverrides map[string]string
+	stopChan    chan struct{}
+}
+
+// CoinGeckoOption configures a CoinGeckoClient
+type CoinGeckoOption func(*CoinGeckoClient)
+
+// WithRequestsPerMinute limits outgoing CoinGecko requests; 0 disables limiting
+func WithRequestsPerMinute(rpm int) CoinGeckoOption {
+	return func(c *CoinGeckoClient) {
+		c.limiter = newRateLimiter(rpm)
+	}
+}
+
+// WithCoalesceWindow sets how long GetPrice collects concurrent calls into one batch
+func WithCoalesceWindow(window time.Duration) CoinGeckoOption {
+	return func(c *CoinGeckoClient) {
+		c.coalesceWindow = window
+	}
+}
+
+// priceResult carries a coalesced GetPrice result
+type priceResult struct {
+	price *PriceData
+	err   error
 }
 
 // NewCoinGeckoClient creates a new CoinGecko client
-func NewCoinGeckoClient(baseURL string) *CoinGeckoClient {
+func NewCoinGeckoClient(baseURL string, opts ...CoinGeckoOption) *CoinGeckoClient {
 	if baseURL == "" {
 		baseURL = coinGeckoBaseURL
 	}
-	return &CoinGeckoClient{
+	c := &CoinGeckoClient{
 		baseURL: baseURL,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		limiter:        newRateLimiter(defaultCoinGeckoRequestsPerMinute),
+		coalesceWindow: defaultCoinGeckoCoalesceWindow,
+		pending:        make(map[string][]chan priceResult),
+		coinIndex:      make(map[string]string),
+		idOverrides:    make(map[string]string),
+		stopChan:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// do sends a request once the rate limiter allows it
+func (c *CoinGeckoClient) do(req *http.Request) (*http.Response, error) {
+	if err := c.limiter.Wait(req.Context()); err != nil {
+		return nil, err
 	}
+	return c.httpClient.Do(req)
 }
 
 // ProviderName returns the provider name
@@ -54,59 +113,67 @@ type CoinMarket struct {
 	LastUpdated              string  `json:"last_updated"`
 }
 
-// GetPrice gets current price for a cryptocurrency
+// GetPrice gets current price for a cryptocurrency. Concurrent calls are
+// coalesced into a single /coins/markets request.
 func (c *CoinGeckoClient) GetPrice(ctx context.Context, symbol string) (*PriceData, error) {
-	// CoinGecko uses coin IDs, not symbols. Common mappings:
-	coinID := symbolToCoinID(symbol)
-
-	url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&ids=%s", c.baseURL, coinID)
+	key := strings.ToUpper(symbol)
+	ch := make(chan priceResult, 1)
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+	c.batchMu.Lock()
+	if len(c.pending) == 0 {
+		time.AfterFunc(c.coalesceWindow, c.flushPending)
 	}
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch price: %w", err)
+	c.pending[key] = append(c.pending[key], ch)
+	c.batchMu.Unlock()
+
+	select {
+	case result := <-ch:
+		return result.price, result.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
-	defer resp.Body.Close()
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
-	}
+// flushPending fetches every pending symbol in one batch and answers the waiting callers
+func (c *CoinGeckoClient) flushPending() {
+	c.batchMu.Lock()
+	pending := c.pending
+	c.pending = make(map[string][]chan priceResult)
+	c.batchMu.Unlock()
 
-	var markets []CoinMarket
-	if err := json.Unmarshal(body, &markets); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	symbols := make([]string, 0, len(pending))
+	for symbol := range pending {
+		symbols = append(symbols, symbol)
 	}
 
-	if len(markets) == 0 {
-		return nil, fmt.Errorf("no data found for symbol: %s", symbol)
-	}
+	// Detached from any single caller so one cancellation doesn't fail the whole batch
+	ctx, cancel := context.WithTimeout(context.Background(), c.httpClient.Timeout)
+	defer cancel()
 
-	market := markets[0]
-	return &PriceData{
-		Symbol:           strings.ToUpper(market.Symbol),
-		Price:            market.CurrentPrice,
-		Currency:         "USD",
-		Change24h:        market.PriceChange24h,
-		ChangePercent24h: market.PriceChangePercentage24h,
-		High24h:          market.High24h,
-		Low24h:           market.Low24h,
-		Volume24h:        market.TotalVolume,
-		MarketCap:        market.MarketCap,
-		UpdatedAt:        time.Now(),
-	}, nil
+	prices, err := c.GetPrices(ctx, symbols)
+	for symbol, waiters := range pending {
+		result := priceResult{err: err}
+		if err == nil {
+			if price, ok := prices[symbol]; ok {
+				result = priceResult{price: price}
+			} else {
+				result.err = fmt.Errorf("no data found for symbol: %s", symbol)
+			}
+		}
+		for _, ch := range waiters {
+			ch <- result
+		}
+	}
 }
 
 // GetPrices gets prices for multiple cryptocurrencies concurrently
 func (c *CoinGeckoClient) GetPrices(ctx context.Context, symbols []string) (map[string]*PriceData, error) {
 	// Convert symbols to CoinGecko IDs
 	coinIDs := make([]string, len(symbols))
+	requested := make(map[string]string, len(symbols))
 	for i, sym := range symbols {
-		coinIDs[i] = symbolToCoinID(sym)
+		coinIDs[i] = c.coinID(sym)
+		requested[coinIDs[i]] = sym
 	}
 
 	url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&ids=%s&per_page=250",
@@ -117,7 +184,7 @@ func (c *CoinGeckoClient) GetPrices(ctx context.Context, symbols []string) (map[
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch prices: %w", err)
 	}
@@ -136,7 +203,12 @@ func (c *CoinGeckoClient) GetPrices(ctx context.Context, symbols []string) (map[
 	results := make(map[string]*PriceData)
 	for _, market := range markets {
 		symbol := strings.ToUpper(market.Symbol)
-		results[symbol] = &PriceData{
+		// Key by the symbol the caller asked for so lookups match
+		key := symbol
+		if sym, ok := requested[market.ID]; ok {
+			key = sym
+		}
+		results[key] = &PriceData{
 			Symbol:           symbol,
 			Price:            market.CurrentPrice,
 			Currency:         "USD",
@@ -153,24 +225,23 @@ func (c *CoinGeckoClient) GetPrices(ctx context.Context, symbols []string) (map[
 	return results, nil
 }
 
-// MarketChartResponse represents CoinGecko market chart response
-type MarketChartResponse struct {
-	Prices [][]float64 `json:"prices"`
-}
+// coinGeckoOHLCDays are the day ranges the /ohlc endpoint accepts. Candle size
+// grows with the range: 30 minutes up to 2 days, 4 hours up to 30, 4 days beyond.
+var coinGeckoOHLCDays = []int{1, 7, 14, 30, 90, 180, 365}
 
-// GetHistoricalPrices gets historical prices for a cryptocurrency
+// GetHistoricalPrices gets historical OHLC candles for a cryptocurrency
 func (c *CoinGeckoClient) GetHistoricalPrices(ctx context.Context, symbol string, from, to time.Time) ([]HistoricalPrice, error) {
-	coinID := symbolToCoinID(symbol)
+	coinID := c.coinID(symbol)
 
-	url := fmt.Sprintf("%s/coins/%s/market_chart/range?vs_currency=usd&from=%d&to=%d",
-		c.baseURL, coinID, from.Unix(), to.Unix())
+	url := fmt.Sprintf("%s/coins/%s/ohlc?vs_currency=usd&days=%s",
+		c.baseURL, coinID, ohlcDays(from))
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch historical data: %w", err)
 	}
@@ -181,31 +252,51 @@ func (c *CoinGeckoClient) GetHistoricalPrices(ctx context.Context, symbol string
 		return nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	var result MarketChartResponse
-	if err := json.Unmarshal(body, &result); err != nil {
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+
+	// Each candle is [timestamp_ms, open, high, low, close]
+	var candles [][]float64
+	if err := json.Unmarshal(body, &candles); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	var prices []HistoricalPrice
-	for _, point := range result.Prices {
-		if len(point) < 2 {
+	for _, candle := range candles {
+		if len(candle) < 5 {
+			continue
+		}
+
+		timestamp := time.UnixMilli(int64(candle[0]))
+		if timestamp.Before(from) || timestamp.After(to) {
 			continue
 		}
-		timestamp := time.Unix(int64(point[0]/1000), 0)
-		price := point[1]
 
 		prices = append(prices, HistoricalPrice{
 			Date:  timestamp,
-			Close: price,
-			Open:  price,
-			High:  price,
-			Low:   price,
+			Open:  candle[1],
+			High:  candle[2],
+			Low:   candle[3],
+			Close: candle[4],
 		})
 	}
 
 	return prices, nil
 }
 
+// ohlcDays picks the smallest /ohlc range that reaches back to from.
+// The endpoint counts days back from now, not from the end of the requested range.
+func ohlcDays(from time.Time) string {
+	needed := int(time.Since(from).Hours()/24) + 1
+	for _, days := range coinGeckoOHLCDays {
+		if needed <= days {
+			return strconv.Itoa(days)
+		}
+	}
+	return "max"
+}
+
 // CoinSearchResult represents CoinGecko search result
 type CoinSearchResult struct {
 	Coins []struct {
@@ -224,7 +315,7 @@ func (c *CoinGeckoClient) Search(ctx context.Context, query string) ([]SearchRes
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to search: %w", err)
 	}
@@ -253,40 +344,6 @@ func (c *CoinGeckoClient) Search(ctx context.Context, query string) ([]SearchRes
 	return results, nil
 }
 
-// symbolToCoinID converts common crypto symbols to CoinGecko IDs
-func symbolToCoinID(symbol string) string {
-	symbol = strings.ToLower(symbol)
-
-	// Common mappings
-	mappings := map[string]string{
-		"btc":   "bitcoin",
-		"eth":   "ethereum",
-		"usdt":  "tether",
-		"bnb":   "binancecoin",
-		"xrp":   "ripple",
-		"usdc":  "usd-coin",
-		"ada":   "cardano",
-		"doge":  "dogecoin",
-		"sol":   "solana",
-		"dot":   "polkadot",
-		"matic": "matic-network",
-		"ltc":   "litecoin",
-		"shib":  "shiba-inu",
-		"trx":   "tron",
-		"avax":  "avalanche-2",
-		"link":  "chainlink",
-		"atom":  "cosmos",
-		"xlm":   "stellar",
-		"etc":   "ethereum-classic",
-		"xmr":   "monero",
-	}
-
-	if id, ok := mappings[symbol]; ok {
-		return id
-	}
-	return symbol
-}
-
 // PriceManager manages multiple price providers
 type PriceManager struct {
 	stockProvider  PriceProvider
@@ -319,26 +376,63 @@ func (m *PriceManager) GetPrice(ctx context.Context, symbol string, assetType st
 	}
 }
 
-// GetPricesConcurrently gets prices for multiple assets concurrently
+// GetPricesConcurrently gets prices for multiple assets. Stocks/ETFs and crypto
+// are each fetched with a single batch call to their provider; assets of
+// unknown type are looked up individually.
 func (m *PriceManager) GetPricesConcurrently(ctx context.Context, assets map[string]string) (map[string]*PriceData, error) {
+	var stocks, cryptos, others []string
+	for symbol, assetType := range assets {
+		switch assetType {
+		case "crypto":
+			cryptos = append(cryptos, symbol)
+		case "stock", "etf":
+			stocks = append(stocks, symbol)
+		default:
+			others = append(others, symbol)
+		}
+	}
+
 	results := make(map[string]*PriceData)
 	var mu sync.Mutex
 	var wg sync.WaitGroup
 
-	for symbol, assetType := range assets {
+	collect := func(prices map[string]*PriceData) {
+		mu.Lock()
+		defer mu.Unlock()
+		for symbol, price := range prices {
+			results[symbol] = price
+		}
+	}
+
+	batch := func(provider PriceProvider, symbols []string) {
+		defer wg.Done()
+		prices, err := provider.GetPrices(ctx, symbols)
+		if err != nil && len(prices) == 0 {
+			return
+		}
+		collect(prices)
+	}
+
+	if len(stocks) > 0 {
+		wg.Add(1)
+		go batch(m.stockProvider, stocks)
+	}
+	if len(cryptos) > 0 {
 		wg.Add(1)
-		go func(sym, aType string) {
+		go batch(m.cryptoProvider, cryptos)
+	}
+
+	for _, symbol := range others {
+		wg.Add(1)
+		go func(sym string) {
 			defer wg.Done()
 
-			price, err := m.GetPrice(ctx, sym, aType)
+			price, err := m.GetPrice(ctx, sym, assets[sym])
 			if err != nil {
 				return
 			}
-
-			mu.Lock()
-			results[sym] = price
-			mu.Unlock()
-		}(symbol, assetType)
+			collect(map[string]*PriceData{sym: price})
+		}(symbol)
 	}
 
 	wg.Wait()
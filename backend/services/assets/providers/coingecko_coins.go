@@ -0,0 +1,202 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Coins ranked by market cap that decide which coin owns an ambiguous symbol
+const coinGeckoRankedPages = 2
+
+// staticCoinIDs pins common symbols regardless of what the coins list says
+var staticCoinIDs = map[string]string{
+	"btc":   "bitcoin",
+	"eth":   "ethereum",
+	"usdt":  "tether",
+	"bnb":   "binancecoin",
+	"xrp":   "ripple",
+	"usdc":  "usd-coin",
+	"ada":   "cardano",
+	"doge":  "dogecoin",
+	"sol":   "solana",
+	"dot":   "polkadot",
+	"matic": "matic-network",
+	"ltc":   "litecoin",
+	"shib":  "shiba-inu",
+	"trx":   "tron",
+	"avax":  "avalanche-2",
+	"link":  "chainlink",
+	"atom":  "cosmos",
+	"xlm":   "stellar",
+	"etc":   "ethereum-classic",
+	"xmr":   "monero",
+}
+
+// WithCoinIDOverrides adds symbol→ID mappings that take precedence over everything else
+func WithCoinIDOverrides(overrides map[string]string) CoinGeckoOption {
+	return func(c *CoinGeckoClient) {
+		for symbol, id := range overrides {
+			c.idOverrides[strings.ToLower(symbol)] = id
+		}
+	}
+}
+
+// ParseCoinIDOverrides parses "symbol:id" entries, skipping malformed ones
+func ParseCoinIDOverrides(entries []string) map[string]string {
+	overrides := make(map[string]string)
+	for _, entry := range entries {
+		symbol, id, ok := strings.Cut(entry, ":")
+		symbol, id = strings.TrimSpace(symbol), strings.TrimSpace(id)
+		if !ok || symbol == "" || id == "" {
+			continue
+		}
+		overrides[strings.ToLower(symbol)] = id
+	}
+	return overrides
+}
+
+// coinID maps a symbol to a CoinGecko ID: configured overrides first, then the
+// static map, then the index built from /coins/list. Unknown symbols pass through.
+func (c *CoinGeckoClient) coinID(symbol string) string {
+	symbol = strings.ToLower(symbol)
+
+	if id, ok := c.idOverrides[symbol]; ok {
+		return id
+	}
+	if id, ok := staticCoinIDs[symbol]; ok {
+		return id
+	}
+
+	c.coinIndexMu.RLock()
+	id, ok := c.coinIndex[symbol]
+	c.coinIndexMu.RUnlock()
+	if ok {
+		return id
+	}
+	return symbol
+}
+
+// StartCoinListRefresher loads the coins list now and refreshes it on interval.
+// Until the first load succeeds lookups use the static mappings only.
+func (c *CoinGeckoClient) StartCoinListRefresher(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		c.refreshCoinIndex()
+
+		for {
+			select {
+			case <-ticker.C:
+				c.refreshCoinIndex()
+			case <-c.stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the coins list refresher
+func (c *CoinGeckoClient) Stop() {
+	close(c.stopChan)
+}
+
+// refreshCoinIndex rebuilds the symbol index, keeping the previous one on failure
+func (c *CoinGeckoClient) refreshCoinIndex() {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	index, err := c.buildCoinIndex(ctx)
+	if err != nil {
+		log.Printf("Failed to refresh CoinGecko coins list: %v", err)
+		return
+	}
+
+	c.coinIndexMu.Lock()
+	c.coinIndex = index
+	c.coinIndexMu.Unlock()
+
+	log.Printf("Loaded %d CoinGecko symbols", len(index))
+}
+
+// buildCoinIndex maps each symbol to a coin ID. When several coins share a
+// symbol the one with the highest market cap wins.
+func (c *CoinGeckoClient) buildCoinIndex(ctx context.Context) (map[string]string, error) {
+	var coins []struct {
+		ID     string `json:"id"`
+		Symbol string `json:"symbol"`
+	}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/coins/list", c.baseURL), &coins); err != nil {
+		return nil, err
+	}
+
+	// Ranking is best-effort; without it the first listed coin keeps the symbol
+	rank := make(map[string]int)
+	for page := 1; page <= coinGeckoRankedPages; page++ {
+		var markets []CoinMarket
+		url := fmt.Sprintf("%s/coins/markets?vs_currency=usd&order=market_cap_desc&per_page=250&page=%d", c.baseURL, page)
+		if err := c.getJSON(ctx, url, &markets); err != nil {
+			log.Printf("Failed to load CoinGecko market cap ranking: %v", err)
+			break
+		}
+		for _, market := range markets {
+			if _, ok := rank[market.ID]; !ok {
+				rank[market.ID] = len(rank) + 1
+			}
+		}
+	}
+
+	index := make(map[string]string, len(coins))
+	for _, coin := range coins {
+		symbol := strings.ToLower(coin.Symbol)
+		current, exists := index[symbol]
+		if !exists || ranksHigher(rank, coin.ID, current) {
+			index[symbol] = coin.ID
+		}
+	}
+	return index, nil
+}
+
+// ranksHigher reports whether coin a has a better market cap rank than coin b
+func ranksHigher(rank map[string]int, a, b string) bool {
+	rankA, okA := rank[a]
+	rankB, okB := rank[b]
+	if !okA {
+		return false
+	}
+	return !okB || rankA < rankB
+}
+
+// getJSON fetches url and decodes the JSON body into v
+func (c *CoinGeckoClient) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API error: %s", resp.Status)
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCoinGeckoHistoricalPricesOHLC(t *testing.T) {
+	to := time.Now().Truncate(time.Hour)
+	from := to.Add(-3 * 24 * time.Hour)
+	inRange := []time.Time{from.Add(4 * time.Hour), from.Add(8 * time.Hour)}
+
+	var gotPath, gotDays string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotDays = r.URL.Path, r.URL.Query().Get("days")
+		// [timestamp_ms, open, high, low, close]; the first candle is before
+		// the requested range and the last is malformed
+		fmt.Fprintf(w, `[
+			[%d, 90, 95, 85, 92],
+			[%d, 100, 110, 95, 105],
+			[%d, 105, 120, 101, 99.5],
+			[%d, 1, 2]
+		]`, from.Add(-4*time.Hour).UnixMilli(), inRange[0].UnixMilli(), inRange[1].UnixMilli(), to.UnixMilli())
+	}))
+	defer server.Close()
+
+	prices, err := NewCoinGeckoClient(server.URL).GetHistoricalPrices(context.Background(), "BTC", from, to)
+	if err != nil {
+		t.Fatalf("GetHistoricalPrices: %v", err)
+	}
+
+	if gotPath != "/coins/bitcoin/ohlc" {
+		t.Errorf("path = %q, want /coins/bitcoin/ohlc", gotPath)
+	}
+	if gotDays != "7" {
+		t.Errorf("days = %q, want 7 for a three-day range", gotDays)
+	}
+
+	want := []HistoricalPrice{
+		{Date: time.UnixMilli(inRange[0].UnixMilli()), Open: 100, High: 110, Low: 95, Close: 105},
+		{Date: time.UnixMilli(inRange[1].UnixMilli()), Open: 105, High: 120, Low: 101, Close: 99.5},
+	}
+	if !reflect.DeepEqual(prices, want) {
+		t.Fatalf("prices = %+v, want %+v", prices, want)
+	}
+}
+
+func TestCoinGeckoHistoricalPricesAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limited", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	to := time.Now()
+	if _, err := NewCoinGeckoClient(server.URL).GetHistoricalPrices(context.Background(), "BTC", to.Add(-time.Hour), to); err == nil {
+		t.Fatal("GetHistoricalPrices succeeded on a 429, want an error")
+	}
+}
+
+func TestOHLCDays(t *testing.T) {
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{ago: time.Hour, want: "1"},
+		{ago: 3 * 24 * time.Hour, want: "7"},
+		{ago: 30 * 24 * time.Hour, want: "90"},
+		{ago: 300 * 24 * time.Hour, want: "365"},
+		{ago: 400 * 24 * time.Hour, want: "max"},
+	}
+
+	for _, tt := range tests {
+		if got := ohlcDays(time.Now().Add(-tt.ago)); got != tt.want {
+			t.Errorf("ohlcDays(%v ago) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+}
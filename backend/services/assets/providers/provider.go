@@ -17,6 +17,7 @@ type PriceData struct {
 	Volume24h        float64
 	MarketCap        float64
 	UpdatedAt        time.Time
+	Stale            bool // served from the durable cache after a provider failure
 }
 
 // HistoricalPrice holds historical price data
@@ -36,6 +37,8 @@ type SearchResult struct {
 	Type     string
 	Exchange string
 	Currency string
+	// Source is the asset type of the provider that returned the result
+	Source string
 }
 
 // PriceProvider defines interface for price data providers
@@ -56,4 +59,82 @@ type PriceProvider interface {
 	ProviderName() string
 }
 
+// FallbackProvider uses a primary provider and falls back to a secondary one
+// for requests or symbols the primary cannot serve
+type FallbackProvider struct {
+	primary   PriceProvider
+	secondary PriceProvider
+}
+
+// NewFallbackProvider creates a provider that prefers primary over secondary
+func NewFallbackProvider(primary, secondary PriceProvider) *FallbackProvider {
+	return &FallbackProvider{
+		primary:   primary,
+		secondary: secondary,
+	}
+}
+
+// ProviderName returns the provider name
+func (p *FallbackProvider) ProviderName() string {
+	return p.primary.ProviderName() + "+" + p.secondary.ProviderName()
+}
+
+// GetPrice gets current price, trying the secondary provider on failure
+func (p *FallbackProvider) GetPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	price, err := p.primary.GetPrice(ctx, symbol)
+	if err == nil {
+		return price, nil
+	}
+	return p.secondary.GetPrice(ctx, symbol)
+}
+
+// GetPrices gets prices from the primary provider and fetches only the
+// missing symbols from the secondary one
+func (p *FallbackProvider) GetPrices(ctx context.Context, symbols []string) (map[string]*PriceData, error) {
+	results, err := p.primary.GetPrices(ctx, symbols)
+	if results == nil {
+		results = make(map[string]*PriceData)
+	}
+
+	var missing []string
+	for _, symbol := range symbols {
+		if _, ok := results[symbol]; !ok {
+			missing = append(missing, symbol)
+		}
+	}
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	fallback, fallbackErr := p.secondary.GetPrices(ctx, missing)
+	for symbol, price := range fallback {
+		results[symbol] = price
+	}
+
+	if len(results) == 0 && err != nil {
+		return nil, err
+	}
+	if len(results) == 0 && fallbackErr != nil {
+		return nil, fallbackErr
+	}
+	return results, nil
+}
+
+// GetHistoricalPrices gets historical prices, trying the secondary provider on failure
+func (p *FallbackProvider) GetHistoricalPrices(ctx context.Context, symbol string, from, to time.Time) ([]HistoricalPrice, error) {
+	prices, err := p.primary.GetHistoricalPrices(ctx, symbol, from, to)
+	if err == nil && len(prices) > 0 {
+		return prices, nil
+	}
+	return p.secondary.GetHistoricalPrices(ctx, symbol, from, to)
+}
+
+// Search searches for assets, trying the secondary provider on failure
+func (p *FallbackProvider) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	results, err := p.primary.Search(ctx, query)
+	if err == nil {
+		return results, nil
+	}
+	return p.secondary.Search(ctx, query)
+}
 
@@ -0,0 +1,230 @@
+package providers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	stooqBaseURL = "https://stooq.com"
+
+	// Symbols per quote request; Stooq accepts long lists but URLs have limits
+	stooqBatchSize = 50
+)
+
+// ErrSearchNotSupported is returned by providers without a search API
+var ErrSearchNotSupported = errors.New("search not supported by provider")
+
+// StooqClient provides stock/ETF quotes from Stooq, many symbols per request
+type StooqClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewStooqClient creates a new Stooq client
+func NewStooqClient() *StooqClient {
+	return &StooqClient{
+		baseURL: stooqBaseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// ProviderName returns the provider name
+func (c *StooqClient) ProviderName() string {
+	return "stooq"
+}
+
+// GetPrice gets current price for a stock symbol
+func (c *StooqClient) GetPrice(ctx context.Context, symbol string) (*PriceData, error) {
+	prices, err := c.GetPrices(ctx, []string{symbol})
+	if err != nil {
+		return nil, err
+	}
+
+	price, ok := prices[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no data found for symbol: %s", symbol)
+	}
+	return price, nil
+}
+
+// GetPrices gets quotes for many symbols in as few requests as possible.
+// Symbols without data are omitted from the result.
+func (c *StooqClient) GetPrices(ctx context.Context, symbols []string) (map[string]*PriceData, error) {
+	results := make(map[string]*PriceData)
+
+	for start := 0; start < len(symbols); start += stooqBatchSize {
+		end := start + stooqBatchSize
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+
+		if err := c.fetchQuotes(ctx, symbols[start:end], results); err != nil {
+			return results, err
+		}
+	}
+
+	return results, nil
+}
+
+// fetchQuotes fetches one batch of quotes into results, keyed by the requested symbol
+func (c *StooqClient) fetchQuotes(ctx context.Context, symbols []string, results map[string]*PriceData) error {
+	requested := make(map[string]string, len(symbols))
+	stooqSymbols := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		s := toStooqSymbol(symbol)
+		requested[strings.ToUpper(s)] = symbol
+		stooqSymbols = append(stooqSymbols, s)
+	}
+
+	// f=sd2t2ohlcv: symbol, date, time, open, high, low, close, volume
+	reqURL := fmt.Sprintf("%s/q/l/?s=%s&f=sd2t2ohlcv&h&e=csv",
+		c.baseURL, url.QueryEscape(strings.Join(stooqSymbols, " ")))
+
+	records, err := c.getCSV(ctx, reqURL)
+	if err != nil {
+		return fmt.Errorf("failed to fetch quotes: %w", err)
+	}
+
+	for _, record := range records {
+		if len(record) < 8 {
+			continue
+		}
+
+		symbol, ok := requested[strings.ToUpper(record[0])]
+		if !ok {
+			continue
+		}
+
+		closePrice, err := strconv.ParseFloat(record[6], 64)
+		if err != nil {
+			continue // "N/D" for unknown symbols
+		}
+		open, _ := strconv.ParseFloat(record[3], 64)
+		high, _ := strconv.ParseFloat(record[4], 64)
+		low, _ := strconv.ParseFloat(record[5], 64)
+		volume, _ := strconv.ParseFloat(record[7], 64)
+
+		// Stooq quotes carry no previous close, so change is measured from the open
+		var change, changePercent float64
+		if open > 0 {
+			change = closePrice - open
+			changePercent = change / open * 100
+		}
+
+		results[symbol] = &PriceData{
+			Symbol:           symbol,
+			Price:            closePrice,
+			Currency:         "USD",
+			Change24h:        change,
+			ChangePercent24h: changePercent,
+			High24h:          high,
+			Low24h:           low,
+			Volume24h:        volume,
+			UpdatedAt:        time.Now(),
+		}
+	}
+
+	return nil
+}
+
+// GetHistoricalPrices gets daily historical prices for a symbol
+func (c *StooqClient) GetHistoricalPrices(ctx context.Context, symbol string, from, to time.Time) ([]HistoricalPrice, error) {
+	reqURL := fmt.Sprintf("%s/q/d/l/?s=%s&d1=%s&d2=%s&i=d",
+		c.baseURL, url.QueryEscape(toStooqSymbol(symbol)), from.Format("20060102"), to.Format("20060102"))
+
+	records, err := c.getCSV(ctx, reqURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch historical data: %w", err)
+	}
+
+	// Columns: Date, Open, High, Low, Close, Volume
+	var prices []HistoricalPrice
+	for _, record := range records {
+		if len(record) < 5 {
+			continue
+		}
+
+		date, err := time.Parse("2006-01-02", record[0])
+		if err != nil {
+			continue
+		}
+
+		open, _ := strconv.ParseFloat(record[1], 64)
+		high, _ := strconv.ParseFloat(record[2], 64)
+		low, _ := strconv.ParseFloat(record[3], 64)
+		closePrice, _ := strconv.ParseFloat(record[4], 64)
+		var volume float64
+		if len(record) > 5 {
+			volume, _ = strconv.ParseFloat(record[5], 64)
+		}
+
+		prices = append(prices, HistoricalPrice{
+			Date:   date,
+			Open:   open,
+			High:   high,
+			Low:    low,
+			Close:  closePrice,
+			Volume: volume,
+		})
+	}
+
+	if len(prices) == 0 {
+		return nil, fmt.Errorf("no historical data found for symbol: %s", symbol)
+	}
+
+	return prices, nil
+}
+
+// Search is not offered by Stooq
+func (c *StooqClient) Search(ctx context.Context, query string) ([]SearchResult, error) {
+	return nil, ErrSearchNotSupported
+}
+
+// getCSV fetches a CSV document and returns its rows without the header
+func (c *StooqClient) getCSV(ctx context.Context, reqURL string) ([][]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: %s", resp.Status)
+	}
+
+	reader := csv.NewReader(resp.Body)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if len(records) <= 1 {
+		return nil, nil
+	}
+	return records[1:], nil
+}
+
+// toStooqSymbol maps a ticker to Stooq's notation; plain tickers are US listings
+func toStooqSymbol(symbol string) string {
+	s := strings.ToLower(strings.TrimSpace(symbol))
+	if !strings.Contains(s, ".") {
+		s += ".us"
+	}
+	return s
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/assets/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAlertNotFound = errors.New("price alert not found")
+)
+
+// AlertRepository handles database operations for price alerts
+type AlertRepository struct {
+	db *gorm.DB
+}
+
+// NewAlertRepository creates a new alert repository
+func NewAlertRepository(db *gorm.DB) *AlertRepository {
+	return &AlertRepository{db: db}
+}
+
+// Create creates a price alert
+func (r *AlertRepository) Create(ctx context.Context, alert *models.PriceAlert) error {
+	return r.db.WithContext(ctx).Create(alert).Error
+}
+
+// ListByUser lists a user's alerts, newest first
+func (r *AlertRepository) ListByUser(ctx context.Context, userID string) ([]models.PriceAlert, error) {
+	var alerts []models.PriceAlert
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at DESC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ListActive lists every active alert
+func (r *AlertRepository) ListActive(ctx context.Context) ([]models.PriceAlert, error) {
+	var alerts []models.PriceAlert
+	if err := r.db.WithContext(ctx).Where("active = ?", true).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// MarkTriggered records that an alert fired, deactivating it if requested
+func (r *AlertRepository) MarkTriggered(ctx context.Context, id string, price float64, at time.Time, deactivate bool) error {
+	updates := map[string]interface{}{
+		"last_triggered_at": at,
+		"last_price":        price,
+	}
+	if deactivate {
+		updates["active"] = false
+	}
+	return r.db.WithContext(ctx).Model(&models.PriceAlert{}).Where("id = ?", id).Updates(updates).Error
+}
+
+// Delete soft-deletes an alert
+func (r *AlertRepository) Delete(ctx context.Context, id, userID string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.PriceAlert{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrAlertNotFound
+	}
+	return nil
+}
@@ -3,14 +3,18 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/radmickey/money-control/backend/pkg/database"
 	"github.com/radmickey/money-control/backend/services/assets/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
 	ErrAssetNotFound = errors.New("asset not found")
+	// ErrConflict means the asset changed since it was loaded
+	ErrConflict = database.ErrConflict
 )
 
 // AssetRepository handles database operations for assets
@@ -23,10 +27,18 @@ func NewAssetRepository(db *gorm.DB) *AssetRepository {
 	return &AssetRepository{db: db}
 }
 
-// Create creates a new asset
+// Create creates a new asset along with its opening lot
 func (r *AssetRepository) Create(ctx context.Context, asset *models.Asset) error {
 	asset.CalculateProfitLoss()
-	return r.db.WithContext(ctx).Create(asset).Error
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(asset).Error; err != nil {
+			return err
+		}
+		if asset.Quantity <= 0 {
+			return nil
+		}
+		return tx.Create(openingLot(asset)).Error
+	})
 }
 
 // GetByID finds an asset by ID
@@ -66,6 +78,15 @@ func (r *AssetRepository) List(ctx context.Context, userID string, subAccountID
 	return assets, total, nil
 }
 
+// ListAll lists every asset for a user, ordered by symbol
+func (r *AssetRepository) ListAll(ctx context.Context, userID string) ([]models.Asset, error) {
+	var assets []models.Asset
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("symbol, created_at").Find(&assets).Error; err != nil {
+		return nil, err
+	}
+	return assets, nil
+}
+
 // GetBySymbol gets assets by symbol for a user
 func (r *AssetRepository) GetBySymbol(ctx context.Context, userID, symbol string) ([]models.Asset, error) {
 	var assets []models.Asset
@@ -97,36 +118,71 @@ func (r *AssetRepository) GetAllSymbols(ctx context.Context, userID string) (map
 	return symbols, nil
 }
 
-// Update updates an asset
+// Update updates an asset if it is still at the version it was loaded at
 func (r *AssetRepository) Update(ctx context.Context, asset *models.Asset) error {
 	asset.CalculateProfitLoss()
-	return r.db.WithContext(ctx).Save(asset).Error
+	return database.SaveVersioned(r.db.WithContext(ctx), asset, &asset.Version)
+}
+
+// UpdateWithLots updates an asset whose quantity or purchase price was edited
+// directly; its open lots are replaced by a single lot matching the new values
+func (r *AssetRepository) UpdateWithLots(ctx context.Context, asset *models.Asset) error {
+	asset.CalculateProfitLoss()
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := database.SaveVersioned(tx, asset, &asset.Version); err != nil {
+			return err
+		}
+		if err := tx.Where("asset_id = ? AND quantity > 0", asset.ID).Delete(&models.Lot{}).Error; err != nil {
+			return err
+		}
+		if asset.Quantity <= 0 {
+			return nil
+		}
+		return tx.Create(openingLot(asset)).Error
+	})
 }
 
 // UpdatePrice updates the current price and recalculates values
 func (r *AssetRepository) UpdatePrice(ctx context.Context, id string, price float64) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		var asset models.Asset
-		if err := tx.First(&asset, "id = ?", id).Error; err != nil {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&asset, "id = ?", id).Error; err != nil {
 			return err
 		}
 
 		asset.CurrentPrice = price
 		asset.CalculateProfitLoss()
 
-		return tx.Save(&asset).Error
+		return database.SaveVersioned(tx, &asset, &asset.Version)
 	})
 }
 
-// BulkUpdatePrices updates prices for multiple assets by symbol
-func (r *AssetRepository) BulkUpdatePrices(ctx context.Context, prices map[string]float64) error {
+// BulkUpdatePrices updates prices by symbol for one user's assets and
+// recalculates their derived values
+func (r *AssetRepository) BulkUpdatePrices(ctx context.Context, userID string, prices map[string]float64) error {
+	if len(prices) == 0 {
+		return nil
+	}
+
+	symbols := make([]string, 0, len(prices))
+	for symbol := range prices {
+		symbols = append(symbols, symbol)
+	}
+
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		for symbol, price := range prices {
-			if err := tx.Model(&models.Asset{}).
-				Where("symbol = ?", symbol).
-				Updates(map[string]interface{}{
-					"current_price": price,
-				}).Error; err != nil {
+		var assets []models.Asset
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("user_id = ? AND symbol IN ?", userID, symbols).Find(&assets).Error; err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for i := range assets {
+			asset := &assets[i]
+			asset.CurrentPrice = prices[asset.Symbol]
+			asset.PriceUpdatedAt = &now
+			asset.CalculateProfitLoss()
+
+			if err := database.SaveVersioned(tx, asset, &asset.Version); err != nil {
 				return err
 			}
 		}
@@ -143,6 +199,24 @@ func (r *AssetRepository) Delete(ctx context.Context, id, userID string) error {
 	return result.Error
 }
 
+// DeleteBySubAccounts soft-deletes a user's assets in the given sub-accounts,
+// marking them deleted at the given time
+func (r *AssetRepository) DeleteBySubAccounts(ctx context.Context, userID string, subAccountIDs []string, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Model(&models.Asset{}).
+		Where("user_id = ? AND sub_account_id IN ?", userID, subAccountIDs).
+		Update("deleted_at", at)
+	return result.RowsAffected, result.Error
+}
+
+// RestoreBySubAccounts undeletes a user's assets in the given sub-accounts
+// that were deleted at the given time
+func (r *AssetRepository) RestoreBySubAccounts(ctx context.Context, userID string, subAccountIDs []string, at time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Unscoped().Model(&models.Asset{}).
+		Where("user_id = ? AND sub_account_id IN ? AND deleted_at = ?", userID, subAccountIDs, at).
+		Update("deleted_at", nil)
+	return result.RowsAffected, result.Error
+}
+
 // GetTotalValue gets total portfolio value for a user
 func (r *AssetRepository) GetTotalValue(ctx context.Context, userID string) (float64, error) {
 	var total float64
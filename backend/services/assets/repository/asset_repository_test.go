@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	"github.com/radmickey/money-control/backend/services/assets/models"
+)
+
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+func TestConcurrentAssetUpdatesConflict(t *testing.T) {
+	db := databasetest.Open(t, &models.Asset{}, &models.Lot{})
+	repo := NewAssetRepository(db)
+	ctx := context.Background()
+
+	asset := &models.Asset{UserID: testUserID, Symbol: "AAPL", Type: models.AssetTypeStock, Quantity: 10, PurchasePrice: 100, Currency: "USD"}
+	if err := repo.Create(ctx, asset); err != nil {
+		t.Fatal(err)
+	}
+
+	// Two clients load the same version and edit it at once
+	first, err := repo.GetByID(ctx, asset.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := repo.GetByID(ctx, asset.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Name = "First"
+	second.Name = "Second"
+
+	errs := make([]error, 2)
+	start := make(chan struct{})
+	var wg sync.WaitGroup
+	for i, update := range []*models.Asset{first, second} {
+		wg.Add(1)
+		go func(i int, update *models.Asset) {
+			defer wg.Done()
+			<-start
+			errs[i] = repo.Update(ctx, update)
+		}(i, update)
+	}
+	close(start)
+	wg.Wait()
+
+	var winner, loser *models.Asset
+	switch {
+	case errs[0] == nil && errors.Is(errs[1], ErrConflict):
+		winner, loser = first, second
+	case errs[1] == nil && errors.Is(errs[0], ErrConflict):
+		winner, loser = second, first
+	default:
+		t.Fatalf("errs = %v, want one success and one ErrConflict", errs)
+	}
+
+	stored, err := repo.GetByID(ctx, asset.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Name != winner.Name || stored.Version != winner.Version {
+		t.Fatalf("stored %q at version %d, want %q at %d", stored.Name, stored.Version, winner.Name, winner.Version)
+	}
+	// The losing copy keeps the version it was loaded at
+	if loser.Version != winner.Version-1 {
+		t.Fatalf("loser version = %d, want %d", loser.Version, winner.Version-1)
+	}
+}
+
+func TestBulkUpdatePricesScopedToUser(t *testing.T) {
+	db := databasetest.Open(t, &models.Asset{}, &models.Lot{})
+	repo := NewAssetRepository(db)
+	ctx := context.Background()
+
+	const otherUserID = "00000000-0000-0000-0000-000000000002"
+	create := func(userID string, purchasePrice float64) *models.Asset {
+		t.Helper()
+		asset := &models.Asset{UserID: userID, Symbol: "AAPL", Type: models.AssetTypeStock, Quantity: 10, PurchasePrice: purchasePrice, CurrentPrice: purchasePrice, Currency: "USD"}
+		if err := repo.Create(ctx, asset); err != nil {
+			t.Fatal(err)
+		}
+		return asset
+	}
+	mine := create(testUserID, 100)
+	theirs := create(otherUserID, 120)
+
+	if err := repo.BulkUpdatePrices(ctx, testUserID, map[string]float64{"AAPL": 150}); err != nil {
+		t.Fatal(err)
+	}
+
+	updated, err := repo.GetByID(ctx, mine.ID, testUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.CurrentPrice != 150 || updated.TotalValue != 1500 || updated.ProfitLoss != 500 {
+		t.Errorf("own asset = price %v, value %v, P/L %v; want 150, 1500, 500", updated.CurrentPrice, updated.TotalValue, updated.ProfitLoss)
+	}
+	if updated.PriceUpdatedAt == nil {
+		t.Error("own asset has no price update time")
+	}
+
+	// The other user's holding of the same symbol is untouched
+	untouched, err := repo.GetByID(ctx, theirs.ID, otherUserID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if untouched.CurrentPrice != 120 || untouched.TotalValue != 1200 || untouched.Version != theirs.Version {
+		t.Errorf("other user's asset = price %v, value %v, version %d; want 120, 1200, %d", untouched.CurrentPrice, untouched.TotalValue, untouched.Version, theirs.Version)
+	}
+	if untouched.PriceUpdatedAt != nil {
+		t.Error("other user's asset got a price update time")
+	}
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/assets/models"
+	"gorm.io/gorm"
+)
+
+// DividendRepository handles database operations for dividends
+type DividendRepository struct {
+	db *gorm.DB
+}
+
+// NewDividendRepository creates a new dividend repository
+func NewDividendRepository(db *gorm.DB) *DividendRepository {
+	return &DividendRepository{db: db}
+}
+
+// Create records a dividend
+func (r *DividendRepository) Create(ctx context.Context, dividend *models.Dividend) error {
+	return r.db.WithContext(ctx).Create(dividend).Error
+}
+
+// ListByAsset lists an asset's dividends, most recent first
+func (r *DividendRepository) ListByAsset(ctx context.Context, assetID, userID string) ([]models.Dividend, error) {
+	var dividends []models.Dividend
+	if err := r.db.WithContext(ctx).
+		Where("asset_id = ? AND user_id = ?", assetID, userID).
+		Order("pay_date DESC").
+		Find(&dividends).Error; err != nil {
+		return nil, err
+	}
+	return dividends, nil
+}
+
+// SumByCurrency sums a user's dividends paid in [start, end), per currency
+func (r *DividendRepository) SumByCurrency(ctx context.Context, userID string, start, end time.Time) (map[string]float64, error) {
+	type Result struct {
+		Currency string
+		Total    float64
+	}
+
+	var results []Result
+	if err := r.db.WithContext(ctx).Model(&models.Dividend{}).
+		Select("currency, SUM(amount) as total").
+		Where("user_id = ? AND pay_date >= ? AND pay_date < ?", userID, start, end).
+		Group("currency").
+		Scan(&results).Error; err != nil {
+		return nil, err
+	}
+
+	totals := make(map[string]float64)
+	for _, r := range results {
+		totals[r.Currency] = r.Total
+	}
+	return totals, nil
+}
@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/services/assets/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	ErrInsufficientQuantity = errors.New("insufficient quantity")
+)
+
+// quantityEpsilon absorbs decimal rounding when a lot is fully consumed
+const quantityEpsilon = 1e-8
+
+// LotRepository handles database operations for purchase lots and realized gains
+type LotRepository struct {
+	db *gorm.DB
+}
+
+// NewLotRepository creates a new lot repository
+func NewLotRepository(db *gorm.DB) *LotRepository {
+	return &LotRepository{db: db}
+}
+
+// ListByAsset lists an asset's lots, oldest first
+func (r *LotRepository) ListByAsset(ctx context.Context, assetID, userID string) ([]models.Lot, error) {
+	var lots []models.Lot
+	if err := r.db.WithContext(ctx).
+		Where("asset_id = ? AND user_id = ?", assetID, userID).
+		Order("date, created_at").
+		Find(&lots).Error; err != nil {
+		return nil, err
+	}
+	return lots, nil
+}
+
+// Sell consumes the asset's open lots FIFO, records the realized gain and
+// reduces the asset's quantity and cost basis accordingly
+func (r *LotRepository) Sell(ctx context.Context, assetID, userID string, quantity, price float64, soldAt time.Time) (*models.Asset, *models.RealizedGain, error) {
+	var asset models.Asset
+	var gain *models.RealizedGain
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND user_id = ?", assetID, userID).
+			First(&asset).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrAssetNotFound
+			}
+			return err
+		}
+
+		var lots []models.Lot
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("asset_id = ? AND quantity > 0", assetID).
+			Order("date, created_at").
+			Find(&lots).Error; err != nil {
+			return err
+		}
+
+		var available float64
+		for _, lot := range lots {
+			available += lot.Quantity
+		}
+		if quantity > available+quantityEpsilon {
+			return ErrInsufficientQuantity
+		}
+
+		remaining := quantity
+		var costBasis float64
+		for i := range lots {
+			if remaining <= quantityEpsilon {
+				break
+			}
+			lot := &lots[i]
+			used := min(lot.Quantity, remaining)
+			costBasis += used * lot.Price
+			remaining -= used
+
+			lot.Quantity -= used
+			if lot.Quantity < quantityEpsilon {
+				lot.Quantity = 0
+			}
+			if err := tx.Model(lot).Update("quantity", lot.Quantity).Error; err != nil {
+				return err
+			}
+		}
+
+		// Remaining cost basis is the weighted average of the open lots
+		var openQuantity, openCost float64
+		for _, lot := range lots {
+			openQuantity += lot.Quantity
+			openCost += lot.Quantity * lot.Price
+		}
+		asset.Quantity = openQuantity
+		if openQuantity > 0 {
+			asset.PurchasePrice = openCost / openQuantity
+		}
+		asset.CalculateProfitLoss()
+		if err := database.SaveVersioned(tx, &asset, &asset.Version); err != nil {
+			return err
+		}
+
+		proceeds := quantity * price
+		gain = &models.RealizedGain{
+			AssetID:   asset.ID,
+			UserID:    userID,
+			Symbol:    asset.Symbol,
+			Quantity:  quantity,
+			SalePrice: price,
+			Proceeds:  proceeds,
+			CostBasis: costBasis,
+			GainLoss:  proceeds - costBasis,
+			Currency:  asset.Currency,
+			SoldAt:    soldAt,
+		}
+		return tx.Create(gain).Error
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &asset, gain, nil
+}
+
+// GetRealizedTotal sums realized gains and losses for a user
+func (r *LotRepository) GetRealizedTotal(ctx context.Context, userID string) (float64, error) {
+	var total float64
+	if err := r.db.WithContext(ctx).Model(&models.RealizedGain{}).
+		Where("user_id = ?", userID).
+		Select("COALESCE(SUM(gain_loss), 0)").
+		Scan(&total).Error; err != nil {
+		return 0, err
+	}
+	return total, nil
+}
+
+// BackfillLots creates an opening lot for every asset that predates lot
+// tracking, so single-purchase assets keep their cost basis
+func (r *LotRepository) BackfillLots(ctx context.Context) (int64, error) {
+	result := r.db.WithContext(ctx).Exec(`
+		INSERT INTO asset_lots (asset_id, user_id, original_quantity, quantity, price, date, created_at, updated_at)
+		SELECT a.id, a.user_id, a.quantity, a.quantity, COALESCE(a.purchase_price, 0),
+			COALESCE(a.purchase_date, a.created_at), NOW(), NOW()
+		FROM assets a
+		WHERE a.deleted_at IS NULL AND a.quantity > 0
+			AND NOT EXISTS (SELECT 1 FROM asset_lots l WHERE l.asset_id = a.id AND l.deleted_at IS NULL)`)
+	return result.RowsAffected, result.Error
+}
+
+// openingLot builds the lot representing an asset's current position
+func openingLot(asset *models.Asset) *models.Lot {
+	date := asset.CreatedAt
+	if asset.PurchaseDate != nil {
+		date = *asset.PurchaseDate
+	}
+	if date.IsZero() {
+		date = time.Now()
+	}
+	return &models.Lot{
+		AssetID:          asset.ID,
+		UserID:           asset.UserID,
+		OriginalQuantity: asset.Quantity,
+		Quantity:         asset.Quantity,
+		Price:            asset.PurchasePrice,
+		Date:             date,
+	}
+}
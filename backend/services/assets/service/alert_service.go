@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/assets/models"
+	"github.com/radmickey/money-control/backend/services/assets/notifier"
+	"github.com/radmickey/money-control/backend/services/assets/repository"
+)
+
+var (
+	ErrInvalidDirection = errors.New("direction must be 'above' or 'below'")
+	ErrInvalidThreshold = errors.New("threshold must be positive")
+)
+
+const defaultAlertCooldown = time.Hour
+
+// AlertService manages price alerts and evaluates them against current prices
+type AlertService struct {
+	alertRepo    *repository.AlertRepository
+	assetService *AssetService
+	notifier     notifier.Notifier
+	cooldown     time.Duration
+	stopChan     chan struct{}
+}
+
+// NewAlertService creates a new alert service. cooldown is the minimum time
+// between two notifications for the same repeating alert.
+func NewAlertService(
+	alertRepo *repository.AlertRepository,
+	assetService *AssetService,
+	n notifier.Notifier,
+	cooldown time.Duration,
+) *AlertService {
+	if n == nil {
+		n = notifier.LogNotifier{}
+	}
+	if cooldown <= 0 {
+		cooldown = defaultAlertCooldown
+	}
+	return &AlertService{
+		alertRepo:    alertRepo,
+		assetService: assetService,
+		notifier:     n,
+		cooldown:     cooldown,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// CreateAlertInput holds input for creating a price alert
+type CreateAlertInput struct {
+	UserID    string
+	Symbol    string
+	AssetType models.AssetType
+	Direction models.AlertDirection
+	Threshold float64
+	OneShot   bool
+}
+
+// CreateAlert creates a price alert
+func (s *AlertService) CreateAlert(ctx context.Context, input CreateAlertInput) (*models.PriceAlert, error) {
+	if input.Direction != models.AlertDirectionAbove && input.Direction != models.AlertDirectionBelow {
+		return nil, ErrInvalidDirection
+	}
+	if input.Threshold <= 0 {
+		return nil, ErrInvalidThreshold
+	}
+	if input.AssetType == "" {
+		input.AssetType = models.AssetTypeStock
+	}
+
+	alert := &models.PriceAlert{
+		UserID:    input.UserID,
+		Symbol:    strings.ToUpper(input.Symbol),
+		AssetType: input.AssetType,
+		Direction: input.Direction,
+		Threshold: input.Threshold,
+		OneShot:   input.OneShot,
+		Active:    true,
+	}
+
+	if err := s.alertRepo.Create(ctx, alert); err != nil {
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+// ListAlerts lists a user's price alerts
+func (s *AlertService) ListAlerts(ctx context.Context, userID string) ([]models.PriceAlert, error) {
+	return s.alertRepo.ListByUser(ctx, userID)
+}
+
+// DeleteAlert deletes a price alert
+func (s *AlertService) DeleteAlert(ctx context.Context, id, userID string) error {
+	return s.alertRepo.Delete(ctx, id, userID)
+}
+
+// StartEvaluator checks active alerts against current prices periodically
+func (s *AlertService) StartEvaluator(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				if err := s.EvaluateAlerts(ctx); err != nil {
+					log.Printf("Failed to evaluate price alerts: %v", err)
+				}
+				cancel()
+			case <-s.stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// Stop stops the alert evaluator
+func (s *AlertService) Stop() {
+	close(s.stopChan)
+}
+
+// EvaluateAlerts fires every active alert whose threshold has been crossed
+func (s *AlertService) EvaluateAlerts(ctx context.Context) error {
+	alerts, err := s.alertRepo.ListActive(ctx)
+	if err != nil {
+		return err
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	queries := make(map[string]string)
+	for _, alert := range alerts {
+		queries[alert.Symbol] = string(alert.AssetType)
+	}
+
+	prices, _, err := s.assetService.GetMultipleAssetPrices(ctx, queries)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range alerts {
+		alert := &alerts[i]
+		price, ok := prices[alert.Symbol]
+		// Stale prices are not evidence that the threshold was crossed
+		if !ok || price.Stale || !alert.Triggered(price.Price) {
+			continue
+		}
+		if alert.LastTriggeredAt != nil && now.Sub(*alert.LastTriggeredAt) < s.cooldown {
+			continue
+		}
+
+		msg := notifier.Message{
+			UserID:  alert.UserID,
+			Subject: fmt.Sprintf("%s price alert", alert.Symbol),
+			Body: fmt.Sprintf("%s is %s %.2f %s (now %.2f %s)",
+				alert.Symbol, alert.Direction, alert.Threshold, price.Currency, price.Price, price.Currency),
+		}
+		if err := s.notifier.Notify(ctx, msg); err != nil {
+			log.Printf("Failed to send price alert %s: %v", alert.ID, err)
+			continue
+		}
+
+		if err := s.alertRepo.MarkTriggered(ctx, alert.ID, price.Price, now, alert.OneShot); err != nil {
+			log.Printf("Failed to mark price alert %s as triggered: %v", alert.ID, err)
+		}
+	}
+
+	return nil
+}
@@ -2,39 +2,75 @@ package service
 
 import (
 	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/events"
 	"github.com/radmickey/money-control/backend/services/assets/models"
 	"github.com/radmickey/money-control/backend/services/assets/providers"
 	"github.com/radmickey/money-control/backend/services/assets/repository"
 )
 
+var (
+	ErrInvalidQuantity = errors.New("quantity must be positive")
+	ErrInvalidAmount   = errors.New("amount must be positive")
+)
+
 const (
 	priceCacheTTL = 5 * time.Minute
+
+	// Daily candles change at most once a day
+	historyCacheTTL = time.Hour
+	maxHistoryRange = 5 * 365 * 24 * time.Hour
+
+	defaultPriceMaxStaleness = 24 * time.Hour
 )
 
 // AssetService handles asset business logic
 type AssetService struct {
 	assetRepo      *repository.AssetRepository
+	lotRepo        *repository.LotRepository
+	dividendRepo   *repository.DividendRepository
 	priceCacheRepo *repository.PriceCacheRepository
 	priceManager   *providers.PriceManager
 	redisCache     *cache.Cache
+	maxStaleness   time.Duration
+	publisher      *events.Publisher
+
+	// Collapses concurrent cache misses for a symbol into one provider call
+	priceFlight cache.Flight[providers.PriceData]
 }
 
-// NewAssetService creates a new asset service
+// NewAssetService creates a new asset service. maxStaleness bounds how old a
+// durably cached price may be when served after a provider failure. Changes
+// are announced through publisher.
 func NewAssetService(
 	assetRepo *repository.AssetRepository,
+	lotRepo *repository.LotRepository,
+	dividendRepo *repository.DividendRepository,
 	priceCacheRepo *repository.PriceCacheRepository,
 	priceManager *providers.PriceManager,
 	redisCache *cache.Cache,
+	maxStaleness time.Duration,
+	publisher *events.Publisher,
 ) *AssetService {
+	if maxStaleness <= 0 {
+		maxStaleness = defaultPriceMaxStaleness
+	}
 	return &AssetService{
 		assetRepo:      assetRepo,
+		lotRepo:        lotRepo,
+		dividendRepo:   dividendRepo,
 		priceCacheRepo: priceCacheRepo,
 		priceManager:   priceManager,
 		redisCache:     redisCache,
+		maxStaleness:   maxStaleness,
+		publisher:      publisher,
 	}
 }
 
@@ -82,6 +118,7 @@ func (s *AssetService) CreateAsset(ctx context.Context, input CreateAssetInput)
 		return nil, err
 	}
 
+	s.publisher.Publish(ctx, asset.UserID, events.AssetCreated, assetData(asset))
 	return asset, nil
 }
 
@@ -123,20 +160,31 @@ type UpdateAssetInput struct {
 	CurrentPrice  float64
 	Name          string
 	Metadata      *string
+	// Version is the version the client last read; zero skips the check
+	Version int64
 }
 
-// UpdateAsset updates an asset
+// UpdateAsset updates an asset. It fails with ErrConflict when the asset
+// changed since the client read it at input.Version, or while this update was
+// in progress; the client should reload and retry.
 func (s *AssetService) UpdateAsset(ctx context.Context, input UpdateAssetInput) (*models.Asset, error) {
 	asset, err := s.assetRepo.GetByID(ctx, input.ID, input.UserID)
 	if err != nil {
 		return nil, err
 	}
+	if input.Version != 0 && input.Version != asset.Version {
+		return nil, repository.ErrConflict
+	}
 
-	if input.Quantity > 0 {
+	// Editing quantity or cost directly resets the lots to a single position
+	resetLots := false
+	if input.Quantity > 0 && input.Quantity != asset.Quantity {
 		asset.Quantity = input.Quantity
+		resetLots = true
 	}
-	if input.PurchasePrice > 0 {
+	if input.PurchasePrice > 0 && input.PurchasePrice != asset.PurchasePrice {
 		asset.PurchasePrice = input.PurchasePrice
+		resetLots = true
 	}
 	if input.CurrentPrice > 0 {
 		asset.CurrentPrice = input.CurrentPrice
@@ -148,16 +196,136 @@ func (s *AssetService) UpdateAsset(ctx context.Context, input UpdateAssetInput)
 		asset.Metadata = input.Metadata
 	}
 
-	if err := s.assetRepo.Update(ctx, asset); err != nil {
+	update := s.assetRepo.Update
+	if resetLots {
+		update = s.assetRepo.UpdateWithLots
+	}
+	if err := update(ctx, asset); err != nil {
 		return nil, err
 	}
 
+	s.publisher.Publish(ctx, asset.UserID, events.AssetUpdated, assetData(asset))
 	return asset, nil
 }
 
+// SellAsset sells quantity of an asset at price, consuming purchase lots FIFO.
+// A zero price sells at the asset's current price.
+func (s *AssetService) SellAsset(ctx context.Context, userID, assetID string, quantity, price float64) (*models.Asset, *models.RealizedGain, error) {
+	if quantity <= 0 {
+		return nil, nil, ErrInvalidQuantity
+	}
+
+	if price <= 0 {
+		asset, err := s.assetRepo.GetByID(ctx, assetID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		price = asset.CurrentPrice
+	}
+
+	asset, gain, err := s.lotRepo.Sell(ctx, assetID, userID, quantity, price, time.Now())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	s.publisher.Publish(ctx, userID, events.AssetUpdated, assetData(asset))
+	return asset, gain, nil
+}
+
+// ListLots lists the purchase lots of an asset
+func (s *AssetService) ListLots(ctx context.Context, userID, assetID string) ([]models.Lot, error) {
+	if _, err := s.assetRepo.GetByID(ctx, assetID, userID); err != nil {
+		return nil, err
+	}
+	return s.lotRepo.ListByAsset(ctx, assetID, userID)
+}
+
+// RecordDividendInput holds input for recording a dividend
+type RecordDividendInput struct {
+	UserID   string
+	AssetID  string
+	Amount   float64
+	Currency string
+	ExDate   *time.Time
+	PayDate  time.Time
+	Notes    string
+}
+
+// RecordDividend records a dividend or coupon payment against an asset
+func (s *AssetService) RecordDividend(ctx context.Context, input RecordDividendInput) (*models.Dividend, error) {
+	if input.Amount <= 0 {
+		return nil, ErrInvalidAmount
+	}
+
+	asset, err := s.assetRepo.GetByID(ctx, input.AssetID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Currency == "" {
+		input.Currency = asset.Currency
+	}
+	if input.PayDate.IsZero() {
+		input.PayDate = time.Now()
+	}
+
+	dividend := &models.Dividend{
+		AssetID:  asset.ID,
+		UserID:   input.UserID,
+		Symbol:   asset.Symbol,
+		Amount:   input.Amount,
+		Currency: input.Currency,
+		ExDate:   input.ExDate,
+		PayDate:  input.PayDate,
+		Notes:    input.Notes,
+	}
+
+	if err := s.dividendRepo.Create(ctx, dividend); err != nil {
+		return nil, err
+	}
+
+	return dividend, nil
+}
+
+// ListDividends lists the dividends recorded for an asset
+func (s *AssetService) ListDividends(ctx context.Context, userID, assetID string) ([]models.Dividend, error) {
+	if _, err := s.assetRepo.GetByID(ctx, assetID, userID); err != nil {
+		return nil, err
+	}
+	return s.dividendRepo.ListByAsset(ctx, assetID, userID)
+}
+
+// GetDividendIncome sums dividends paid in [start, end), per currency
+func (s *AssetService) GetDividendIncome(ctx context.Context, userID string, start, end time.Time) (map[string]float64, error) {
+	return s.dividendRepo.SumByCurrency(ctx, userID, start, end)
+}
+
 // DeleteAsset deletes an asset
 func (s *AssetService) DeleteAsset(ctx context.Context, id, userID string) error {
-	return s.assetRepo.Delete(ctx, id, userID)
+	if err := s.assetRepo.Delete(ctx, id, userID); err != nil {
+		return err
+	}
+
+	s.publisher.Publish(ctx, userID, events.AssetDeleted, events.DeletedData{ID: id})
+	return nil
+}
+
+// DeleteSubAccountAssets deletes the assets held in sub-accounts removed with
+// their account
+func (s *AssetService) DeleteSubAccountAssets(ctx context.Context, userID string, subAccountIDs []string, at time.Time) (int64, error) {
+	if len(subAccountIDs) == 0 {
+		return 0, nil
+	}
+	return s.assetRepo.DeleteBySubAccounts(ctx, userID, subAccountIDs, at)
+}
+
+// RestoreSubAccountAssets restores the assets DeleteSubAccountAssets deleted
+// at the same time
+func (s *AssetService) RestoreSubAccountAssets(ctx context.Context, userID string, subAccountIDs []string, at time.Time) (int64, error) {
+	if len(subAccountIDs) == 0 {
+		return 0, nil
+	}
+	return s.assetRepo.RestoreBySubAccounts(ctx, userID, subAccountIDs, at)
 }
 
 // GetAssetPrice gets current price for an asset
@@ -168,28 +336,65 @@ func (s *AssetService) GetAssetPrice(ctx context.Context, symbol string, assetTy
 // GetMultipleAssetPrices gets prices for multiple assets
 func (s *AssetService) GetMultipleAssetPrices(ctx context.Context, queries map[string]string) (map[string]*providers.PriceData, []string, error) {
 	results := make(map[string]*providers.PriceData)
-	var failedSymbols []string
-	var mu sync.Mutex
-	var wg sync.WaitGroup
+	misses := make(map[string]string)
+
+	// Serve what we can from Redis in one read, then batch the rest through
+	// the providers
+	keys := make([]string, 0, len(queries))
+	for symbol := range queries {
+		keys = append(keys, cache.AssetPriceKey(symbol))
+	}
+	cachedPrices := make(map[string]*providers.PriceData)
+	if err := s.redisCache.MGet(ctx, keys, &cachedPrices); err != nil {
+		log.Printf("Failed to read cached prices: %v", err)
+	}
 
 	for symbol, assetType := range queries {
-		wg.Add(1)
-		go func(sym, aType string) {
-			defer wg.Done()
+		if price := cachedPrices[cache.AssetPriceKey(symbol)]; price != nil {
+			results[symbol] = price
+			continue
+		}
+		misses[symbol] = assetType
+	}
 
-			price, err := s.getPrice(ctx, sym, aType)
-			mu.Lock()
-			defer mu.Unlock()
+	if len(misses) > 0 {
+		fetched, err := s.priceManager.GetPricesConcurrently(ctx, misses)
+		if err != nil {
+			return nil, nil, err
+		}
 
-			if err != nil {
-				failedSymbols = append(failedSymbols, sym)
-				return
+		items := make(map[string]interface{}, len(fetched))
+		for symbol, price := range fetched {
+			results[symbol] = price
+			items[cache.AssetPriceKey(symbol)] = price
+			s.persistPrice(ctx, symbol, misses[symbol], price)
+		}
+		_ = s.redisCache.MSet(ctx, items, priceCacheTTL)
+	}
+
+	// Fall back to the durable cache for anything the providers could not price
+	var unpriced []string
+	for symbol := range queries {
+		if _, ok := results[symbol]; !ok {
+			unpriced = append(unpriced, symbol)
+		}
+	}
+
+	var failedSymbols []string
+	if len(unpriced) > 0 {
+		cached, err := s.priceCacheRepo.GetMultiple(ctx, unpriced)
+		if err != nil {
+			log.Printf("Failed to read price cache: %v", err)
+		}
+		for _, symbol := range unpriced {
+			if price := s.stalePrice(cached[symbol]); price != nil {
+				results[symbol] = price
+				continue
 			}
-			results[sym] = price
-		}(symbol, assetType)
+			failedSymbols = append(failedSymbols, symbol)
+		}
 	}
 
-	wg.Wait()
 	return results, failedSymbols, nil
 }
 
@@ -228,33 +433,62 @@ func (s *AssetService) RefreshAssetPrices(ctx context.Context, userID string, as
 	// Update prices in database
 	priceUpdates := make(map[string]float64)
 	for symbol, priceData := range prices {
+		// Stale fallbacks are not a successful refresh
+		if priceData.Stale {
+			failedSymbols = append(failedSymbols, symbol)
+			continue
+		}
 		priceUpdates[symbol] = priceData.Price
-
-		// Update price cache
-		_ = s.priceCacheRepo.Upsert(ctx, &models.PriceCache{
-			Symbol:           symbol,
-			AssetType:        symbols[symbol],
-			Price:            priceData.Price,
-			Currency:         priceData.Currency,
-			Change24h:        priceData.Change24h,
-			ChangePercent24h: priceData.ChangePercent24h,
-			High24h:          priceData.High24h,
-			Low24h:           priceData.Low24h,
-			Volume24h:        priceData.Volume24h,
-			MarketCap:        priceData.MarketCap,
-			UpdatedAt:        time.Now(),
-		})
 	}
 
-	if err := s.assetRepo.BulkUpdatePrices(ctx, priceUpdates); err != nil {
+	if err := s.assetRepo.BulkUpdatePrices(ctx, userID, priceUpdates); err != nil {
 		return 0, nil, err
 	}
 
-	return len(prices), failedSymbols, nil
+	if len(priceUpdates) > 0 {
+		s.publishPricesRefreshed(ctx, userID, priceUpdates)
+	}
+
+	return len(priceUpdates), failedSymbols, nil
 }
 
-// GetAssetHistory gets historical prices for an asset
+// publishPricesRefreshed announces refreshed prices with the new portfolio value
+func (s *AssetService) publishPricesRefreshed(ctx context.Context, userID string, prices map[string]float64) {
+	totalValue, err := s.assetRepo.GetTotalValue(ctx, userID)
+	if err != nil {
+		log.Printf("Failed to get portfolio value for price update: %v", err)
+		return
+	}
+
+	s.publisher.Publish(ctx, userID, events.PricesRefreshed, events.PricesData{
+		Prices:         prices,
+		PortfolioValue: totalValue,
+	})
+}
+
+// assetData builds the event payload for an asset
+func assetData(asset *models.Asset) events.AssetData {
+	return events.AssetData{
+		ID:           asset.ID,
+		Symbol:       asset.Symbol,
+		Type:         string(asset.Type),
+		Quantity:     asset.Quantity,
+		CurrentPrice: asset.CurrentPrice,
+		Currency:     asset.Currency,
+	}
+}
+
+// GetAssetHistory gets historical prices for an asset. The range is
+// truncated to whole days, clamped to maxHistoryRange and cached.
 func (s *AssetService) GetAssetHistory(ctx context.Context, symbol string, assetType models.AssetType, startDate, endDate time.Time) ([]providers.HistoricalPrice, error) {
+	startDate, endDate = clampHistoryRange(startDate, endDate)
+
+	cacheKey := cache.AssetHistoryKey(string(assetType), symbol, startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	var cached []providers.HistoricalPrice
+	if err := s.redisCache.Get(ctx, cacheKey, &cached); err == nil {
+		return cached, nil
+	}
+
 	var provider providers.PriceProvider
 	switch assetType {
 	case models.AssetTypeCrypto:
@@ -263,7 +497,43 @@ func (s *AssetService) GetAssetHistory(ctx context.Context, symbol string, asset
 		provider = s.priceManager.GetStockProvider()
 	}
 
-	return provider.GetHistoricalPrices(ctx, symbol, startDate, endDate)
+	history, err := provider.GetHistoricalPrices(ctx, symbol, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	_ = s.redisCache.Set(ctx, cacheKey, history, historyCacheTTL)
+	return history, nil
+}
+
+// GetAssetHistoryByID gets historical prices for one of the user's assets
+func (s *AssetService) GetAssetHistoryByID(ctx context.Context, id, userID string, startDate, endDate time.Time) (*models.Asset, []providers.HistoricalPrice, error) {
+	asset, err := s.assetRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	history, err := s.GetAssetHistory(ctx, asset.Symbol, asset.Type, startDate, endDate)
+	if err != nil {
+		return nil, nil, err
+	}
+	return asset, history, nil
+}
+
+// clampHistoryRange fills in missing bounds, truncates them to days and
+// limits the span to maxHistoryRange
+func clampHistoryRange(startDate, endDate time.Time) (time.Time, time.Time) {
+	now := time.Now().UTC()
+	if endDate.IsZero() || endDate.After(now) {
+		endDate = now
+	}
+	if startDate.IsZero() {
+		startDate = endDate.AddDate(0, -1, 0)
+	}
+	if endDate.Sub(startDate) > maxHistoryRange {
+		startDate = endDate.Add(-maxHistoryRange)
+	}
+	return startDate.UTC().Truncate(24 * time.Hour), endDate.UTC().Truncate(24 * time.Hour).Add(24*time.Hour - time.Second)
 }
 
 // GetPortfolioPerformance gets portfolio performance metrics
@@ -298,10 +568,31 @@ func (s *AssetService) GetPortfolioPerformance(ctx context.Context, userID strin
 		return nil, err
 	}
 
-	var totalInvested, totalProfitLoss float64
+	var totalInvested, unrealized float64
 	for _, asset := range assets {
 		totalInvested += asset.Quantity * asset.PurchasePrice
-		totalProfitLoss += asset.ProfitLoss
+		unrealized += asset.ProfitLoss
+	}
+
+	realized, err := s.lotRepo.GetRealizedTotal(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	totalProfitLoss := unrealized + realized
+
+	// Trailing-12-month dividend income
+	now := time.Now()
+	dividends, err := s.dividendRepo.SumByCurrency(ctx, userID, now.AddDate(-1, 0, 0), now)
+	if err != nil {
+		return nil, err
+	}
+	var dividendIncome float64
+	for _, amount := range dividends {
+		dividendIncome += amount
+	}
+	dividendYield := 0.0
+	if totalValue > 0 {
+		dividendYield = (dividendIncome / totalValue) * 100
 	}
 
 	profitLossPercent := 0.0
@@ -310,34 +601,178 @@ func (s *AssetService) GetPortfolioPerformance(ctx context.Context, userID strin
 	}
 
 	return &PortfolioPerformance{
-		TotalValue:        totalValue,
-		TotalInvested:     totalInvested,
-		TotalProfitLoss:   totalProfitLoss,
-		ProfitLossPercent: profitLossPercent,
-		Allocation:        allocations,
+		TotalValue:           totalValue,
+		TotalInvested:        totalInvested,
+		TotalProfitLoss:      totalProfitLoss,
+		RealizedProfitLoss:   realized,
+		UnrealizedProfitLoss: unrealized,
+		ProfitLossPercent:    profitLossPercent,
+		DividendIncomeTTM:    dividendIncome,
+		DividendYield:        dividendYield,
+		Allocation:           allocations,
 	}, nil
 }
 
-// SearchAssets searches for assets
+// GetAggregatedHoldings merges a user's assets by symbol, so several lots of
+// the same instrument are reported as one holding with a blended cost basis
+func (s *AssetService) GetAggregatedHoldings(ctx context.Context, userID string) ([]AggregatedHolding, error) {
+	assets, err := s.assetRepo.ListAll(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	holdings := make([]AggregatedHolding, 0)
+	index := make(map[string]int)
+	for _, asset := range assets {
+		key := string(asset.Type) + ":" + strings.ToUpper(asset.Symbol)
+		i, ok := index[key]
+		if !ok {
+			i = len(holdings)
+			index[key] = i
+			holdings = append(holdings, AggregatedHolding{
+				Symbol:   strings.ToUpper(asset.Symbol),
+				Name:     asset.Name,
+				Type:     asset.Type,
+				Currency: asset.Currency,
+			})
+		}
+
+		h := &holdings[i]
+		h.Quantity += asset.Quantity
+		h.TotalValue += asset.TotalValue
+		h.TotalInvested += asset.Quantity * asset.PurchasePrice
+		h.AssetIDs = append(h.AssetIDs, asset.ID)
+		if asset.CurrentPrice > 0 {
+			h.CurrentPrice = asset.CurrentPrice
+		}
+	}
+
+	for i := range holdings {
+		holdings[i].calculate()
+	}
+
+	return holdings, nil
+}
+
+// SearchAssets searches for assets. Without a type filter both the stock and
+// crypto providers are queried and their results merged by relevance.
 func (s *AssetService) SearchAssets(ctx context.Context, query string, assetType models.AssetType, limit int) ([]providers.SearchResult, error) {
-	var provider providers.PriceProvider
+	var results []providers.SearchResult
 	switch assetType {
 	case models.AssetTypeCrypto:
-		provider = s.priceManager.GetCryptoProvider()
+		found, err := s.searchProvider(ctx, s.priceManager.GetCryptoProvider(), models.AssetTypeCrypto, query)
+		if err != nil {
+			return nil, err
+		}
+		results = found
+	case "", models.AssetTypeOther:
+		found, err := s.searchAll(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		results = found
 	default:
-		provider = s.priceManager.GetStockProvider()
+		found, err := s.searchProvider(ctx, s.priceManager.GetStockProvider(), models.AssetTypeStock, query)
+		if err != nil {
+			return nil, err
+		}
+		results = found
+	}
+
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
 	}
 
+	return results, nil
+}
+
+// searchProvider runs a search and tags every result with its source type
+func (s *AssetService) searchProvider(ctx context.Context, provider providers.PriceProvider, source models.AssetType, query string) ([]providers.SearchResult, error) {
 	results, err := provider.Search(ctx, query)
 	if err != nil {
 		return nil, err
 	}
+	for i := range results {
+		results[i].Source = string(source)
+	}
+	return results, nil
+}
 
-	if limit > 0 && len(results) > limit {
-		results = results[:limit]
+// searchAll queries the stock and crypto providers concurrently, interleaves
+// their results, removes duplicates and orders them by relevance. It fails
+// only if both providers fail.
+func (s *AssetService) searchAll(ctx context.Context, query string) ([]providers.SearchResult, error) {
+	type searchOutcome struct {
+		results []providers.SearchResult
+		err     error
 	}
 
-	return results, nil
+	var stocks, crypto searchOutcome
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		stocks.results, stocks.err = s.searchProvider(ctx, s.priceManager.GetStockProvider(), models.AssetTypeStock, query)
+	}()
+	go func() {
+		defer wg.Done()
+		crypto.results, crypto.err = s.searchProvider(ctx, s.priceManager.GetCryptoProvider(), models.AssetTypeCrypto, query)
+	}()
+	wg.Wait()
+
+	if stocks.err != nil && crypto.err != nil {
+		return nil, stocks.err
+	}
+	if stocks.err != nil {
+		log.Printf("Stock search for %q failed: %v", query, stocks.err)
+	}
+	if crypto.err != nil {
+		log.Printf("Crypto search for %q failed: %v", query, crypto.err)
+	}
+
+	// Interleave so neither provider crowds out the other
+	merged := make([]providers.SearchResult, 0, len(stocks.results)+len(crypto.results))
+	seen := make(map[string]bool)
+	for i := 0; i < max(len(stocks.results), len(crypto.results)); i++ {
+		for _, list := range [][]providers.SearchResult{stocks.results, crypto.results} {
+			if i >= len(list) {
+				continue
+			}
+			key := list[i].Source + ":" + strings.ToUpper(list[i].Symbol)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged = append(merged, list[i])
+		}
+	}
+
+	// Stable, so providers' own ordering breaks ties
+	sort.SliceStable(merged, func(i, j int) bool {
+		return searchRank(merged[i], query) < searchRank(merged[j], query)
+	})
+
+	return merged, nil
+}
+
+// searchRank scores how closely a result matches query; lower is better
+func searchRank(r providers.SearchResult, query string) int {
+	q := strings.ToLower(strings.TrimSpace(query))
+	symbol := strings.ToLower(r.Symbol)
+	name := strings.ToLower(r.Name)
+
+	switch {
+	case symbol == q:
+		return 0
+	case name == q:
+		return 1
+	case strings.HasPrefix(symbol, q):
+		return 2
+	case strings.HasPrefix(name, q):
+		return 3
+	default:
+		return 4
+	}
 }
 
 func (s *AssetService) getPrice(ctx context.Context, symbol, assetType string) (*providers.PriceData, error) {
@@ -348,25 +783,118 @@ func (s *AssetService) getPrice(ctx context.Context, symbol, assetType string) (
 		return &cachedPrice, nil
 	}
 
-	// Fetch from provider
+	// Callers missing the cache at the same time share one provider call
+	return s.priceFlight.Do(ctx, assetType+":"+symbol, func(ctx context.Context) (*providers.PriceData, error) {
+		return s.fetchPrice(ctx, symbol, assetType)
+	})
+}
+
+// fetchPrice gets a price from the providers, falling back to a recent
+// durably cached price when they fail
+func (s *AssetService) fetchPrice(ctx context.Context, symbol, assetType string) (*providers.PriceData, error) {
 	price, err := s.priceManager.GetPrice(ctx, symbol, assetType)
 	if err != nil {
+		// Serve the last known price if it is recent enough
+		cached, cacheErr := s.priceCacheRepo.Get(ctx, symbol)
+		if cacheErr != nil {
+			log.Printf("Failed to read price cache for %s: %v", symbol, cacheErr)
+		}
+		if stale := s.stalePrice(cached); stale != nil {
+			return stale, nil
+		}
 		return nil, err
 	}
 
-	// Cache the result
-	_ = s.redisCache.Set(ctx, cacheKey, price, priceCacheTTL)
+	s.storePrice(ctx, symbol, assetType, price)
 
 	return price, nil
 }
 
+// storePrice writes a freshly fetched price to Redis and the durable price cache
+func (s *AssetService) storePrice(ctx context.Context, symbol, assetType string, price *providers.PriceData) {
+	_ = s.redisCache.Set(ctx, cache.AssetPriceKey(symbol), price, priceCacheTTL)
+	s.persistPrice(ctx, symbol, assetType, price)
+}
+
+// persistPrice records a fetched price in the durable price cache
+func (s *AssetService) persistPrice(ctx context.Context, symbol, assetType string, price *providers.PriceData) {
+	if err := s.priceCacheRepo.Upsert(ctx, &models.PriceCache{
+		Symbol:           symbol,
+		AssetType:        models.AssetType(assetType),
+		Price:            price.Price,
+		Currency:         price.Currency,
+		Change24h:        price.Change24h,
+		ChangePercent24h: price.ChangePercent24h,
+		High24h:          price.High24h,
+		Low24h:           price.Low24h,
+		Volume24h:        price.Volume24h,
+		MarketCap:        price.MarketCap,
+		UpdatedAt:        time.Now(),
+	}); err != nil {
+		log.Printf("Failed to persist price for %s: %v", symbol, err)
+	}
+}
+
+// stalePrice converts a durable cache entry into a price flagged as stale,
+// or returns nil if there is none or it is older than maxStaleness
+func (s *AssetService) stalePrice(cached *models.PriceCache) *providers.PriceData {
+	if cached == nil || time.Since(cached.UpdatedAt) > s.maxStaleness {
+		return nil
+	}
+
+	return &providers.PriceData{
+		Symbol:           cached.Symbol,
+		Price:            cached.Price,
+		Currency:         cached.Currency,
+		Change24h:        cached.Change24h,
+		ChangePercent24h: cached.ChangePercent24h,
+		High24h:          cached.High24h,
+		Low24h:           cached.Low24h,
+		Volume24h:        cached.Volume24h,
+		MarketCap:        cached.MarketCap,
+		UpdatedAt:        cached.UpdatedAt,
+		Stale:            true,
+	}
+}
+
 // PortfolioPerformance holds portfolio performance data
 type PortfolioPerformance struct {
-	TotalValue        float64
-	TotalInvested     float64
-	TotalProfitLoss   float64
-	ProfitLossPercent float64
-	Allocation        []AssetAllocation
+	TotalValue           float64
+	TotalInvested        float64
+	TotalProfitLoss      float64
+	RealizedProfitLoss   float64
+	UnrealizedProfitLoss float64
+	ProfitLossPercent    float64
+	DividendIncomeTTM    float64
+	DividendYield        float64
+	Allocation           []AssetAllocation
+}
+
+// AggregatedHolding holds all lots of one symbol merged into a single position
+type AggregatedHolding struct {
+	Symbol               string
+	Name                 string
+	Type                 models.AssetType
+	Currency             string
+	Quantity             float64
+	AveragePurchasePrice float64
+	CurrentPrice         float64
+	TotalValue           float64
+	TotalInvested        float64
+	ProfitLoss           float64
+	ProfitLossPercent    float64
+	AssetIDs             []string
+}
+
+// calculate derives the quantity-weighted average cost and profit/loss
+func (h *AggregatedHolding) calculate() {
+	if h.Quantity > 0 {
+		h.AveragePurchasePrice = h.TotalInvested / h.Quantity
+	}
+	if h.TotalInvested > 0 {
+		h.ProfitLoss = h.TotalValue - h.TotalInvested
+		h.ProfitLossPercent = (h.ProfitLoss / h.TotalInvested) * 100
+	}
 }
 
 // AssetAllocation holds allocation data for an asset type
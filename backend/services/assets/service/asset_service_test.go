@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	"github.com/radmickey/money-control/backend/services/assets/models"
+	"github.com/radmickey/money-control/backend/services/assets/providers"
+	"github.com/radmickey/money-control/backend/services/assets/repository"
+)
+
+func newTestCache(t *testing.T) *cache.Cache {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	c, err := cache.New(cache.Config{URL: "redis://" + mr.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return c
+}
+
+// fakeProvider quotes fixed prices and counts its GetPrice calls. Each call
+// waits for delay, long enough for concurrent callers to pile up.
+type fakeProvider struct {
+	prices map[string]float64
+	delay  time.Duration
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func newFakeProvider(prices map[string]float64) *fakeProvider {
+	return &fakeProvider{prices: prices, calls: make(map[string]int)}
+}
+
+func (p *fakeProvider) GetPrice(ctx context.Context, symbol string) (*providers.PriceData, error) {
+	p.mu.Lock()
+	p.calls[symbol]++
+	p.mu.Unlock()
+	time.Sleep(p.delay)
+
+	price, ok := p.prices[symbol]
+	if !ok {
+		return nil, fmt.Errorf("no price for %s", symbol)
+	}
+	return &providers.PriceData{Symbol: symbol, Price: price, Currency: "USD", UpdatedAt: time.Now()}, nil
+}
+
+func (p *fakeProvider) GetPrices(ctx context.Context, symbols []string) (map[string]*providers.PriceData, error) {
+	results := make(map[string]*providers.PriceData)
+	for _, symbol := range symbols {
+		if price, err := p.GetPrice(ctx, symbol); err == nil {
+			results[symbol] = price
+		}
+	}
+	return results, nil
+}
+
+func (p *fakeProvider) GetHistoricalPrices(ctx context.Context, symbol string, from, to time.Time) ([]providers.HistoricalPrice, error) {
+	return nil, fmt.Errorf("no history for %s", symbol)
+}
+
+func (p *fakeProvider) Search(ctx context.Context, query string) ([]providers.SearchResult, error) {
+	return nil, nil
+}
+
+func (p *fakeProvider) ProviderName() string { return "fake" }
+
+func (p *fakeProvider) callCount(symbol string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.calls[symbol]
+}
+
+// newTestAssetService returns a service on a test database pricing stocks
+// and crypto from provider
+func newTestAssetService(t *testing.T, provider providers.PriceProvider) *AssetService {
+	t.Helper()
+	db := databasetest.Open(t, &models.Asset{}, &models.Lot{}, &models.RealizedGain{}, &models.Dividend{}, &models.PriceCache{}, &models.PriceHistory{})
+	return NewAssetService(
+		repository.NewAssetRepository(db),
+		repository.NewLotRepository(db),
+		repository.NewDividendRepository(db),
+		repository.NewPriceCacheRepository(db),
+		providers.NewPriceManager(provider, provider),
+		newTestCache(t),
+		0,
+		nil,
+	)
+}
+
+func TestConcurrentPriceMissesCallProviderOnce(t *testing.T) {
+	provider := newFakeProvider(map[string]float64{"BTC": 50000, "ETH": 3000})
+	provider.delay = 100 * time.Millisecond
+	s := newTestAssetService(t, provider)
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 50; i++ {
+		symbol := "BTC"
+		if i%2 == 1 {
+			symbol = "ETH"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			price, err := s.getPrice(context.Background(), symbol, "crypto")
+			if err != nil || price.Symbol != symbol {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := failures.Load(); n != 0 {
+		t.Fatalf("%d callers failed", n)
+	}
+	for _, symbol := range []string{"BTC", "ETH"} {
+		if calls := provider.callCount(symbol); calls != 1 {
+			t.Errorf("provider called %d times for %s, want 1", calls, symbol)
+		}
+	}
+}
+
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+// closeTo reports whether got is within a rounding error of want
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
+
+func TestAggregatedHoldingCalculate(t *testing.T) {
+	// 10 @ 100 and 30 @ 200, now worth 250 each
+	h := AggregatedHolding{Quantity: 40, TotalInvested: 10*100 + 30*200, TotalValue: 40 * 250}
+	h.calculate()
+
+	if !closeTo(h.AveragePurchasePrice, 175) {
+		t.Errorf("average price = %v, want 175", h.AveragePurchasePrice)
+	}
+	if !closeTo(h.ProfitLoss, 3000) {
+		t.Errorf("profit/loss = %v, want 3000", h.ProfitLoss)
+	}
+	if !closeTo(h.ProfitLossPercent, 3000.0/7000*100) {
+		t.Errorf("profit/loss percent = %v, want %v", h.ProfitLossPercent, 3000.0/7000*100)
+	}
+
+	// Nothing invested leaves the cost figures at zero rather than dividing by it
+	empty := AggregatedHolding{}
+	empty.calculate()
+	if empty.AveragePurchasePrice != 0 || empty.ProfitLossPercent != 0 {
+		t.Errorf("empty holding = %+v, want zero cost figures", empty)
+	}
+}
+
+func TestAggregatedHoldingsWeightedCost(t *testing.T) {
+	s := newTestAssetService(t, newFakeProvider(map[string]float64{"BTC": 250, "ETH": 10}))
+	ctx := context.Background()
+
+	brokerage, wallet := "00000000-0000-0000-0000-0000000000a1", "00000000-0000-0000-0000-0000000000a2"
+	buy := func(subAccountID *string, symbol string, quantity, price float64) *models.Asset {
+		t.Helper()
+		asset, err := s.CreateAsset(ctx, CreateAssetInput{
+			UserID:        testUserID,
+			SubAccountID:  subAccountID,
+			Symbol:        symbol,
+			Type:          models.AssetTypeCrypto,
+			Quantity:      quantity,
+			PurchasePrice: price,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return asset
+	}
+	first := buy(&brokerage, "BTC", 10, 100)
+	buy(&wallet, "btc", 30, 200)
+	buy(&wallet, "ETH", 4, 5)
+
+	holding := func() AggregatedHolding {
+		t.Helper()
+		holdings, err := s.GetAggregatedHoldings(ctx, testUserID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(holdings) != 2 {
+			t.Fatalf("%d holdings, want BTC and ETH", len(holdings))
+		}
+		for _, h := range holdings {
+			if h.Symbol == "BTC" {
+				return h
+			}
+		}
+		t.Fatalf("no BTC holding in %+v", holdings)
+		return AggregatedHolding{}
+	}
+
+	btc := holding()
+	if !closeTo(btc.Quantity, 40) || !closeTo(btc.TotalInvested, 7000) || !closeTo(btc.TotalValue, 10000) {
+		t.Fatalf("BTC = %+v, want 40 units, 7000 invested, worth 10000", btc)
+	}
+	if !closeTo(btc.AveragePurchasePrice, 175) {
+		t.Errorf("BTC average price = %v, want 175", btc.AveragePurchasePrice)
+	}
+	if len(btc.AssetIDs) != 2 {
+		t.Errorf("BTC merges %d assets, want 2", len(btc.AssetIDs))
+	}
+
+	// Selling half the cheaper lot leaves 5 @ 100 and 30 @ 200
+	if _, gain, err := s.SellAsset(ctx, testUserID, first.ID, 5, 300); err != nil {
+		t.Fatal(err)
+	} else if !closeTo(gain.CostBasis, 500) {
+		t.Errorf("sold cost basis = %v, want 500", gain.CostBasis)
+	}
+
+	btc = holding()
+	if !closeTo(btc.Quantity, 35) || !closeTo(btc.TotalInvested, 6500) {
+		t.Fatalf("BTC after sale = %+v, want 35 units, 6500 invested", btc)
+	}
+	if !closeTo(btc.AveragePurchasePrice, 6500.0/35) {
+		t.Errorf("BTC average price after sale = %v, want %v", btc.AveragePurchasePrice, 6500.0/35)
+	}
+	if !closeTo(btc.ProfitLoss, 35*250-6500) {
+		t.Errorf("BTC profit/loss after sale = %v, want %v", btc.ProfitLoss, 35*250-6500.0)
+	}
+}
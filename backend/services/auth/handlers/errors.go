@@ -0,0 +1,34 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	"github.com/radmickey/money-control/backend/services/auth/repository"
+	"github.com/radmickey/money-control/backend/services/auth/service"
+)
+
+// errorMap maps the auth service's errors to HTTP responses
+var errorMap = utils.ErrorMap{
+	{Err: repository.ErrUserNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "User not found"},
+	{Err: repository.ErrUserExists, Status: http.StatusConflict, Code: utils.CodeConflict, Message: "User with this email already exists"},
+	{Err: repository.ErrInvalidToken, Status: http.StatusUnauthorized, Code: utils.CodeUnauthorized, Message: "Invalid or expired token"},
+	{Err: repository.ErrInvalidCode, Status: http.StatusBadRequest, Code: utils.CodeBadRequest, Message: "Invalid or expired confirmation code"},
+	{Err: service.ErrInvalidCredentials, Status: http.StatusUnauthorized, Code: utils.CodeUnauthorized, Message: "Invalid email or password"},
+	{Err: service.ErrUserNotActive, Status: http.StatusForbidden, Code: utils.CodeForbidden, Message: "Account is not active"},
+	{Err: service.ErrIncorrectPassword, Status: http.StatusUnauthorized, Code: utils.CodeUnauthorized, Message: "Current password is incorrect"},
+	{Err: service.ErrEmailUnchanged, Status: http.StatusBadRequest, Code: utils.CodeBadRequest, Message: "New email matches the current email"},
+	{Err: auth.ErrPasswordTooShort, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrReauthRequired, Status: http.StatusForbidden, Code: utils.CodeReauthRequired, Message: "Confirm with a code from POST /auth/reauth"},
+	{Err: service.ErrNoReauthChannel, Status: http.StatusUnprocessableEntity, Code: utils.CodePreconditionFailed, Message: "No email or Telegram chat to send a verification code to"},
+	{Err: service.ErrTooManyRequests, Status: http.StatusTooManyRequests, Code: utils.CodeRateLimited, Message: "Too many requests, try again later"},
+	{Err: service.ErrMailUnavailable, Status: http.StatusServiceUnavailable, Code: utils.CodeUnavailable, Message: "Email delivery is not available"},
+	{Err: auth.ErrAppleNotConfigured, Status: http.StatusServiceUnavailable, Code: utils.CodeUnavailable, Message: "Apple Sign-In is not configured"},
+}
+
+// respondError writes err as an HTTP error response
+func respondError(c *gin.Context, err error) {
+	errorMap.Respond(c, err)
+}
@@ -2,25 +2,32 @@ package handlers
 
 import (
 	"context"
+	"errors"
 
+	"github.com/radmickey/money-control/backend/pkg/auth"
 	pb "github.com/radmickey/money-control/backend/proto/auth"
 	"github.com/radmickey/money-control/backend/services/auth/models"
+	"github.com/radmickey/money-control/backend/services/auth/repository"
 	"github.com/radmickey/money-control/backend/services/auth/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // GRPCHandler implements the AuthServiceServer interface
 type GRPCHandler struct {
 	pb.UnimplementedAuthServiceServer
-	authService *service.AuthService
+	authService       *service.AuthService
+	telegramValidator *TelegramInitDataValidator
 }
 
 // NewGRPCHandler creates a new gRPC handler
-func NewGRPCHandler(authService *service.AuthService) *GRPCHandler {
+func NewGRPCHandler(authService *service.AuthService, telegramValidator *TelegramInitDataValidator) *GRPCHandler {
 	return &GRPCHandler{
-		authService: authService,
+		authService:       authService,
+		telegramValidator: telegramValidator,
 	}
 }
 
@@ -34,6 +41,12 @@ func (h *GRPCHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 		BaseCurrency: req.BaseCurrency,
 	})
 	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserExists):
+			return nil, status.Errorf(codes.AlreadyExists, "user already exists: %v", err)
+		case errors.Is(err, auth.ErrPasswordTooShort):
+			return nil, status.Errorf(codes.InvalidArgument, "invalid password: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to register: %v", err)
 	}
 
@@ -49,6 +62,16 @@ func (h *GRPCHandler) Register(ctx context.Context, req *pb.RegisterRequest) (*p
 func (h *GRPCHandler) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
 	result, err := h.authService.Login(ctx, req.Email, req.Password)
 	if err != nil {
+		var lockedErr *service.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			st := status.New(codes.ResourceExhausted, lockedErr.Error())
+			if detailed, detailErr := st.WithDetails(&errdetails.RetryInfo{
+				RetryDelay: durationpb.New(lockedErr.RetryAfter),
+			}); detailErr == nil {
+				st = detailed
+			}
+			return nil, st.Err()
+		}
 		return nil, status.Errorf(codes.Unauthenticated, "invalid credentials: %v", err)
 	}
 
@@ -89,6 +112,9 @@ func (h *GRPCHandler) GetProfile(ctx context.Context, req *pb.GetProfileRequest)
 func (h *GRPCHandler) UpdateProfile(ctx context.Context, req *pb.UpdateProfileRequest) (*pb.User, error) {
 	user, err := h.authService.UpdateProfile(ctx, req.UserId, req.FirstName, req.LastName, req.BaseCurrency)
 	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update profile: %v", err)
 	}
 
@@ -110,11 +136,49 @@ func (h *GRPCHandler) GoogleAuth(ctx context.Context, req *pb.GoogleAuthRequest)
 	}, nil
 }
 
+// GetAppleAuthURL returns the Apple Sign-In authorization URL
+func (h *GRPCHandler) GetAppleAuthURL(ctx context.Context, req *pb.GetAppleAuthURLRequest) (*pb.GetAppleAuthURLResponse, error) {
+	url, err := h.authService.GetAppleAuthURL(ctx)
+	if err != nil {
+		if errors.Is(err, auth.ErrAppleNotConfigured) {
+			return nil, status.Errorf(codes.FailedPrecondition, "apple sign-in unavailable: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to build apple auth url: %v", err)
+	}
+
+	return &pb.GetAppleAuthURLResponse{Url: url}, nil
+}
+
+// AppleAuth handles Apple Sign-In authentication
+func (h *GRPCHandler) AppleAuth(ctx context.Context, req *pb.AppleAuthRequest) (*pb.AuthResponse, error) {
+	if err := h.authService.ValidateOAuthState(ctx, req.State); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid oauth state: %v", err)
+	}
+
+	result, err := h.authService.AppleAuth(ctx, req.Code)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "apple auth failed: %v", err)
+	}
+
+	return &pb.AuthResponse{
+		AccessToken:  result.AccessToken,
+		RefreshToken: result.RefreshToken,
+		User:         userToProto(result.User),
+		ExpiresIn:    result.ExpiresIn,
+	}, nil
+}
+
 // TelegramAuth handles Telegram WebApp authentication
 func (h *GRPCHandler) TelegramAuth(ctx context.Context, req *pb.TelegramAuthRequest) (*pb.AuthResponse, error) {
-	// Parse initData
-	telegramData, err := ParseTelegramInitData(req.InitData)
+	// Verify and parse initData
+	telegramData, err := h.telegramValidator.Parse(req.InitData)
 	if err != nil {
+		switch {
+		case errors.Is(err, ErrTelegramNotConfigured):
+			return nil, status.Errorf(codes.FailedPrecondition, "telegram auth unavailable: %v", err)
+		case errors.Is(err, ErrInitDataInvalidHash), errors.Is(err, ErrInitDataExpired):
+			return nil, status.Errorf(codes.Unauthenticated, "invalid telegram init data: %v", err)
+		}
 		return nil, status.Errorf(codes.InvalidArgument, "invalid telegram init data: %v", err)
 	}
 
@@ -138,7 +202,7 @@ func (h *GRPCHandler) TelegramAuth(ctx context.Context, req *pb.TelegramAuthRequ
 
 // ValidateToken validates an access token
 func (h *GRPCHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRequest) (*pb.ValidateTokenResponse, error) {
-	claims, err := h.authService.ValidateToken(req.AccessToken)
+	claims, err := h.authService.ValidateToken(ctx, req.AccessToken)
 	if err != nil {
 		return &pb.ValidateTokenResponse{Valid: false}, nil
 	}
@@ -150,6 +214,23 @@ func (h *GRPCHandler) ValidateToken(ctx context.Context, req *pb.ValidateTokenRe
 	}, nil
 }
 
+// GetTokenVersion returns a user's token version for other services' caches
+func (h *GRPCHandler) GetTokenVersion(ctx context.Context, req *pb.GetTokenVersionRequest) (*pb.GetTokenVersionResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Error(codes.InvalidArgument, "user_id is required")
+	}
+
+	version, err := h.authService.GetTokenVersion(ctx, req.UserId)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get token version: %v", err)
+	}
+
+	return &pb.GetTokenVersionResponse{TokenVersion: int32(version)}, nil
+}
+
 // Logout logs out a user
 func (h *GRPCHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
 	err := h.authService.Logout(ctx, req.UserId, req.RefreshToken)
@@ -160,6 +241,84 @@ func (h *GRPCHandler) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.Lo
 	return &pb.LogoutResponse{Success: true}, nil
 }
 
+// RequestTelegramLink sends a one-time link code to the account's email
+func (h *GRPCHandler) RequestTelegramLink(ctx context.Context, req *pb.RequestTelegramLinkRequest) (*pb.RequestTelegramLinkResponse, error) {
+	expiresAt, err := h.authService.RequestTelegramLink(ctx, req.Email, req.TelegramId, req.TelegramUsername)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		case errors.Is(err, service.ErrUserNotActive):
+			return nil, status.Errorf(codes.PermissionDenied, "user not active: %v", err)
+		case errors.Is(err, service.ErrTooManyRequests):
+			return nil, status.Errorf(codes.ResourceExhausted, "too many link codes requested: %v", err)
+		case errors.Is(err, service.ErrMailUnavailable):
+			return nil, status.Errorf(codes.Unavailable, "email delivery is not configured: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to request telegram link: %v", err)
+	}
+
+	return &pb.RequestTelegramLinkResponse{
+		CodeSent:  true,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+// ConfirmTelegramLink verifies a link code and links the Telegram account
+func (h *GRPCHandler) ConfirmTelegramLink(ctx context.Context, req *pb.ConfirmTelegramLinkRequest) (*pb.User, error) {
+	user, err := h.authService.ConfirmTelegramLink(ctx, req.TelegramId, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidLinkCode):
+			return nil, status.Errorf(codes.InvalidArgument, "invalid link code: %v", err)
+		case errors.Is(err, service.ErrTelegramLinked):
+			return nil, status.Errorf(codes.AlreadyExists, "telegram already linked: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to confirm telegram link: %v", err)
+	}
+
+	return userToProto(user), nil
+}
+
+// IssueTelegramLoginToken mints a short-lived token for opening the Mini App from the bot
+func (h *GRPCHandler) IssueTelegramLoginToken(ctx context.Context, req *pb.IssueTelegramLoginTokenRequest) (*pb.IssueTelegramLoginTokenResponse, error) {
+	token, expiresAt, err := h.authService.IssueTelegramLoginToken(ctx, req.UserId, req.TelegramId)
+	if err != nil {
+		switch {
+		case errors.Is(err, repository.ErrUserNotFound):
+			return nil, status.Errorf(codes.NotFound, "user not found: %v", err)
+		case errors.Is(err, service.ErrUserNotActive):
+			return nil, status.Errorf(codes.PermissionDenied, "user not active: %v", err)
+		case errors.Is(err, service.ErrTelegramNotLinked):
+			return nil, status.Errorf(codes.PermissionDenied, "telegram not linked: %v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to issue login token: %v", err)
+	}
+
+	return &pb.IssueTelegramLoginTokenResponse{
+		Token:     token,
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+// ListUsers pages through active users for internal batch jobs
+func (h *GRPCHandler) ListUsers(ctx context.Context, req *pb.ListUsersRequest) (*pb.ListUsersResponse, error) {
+	users, next, err := h.authService.ListActiveUsers(ctx, req.PageToken, int(req.PageSize))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list users: %v", err)
+	}
+
+	pbUsers := make([]*pb.User, len(users))
+	for i := range users {
+		pbUsers[i] = userToProto(&users[i])
+	}
+
+	return &pb.ListUsersResponse{
+		Users:         pbUsers,
+		NextPageToken: next,
+	}, nil
+}
+
 // Helper functions
 func userToProto(u *models.User) *pb.User {
 	googleID := ""
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	pb "github.com/radmickey/money-control/backend/proto/auth"
+	"github.com/radmickey/money-control/backend/services/auth/models"
+	"github.com/radmickey/money-control/backend/services/auth/repository"
+	"github.com/radmickey/money-control/backend/services/auth/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// newTestGRPCHandler returns a handler whose auth service stores users and
+// refresh tokens in a test database
+func newTestGRPCHandler(t *testing.T) *GRPCHandler {
+	t.Helper()
+	db := databasetest.Open(t, &models.User{}, &models.RefreshToken{})
+	authService := service.NewAuthService(
+		repository.NewUserRepository(db),
+		repository.NewRefreshTokenRepository(db),
+		nil, nil, nil, nil,
+		auth.NewJWTManager("test-secret", time.Hour, 24*time.Hour),
+		nil, nil, nil, nil, nil, nil,
+		24*time.Hour,
+	)
+	return NewGRPCHandler(authService, nil)
+}
+
+func TestRegisterRejectsShortPassword(t *testing.T) {
+	// Rejected before the user repository is consulted
+	h := NewGRPCHandler(&service.AuthService{}, nil)
+	_, err := h.Register(context.Background(), &pb.RegisterRequest{Email: "a@example.com", Password: "short"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestUpdateProfileUnknownUser(t *testing.T) {
+	h := newTestGRPCHandler(t)
+	_, err := h.UpdateProfile(context.Background(), &pb.UpdateProfileRequest{
+		UserId:    "00000000-0000-0000-0000-0000000000ff",
+		FirstName: "A",
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("err = %v, want NotFound", err)
+	}
+}
+
+func TestRegisterDuplicateEmail(t *testing.T) {
+	h := newTestGRPCHandler(t)
+	ctx := context.Background()
+	req := &pb.RegisterRequest{Email: "a@example.com", Password: "longenough"}
+
+	if _, err := h.Register(ctx, req); err != nil {
+		t.Fatalf("first register: %v", err)
+	}
+
+	// The gateway turns AlreadyExists into a 409 CONFLICT
+	_, err := h.Register(ctx, req)
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("err = %v, want AlreadyExists", err)
+	}
+}
@@ -1,7 +1,11 @@
 package handlers
 
 import (
+	"errors"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
@@ -32,6 +36,10 @@ func (h *HTTPHandler) RegisterRoutes(r *gin.RouterGroup, authMiddleware gin.Hand
 		auth.POST("/refresh", h.RefreshToken)
 		auth.GET("/google", h.GoogleAuthURL)
 		auth.GET("/google/callback", h.GoogleCallback)
+		auth.GET("/apple", h.AppleAuthURL)
+		auth.POST("/apple/callback", h.AppleCallback)
+		auth.POST("/forgot-password", h.ForgotPassword)
+		auth.POST("/reset-password", h.ResetPassword)
 	}
 
 	// Protected routes
@@ -41,6 +49,10 @@ func (h *HTTPHandler) RegisterRoutes(r *gin.RouterGroup, authMiddleware gin.Hand
 		protected.GET("/profile", h.GetProfile)
 		protected.PUT("/profile", h.UpdateProfile)
 		protected.POST("/logout", h.Logout)
+		protected.POST("/reauth", h.RequestReauthCode)
+		protected.PUT("/password", h.ChangePassword)
+		protected.POST("/email", h.RequestEmailChange)
+		protected.POST("/email/confirm", h.ConfirmEmailChange)
 	}
 }
 
@@ -57,7 +69,7 @@ type RegisterRequest struct {
 func (h *HTTPHandler) Register(c *gin.Context) {
 	var req RegisterRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -71,11 +83,7 @@ func (h *HTTPHandler) Register(c *gin.Context) {
 
 	result, err := h.authService.Register(c.Request.Context(), input)
 	if err != nil {
-		if err == repository.ErrUserExists {
-			utils.Conflict(c, "User with this email already exists")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -98,21 +106,19 @@ type LoginRequest struct {
 func (h *HTTPHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	result, err := h.authService.Login(c.Request.Context(), req.Email, req.Password)
 	if err != nil {
-		if err == service.ErrInvalidCredentials {
-			utils.Unauthorized(c, "Invalid email or password")
-			return
-		}
-		if err == service.ErrUserNotActive {
-			utils.Forbidden(c, "Account is not active")
+		var lockedErr *service.AccountLockedError
+		if errors.As(err, &lockedErr) {
+			c.Header("Retry-After", strconv.Itoa(retryAfterSeconds(lockedErr.RetryAfter)))
+			utils.Locked(c, "Too many failed login attempts. Try again later")
 			return
 		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -134,17 +140,13 @@ type RefreshTokenRequest struct {
 func (h *HTTPHandler) RefreshToken(c *gin.Context) {
 	var req RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	result, err := h.authService.RefreshToken(c.Request.Context(), req.RefreshToken)
 	if err != nil {
-		if err == repository.ErrInvalidToken {
-			utils.Unauthorized(c, "Invalid or expired refresh token")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -161,7 +163,7 @@ func (h *HTTPHandler) RefreshToken(c *gin.Context) {
 func (h *HTTPHandler) GoogleAuthURL(c *gin.Context) {
 	url, err := h.authService.GetGoogleAuthURL(c.Request.Context())
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -190,7 +192,7 @@ func (h *HTTPHandler) GoogleCallback(c *gin.Context) {
 
 	result, err := h.authService.GoogleAuth(c.Request.Context(), code)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -204,6 +206,102 @@ func (h *HTTPHandler) GoogleCallback(c *gin.Context) {
 	})
 }
 
+// AppleAuthURL returns the Apple Sign-In URL
+func (h *HTTPHandler) AppleAuthURL(c *gin.Context) {
+	url, err := h.authService.GetAppleAuthURL(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"url": url,
+	})
+}
+
+// AppleCallback handles the Apple Sign-In callback (delivered as a form POST)
+func (h *HTTPHandler) AppleCallback(c *gin.Context) {
+	code := c.PostForm("code")
+	state := c.PostForm("state")
+
+	if code == "" {
+		utils.BadRequest(c, "Authorization code is required")
+		return
+	}
+
+	if err := h.authService.ValidateOAuthState(c.Request.Context(), state); err != nil {
+		utils.BadRequest(c, "Invalid OAuth state")
+		return
+	}
+
+	result, err := h.authService.AppleAuth(c.Request.Context(), code)
+	if err != nil {
+		if errors.Is(err, service.ErrUserNotActive) {
+			utils.Forbidden(c, "Account is not active")
+			return
+		}
+		utils.Unauthorized(c, "Apple authentication failed")
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"user":          userToResponse(result.User),
+		"access_token":  result.AccessToken,
+		"refresh_token": result.RefreshToken,
+		"expires_in":    result.ExpiresIn,
+		"token_type":    "Bearer",
+	})
+}
+
+// ForgotPasswordRequest represents password reset request
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+// ForgotPassword emails a password reset token if the account exists
+func (h *HTTPHandler) ForgotPassword(c *gin.Context) {
+	var req ForgotPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	if err := h.authService.RequestPasswordReset(c.Request.Context(), req.Email); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	// Same response whether or not the account exists
+	utils.Success(c, gin.H{"message": "If an account exists for this email, a reset token has been sent"})
+}
+
+// ResetPasswordRequest represents password reset confirmation request
+type ResetPasswordRequest struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required,min=8"`
+}
+
+// ResetPassword sets a new password using a reset token
+func (h *HTTPHandler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	if err := h.authService.ResetPassword(c.Request.Context(), req.Token, req.NewPassword); err != nil {
+		switch {
+		case errors.Is(err, repository.ErrInvalidToken), errors.Is(err, repository.ErrUserNotFound):
+			utils.BadRequest(c, "Invalid or expired reset token")
+		default:
+			respondError(c, err)
+		}
+		return
+	}
+
+	utils.Success(c, gin.H{"message": "Password has been reset. Please log in again"})
+}
+
 // GetProfile returns the current user's profile
 func (h *HTTPHandler) GetProfile(c *gin.Context) {
 	userID, ok := middleware.GetUserID(c)
@@ -214,11 +312,7 @@ func (h *HTTPHandler) GetProfile(c *gin.Context) {
 
 	user, err := h.authService.GetProfile(c.Request.Context(), userID)
 	if err != nil {
-		if err == repository.ErrUserNotFound {
-			utils.NotFound(c, "User not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -242,13 +336,13 @@ func (h *HTTPHandler) UpdateProfile(c *gin.Context) {
 
 	var req UpdateProfileRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	user, err := h.authService.UpdateProfile(c.Request.Context(), userID, req.FirstName, req.LastName, req.BaseCurrency)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -265,18 +359,132 @@ func (h *HTTPHandler) Logout(c *gin.Context) {
 
 	var req RefreshTokenRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	if err := h.authService.Logout(c.Request.Context(), userID, req.RefreshToken); err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.Success(c, gin.H{"message": "Successfully logged out"})
 }
 
+// RequestReauthCode sends a one-time code to confirm a password or email
+// change on an account without a password
+func (h *HTTPHandler) RequestReauthCode(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	channel, expiresAt, err := h.authService.RequestReauthCode(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"message":    "Verification code sent",
+		"channel":    channel,
+		"expires_at": expiresAt.Format(http.TimeFormat),
+	})
+}
+
+// ChangePasswordRequest represents password change request. Accounts without
+// a password send a reauth_code instead of current_password.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	ReauthCode      string `json:"reauth_code"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+}
+
+// ChangePassword changes the current user's password
+func (h *HTTPHandler) ChangePassword(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	if err := h.authService.ChangePassword(c.Request.Context(), userID, req.CurrentPassword, req.ReauthCode, req.NewPassword); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{"message": "Password changed. Please log in again"})
+}
+
+// ChangeEmailRequest represents email change request. Accounts without a
+// password send a reauth_code instead of current_password.
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" binding:"required,email"`
+	CurrentPassword string `json:"current_password"`
+	ReauthCode      string `json:"reauth_code"`
+}
+
+// RequestEmailChange sends a confirmation code to the new email address
+func (h *HTTPHandler) RequestEmailChange(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	expiresAt, err := h.authService.RequestEmailChange(c.Request.Context(), userID, req.NewEmail, req.CurrentPassword, req.ReauthCode)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"message":    "Confirmation code sent to the new email",
+		"expires_at": expiresAt.Format(http.TimeFormat),
+	})
+}
+
+// ConfirmEmailChangeRequest represents email change confirmation request
+type ConfirmEmailChangeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// ConfirmEmailChange applies a pending email change
+func (h *HTTPHandler) ConfirmEmailChange(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req ConfirmEmailChangeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	user, err := h.authService.ConfirmEmailChange(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, userToResponse(user))
+}
+
 // UserResponse represents user response
 type UserResponse struct {
 	ID           string `json:"id"`
@@ -300,3 +508,7 @@ func userToResponse(u *models.User) UserResponse {
 	}
 }
 
+// retryAfterSeconds rounds a wait up to whole seconds for the Retry-After header
+func retryAfterSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -5,12 +5,13 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
-	"os"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // TelegramUser represents user data from Telegram
@@ -33,8 +34,35 @@ type TelegramInitData struct {
 	ChatInstance string        `json:"chat_instance"`
 }
 
-// ParseTelegramInitData parses and validates Telegram Web App init data
-func ParseTelegramInitData(initData string) (*TelegramInitData, error) {
+// Telegram init data validation errors
+var (
+	ErrTelegramNotConfigured = errors.New("telegram bot token is not configured")
+	ErrInitDataNoHash        = errors.New("hash not found in init data")
+	ErrInitDataInvalidHash   = errors.New("invalid init data hash")
+	ErrInitDataExpired       = errors.New("init data has expired")
+)
+
+// TelegramInitDataValidator verifies Telegram Web App init data signatures
+type TelegramInitDataValidator struct {
+	botToken string
+	maxAge   time.Duration
+}
+
+// NewTelegramInitDataValidator creates a validator for the given bot token.
+// Init data older than maxAge is rejected; a maxAge of zero disables the check.
+func NewTelegramInitDataValidator(botToken string, maxAge time.Duration) *TelegramInitDataValidator {
+	return &TelegramInitDataValidator{
+		botToken: botToken,
+		maxAge:   maxAge,
+	}
+}
+
+// Parse validates the init data signature and freshness, then parses it
+func (v *TelegramInitDataValidator) Parse(initData string) (*TelegramInitData, error) {
+	if v.botToken == "" {
+		return nil, ErrTelegramNotConfigured
+	}
+
 	// Parse the URL-encoded data
 	values, err := url.ParseQuery(initData)
 	if err != nil {
@@ -44,15 +72,12 @@ func ParseTelegramInitData(initData string) (*TelegramInitData, error) {
 	// Extract hash
 	hash := values.Get("hash")
 	if hash == "" {
-		return nil, fmt.Errorf("hash not found in init data")
+		return nil, ErrInitDataNoHash
 	}
 
-	// Validate the hash
-	botToken := os.Getenv("TELEGRAM_BOT_TOKEN")
-	if botToken != "" {
-		if !validateTelegramHash(values, hash, botToken) {
-			return nil, fmt.Errorf("invalid hash")
-		}
+	// Validate the hash before trusting any field
+	if !validateTelegramHash(values, hash, v.botToken) {
+		return nil, ErrInitDataInvalidHash
 	}
 
 	// Parse the data
@@ -64,11 +89,14 @@ func ParseTelegramInitData(initData string) (*TelegramInitData, error) {
 	}
 
 	// Parse auth_date
-	if authDateStr := values.Get("auth_date"); authDateStr != "" {
-		authDate, err := strconv.ParseInt(authDateStr, 10, 64)
-		if err == nil {
-			data.AuthDate = authDate
-		}
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth_date: %w", err)
+	}
+	data.AuthDate = authDate
+
+	if v.maxAge > 0 && time.Since(time.Unix(authDate, 0)) > v.maxAge {
+		return nil, ErrInitDataExpired
 	}
 
 	// Parse user
@@ -112,8 +140,11 @@ func validateTelegramHash(values url.Values, hash, botToken string) bool {
 	// Create hash of data-check-string
 	dataHMAC := hmac.New(sha256.New, secretKey)
 	dataHMAC.Write([]byte(dataCheckString))
-	calculatedHash := hex.EncodeToString(dataHMAC.Sum(nil))
+	calculatedHash := dataHMAC.Sum(nil)
 
-	return calculatedHash == hash
+	providedHash, err := hex.DecodeString(hash)
+	if err != nil {
+		return false
+	}
+	return hmac.Equal(calculatedHash, providedHash)
 }
-
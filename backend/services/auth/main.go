@@ -2,7 +2,6 @@ package main
 
 import (
 	"context"
-	"fmt"
 	"log"
 	"net"
 	"net/http"
@@ -16,7 +15,11 @@ import (
 	"github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/logger"
+	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
 	pb "github.com/radmickey/money-control/backend/proto/auth"
 	"github.com/radmickey/money-control/backend/services/auth/handlers"
 	"github.com/radmickey/money-control/backend/services/auth/models"
@@ -32,11 +35,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "auth-service",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := database.New(database.Config{
-		URL:   cfg.DatabaseURL,
-		Debug: cfg.Debug,
+		URL:             cfg.DatabaseURL,
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		Debug:           cfg.Debug,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -44,7 +63,7 @@ func main() {
 	defer db.Close()
 
 	// Run migrations
-	if err := db.Migrate(&models.User{}, &models.RefreshToken{}, &models.OAuthState{}); err != nil {
+	if err := db.Migrate(&models.User{}, &models.RefreshToken{}, &models.OAuthState{}, &models.TelegramLinkCode{}, &models.EmailChangeRequest{}, &models.PasswordResetToken{}); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
@@ -71,31 +90,90 @@ func main() {
 		cfg.GoogleClientSecret,
 		cfg.GoogleRedirectURL,
 	)
+	if cfg.AppleClientID != "" {
+		if err := oauthManager.EnableApple(auth.AppleConfig{
+			ClientID:    cfg.AppleClientID,
+			TeamID:      cfg.AppleTeamID,
+			KeyID:       cfg.AppleKeyID,
+			PrivateKey:  cfg.ApplePrivateKey,
+			RedirectURL: cfg.AppleRedirectURL,
+		}); err != nil {
+			log.Fatalf("Failed to configure Apple Sign-In: %v", err)
+		}
+	}
 
 	// Initialize repositories
 	userRepo := repository.NewUserRepository(db.DB)
 	refreshTokenRepo := repository.NewRefreshTokenRepository(db.DB)
 	oauthStateRepo := repository.NewOAuthStateRepository(db.DB)
+	linkCodeRepo := repository.NewTelegramLinkCodeRepository(db.DB)
+	emailChangeRepo := repository.NewEmailChangeRepository(db.DB)
+	resetRepo := repository.NewPasswordResetRepository(db.DB)
+
+	// Unique indexes used to count deleted users, blocking sign-up with their email
+	if err := userRepo.MigrateUniqueIndexes(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate user indexes: %v", err)
+	}
+
+	// Refresh tokens used to be stored in plaintext; drop them (forces re-login)
+	if err := refreshTokenRepo.MigrateLegacyTokens(context.Background()); err != nil {
+		log.Fatalf("Failed to migrate refresh tokens: %v", err)
+	}
+
+	// Token versions are cached in Redis and loaded from the database on a miss
+	tokenVersions := auth.NewTokenVersionStore(redisCache.Client(), userRepo.GetTokenVersion)
+
+	loginLimiter := service.NewLoginLimiter(
+		redisCache.Client(),
+		cfg.LoginMaxAttempts,
+		cfg.LoginLockoutDuration,
+		cfg.LoginLockoutMaxDuration,
+	)
+
+	mailer := newMailer(cfg)
+
+	// Re-authentication codes reach Telegram sign-ups through the bot
+	var telegramSender service.TelegramSender
+	if cfg.TelegramBotToken != "" {
+		telegramSender = service.NewBotAPISender(cfg.TelegramBotToken)
+	}
 
 	// Initialize service
 	authService := service.NewAuthService(
 		userRepo,
 		refreshTokenRepo,
 		oauthStateRepo,
+		linkCodeRepo,
+		emailChangeRepo,
+		resetRepo,
 		jwtManager,
 		oauthManager,
+		tokenVersions,
+		loginLimiter,
+		service.NewLinkCodeLimiter(redisCache.Client()),
+		service.NewReauthenticator(redisCache.Client(), mailer, telegramSender),
+		mailer,
 		cfg.JWTRefreshDuration,
 	)
 
 	// Start cleanup goroutine for expired tokens
-	go cleanupExpiredTokens(refreshTokenRepo, oauthStateRepo)
+	go cleanupExpiredTokens(logger.New(logger.NewConfig(cfg, "auth-service")), refreshTokenRepo, oauthStateRepo, linkCodeRepo, emailChangeRepo, resetRepo)
 
 	// Start gRPC server
-	grpcServer := grpc.NewServer()
-	grpcHandler := handlers.NewGRPCHandler(authService)
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		middleware.UnaryServerRequestIDInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
+	telegramValidator := handlers.NewTelegramInitDataValidator(cfg.TelegramBotToken, cfg.TelegramInitDataMaxAge)
+	grpcHandler := handlers.NewGRPCHandler(authService, telegramValidator)
 	pb.RegisterAuthServiceServer(grpcServer, grpcHandler)
 	reflection.Register(grpcServer)
 
+	// Health checks, served over gRPC here and on /health/details below
+	healthChecker := health.NewHealthChecker("")
+	healthChecker.Register("database", health.DatabasePoolCheck(db.Ping, db.Stats))
+	grpcHealth := health.RegisterGRPCServer(grpcServer, healthChecker)
+
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
@@ -114,20 +192,31 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery())
+	logConfig := middleware.NewLoggingConfig(cfg)
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "auth"})
 	})
 
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Detailed health check with connection pool stats
+	router.GET("/health/details", func(c *gin.Context) {
+		c.JSON(http.StatusOK, healthChecker.Check(c.Request.Context()))
+	})
+
 	// Auth routes
 	httpHandler := handlers.NewHTTPHandler(authService)
 	v1 := router.Group("/api/v1")
-	authMiddleware := middleware.AuthMiddleware(jwtManager)
+	authMiddleware := middleware.AuthMiddleware(middleware.NewLocalTokenValidator(jwtManager, tokenVersions))
 	httpHandler.RegisterRoutes(v1, authMiddleware)
 
 	httpServer := &http.Server{
@@ -153,6 +242,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
@@ -161,19 +251,54 @@ func main() {
 	log.Println("Auth service stopped")
 }
 
-func cleanupExpiredTokens(refreshRepo *repository.RefreshTokenRepository, oauthRepo *repository.OAuthStateRepository) {
+func cleanupExpiredTokens(
+	appLog *logger.Logger,
+	refreshRepo *repository.RefreshTokenRepository,
+	oauthRepo *repository.OAuthStateRepository,
+	linkCodeRepo *repository.TelegramLinkCodeRepository,
+	emailChangeRepo *repository.EmailChangeRepository,
+	resetRepo *repository.PasswordResetRepository,
+) {
 	ticker := time.NewTicker(1 * time.Hour)
 	defer ticker.Stop()
 
 	for range ticker.C {
 		ctx := context.Background()
 		if err := refreshRepo.DeleteExpired(ctx); err != nil {
-			log.Printf("Failed to delete expired refresh tokens: %v", err)
+			appLog.Error("failed to delete expired refresh tokens", "error", err)
 		}
 		if err := oauthRepo.DeleteExpired(ctx); err != nil {
-			log.Printf("Failed to delete expired OAuth states: %v", err)
+			appLog.Error("failed to delete expired OAuth states", "error", err)
 		}
-		fmt.Println("Cleaned up expired tokens")
+		if err := linkCodeRepo.DeleteExpired(ctx); err != nil {
+			appLog.Error("failed to delete expired Telegram link codes", "error", err)
+		}
+		if err := emailChangeRepo.DeleteExpired(ctx); err != nil {
+			appLog.Error("failed to delete expired email change requests", "error", err)
+		}
+		if err := resetRepo.DeleteExpired(ctx); err != nil {
+			appLog.Error("failed to delete expired password reset tokens", "error", err)
+		}
+		appLog.Debug("cleaned up expired tokens")
 	}
 }
 
+// newMailer sends email over SMTP when it is configured. Without SMTP, debug
+// builds log that a message was sent, and production refuses to send so
+// emailed codes fail instead of being lost.
+func newMailer(cfg *config.Config) service.Mailer {
+	if cfg.SMTPHost != "" {
+		return service.NewSMTPMailer(service.SMTPConfig{
+			Host:     cfg.SMTPHost,
+			Port:     cfg.SMTPPort,
+			Username: cfg.SMTPUsername,
+			Password: cfg.SMTPPassword,
+			From:     cfg.SMTPFrom,
+		})
+	}
+	if cfg.Debug {
+		return service.NewLogMailer()
+	}
+	log.Printf("Warning: SMTP_HOST not set, emails (password reset, Telegram and email change codes) are disabled")
+	return service.DisabledMailer{}
+}
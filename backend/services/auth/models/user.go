@@ -6,19 +6,22 @@ import (
 	"gorm.io/gorm"
 )
 
-// User represents a user in the system
+// User represents a user in the system. Identities are unique among users
+// that aren't deleted, so a deleted user's email or login can be reused.
 type User struct {
 	ID               string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	Email            string         `gorm:"uniqueIndex;not null" json:"email"`
+	Email            string         `gorm:"index:idx_users_email_active,unique,where:deleted_at IS NULL;not null" json:"email"`
 	PasswordHash     string         `gorm:"" json:"-"`
-	GoogleID         *string        `gorm:"index:idx_users_google_id,unique,where:google_id IS NOT NULL" json:"google_id,omitempty"`
-	TelegramID       *int64         `gorm:"index:idx_users_telegram_id,unique,where:telegram_id IS NOT NULL" json:"telegram_id,omitempty"`
+	GoogleID         *string        `gorm:"index:idx_users_google_id_active,unique,where:google_id IS NOT NULL AND deleted_at IS NULL" json:"google_id,omitempty"`
+	AppleID          *string        `gorm:"index:idx_users_apple_id_active,unique,where:apple_id IS NOT NULL AND deleted_at IS NULL" json:"apple_id,omitempty"`
+	TelegramID       *int64         `gorm:"index:idx_users_telegram_id_active,unique,where:telegram_id IS NOT NULL AND deleted_at IS NULL" json:"telegram_id,omitempty"`
 	TelegramUsername *string        `gorm:"size:100" json:"telegram_username,omitempty"`
 	FirstName        string         `gorm:"size:100" json:"first_name"`
 	LastName         string         `gorm:"size:100" json:"last_name"`
 	BaseCurrency     string         `gorm:"size:3;default:'USD'" json:"base_currency"`
 	AvatarURL        string         `gorm:"size:500" json:"avatar_url,omitempty"`
 	IsActive         bool           `gorm:"default:true" json:"is_active"`
+	TokenVersion     int            `gorm:"not null;default:0" json:"-"`
 	LastLoginAt      *time.Time     `json:"last_login_at,omitempty"`
 	CreatedAt        time.Time      `json:"created_at"`
 	UpdatedAt        time.Time      `json:"updated_at"`
@@ -30,11 +33,12 @@ func (User) TableName() string {
 	return "users"
 }
 
-// RefreshToken represents a refresh token stored in the database
+// RefreshToken represents a refresh token stored in the database.
+// Only the SHA-256 hash of the opaque token is persisted.
 type RefreshToken struct {
 	ID        string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	UserID    string         `gorm:"type:uuid;not null;index" json:"user_id"`
-	Token     string         `gorm:"uniqueIndex;not null" json:"token"`
+	TokenHash string         `gorm:"size:64;uniqueIndex" json:"-"`
 	ExpiresAt time.Time      `gorm:"not null" json:"expires_at"`
 	IsRevoked bool           `gorm:"default:false" json:"is_revoked"`
 	CreatedAt time.Time      `json:"created_at"`
@@ -62,3 +66,57 @@ func (OAuthState) TableName() string {
 	return "oauth_states"
 }
 
+// TelegramLinkCode stores a one-time code for linking a Telegram chat to an account
+type TelegramLinkCode struct {
+	ID               string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID           string    `gorm:"type:uuid;not null;index" json:"user_id"`
+	TelegramID       int64     `gorm:"not null;uniqueIndex" json:"telegram_id"`
+	TelegramUsername string    `gorm:"size:100" json:"telegram_username,omitempty"`
+	Code             string    `gorm:"size:10;not null" json:"-"`
+	Attempts         int       `gorm:"default:0" json:"attempts"`
+	ExpiresAt        time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (TelegramLinkCode) TableName() string {
+	return "telegram_link_codes"
+}
+
+// EmailChangeRequest stores a pending email change awaiting confirmation
+type EmailChangeRequest struct {
+	ID        string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    string    `gorm:"type:uuid;not null;uniqueIndex" json:"user_id"`
+	NewEmail  string    `gorm:"not null" json:"new_email"`
+	Code      string    `gorm:"size:10;not null" json:"-"`
+	Attempts  int       `gorm:"default:0" json:"attempts"`
+	ExpiresAt time.Time `gorm:"not null" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (EmailChangeRequest) TableName() string {
+	return "email_change_requests"
+}
+
+// PasswordResetToken stores a single-use password reset token.
+// Only the SHA-256 hash of the token is persisted.
+type PasswordResetToken struct {
+	ID        string     `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    string     `gorm:"type:uuid;not null;index" json:"user_id"`
+	TokenHash string     `gorm:"size:64;uniqueIndex;not null" json:"-"`
+	ExpiresAt time.Time  `gorm:"not null" json:"expires_at"`
+	UsedAt    *time.Time `json:"used_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	User User `gorm:"foreignKey:UserID" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (PasswordResetToken) TableName() string {
+	return "password_reset_tokens"
+}
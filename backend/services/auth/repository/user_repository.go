@@ -2,9 +2,13 @@ package repository
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
 	"errors"
 	"time"
 
+	"github.com/radmickey/money-control/backend/pkg/database"
 	"github.com/radmickey/money-control/backend/services/auth/models"
 	"gorm.io/gorm"
 )
@@ -14,6 +18,8 @@ var (
 	ErrUserExists      = errors.New("user already exists")
 	ErrInvalidToken    = errors.New("invalid or expired token")
 	ErrInvalidState    = errors.New("invalid or expired OAuth state")
+	ErrInvalidLinkCode = errors.New("invalid or expired link code")
+	ErrInvalidCode     = errors.New("invalid or expired verification code")
 )
 
 // UserRepository handles database operations for users
@@ -34,7 +40,14 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 		return ErrUserExists
 	}
 
-	return r.db.WithContext(ctx).Create(user).Error
+	// The check above can race another sign-up
+	if err := r.db.WithContext(ctx).Create(user).Error; err != nil {
+		if database.IsUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return err
+	}
+	return nil
 }
 
 // GetByID finds a user by ID
@@ -49,6 +62,19 @@ func (r *UserRepository) GetByID(ctx context.Context, id string) (*models.User,
 	return &user, nil
 }
 
+// ListActive lists up to limit active users with IDs after afterID, in ID order
+func (r *UserRepository) ListActive(ctx context.Context, afterID string, limit int) ([]models.User, error) {
+	var users []models.User
+	query := r.db.WithContext(ctx).Where("is_active = ?", true)
+	if afterID != "" {
+		query = query.Where("id > ?", afterID)
+	}
+	if err := query.Order("id").Limit(limit).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
 // GetByEmail finds a user by email
 func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var user models.User
@@ -73,6 +99,18 @@ func (r *UserRepository) GetByGoogleID(ctx context.Context, googleID string) (*m
 	return &user, nil
 }
 
+// GetByAppleID finds a user by Apple ID
+func (r *UserRepository) GetByAppleID(ctx context.Context, appleID string) (*models.User, error) {
+	var user models.User
+	if err := r.db.WithContext(ctx).Where("apple_id = ?", appleID).First(&user).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &user, nil
+}
+
 // GetByTelegramID finds a user by Telegram ID
 func (r *UserRepository) GetByTelegramID(ctx context.Context, telegramID int64) (*models.User, error) {
 	var user models.User
@@ -87,7 +125,14 @@ func (r *UserRepository) GetByTelegramID(ctx context.Context, telegramID int64)
 
 // Update updates a user
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
-	return r.db.WithContext(ctx).Save(user).Error
+	if err := r.db.WithContext(ctx).Save(user).Error; err != nil {
+		// Another user already has the email or login being set
+		if database.IsUniqueViolation(err) {
+			return ErrUserExists
+		}
+		return err
+	}
+	return nil
 }
 
 // UpdateLastLogin updates the last login timestamp
@@ -96,11 +141,59 @@ func (r *UserRepository) UpdateLastLogin(ctx context.Context, userID string) err
 	return r.db.WithContext(ctx).Model(&models.User{}).Where("id = ?", userID).Update("last_login_at", now).Error
 }
 
+// IncrementTokenVersion bumps the user's token version and returns the new value
+func (r *UserRepository) IncrementTokenVersion(ctx context.Context, userID string) (int, error) {
+	var user models.User
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Where("id = ?", userID).
+			Update("token_version", gorm.Expr("token_version + 1"))
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrUserNotFound
+		}
+		return tx.Select("token_version").Where("id = ?", userID).First(&user).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return user.TokenVersion, nil
+}
+
+// GetTokenVersion returns the user's current token version
+func (r *UserRepository) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	user, err := r.GetByID(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	return user.TokenVersion, nil
+}
+
 // Delete soft-deletes a user
 func (r *UserRepository) Delete(ctx context.Context, id string) error {
 	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.User{}).Error
 }
 
+// legacyUserIndexes are the unique indexes older versions created over all
+// users, deleted ones included
+var legacyUserIndexes = []string{"idx_users_email", "idx_users_google_id", "idx_users_apple_id", "idx_users_telegram_id"}
+
+// MigrateUniqueIndexes drops the unique indexes that counted deleted users.
+// AutoMigrate creates their replacements but never drops an index.
+func (r *UserRepository) MigrateUniqueIndexes(ctx context.Context) error {
+	migrator := r.db.WithContext(ctx).Migrator()
+	for _, name := range legacyUserIndexes {
+		if !migrator.HasIndex(&models.User{}, name) {
+			continue
+		}
+		if err := migrator.DropIndex(&models.User{}, name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // RefreshTokenRepository handles refresh token operations
 type RefreshTokenRepository struct {
 	db *gorm.DB
@@ -111,15 +204,19 @@ func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
 	return &RefreshTokenRepository{db: db}
 }
 
-// Create creates a new refresh token
-func (r *RefreshTokenRepository) Create(ctx context.Context, token *models.RefreshToken) error {
-	return r.db.WithContext(ctx).Create(token).Error
+// Create stores a new refresh token by hash. The plaintext is only returned to the client.
+func (r *RefreshTokenRepository) Create(ctx context.Context, userID, token string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Create(&models.RefreshToken{
+		UserID:    userID,
+		TokenHash: hashRefreshToken(token),
+		ExpiresAt: expiresAt,
+	}).Error
 }
 
-// GetByToken finds a refresh token by its value
+// GetByToken finds an active refresh token by its value
 func (r *RefreshTokenRepository) GetByToken(ctx context.Context, token string) (*models.RefreshToken, error) {
 	var rt models.RefreshToken
-	if err := r.db.WithContext(ctx).Where("token = ? AND is_revoked = false AND expires_at > ?", token, time.Now()).First(&rt).Error; err != nil {
+	if err := r.db.WithContext(ctx).Where("token_hash = ? AND is_revoked = false AND expires_at > ?", hashRefreshToken(token), time.Now()).First(&rt).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrInvalidToken
 		}
@@ -128,9 +225,31 @@ func (r *RefreshTokenRepository) GetByToken(ctx context.Context, token string) (
 	return &rt, nil
 }
 
+// Consume atomically revokes an active refresh token and returns it, so a token
+// can only be rotated once even under concurrent refreshes
+func (r *RefreshTokenRepository) Consume(ctx context.Context, token string) (*models.RefreshToken, error) {
+	rt, err := r.GetByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.RefreshToken{}).
+		Where("id = ? AND is_revoked = false", rt.ID).
+		Update("is_revoked", true)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrInvalidToken
+	}
+
+	rt.IsRevoked = true
+	return rt, nil
+}
+
 // Revoke revokes a refresh token
 func (r *RefreshTokenRepository) Revoke(ctx context.Context, token string) error {
-	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).Where("token = ?", token).Update("is_revoked", true).Error
+	return r.db.WithContext(ctx).Model(&models.RefreshToken{}).Where("token_hash = ?", hashRefreshToken(token)).Update("is_revoked", true).Error
 }
 
 // RevokeAllForUser revokes all refresh tokens for a user
@@ -143,6 +262,26 @@ func (r *RefreshTokenRepository) DeleteExpired(ctx context.Context) error {
 	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.RefreshToken{}).Error
 }
 
+// MigrateLegacyTokens removes refresh tokens stored in plaintext by older versions.
+// Affected users have to log in again.
+func (r *RefreshTokenRepository) MigrateLegacyTokens(ctx context.Context) error {
+	migrator := r.db.WithContext(ctx).Migrator()
+	if !migrator.HasColumn(&models.RefreshToken{}, "token") {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).Unscoped().Where("token_hash IS NULL").Delete(&models.RefreshToken{}).Error; err != nil {
+		return err
+	}
+	return migrator.DropColumn(&models.RefreshToken{}, "token")
+}
+
+// hashRefreshToken hashes a refresh token for storage and lookup
+func hashRefreshToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
 // OAuthStateRepository handles OAuth state operations
 type OAuthStateRepository struct {
 	db *gorm.DB
@@ -177,3 +316,167 @@ func (r *OAuthStateRepository) DeleteExpired(ctx context.Context) error {
 	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.OAuthState{}).Error
 }
 
+const (
+	maxLinkCodeAttempts  = 5
+	maxEmailCodeAttempts = 5
+)
+
+// TelegramLinkCodeRepository handles Telegram link code operations
+type TelegramLinkCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewTelegramLinkCodeRepository creates a new Telegram link code repository
+func NewTelegramLinkCodeRepository(db *gorm.DB) *TelegramLinkCodeRepository {
+	return &TelegramLinkCodeRepository{db: db}
+}
+
+// Create creates a new link code, replacing any pending code for the same Telegram ID
+func (r *TelegramLinkCodeRepository) Create(ctx context.Context, code *models.TelegramLinkCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("telegram_id = ?", code.TelegramID).Delete(&models.TelegramLinkCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(code).Error
+	})
+}
+
+// Consume validates and deletes a link code (one-time use).
+// Failed attempts are counted and the code is discarded after too many.
+func (r *TelegramLinkCodeRepository) Consume(ctx context.Context, telegramID int64, code string) (*models.TelegramLinkCode, error) {
+	var lc models.TelegramLinkCode
+	if err := r.db.WithContext(ctx).Where("telegram_id = ? AND expires_at > ?", telegramID, time.Now()).First(&lc).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidLinkCode
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(lc.Code), []byte(code)) != 1 {
+		lc.Attempts++
+		if lc.Attempts >= maxLinkCodeAttempts {
+			_ = r.db.WithContext(ctx).Delete(&lc).Error
+		} else {
+			_ = r.db.WithContext(ctx).Model(&lc).Update("attempts", lc.Attempts).Error
+		}
+		return nil, ErrInvalidLinkCode
+	}
+
+	if err := r.db.WithContext(ctx).Delete(&lc).Error; err != nil {
+		return nil, err
+	}
+	return &lc, nil
+}
+
+// DeleteExpired deletes expired link codes
+func (r *TelegramLinkCodeRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.TelegramLinkCode{}).Error
+}
+
+// EmailChangeRepository handles pending email change operations
+type EmailChangeRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailChangeRepository creates a new email change repository
+func NewEmailChangeRepository(db *gorm.DB) *EmailChangeRepository {
+	return &EmailChangeRepository{db: db}
+}
+
+// Create creates a pending email change, replacing any existing one for the user
+func (r *EmailChangeRepository) Create(ctx context.Context, req *models.EmailChangeRequest) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", req.UserID).Delete(&models.EmailChangeRequest{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(req).Error
+	})
+}
+
+// Consume validates and deletes a pending email change (one-time use).
+// Failed attempts are counted and the request is discarded after too many.
+func (r *EmailChangeRepository) Consume(ctx context.Context, userID, code string) (*models.EmailChangeRequest, error) {
+	var req models.EmailChangeRequest
+	if err := r.db.WithContext(ctx).Where("user_id = ? AND expires_at > ?", userID, time.Now()).First(&req).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrInvalidCode
+		}
+		return nil, err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(req.Code), []byte(code)) != 1 {
+		req.Attempts++
+		if req.Attempts >= maxEmailCodeAttempts {
+			_ = r.db.WithContext(ctx).Delete(&req).Error
+		} else {
+			_ = r.db.WithContext(ctx).Model(&req).Update("attempts", req.Attempts).Error
+		}
+		return nil, ErrInvalidCode
+	}
+
+	if err := r.db.WithContext(ctx).Delete(&req).Error; err != nil {
+		return nil, err
+	}
+	return &req, nil
+}
+
+// DeleteExpired deletes expired email change requests
+func (r *EmailChangeRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&models.EmailChangeRequest{}).Error
+}
+
+// PasswordResetRepository handles password reset token operations
+type PasswordResetRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository creates a new password reset repository
+func NewPasswordResetRepository(db *gorm.DB) *PasswordResetRepository {
+	return &PasswordResetRepository{db: db}
+}
+
+// Create stores a new reset token, invalidating any earlier tokens for the user
+func (r *PasswordResetRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", token.UserID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(token).Error
+	})
+}
+
+// Consume marks a reset token as used and returns it (one-time use)
+func (r *PasswordResetRepository) Consume(ctx context.Context, tokenHash string) (*models.PasswordResetToken, error) {
+	var token models.PasswordResetToken
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("token_hash = ? AND used_at IS NULL AND expires_at > ?", tokenHash, time.Now()).First(&token).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidToken
+			}
+			return err
+		}
+
+		// Guard against concurrent use of the same token
+		now := time.Now()
+		result := tx.Model(&models.PasswordResetToken{}).
+			Where("id = ? AND used_at IS NULL", token.ID).
+			Update("used_at", now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrInvalidToken
+		}
+		token.UsedAt = &now
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// DeleteExpired deletes expired and used reset tokens
+func (r *PasswordResetRepository) DeleteExpired(ctx context.Context) error {
+	return r.db.WithContext(ctx).Where("expires_at < ? OR used_at IS NOT NULL", time.Now()).Delete(&models.PasswordResetToken{}).Error
+}
@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	"github.com/radmickey/money-control/backend/services/auth/models"
+)
+
+func TestCreateUserReusesDeletedEmail(t *testing.T) {
+	db := databasetest.Open(t, &models.User{})
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+
+	first := &models.User{Email: "ann@example.com"}
+	if err := repo.Create(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, &models.User{Email: "ann@example.com"}); !errors.Is(err, ErrUserExists) {
+		t.Fatalf("duplicate email: got %v, want ErrUserExists", err)
+	}
+
+	if err := repo.Delete(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(ctx, &models.User{Email: "ann@example.com"}); err != nil {
+		t.Fatalf("signing up with a deleted user's email: %v", err)
+	}
+}
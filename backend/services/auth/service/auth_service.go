@@ -2,7 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,6 +21,21 @@ import (
 var (
 	ErrInvalidCredentials = errors.New("invalid email or password")
 	ErrUserNotActive      = errors.New("user account is not active")
+	ErrTelegramLinked     = errors.New("telegram account is already linked to another user")
+	ErrTelegramNotLinked  = errors.New("telegram account is not linked to this user")
+	ErrIncorrectPassword  = errors.New("current password is incorrect")
+	ErrEmailUnchanged     = errors.New("new email matches the current email")
+	ErrAppleEmailMissing  = errors.New("apple did not return an email for this account")
+)
+
+const (
+	telegramLinkCodeTTL   = 10 * time.Minute
+	telegramLoginTokenTTL = 5 * time.Minute
+	emailChangeCodeTTL    = 15 * time.Minute
+	passwordResetTokenTTL = 30 * time.Minute
+
+	// maxListUsersPageSize caps ListActiveUsers pages
+	maxListUsersPageSize = 500
 )
 
 // AuthService handles authentication business logic
@@ -21,8 +43,16 @@ type AuthService struct {
 	userRepo         *repository.UserRepository
 	refreshTokenRepo *repository.RefreshTokenRepository
 	oauthStateRepo   *repository.OAuthStateRepository
+	linkCodeRepo     *repository.TelegramLinkCodeRepository
+	emailChangeRepo  *repository.EmailChangeRepository
+	resetRepo        *repository.PasswordResetRepository
 	jwtManager       *auth.JWTManager
 	oauthManager     *auth.OAuthManager
+	tokenVersions    *auth.TokenVersionStore
+	loginLimiter     *LoginLimiter
+	linkLimiter      *RequestLimiter
+	reauth           *Reauthenticator
+	mailer           Mailer
 	refreshDuration  time.Duration
 }
 
@@ -31,16 +61,32 @@ func NewAuthService(
 	userRepo *repository.UserRepository,
 	refreshTokenRepo *repository.RefreshTokenRepository,
 	oauthStateRepo *repository.OAuthStateRepository,
+	linkCodeRepo *repository.TelegramLinkCodeRepository,
+	emailChangeRepo *repository.EmailChangeRepository,
+	resetRepo *repository.PasswordResetRepository,
 	jwtManager *auth.JWTManager,
 	oauthManager *auth.OAuthManager,
+	tokenVersions *auth.TokenVersionStore,
+	loginLimiter *LoginLimiter,
+	linkLimiter *RequestLimiter,
+	reauth *Reauthenticator,
+	mailer Mailer,
 	refreshDuration time.Duration,
 ) *AuthService {
 	return &AuthService{
 		userRepo:         userRepo,
 		refreshTokenRepo: refreshTokenRepo,
 		oauthStateRepo:   oauthStateRepo,
+		linkCodeRepo:     linkCodeRepo,
+		emailChangeRepo:  emailChangeRepo,
+		resetRepo:        resetRepo,
 		jwtManager:       jwtManager,
 		oauthManager:     oauthManager,
+		tokenVersions:    tokenVersions,
+		loginLimiter:     loginLimiter,
+		linkLimiter:      linkLimiter,
+		reauth:           reauth,
+		mailer:           mailer,
 		refreshDuration:  refreshDuration,
 	}
 }
@@ -100,11 +146,21 @@ func (s *AuthService) Register(ctx context.Context, input RegisterInput) (*AuthR
 
 // Login authenticates a user with email and password
 func (s *AuthService) Login(ctx context.Context, email, password string) (*AuthResult, error) {
+	// Refuse while the email is locked out
+	if s.loginLimiter != nil {
+		remaining, err := s.loginLimiter.Check(ctx, email)
+		if err != nil {
+			log.Printf("Login lockout check failed: %v", err)
+		} else if remaining > 0 {
+			return nil, &AccountLockedError{RetryAfter: remaining}
+		}
+	}
+
 	// Find user
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return nil, ErrInvalidCredentials
+			return nil, s.loginFailed(ctx, email)
 		}
 		return nil, err
 	}
@@ -116,7 +172,13 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*AuthR
 
 	// Verify password
 	if err := auth.CheckPassword(password, user.PasswordHash); err != nil {
-		return nil, ErrInvalidCredentials
+		return nil, s.loginFailed(ctx, email)
+	}
+
+	if s.loginLimiter != nil {
+		if err := s.loginLimiter.Reset(ctx, email); err != nil {
+			log.Printf("Failed to reset login failures: %v", err)
+		}
 	}
 
 	// Update last login
@@ -126,6 +188,23 @@ func (s *AuthService) Login(ctx context.Context, email, password string) (*AuthR
 	return s.generateAuthResult(ctx, user)
 }
 
+// loginFailed records a failed login and returns the error to report to the caller
+func (s *AuthService) loginFailed(ctx context.Context, email string) error {
+	if s.loginLimiter == nil {
+		return ErrInvalidCredentials
+	}
+
+	lockout, err := s.loginLimiter.RecordFailure(ctx, email)
+	if err != nil {
+		log.Printf("Failed to record login failure: %v", err)
+		return ErrInvalidCredentials
+	}
+	if lockout > 0 {
+		return &AccountLockedError{RetryAfter: lockout}
+	}
+	return ErrInvalidCredentials
+}
+
 // GoogleAuth authenticates or registers a user via Google OAuth
 func (s *AuthService) GoogleAuth(ctx context.Context, code string) (*AuthResult, error) {
 	// Get user info from Google
@@ -183,6 +262,63 @@ func (s *AuthService) GoogleAuth(ctx context.Context, code string) (*AuthResult,
 	return s.generateAuthResult(ctx, user)
 }
 
+// AppleAuth authenticates or registers a user via Apple Sign-In
+func (s *AuthService) AppleAuth(ctx context.Context, code string) (*AuthResult, error) {
+	// Exchange the code and verify the identity token against Apple's keys
+	appleUser, err := s.oauthManager.AuthenticateWithApple(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to find existing user by Apple ID
+	user, err := s.userRepo.GetByAppleID(ctx, appleUser.ID)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+
+	if user == nil {
+		if appleUser.Email == "" {
+			return nil, ErrAppleEmailMissing
+		}
+
+		// Only link by email when Apple has verified it
+		if appleUser.EmailVerified {
+			user, err = s.userRepo.GetByEmail(ctx, appleUser.Email)
+			if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+				return nil, err
+			}
+		}
+
+		if user != nil {
+			// Link Apple account to existing user
+			user.AppleID = &appleUser.ID
+			if err := s.userRepo.Update(ctx, user); err != nil {
+				return nil, err
+			}
+		} else {
+			// Create new user
+			user = &models.User{
+				Email:        appleUser.Email,
+				AppleID:      &appleUser.ID,
+				BaseCurrency: "USD",
+				IsActive:     true,
+			}
+			if err := s.userRepo.Create(ctx, user); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if !user.IsActive {
+		return nil, ErrUserNotActive
+	}
+
+	// Update last login
+	_ = s.userRepo.UpdateLastLogin(ctx, user.ID)
+
+	return s.generateAuthResult(ctx, user)
+}
+
 // TelegramAuthInput holds Telegram auth data
 type TelegramAuthInput struct {
 	ID        int64
@@ -201,7 +337,7 @@ func (s *AuthService) TelegramAuth(ctx context.Context, input TelegramAuthInput)
 
 	if user == nil {
 		// Create new user - generate unique email for Telegram users
-		email := uuid.New().String() + "@telegram.user"
+		email := uuid.New().String() + telegramEmailDomain
 		username := input.Username
 		user = &models.User{
 			Email:            email,
@@ -227,6 +363,107 @@ func (s *AuthService) TelegramAuth(ctx context.Context, input TelegramAuthInput)
 	return s.generateAuthResult(ctx, user)
 }
 
+// RequestTelegramLink sends a one-time code to the account's email so a Telegram chat can be linked
+func (s *AuthService) RequestTelegramLink(ctx context.Context, email string, telegramID int64, telegramUsername string) (time.Time, error) {
+	// Limit codes per chat and per account, counting unknown emails too
+	if s.linkLimiter != nil {
+		if err := s.linkLimiter.Allow(ctx, telegramLimitKey(telegramID), emailLimitKey(email)); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if !user.IsActive {
+		return time.Time{}, ErrUserNotActive
+	}
+
+	code, err := generateNumericCode(6)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(telegramLinkCodeTTL)
+	linkCode := &models.TelegramLinkCode{
+		UserID:           user.ID,
+		TelegramID:       telegramID,
+		TelegramUsername: telegramUsername,
+		Code:             code,
+		ExpiresAt:        expiresAt,
+	}
+	if err := s.linkCodeRepo.Create(ctx, linkCode); err != nil {
+		return time.Time{}, err
+	}
+
+	body := fmt.Sprintf("Your Money Control Telegram link code is %s.\n\n"+
+		"Send /link %s to the bot to finish linking. The code expires in %d minutes.\n"+
+		"If you did not request this, you can ignore this email.",
+		code, code, int(telegramLinkCodeTTL.Minutes()))
+	if err := s.mailer.Send(ctx, user.Email, "Your Telegram link code", body); err != nil {
+		return time.Time{}, err
+	}
+
+	return expiresAt, nil
+}
+
+// ConfirmTelegramLink verifies a link code and binds the Telegram ID to the account
+func (s *AuthService) ConfirmTelegramLink(ctx context.Context, telegramID int64, code string) (*models.User, error) {
+	linkCode, err := s.linkCodeRepo.Consume(ctx, telegramID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, linkCode.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.userRepo.GetByTelegramID(ctx, telegramID)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.ID != user.ID {
+		return nil, ErrTelegramLinked
+	}
+
+	user.TelegramID = &telegramID
+	if linkCode.TelegramUsername != "" {
+		user.TelegramUsername = &linkCode.TelegramUsername
+	}
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// IssueTelegramLoginToken mints a short-lived access token for a user linked to the given Telegram ID
+func (s *AuthService) IssueTelegramLoginToken(ctx context.Context, userID string, telegramID int64) (string, time.Time, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	if !user.IsActive {
+		return "", time.Time{}, ErrUserNotActive
+	}
+
+	if user.TelegramID == nil || *user.TelegramID != telegramID {
+		return "", time.Time{}, ErrTelegramNotLinked
+	}
+
+	token, _, err := s.jwtManager.GenerateAccessTokenWithDuration(user.ID, user.Email, user.TokenVersion, telegramLoginTokenTTL)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, time.Now().Add(telegramLoginTokenTTL), nil
+}
+
 // GetGoogleAuthURL returns the Google OAuth authorization URL
 func (s *AuthService) GetGoogleAuthURL(ctx context.Context) (string, error) {
 	// Generate and store state
@@ -243,6 +480,26 @@ func (s *AuthService) GetGoogleAuthURL(ctx context.Context) (string, error) {
 	return s.oauthManager.GetGoogleAuthURL(state), nil
 }
 
+// GetAppleAuthURL returns the Apple Sign-In authorization URL
+func (s *AuthService) GetAppleAuthURL(ctx context.Context) (string, error) {
+	if !s.oauthManager.AppleEnabled() {
+		return "", auth.ErrAppleNotConfigured
+	}
+
+	// Generate and store state
+	state := uuid.New().String()
+	oauthState := &models.OAuthState{
+		State:     state,
+		ExpiresAt: time.Now().Add(10 * time.Minute),
+	}
+
+	if err := s.oauthStateRepo.Create(ctx, oauthState); err != nil {
+		return "", err
+	}
+
+	return s.oauthManager.GetAppleAuthURL(state)
+}
+
 // ValidateOAuthState validates an OAuth state
 func (s *AuthService) ValidateOAuthState(ctx context.Context, state string) error {
 	return s.oauthStateRepo.Validate(ctx, state)
@@ -250,8 +507,8 @@ func (s *AuthService) ValidateOAuthState(ctx context.Context, state string) erro
 
 // RefreshToken refreshes an access token using a refresh token
 func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*AuthResult, error) {
-	// Validate refresh token in database
-	rt, err := s.refreshTokenRepo.GetByToken(ctx, refreshToken)
+	// Validate and revoke the refresh token in one step so it cannot be reused
+	rt, err := s.refreshTokenRepo.Consume(ctx, refreshToken)
 	if err != nil {
 		return nil, err
 	}
@@ -266,9 +523,6 @@ func (s *AuthService) RefreshToken(ctx context.Context, refreshToken string) (*A
 		return nil, ErrUserNotActive
 	}
 
-	// Revoke old refresh token
-	_ = s.refreshTokenRepo.Revoke(ctx, refreshToken)
-
 	// Generate new tokens
 	return s.generateAuthResult(ctx, user)
 }
@@ -278,9 +532,9 @@ func (s *AuthService) Logout(ctx context.Context, userID, refreshToken string) e
 	return s.refreshTokenRepo.Revoke(ctx, refreshToken)
 }
 
-// LogoutAll revokes all refresh tokens for a user
+// LogoutAll revokes all refresh tokens and outstanding access tokens for a user
 func (s *AuthService) LogoutAll(ctx context.Context, userID string) error {
-	return s.refreshTokenRepo.RevokeAllForUser(ctx, userID)
+	return s.revokeAllSessions(ctx, userID)
 }
 
 // GetProfile returns user profile
@@ -288,6 +542,25 @@ func (s *AuthService) GetProfile(ctx context.Context, userID string) (*models.Us
 	return s.userRepo.GetByID(ctx, userID)
 }
 
+// ListActiveUsers pages through active users by ID. It returns the cursor for
+// the next page, or "" after the last one.
+func (s *AuthService) ListActiveUsers(ctx context.Context, afterID string, limit int) ([]models.User, string, error) {
+	if limit <= 0 || limit > maxListUsersPageSize {
+		limit = maxListUsersPageSize
+	}
+
+	users, err := s.userRepo.ListActive(ctx, afterID, limit)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(users) == limit {
+		next = users[len(users)-1].ID
+	}
+	return users, next, nil
+}
+
 // UpdateProfile updates user profile
 func (s *AuthService) UpdateProfile(ctx context.Context, userID, firstName, lastName, baseCurrency string) (*models.User, error) {
 	user, err := s.userRepo.GetByID(ctx, userID)
@@ -312,34 +585,312 @@ func (s *AuthService) UpdateProfile(ctx context.Context, userID, firstName, last
 	return user, nil
 }
 
-// ValidateToken validates an access token
-func (s *AuthService) ValidateToken(token string) (*auth.AccessClaims, error) {
-	return s.jwtManager.ValidateAccessToken(token)
+// ChangePassword replaces the user's password after confirming the current one,
+// or a re-authentication code for accounts without one.
+// All refresh tokens are revoked so other sessions must log in again.
+func (s *AuthService) ChangePassword(ctx context.Context, userID, currentPassword, reauthCode, newPassword string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.confirmIdentity(ctx, user, currentPassword, reauthCode); err != nil {
+		return err
+	}
+
+	if err := auth.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	hashedPassword, err := auth.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = hashedPassword
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return s.revokeAllSessions(ctx, user.ID)
 }
 
-func (s *AuthService) generateAuthResult(ctx context.Context, user *models.User) (*AuthResult, error) {
-	// Generate token pair
-	tokenPair, err := s.jwtManager.GenerateTokenPair(user.ID, user.Email)
+// RequestEmailChange sends a confirmation code to the new address after confirming
+// the current password, or a re-authentication code for accounts without one
+func (s *AuthService) RequestEmailChange(ctx context.Context, userID, newEmail, currentPassword, reauthCode string) (time.Time, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if err := s.confirmIdentity(ctx, user, currentPassword, reauthCode); err != nil {
+		return time.Time{}, err
+	}
+
+	if strings.EqualFold(user.Email, newEmail) {
+		return time.Time{}, ErrEmailUnchanged
+	}
+
+	if _, err := s.userRepo.GetByEmail(ctx, newEmail); err == nil {
+		return time.Time{}, repository.ErrUserExists
+	} else if !errors.Is(err, repository.ErrUserNotFound) {
+		return time.Time{}, err
+	}
+
+	code, err := generateNumericCode(6)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(emailChangeCodeTTL)
+	req := &models.EmailChangeRequest{
+		UserID:    user.ID,
+		NewEmail:  newEmail,
+		Code:      code,
+		ExpiresAt: expiresAt,
+	}
+	if err := s.emailChangeRepo.Create(ctx, req); err != nil {
+		return time.Time{}, err
+	}
+
+	body := fmt.Sprintf("Your Money Control email confirmation code is %s.\n\n"+
+		"Enter it in the app to finish changing your email. The code expires in %d minutes.\n"+
+		"If you did not request this, you can ignore this email.",
+		code, int(emailChangeCodeTTL.Minutes()))
+	if err := s.mailer.Send(ctx, newEmail, "Confirm your new email", body); err != nil {
+		return time.Time{}, err
+	}
+
+	return expiresAt, nil
+}
+
+// ConfirmEmailChange verifies the code sent to the new address and applies the change
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, userID, code string) (*models.User, error) {
+	req, err := s.emailChangeRepo.Consume(ctx, userID, code)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	// Store refresh token in database
-	rt := &models.RefreshToken{
+	// The address may have been claimed while the code was pending
+	existing, err := s.userRepo.GetByEmail(ctx, req.NewEmail)
+	if err != nil && !errors.Is(err, repository.ErrUserNotFound) {
+		return nil, err
+	}
+	if existing != nil && existing.ID != user.ID {
+		return nil, repository.ErrUserExists
+	}
+
+	user.Email = req.NewEmail
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// Access tokens carry the email claim, so force other sessions to re-authenticate
+	if err := s.revokeAllSessions(ctx, user.ID); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// RequestReauthCode sends a one-time code to the account's email or
+// Telegram chat, for confirming changes on accounts without a password.
+// It returns the channel the code was sent to.
+func (s *AuthService) RequestReauthCode(ctx context.Context, userID string) (string, time.Time, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return s.reauth.Send(ctx, user)
+}
+
+// confirmIdentity checks the current password, or for accounts without one
+// (OAuth and Telegram sign-ups) a code from RequestReauthCode, since the
+// access token alone must not be enough to take the account over
+func (s *AuthService) confirmIdentity(ctx context.Context, user *models.User, currentPassword, reauthCode string) error {
+	if user.PasswordHash != "" {
+		if err := auth.CheckPassword(currentPassword, user.PasswordHash); err != nil {
+			return ErrIncorrectPassword
+		}
+		return nil
+	}
+
+	if reauthCode == "" || s.reauth == nil {
+		return ErrReauthRequired
+	}
+	return s.reauth.Verify(ctx, user.ID, reauthCode)
+}
+
+// RequestPasswordReset emails a single-use reset token to the account.
+// Unknown or inactive emails are silently ignored so callers cannot probe for accounts.
+// The token only ever leaves through the mailer; it is never logged.
+func (s *AuthService) RequestPasswordReset(ctx context.Context, email string) error {
+	// Fail the same way for every email when mail can't be sent, so the
+	// error doesn't reveal which accounts exist
+	if !canSend(s.mailer) {
+		return ErrMailUnavailable
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	if !user.IsActive {
+		return nil
+	}
+
+	token, err := generateSecureToken()
+	if err != nil {
+		return err
+	}
+
+	resetToken := &models.PasswordResetToken{
 		UserID:    user.ID,
-		Token:     tokenPair.RefreshToken,
-		ExpiresAt: time.Now().Add(s.refreshDuration),
+		TokenHash: hashResetToken(token),
+		ExpiresAt: time.Now().Add(passwordResetTokenTTL),
+	}
+	if err := s.resetRepo.Create(ctx, resetToken); err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf("Use this token to reset your Money Control password:\n\n%s\n\n"+
+		"The token expires in %d minutes and can only be used once.\n"+
+		"If you did not request this, you can ignore this email.",
+		token, int(passwordResetTokenTTL.Minutes()))
+	return s.mailer.Send(ctx, user.Email, "Reset your password", body)
+}
+
+// ResetPassword sets a new password using a reset token and revokes all sessions
+func (s *AuthService) ResetPassword(ctx context.Context, token, newPassword string) error {
+	if err := auth.ValidatePassword(newPassword); err != nil {
+		return err
+	}
+
+	resetToken, err := s.resetRepo.Consume(ctx, hashResetToken(token))
+	if err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, resetToken.UserID)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := auth.HashPassword(newPassword)
+	if err != nil {
+		return err
+	}
+
+	user.PasswordHash = hashedPassword
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return err
+	}
+
+	return s.revokeAllSessions(ctx, user.ID)
+}
+
+// ValidateToken validates an access token and rejects tokens with a stale version
+func (s *AuthService) ValidateToken(ctx context.Context, token string) (*auth.AccessClaims, error) {
+	claims, err := s.jwtManager.ValidateAccessToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.tokenVersions.Check(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// GetTokenVersion returns the user's current token version, through the cache when there is one
+func (s *AuthService) GetTokenVersion(ctx context.Context, userID string) (int, error) {
+	if s.tokenVersions == nil {
+		return s.userRepo.GetTokenVersion(ctx, userID)
+	}
+	return s.tokenVersions.Get(ctx, userID)
+}
+
+// revokeAllSessions revokes refresh tokens and bumps the token version so
+// already-issued access tokens stop validating
+func (s *AuthService) revokeAllSessions(ctx context.Context, userID string) error {
+	if err := s.refreshTokenRepo.RevokeAllForUser(ctx, userID); err != nil {
+		return err
+	}
+
+	version, err := s.userRepo.IncrementTokenVersion(ctx, userID)
+	if err != nil {
+		return err
 	}
 
-	if err := s.refreshTokenRepo.Create(ctx, rt); err != nil {
+	if s.tokenVersions != nil {
+		if err := s.tokenVersions.Set(ctx, userID, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *AuthService) generateAuthResult(ctx context.Context, user *models.User) (*AuthResult, error) {
+	accessToken, expiresIn, err := s.jwtManager.GenerateAccessToken(user.ID, user.Email, user.TokenVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	// Refresh tokens are opaque; only their hash is stored
+	refreshToken, err := generateSecureToken()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.refreshTokenRepo.Create(ctx, user.ID, refreshToken, time.Now().Add(s.refreshDuration)); err != nil {
 		return nil, err
 	}
 
 	return &AuthResult{
 		User:         user,
-		AccessToken:  tokenPair.AccessToken,
-		RefreshToken: tokenPair.RefreshToken,
-		ExpiresIn:    tokenPair.ExpiresIn,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    expiresIn,
 	}, nil
 }
 
+// generateNumericCode generates a random numeric code of the given length
+func generateNumericCode(digits int) (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < digits; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", digits, n), nil
+}
+
+// generateSecureToken generates a random hex-encoded token
+func generateSecureToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashResetToken hashes a reset token for storage so lookups never compare raw tokens
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
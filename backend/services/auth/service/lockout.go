@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrAccountLocked is returned when login is refused because of repeated failures
+var ErrAccountLocked = errors.New("account temporarily locked due to too many failed login attempts")
+
+// loginFailureWindow is how long failed attempts are remembered without a successful login
+const loginFailureWindow = 24 * time.Hour
+
+// AccountLockedError carries how long the caller must wait before trying again
+type AccountLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *AccountLockedError) Error() string {
+	return fmt.Sprintf("%s, retry in %s", ErrAccountLocked.Error(), e.RetryAfter.Round(time.Second))
+}
+
+// Unwrap allows errors.Is(err, ErrAccountLocked)
+func (e *AccountLockedError) Unwrap() error {
+	return ErrAccountLocked
+}
+
+// LoginLimiter tracks failed logins per email in Redis and locks the account
+// with exponential backoff: every maxAttempts failures trigger a lockout that
+// doubles in length, up to maxLockout
+type LoginLimiter struct {
+	redis       *redis.Client
+	maxAttempts int
+	baseLockout time.Duration
+	maxLockout  time.Duration
+}
+
+// NewLoginLimiter creates a new login limiter
+func NewLoginLimiter(redisClient *redis.Client, maxAttempts int, baseLockout, maxLockout time.Duration) *LoginLimiter {
+	if maxAttempts <= 0 {
+		maxAttempts = 5
+	}
+	if baseLockout <= 0 {
+		baseLockout = time.Minute
+	}
+	if maxLockout < baseLockout {
+		maxLockout = baseLockout
+	}
+	return &LoginLimiter{
+		redis:       redisClient,
+		maxAttempts: maxAttempts,
+		baseLockout: baseLockout,
+		maxLockout:  maxLockout,
+	}
+}
+
+// Check returns the remaining lockout for an email, or zero if login is allowed
+func (l *LoginLimiter) Check(ctx context.Context, email string) (time.Duration, error) {
+	ttl, err := l.redis.PTTL(ctx, lockKey(email)).Result()
+	if err != nil {
+		return 0, err
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// RecordFailure counts a failed login and returns the lockout it triggered, if any
+func (l *LoginLimiter) RecordFailure(ctx context.Context, email string) (time.Duration, error) {
+	key := failuresKey(email)
+
+	pipe := l.redis.TxPipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.Expire(ctx, key, loginFailureWindow)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+
+	failures := int(incr.Val())
+	if failures%l.maxAttempts != 0 {
+		return 0, nil
+	}
+
+	lockout := l.lockoutFor(failures / l.maxAttempts)
+	if err := l.redis.Set(ctx, lockKey(email), failures, lockout).Err(); err != nil {
+		return 0, err
+	}
+	return lockout, nil
+}
+
+// Reset clears failures and any lockout after a successful login
+func (l *LoginLimiter) Reset(ctx context.Context, email string) error {
+	return l.redis.Del(ctx, failuresKey(email), lockKey(email)).Err()
+}
+
+// lockoutFor returns the lockout duration for the given burst (1-based)
+func (l *LoginLimiter) lockoutFor(burst int) time.Duration {
+	lockout := l.baseLockout
+	for i := 1; i < burst; i++ {
+		lockout *= 2
+		if lockout >= l.maxLockout {
+			return l.maxLockout
+		}
+	}
+	return lockout
+}
+
+func failuresKey(email string) string {
+	return "auth:login_failures:" + normalizeEmail(email)
+}
+
+func lockKey(email string) string {
+	return "auth:login_lock:" + normalizeEmail(email)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
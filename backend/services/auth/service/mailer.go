@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+)
+
+// ErrMailUnavailable is returned when no mail transport is configured
+var ErrMailUnavailable = errors.New("email delivery is not configured")
+
+// Mailer delivers transactional emails to users
+type Mailer interface {
+	Send(ctx context.Context, to, subject, body string) error
+}
+
+// canSend reports whether m delivers mail at all
+func canSend(m Mailer) bool {
+	_, disabled := m.(DisabledMailer)
+	return m != nil && !disabled
+}
+
+// SMTPConfig holds SMTP settings
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer is a Mailer that delivers messages over SMTP
+type SMTPMailer struct {
+	cfg SMTPConfig
+}
+
+// NewSMTPMailer creates a new SMTP mailer
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	return &SMTPMailer{cfg: cfg}
+}
+
+// Send delivers the email through the configured SMTP server
+func (m *SMTPMailer) Send(ctx context.Context, to, subject, body string) error {
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", to)
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body)
+	msg.WriteString("\r\n")
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+
+	addr := net.JoinHostPort(m.cfg.Host, strconv.Itoa(m.cfg.Port))
+	if err := smtp.SendMail(addr, auth, m.cfg.From, []string{to}, []byte(msg.String())); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// LogMailer is a Mailer that only records that a message was sent, for
+// development without an SMTP server. Bodies carry codes and tokens, so they
+// are never logged.
+type LogMailer struct{}
+
+// NewLogMailer creates a new log mailer
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+// Send logs the recipient and subject instead of delivering the email
+func (m *LogMailer) Send(ctx context.Context, to, subject, body string) error {
+	log.Printf("[mail] not delivered, no SMTP server configured: to=%s subject=%q", to, subject)
+	return nil
+}
+
+// DisabledMailer is a Mailer that refuses to send, used in production when
+// SMTP isn't configured so emailed codes fail loudly instead of vanishing
+type DisabledMailer struct{}
+
+// Send returns ErrMailUnavailable
+func (DisabledMailer) Send(ctx context.Context, to, subject, body string) error {
+	return ErrMailUnavailable
+}
@@ -0,0 +1,44 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"strings"
+	"testing"
+)
+
+func TestLogMailerDoesNotLogBody(t *testing.T) {
+	var buf bytes.Buffer
+	orig := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(orig)
+
+	if err := NewLogMailer().Send(context.Background(), "user@example.com", "Your code", "code 123456"); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if strings.Contains(buf.String(), "123456") {
+		t.Fatalf("log output contains the message body: %q", buf.String())
+	}
+	if !strings.Contains(buf.String(), "user@example.com") {
+		t.Fatalf("log output is missing the recipient: %q", buf.String())
+	}
+}
+
+func TestDisabledMailerRefusesToSend(t *testing.T) {
+	err := DisabledMailer{}.Send(context.Background(), "user@example.com", "Your code", "code 123456")
+	if !errors.Is(err, ErrMailUnavailable) {
+		t.Fatalf("got %v, want ErrMailUnavailable", err)
+	}
+}
+
+func TestRequestPasswordResetWithoutMail(t *testing.T) {
+	// Checked before the account lookup, so the repositories aren't needed
+	for _, mailer := range []Mailer{nil, DisabledMailer{}} {
+		s := &AuthService{mailer: mailer}
+		if err := s.RequestPasswordReset(context.Background(), "user@example.com"); !errors.Is(err, ErrMailUnavailable) {
+			t.Fatalf("mailer %T: got %v, want ErrMailUnavailable", mailer, err)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/radmickey/money-control/backend/services/auth/models"
+	"github.com/radmickey/money-control/backend/services/auth/repository"
+)
+
+var (
+	ErrReauthRequired  = errors.New("re-authentication code required")
+	ErrNoReauthChannel = errors.New("account has no email or Telegram chat to send a code to")
+)
+
+const (
+	reauthCodeTTL         = 10 * time.Minute
+	maxReauthCodeAttempts = 5
+
+	// Codes issued per user per window
+	reauthCodesPerWindow = 3
+	reauthCodeWindow     = time.Hour
+
+	// telegramEmailDomain marks the placeholder emails of Telegram sign-ups,
+	// which can't receive mail
+	telegramEmailDomain = "@telegram.user"
+)
+
+// Reauth code delivery channels
+const (
+	ReauthChannelEmail    = "email"
+	ReauthChannelTelegram = "telegram"
+)
+
+// TelegramSender sends a text message to a Telegram chat
+type TelegramSender interface {
+	SendMessage(ctx context.Context, chatID int64, text string) error
+}
+
+// BotAPISender is a TelegramSender using the Bot API
+type BotAPISender struct {
+	token      string
+	httpClient *http.Client
+}
+
+// NewBotAPISender creates a sender for the bot token
+func NewBotAPISender(token string) *BotAPISender {
+	return &BotAPISender{
+		token: token,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// SendMessage sends text via the Bot API sendMessage method
+func (b *BotAPISender) SendMessage(ctx context.Context, chatID int64, text string) error {
+	data, err := json.Marshal(map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	})
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", b.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("telegram api returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// Reauthenticator issues and checks one-time codes that confirm a sensitive
+// change for accounts without a password, such as OAuth and Telegram sign-ups.
+// Codes go to the account's own email, or its Telegram chat, so a stolen
+// access token alone can't take the account over.
+type Reauthenticator struct {
+	redis    *redis.Client
+	limiter  *RequestLimiter
+	mailer   Mailer
+	telegram TelegramSender
+}
+
+// NewReauthenticator creates a reauthenticator. telegram may be nil when no
+// bot is configured.
+func NewReauthenticator(redisClient *redis.Client, mailer Mailer, telegram TelegramSender) *Reauthenticator {
+	return &Reauthenticator{
+		redis:    redisClient,
+		limiter:  NewRequestLimiter(redisClient, "auth:reauth_limit", reauthCodesPerWindow, reauthCodeWindow),
+		mailer:   mailer,
+		telegram: telegram,
+	}
+}
+
+// Send issues a code for the user and delivers it, returning the channel used
+func (r *Reauthenticator) Send(ctx context.Context, user *models.User) (string, time.Time, error) {
+	channel := r.channelFor(user)
+	if channel == "" {
+		return "", time.Time{}, ErrNoReauthChannel
+	}
+
+	if err := r.limiter.Allow(ctx, user.ID); err != nil {
+		return "", time.Time{}, err
+	}
+
+	code, err := generateNumericCode(6)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	pipe := r.redis.TxPipeline()
+	pipe.Set(ctx, reauthCodeKey(user.ID), hashResetToken(code), reauthCodeTTL)
+	pipe.Del(ctx, reauthAttemptsKey(user.ID))
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", time.Time{}, err
+	}
+
+	text := fmt.Sprintf("Your Money Control verification code is %s.\n\n"+
+		"Enter it in the app to confirm the change to your account. The code expires in %d minutes.\n"+
+		"If you did not request this, someone may have access to your session: log out of all devices.",
+		code, int(reauthCodeTTL.Minutes()))
+
+	switch channel {
+	case ReauthChannelEmail:
+		err = r.mailer.Send(ctx, user.Email, "Confirm a change to your account", text)
+	case ReauthChannelTelegram:
+		err = r.telegram.SendMessage(ctx, *user.TelegramID, text)
+	}
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return channel, time.Now().Add(reauthCodeTTL), nil
+}
+
+// Verify consumes the user's code. Each code allows a few attempts and can
+// be used once.
+func (r *Reauthenticator) Verify(ctx context.Context, userID, code string) error {
+	stored, err := r.redis.Get(ctx, reauthCodeKey(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return repository.ErrInvalidCode
+	}
+	if err != nil {
+		return err
+	}
+
+	attempts, err := r.redis.Incr(ctx, reauthAttemptsKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	r.redis.Expire(ctx, reauthAttemptsKey(userID), reauthCodeTTL)
+	if attempts > maxReauthCodeAttempts {
+		r.redis.Del(ctx, reauthCodeKey(userID), reauthAttemptsKey(userID))
+		return repository.ErrInvalidCode
+	}
+
+	if subtle.ConstantTimeCompare([]byte(stored), []byte(hashResetToken(code))) != 1 {
+		return repository.ErrInvalidCode
+	}
+
+	// Only the request that deletes the code may use it
+	deleted, err := r.redis.Del(ctx, reauthCodeKey(userID)).Result()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return repository.ErrInvalidCode
+	}
+	r.redis.Del(ctx, reauthAttemptsKey(userID))
+	return nil
+}
+
+// channelFor picks where the user's code is sent: a real email first, then
+// the linked Telegram chat
+func (r *Reauthenticator) channelFor(user *models.User) string {
+	if user.Email != "" && !strings.HasSuffix(user.Email, telegramEmailDomain) && canSend(r.mailer) {
+		return ReauthChannelEmail
+	}
+	if user.TelegramID != nil && r.telegram != nil {
+		return ReauthChannelTelegram
+	}
+	return ""
+}
+
+func reauthCodeKey(userID string) string {
+	return "auth:reauth_code:code:" + userID
+}
+
+func reauthAttemptsKey(userID string) string {
+	return "auth:reauth_code:attempts:" + userID
+}
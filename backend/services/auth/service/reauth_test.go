@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/services/auth/models"
+	"github.com/radmickey/money-control/backend/services/auth/repository"
+)
+
+// recordingMailer keeps the last message instead of sending it
+type recordingMailer struct {
+	to, body string
+}
+
+func (m *recordingMailer) Send(ctx context.Context, to, subject, body string) error {
+	m.to, m.body = to, body
+	return nil
+}
+
+// recordingTelegram keeps the last message instead of sending it
+type recordingTelegram struct {
+	chatID int64
+	text   string
+}
+
+func (t *recordingTelegram) SendMessage(ctx context.Context, chatID int64, text string) error {
+	t.chatID, t.text = chatID, text
+	return nil
+}
+
+var codePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+func TestReauthenticatorChannels(t *testing.T) {
+	_, client := newTestRedis(t)
+	telegramID := int64(42)
+
+	tests := []struct {
+		name     string
+		user     *models.User
+		telegram TelegramSender
+		want     string
+		wantErr  error
+	}{
+		{"email account", &models.User{ID: "u1", Email: "a@example.com"}, nil, ReauthChannelEmail, nil},
+		{"telegram sign-up", &models.User{ID: "u2", Email: "x" + telegramEmailDomain, TelegramID: &telegramID}, &recordingTelegram{}, ReauthChannelTelegram, nil},
+		{"telegram sign-up without bot", &models.User{ID: "u3", Email: "x" + telegramEmailDomain, TelegramID: &telegramID}, nil, "", ErrNoReauthChannel},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReauthenticator(client, &recordingMailer{}, tt.telegram)
+			channel, _, err := r.Send(context.Background(), tt.user)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("got error %v, want %v", err, tt.wantErr)
+			}
+			if channel != tt.want {
+				t.Fatalf("got channel %q, want %q", channel, tt.want)
+			}
+		})
+	}
+}
+
+func TestReauthenticatorVerify(t *testing.T) {
+	_, client := newTestRedis(t)
+	mailer := &recordingMailer{}
+	r := NewReauthenticator(client, mailer, nil)
+	user := &models.User{ID: "u1", Email: "a@example.com"}
+	ctx := context.Background()
+
+	if _, _, err := r.Send(ctx, user); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	code := codePattern.FindString(mailer.body)
+	if code == "" {
+		t.Fatalf("no code in message %q", mailer.body)
+	}
+
+	if err := r.Verify(ctx, user.ID, "000000x"); !errors.Is(err, repository.ErrInvalidCode) {
+		t.Fatalf("wrong code: got %v, want ErrInvalidCode", err)
+	}
+	if err := r.Verify(ctx, user.ID, code); err != nil {
+		t.Fatalf("right code: %v", err)
+	}
+	if err := r.Verify(ctx, user.ID, code); !errors.Is(err, repository.ErrInvalidCode) {
+		t.Fatalf("reused code: got %v, want ErrInvalidCode", err)
+	}
+}
+
+func TestReauthenticatorAttemptLimit(t *testing.T) {
+	_, client := newTestRedis(t)
+	mailer := &recordingMailer{}
+	r := NewReauthenticator(client, mailer, nil)
+	user := &models.User{ID: "u1", Email: "a@example.com"}
+	ctx := context.Background()
+
+	if _, _, err := r.Send(ctx, user); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	code := codePattern.FindString(mailer.body)
+
+	for i := 0; i < maxReauthCodeAttempts; i++ {
+		_ = r.Verify(ctx, user.ID, "wrong")
+	}
+	if err := r.Verify(ctx, user.ID, code); !errors.Is(err, repository.ErrInvalidCode) {
+		t.Fatalf("code after the attempts ran out: got %v, want ErrInvalidCode", err)
+	}
+}
+
+func TestReauthenticatorSendLimit(t *testing.T) {
+	_, client := newTestRedis(t)
+	r := NewReauthenticator(client, &recordingMailer{}, nil)
+	user := &models.User{ID: "u1", Email: "a@example.com"}
+
+	for i := 0; i < reauthCodesPerWindow; i++ {
+		if _, _, err := r.Send(context.Background(), user); err != nil {
+			t.Fatalf("send %d: %v", i+1, err)
+		}
+	}
+	if _, _, err := r.Send(context.Background(), user); !errors.Is(err, ErrTooManyRequests) {
+		t.Fatalf("send over the limit: got %v, want ErrTooManyRequests", err)
+	}
+}
+
+func TestConfirmIdentity(t *testing.T) {
+	_, client := newTestRedis(t)
+	mailer := &recordingMailer{}
+	s := &AuthService{reauth: NewReauthenticator(client, mailer, nil)}
+	ctx := context.Background()
+
+	hash, err := auth.HashPassword("correct-password")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+	withPassword := &models.User{ID: "u1", Email: "a@example.com", PasswordHash: hash}
+	if err := s.confirmIdentity(ctx, withPassword, "wrong-password", ""); !errors.Is(err, ErrIncorrectPassword) {
+		t.Fatalf("wrong password: got %v, want ErrIncorrectPassword", err)
+	}
+	if err := s.confirmIdentity(ctx, withPassword, "correct-password", ""); err != nil {
+		t.Fatalf("right password: %v", err)
+	}
+
+	// A passwordless account needs a code; the access token alone isn't enough
+	oauthUser := &models.User{ID: "u2", Email: "b@example.com"}
+	if err := s.confirmIdentity(ctx, oauthUser, "", ""); !errors.Is(err, ErrReauthRequired) {
+		t.Fatalf("no code: got %v, want ErrReauthRequired", err)
+	}
+	if err := s.confirmIdentity(ctx, oauthUser, "", "123456"); !errors.Is(err, repository.ErrInvalidCode) {
+		t.Fatalf("code never issued: got %v, want ErrInvalidCode", err)
+	}
+
+	if _, _, err := s.reauth.Send(ctx, oauthUser); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if err := s.confirmIdentity(ctx, oauthUser, "", codePattern.FindString(mailer.body)); err != nil {
+		t.Fatalf("issued code: %v", err)
+	}
+}
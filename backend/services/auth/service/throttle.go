@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ErrTooManyRequests is returned when a caller asked for too many codes
+var ErrTooManyRequests = errors.New("too many requests, try again later")
+
+const (
+	// linkCodesPerWindow caps the Telegram link codes issued per Telegram
+	// ID and per email, so the attempt budget of each code can't be renewed
+	// at will to brute-force the code space
+	linkCodesPerWindow = 3
+	linkCodeWindow     = time.Hour
+)
+
+// RequestLimiter counts requests per key in Redis and refuses them past a
+// limit until the key's window, which starts with its first request, ends
+type RequestLimiter struct {
+	redis  *redis.Client
+	prefix string
+	limit  int
+	window time.Duration
+}
+
+// NewRequestLimiter creates a limiter allowing limit requests per window
+func NewRequestLimiter(redisClient *redis.Client, prefix string, limit int, window time.Duration) *RequestLimiter {
+	return &RequestLimiter{
+		redis:  redisClient,
+		prefix: prefix,
+		limit:  limit,
+		window: window,
+	}
+}
+
+// NewLinkCodeLimiter creates the limiter for Telegram link code requests
+func NewLinkCodeLimiter(redisClient *redis.Client) *RequestLimiter {
+	return NewRequestLimiter(redisClient, "auth:link_code", linkCodesPerWindow, linkCodeWindow)
+}
+
+// Allow counts a request under every key and returns ErrTooManyRequests if
+// any of them is over the limit
+func (l *RequestLimiter) Allow(ctx context.Context, keys ...string) error {
+	pipe := l.redis.TxPipeline()
+	counts := make([]*redis.IntCmd, len(keys))
+	for i, key := range keys {
+		counts[i] = pipe.Incr(ctx, l.prefix+":"+key)
+		// Only the first request of a window sets its expiry
+		pipe.ExpireNX(ctx, l.prefix+":"+key, l.window)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		if count.Val() > int64(l.limit) {
+			return ErrTooManyRequests
+		}
+	}
+	return nil
+}
+
+func telegramLimitKey(telegramID int64) string {
+	return fmt.Sprintf("telegram:%d", telegramID)
+}
+
+func emailLimitKey(email string) string {
+	return "email:" + normalizeEmail(email)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-redis/redis/v8"
+)
+
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+	return mr, client
+}
+
+func TestRequestLimiterAllow(t *testing.T) {
+	mr, client := newTestRedis(t)
+	limiter := NewRequestLimiter(client, "test", 3, time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Allow(ctx, "a"); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i+1, err)
+		}
+	}
+	if err := limiter.Allow(ctx, "a"); !errors.Is(err, ErrTooManyRequests) {
+		t.Fatalf("request over the limit: got %v, want ErrTooManyRequests", err)
+	}
+	if err := limiter.Allow(ctx, "b"); err != nil {
+		t.Fatalf("other key: unexpected error %v", err)
+	}
+
+	mr.FastForward(time.Hour)
+	if err := limiter.Allow(ctx, "a"); err != nil {
+		t.Fatalf("after the window: unexpected error %v", err)
+	}
+}
+
+func TestLinkCodeLimiterPerTelegramIDAndEmail(t *testing.T) {
+	_, client := newTestRedis(t)
+	limiter := NewLinkCodeLimiter(client)
+	ctx := context.Background()
+
+	// One chat cycling through emails is stopped by its Telegram ID
+	for i := 0; i < linkCodesPerWindow; i++ {
+		email := string(rune('a'+i)) + "@example.com"
+		if err := limiter.Allow(ctx, telegramLimitKey(1), emailLimitKey(email)); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i+1, err)
+		}
+	}
+	if err := limiter.Allow(ctx, telegramLimitKey(1), emailLimitKey("z@example.com")); !errors.Is(err, ErrTooManyRequests) {
+		t.Fatalf("same chat: got %v, want ErrTooManyRequests", err)
+	}
+
+	// Many chats targeting one email are stopped by the email, whatever its case
+	for i := 0; i < linkCodesPerWindow; i++ {
+		if err := limiter.Allow(ctx, telegramLimitKey(int64(100+i)), emailLimitKey("victim@example.com")); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i+1, err)
+		}
+	}
+	if err := limiter.Allow(ctx, telegramLimitKey(200), emailLimitKey(" Victim@Example.com")); !errors.Is(err, ErrTooManyRequests) {
+		t.Fatalf("same email: got %v, want ErrTooManyRequests", err)
+	}
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	"github.com/radmickey/money-control/backend/services/currency/service"
+)
+
+// errorMap maps the currency service's errors to HTTP responses
+var errorMap = utils.ErrorMap{
+	{Err: service.ErrInvalidCurrency, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+}
+
+// respondError writes err as an HTTP error response
+func respondError(c *gin.Context, err error) {
+	errorMap.Respond(c, err)
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	pb "github.com/radmickey/money-control/backend/proto/currency"
 	"github.com/radmickey/money-control/backend/services/currency/service"
@@ -27,6 +29,9 @@ func NewGRPCHandler(currencyService *service.CurrencyService) *GRPCHandler {
 func (h *GRPCHandler) GetExchangeRate(ctx context.Context, req *pb.GetExchangeRateRequest) (*pb.ExchangeRateResponse, error) {
 	rate, err := h.currencyService.GetExchangeRate(ctx, req.FromCurrency, req.ToCurrency)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCurrency) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get exchange rate: %v", err)
 	}
 
@@ -58,6 +63,9 @@ func (h *GRPCHandler) GetMultipleExchangeRates(ctx context.Context, req *pb.GetM
 func (h *GRPCHandler) ConvertAmount(ctx context.Context, req *pb.ConvertAmountRequest) (*pb.ConvertAmountResponse, error) {
 	convertedAmount, rate, err := h.currencyService.ConvertAmount(ctx, req.Amount, req.FromCurrency, req.ToCurrency)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCurrency) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
 		return nil, status.Errorf(codes.Internal, "failed to convert currency: %v", err)
 	}
 
@@ -71,24 +79,80 @@ func (h *GRPCHandler) ConvertAmount(ctx context.Context, req *pb.ConvertAmountRe
 	}, nil
 }
 
+// ConvertDetailed converts an amount and returns rate metadata for display
+func (h *GRPCHandler) ConvertDetailed(ctx context.Context, req *pb.ConvertAmountRequest) (*pb.ConvertDetailedResponse, error) {
+	result, err := h.currencyService.ConvertDetailed(ctx, req.Amount, req.FromCurrency, req.ToCurrency)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCurrency) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to convert currency: %v", err)
+	}
+
+	return &pb.ConvertDetailedResponse{
+		OriginalAmount:  result.OriginalAmount,
+		FromCurrency:    result.FromCurrency,
+		ConvertedAmount: result.ConvertedAmount,
+		ToCurrency:      result.ToCurrency,
+		Rate:            result.Rate,
+		InverseRate:     result.InverseRate,
+		UpdatedAt:       timestamppb.New(result.UpdatedAt),
+		Stale:           result.Stale,
+		Provider:        result.Provider,
+	}, nil
+}
+
+// ConvertAsOf converts an amount using the historical rate for a date
+func (h *GRPCHandler) ConvertAsOf(ctx context.Context, req *pb.ConvertAsOfRequest) (*pb.ConvertAmountResponse, error) {
+	date := time.Now()
+	if req.Date != nil {
+		date = req.Date.AsTime()
+	}
+
+	convertedAmount, rate, rateDate, err := h.currencyService.ConvertAmountAsOf(ctx, req.Amount, req.FromCurrency, req.ToCurrency, date)
+	if err != nil {
+		if errors.Is(err, service.ErrNoHistoricalRate) {
+			return nil, status.Errorf(codes.NotFound, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to convert currency: %v", err)
+	}
+
+	return &pb.ConvertAmountResponse{
+		OriginalAmount:  req.Amount,
+		FromCurrency:    req.FromCurrency,
+		ConvertedAmount: convertedAmount,
+		ToCurrency:      req.ToCurrency,
+		RateUsed:        rate,
+		RateTimestamp:   timestamppb.New(rateDate),
+	}, nil
+}
+
 // ConvertMultipleAmounts converts multiple amounts
 func (h *GRPCHandler) ConvertMultipleAmounts(ctx context.Context, req *pb.ConvertMultipleAmountsRequest) (*pb.ConvertMultipleAmountsResponse, error) {
-	var converted []*pb.ConvertedAmount
-	var total float64
+	amounts := make([]service.AmountToConvert, len(req.Amounts))
+	for i, a := range req.Amounts {
+		amounts[i] = service.AmountToConvert{
+			ID:           a.Id,
+			Amount:       a.Amount,
+			FromCurrency: a.FromCurrency,
+		}
+	}
+
+	results, total, err := h.currencyService.ConvertMultipleAmounts(ctx, amounts, req.ToCurrency)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to convert amounts: %v", err)
+	}
 
-	for _, a := range req.Amounts {
-		convertedAmount, rate, err := h.currencyService.ConvertAmount(ctx, a.Amount, a.FromCurrency, req.ToCurrency)
-		if err != nil {
-			continue
+	converted := make([]*pb.ConvertedAmount, len(results))
+	for i, r := range results {
+		converted[i] = &pb.ConvertedAmount{
+			Id:              r.ID,
+			OriginalAmount:  r.OriginalAmount,
+			FromCurrency:    r.FromCurrency,
+			ConvertedAmount: r.ConvertedAmount,
+			RateUsed:        r.RateUsed,
+			Error:           r.Error,
 		}
-		total += convertedAmount
-		converted = append(converted, &pb.ConvertedAmount{
-			Id:              a.Id,
-			OriginalAmount:  a.Amount,
-			FromCurrency:    a.FromCurrency,
-			ConvertedAmount: convertedAmount,
-			RateUsed:        rate,
-		})
 	}
 
 	return &pb.ConvertMultipleAmountsResponse{
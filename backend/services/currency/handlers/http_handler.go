@@ -4,28 +4,29 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/radmickey/money-control/backend/pkg/auth"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	"github.com/radmickey/money-control/backend/services/currency/service"
 )
 
 // RegisterHTTPRoutes registers HTTP routes for currency
-func RegisterHTTPRoutes(r *gin.RouterGroup, currencyService *service.CurrencyService, jwtManager *auth.JWTManager) {
+func RegisterHTTPRoutes(r *gin.RouterGroup, currencyService *service.CurrencyService, tokenValidator middleware.TokenValidator) {
 	h := &HTTPHandler{currencyService: currencyService}
 
 	// Public routes
 	currencies := r.Group("/currencies")
 	{
 		currencies.GET("", h.ListCurrencies)
+		currencies.GET("/status", h.GetRateStatus)
 		currencies.GET("/rates/:base", h.GetRates)
 		currencies.GET("/rate/:from/:to", h.GetRate)
 		currencies.POST("/convert", h.Convert)
+		currencies.POST("/convert/detailed", h.ConvertDetailed)
 	}
 
 	// Protected routes
 	protected := r.Group("/currencies")
-	protected.Use(middleware.AuthMiddleware(jwtManager))
+	protected.Use(middleware.AuthMiddleware(tokenValidator))
 	{
 		protected.POST("/convert-multiple", h.ConvertMultiple)
 		protected.POST("/refresh", h.RefreshRates)
@@ -37,26 +38,55 @@ type HTTPHandler struct {
 	currencyService *service.CurrencyService
 }
 
+// validateCodes validates currency codes up front, writing a 400 on failure.
+// Crypto symbols are accepted only when the request sets include_crypto=true.
+func (h *HTTPHandler) validateCodes(c *gin.Context, codes ...*string) bool {
+	allowCrypto := c.Query("include_crypto") == "true"
+	for _, code := range codes {
+		normalized, err := h.currencyService.ValidateCurrencyCode(c.Request.Context(), *code, allowCrypto)
+		if err != nil {
+			respondError(c, err)
+			return false
+		}
+		*code = normalized
+	}
+	return true
+}
+
 // ListCurrencies lists all supported currencies
 func (h *HTTPHandler) ListCurrencies(c *gin.Context) {
 	includeCrypto := c.Query("include_crypto") == "true"
 
 	currencies, err := h.currencyService.ListSupportedCurrencies(c.Request.Context(), includeCrypto)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.Success(c, currencies)
 }
 
+// GetRateStatus reports the active rate provider and rate freshness
+func (h *HTTPHandler) GetRateStatus(c *gin.Context) {
+	rateStatus, err := h.currencyService.GetRateStatus(c.Request.Context())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, rateStatus)
+}
+
 // GetRates gets all rates for a base currency
 func (h *HTTPHandler) GetRates(c *gin.Context) {
 	base := c.Param("base")
+	if !h.validateCodes(c, &base) {
+		return
+	}
 
 	rates, updatedAt, err := h.currencyService.GetMultipleExchangeRates(c.Request.Context(), base, nil)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -71,10 +101,13 @@ func (h *HTTPHandler) GetRates(c *gin.Context) {
 func (h *HTTPHandler) GetRate(c *gin.Context) {
 	from := c.Param("from")
 	to := c.Param("to")
+	if !h.validateCodes(c, &from, &to) {
+		return
+	}
 
 	rate, err := h.currencyService.GetExchangeRate(c.Request.Context(), from, to)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -92,13 +125,16 @@ type ConvertRequest struct {
 func (h *HTTPHandler) Convert(c *gin.Context) {
 	var req ConvertRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
+		return
+	}
+	if !h.validateCodes(c, &req.From, &req.To) {
 		return
 	}
 
 	converted, rate, err := h.currencyService.ConvertAmount(c.Request.Context(), req.Amount, req.From, req.To)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -112,6 +148,26 @@ func (h *HTTPHandler) Convert(c *gin.Context) {
 	})
 }
 
+// ConvertDetailed converts an amount and returns rate metadata for display
+func (h *HTTPHandler) ConvertDetailed(c *gin.Context) {
+	var req ConvertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+	if !h.validateCodes(c, &req.From, &req.To) {
+		return
+	}
+
+	result, err := h.currencyService.ConvertDetailed(c.Request.Context(), req.Amount, req.From, req.To)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, result)
+}
+
 // ConvertMultipleRequest represents convert multiple request
 type ConvertMultipleRequest struct {
 	Amounts []struct {
@@ -126,7 +182,10 @@ type ConvertMultipleRequest struct {
 func (h *HTTPHandler) ConvertMultiple(c *gin.Context) {
 	var req ConvertMultipleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
+		return
+	}
+	if !h.validateCodes(c, &req.ToCurrency) {
 		return
 	}
 
@@ -141,7 +200,7 @@ func (h *HTTPHandler) ConvertMultiple(c *gin.Context) {
 
 	results, total, err := h.currencyService.ConvertMultipleAmounts(c.Request.Context(), amounts, req.ToCurrency)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -158,10 +217,13 @@ func (h *HTTPHandler) RefreshRates(c *gin.Context) {
 	if baseCurrency == "" {
 		baseCurrency = "USD"
 	}
+	if !h.validateCodes(c, &baseCurrency) {
+		return
+	}
 
 	count, err := h.currencyService.RefreshRates(c.Request.Context(), baseCurrency)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
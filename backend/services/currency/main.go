@@ -15,7 +15,11 @@ import (
 	pkgcache "github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/logger"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
 	pb "github.com/radmickey/money-control/backend/proto/currency"
 	"github.com/radmickey/money-control/backend/services/currency/handlers"
 	"github.com/radmickey/money-control/backend/services/currency/models"
@@ -32,11 +36,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "currency-service",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := database.New(database.Config{
-		URL:   cfg.DatabaseURL,
-		Debug: cfg.Debug,
+		URL:             cfg.DatabaseURL,
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		Debug:           cfg.Debug,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -58,29 +78,36 @@ func main() {
 	}
 	defer redisCache.Close()
 
-	// Initialize exchange rates client
-	ratesClient := providers.NewExchangeRatesClient(cfg.ExchangeRatesAPIKey)
+	// Initialize exchange rate providers (tried in order)
+	rateProvider := providers.NewChainProvider(
+		providers.NewExchangeRatesClient(cfg.ExchangeRatesAPIKey),
+		providers.NewOpenERAPIClient(),
+	)
 
 	// Initialize repositories
 	currencyRepo := repository.NewCurrencyRepository(db.DB)
 	rateRepo := repository.NewExchangeRateRepository(db.DB)
 	historyRepo := repository.NewRateHistoryRepository(db.DB)
 
-	// Seed currencies
-	if err := currencyRepo.SeedCurrencies(context.Background()); err != nil {
-		log.Printf("Warning: Failed to seed currencies: %v", err)
-	}
-
 	// Initialize service
 	currencyService := service.NewCurrencyService(
 		currencyRepo, rateRepo, historyRepo,
-		ratesClient, redisCache, "USD",
+		rateProvider, redisCache, "USD", cfg.RateBaseCurrencies, cfg.RateStaleAfter,
+		logger.New(logger.NewConfig(cfg, "currency-service")),
 	)
 
+	// Seed currencies
+	if err := currencyService.SeedCurrencies(context.Background()); err != nil {
+		log.Printf("Warning: Failed to seed currencies: %v", err)
+	}
+
 	// Start rate updater (update every hour)
 	currencyService.StartRateUpdater(1 * time.Hour)
 	defer currencyService.Stop()
 
+	// Trim old rate history periodically
+	go cleanupRateHistory(historyRepo)
+
 	// Initialize JWT manager for auth middleware
 	jwtManager := auth.NewJWTManager(
 		cfg.JWTSecret,
@@ -89,11 +116,19 @@ func main() {
 	)
 
 	// Start gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		middleware.UnaryServerRequestIDInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
 	grpcHandler := handlers.NewGRPCHandler(currencyService)
 	pb.RegisterCurrencyServiceServer(grpcServer, grpcHandler)
 	reflection.Register(grpcServer)
 
+	// Health checks, served over gRPC here and on /health/details below
+	healthChecker := health.NewHealthChecker("")
+	healthChecker.Register("database", health.DatabasePoolCheck(db.Ping, db.Stats))
+	grpcHealth := health.RegisterGRPCServer(grpcServer, healthChecker)
+
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
@@ -112,19 +147,38 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery())
+	logConfig := middleware.NewLoggingConfig(cfg)
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "currency"})
 	})
 
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Detailed health check with connection pool stats
+	router.GET("/health/details", func(c *gin.Context) {
+		c.JSON(http.StatusOK, healthChecker.Check(c.Request.Context()))
+	})
+
 	// Routes (some public, some protected)
 	v1 := router.Group("/api/v1")
-	handlers.RegisterHTTPRoutes(v1, currencyService, jwtManager)
+	tokenVersions, err := middleware.NewTokenVersionStore(cfg, redisCache.Client())
+	if err != nil {
+		log.Fatalf("Failed to create token version store: %v", err)
+	}
+	tokenValidator, err := middleware.NewTokenValidator(cfg, jwtManager, tokenVersions)
+	if err != nil {
+		log.Fatalf("Failed to create token validator: %v", err)
+	}
+	handlers.RegisterHTTPRoutes(v1, currencyService, tokenValidator)
 
 	httpServer := &http.Server{
 		Addr:    ":" + cfg.HTTPPort,
@@ -149,6 +203,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
@@ -157,3 +212,22 @@ func main() {
 	log.Println("Currency service stopped")
 }
 
+// rateHistoryRetention bounds how much daily rate history is kept
+const rateHistoryRetention = 5 * 365 * 24 * time.Hour
+
+// cleanupRateHistory periodically deletes rate history past the retention window
+func cleanupRateHistory(historyRepo *repository.RateHistoryRepository) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		deleted, err := historyRepo.DeleteOlderThan(context.Background(), time.Now().Add(-rateHistoryRetention))
+		if err != nil {
+			log.Printf("Failed to delete old rate history: %v", err)
+			continue
+		}
+		if deleted > 0 {
+			log.Printf("Deleted %d rate history entries", deleted)
+		}
+	}
+}
@@ -18,13 +18,14 @@ func (ExchangeRate) TableName() string {
 	return "exchange_rates"
 }
 
-// RateHistory stores historical exchange rates
+// RateHistory stores historical exchange rates, one row per currency pair per day
 type RateHistory struct {
 	ID           string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
-	FromCurrency string    `gorm:"size:3;not null;index" json:"from_currency"`
-	ToCurrency   string    `gorm:"size:3;not null;index" json:"to_currency"`
+	FromCurrency string    `gorm:"size:3;not null;uniqueIndex:idx_rate_history_pair_date,priority:1" json:"from_currency"`
+	ToCurrency   string    `gorm:"size:3;not null;uniqueIndex:idx_rate_history_pair_date,priority:2" json:"to_currency"`
 	Rate         float64   `gorm:"type:decimal(20,10);not null" json:"rate"`
-	Date         time.Time `gorm:"type:date;not null;index" json:"date"`
+	Date         time.Time `gorm:"type:date;not null;uniqueIndex:idx_rate_history_pair_date,priority:3;index" json:"date"`
+	RecordedAt   time.Time `gorm:"not null" json:"recorded_at"`
 	CreatedAt    time.Time `json:"created_at"`
 }
 
@@ -32,6 +32,11 @@ func NewExchangeRatesClient(apiKey string) *ExchangeRatesClient {
 	}
 }
 
+// Name returns the provider name
+func (c *ExchangeRatesClient) Name() string {
+	return "frankfurter.app"
+}
+
 // FrankfurterResponse represents the API response
 type FrankfurterResponse struct {
 	Amount float64            `json:"amount"`
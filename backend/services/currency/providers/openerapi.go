@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// open.er-api.com - Free, no API key required, updated daily
+	openERAPIBaseURL = "https://open.er-api.com/v6"
+)
+
+// OpenERAPIClient provides exchange rates from open.er-api.com (fallback provider)
+type OpenERAPIClient struct {
+	httpClient *http.Client
+	baseURL    string
+}
+
+// NewOpenERAPIClient creates a new open.er-api.com client
+func NewOpenERAPIClient() *OpenERAPIClient {
+	return &OpenERAPIClient{
+		baseURL: openERAPIBaseURL,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// OpenERAPIResponse represents the API response
+type OpenERAPIResponse struct {
+	Result    string             `json:"result"`
+	BaseCode  string             `json:"base_code"`
+	ErrorType string             `json:"error-type"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// Name returns the provider name
+func (c *OpenERAPIClient) Name() string {
+	return "open.er-api.com"
+}
+
+// GetLatestRates gets the latest exchange rates for a base currency
+func (c *OpenERAPIClient) GetLatestRates(ctx context.Context, baseCurrency string) (map[string]float64, error) {
+	url := fmt.Sprintf("%s/latest/%s", c.baseURL, strings.ToUpper(baseCurrency))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch rates: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API error: %s - %s", resp.Status, string(body))
+	}
+
+	var result OpenERAPIResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	if result.Result != "success" {
+		return nil, fmt.Errorf("API error: %s", result.ErrorType)
+	}
+
+	rates := result.Rates
+	if rates == nil {
+		rates = make(map[string]float64)
+	}
+	rates[strings.ToUpper(baseCurrency)] = 1.0
+
+	return rates, nil
+}
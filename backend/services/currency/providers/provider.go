@@ -0,0 +1,97 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateProvider supplies the latest exchange rates for a base currency.
+// Rates are units of each currency per 1 unit of base.
+type RateProvider interface {
+	Name() string
+	GetLatestRates(ctx context.Context, baseCurrency string) (map[string]float64, error)
+}
+
+// ProviderStatus describes the outcome of the most recent rate fetches
+type ProviderStatus struct {
+	ActiveProvider string
+	LastSuccess    time.Time
+	LastAttempt    time.Time
+	LastError      string
+}
+
+// ChainProvider tries providers in order and returns the first successful result
+type ChainProvider struct {
+	providers []RateProvider
+
+	mu     sync.RWMutex
+	status ProviderStatus
+}
+
+// NewChainProvider creates a provider chain; earlier providers take precedence
+func NewChainProvider(providers ...RateProvider) *ChainProvider {
+	return &ChainProvider{providers: providers}
+}
+
+// Name returns the provider name
+func (c *ChainProvider) Name() string {
+	return "chain"
+}
+
+// GetLatestRates fetches rates from the first provider that succeeds
+func (c *ChainProvider) GetLatestRates(ctx context.Context, baseCurrency string) (map[string]float64, error) {
+	rates, _, err := c.FetchLatestRates(ctx, baseCurrency)
+	return rates, err
+}
+
+// FetchLatestRates fetches rates and reports which provider supplied them
+func (c *ChainProvider) FetchLatestRates(ctx context.Context, baseCurrency string) (map[string]float64, string, error) {
+	if len(c.providers) == 0 {
+		return nil, "", errors.New("no rate providers configured")
+	}
+
+	var errs []string
+	for _, p := range c.providers {
+		rates, err := p.GetLatestRates(ctx, baseCurrency)
+		if err == nil && len(rates) > 0 {
+			c.recordSuccess(p.Name())
+			return rates, p.Name(), nil
+		}
+		if err == nil {
+			err = errors.New("empty response")
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", p.Name(), err))
+	}
+
+	err := fmt.Errorf("all rate providers failed: %s", strings.Join(errs, "; "))
+	c.recordFailure(err)
+	return nil, "", err
+}
+
+// Status returns the provider that supplied the last successful refresh and when
+func (c *ChainProvider) Status() ProviderStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.status
+}
+
+func (c *ChainProvider) recordSuccess(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.status.ActiveProvider = name
+	c.status.LastSuccess = now
+	c.status.LastAttempt = now
+	c.status.LastError = ""
+}
+
+func (c *ChainProvider) recordFailure(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.status.LastAttempt = time.Now()
+	c.status.LastError = err.Error()
+}
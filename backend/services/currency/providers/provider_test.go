@@ -0,0 +1,77 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// stubProvider returns fixed rates or a fixed error and counts its calls
+type stubProvider struct {
+	name  string
+	rates map[string]float64
+	err   error
+	calls int
+}
+
+func (p *stubProvider) Name() string { return p.name }
+
+func (p *stubProvider) GetLatestRates(ctx context.Context, baseCurrency string) (map[string]float64, error) {
+	p.calls++
+	return p.rates, p.err
+}
+
+func TestChainProviderFallsBack(t *testing.T) {
+	primary := &stubProvider{name: "primary", err: errors.New("rate limited")}
+	empty := &stubProvider{name: "empty", rates: map[string]float64{}}
+	fallback := &stubProvider{name: "fallback", rates: map[string]float64{"EUR": 0.9}}
+	unused := &stubProvider{name: "unused", rates: map[string]float64{"EUR": 0.8}}
+	chain := NewChainProvider(primary, empty, fallback, unused)
+
+	rates, name, err := chain.FetchLatestRates(context.Background(), "USD")
+	if err != nil {
+		t.Fatalf("FetchLatestRates: %v", err)
+	}
+	if name != "fallback" || rates["EUR"] != 0.9 {
+		t.Errorf("got %v from %q, want EUR 0.9 from fallback", rates, name)
+	}
+	if unused.calls != 0 {
+		t.Errorf("provider after the first success called %d times", unused.calls)
+	}
+
+	status := chain.Status()
+	if status.ActiveProvider != "fallback" || status.LastSuccess.IsZero() || status.LastError != "" {
+		t.Errorf("status = %+v, want fallback active with no error", status)
+	}
+}
+
+func TestChainProviderAllFail(t *testing.T) {
+	ok := &stubProvider{name: "primary", rates: map[string]float64{"EUR": 0.9}}
+	chain := NewChainProvider(ok)
+	if _, _, err := chain.FetchLatestRates(context.Background(), "USD"); err != nil {
+		t.Fatal(err)
+	}
+	lastSuccess := chain.Status().LastSuccess
+
+	// Once every provider fails the status keeps the last good refresh
+	ok.rates, ok.err = nil, errors.New("timeout")
+	_, _, err := chain.FetchLatestRates(context.Background(), "USD")
+	if err == nil || !strings.Contains(err.Error(), "primary: timeout") {
+		t.Fatalf("err = %v, want each provider's failure", err)
+	}
+
+	status := chain.Status()
+	if status.ActiveProvider != "primary" || !status.LastSuccess.Equal(lastSuccess) {
+		t.Errorf("status = %+v, want the last success kept", status)
+	}
+	if !strings.Contains(status.LastError, "timeout") || status.LastAttempt.Before(lastSuccess) {
+		t.Errorf("status = %+v, want the failed attempt recorded", status)
+	}
+}
+
+func TestChainProviderEmpty(t *testing.T) {
+	if _, _, err := NewChainProvider().FetchLatestRates(context.Background(), "USD"); err == nil {
+		t.Fatal("empty chain succeeded, want an error")
+	}
+}
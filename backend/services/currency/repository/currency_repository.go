@@ -7,10 +7,12 @@ import (
 
 	"github.com/radmickey/money-control/backend/services/currency/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var (
-	ErrRateNotFound = errors.New("exchange rate not found")
+	ErrRateNotFound     = errors.New("exchange rate not found")
+	ErrCurrencyNotFound = errors.New("currency not found")
 )
 
 // CurrencyRepository handles database operations for currencies
@@ -48,6 +50,18 @@ func (r *CurrencyRepository) GetAllCurrencies(ctx context.Context, includeCrypto
 	return currencies, nil
 }
 
+// GetByCode gets a currency by code
+func (r *CurrencyRepository) GetByCode(ctx context.Context, code string) (*models.Currency, error) {
+	var currency models.Currency
+	if err := r.db.WithContext(ctx).Where("code = ?", code).First(&currency).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrCurrencyNotFound
+		}
+		return nil, err
+	}
+	return &currency, nil
+}
+
 // ExchangeRateRepository handles exchange rate operations
 type ExchangeRateRepository struct {
 	db *gorm.DB
@@ -151,6 +165,37 @@ func (r *RateHistoryRepository) Create(ctx context.Context, history *models.Rate
 	return r.db.WithContext(ctx).Create(history).Error
 }
 
+// RecordRates stores the rates of a refresh as the day's history point for each pair.
+// A later refresh on the same day overwrites the earlier point.
+func (r *RateHistoryRepository) RecordRates(ctx context.Context, baseCurrency string, rates map[string]float64, recordedAt time.Time) error {
+	if len(rates) == 0 {
+		return nil
+	}
+
+	date := time.Date(recordedAt.Year(), recordedAt.Month(), recordedAt.Day(), 0, 0, 0, 0, time.UTC)
+	history := make([]models.RateHistory, 0, len(rates))
+	for currency, rate := range rates {
+		history = append(history, models.RateHistory{
+			FromCurrency: baseCurrency,
+			ToCurrency:   currency,
+			Rate:         rate,
+			Date:         date,
+			RecordedAt:   recordedAt,
+		})
+	}
+
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "from_currency"}, {Name: "to_currency"}, {Name: "date"}},
+		DoUpdates: clause.AssignmentColumns([]string{"rate", "recorded_at"}),
+	}).Create(&history).Error
+}
+
+// DeleteOlderThan removes history points dated before cutoff
+func (r *RateHistoryRepository) DeleteOlderThan(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("date < ?", cutoff).Delete(&models.RateHistory{})
+	return result.RowsAffected, result.Error
+}
+
 // GetHistory gets rate history for a currency pair
 func (r *RateHistoryRepository) GetHistory(ctx context.Context, from, to string, startDate, endDate time.Time) ([]models.RateHistory, error) {
 	var history []models.RateHistory
@@ -2,11 +2,16 @@ package service
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/logger"
 	"github.com/radmickey/money-control/backend/services/currency/models"
 	"github.com/radmickey/money-control/backend/services/currency/providers"
 	"github.com/radmickey/money-control/backend/services/currency/repository"
@@ -14,6 +19,22 @@ import (
 
 const (
 	rateCacheTTL = 1 * time.Hour
+
+	// The currency list only changes when currencies are seeded
+	currencyListCacheTTL = 24 * time.Hour
+
+	// Default age after which rates are reported as stale
+	defaultRateStaleAfter = 3 * time.Hour
+
+	// How far back to look for a historical rate before giving up
+	historicalRateLookback = 365 * 24 * time.Hour
+)
+
+var (
+	// ErrNoHistoricalRate is returned when no rate is recorded on or before the requested date
+	ErrNoHistoricalRate = errors.New("no historical rate available")
+	// ErrInvalidCurrency is returned for codes that are neither ISO 4217 nor a supported crypto symbol
+	ErrInvalidCurrency = errors.New("invalid currency code")
 )
 
 // CurrencyService handles currency business logic
@@ -21,13 +42,31 @@ type CurrencyService struct {
 	currencyRepo     *repository.CurrencyRepository
 	rateRepo         *repository.ExchangeRateRepository
 	historyRepo      *repository.RateHistoryRepository
-	ratesClient      *providers.ExchangeRatesClient
+	rateProvider     *providers.ChainProvider
 	cbrClient        *providers.CBRClient
 	cache            *cache.Cache
 	updateTicker     *time.Ticker
 	stopChan         chan struct{}
 	defaultBase      string
+	baseCurrencies   []string
+	staleAfter       time.Duration
+	log              *logger.Logger
 	mu               sync.RWMutex
+
+	// Collapses concurrent cache misses for a pair into one lookup
+	rateFlight cache.Flight[models.ExchangeRate]
+
+	// Background jobs, waited for by Stop
+	jobs sync.WaitGroup
+
+	// Rate lookup counters, by how the rate was obtained
+	directLookups       atomic.Int64
+	triangulatedLookups atomic.Int64
+	cachedLookups       atomic.Int64
+
+	// Currency list cache counters
+	currencyListHits   atomic.Int64
+	currencyListMisses atomic.Int64
 }
 
 // NewCurrencyService creates a new currency service
@@ -35,44 +74,71 @@ func NewCurrencyService(
 	currencyRepo *repository.CurrencyRepository,
 	rateRepo *repository.ExchangeRateRepository,
 	historyRepo *repository.RateHistoryRepository,
-	ratesClient *providers.ExchangeRatesClient,
+	rateProvider *providers.ChainProvider,
 	redisCache *cache.Cache,
 	defaultBase string,
+	baseCurrencies []string,
+	staleAfter time.Duration,
+	log *logger.Logger,
 ) *CurrencyService {
 	if defaultBase == "" {
 		defaultBase = "USD"
 	}
+	if staleAfter <= 0 {
+		staleAfter = defaultRateStaleAfter
+	}
 
 	s := &CurrencyService{
 		currencyRepo: currencyRepo,
 		rateRepo:     rateRepo,
 		historyRepo:  historyRepo,
-		ratesClient:  ratesClient,
+		rateProvider: rateProvider,
 		cbrClient:    providers.NewCBRClient(),
 		cache:        redisCache,
 		defaultBase:  defaultBase,
+		staleAfter:   staleAfter,
+		log:          log,
 		stopChan:     make(chan struct{}),
 	}
 
+	// The default base is always refreshed first; other bases add direct rates
+	s.baseCurrencies = []string{defaultBase}
+	for _, base := range baseCurrencies {
+		base = strings.ToUpper(strings.TrimSpace(base))
+		if base != "" && base != defaultBase && !slices.Contains(s.baseCurrencies, base) {
+			s.baseCurrencies = append(s.baseCurrencies, base)
+		}
+	}
+
 	return s
 }
 
-// StartRateUpdater starts the background rate updater
+// rateUpdaterJob names the rate refresh for the job lock shared by replicas
+const rateUpdaterJob = "rate-updater"
+
+// StartRateUpdater starts the background rate updater. With several
+// replicas, each interval's refresh runs on only one of them.
 func (s *CurrencyService) StartRateUpdater(interval time.Duration) {
 	s.updateTicker = time.NewTicker(interval)
 
+	// Cancel an in-flight refresh on Stop
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-s.stopChan
+		cancel()
+	}()
+
+	s.jobs.Add(1)
 	go func() {
+		defer s.jobs.Done()
+
 		// Initial update
-		if _, err := s.RefreshRates(context.Background(), s.defaultBase); err != nil {
-			log.Printf("Failed to refresh rates: %v", err)
-		}
+		s.runRateUpdate(ctx, interval)
 
 		for {
 			select {
 			case <-s.updateTicker.C:
-				if _, err := s.RefreshRates(context.Background(), s.defaultBase); err != nil {
-					log.Printf("Failed to refresh rates: %v", err)
-				}
+				s.runRateUpdate(ctx, interval)
 			case <-s.stopChan:
 				s.updateTicker.Stop()
 				return
@@ -81,28 +147,124 @@ func (s *CurrencyService) StartRateUpdater(interval time.Duration) {
 	}()
 }
 
-// Stop stops the rate updater
+// runRateUpdate refreshes all bases unless another replica is refreshing
+// them or already has this interval
+func (s *CurrencyService) runRateUpdate(ctx context.Context, interval time.Duration) {
+	run := time.Now().UTC().Truncate(interval).Format(time.RFC3339)
+	ran, err := s.cache.RunSingleton(ctx, rateUpdaterJob, run, interval, s.refreshAllBases)
+	if err != nil {
+		s.log.Error("rate update failed", "error", err)
+		return
+	}
+	if !ran {
+		s.log.Debug("rate update skipped, handled by another replica", "run", run)
+	}
+}
+
+// refreshAllBases refreshes rates for every configured base currency,
+// returning the failures so the run can be retried
+func (s *CurrencyService) refreshAllBases(ctx context.Context) error {
+	var errs []error
+	for _, base := range s.baseCurrencies {
+		if _, err := s.RefreshRates(ctx, base); err != nil {
+			s.log.Error("failed to refresh rates", "base", base, "error", err)
+			errs = append(errs, fmt.Errorf("%s: %w", base, err))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+	// Don't record an interrupted refresh as done
+	return ctx.Err()
+}
+
+// Stop stops the rate updater, waiting for an in-flight refresh to finish
 func (s *CurrencyService) Stop() {
 	close(s.stopChan)
+	s.jobs.Wait()
+}
+
+// ValidateCurrencyCode normalizes a currency code and checks it is a valid ISO 4217 code.
+// Crypto symbols from the currency table are accepted only when allowCrypto is set.
+func (s *CurrencyService) ValidateCurrencyCode(ctx context.Context, code string, allowCrypto bool) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if models.IsISO4217(code) {
+		return code, nil
+	}
+
+	if allowCrypto && code != "" {
+		currency, err := s.currencyRepo.GetByCode(ctx, code)
+		if err == nil && currency.IsCrypto {
+			return code, nil
+		}
+		if err != nil && !errors.Is(err, repository.ErrCurrencyNotFound) {
+			return "", err
+		}
+	}
+
+	return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+}
+
+// validatePair validates and normalizes both sides of a conversion
+func (s *CurrencyService) validatePair(ctx context.Context, from, to string) (string, string, error) {
+	from, err := s.ValidateCurrencyCode(ctx, from, true)
+	if err != nil {
+		return "", "", err
+	}
+	to, err = s.ValidateCurrencyCode(ctx, to, true)
+	if err != nil {
+		return "", "", err
+	}
+	return from, to, nil
 }
 
 // GetExchangeRate gets exchange rate between two currencies
 func (s *CurrencyService) GetExchangeRate(ctx context.Context, from, to string) (*models.ExchangeRate, error) {
+	from, to, err := s.validatePair(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check cache first
 	cacheKey := cache.ExchangeRateKey(from, to)
 	var cachedRate models.ExchangeRate
 	if err := s.cache.Get(ctx, cacheKey, &cachedRate); err == nil {
+		s.cachedLookups.Add(1)
 		return &cachedRate, nil
 	}
 
-	// Check database
+	// Callers missing the cache at the same time share one lookup
+	return s.rateFlight.Do(ctx, from+"/"+to, func(ctx context.Context) (*models.ExchangeRate, error) {
+		return s.lookupRate(ctx, from, to)
+	})
+}
+
+// lookupRate finds the rate for a validated pair in the database, directly,
+// inverted or through the default base, and caches it
+func (s *CurrencyService) lookupRate(ctx context.Context, from, to string) (*models.ExchangeRate, error) {
+	cacheKey := cache.ExchangeRateKey(from, to)
+
+	// Prefer a rate stored directly for the pair
 	rate, err := s.rateRepo.GetRate(ctx, from, to)
 	if err == nil {
-		// Cache the result
+		s.directLookups.Add(1)
 		_ = s.cache.Set(ctx, cacheKey, rate, rateCacheTTL)
 		return rate, nil
 	}
 
+	// Then the inverse of a stored rate (e.g. EUR->USD from the USD base)
+	if inverse, invErr := s.rateRepo.GetRate(ctx, to, from); invErr == nil && inverse.Rate > 0 {
+		s.directLookups.Add(1)
+		result := &models.ExchangeRate{
+			FromCurrency: from,
+			ToCurrency:   to,
+			Rate:         1 / inverse.Rate,
+			UpdatedAt:    inverse.UpdatedAt,
+		}
+		_ = s.cache.Set(ctx, cacheKey, result, rateCacheTTL)
+		return result, nil
+	}
+
 	// Try to calculate from available rates
 	if from != s.defaultBase && to != s.defaultBase {
 		fromRate, err := s.rateRepo.GetRate(ctx, s.defaultBase, from)
@@ -115,11 +277,19 @@ func (s *CurrencyService) GetExchangeRate(ctx context.Context, from, to string)
 		}
 
 		calculatedRate := toRate.Rate / fromRate.Rate
+
+		// A cross rate is only as fresh as its older leg
+		updatedAt := fromRate.UpdatedAt
+		if toRate.UpdatedAt.Before(updatedAt) {
+			updatedAt = toRate.UpdatedAt
+		}
+
+		s.triangulatedLookups.Add(1)
 		result := &models.ExchangeRate{
 			FromCurrency: from,
 			ToCurrency:   to,
 			Rate:         calculatedRate,
-			UpdatedAt:    time.Now(),
+			UpdatedAt:    updatedAt,
 		}
 		_ = s.cache.Set(ctx, cacheKey, result, rateCacheTTL)
 		return result, nil
@@ -189,6 +359,11 @@ func (s *CurrencyService) filterRates(rates map[string]float64, targetCurrencies
 
 // ConvertAmount converts an amount from one currency to another
 func (s *CurrencyService) ConvertAmount(ctx context.Context, amount float64, from, to string) (float64, float64, error) {
+	from, to, err := s.validatePair(ctx, from, to)
+	if err != nil {
+		return 0, 0, err
+	}
+
 	if from == to {
 		return amount, 1.0, nil
 	}
@@ -201,33 +376,253 @@ func (s *CurrencyService) ConvertAmount(ctx context.Context, amount float64, fro
 	return amount * rate.Rate, rate.Rate, nil
 }
 
-// ConvertMultipleAmounts converts multiple amounts to a target currency
+// DetailedConversion holds a conversion with the rate metadata needed for display
+type DetailedConversion struct {
+	OriginalAmount  float64   `json:"original_amount"`
+	FromCurrency    string    `json:"from_currency"`
+	ConvertedAmount float64   `json:"converted_amount"`
+	ToCurrency      string    `json:"to_currency"`
+	Rate            float64   `json:"rate"`
+	InverseRate     float64   `json:"inverse_rate"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	Stale           bool      `json:"stale"`
+	Provider        string    `json:"provider,omitempty"`
+}
+
+// ConvertDetailed converts an amount and reports the rate, its inverse and how fresh it is
+func (s *CurrencyService) ConvertDetailed(ctx context.Context, amount float64, from, to string) (*DetailedConversion, error) {
+	from, to, err := s.validatePair(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &DetailedConversion{
+		OriginalAmount: amount,
+		FromCurrency:   from,
+		ToCurrency:     to,
+		Provider:       s.rateProvider.Status().ActiveProvider,
+	}
+
+	if from == to {
+		result.ConvertedAmount = amount
+		result.Rate = 1.0
+		result.InverseRate = 1.0
+		result.UpdatedAt = time.Now()
+		return result, nil
+	}
+
+	rate, err := s.GetExchangeRate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if rate.Rate <= 0 {
+		return nil, fmt.Errorf("invalid exchange rate for %s/%s", from, to)
+	}
+
+	result.ConvertedAmount = amount * rate.Rate
+	result.Rate = rate.Rate
+	result.InverseRate = 1 / rate.Rate
+	result.UpdatedAt = rate.UpdatedAt
+	result.Stale = s.isStale(rate.UpdatedAt)
+	return result, nil
+}
+
+// isStale reports whether a rate updated at t is older than the stale threshold
+func (s *CurrencyService) isStale(t time.Time) bool {
+	return t.IsZero() || time.Since(t) > s.staleAfter
+}
+
+// ConvertAmountAsOf converts an amount using the rate in effect on date.
+// When no rate was recorded on that exact date the nearest prior one is used;
+// the returned time is the date of the rate actually applied.
+func (s *CurrencyService) ConvertAmountAsOf(ctx context.Context, amount float64, from, to string, date time.Time) (float64, float64, time.Time, error) {
+	if from == to {
+		return amount, 1.0, date, nil
+	}
+
+	rate, rateDate, err := s.getHistoricalRate(ctx, from, to, date)
+	if err != nil {
+		return 0, 0, time.Time{}, err
+	}
+
+	return amount * rate, rate, rateDate, nil
+}
+
+// getHistoricalRate finds the closest rate on or before date, trying the direct pair,
+// the inverse pair and finally a cross rate through the default base currency
+func (s *CurrencyService) getHistoricalRate(ctx context.Context, from, to string, date time.Time) (float64, time.Time, error) {
+	if rate, rateDate, err := s.latestHistoryEntry(ctx, from, to, date); err != nil || rate > 0 {
+		return rate, rateDate, err
+	}
+
+	if rate, rateDate, err := s.latestHistoryEntry(ctx, to, from, date); err != nil || rate > 0 {
+		if err != nil {
+			return 0, time.Time{}, err
+		}
+		return 1 / rate, rateDate, nil
+	}
+
+	if from == s.defaultBase || to == s.defaultBase {
+		return 0, time.Time{}, fmt.Errorf("%w for %s/%s on %s", ErrNoHistoricalRate, from, to, date.Format("2006-01-02"))
+	}
+
+	baseToFrom, fromDate, err := s.getHistoricalRate(ctx, s.defaultBase, from, date)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+	baseToTo, toDate, err := s.getHistoricalRate(ctx, s.defaultBase, to, date)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	// Report the older of the two legs as the effective rate date
+	rateDate := fromDate
+	if toDate.Before(rateDate) {
+		rateDate = toDate
+	}
+	return baseToTo / baseToFrom, rateDate, nil
+}
+
+// latestHistoryEntry returns the most recent recorded rate for a pair on or before date.
+// A zero rate means nothing was recorded within the lookback window.
+func (s *CurrencyService) latestHistoryEntry(ctx context.Context, from, to string, date time.Time) (float64, time.Time, error) {
+	history, err := s.historyRepo.GetHistory(ctx, from, to, date.Add(-historicalRateLookback), date)
+	if err != nil {
+		return 0, time.Time{}, err
+	}
+
+	// GetHistory orders by date descending, so the first valid entry is the closest prior one
+	for _, h := range history {
+		if h.Rate > 0 {
+			return h.Rate, h.Date, nil
+		}
+	}
+	return 0, time.Time{}, nil
+}
+
+// ConvertMultipleAmounts converts multiple amounts to a target currency.
+// Rates for all distinct source currencies are loaded up front; items whose
+// currency has no rate carry a per-item error and are excluded from the total.
 func (s *CurrencyService) ConvertMultipleAmounts(ctx context.Context, amounts []AmountToConvert, toCurrency string) ([]ConvertedAmount, float64, error) {
+	sources := make([]string, 0, len(amounts))
+	seen := make(map[string]bool)
+	for _, amount := range amounts {
+		if amount.FromCurrency != toCurrency && !seen[amount.FromCurrency] {
+			seen[amount.FromCurrency] = true
+			sources = append(sources, amount.FromCurrency)
+		}
+	}
+
+	rates, rateErrs := s.loadRatesTo(ctx, sources, toCurrency)
+
 	results := make([]ConvertedAmount, len(amounts))
 	var total float64
 
 	for i, amount := range amounts {
-		converted, rate, err := s.ConvertAmount(ctx, amount.Amount, amount.FromCurrency, toCurrency)
-		if err != nil {
-			return nil, 0, err
+		result := ConvertedAmount{
+			ID:             amount.ID,
+			OriginalAmount: amount.Amount,
+			FromCurrency:   amount.FromCurrency,
 		}
 
-		results[i] = ConvertedAmount{
-			ID:              amount.ID,
-			OriginalAmount:  amount.Amount,
-			FromCurrency:    amount.FromCurrency,
-			ConvertedAmount: converted,
-			RateUsed:        rate,
+		rate := 1.0
+		if amount.FromCurrency != toCurrency {
+			var ok bool
+			if rate, ok = rates[amount.FromCurrency]; !ok {
+				result.Error = rateErrs[amount.FromCurrency].Error()
+				results[i] = result
+				continue
+			}
 		}
-		total += converted
+
+		result.ConvertedAmount = amount.Amount * rate
+		result.RateUsed = rate
+		results[i] = result
+		total += result.ConvertedAmount
 	}
 
 	return results, total, nil
 }
 
+// loadRatesTo returns the rate from each source currency to toCurrency.
+// Rates come from a single batch fetch; currencies missing from the batch are
+// looked up individually and concurrently, and failures are reported per currency.
+func (s *CurrencyService) loadRatesTo(ctx context.Context, sources []string, toCurrency string) (map[string]float64, map[string]error) {
+	rates := make(map[string]float64, len(sources))
+	rateErrs := make(map[string]error)
+	if len(sources) == 0 {
+		return rates, rateErrs
+	}
+
+	// Rates relative to toCurrency: 1 toCurrency = baseRates[c] units of c
+	baseRates, _, err := s.GetMultipleExchangeRates(ctx, toCurrency, sources)
+	if err != nil {
+		s.log.WarnContext(ctx, "batch rate fetch failed", "currency", toCurrency, "error", err)
+	}
+
+	var missing []string
+	for _, currency := range sources {
+		if r, ok := baseRates[currency]; ok && r > 0 {
+			rates[currency] = 1 / r
+		} else {
+			missing = append(missing, currency)
+		}
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for _, currency := range missing {
+		wg.Add(1)
+		go func(currency string) {
+			defer wg.Done()
+			rate, err := s.GetExchangeRate(ctx, currency, toCurrency)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				rateErrs[currency] = fmt.Errorf("no exchange rate from %s to %s: %w", currency, toCurrency, err)
+				return
+			}
+			rates[currency] = rate.Rate
+		}(currency)
+	}
+	wg.Wait()
+
+	return rates, rateErrs
+}
+
 // ListSupportedCurrencies lists all supported currencies
 func (s *CurrencyService) ListSupportedCurrencies(ctx context.Context, includeCrypto bool) ([]models.Currency, error) {
-	return s.currencyRepo.GetAllCurrencies(ctx, includeCrypto)
+	cacheKey := cache.CurrenciesKey(includeCrypto)
+	var cached []models.Currency
+	if err := s.cache.Get(ctx, cacheKey, &cached); err == nil && len(cached) > 0 {
+		s.currencyListHits.Add(1)
+		return cached, nil
+	}
+	s.currencyListMisses.Add(1)
+
+	currencies, err := s.currencyRepo.GetAllCurrencies(ctx, includeCrypto)
+	if err != nil {
+		return nil, err
+	}
+	_ = s.cache.Set(ctx, cacheKey, currencies, currencyListCacheTTL)
+	return currencies, nil
+}
+
+// SeedCurrencies adds any missing supported currencies and drops the cached
+// currency lists
+func (s *CurrencyService) SeedCurrencies(ctx context.Context) error {
+	err := s.currencyRepo.SeedCurrencies(ctx)
+	s.invalidateCurrencyList(ctx)
+	return err
+}
+
+// invalidateCurrencyList drops both cached currency lists; call it after
+// any change to the currencies table
+func (s *CurrencyService) invalidateCurrencyList(ctx context.Context) {
+	if err := s.cache.Delete(ctx, cache.CurrenciesKey(false), cache.CurrenciesKey(true)); err != nil {
+		s.log.WarnContext(ctx, "failed to invalidate currency list cache", "error", err)
+	}
 }
 
 // GetRateHistory gets historical rates for a currency pair
@@ -240,15 +635,15 @@ func (s *CurrencyService) RefreshRates(ctx context.Context, baseCurrency string)
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	// Get rates from frankfurter.app (ECB data)
-	rates, err := s.ratesClient.GetLatestRates(ctx, baseCurrency)
+	// Get rates from the first available provider (frankfurter.app, then fallbacks)
+	rates, providerName, err := s.rateProvider.FetchLatestRates(ctx, baseCurrency)
 	if err != nil {
 		return 0, err
 	}
 
 	// Get RUB rate from Central Bank of Russia
 	if err := s.addCBRRates(ctx, rates, baseCurrency); err != nil {
-		log.Printf("Warning: Failed to get CBR rates: %v", err)
+		s.log.WarnContext(ctx, "failed to get CBR rates", "base", baseCurrency, "error", err)
 	}
 
 	// Save to database
@@ -256,6 +651,11 @@ func (s *CurrencyService) RefreshRates(ctx context.Context, baseCurrency string)
 		return 0, err
 	}
 
+	// Append to rate history so historical conversions have data
+	if err := s.historyRepo.RecordRates(ctx, baseCurrency, rates, time.Now()); err != nil {
+		s.log.WarnContext(ctx, "failed to record rate history", "base", baseCurrency, "error", err)
+	}
+
 	// Invalidate cache
 	cacheKey := cache.ExchangeRatesKey(baseCurrency)
 	_ = s.cache.Delete(ctx, cacheKey)
@@ -263,11 +663,66 @@ func (s *CurrencyService) RefreshRates(ctx context.Context, baseCurrency string)
 	// Cache new rates
 	_ = s.cache.Set(ctx, cacheKey, rates, rateCacheTTL)
 
-	log.Printf("Updated %d exchange rates for base %s", len(rates), baseCurrency)
+	s.log.InfoContext(ctx, "updated exchange rates", "base", baseCurrency, "count", len(rates), "provider", providerName)
 
 	return len(rates), nil
 }
 
+// RateStatus describes the freshness of stored exchange rates
+type RateStatus struct {
+	BaseCurrency   string           `json:"base_currency"`
+	BaseCurrencies []string         `json:"base_currencies"`
+	ActiveProvider string           `json:"active_provider"`
+	LastSuccess    time.Time        `json:"last_success"`
+	LastAttempt    time.Time        `json:"last_attempt"`
+	LastError      string           `json:"last_error,omitempty"`
+	RatesUpdatedAt time.Time        `json:"rates_updated_at"`
+	Stale          bool             `json:"stale"`
+	RateLookups    map[string]int64 `json:"rate_lookups"`
+	CurrencyCache  map[string]int64 `json:"currency_cache"`
+}
+
+// GetRateStatus reports which provider supplied the last refresh and whether rates are stale
+func (s *CurrencyService) GetRateStatus(ctx context.Context) (*RateStatus, error) {
+	providerStatus := s.rateProvider.Status()
+
+	updatedAt, err := s.rateRepo.GetLastUpdateTime(ctx, s.defaultBase)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RateStatus{
+		BaseCurrency:   s.defaultBase,
+		BaseCurrencies: s.baseCurrencies,
+		ActiveProvider: providerStatus.ActiveProvider,
+		LastSuccess:    providerStatus.LastSuccess,
+		LastAttempt:    providerStatus.LastAttempt,
+		LastError:      providerStatus.LastError,
+		RatesUpdatedAt: updatedAt,
+		Stale:          s.isStale(updatedAt),
+		RateLookups:    s.RateLookupStats(),
+		CurrencyCache:  s.CurrencyCacheStats(),
+	}, nil
+}
+
+// RateLookupStats returns how many rate lookups were served directly, by triangulation or from cache
+func (s *CurrencyService) RateLookupStats() map[string]int64 {
+	return map[string]int64{
+		"direct":       s.directLookups.Load(),
+		"triangulated": s.triangulatedLookups.Load(),
+		"cached":       s.cachedLookups.Load(),
+	}
+}
+
+// CurrencyCacheStats returns how many currency list requests were served
+// from cache and how many went to the database
+func (s *CurrencyService) CurrencyCacheStats() map[string]int64 {
+	return map[string]int64{
+		"hits":   s.currencyListHits.Load(),
+		"misses": s.currencyListMisses.Load(),
+	}
+}
+
 // addCBRRates adds RUB and CIS currencies from Central Bank of Russia
 func (s *CurrencyService) addCBRRates(ctx context.Context, rates map[string]float64, baseCurrency string) error {
 	// Get USD/RUB rate from CBR
@@ -310,5 +765,6 @@ type ConvertedAmount struct {
 	FromCurrency    string
 	ConvertedAmount float64
 	RateUsed        float64
+	Error           string
 }
 
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	"github.com/radmickey/money-control/backend/pkg/logger"
+	"github.com/radmickey/money-control/backend/services/currency/models"
+	"github.com/radmickey/money-control/backend/services/currency/providers"
+	"github.com/radmickey/money-control/backend/services/currency/repository"
+	"gorm.io/gorm"
+)
+
+// newTestCurrencyService returns a service on a test database, which the
+// test can seed through the returned handle
+func newTestCurrencyService(t *testing.T) (*CurrencyService, *gorm.DB) {
+	t.Helper()
+	db := databasetest.Open(t, &models.Currency{}, &models.ExchangeRate{}, &models.RateHistory{})
+
+	mr := miniredis.RunT(t)
+	redisCache, err := cache.New(cache.Config{URL: "redis://" + mr.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { redisCache.Close() })
+
+	s := NewCurrencyService(
+		repository.NewCurrencyRepository(db),
+		repository.NewExchangeRateRepository(db),
+		repository.NewRateHistoryRepository(db),
+		nil,
+		redisCache,
+		"USD",
+		nil,
+		0,
+		logger.New(logger.Config{Output: io.Discard}),
+	)
+	return s, db
+}
+
+func TestConcurrentRateMissesLookUpOnce(t *testing.T) {
+	s, db := newTestCurrencyService(t)
+	ctx := context.Background()
+
+	rates := repository.NewExchangeRateRepository(db)
+	if err := rates.UpsertRates(ctx, "USD", map[string]float64{"EUR": 0.9, "GBP": 0.8}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Count the exchange rate reads that reach the database
+	var reads atomic.Int32
+	if err := db.Callback().Query().After("gorm:query").Register("test:count_rate_reads", func(tx *gorm.DB) {
+		if tx.Statement.Table == "exchange_rates" {
+			reads.Add(1)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	var failures atomic.Int32
+	for i := 0; i < 50; i++ {
+		to := "EUR"
+		if i%2 == 1 {
+			to = "GBP"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rate, err := s.GetExchangeRate(ctx, "USD", to)
+			if err != nil || rate.ToCurrency != to {
+				failures.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := failures.Load(); n != 0 {
+		t.Fatalf("%d callers failed", n)
+	}
+	// One direct read per pair; every other caller shared it or hit the cache
+	if got := s.directLookups.Load(); got != 2 {
+		t.Errorf("%d direct lookups, want 2", got)
+	}
+	if got := reads.Load(); got != 2 {
+		t.Errorf("%d exchange rate reads, want 2", got)
+	}
+}
+
+// fakeRateProvider returns the rates it holds, which tests change between refreshes
+type fakeRateProvider struct {
+	rates map[string]float64
+}
+
+func (p *fakeRateProvider) Name() string { return "fake" }
+
+func (p *fakeRateProvider) GetLatestRates(ctx context.Context, baseCurrency string) (map[string]float64, error) {
+	rates := make(map[string]float64, len(p.rates))
+	for currency, rate := range p.rates {
+		rates[currency] = rate
+	}
+	return rates, nil
+}
+
+// offlineTransport fails every HTTP request, keeping the CBR lookup made by
+// RefreshRates off the network
+type offlineTransport struct{}
+
+func (offlineTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("network disabled in tests")
+}
+
+func TestRefreshRatesRecordsHistory(t *testing.T) {
+	s, db := newTestCurrencyService(t)
+	ctx := context.Background()
+
+	transport := http.DefaultTransport
+	http.DefaultTransport = offlineTransport{}
+	t.Cleanup(func() { http.DefaultTransport = transport })
+
+	provider := &fakeRateProvider{rates: map[string]float64{"EUR": 0.90, "GBP": 0.80}}
+	s.rateProvider = providers.NewChainProvider(provider)
+	history := repository.NewRateHistoryRepository(db)
+
+	if _, err := s.RefreshRates(ctx, "USD"); err != nil {
+		t.Fatalf("first refresh: %v", err)
+	}
+	// Make the first refresh yesterday's, as if the job ran a day apart
+	if err := db.Exec("UPDATE rate_history SET date = date - 1").Error; err != nil {
+		t.Fatal(err)
+	}
+
+	provider.rates = map[string]float64{"EUR": 0.92, "GBP": 0.81}
+	if _, err := s.RefreshRates(ctx, "USD"); err != nil {
+		t.Fatalf("second refresh: %v", err)
+	}
+
+	points, err := history.GetHistory(ctx, "USD", "EUR", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("%d EUR history points, want 2", len(points))
+	}
+	// Newest first
+	if points[0].Rate != 0.92 || points[1].Rate != 0.90 {
+		t.Errorf("EUR history = %v then %v, want 0.92 then 0.90", points[0].Rate, points[1].Rate)
+	}
+	if !points[0].Date.After(points[1].Date) {
+		t.Errorf("history dates %v and %v, want distinct days newest first", points[0].Date, points[1].Date)
+	}
+
+	if gbp, err := history.GetHistory(ctx, "USD", "GBP", time.Time{}, time.Time{}); err != nil || len(gbp) != 2 {
+		t.Errorf("GBP history: %d points, err %v; want 2", len(gbp), err)
+	}
+
+	// A second refresh on the same day updates that day's point
+	provider.rates = map[string]float64{"EUR": 0.93, "GBP": 0.82}
+	if _, err := s.RefreshRates(ctx, "USD"); err != nil {
+		t.Fatalf("same-day refresh: %v", err)
+	}
+	points, err = history.GetHistory(ctx, "USD", "EUR", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("after a same-day refresh: %d EUR history points, want 2", len(points))
+	}
+	if points[0].Rate != 0.93 {
+		t.Errorf("after a same-day refresh: latest EUR rate %v, want 0.93", points[0].Rate)
+	}
+}
+
+func TestConvertAmountAsOf(t *testing.T) {
+	s, db := newTestCurrencyService(t)
+	ctx := context.Background()
+	history := repository.NewRateHistoryRepository(db)
+
+	march := func(day int) time.Time { return time.Date(2026, 3, day, 0, 0, 0, 0, time.UTC) }
+	for _, point := range []struct {
+		day   int
+		rates map[string]float64
+	}{
+		{day: 2, rates: map[string]float64{"EUR": 0.90, "GBP": 0.80}},
+		{day: 5, rates: map[string]float64{"EUR": 0.95, "GBP": 0.75}},
+	} {
+		if err := history.RecordRates(ctx, "USD", point.rates, march(point.day)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		from, to string
+		date     time.Time
+		want     float64
+		wantDate time.Time
+	}{
+		{name: "exact date", from: "USD", to: "EUR", date: march(2), want: 90, wantDate: march(2)},
+		{name: "gap uses the prior date", from: "USD", to: "EUR", date: march(4).Add(12 * time.Hour), want: 90, wantDate: march(2)},
+		{name: "later rate once recorded", from: "USD", to: "EUR", date: march(6), want: 95, wantDate: march(5)},
+		{name: "inverse pair", from: "EUR", to: "USD", date: march(3), want: 100 / 0.90, wantDate: march(2)},
+		{name: "cross rate through the base", from: "EUR", to: "GBP", date: march(5), want: 100 * 0.75 / 0.95, wantDate: march(5)},
+		{name: "same currency", from: "EUR", to: "EUR", date: march(1), want: 100, wantDate: march(1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			converted, _, rateDate, err := s.ConvertAmountAsOf(ctx, 100, tt.from, tt.to, tt.date)
+			if err != nil {
+				t.Fatalf("ConvertAmountAsOf: %v", err)
+			}
+			if math.Abs(converted-tt.want) > 1e-6 || !rateDate.Equal(tt.wantDate) {
+				t.Errorf("got %v as of %v, want %v as of %v", converted, rateDate, tt.want, tt.wantDate)
+			}
+		})
+	}
+
+	// Nothing recorded on or before the date
+	if _, _, _, err := s.ConvertAmountAsOf(ctx, 100, "USD", "EUR", march(1)); !errors.Is(err, ErrNoHistoricalRate) {
+		t.Errorf("before any history: err = %v, want ErrNoHistoricalRate", err)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"sync/atomic"
+
+	"github.com/gin-gonic/gin"
+)
+
+// drainState tracks in-flight requests and whether the gateway is draining
+type drainState struct {
+	draining atomic.Bool
+	active   atomic.Int64
+}
+
+// trackRequests counts requests currently being served
+func (d *drainState) trackRequests() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		d.active.Add(1)
+		defer d.active.Add(-1)
+		c.Next()
+	}
+}
+
+// startDraining marks the gateway as draining and reports whether it already was
+func (d *drainState) startDraining() bool {
+	return d.draining.Swap(true)
+}
+
+func (d *drainState) isDraining() bool {
+	return d.draining.Load()
+}
+
+func (d *drainState) activeRequests() int64 {
+	return d.active.Load()
+}
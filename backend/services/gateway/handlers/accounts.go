@@ -1,22 +1,18 @@
 package handlers
 
 import (
-	"context"
 	"log"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/converters"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
-	"github.com/radmickey/money-control/backend/pkg/resilience"
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
 	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
-)
-
-const (
-	serviceAccounts = "accounts-service"
-	serviceCurrency = "currency-service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // AccountsHandler handles accounts-related requests
@@ -31,7 +27,11 @@ func NewAccountsHandler(sp *proxy.ServiceProxy) *AccountsHandler {
 
 // CreateAccount creates a new account
 func (h *AccountsHandler) CreateAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req struct {
 		Name        string `json:"name" binding:"required"`
@@ -39,27 +39,27 @@ func (h *AccountsHandler) CreateAccount(c *gin.Context) {
 		Currency    string `json:"currency"`
 		Description string `json:"description"`
 		Icon        string `json:"icon"`
+		IsLiability bool   `json:"is_liability"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
-	// Call with circuit breaker and timeout
-	resp, err := resilience.Call(c.Request.Context(), resilience.DefaultCallOptions(serviceAccounts),
-		func(ctx context.Context) (*accountspb.Account, error) {
-			return h.proxy.Accounts.CreateAccount(ctx, &accountspb.CreateAccountRequest{
-				UserId:      userID,
-				Name:        req.Name,
-				Type:        converters.StringToAccountType(req.Type),
-				Currency:    converters.DefaultCurrency(req.Currency),
-				Description: req.Description,
-				Icon:        req.Icon,
-			})
-		})
+	// The proxy applies the circuit breaker, and retries only when the client
+	// sent an idempotency key
+	resp, err := h.proxy.Accounts.CreateAccount(c.Request.Context(), &accountspb.CreateAccountRequest{
+		UserId:      userID,
+		Name:        req.Name,
+		Type:        converters.StringToAccountType(req.Type),
+		Currency:    converters.DefaultCurrency(req.Currency),
+		Description: req.Description,
+		Icon:        req.Icon,
+		IsLiability: req.IsLiability,
+	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -81,6 +81,10 @@ type AccountWithConvertedBalance struct {
 	DisplayCurrency       string                    `json:"display_currency"`
 	IsMixedCurrency       bool                      `json:"is_mixed_currency"`
 	SubAccounts           []SubAccountWithConverted `json:"subAccounts,omitempty"`
+	Archived              bool                      `json:"archived"`
+	IsLiability           bool                      `json:"is_liability"`
+	DisplayOrder          int32                     `json:"display_order"`
+	Version               int64                     `json:"version"`
 	CreatedAt             string                    `json:"created_at"`
 	UpdatedAt             string                    `json:"updated_at"`
 }
@@ -94,13 +98,19 @@ type SubAccountWithConverted struct {
 	Balance          float64 `json:"balance"`
 	ConvertedBalance float64 `json:"converted_balance"`
 	Description      string  `json:"description,omitempty"`
+	DisplayOrder     int32   `json:"display_order"`
+	Version          int64   `json:"version"`
 	CreatedAt        string  `json:"created_at"`
 	UpdatedAt        string  `json:"updated_at"`
 }
 
 // ListAccounts lists accounts with converted balances
 func (h *AccountsHandler) ListAccounts(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	accountType := c.Query("type")
 	// Support both "currency" and "baseCurrency" query params
 	baseCurrency := c.Query("baseCurrency")
@@ -108,16 +118,18 @@ func (h *AccountsHandler) ListAccounts(c *gin.Context) {
 		baseCurrency = c.Query("currency")
 	}
 	baseCurrency = converters.DefaultCurrency(baseCurrency)
+	page, pageSize := parsePagination(c, maxPageSize)
 
 	// Get accounts
 	resp, err := h.proxy.Accounts.ListAccounts(c.Request.Context(), &accountspb.ListAccountsRequest{
-		UserId:   userID,
-		Type:     converters.StringToAccountType(accountType),
-		Page:     1,
-		PageSize: 100,
+		UserId:          userID,
+		Type:            converters.StringToAccountType(accountType),
+		Page:            page,
+		PageSize:        pageSize,
+		IncludeArchived: c.Query("include_archived") == "true",
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -130,6 +142,8 @@ func (h *AccountsHandler) ListAccounts(c *gin.Context) {
 	utils.Success(c, gin.H{
 		"accounts":      accounts,
 		"total":         resp.Total,
+		"page":          resp.Page,
+		"page_size":     resp.PageSize,
 		"base_currency": baseCurrency,
 		"rates":         rates,
 	})
@@ -184,6 +198,10 @@ func (h *AccountsHandler) convertSingleAccount(acc *accountspb.Account, baseCurr
 		DisplayCurrency:       accountCurrency,
 		IsMixedCurrency:       isMixed,
 		SubAccounts:           subAccounts,
+		Archived:              acc.Archived,
+		IsLiability:           acc.IsLiability,
+		DisplayOrder:          acc.DisplayOrder,
+		Version:               acc.Version,
 		CreatedAt:             converters.FormatTime(acc.CreatedAt),
 		UpdatedAt:             converters.FormatTime(acc.UpdatedAt),
 	}
@@ -215,6 +233,8 @@ func (h *AccountsHandler) convertSubAccounts(subs []*accountspb.SubAccount, acco
 			Balance:          sub.Balance,
 			ConvertedBalance: convertedBalance,
 			Description:      sub.Description,
+			DisplayOrder:     sub.DisplayOrder,
+			Version:          sub.Version,
 			CreatedAt:        converters.FormatTime(sub.CreatedAt),
 			UpdatedAt:        converters.FormatTime(sub.UpdatedAt),
 		})
@@ -228,7 +248,11 @@ func (h *AccountsHandler) convertSubAccounts(subs []*accountspb.SubAccount, acco
 
 // GetAccount gets an account
 func (h *AccountsHandler) GetAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	resp, err := h.proxy.Accounts.GetAccount(c.Request.Context(), &accountspb.GetAccountRequest{
@@ -245,7 +269,11 @@ func (h *AccountsHandler) GetAccount(c *gin.Context) {
 
 // UpdateAccount updates an account
 func (h *AccountsHandler) UpdateAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req struct {
@@ -253,10 +281,11 @@ func (h *AccountsHandler) UpdateAccount(c *gin.Context) {
 		Description string `json:"description"`
 		Icon        string `json:"icon"`
 		Currency    string `json:"currency"`
+		Version     int64  `json:"version"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -267,9 +296,14 @@ func (h *AccountsHandler) UpdateAccount(c *gin.Context) {
 		Description: req.Description,
 		Icon:        req.Icon,
 		Currency:    req.Currency,
+		Version:     req.Version,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.Aborted {
+			utils.Conflict(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -278,7 +312,11 @@ func (h *AccountsHandler) UpdateAccount(c *gin.Context) {
 
 // DeleteAccount deletes an account
 func (h *AccountsHandler) DeleteAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	_, err := h.proxy.Accounts.DeleteAccount(c.Request.Context(), &accountspb.DeleteAccountRequest{
@@ -286,16 +324,100 @@ func (h *AccountsHandler) DeleteAccount(c *gin.Context) {
 		UserId: userID,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.NoContent(c)
 }
 
+// ArchiveAccount archives an account
+func (h *AccountsHandler) ArchiveAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Accounts.ArchiveAccount(c.Request.Context(), &accountspb.ArchiveAccountRequest{
+		Id:     c.Param("id"),
+		UserId: userID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			utils.NotFound(c, "Account not found")
+			return
+		}
+		if status.Code(err) == codes.Aborted {
+			utils.Conflict(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
+
+// UnarchiveAccount restores an archived account
+func (h *AccountsHandler) UnarchiveAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Accounts.UnarchiveAccount(c.Request.Context(), &accountspb.ArchiveAccountRequest{
+		Id:     c.Param("id"),
+		UserId: userID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			utils.NotFound(c, "Account not found")
+			return
+		}
+		if status.Code(err) == codes.Aborted {
+			utils.Conflict(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
+
+// UndeleteAccount restores a recently deleted account
+func (h *AccountsHandler) UndeleteAccount(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Accounts.UndeleteAccount(c.Request.Context(), &accountspb.UndeleteAccountRequest{
+		Id:     c.Param("id"),
+		UserId: userID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			utils.NotFound(c, "No deleted account to restore")
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
+
 // CreateSubAccount creates a sub-account
 func (h *AccountsHandler) CreateSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	accountID := c.Param("id")
 
 	var req struct {
@@ -309,7 +431,7 @@ func (h *AccountsHandler) CreateSubAccount(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -331,7 +453,7 @@ func (h *AccountsHandler) CreateSubAccount(c *gin.Context) {
 		Description: req.Description,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -340,7 +462,11 @@ func (h *AccountsHandler) CreateSubAccount(c *gin.Context) {
 
 // ListSubAccounts lists sub-accounts
 func (h *AccountsHandler) ListSubAccounts(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	accountID := c.Param("id")
 
 	resp, err := h.proxy.Accounts.ListSubAccounts(c.Request.Context(), &accountspb.ListSubAccountsRequest{
@@ -348,16 +474,88 @@ func (h *AccountsHandler) ListSubAccounts(c *gin.Context) {
 		UserId:    userID,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.Success(c, resp.SubAccounts)
 }
 
+// ReorderAccounts sets the display order of the user's accounts
+func (h *AccountsHandler) ReorderAccounts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req struct {
+		IDs []string `json:"ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	_, err := h.proxy.Accounts.ReorderAccounts(c.Request.Context(), &accountspb.ReorderAccountsRequest{
+		UserId: userID,
+		Ids:    req.IDs,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			utils.BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// ReorderSubAccounts sets the display order of an account's sub-accounts
+func (h *AccountsHandler) ReorderSubAccounts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req struct {
+		IDs []string `json:"ids" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	_, err := h.proxy.Accounts.ReorderSubAccounts(c.Request.Context(), &accountspb.ReorderSubAccountsRequest{
+		AccountId: c.Param("id"),
+		UserId:    userID,
+		Ids:       req.IDs,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			utils.NotFound(c, "Account not found")
+		case codes.InvalidArgument:
+			utils.BadRequest(c, status.Convert(err).Message())
+		default:
+			utils.RespondError(c, err)
+		}
+		return
+	}
+
+	utils.NoContent(c)
+}
+
 // GetSubAccount gets a sub-account
 func (h *AccountsHandler) GetSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	resp, err := h.proxy.Accounts.GetSubAccount(c.Request.Context(), &accountspb.GetSubAccountRequest{
@@ -374,7 +572,11 @@ func (h *AccountsHandler) GetSubAccount(c *gin.Context) {
 
 // UpdateSubAccount updates a sub-account
 func (h *AccountsHandler) UpdateSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req struct {
@@ -382,10 +584,11 @@ func (h *AccountsHandler) UpdateSubAccount(c *gin.Context) {
 		Description string  `json:"description"`
 		Balance     float64 `json:"balance"`
 		Quantity    float64 `json:"quantity"`
+		Version     int64   `json:"version"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -396,9 +599,14 @@ func (h *AccountsHandler) UpdateSubAccount(c *gin.Context) {
 		Description: req.Description,
 		Balance:     req.Balance,
 		Quantity:    req.Quantity,
+		Version:     req.Version,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.Aborted {
+			utils.Conflict(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -407,7 +615,11 @@ func (h *AccountsHandler) UpdateSubAccount(c *gin.Context) {
 
 // DeleteSubAccount deletes a sub-account
 func (h *AccountsHandler) DeleteSubAccount(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	_, err := h.proxy.Accounts.DeleteSubAccount(c.Request.Context(), &accountspb.DeleteSubAccountRequest{
@@ -415,7 +627,7 @@ func (h *AccountsHandler) DeleteSubAccount(c *gin.Context) {
 		UserId: userID,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -424,7 +636,11 @@ func (h *AccountsHandler) DeleteSubAccount(c *gin.Context) {
 
 // UpdateSubAccountBalance updates sub-account balance
 func (h *AccountsHandler) UpdateSubAccountBalance(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req struct {
@@ -433,7 +649,7 @@ func (h *AccountsHandler) UpdateSubAccountBalance(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -444,16 +660,118 @@ func (h *AccountsHandler) UpdateSubAccountBalance(c *gin.Context) {
 		Quantity: req.Quantity,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.Aborted {
+			utils.Conflict(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.Success(c, resp)
 }
 
+// BulkUpdateSubAccountBalances sets many sub-account balances at once
+func (h *AccountsHandler) BulkUpdateSubAccountBalances(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req struct {
+		Updates []struct {
+			ID       string  `json:"id" binding:"required"`
+			Balance  float64 `json:"balance"`
+			Quantity float64 `json:"quantity"`
+			Version  int64   `json:"version"`
+		} `json:"updates" binding:"required,dive"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	updates := make([]*accountspb.SubAccountBalanceUpdate, len(req.Updates))
+	for i, u := range req.Updates {
+		updates[i] = &accountspb.SubAccountBalanceUpdate{
+			Id:       u.ID,
+			Balance:  u.Balance,
+			Quantity: u.Quantity,
+			Version:  u.Version,
+		}
+	}
+
+	resp, err := h.proxy.Accounts.BulkUpdateSubAccountBalances(c.Request.Context(), &accountspb.BulkUpdateSubAccountBalancesRequest{
+		UserId:  userID,
+		Updates: updates,
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			utils.BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
+
+// GetSubAccountBalanceHistory gets a sub-account's daily balances for charting
+func (h *AccountsHandler) GetSubAccountBalanceHistory(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	req := &accountspb.GetBalanceHistoryRequest{Id: id, UserId: userID}
+	if v := c.Query("start_date"); v != "" {
+		if req.StartDate = converters.ParseDate(v); req.StartDate == nil {
+			utils.BadRequest(c, "Invalid start_date format (use YYYY-MM-DD)")
+			return
+		}
+	}
+	if v := c.Query("end_date"); v != "" {
+		if req.EndDate = converters.ParseDate(v); req.EndDate == nil {
+			utils.BadRequest(c, "Invalid end_date format (use YYYY-MM-DD)")
+			return
+		}
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			utils.BadRequest(c, "Invalid limit")
+			return
+		}
+		req.Limit = int32(limit)
+	}
+
+	resp, err := h.proxy.Accounts.GetSubAccountBalanceHistory(c.Request.Context(), req)
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			utils.NotFound(c, "Sub-account not found")
+		case codes.InvalidArgument:
+			utils.BadRequest(c, status.Convert(err).Message())
+		default:
+			utils.RespondError(c, err)
+		}
+		return
+	}
+
+	utils.Success(c, resp.Points)
+}
+
 // GetNetWorth gets user net worth
 func (h *AccountsHandler) GetNetWorth(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 	if baseCurrency == "" {
 		baseCurrency = "USD"
@@ -464,7 +782,7 @@ func (h *AccountsHandler) GetNetWorth(c *gin.Context) {
 		BaseCurrency: baseCurrency,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -7,6 +7,8 @@ import (
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	assetspb "github.com/radmickey/money-control/backend/proto/assets"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // AssetsHandler handles assets-related requests
@@ -21,7 +23,11 @@ func NewAssetsHandler(sp *proxy.ServiceProxy) *AssetsHandler {
 
 // CreateAsset creates a new asset
 func (h *AssetsHandler) CreateAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req struct {
 		SubAccountID  string  `json:"sub_account_id"`
@@ -34,7 +40,7 @@ func (h *AssetsHandler) CreateAsset(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -49,7 +55,7 @@ func (h *AssetsHandler) CreateAsset(c *gin.Context) {
 		Currency:      req.Currency,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -58,19 +64,27 @@ func (h *AssetsHandler) CreateAsset(c *gin.Context) {
 
 // ListAssets lists assets
 func (h *AssetsHandler) ListAssets(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	subAccountID := c.Query("sub_account_id")
 	assetType := c.Query("type")
 
-	resp, err := h.proxy.Assets.ListAssets(c.Request.Context(), &assetspb.ListAssetsRequest{
+	req := &assetspb.ListAssetsRequest{
 		UserId:       userID,
 		SubAccountId: subAccountID,
-		Type:         converters.StringToAssetTypeAssets(assetType),
 		Page:         1,
 		PageSize:     100,
-	})
+	}
+	if assetType != "" {
+		req.Type = converters.StringToAssetTypeAssets(assetType)
+	}
+
+	resp, err := h.proxy.Assets.ListAssets(c.Request.Context(), req)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -84,7 +98,11 @@ func (h *AssetsHandler) ListAssets(c *gin.Context) {
 
 // GetAsset gets an asset
 func (h *AssetsHandler) GetAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	resp, err := h.proxy.Assets.GetAsset(c.Request.Context(), &assetspb.GetAssetRequest{
@@ -101,7 +119,11 @@ func (h *AssetsHandler) GetAsset(c *gin.Context) {
 
 // UpdateAsset updates an asset
 func (h *AssetsHandler) UpdateAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req struct {
@@ -109,10 +131,11 @@ func (h *AssetsHandler) UpdateAsset(c *gin.Context) {
 		PurchasePrice float64 `json:"purchase_price"`
 		CurrentPrice  float64 `json:"current_price"`
 		Name          string  `json:"name"`
+		Version       int64   `json:"version"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -123,9 +146,14 @@ func (h *AssetsHandler) UpdateAsset(c *gin.Context) {
 		PurchasePrice: req.PurchasePrice,
 		CurrentPrice:  req.CurrentPrice,
 		Name:          req.Name,
+		Version:       req.Version,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.Aborted {
+			utils.Conflict(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -134,7 +162,11 @@ func (h *AssetsHandler) UpdateAsset(c *gin.Context) {
 
 // DeleteAsset deletes an asset
 func (h *AssetsHandler) DeleteAsset(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	_, err := h.proxy.Assets.DeleteAsset(c.Request.Context(), &assetspb.DeleteAssetRequest{
@@ -142,13 +174,41 @@ func (h *AssetsHandler) DeleteAsset(c *gin.Context) {
 		UserId: userID,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.NoContent(c)
 }
 
+// RefreshPrices refreshes prices for the user's assets
+func (h *AssetsHandler) RefreshPrices(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req struct {
+		AssetIDs []string `json:"asset_ids"`
+	}
+	_ = c.ShouldBindJSON(&req)
+
+	resp, err := h.proxy.Assets.RefreshAssetPrices(c.Request.Context(), &assetspb.RefreshAssetPricesRequest{
+		UserId:   userID,
+		AssetIds: req.AssetIDs,
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"updated_count": resp.UpdatedCount,
+		"failed_ids":    resp.FailedIds,
+	})
+}
+
 // GetPrice gets asset price
 func (h *AssetsHandler) GetPrice(c *gin.Context) {
 	symbol := c.Param("symbol")
@@ -159,7 +219,7 @@ func (h *AssetsHandler) GetPrice(c *gin.Context) {
 		Type:   converters.StringToAssetTypeAssets(assetType),
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -177,10 +237,90 @@ func (h *AssetsHandler) SearchAssets(c *gin.Context) {
 		Limit: 10,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.Success(c, resp.Results)
 }
 
+// CreatePriceAlert creates a price alert
+func (h *AssetsHandler) CreatePriceAlert(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req struct {
+		Symbol    string  `json:"symbol" binding:"required"`
+		Type      string  `json:"type"`
+		Direction string  `json:"direction" binding:"required"`
+		Threshold float64 `json:"threshold" binding:"required"`
+		OneShot   *bool   `json:"one_shot"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	oneShot := true
+	if req.OneShot != nil {
+		oneShot = *req.OneShot
+	}
+
+	resp, err := h.proxy.Assets.CreatePriceAlert(c.Request.Context(), &assetspb.CreatePriceAlertRequest{
+		UserId:    userID,
+		Symbol:    req.Symbol,
+		Type:      converters.StringToAssetTypeAssets(req.Type),
+		Direction: req.Direction,
+		Threshold: req.Threshold,
+		OneShot:   oneShot,
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Created(c, resp)
+}
+
+// ListPriceAlerts lists the user's price alerts
+func (h *AssetsHandler) ListPriceAlerts(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Assets.ListPriceAlerts(c.Request.Context(), &assetspb.ListPriceAlertsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp.Alerts)
+}
+
+// DeletePriceAlert deletes a price alert
+func (h *AssetsHandler) DeletePriceAlert(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	_, err := h.proxy.Assets.DeletePriceAlert(c.Request.Context(), &assetspb.DeletePriceAlertRequest{
+		Id:     c.Param("id"),
+		UserId: userID,
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
@@ -3,6 +3,9 @@ package handlers
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"math"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/auth"
@@ -10,6 +13,9 @@ import (
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	authpb "github.com/radmickey/money-control/backend/proto/auth"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // AuthHandler handles auth-related requests
@@ -44,7 +50,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -56,7 +62,7 @@ func (h *AuthHandler) Register(c *gin.Context) {
 		BaseCurrency: req.BaseCurrency,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -77,7 +83,7 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -86,6 +92,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		Password: req.Password,
 	})
 	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.ResourceExhausted {
+			if retryAfter := retryDelay(st); retryAfter > 0 {
+				c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			}
+			utils.Locked(c, "Too many failed login attempts. Try again later")
+			return
+		}
 		utils.Unauthorized(c, "Invalid email or password")
 		return
 	}
@@ -106,7 +119,7 @@ func (h *AuthHandler) RefreshToken(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -178,15 +191,66 @@ func (h *AuthHandler) GoogleCallback(c *gin.Context) {
 	c.Redirect(302, redirectURL)
 }
 
+// AppleAuthURL returns the Apple Sign-In URL and redirects the user
+func (h *AuthHandler) AppleAuthURL(c *gin.Context) {
+	// State is generated and stored by the auth service
+	resp, err := h.proxy.Auth.GetAppleAuthURL(c.Request.Context(), &authpb.GetAppleAuthURLRequest{})
+	if err != nil {
+		if status.Code(err) == codes.FailedPrecondition {
+			utils.ServiceUnavailable(c, "Apple Sign-In is not configured")
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	// Check if client wants JSON response or redirect
+	if c.Query("redirect") == "false" {
+		utils.Success(c, gin.H{"url": resp.Url})
+		return
+	}
+
+	// Redirect to Apple
+	c.Redirect(302, resp.Url)
+}
+
+// AppleCallback handles the Apple Sign-In callback (delivered as a form POST)
+func (h *AuthHandler) AppleCallback(c *gin.Context) {
+	code := c.PostForm("code")
+	if code == "" {
+		utils.BadRequest(c, "Authorization code is required")
+		return
+	}
+
+	resp, err := h.proxy.Auth.AppleAuth(c.Request.Context(), &authpb.AppleAuthRequest{
+		Code:  code,
+		State: c.PostForm("state"),
+	})
+	if err != nil {
+		// Redirect to frontend with error
+		c.Redirect(302, "http://localhost:3000/login?error=apple_auth_failed")
+		return
+	}
+
+	// Redirect to frontend with tokens, mirroring the Google flow
+	redirectURL := "http://localhost:3000/auth/callback?access_token=" + resp.AccessToken +
+		"&refresh_token=" + resp.RefreshToken
+	c.Redirect(302, redirectURL)
+}
+
 // GetProfile returns user profile
 func (h *AuthHandler) GetProfile(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	resp, err := h.proxy.Auth.GetProfile(c.Request.Context(), &authpb.GetProfileRequest{
 		UserId: userID,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -195,7 +259,11 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 
 // UpdateProfile updates user profile
 func (h *AuthHandler) UpdateProfile(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req struct {
 		FirstName    string `json:"first_name"`
@@ -204,7 +272,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -215,7 +283,7 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 		BaseCurrency: req.BaseCurrency,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -224,14 +292,18 @@ func (h *AuthHandler) UpdateProfile(c *gin.Context) {
 
 // Logout handles user logout
 func (h *AuthHandler) Logout(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req struct {
 		RefreshToken string `json:"refresh_token" binding:"required"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -240,7 +312,7 @@ func (h *AuthHandler) Logout(c *gin.Context) {
 		RefreshToken: req.RefreshToken,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -254,7 +326,7 @@ func (h *AuthHandler) TelegramAuth(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -262,7 +334,7 @@ func (h *AuthHandler) TelegramAuth(c *gin.Context) {
 		InitData: req.InitData,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -274,3 +346,13 @@ func (h *AuthHandler) TelegramAuth(c *gin.Context) {
 		"token_type":    "Bearer",
 	})
 }
+
+// retryDelay extracts the RetryInfo delay attached to a gRPC status, if any
+func retryDelay(st *status.Status) time.Duration {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
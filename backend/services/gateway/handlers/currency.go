@@ -25,7 +25,7 @@ func (h *CurrencyHandler) ListCurrencies(c *gin.Context) {
 		IncludeCrypto: includeCrypto,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -40,7 +40,7 @@ func (h *CurrencyHandler) GetRates(c *gin.Context) {
 		BaseCurrency: base,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -61,7 +61,7 @@ func (h *CurrencyHandler) GetRate(c *gin.Context) {
 		ToCurrency:   to,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -80,7 +80,7 @@ func (h *CurrencyHandler) Convert(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -90,7 +90,7 @@ func (h *CurrencyHandler) Convert(c *gin.Context) {
 		ToCurrency:   req.To,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -102,3 +102,39 @@ func (h *CurrencyHandler) Convert(c *gin.Context) {
 		"rate_used":        resp.RateUsed,
 	})
 }
+
+// ConvertDetailed converts an amount and returns rate metadata for display
+func (h *CurrencyHandler) ConvertDetailed(c *gin.Context) {
+	var req struct {
+		Amount float64 `json:"amount" binding:"required"`
+		From   string  `json:"from" binding:"required"`
+		To     string  `json:"to" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	resp, err := h.proxy.Currency.ConvertDetailed(c.Request.Context(), &currencypb.ConvertAmountRequest{
+		Amount:       req.Amount,
+		FromCurrency: req.From,
+		ToCurrency:   req.To,
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"original_amount":  resp.OriginalAmount,
+		"from_currency":    resp.FromCurrency,
+		"converted_amount": resp.ConvertedAmount,
+		"to_currency":      resp.ToCurrency,
+		"rate":             resp.Rate,
+		"inverse_rate":     resp.InverseRate,
+		"updated_at":       resp.UpdatedAt.AsTime(),
+		"stale":            resp.Stale,
+		"provider":         resp.Provider,
+	})
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/radmickey/money-control/backend/pkg/events"
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+)
+
+const (
+	// Proxies commonly drop connections idle for 30-60 seconds
+	sseHeartbeatInterval = 15 * time.Second
+	sseWriteWait         = 10 * time.Second
+)
+
+// StreamEvents streams the user's events as Server-Sent Events. A client
+// reconnecting with Last-Event-ID first receives the events it missed, as
+// far back as the event log reaches.
+func (h *LiveHandler) StreamEvents(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	if h.cache == nil {
+		utils.ServiceUnavailable(c, "Live updates are unavailable")
+		return
+	}
+
+	var lastID int64
+	if header := c.GetHeader("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil || id < 0 {
+			utils.BadRequest(c, "Invalid Last-Event-ID")
+			return
+		}
+		lastID = id
+	}
+
+	ctx := c.Request.Context()
+
+	// Subscribe before reading the log so nothing published in between is lost
+	pubsub := h.cache.SubscribeUserEvents(ctx, userID)
+	defer pubsub.Close()
+	if _, err := pubsub.Receive(ctx); err != nil {
+		utils.InternalError(c, "Failed to subscribe to events")
+		return
+	}
+
+	var backlog []events.Event
+	if lastID > 0 {
+		missed, complete, err := events.Since(ctx, h.cache, userID, lastID)
+		if err != nil {
+			utils.InternalError(c, "Failed to load missed events")
+			return
+		}
+		backlog = missed
+		if !complete {
+			backlog = append([]events.Event{{Type: events.Resync, Data: json.RawMessage("{}"), At: time.Now()}}, backlog...)
+			lastID = 0
+		}
+	}
+
+	// The stream outlives the server's read and write timeouts, so deadlines
+	// are managed per write instead
+	rc := http.NewResponseController(c.Writer)
+	_ = rc.SetReadDeadline(time.Time{})
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	// Stop nginx from buffering the stream
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	write := func(fn func(w io.Writer) error) bool {
+		_ = rc.SetWriteDeadline(time.Now().Add(sseWriteWait))
+		if err := fn(c.Writer); err != nil {
+			return false
+		}
+		return rc.Flush() == nil
+	}
+
+	// Tell the client how long to wait before reconnecting
+	if !write(func(w io.Writer) error {
+		_, err := fmt.Fprintf(w, "retry: %d\n\n", (5 * time.Second).Milliseconds())
+		return err
+	}) {
+		return
+	}
+
+	for _, event := range backlog {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			continue
+		}
+		if !write(func(w io.Writer) error { return writeSSEEvent(w, event, payload) }) {
+			return
+		}
+		if event.ID > lastID {
+			lastID = event.ID
+		}
+	}
+
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if !write(func(w io.Writer) error {
+				_, err := io.WriteString(w, ": heartbeat\n\n")
+				return err
+			}) {
+				return
+			}
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var event events.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Dropping malformed event for user %s: %v", userID, err)
+				continue
+			}
+			// Already sent from the backlog
+			if event.ID != 0 && event.ID <= lastID {
+				continue
+			}
+			if !write(func(w io.Writer) error { return writeSSEEvent(w, event, []byte(msg.Payload)) }) {
+				return
+			}
+			lastID = event.ID
+		}
+	}
+}
+
+// writeSSEEvent writes one event in text/event-stream framing
+func writeSSEEvent(w io.Writer, event events.Event, payload []byte) error {
+	if event.ID != 0 {
+		if _, err := fmt.Fprintf(w, "id: %d\n", event.ID); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, payload)
+	return err
+}
@@ -2,6 +2,8 @@ package handlers
 
 import (
 	"log"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -12,6 +14,8 @@ import (
 	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	insightspb "github.com/radmickey/money-control/backend/proto/insights"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // InsightsHandler handles insights-related requests
@@ -26,7 +30,11 @@ func NewInsightsHandler(sp *proxy.ServiceProxy) *InsightsHandler {
 
 // GetNetWorth gets current net worth with proper currency conversion
 func (h *InsightsHandler) GetNetWorth(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("baseCurrency")
 	if baseCurrency == "" {
 		baseCurrency = c.Query("currency")
@@ -40,7 +48,7 @@ func (h *InsightsHandler) GetNetWorth(c *gin.Context) {
 		PageSize: 1000,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -119,7 +127,11 @@ func (h *InsightsHandler) fetchExchangeRates(c *gin.Context, baseCurrency string
 
 // GetTrends gets net worth trends/history
 func (h *InsightsHandler) GetTrends(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := converters.DefaultCurrency(c.Query("currency"))
 	period := c.Query("period")
 	if period == "" {
@@ -132,7 +144,7 @@ func (h *InsightsHandler) GetTrends(c *gin.Context) {
 		Period:       period,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -141,7 +153,11 @@ func (h *InsightsHandler) GetTrends(c *gin.Context) {
 
 // GetAllocation gets asset allocation
 func (h *InsightsHandler) GetAllocation(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	// Support both "baseCurrency" and "currency" query params
 	baseCurrency := c.Query("baseCurrency")
 	if baseCurrency == "" {
@@ -159,7 +175,7 @@ func (h *InsightsHandler) GetAllocation(c *gin.Context) {
 		GroupBy:      groupBy,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -168,7 +184,11 @@ func (h *InsightsHandler) GetAllocation(c *gin.Context) {
 
 // GetDashboard gets dashboard summary
 func (h *InsightsHandler) GetDashboard(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := converters.DefaultCurrency(c.Query("currency"))
 
 	resp, err := h.proxy.Insights.GetDashboardSummary(c.Request.Context(), &insightspb.GetDashboardSummaryRequest{
@@ -176,16 +196,49 @@ func (h *InsightsHandler) GetDashboard(c *gin.Context) {
 		BaseCurrency: baseCurrency,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.Success(c, resp)
 }
 
+// CreateSnapshot creates a net worth snapshot on behalf of a user (service principals only)
+func (h *InsightsHandler) CreateSnapshot(c *gin.Context) {
+	if !middleware.IsServicePrincipal(c) {
+		utils.Forbidden(c, "Service API key required")
+		return
+	}
+
+	var req struct {
+		UserID       string `json:"user_id" binding:"required"`
+		BaseCurrency string `json:"base_currency"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	resp, err := h.proxy.Insights.CreateSnapshot(c.Request.Context(), &insightspb.CreateSnapshotRequest{
+		UserId:       req.UserID,
+		BaseCurrency: converters.DefaultCurrency(req.BaseCurrency),
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Created(c, resp)
+}
+
 // GetCashFlow gets cash flow
 func (h *InsightsHandler) GetCashFlow(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := converters.DefaultCurrency(c.Query("currency"))
 	period := c.Query("period")
 	if period == "" {
@@ -200,7 +253,30 @@ func (h *InsightsHandler) GetCashFlow(c *gin.Context) {
 		EndDate:      converters.ParseDate(c.Query("end_date")),
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
+
+// GetBalanceChanges gets per-category balance changes over a period
+func (h *InsightsHandler) GetBalanceChanges(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Insights.GetBalanceChanges(c.Request.Context(), &insightspb.GetBalanceChangesRequest{
+		UserId:       userID,
+		BaseCurrency: converters.DefaultCurrency(c.Query("currency")),
+		Period:       c.Query("period"),
+		StartDate:    converters.ParseDate(c.Query("start_date")),
+		EndDate:      converters.ParseDate(c.Query("end_date")),
+	})
+	if err != nil {
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -209,7 +285,11 @@ func (h *InsightsHandler) GetCashFlow(c *gin.Context) {
 
 // GetNetWorthHistory gets net worth history
 func (h *InsightsHandler) GetNetWorthHistory(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := converters.DefaultCurrency(c.Query("currency"))
 	period := c.Query("period")
 	if period == "" {
@@ -222,9 +302,47 @@ func (h *InsightsHandler) GetNetWorthHistory(c *gin.Context) {
 		Period:       period,
 		StartDate:    converters.ParseDate(c.Query("start_date")),
 		EndDate:      converters.ParseDate(c.Query("end_date")),
+		Raw:          c.Query("raw") == "true",
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
+
+// ProjectNetWorth projects net worth from the snapshot trend
+func (h *InsightsHandler) ProjectNetWorth(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	horizon := 0
+	if v := c.Query("horizon"); v != "" {
+		var err error
+		if horizon, err = strconv.Atoi(v); err != nil {
+			utils.BadRequest(c, "Invalid horizon")
+			return
+		}
+	}
+
+	resp, err := h.proxy.Insights.ProjectNetWorth(c.Request.Context(), &insightspb.ProjectNetWorthRequest{
+		UserId:        userID,
+		HorizonMonths: int32(horizon),
+		Method:        c.Query("method"),
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			utils.BadRequest(c, status.Convert(err).Message())
+		case codes.FailedPrecondition:
+			utils.Error(c, http.StatusUnprocessableEntity, utils.CodeInsufficientHistory, status.Convert(err).Message())
+		default:
+			utils.RespondError(c, err)
+		}
 		return
 	}
 
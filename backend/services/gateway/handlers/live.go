@@ -0,0 +1,342 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
+	"github.com/gorilla/websocket"
+
+	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/converters"
+	"github.com/radmickey/money-control/backend/pkg/events"
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+)
+
+const (
+	// Tickets only need to live long enough for the client to open the socket
+	wsTicketTTL = 30 * time.Second
+
+	wsWriteWait    = 10 * time.Second
+	wsPongWait     = 60 * time.Second
+	wsPingInterval = wsPongWait * 9 / 10
+	wsMaxReadSize  = 512
+
+	// A client that falls this many messages behind is disconnected
+	wsSendBuffer = 32
+)
+
+// LiveHandler pushes price and net worth updates over WebSocket
+type LiveHandler struct {
+	proxy    *proxy.ServiceProxy
+	cache    *cache.Cache
+	upgrader websocket.Upgrader
+}
+
+// NewLiveHandler creates a new live updates handler
+func NewLiveHandler(sp *proxy.ServiceProxy, c *cache.Cache) *LiveHandler {
+	return &LiveHandler{
+		proxy: sp,
+		cache: c,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+			// Connections are authenticated with a single-use ticket that
+			// can only be obtained with a bearer token, so a cross-site page
+			// can't open one on the user's behalf
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+	}
+}
+
+// IssueTicket returns a short-lived, single-use ticket for opening a
+// WebSocket connection. Browsers can't set headers on the upgrade request,
+// so the ticket is passed as a query parameter instead of the access token.
+func (h *LiveHandler) IssueTicket(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	if h.cache == nil {
+		utils.ServiceUnavailable(c, "Live updates are unavailable")
+		return
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		utils.InternalError(c, "Failed to generate ticket")
+		return
+	}
+	ticket := hex.EncodeToString(buf)
+
+	if err := h.cache.SetString(c.Request.Context(), cache.WSTicketKey(ticket), userID, wsTicketTTL); err != nil {
+		utils.InternalError(c, "Failed to store ticket")
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"ticket":     ticket,
+		"expires_in": int(wsTicketTTL.Seconds()),
+	})
+}
+
+// Connect upgrades the request to a WebSocket and streams the user's price
+// and net worth updates until the client disconnects
+func (h *LiveHandler) Connect(c *gin.Context) {
+	if h.cache == nil {
+		utils.ServiceUnavailable(c, "Live updates are unavailable")
+		return
+	}
+
+	userID, ok := h.redeemTicket(c)
+	if !ok {
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// The upgrader has already written an error response
+		return
+	}
+
+	lc := &liveConn{
+		handler:  h,
+		conn:     conn,
+		send:     make(chan []byte, wsSendBuffer),
+		userID:   userID,
+		currency: converters.DefaultCurrency(c.Query("currency")),
+	}
+	lc.serve(c.Request.Context())
+}
+
+// TicketAuth authenticates with a ticket from IssueTicket when the request
+// carries one, and with next otherwise. It lets clients that can't set
+// headers, such as browser EventSource, use bearer-protected streams.
+func (h *LiveHandler) TicketAuth(next gin.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Query("ticket") == "" || h.cache == nil {
+			next(c)
+			return
+		}
+
+		userID, ok := h.redeemTicket(c)
+		if !ok {
+			c.Abort()
+			return
+		}
+		c.Set(middleware.UserIDKey, userID)
+		c.Next()
+	}
+}
+
+// redeemTicket consumes the request's ticket and returns the user it was
+// issued to. A ticket can only be redeemed once.
+func (h *LiveHandler) redeemTicket(c *gin.Context) (string, bool) {
+	ticket := c.Query("ticket")
+	if ticket == "" {
+		utils.Unauthorized(c, "ticket is required")
+		return "", false
+	}
+
+	userID, err := h.cache.GetDelString(c.Request.Context(), cache.WSTicketKey(ticket))
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			utils.Unauthorized(c, "Invalid or expired ticket")
+			return "", false
+		}
+		utils.InternalError(c, "Failed to verify ticket")
+		return "", false
+	}
+	return userID, true
+}
+
+// liveConn is a single WebSocket connection
+type liveConn struct {
+	handler  *LiveHandler
+	conn     *websocket.Conn
+	send     chan []byte
+	userID   string
+	currency string
+
+	// Last net worth sent, to push deltas
+	netWorth    float64
+	hasNetWorth bool
+}
+
+// serve runs the connection until either side goes away. Reads happen on
+// the calling goroutine, writes and Redis events on their own.
+func (lc *liveConn) serve(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	pubsub := lc.handler.cache.SubscribeUserEvents(ctx, lc.userID)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		lc.writePump(ctx, cancel)
+	}()
+	go func() {
+		defer wg.Done()
+		lc.relayEvents(ctx, cancel, pubsub)
+	}()
+
+	lc.readPump()
+	cancel()
+	_ = pubsub.Close()
+	wg.Wait()
+	_ = lc.conn.Close()
+}
+
+// readPump discards client messages, keeping the read deadline alive on pongs.
+// It returns when the client disconnects or stops answering pings.
+func (lc *liveConn) readPump() {
+	lc.conn.SetReadLimit(wsMaxReadSize)
+	_ = lc.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	lc.conn.SetPongHandler(func(string) error {
+		return lc.conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		if _, _, err := lc.conn.NextReader(); err != nil {
+			return
+		}
+	}
+}
+
+// writePump is the only writer on the connection. It sends queued messages
+// and pings, and closes the socket when the connection is torn down so the
+// blocked reader returns.
+func (lc *liveConn) writePump(ctx context.Context, cancel context.CancelFunc) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			_ = lc.conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""), time.Now().Add(wsWriteWait))
+			_ = lc.conn.Close()
+			return
+		case msg := <-lc.send:
+			_ = lc.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+			if err := lc.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				_ = lc.conn.Close()
+				return
+			}
+		case <-ticker.C:
+			if err := lc.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				_ = lc.conn.Close()
+				return
+			}
+		}
+	}
+}
+
+// relayEvents forwards the user's events to the client, following each
+// event that can move net worth with the resulting change
+func (lc *liveConn) relayEvents(ctx context.Context, cancel context.CancelFunc, pubsub *redis.PubSub) {
+	// Start every connection with the current net worth
+	if !lc.pushNetWorth(ctx, cancel) {
+		return
+	}
+
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			var event events.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				log.Printf("Dropping malformed live event for user %s: %v", lc.userID, err)
+				continue
+			}
+			if !lc.enqueue([]byte(msg.Payload), cancel) {
+				return
+			}
+			if affectsNetWorth(event.Type) && !lc.pushNetWorth(ctx, cancel) {
+				return
+			}
+		}
+	}
+}
+
+// affectsNetWorth reports whether an event of the given type can change net worth
+func affectsNetWorth(eventType string) bool {
+	switch eventType {
+	case events.PricesRefreshed, events.BalanceUpdated,
+		events.AccountCreated, events.AccountUpdated, events.AccountDeleted,
+		events.SubAccountCreated, events.SubAccountDeleted,
+		events.AssetCreated, events.AssetUpdated, events.AssetDeleted:
+		return true
+	}
+	return false
+}
+
+// pushNetWorth sends the user's net worth if it changed since the last push.
+// It reports false once the connection is being torn down.
+func (lc *liveConn) pushNetWorth(ctx context.Context, cancel context.CancelFunc) bool {
+	resp, err := lc.handler.proxy.Accounts.GetUserNetWorth(ctx, &accountspb.GetUserNetWorthRequest{
+		UserId:       lc.userID,
+		BaseCurrency: lc.currency,
+	})
+	if err != nil {
+		if ctx.Err() != nil {
+			return false
+		}
+		log.Printf("Warning: live net worth failed for user %s: %v", lc.userID, err)
+		return true
+	}
+
+	total := resp.GetTotalNetWorth()
+	if lc.hasNetWorth && total == lc.netWorth {
+		return true
+	}
+
+	update := events.NetWorthData{TotalNetWorth: total, Currency: lc.currency}
+	if lc.hasNetWorth {
+		update.Change = total - lc.netWorth
+	}
+	lc.netWorth, lc.hasNetWorth = total, true
+
+	data, err := json.Marshal(update)
+	if err != nil {
+		return true
+	}
+	msg, err := json.Marshal(events.Event{Type: events.NetWorthUpdated, Data: data, At: time.Now()})
+	if err != nil {
+		return true
+	}
+	return lc.enqueue(msg, cancel)
+}
+
+// enqueue queues a message without blocking. A client too slow to keep up
+// is disconnected rather than buffered without bound; it can reconnect and
+// start again from the current state.
+func (lc *liveConn) enqueue(msg []byte, cancel context.CancelFunc) bool {
+	select {
+	case lc.send <- msg:
+		return true
+	default:
+		log.Printf("Disconnecting slow live client for user %s", lc.userID)
+		cancel()
+		return false
+	}
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/converters"
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	insightspb "github.com/radmickey/money-control/backend/proto/insights"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+)
+
+const (
+	overviewCacheTTL           = 30 * time.Second
+	overviewRecentTransactions = 10
+)
+
+// OverviewHandler aggregates the data needed to render the home screen
+type OverviewHandler struct {
+	proxy *proxy.ServiceProxy
+	cache *cache.Cache
+}
+
+// NewOverviewHandler creates a new overview handler
+func NewOverviewHandler(sp *proxy.ServiceProxy, c *cache.Cache) *OverviewHandler {
+	return &OverviewHandler{proxy: sp, cache: c}
+}
+
+// Overview is the merged home screen payload
+type Overview struct {
+	Currency           string                               `json:"currency"`
+	Dashboard          *insightspb.DashboardSummaryResponse `json:"dashboard,omitempty"`
+	NetWorth           *accountspb.NetWorthResponse         `json:"net_worth,omitempty"`
+	RecentTransactions []*transactionspb.Transaction        `json:"recent_transactions,omitempty"`
+	Warnings           []string                             `json:"warnings,omitempty"`
+	GeneratedAt        time.Time                            `json:"generated_at"`
+}
+
+// overviewSource loads one part of the overview
+type overviewSource struct {
+	name  string
+	fetch func(ctx context.Context) error
+}
+
+// GetOverview fetches the dashboard, net worth and recent transactions concurrently.
+// If an upstream fails the remaining data is returned with a warning.
+func (h *OverviewHandler) GetOverview(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	baseCurrency := converters.DefaultCurrency(c.Query("currency"))
+	ctx := c.Request.Context()
+
+	cacheKey := fmt.Sprintf("overview:%s:%s", userID, baseCurrency)
+	if h.cache != nil {
+		var cached Overview
+		if err := h.cache.Get(ctx, cacheKey, &cached); err == nil {
+			utils.Success(c, cached)
+			return
+		}
+	}
+
+	overview := Overview{
+		Currency:    baseCurrency,
+		GeneratedAt: time.Now(),
+	}
+
+	sources := []overviewSource{
+		{name: "dashboard", fetch: func(ctx context.Context) error {
+			resp, err := h.proxy.Insights.GetDashboardSummary(ctx, &insightspb.GetDashboardSummaryRequest{
+				UserId:       userID,
+				BaseCurrency: baseCurrency,
+			})
+			if err != nil {
+				return err
+			}
+			overview.Dashboard = resp
+			return nil
+		}},
+		{name: "net worth", fetch: func(ctx context.Context) error {
+			resp, err := h.proxy.Accounts.GetUserNetWorth(ctx, &accountspb.GetUserNetWorthRequest{
+				UserId:       userID,
+				BaseCurrency: baseCurrency,
+			})
+			if err != nil {
+				return err
+			}
+			overview.NetWorth = resp
+			return nil
+		}},
+		{name: "recent transactions", fetch: func(ctx context.Context) error {
+			resp, err := h.proxy.Transactions.ListTransactions(ctx, &transactionspb.ListTransactionsRequest{
+				UserId:   userID,
+				Page:     1,
+				PageSize: overviewRecentTransactions,
+				SortBy:   "date",
+				SortDesc: true,
+			})
+			if err != nil {
+				return err
+			}
+			overview.RecentTransactions = resp.Transactions
+			return nil
+		}},
+	}
+
+	var mu sync.Mutex
+	failed := 0
+
+	// Errors are collected as warnings, so the group never cancels sibling calls
+	g, gctx := errgroup.WithContext(ctx)
+	for _, source := range sources {
+		g.Go(func() error {
+			if err := source.fetch(gctx); err != nil {
+				log.Printf("Warning: overview %s failed for user %s: %v", source.name, userID, err)
+				mu.Lock()
+				overview.Warnings = append(overview.Warnings, source.name+" unavailable")
+				failed++
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	if failed == len(sources) {
+		utils.InternalError(c, "Failed to load overview")
+		return
+	}
+
+	// Only cache complete results so a transient failure is not served for the whole TTL
+	if h.cache != nil && failed == 0 {
+		h.storeOverview(ctx, cacheKey, &overview)
+	}
+
+	utils.Success(c, overview)
+}
+
+func (h *OverviewHandler) storeOverview(ctx context.Context, key string, overview *Overview) {
+	if err := h.cache.Set(ctx, key, overview, overviewCacheTTL); err != nil {
+		log.Printf("Warning: failed to cache overview: %v", err)
+	}
+}
@@ -0,0 +1,203 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+
+	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	insightspb "github.com/radmickey/money-control/backend/proto/insights"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+var errUnavailable = errors.New("connection refused")
+
+type fakeInsights struct {
+	insightspb.InsightsServiceClient
+	err error
+}
+
+func (f *fakeInsights) GetDashboardSummary(ctx context.Context, req *insightspb.GetDashboardSummaryRequest, opts ...grpc.CallOption) (*insightspb.DashboardSummaryResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &insightspb.DashboardSummaryResponse{NetWorth: 1000}, nil
+}
+
+type fakeAccounts struct {
+	accountspb.AccountsServiceClient
+	err error
+}
+
+func (f *fakeAccounts) GetUserNetWorth(ctx context.Context, req *accountspb.GetUserNetWorthRequest, opts ...grpc.CallOption) (*accountspb.NetWorthResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &accountspb.NetWorthResponse{TotalNetWorth: 1000, Currency: req.BaseCurrency}, nil
+}
+
+type fakeTransactions struct {
+	transactionspb.TransactionsServiceClient
+	err error
+}
+
+func (f *fakeTransactions) ListTransactions(ctx context.Context, req *transactionspb.ListTransactionsRequest, opts ...grpc.CallOption) (*transactionspb.ListTransactionsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &transactionspb.ListTransactionsResponse{Transactions: []*transactionspb.Transaction{{Id: "tx-1"}}}, nil
+}
+
+// getOverview serves GET /me/overview for user-1 with the given upstreams
+func getOverview(t *testing.T, sp *proxy.ServiceProxy, c *cache.Cache) *httptest.ResponseRecorder {
+	t.Helper()
+	router := gin.New()
+	router.GET("/me/overview", func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, "user-1")
+	}, NewOverviewHandler(sp, c).GetOverview)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/me/overview?currency=USD", nil))
+	return w
+}
+
+// overviewData decodes the response data as raw fields, so the test sees the
+// JSON names clients do
+func overviewData(t *testing.T, w *httptest.ResponseRecorder) map[string]json.RawMessage {
+	t.Helper()
+	var resp struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("decode %q: %v", w.Body.String(), err)
+	}
+	return resp.Data
+}
+
+func TestGetOverview(t *testing.T) {
+	tests := []struct {
+		name         string
+		dashboard    error
+		netWorth     error
+		transactions error
+		wantStatus   int
+		wantFields   []string
+		wantWarnings []string
+	}{
+		{
+			name:       "all upstreams up",
+			wantStatus: http.StatusOK,
+			wantFields: []string{"currency", "dashboard", "generated_at", "net_worth", "recent_transactions"},
+		},
+		{
+			name:         "one upstream down",
+			netWorth:     errUnavailable,
+			wantStatus:   http.StatusOK,
+			wantFields:   []string{"currency", "dashboard", "generated_at", "recent_transactions", "warnings"},
+			wantWarnings: []string{"net worth unavailable"},
+		},
+		{
+			name:         "two upstreams down",
+			dashboard:    errUnavailable,
+			transactions: errUnavailable,
+			wantStatus:   http.StatusOK,
+			wantFields:   []string{"currency", "generated_at", "net_worth", "warnings"},
+		},
+		{
+			name:         "every upstream down",
+			dashboard:    errUnavailable,
+			netWorth:     errUnavailable,
+			transactions: errUnavailable,
+			wantStatus:   http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := getOverview(t, &proxy.ServiceProxy{
+				Insights:     &fakeInsights{err: tt.dashboard},
+				Accounts:     &fakeAccounts{err: tt.netWorth},
+				Transactions: &fakeTransactions{err: tt.transactions},
+			}, nil)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d; body %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			data := overviewData(t, w)
+			var fields []string
+			for _, field := range []string{"currency", "dashboard", "generated_at", "net_worth", "recent_transactions", "warnings"} {
+				if _, ok := data[field]; ok {
+					fields = append(fields, field)
+				}
+			}
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Errorf("fields = %v, want %v; body %s", fields, tt.wantFields, w.Body)
+			}
+			if len(data) != len(fields) {
+				t.Errorf("unexpected fields in %s", w.Body)
+			}
+
+			if tt.wantWarnings != nil {
+				var warnings []string
+				if err := json.Unmarshal(data["warnings"], &warnings); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+					t.Errorf("warnings = %v, want %v", warnings, tt.wantWarnings)
+				}
+			}
+		})
+	}
+}
+
+func TestGetOverviewCachesOnlyCompleteResults(t *testing.T) {
+	mr := miniredis.RunT(t)
+	c, err := cache.New(cache.Config{URL: "redis://" + mr.Addr()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	accounts := &fakeAccounts{err: errUnavailable}
+	sp := &proxy.ServiceProxy{
+		Insights:     &fakeInsights{},
+		Accounts:     accounts,
+		Transactions: &fakeTransactions{},
+	}
+
+	getOverview(t, sp, c)
+	if keys := mr.Keys(); len(keys) != 0 {
+		t.Fatalf("partial overview cached under %v", keys)
+	}
+
+	accounts.err = nil
+	getOverview(t, sp, c)
+	if keys := mr.Keys(); len(keys) != 1 {
+		t.Fatalf("cached keys = %v, want the complete overview", keys)
+	}
+
+	// Served from the cache even once an upstream goes down again
+	accounts.err = errUnavailable
+	data := overviewData(t, getOverview(t, sp, c))
+	if _, ok := data["warnings"]; ok {
+		t.Errorf("got warnings %s, want the cached complete overview", data["warnings"])
+	}
+}
@@ -0,0 +1,37 @@
+package handlers
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxPageSize matches the cap applied by database.Paginate in the services
+const maxPageSize = 100
+
+// transactionSortFields are the columns clients may sort transactions by
+var transactionSortFields = map[string]bool{
+	"date":       true,
+	"amount":     true,
+	"created_at": true,
+	"merchant":   true,
+	"category":   true,
+}
+
+// parsePagination reads page and page_size from the query string, clamped to sane values
+func parsePagination(c *gin.Context, defaultPageSize int) (int32, int32) {
+	page, err := strconv.Atoi(c.Query("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	pageSize, err := strconv.Atoi(c.Query("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	return int32(page), int32(pageSize)
+}
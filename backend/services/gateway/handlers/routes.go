@@ -1,22 +1,37 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
 	"github.com/radmickey/money-control/backend/pkg/auth"
+	"github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
 )
 
 // RegisterRoutes registers all API routes
-func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth.JWTManager, oauthManager *auth.OAuthManager) {
+func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, redisCache *cache.Cache, tokenValidator middleware.TokenValidator, oauthManager *auth.OAuthManager, apiKeyHashes []string) {
 	authHandler := NewAuthHandler(sp, oauthManager)
 	accountsHandler := NewAccountsHandler(sp)
 	transactionsHandler := NewTransactionsHandler(sp)
 	assetsHandler := NewAssetsHandler(sp)
 	currencyHandler := NewCurrencyHandler(sp)
 	insightsHandler := NewInsightsHandler(sp)
+	overviewHandler := NewOverviewHandler(sp, redisCache)
+	liveHandler := NewLiveHandler(sp, redisCache)
+
+	var idempotencyClient *redis.Client
+	if redisCache != nil {
+		idempotencyClient = redisCache.Client()
+	}
+
+	authMiddleware := middleware.AuthMiddleware(tokenValidator)
 
-	authMiddleware := middleware.AuthMiddleware(jwtManager)
+	// Idempotency-Key support for create endpoints that clients retry:
+	// POST /accounts, POST /transactions and POST /assets
+	idempotency := middleware.IdempotencyMiddleware(idempotencyClient, "gateway", 24*time.Hour)
 
 	// Auth routes (public)
 	authRoutes := r.Group("/auth")
@@ -26,6 +41,8 @@ func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth
 		authRoutes.POST("/refresh", authHandler.RefreshToken)
 		authRoutes.GET("/google", authHandler.GoogleAuthURL)
 		authRoutes.GET("/google/callback", authHandler.GoogleCallback)
+		authRoutes.GET("/apple", authHandler.AppleAuthURL)
+		authRoutes.POST("/apple/callback", authHandler.AppleCallback)
 		authRoutes.POST("/telegram", authHandler.TelegramAuth)
 	}
 
@@ -42,32 +59,41 @@ func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth
 	accountsRoutes := r.Group("/accounts")
 	accountsRoutes.Use(authMiddleware)
 	{
-		accountsRoutes.POST("", accountsHandler.CreateAccount)
+		accountsRoutes.POST("", idempotency, accountsHandler.CreateAccount)
 		accountsRoutes.GET("", accountsHandler.ListAccounts)
+		accountsRoutes.PUT("/reorder", accountsHandler.ReorderAccounts)
 		accountsRoutes.GET("/:id", accountsHandler.GetAccount)
 		accountsRoutes.PUT("/:id", accountsHandler.UpdateAccount)
 		accountsRoutes.DELETE("/:id", accountsHandler.DeleteAccount)
+		accountsRoutes.POST("/:id/archive", accountsHandler.ArchiveAccount)
+		accountsRoutes.POST("/:id/unarchive", accountsHandler.UnarchiveAccount)
+		accountsRoutes.POST("/:id/undelete", accountsHandler.UndeleteAccount)
 		accountsRoutes.POST("/:id/sub-accounts", accountsHandler.CreateSubAccount)
 		accountsRoutes.GET("/:id/sub-accounts", accountsHandler.ListSubAccounts)
+		accountsRoutes.PUT("/:id/sub-accounts/reorder", accountsHandler.ReorderSubAccounts)
 	}
 
 	// Sub-accounts routes (protected)
 	subAccountsRoutes := r.Group("/sub-accounts")
 	subAccountsRoutes.Use(authMiddleware)
 	{
+		subAccountsRoutes.POST("/bulk-balance", idempotency, accountsHandler.BulkUpdateSubAccountBalances)
 		subAccountsRoutes.GET("/:id", accountsHandler.GetSubAccount)
 		subAccountsRoutes.PUT("/:id", accountsHandler.UpdateSubAccount)
 		subAccountsRoutes.DELETE("/:id", accountsHandler.DeleteSubAccount)
 		subAccountsRoutes.PATCH("/:id/balance", accountsHandler.UpdateSubAccountBalance)
+		subAccountsRoutes.GET("/:id/balance-history", accountsHandler.GetSubAccountBalanceHistory)
 	}
 
 	// Transactions routes (protected)
 	transactionsRoutes := r.Group("/transactions")
 	transactionsRoutes.Use(authMiddleware)
 	{
-		transactionsRoutes.POST("", transactionsHandler.CreateTransaction)
+		transactionsRoutes.POST("", idempotency, transactionsHandler.CreateTransaction)
 		transactionsRoutes.GET("", transactionsHandler.ListTransactions)
 		transactionsRoutes.GET("/summary", transactionsHandler.GetSummary)
+		transactionsRoutes.GET("/recurring", transactionsHandler.DetectRecurring)
+		transactionsRoutes.GET("/report", transactionsHandler.GetSpendingReport)
 		transactionsRoutes.GET("/:id", transactionsHandler.GetTransaction)
 		transactionsRoutes.PUT("/:id", transactionsHandler.UpdateTransaction)
 		transactionsRoutes.DELETE("/:id", transactionsHandler.DeleteTransaction)
@@ -77,13 +103,23 @@ func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth
 	assetsRoutes := r.Group("/assets")
 	assetsRoutes.Use(authMiddleware)
 	{
-		assetsRoutes.POST("", assetsHandler.CreateAsset)
+		assetsRoutes.POST("", idempotency, assetsHandler.CreateAsset)
 		assetsRoutes.GET("", assetsHandler.ListAssets)
+		assetsRoutes.POST("/refresh-prices", assetsHandler.RefreshPrices)
 		assetsRoutes.GET("/:id", assetsHandler.GetAsset)
 		assetsRoutes.PUT("/:id", assetsHandler.UpdateAsset)
 		assetsRoutes.DELETE("/:id", assetsHandler.DeleteAsset)
 	}
 
+	// Price alert routes (protected)
+	alertsRoutes := r.Group("/alerts")
+	alertsRoutes.Use(authMiddleware)
+	{
+		alertsRoutes.POST("", assetsHandler.CreatePriceAlert)
+		alertsRoutes.GET("", assetsHandler.ListPriceAlerts)
+		alertsRoutes.DELETE("/:id", assetsHandler.DeletePriceAlert)
+	}
+
 	// Prices routes (public)
 	pricesRoutes := r.Group("/prices")
 	{
@@ -100,6 +136,7 @@ func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth
 		currencyRoutes.GET("/rates/:base", currencyHandler.GetRates)
 		currencyRoutes.GET("/rate/:from/:to", currencyHandler.GetRate)
 		currencyRoutes.POST("/convert", currencyHandler.Convert)
+		currencyRoutes.POST("/convert/detailed", currencyHandler.ConvertDetailed)
 	}
 
 	// Insights routes (protected)
@@ -108,6 +145,8 @@ func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth
 	{
 		insightsRoutes.GET("/net-worth", insightsHandler.GetNetWorth)
 		insightsRoutes.GET("/net-worth/history", insightsHandler.GetNetWorthHistory)
+		insightsRoutes.GET("/net-worth/projection", insightsHandler.ProjectNetWorth)
+		insightsRoutes.GET("/balance-changes", insightsHandler.GetBalanceChanges)
 		insightsRoutes.GET("/trends", insightsHandler.GetTrends)
 		insightsRoutes.GET("/allocation", insightsHandler.GetAllocation)
 		insightsRoutes.GET("/dashboard", insightsHandler.GetDashboard)
@@ -116,4 +155,23 @@ func RegisterRoutes(r *gin.RouterGroup, sp *proxy.ServiceProxy, jwtManager *auth
 
 	// Net worth convenience route (protected)
 	r.GET("/net-worth", authMiddleware, accountsHandler.GetNetWorth)
+
+	// Home screen aggregation route (protected)
+	r.GET("/me/overview", authMiddleware, overviewHandler.GetOverview)
+
+	// Live updates: a ticket is issued to an authenticated user, then
+	// exchanged for the WebSocket connection
+	r.POST("/ws/ticket", authMiddleware, liveHandler.IssueTicket)
+	r.GET("/ws", liveHandler.Connect)
+
+	// Server-Sent Events stream, for clients without WebSocket support.
+	// EventSource can't set headers either, so a ticket is accepted too.
+	r.GET("/events", liveHandler.TicketAuth(authMiddleware), liveHandler.StreamEvents)
+
+	// System routes (service API key)
+	systemRoutes := r.Group("/system")
+	systemRoutes.Use(middleware.APIKeyMiddleware(apiKeyHashes))
+	{
+		systemRoutes.POST("/snapshots", insightsHandler.CreateSnapshot)
+	}
 }
@@ -7,6 +7,8 @@ import (
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // TransactionsHandler handles transactions-related requests
@@ -21,40 +23,50 @@ func NewTransactionsHandler(sp *proxy.ServiceProxy) *TransactionsHandler {
 
 // CreateTransaction creates a new transaction
 func (h *TransactionsHandler) CreateTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req struct {
-		AccountID      string  `json:"account_id"`
-		SubAccountID   string  `json:"sub_account_id"`
-		Amount         float64 `json:"amount" binding:"required"`
-		Currency       string  `json:"currency"`
-		Type           string  `json:"type" binding:"required"`
-		Category       string  `json:"category"`
-		CustomCategory string  `json:"custom_category"`
-		Description    string  `json:"description"`
-		Merchant       string  `json:"merchant"`
-		Date           string  `json:"date"`
+		AccountID            string  `json:"account_id"`
+		SubAccountID         string  `json:"sub_account_id"`
+		TransferToSubAccount string  `json:"transfer_to_sub_account_id"`
+		Amount               float64 `json:"amount" binding:"required"`
+		Currency             string  `json:"currency"`
+		Type                 string  `json:"type" binding:"required"`
+		Category             string  `json:"category"`
+		CustomCategory       string  `json:"custom_category"`
+		Description          string  `json:"description"`
+		Merchant             string  `json:"merchant"`
+		Date                 string  `json:"date"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	resp, err := h.proxy.Transactions.CreateTransaction(c.Request.Context(), &transactionspb.CreateTransactionRequest{
-		UserId:         userID,
-		SubAccountId:   req.SubAccountID,
-		Amount:         req.Amount,
-		Currency:       converters.DefaultCurrency(req.Currency),
-		Type:           converters.StringToTransactionType(req.Type),
-		Category:       converters.StringToTransactionCategory(req.Category),
-		CustomCategory: req.CustomCategory,
-		Description:    req.Description,
-		Merchant:       req.Merchant,
-		Date:           converters.ParseDate(req.Date),
+		UserId:                 userID,
+		SubAccountId:           req.SubAccountID,
+		TransferToSubAccountId: req.TransferToSubAccount,
+		Amount:                 req.Amount,
+		Currency:               req.Currency,
+		Type:                   converters.StringToTransactionType(req.Type),
+		Category:               converters.StringToTransactionCategory(req.Category),
+		CustomCategory:         req.CustomCategory,
+		Description:            req.Description,
+		Merchant:               req.Merchant,
+		Date:                   converters.ParseDate(req.Date),
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.InvalidArgument {
+			utils.BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -63,31 +75,55 @@ func (h *TransactionsHandler) CreateTransaction(c *gin.Context) {
 
 // ListTransactions lists transactions
 func (h *TransactionsHandler) ListTransactions(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	subAccountID := c.Query("sub_account_id")
+	page, pageSize := parsePagination(c, 50)
+
+	sortBy := c.DefaultQuery("sort_by", "date")
+	if !transactionSortFields[sortBy] {
+		utils.BadRequest(c, "Invalid sort_by field")
+		return
+	}
+	sortDesc := c.DefaultQuery("sort_desc", "true") != "false"
 
 	resp, err := h.proxy.Transactions.ListTransactions(c.Request.Context(), &transactionspb.ListTransactionsRequest{
 		UserId:       userID,
 		SubAccountId: subAccountID,
-		Page:         1,
-		PageSize:     50,
-		SortBy:       "date",
-		SortDesc:     true,
+		Page:         page,
+		PageSize:     pageSize,
+		SortBy:       sortBy,
+		SortDesc:     sortDesc,
+		Cursor:       c.Query("cursor"),
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.InvalidArgument {
+			utils.BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.Success(c, gin.H{
 		"transactions": resp.Transactions,
 		"total":        resp.Total,
+		"page":         resp.Page,
+		"page_size":    resp.PageSize,
+		"next_cursor":  resp.NextCursor,
 	})
 }
 
 // GetTransaction gets a transaction
 func (h *TransactionsHandler) GetTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	resp, err := h.proxy.Transactions.GetTransaction(c.Request.Context(), &transactionspb.GetTransactionRequest{
@@ -104,7 +140,11 @@ func (h *TransactionsHandler) GetTransaction(c *gin.Context) {
 
 // UpdateTransaction updates a transaction
 func (h *TransactionsHandler) UpdateTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req struct {
@@ -117,7 +157,7 @@ func (h *TransactionsHandler) UpdateTransaction(c *gin.Context) {
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -129,10 +169,14 @@ func (h *TransactionsHandler) UpdateTransaction(c *gin.Context) {
 		Category:    converters.StringToTransactionCategory(req.Category),
 		Description: req.Description,
 		Date:        converters.ParseDate(req.Date),
-		Currency:    converters.DefaultCurrency(req.Currency),
+		Currency:    req.Currency,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		if status.Code(err) == codes.InvalidArgument {
+			utils.BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -141,7 +185,11 @@ func (h *TransactionsHandler) UpdateTransaction(c *gin.Context) {
 
 // DeleteTransaction deletes a transaction
 func (h *TransactionsHandler) DeleteTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	_, err := h.proxy.Transactions.DeleteTransaction(c.Request.Context(), &transactionspb.DeleteTransactionRequest{
@@ -149,7 +197,7 @@ func (h *TransactionsHandler) DeleteTransaction(c *gin.Context) {
 		UserId: userID,
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
@@ -158,7 +206,11 @@ func (h *TransactionsHandler) DeleteTransaction(c *gin.Context) {
 
 // GetSummary gets transaction summary
 func (h *TransactionsHandler) GetSummary(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	resp, err := h.proxy.Transactions.GetTransactionsSummary(c.Request.Context(), &transactionspb.GetTransactionsSummaryRequest{
 		UserId:       userID,
@@ -167,10 +219,54 @@ func (h *TransactionsHandler) GetSummary(c *gin.Context) {
 		BaseCurrency: converters.DefaultCurrency(c.Query("currency")),
 	})
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		utils.RespondError(c, err)
 		return
 	}
 
 	utils.Success(c, resp)
 }
 
+// DetectRecurring lists recurring transactions and upcoming bills
+func (h *TransactionsHandler) DetectRecurring(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Transactions.DetectRecurring(c.Request.Context(), &transactionspb.DetectRecurringRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp.Recurring)
+}
+
+// GetSpendingReport compares spending per category with the previous period
+func (h *TransactionsHandler) GetSpendingReport(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	resp, err := h.proxy.Transactions.GetSpendingReport(c.Request.Context(), &transactionspb.GetSpendingReportRequest{
+		UserId:       userID,
+		Period:       c.Query("period"),
+		Date:         converters.ParseDate(c.Query("date")),
+		BaseCurrency: converters.DefaultCurrency(c.Query("currency")),
+	})
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			utils.BadRequest(c, status.Convert(err).Message())
+			return
+		}
+		utils.RespondError(c, err)
+		return
+	}
+
+	utils.Success(c, resp)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
@@ -14,8 +15,10 @@ import (
 	"github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
 	"github.com/radmickey/money-control/backend/pkg/resilience"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
 	"github.com/radmickey/money-control/backend/services/gateway/handlers"
 	"github.com/radmickey/money-control/backend/services/gateway/proxy"
 )
@@ -24,10 +27,23 @@ const version = "1.0.0"
 
 func main() {
 	// Load configuration
-	cfg, err := config.Load()
+	cfg, err := config.LoadForService("GATEWAY")
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "gateway",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Initialize health checker
 	healthChecker := health.NewHealthChecker(version)
@@ -84,35 +100,69 @@ func main() {
 		}
 	}
 
+	// Verify tokens locally by default, or through the auth service (AUTH_VALIDATION_MODE=remote)
+	var tokenVersions *auth.TokenVersionStore
+	if redisCache != nil {
+		tokenVersions, err = middleware.NewTokenVersionStore(cfg, redisCache.Client())
+		if err != nil {
+			log.Fatalf("Failed to create token version store: %v", err)
+		}
+	}
+	tokenValidator, err := middleware.NewTokenValidator(cfg, jwtManager, tokenVersions)
+	if err != nil {
+		log.Fatalf("Failed to create token validator: %v", err)
+	}
+
 	// Setup Gin router
 	if !cfg.Debug {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
 	router := gin.New()
+	logConfig := middleware.NewLoggingConfig(cfg)
+	drain := &drainState{}
 
 	// Global middleware
-	router.Use(gin.Recovery())
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
+	router.Use(drain.trackRequests())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
+	router.Use(middleware.BodyLimitMiddleware(middleware.BodyLimitConfig{
+		MaxBytes:     cfg.MaxBodyBytes,
+		ContentTypes: []string{"application/json"},
+		Routes: map[string]middleware.BodyLimitConfig{
+			// Apple posts the sign-in result as a form
+			"POST /api/v1/auth/apple/callback": {ContentTypes: []string{"application/x-www-form-urlencoded", "multipart/form-data"}},
+		},
+	}))
 
 	// Only enable rate limiting if Redis is available
 	if rateLimiter != nil {
 		router.Use(middleware.RateLimitMiddleware(rateLimiter, middleware.RateLimitConfig{
 			Requests: 100,
 			Window:   time.Minute,
+			Routes: map[string]middleware.RateLimitConfig{
+				"POST /api/v1/auth/login":            {Requests: 10, Window: time.Minute},
+				"POST /api/v1/auth/register":         {Requests: 5, Window: time.Minute},
+				"POST /api/v1/assets/refresh-prices": {Requests: 5, Window: time.Minute},
+			},
 		}))
 	}
 
 	// Health endpoints
-	registerHealthEndpoints(router, healthChecker, redisCache)
+	registerHealthEndpoints(router, healthChecker, redisCache, drain, cfg.AdminToken)
+
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 
 	// API v1 routes
 	v1 := router.Group("/api/v1")
 
 	// Register route handlers
-	handlers.RegisterRoutes(v1, serviceProxy, jwtManager, oauthManager)
+	handlers.RegisterRoutes(v1, serviceProxy, redisCache, tokenValidator, oauthManager, cfg.APIKeyHashes)
 
 	// Start HTTP server
 	httpServer := &http.Server{
@@ -135,6 +185,11 @@ func main() {
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
+	// Fail readiness first and give load balancers time to deregister us
+	drain.startDraining()
+	log.Printf("Draining gateway for %s (%d active requests)...", cfg.ShutdownGracePeriod, drain.activeRequests())
+	time.Sleep(cfg.ShutdownGracePeriod)
+
 	log.Println("Shutting down gateway...")
 
 	// Graceful shutdown
@@ -149,7 +204,7 @@ func main() {
 }
 
 // registerHealthEndpoints registers health check endpoints
-func registerHealthEndpoints(router *gin.Engine, healthChecker *health.HealthChecker, redisCache *cache.Cache) {
+func registerHealthEndpoints(router *gin.Engine, healthChecker *health.HealthChecker, redisCache *cache.Cache, drain *drainState, adminToken string) {
 	// Basic health check (liveness probe)
 	router.GET("/health", func(c *gin.Context) {
 		check := healthChecker.Liveness(c.Request.Context())
@@ -163,6 +218,14 @@ func registerHealthEndpoints(router *gin.Engine, healthChecker *health.HealthChe
 
 	// Readiness probe - checks all dependencies
 	router.GET("/ready", func(c *gin.Context) {
+		if drain.isDraining() {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":   "draining",
+				"draining": true,
+			})
+			return
+		}
+
 		report := healthChecker.Readiness(c.Request.Context())
 
 		statusCode := http.StatusOK
@@ -183,6 +246,19 @@ func registerHealthEndpoints(router *gin.Engine, healthChecker *health.HealthChe
 		c.JSON(http.StatusOK, gin.H{
 			"health":           report,
 			"circuit_breakers": cbStats,
+			"active_requests":  drain.activeRequests(),
+			"draining":         drain.isDraining(),
+		})
+	})
+
+	// Start draining: readiness fails so load balancers stop routing new traffic
+	router.POST("/health/drain", middleware.AdminTokenMiddleware(adminToken), func(c *gin.Context) {
+		if !drain.startDraining() {
+			log.Println("Gateway draining requested")
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"draining":        true,
+			"active_requests": drain.activeRequests(),
 		})
 	})
 
@@ -191,4 +267,20 @@ func registerHealthEndpoints(router *gin.Engine, healthChecker *health.HealthChe
 		stats := resilience.GlobalManager.AllStats()
 		c.JSON(http.StatusOK, stats)
 	})
+
+	// Manually close a circuit breaker once the downstream has recovered
+	router.POST("/health/circuits/:name/reset", middleware.AdminTokenMiddleware(adminToken), func(c *gin.Context) {
+		name := c.Param("name")
+
+		cb, err := resilience.GlobalManager.Reset(name)
+		if errors.Is(err, resilience.ErrUnknownCircuit) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Not Found", "message": "circuit breaker not found"})
+			return
+		}
+
+		requestID, _ := c.Get(middleware.RequestIDKey)
+		log.Printf("[%v] Circuit breaker %q reset by admin from %s", requestID, name, c.ClientIP())
+
+		c.JSON(http.StatusOK, cb.Stats())
+	})
 }
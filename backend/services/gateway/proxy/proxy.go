@@ -7,6 +7,10 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/keepalive"
 
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/resilience"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
+
 	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
 	assetspb "github.com/radmickey/money-control/backend/proto/assets"
 	authpb "github.com/radmickey/money-control/backend/proto/auth"
@@ -15,26 +19,20 @@ import (
 	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
 )
 
-// Default timeouts and retry configuration
+// Default timeouts
 const (
 	DefaultConnectTimeout = 5 * time.Second
 	DefaultRequestTimeout = 10 * time.Second
-	MaxRetryAttempts      = 3
 )
 
-// Retry policy for gRPC calls - handles transient failures
-var retryPolicy = `{
+// Service config for backend connections: round-robin across backends when
+// several are available. Retries are left to the resilience interceptor,
+// which only retries calls that are safe to repeat.
+var serviceConfig = `{
+	"loadBalancingPolicy": "round_robin",
 	"methodConfig": [{
 		"name": [{"service": ""}],
-		"waitForReady": true,
-		"timeout": "10s",
-		"retryPolicy": {
-			"maxAttempts": 3,
-			"initialBackoff": "0.1s",
-			"maxBackoff": "1s",
-			"backoffMultiplier": 2.0,
-			"retryableStatusCodes": ["UNAVAILABLE", "DEADLINE_EXCEEDED", "RESOURCE_EXHAUSTED"]
-		}
+		"timeout": "10s"
 	}]
 }`
 
@@ -76,15 +74,25 @@ func NewServiceProxy(cfg Config) (*ServiceProxy, error) {
 	// Common dial options for all connections
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-		grpc.WithDefaultServiceConfig(retryPolicy),
+		grpc.WithDefaultServiceConfig(serviceConfig),
 		grpc.WithKeepaliveParams(keepaliveParams),
-		// Connection pooling via round-robin when multiple backends available
-		grpc.WithDefaultServiceConfig(`{"loadBalancingPolicy": "round_robin"}`),
+		// Forward the gateway request ID to backend services
+		grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+		// Continue the request's trace in backend services
+		tracing.DialOption(),
+	}
+
+	// Each service gets its own circuit breaker, and transient failures of
+	// idempotent calls are retried with backoff
+	serviceOpts := func(serviceName string) []grpc.DialOption {
+		return append(opts[:len(opts):len(opts)], grpc.WithChainUnaryInterceptor(
+			resilience.UnaryClientInterceptor(serviceName, resilience.DefaultRetryPolicy()),
+		))
 	}
 
 	// Connect to Auth service
 	if cfg.AuthServiceURL != "" {
-		conn, err := grpc.Dial(cfg.AuthServiceURL, opts...)
+		conn, err := grpc.Dial(cfg.AuthServiceURL, serviceOpts("auth-service")...)
 		if err != nil {
 			return nil, err
 		}
@@ -94,7 +102,7 @@ func NewServiceProxy(cfg Config) (*ServiceProxy, error) {
 
 	// Connect to Accounts service
 	if cfg.AccountsServiceURL != "" {
-		conn, err := grpc.Dial(cfg.AccountsServiceURL, opts...)
+		conn, err := grpc.Dial(cfg.AccountsServiceURL, serviceOpts("accounts-service")...)
 		if err != nil {
 			return nil, err
 		}
@@ -104,7 +112,7 @@ func NewServiceProxy(cfg Config) (*ServiceProxy, error) {
 
 	// Connect to Transactions service
 	if cfg.TransactionsServiceURL != "" {
-		conn, err := grpc.Dial(cfg.TransactionsServiceURL, opts...)
+		conn, err := grpc.Dial(cfg.TransactionsServiceURL, serviceOpts("transactions-service")...)
 		if err != nil {
 			return nil, err
 		}
@@ -114,7 +122,7 @@ func NewServiceProxy(cfg Config) (*ServiceProxy, error) {
 
 	// Connect to Assets service
 	if cfg.AssetsServiceURL != "" {
-		conn, err := grpc.Dial(cfg.AssetsServiceURL, opts...)
+		conn, err := grpc.Dial(cfg.AssetsServiceURL, serviceOpts("assets-service")...)
 		if err != nil {
 			return nil, err
 		}
@@ -124,7 +132,7 @@ func NewServiceProxy(cfg Config) (*ServiceProxy, error) {
 
 	// Connect to Currency service
 	if cfg.CurrencyServiceURL != "" {
-		conn, err := grpc.Dial(cfg.CurrencyServiceURL, opts...)
+		conn, err := grpc.Dial(cfg.CurrencyServiceURL, serviceOpts("currency-service")...)
 		if err != nil {
 			return nil, err
 		}
@@ -134,7 +142,7 @@ func NewServiceProxy(cfg Config) (*ServiceProxy, error) {
 
 	// Connect to Insights service
 	if cfg.InsightsServiceURL != "" {
-		conn, err := grpc.Dial(cfg.InsightsServiceURL, opts...)
+		conn, err := grpc.Dial(cfg.InsightsServiceURL, serviceOpts("insights-service")...)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	"github.com/radmickey/money-control/backend/services/insights/repository"
+	"github.com/radmickey/money-control/backend/services/insights/service"
+)
+
+// errorMap maps the insights service's errors to HTTP responses
+var errorMap = utils.ErrorMap{
+	{Err: repository.ErrGoalNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Goal not found"},
+	{Err: service.ErrInvalidGoalName, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidGoalAmount, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidGoalDate, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidGoalLink, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidProjectionMethod, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidProjectionHorizon, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInsufficientHistory, Status: http.StatusUnprocessableEntity, Code: utils.CodeInsufficientHistory},
+	{Err: service.ErrProjectionNonPositive, Status: http.StatusUnprocessableEntity, Code: utils.CodeInsufficientHistory},
+	{Err: service.ErrInvalidAssetType, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidTargetAllocation, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrNoTargetAllocation, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "No target allocation set"},
+}
+
+// respondError writes err as an HTTP error response
+func respondError(c *gin.Context, err error) {
+	errorMap.Respond(c, err)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	pb "github.com/radmickey/money-control/backend/proto/insights"
@@ -42,6 +43,8 @@ func (h *GRPCHandler) GetNetWorth(ctx context.Context, req *pb.GetNetWorthReques
 		Change_30D:          netWorth.Change30d,
 		ChangePercent_30D:   netWorth.ChangePercent30d,
 		CalculatedAt:        timestamppb.New(netWorth.CalculatedAt),
+		TotalAssets:         netWorth.TotalAssets,
+		TotalLiabilities:    netWorth.TotalLiabilities,
 	}, nil
 }
 
@@ -55,7 +58,7 @@ func (h *GRPCHandler) GetNetWorthHistory(ctx context.Context, req *pb.GetNetWort
 		endDate = req.EndDate.AsTime()
 	}
 
-	history, err := h.insightService.GetNetWorthHistory(ctx, req.UserId, req.BaseCurrency, req.Period, startDate, endDate)
+	history, err := h.insightService.GetNetWorthHistory(ctx, req.UserId, req.BaseCurrency, req.Period, startDate, endDate, req.Raw)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get net worth history: %v", err)
 	}
@@ -74,6 +77,39 @@ func (h *GRPCHandler) GetNetWorthHistory(ctx context.Context, req *pb.GetNetWort
 	}, nil
 }
 
+// ProjectNetWorth projects net worth from the snapshot trend
+func (h *GRPCHandler) ProjectNetWorth(ctx context.Context, req *pb.ProjectNetWorthRequest) (*pb.NetWorthProjectionResponse, error) {
+	projection, err := h.insightService.ProjectNetWorth(ctx, req.UserId, int(req.HorizonMonths), service.ProjectionMethod(req.Method))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidProjectionMethod), errors.Is(err, service.ErrInvalidProjectionHorizon):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, service.ErrInsufficientHistory), errors.Is(err, service.ErrProjectionNonPositive):
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to project net worth: %v", err)
+	}
+
+	points := make([]*pb.ProjectionPoint, len(projection.Points))
+	for i, p := range projection.Points {
+		points[i] = &pb.ProjectionPoint{
+			Date:  timestamppb.New(p.Date),
+			Value: p.Value,
+			Lower: p.Lower,
+			Upper: p.Upper,
+		}
+	}
+
+	return &pb.NetWorthProjectionResponse{
+		Method:           projection.Method,
+		Currency:         projection.Currency,
+		Points:           points,
+		SnapshotsUsed:    int32(projection.SnapshotsUsed),
+		MonthlyChange:    projection.MonthlyChange,
+		AnnualGrowthRate: projection.AnnualGrowthRate,
+	}, nil
+}
+
 // GetAllocation gets asset allocation
 func (h *GRPCHandler) GetAllocation(ctx context.Context, req *pb.GetAllocationRequest) (*pb.AllocationResponse, error) {
 	allocations, totalValue, err := h.insightService.GetAllocation(ctx, req.UserId, req.BaseCurrency, req.GroupBy)
@@ -101,18 +137,42 @@ func (h *GRPCHandler) GetAllocation(ctx context.Context, req *pb.GetAllocationRe
 
 // GetBalanceChanges gets balance changes
 func (h *GRPCHandler) GetBalanceChanges(ctx context.Context, req *pb.GetBalanceChangesRequest) (*pb.BalanceChangesResponse, error) {
-	// Simplified - return empty response for now
+	var startDate, endDate time.Time
+	if req.StartDate != nil {
+		startDate = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		endDate = req.EndDate.AsTime()
+	}
+
+	changes, totalChange, totalChangePercent, err := h.insightService.GetBalanceChanges(ctx, req.UserId, req.Period, startDate, endDate)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get balance changes: %v", err)
+	}
+
+	pbChanges := make([]*pb.BalanceChange, len(changes))
+	for i, c := range changes {
+		pbChanges[i] = &pb.BalanceChange{
+			AccountId:       c.AccountID,
+			AccountName:     c.AccountName,
+			StartingBalance: c.StartingBalance,
+			EndingBalance:   c.EndingBalance,
+			Change:          c.Change,
+			ChangePercent:   c.ChangePercent,
+		}
+	}
+
 	return &pb.BalanceChangesResponse{
-		Changes:            []*pb.BalanceChange{},
-		TotalChange:        0,
-		TotalChangePercent: 0,
+		Changes:            pbChanges,
+		TotalChange:        totalChange,
+		TotalChangePercent: totalChangePercent,
 		Currency:           req.BaseCurrency,
 	}, nil
 }
 
 // GetTrends gets trends
 func (h *GRPCHandler) GetTrends(ctx context.Context, req *pb.GetTrendsRequest) (*pb.TrendsResponse, error) {
-	history, err := h.insightService.GetNetWorthHistory(ctx, req.UserId, req.BaseCurrency, req.Period, time.Time{}, time.Time{})
+	history, err := h.insightService.GetNetWorthHistory(ctx, req.UserId, req.BaseCurrency, req.Period, time.Time{}, time.Time{}, true)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get trends: %v", err)
 	}
@@ -239,12 +299,13 @@ func (h *GRPCHandler) GetCashFlow(ctx context.Context, req *pb.GetCashFlowReques
 	pbPeriods := make([]*pb.CashFlowPeriod, len(periods))
 	for i, p := range periods {
 		pbPeriods[i] = &pb.CashFlowPeriod{
-			Label:     p.Label,
-			StartDate: timestamppb.New(p.StartDate),
-			EndDate:   timestamppb.New(p.EndDate),
-			Income:    p.Income,
-			Expenses:  p.Expenses,
-			Net:       p.Net,
+			Label:            p.Label,
+			StartDate:        timestamppb.New(p.StartDate),
+			EndDate:          timestamppb.New(p.EndDate),
+			Income:           p.Income,
+			InvestmentIncome: p.InvestmentIncome,
+			Expenses:         p.Expenses,
+			Net:              p.Net,
 		}
 	}
 
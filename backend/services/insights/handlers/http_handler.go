@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -17,11 +18,22 @@ func RegisterHTTPRoutes(r *gin.RouterGroup, insightService *service.InsightServi
 	{
 		insights.GET("/net-worth", h.GetNetWorth)
 		insights.GET("/net-worth/history", h.GetNetWorthHistory)
+		insights.GET("/net-worth/projection", h.ProjectNetWorth)
+		insights.GET("/balance-changes", h.GetBalanceChanges)
 		insights.GET("/allocation", h.GetAllocation)
+		insights.GET("/allocation/targets", h.GetTargetAllocation)
+		insights.PUT("/allocation/targets", h.SetTargetAllocation)
+		insights.GET("/rebalancing", h.GetRebalancing)
 		insights.GET("/dashboard", h.GetDashboardSummary)
 		insights.GET("/cash-flow", h.GetCashFlow)
 		insights.POST("/snapshots", h.CreateSnapshot)
 		insights.GET("/snapshots", h.GetSnapshots)
+		insights.POST("/goals", h.CreateGoal)
+		insights.GET("/goals", h.ListGoals)
+		insights.GET("/goals/:id", h.GetGoal)
+		insights.GET("/goals/:id/progress", h.GetGoalProgress)
+		insights.PUT("/goals/:id", h.UpdateGoal)
+		insights.DELETE("/goals/:id", h.DeleteGoal)
 	}
 }
 
@@ -32,7 +44,11 @@ type HTTPHandler struct {
 
 // GetNetWorth gets current net worth
 func (h *HTTPHandler) GetNetWorth(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 	if baseCurrency == "" {
 		baseCurrency = "USD"
@@ -40,7 +56,7 @@ func (h *HTTPHandler) GetNetWorth(c *gin.Context) {
 
 	netWorth, err := h.insightService.GetNetWorth(c.Request.Context(), userID, baseCurrency)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -49,7 +65,11 @@ func (h *HTTPHandler) GetNetWorth(c *gin.Context) {
 
 // GetNetWorthHistory gets net worth history
 func (h *HTTPHandler) GetNetWorthHistory(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 	period := c.Query("period")
 	startDateStr := c.Query("start_date")
@@ -63,9 +83,9 @@ func (h *HTTPHandler) GetNetWorthHistory(c *gin.Context) {
 		endDate, _ = time.Parse("2006-01-02", endDateStr)
 	}
 
-	history, err := h.insightService.GetNetWorthHistory(c.Request.Context(), userID, baseCurrency, period, startDate, endDate)
+	history, err := h.insightService.GetNetWorthHistory(c.Request.Context(), userID, baseCurrency, period, startDate, endDate, c.Query("raw") == "true")
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -75,15 +95,75 @@ func (h *HTTPHandler) GetNetWorthHistory(c *gin.Context) {
 	})
 }
 
+// ProjectNetWorth projects net worth from the snapshot trend
+func (h *HTTPHandler) ProjectNetWorth(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	horizon := 0
+	if v := c.Query("horizon"); v != "" {
+		var err error
+		if horizon, err = strconv.Atoi(v); err != nil {
+			utils.BadRequest(c, "Invalid horizon")
+			return
+		}
+	}
+
+	projection, err := h.insightService.ProjectNetWorth(c.Request.Context(), userID, horizon, service.ProjectionMethod(c.Query("method")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, projection)
+}
+
+// GetBalanceChanges gets per-category balance changes over a period
+func (h *HTTPHandler) GetBalanceChanges(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var startDate, endDate time.Time
+	if v := c.Query("start_date"); v != "" {
+		startDate, _ = time.Parse("2006-01-02", v)
+	}
+	if v := c.Query("end_date"); v != "" {
+		endDate, _ = time.Parse("2006-01-02", v)
+	}
+
+	changes, totalChange, totalChangePercent, err := h.insightService.GetBalanceChanges(c.Request.Context(), userID, c.Query("period"), startDate, endDate)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, gin.H{
+		"changes":              changes,
+		"total_change":         totalChange,
+		"total_change_percent": totalChangePercent,
+		"currency":             c.Query("currency"),
+	})
+}
+
 // GetAllocation gets asset allocation
 func (h *HTTPHandler) GetAllocation(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 	groupBy := c.Query("group_by")
 
 	allocations, totalValue, err := h.insightService.GetAllocation(c.Request.Context(), userID, baseCurrency, groupBy)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -94,14 +174,97 @@ func (h *HTTPHandler) GetAllocation(c *gin.Context) {
 	})
 }
 
+// GetTargetAllocation gets the target allocation
+func (h *HTTPHandler) GetTargetAllocation(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	targets, err := h.insightService.GetTargetAllocation(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, targets)
+}
+
+// SetTargetAllocationRequest represents set target allocation request,
+// percentages keyed by asset type
+type SetTargetAllocationRequest struct {
+	Targets map[string]float64 `json:"targets"`
+}
+
+// SetTargetAllocation replaces the target allocation
+func (h *HTTPHandler) SetTargetAllocation(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req SetTargetAllocationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	targets, err := h.insightService.SetTargetAllocation(c.Request.Context(), userID, req.Targets)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, targets)
+}
+
+// GetRebalancing suggests trades to bring the allocation back to target
+func (h *HTTPHandler) GetRebalancing(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var tolerance, minTrade float64
+	if v := c.Query("tolerance"); v != "" {
+		var err error
+		if tolerance, err = strconv.ParseFloat(v, 64); err != nil || tolerance < 0 {
+			utils.BadRequest(c, "Invalid tolerance")
+			return
+		}
+	}
+	if v := c.Query("min_trade"); v != "" {
+		var err error
+		if minTrade, err = strconv.ParseFloat(v, 64); err != nil || minTrade < 0 {
+			utils.BadRequest(c, "Invalid min_trade")
+			return
+		}
+	}
+
+	rebalancing, err := h.insightService.GetRebalancing(c.Request.Context(), userID, c.Query("currency"), tolerance, minTrade)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, rebalancing)
+}
+
 // GetDashboardSummary gets dashboard summary
 func (h *HTTPHandler) GetDashboardSummary(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 
 	summary, err := h.insightService.GetDashboardSummary(c.Request.Context(), userID, baseCurrency)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -110,7 +273,11 @@ func (h *HTTPHandler) GetDashboardSummary(c *gin.Context) {
 
 // GetCashFlow gets cash flow analysis
 func (h *HTTPHandler) GetCashFlow(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 	period := c.Query("period")
 	startDateStr := c.Query("start_date")
@@ -128,7 +295,7 @@ func (h *HTTPHandler) GetCashFlow(c *gin.Context) {
 		c.Request.Context(), userID, baseCurrency, period, startDate, endDate,
 	)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -143,12 +310,16 @@ func (h *HTTPHandler) GetCashFlow(c *gin.Context) {
 
 // CreateSnapshot creates a snapshot
 func (h *HTTPHandler) CreateSnapshot(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	baseCurrency := c.Query("currency")
 
 	snapshot, err := h.insightService.CreateSnapshot(c.Request.Context(), userID, baseCurrency)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -157,7 +328,11 @@ func (h *HTTPHandler) CreateSnapshot(c *gin.Context) {
 
 // GetSnapshots gets historical snapshots
 func (h *HTTPHandler) GetSnapshots(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
 
@@ -171,10 +346,174 @@ func (h *HTTPHandler) GetSnapshots(c *gin.Context) {
 
 	snapshots, err := h.insightService.GetSnapshots(c.Request.Context(), userID, startDate, endDate, 30)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.Success(c, snapshots)
 }
 
+// CreateGoalRequest represents create goal request
+type CreateGoalRequest struct {
+	Name         string  `json:"name" binding:"required"`
+	TargetAmount float64 `json:"target_amount" binding:"required,gt=0"`
+	Currency     string  `json:"currency"`
+	TargetDate   string  `json:"target_date" binding:"required"`
+	AccountID    *string `json:"account_id"`
+	AssetType    string  `json:"asset_type"`
+}
+
+// CreateGoal creates a savings goal
+func (h *HTTPHandler) CreateGoal(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req CreateGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	targetDate, err := time.Parse("2006-01-02", req.TargetDate)
+	if err != nil {
+		utils.BadRequest(c, "Invalid target_date, expected YYYY-MM-DD")
+		return
+	}
+
+	goal, err := h.insightService.CreateGoal(c.Request.Context(), service.CreateGoalInput{
+		UserID:       userID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		Currency:     req.Currency,
+		TargetDate:   targetDate,
+		AccountID:    req.AccountID,
+		AssetType:    req.AssetType,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Created(c, goal)
+}
+
+// ListGoals lists goals
+func (h *HTTPHandler) ListGoals(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	goals, err := h.insightService.ListGoals(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, goals)
+}
+
+// GetGoal gets a goal
+func (h *HTTPHandler) GetGoal(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	goal, err := h.insightService.GetGoal(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, goal)
+}
+
+// GetGoalProgress reports progress toward a goal
+func (h *HTTPHandler) GetGoalProgress(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	progress, err := h.insightService.GetGoalProgress(c.Request.Context(), c.Param("id"), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, progress)
+}
+
+// UpdateGoalRequest represents update goal request
+type UpdateGoalRequest struct {
+	Name         string  `json:"name"`
+	TargetAmount float64 `json:"target_amount"`
+	Currency     string  `json:"currency"`
+	TargetDate   string  `json:"target_date"`
+	AccountID    *string `json:"account_id"`
+	AssetType    *string `json:"asset_type"`
+}
+
+// UpdateGoal updates a goal
+func (h *HTTPHandler) UpdateGoal(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req UpdateGoalRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	var targetDate time.Time
+	if req.TargetDate != "" {
+		var err error
+		if targetDate, err = time.Parse("2006-01-02", req.TargetDate); err != nil {
+			utils.BadRequest(c, "Invalid target_date, expected YYYY-MM-DD")
+			return
+		}
+	}
+
+	goal, err := h.insightService.UpdateGoal(c.Request.Context(), service.UpdateGoalInput{
+		ID:           c.Param("id"),
+		UserID:       userID,
+		Name:         req.Name,
+		TargetAmount: req.TargetAmount,
+		Currency:     req.Currency,
+		TargetDate:   targetDate,
+		AccountID:    req.AccountID,
+		AssetType:    req.AssetType,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, goal)
+}
+
+// DeleteGoal deletes a goal
+func (h *HTTPHandler) DeleteGoal(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	if err := h.insightService.DeleteGoal(c.Request.Context(), c.Param("id"), userID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
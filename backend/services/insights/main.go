@@ -12,9 +12,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/auth"
+	pkgcache "github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/logger"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
 	pb "github.com/radmickey/money-control/backend/proto/insights"
 	"github.com/radmickey/money-control/backend/services/insights/handlers"
 	"github.com/radmickey/money-control/backend/services/insights/models"
@@ -30,11 +35,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "insights-service",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := database.New(database.Config{
-		URL:   cfg.DatabaseURL,
-		Debug: cfg.Debug,
+		URL:             cfg.DatabaseURL,
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		Debug:           cfg.Debug,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -42,27 +63,48 @@ func main() {
 	defer db.Close()
 
 	// Run migrations
-	if err := db.Migrate(&models.Snapshot{}); err != nil {
+	if err := db.Migrate(&models.Snapshot{}, &models.Goal{}, &models.TargetAllocation{}); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// Initialize repositories
 	snapshotRepo := repository.NewSnapshotRepository(db.DB)
+	goalRepo := repository.NewGoalRepository(db.DB)
+	targetRepo := repository.NewTargetAllocationRepository(db.DB)
+
+	// Connect to Redis to coordinate the snapshot job between replicas (optional)
+	var redisCache *pkgcache.Cache
+	if cfg.RedisURL != "" {
+		redisCache, err = pkgcache.New(pkgcache.Config{
+			URL:    cfg.RedisURL,
+			Prefix: "insights",
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to connect to Redis (snapshot job runs on every replica): %v", err)
+		} else {
+			defer redisCache.Close()
+		}
+	}
 
 	// Initialize service with connections to other services
 	insightService, err := service.NewInsightService(
 		snapshotRepo,
+		goalRepo,
+		targetRepo,
+		cfg.AuthServiceURL,
 		cfg.AccountsServiceURL,
 		cfg.AssetsServiceURL,
 		cfg.TransactionsServiceURL,
 		cfg.CurrencyServiceURL,
+		logger.New(logger.NewConfig(cfg, "insights-service")),
+		redisCache,
 	)
 	if err != nil {
 		log.Fatalf("Failed to initialize insight service: %v", err)
 	}
 
 	// Start daily snapshot job
-	go runDailySnapshots(insightService)
+	insightService.StartDailySnapshots(cfg.SnapshotTime, cfg.SnapshotJitter)
 
 	// Initialize JWT manager for auth middleware
 	jwtManager := auth.NewJWTManager(
@@ -72,11 +114,19 @@ func main() {
 	)
 
 	// Start gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		middleware.UnaryServerRequestIDInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
 	grpcHandler := handlers.NewGRPCHandler(insightService)
 	pb.RegisterInsightsServiceServer(grpcServer, grpcHandler)
 	reflection.Register(grpcServer)
 
+	// Health checks, served over gRPC here and on /health/details below
+	healthChecker := health.NewHealthChecker("")
+	healthChecker.Register("database", health.DatabasePoolCheck(db.Ping, db.Stats))
+	grpcHealth := health.RegisterGRPCServer(grpcServer, healthChecker)
+
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
@@ -95,19 +145,34 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery())
+	logConfig := middleware.NewLoggingConfig(cfg)
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "insights"})
 	})
 
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Detailed health check with connection pool stats
+	router.GET("/health/details", func(c *gin.Context) {
+		c.JSON(http.StatusOK, healthChecker.Check(c.Request.Context()))
+	})
+
 	// Protected routes
 	v1 := router.Group("/api/v1")
-	v1.Use(middleware.AuthMiddleware(jwtManager))
+	tokenValidator, err := middleware.NewTokenValidator(cfg, jwtManager, nil)
+	if err != nil {
+		log.Fatalf("Failed to create token validator: %v", err)
+	}
+	v1.Use(middleware.AuthMiddleware(tokenValidator))
 	handlers.RegisterHTTPRoutes(v1, insightService)
 
 	httpServer := &http.Server{
@@ -133,23 +198,13 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	insightService.Stop()
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
 	}
+	insightService.Close()
 
 	log.Println("Insights service stopped")
 }
-
-func runDailySnapshots(svc *service.InsightService) {
-	// Run at midnight every day
-	ticker := time.NewTicker(24 * time.Hour)
-	defer ticker.Stop()
-
-	for range ticker.C {
-		log.Println("Running daily snapshot job...")
-		// In a real implementation, you would iterate over all users
-		// For now, this is a placeholder
-	}
-}
-
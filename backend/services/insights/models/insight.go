@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"gorm.io/gorm"
 )
 
 // Snapshot represents a point-in-time financial snapshot
@@ -33,6 +35,8 @@ type AllocationItem struct {
 type NetWorthData struct {
 	TotalNetWorth       float64   `json:"total_net_worth"`
 	Currency            string    `json:"currency"`
+	TotalAssets         float64   `json:"total_assets"`
+	TotalLiabilities    float64   `json:"total_liabilities"`
 	Change24h           float64   `json:"change_24h"`
 	ChangePercent24h    float64   `json:"change_percent_24h"`
 	Change7d            float64   `json:"change_7d"`
@@ -44,12 +48,13 @@ type NetWorthData struct {
 
 // CashFlowPeriod represents cash flow for a period
 type CashFlowPeriod struct {
-	Label     string    `json:"label"`
-	StartDate time.Time `json:"start_date"`
-	EndDate   time.Time `json:"end_date"`
-	Income    float64   `json:"income"`
-	Expenses  float64   `json:"expenses"`
-	Net       float64   `json:"net"`
+	Label            string    `json:"label"`
+	StartDate        time.Time `json:"start_date"`
+	EndDate          time.Time `json:"end_date"`
+	Income           float64   `json:"income"`
+	InvestmentIncome float64   `json:"investment_income"`
+	Expenses         float64   `json:"expenses"`
+	Net              float64   `json:"net"`
 }
 
 // TrendPoint represents a data point in a trend
@@ -83,6 +88,7 @@ type DashboardSummary struct {
 	TopAllocations         []AllocationItem `json:"top_allocations"`
 	RecentTransactions     []RecentTx       `json:"recent_transactions"`
 	TopPerformers          []TopPerformer   `json:"top_performers"`
+	Goals                  []GoalProgress   `json:"goals"`
 	Currency               string           `json:"currency"`
 	CalculatedAt           time.Time        `json:"calculated_at"`
 }
@@ -106,3 +112,101 @@ type TopPerformer struct {
 	ChangePercent float64 `json:"change_percent"`
 }
 
+// Goal is a savings target, tracked against net worth or against one
+// account or asset type when linked
+type Goal struct {
+	ID           string         `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID       string         `gorm:"type:uuid;not null;index" json:"user_id"`
+	Name         string         `gorm:"size:255;not null" json:"name"`
+	TargetAmount float64        `gorm:"type:decimal(20,8);not null" json:"target_amount"`
+	Currency     string         `gorm:"size:3;not null;default:'USD'" json:"currency"`
+	TargetDate   time.Time      `gorm:"type:date;not null" json:"target_date"`
+	AccountID    *string        `gorm:"type:uuid" json:"account_id,omitempty"`
+	AssetType    string         `gorm:"size:50" json:"asset_type,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (Goal) TableName() string {
+	return "goals"
+}
+
+// GoalProgress reports how far a goal is from its target and whether the
+// recent savings rate gets there by the target date
+type GoalProgress struct {
+	Goal            Goal       `json:"goal"`
+	CurrentAmount   float64    `json:"current_amount"`
+	Remaining       float64    `json:"remaining"`
+	Percent         float64    `json:"percent"`
+	MonthlySavings  float64    `json:"monthly_savings"`
+	RequiredMonthly float64    `json:"required_monthly"`
+	ProjectedAmount float64    `json:"projected_amount"`
+	ProjectedDate   *time.Time `json:"projected_date,omitempty"`
+	OnTrack         bool       `json:"on_track"`
+}
+
+// ProjectionPoint is a projected net worth with its confidence band
+type ProjectionPoint struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+	Lower float64   `json:"lower"`
+	Upper float64   `json:"upper"`
+}
+
+// NetWorthProjection is a forward projection of net worth from snapshots
+type NetWorthProjection struct {
+	Method           string            `json:"method"`
+	Currency         string            `json:"currency"`
+	Points           []ProjectionPoint `json:"points"`
+	SnapshotsUsed    int               `json:"snapshots_used"`
+	MonthlyChange    float64           `json:"monthly_change"`
+	AnnualGrowthRate float64           `json:"annual_growth_rate"`
+}
+
+// TargetAllocation is the share of a portfolio a user wants in one asset type
+type TargetAllocation struct {
+	ID         string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID     string    `gorm:"type:uuid;not null;uniqueIndex:idx_target_allocations_user_asset_type" json:"user_id"`
+	AssetType  string    `gorm:"size:50;not null;uniqueIndex:idx_target_allocations_user_asset_type" json:"asset_type"`
+	Percentage float64   `gorm:"type:decimal(5,2);not null" json:"percentage"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// TableName returns the table name for GORM
+func (TargetAllocation) TableName() string {
+	return "target_allocations"
+}
+
+// RebalanceAction is what to do with an asset type to reach its target
+type RebalanceAction string
+
+const (
+	RebalanceBuy  RebalanceAction = "buy"
+	RebalanceSell RebalanceAction = "sell"
+	RebalanceHold RebalanceAction = "hold"
+)
+
+// RebalanceItem compares one asset type's allocation with its target
+type RebalanceItem struct {
+	AssetType      string          `json:"asset_type"`
+	CurrentValue   float64         `json:"current_value"`
+	CurrentPercent float64         `json:"current_percent"`
+	TargetPercent  float64         `json:"target_percent"`
+	TargetValue    float64         `json:"target_value"`
+	Drift          float64         `json:"drift"`
+	Action         RebalanceAction `json:"action"`
+	Amount         float64         `json:"amount"`
+}
+
+// Rebalancing reports allocation drift and the trades that realign it
+type Rebalancing struct {
+	Currency         string          `json:"currency"`
+	TotalValue       float64         `json:"total_value"`
+	Tolerance        float64         `json:"tolerance"`
+	MinTrade         float64         `json:"min_trade"`
+	NeedsRebalancing bool            `json:"needs_rebalancing"`
+	Items            []RebalanceItem `json:"items"`
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+	"gorm.io/gorm"
+)
+
+// ErrGoalNotFound is returned when a goal does not exist for the user
+var ErrGoalNotFound = errors.New("goal not found")
+
+// GoalRepository handles goal operations
+type GoalRepository struct {
+	db *gorm.DB
+}
+
+// NewGoalRepository creates a new goal repository
+func NewGoalRepository(db *gorm.DB) *GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+// Create creates a new goal
+func (r *GoalRepository) Create(ctx context.Context, goal *models.Goal) error {
+	return r.db.WithContext(ctx).Create(goal).Error
+}
+
+// GetByID finds a user's goal by ID
+func (r *GoalRepository) GetByID(ctx context.Context, id, userID string) (*models.Goal, error) {
+	var goal models.Goal
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&goal).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrGoalNotFound
+		}
+		return nil, err
+	}
+	return &goal, nil
+}
+
+// ListByUser lists a user's goals, nearest target date first
+func (r *GoalRepository) ListByUser(ctx context.Context, userID string) ([]models.Goal, error) {
+	var goals []models.Goal
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("target_date, created_at").
+		Find(&goals).Error; err != nil {
+		return nil, err
+	}
+	return goals, nil
+}
+
+// Update updates a goal
+func (r *GoalRepository) Update(ctx context.Context, goal *models.Goal) error {
+	return r.db.WithContext(ctx).Save(goal).Error
+}
+
+// Delete soft-deletes a goal
+func (r *GoalRepository) Delete(ctx context.Context, id, userID string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Goal{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrGoalNotFound
+	}
+	return nil
+}
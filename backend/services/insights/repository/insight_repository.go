@@ -46,6 +46,40 @@ func (r *SnapshotRepository) GetLatest(ctx context.Context, userID string) (*mod
 	return &snapshot, nil
 }
 
+// GetLatestBefore gets the newest snapshot dated before the given time, or nil
+// when there is none
+func (r *SnapshotRepository) GetLatestBefore(ctx context.Context, userID string, before time.Time) (*models.Snapshot, error) {
+	var snapshots []models.Snapshot
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND date < ?", userID, before).
+		Order("date DESC").
+		Limit(1).
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return &snapshots[0], nil
+}
+
+// GetEarliestSince gets the oldest snapshot dated on or after the given time,
+// or nil when there is none
+func (r *SnapshotRepository) GetEarliestSince(ctx context.Context, userID string, since time.Time) (*models.Snapshot, error) {
+	var snapshots []models.Snapshot
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ? AND date >= ?", userID, since).
+		Order("date ASC").
+		Limit(1).
+		Find(&snapshots).Error; err != nil {
+		return nil, err
+	}
+	if len(snapshots) == 0 {
+		return nil, nil
+	}
+	return &snapshots[0], nil
+}
+
 // GetByDateRange gets snapshots within a date range
 func (r *SnapshotRepository) GetByDateRange(ctx context.Context, userID string, startDate, endDate time.Time, limit int) ([]models.Snapshot, error) {
 	var snapshots []models.Snapshot
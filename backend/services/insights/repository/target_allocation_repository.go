@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+	"gorm.io/gorm"
+)
+
+// TargetAllocationRepository handles target allocation operations
+type TargetAllocationRepository struct {
+	db *gorm.DB
+}
+
+// NewTargetAllocationRepository creates a new target allocation repository
+func NewTargetAllocationRepository(db *gorm.DB) *TargetAllocationRepository {
+	return &TargetAllocationRepository{db: db}
+}
+
+// ListByUser lists a user's target allocation
+func (r *TargetAllocationRepository) ListByUser(ctx context.Context, userID string) ([]models.TargetAllocation, error) {
+	var targets []models.TargetAllocation
+	if err := r.db.WithContext(ctx).
+		Where("user_id = ?", userID).
+		Order("percentage DESC, asset_type").
+		Find(&targets).Error; err != nil {
+		return nil, err
+	}
+	return targets, nil
+}
+
+// Replace swaps a user's target allocation for a new set in one transaction
+func (r *TargetAllocationRepository) Replace(ctx context.Context, userID string, targets []models.TargetAllocation) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TargetAllocation{}).Error; err != nil {
+			return err
+		}
+		if len(targets) == 0 {
+			return nil
+		}
+		return tx.Create(&targets).Error
+	})
+}
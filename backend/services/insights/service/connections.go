@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+// downstreamConnectTimeout bounds the startup reachability check per service
+const downstreamConnectTimeout = 5 * time.Second
+
+// Retry policy for calls to other services - handles transient failures
+var downstreamRetryPolicy = `{
+	"methodConfig": [{
+		"name": [{"service": ""}],
+		"timeout": "10s",
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2.0,
+			"retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+		}
+	}]
+}`
+
+// Keepalive parameters to detect dead connections between calls
+var downstreamKeepalive = keepalive.ClientParameters{
+	Time:                30 * time.Second,
+	Timeout:             5 * time.Second,
+	PermitWithoutStream: true,
+}
+
+// connectionManager owns the gRPC connections to other services. Each
+// downstream gets one shared connection, which gRPC reconnects lazily with
+// backoff after failures.
+type connectionManager struct {
+	conns []*grpc.ClientConn
+	opts  []grpc.DialOption
+}
+
+func newConnectionManager() *connectionManager {
+	return &connectionManager{
+		opts: []grpc.DialOption{
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithDefaultServiceConfig(downstreamRetryPolicy),
+			grpc.WithKeepaliveParams(downstreamKeepalive),
+			// Forward the incoming request ID on calls to other services
+			grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+			// Continue the request's trace in other services
+			tracing.DialOption(),
+		},
+	}
+}
+
+// dial opens a connection to a downstream service and checks it can be
+// reached. An unreachable service is logged rather than fatal since it may
+// still be starting; the connection keeps retrying in the background.
+func (m *connectionManager) dial(name, url string) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(url, m.opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to %s service at %s: %w", name, url, err)
+	}
+	m.conns = append(m.conns, conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), downstreamConnectTimeout)
+	defer cancel()
+	if !waitForReady(ctx, conn) {
+		log.Printf("WARNING: %s service at %s is unreachable; insights depending on it will be incomplete until it recovers", name, url)
+	}
+
+	return conn, nil
+}
+
+// waitForReady reports whether conn becomes ready before ctx is done
+func waitForReady(ctx context.Context, conn *grpc.ClientConn) bool {
+	conn.Connect()
+	for {
+		state := conn.GetState()
+		if state == connectivity.Ready {
+			return true
+		}
+		if !conn.WaitForStateChange(ctx, state) {
+			return false
+		}
+	}
+}
+
+// Close closes all connections
+func (m *connectionManager) Close() {
+	for _, conn := range m.conns {
+		if err := conn.Close(); err != nil {
+			log.Printf("Failed to close connection to %s: %v", conn.Target(), err)
+		}
+	}
+	m.conns = nil
+}
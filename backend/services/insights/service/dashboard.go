@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	currencypb "github.com/radmickey/money-control/backend/proto/currency"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+)
+
+const (
+	// dashboardRecentTransactions is how many transactions the dashboard lists
+	dashboardRecentTransactions = 5
+	// dashboardTopPerformers is how many gaining and how many losing holdings are listed
+	dashboardTopPerformers = 3
+	// accountsPageSize matches the accounts service's maximum page size
+	accountsPageSize = 100
+)
+
+// recentTransactions returns the user's latest transactions. Expenses are
+// negative so the dashboard can show direction without a type.
+func (s *InsightService) recentTransactions(ctx context.Context, userID string) []models.RecentTx {
+	if s.clients.TransactionsClient == nil {
+		return nil
+	}
+
+	resp, err := s.clients.TransactionsClient.ListTransactions(ctx, &transactionspb.ListTransactionsRequest{
+		UserId:   userID,
+		Page:     1,
+		PageSize: dashboardRecentTransactions,
+		SortBy:   "date",
+		SortDesc: true,
+	})
+	if err != nil {
+		log.Printf("Failed to get recent transactions for user %s: %v", userID, err)
+		return nil
+	}
+
+	recent := make([]models.RecentTx, 0, len(resp.Transactions))
+	for _, tx := range resp.Transactions {
+		amount := tx.Amount
+		if tx.Type == transactionspb.TransactionType_TRANSACTION_TYPE_EXPENSE {
+			amount = -amount
+		}
+		category := tx.CustomCategory
+		if category == "" {
+			category = strings.ToLower(strings.TrimPrefix(tx.Category.String(), "TRANSACTION_CATEGORY_"))
+		}
+		recent = append(recent, models.RecentTx{
+			ID:          tx.Id,
+			Description: tx.Description,
+			Amount:      amount,
+			Currency:    tx.Currency,
+			Category:    category,
+			Date:        tx.Date.AsTime(),
+		})
+	}
+	return recent
+}
+
+// topPerformers returns the best gaining holdings followed by the worst
+// losing ones, ranked by profit/loss percent, with values in baseCurrency
+func (s *InsightService) topPerformers(ctx context.Context, userID, baseCurrency string) []models.TopPerformer {
+	if s.clients.AssetsClient == nil {
+		return nil
+	}
+
+	resp, err := s.clients.AssetsClient.GetHoldings(ctx, &assetspb.GetHoldingsRequest{UserId: userID})
+	if err != nil {
+		log.Printf("Failed to get holdings for user %s: %v", userID, err)
+		return nil
+	}
+
+	holdings := make([]models.TopPerformer, 0, len(resp.Holdings))
+	for _, h := range resp.Holdings {
+		if h.TotalInvested <= 0 {
+			continue
+		}
+		holdings = append(holdings, models.TopPerformer{
+			Symbol:        h.Symbol,
+			Name:          h.Name,
+			Value:         s.convertAmount(ctx, h.TotalValue, h.Currency, baseCurrency),
+			Change:        s.convertAmount(ctx, h.ProfitLoss, h.Currency, baseCurrency),
+			ChangePercent: h.ProfitLossPercent,
+		})
+	}
+	sort.Slice(holdings, func(i, j int) bool {
+		return holdings[i].ChangePercent > holdings[j].ChangePercent
+	})
+
+	var performers []models.TopPerformer
+	for i := 0; i < len(holdings) && i < dashboardTopPerformers && holdings[i].ChangePercent > 0; i++ {
+		performers = append(performers, holdings[i])
+	}
+	for i := len(holdings) - 1; i >= 0 && len(holdings)-i <= dashboardTopPerformers && holdings[i].ChangePercent < 0; i-- {
+		performers = append(performers, holdings[i])
+	}
+	return performers
+}
+
+// listAccounts loads all of a user's accounts with their sub-accounts
+func (s *InsightService) listAccounts(ctx context.Context, userID string) ([]*accountspb.Account, error) {
+	if s.clients.AccountsClient == nil {
+		return nil, errors.New("accounts service unavailable")
+	}
+
+	var accounts []*accountspb.Account
+	for page := int32(1); ; page++ {
+		resp, err := s.clients.AccountsClient.ListAccounts(ctx, &accountspb.ListAccountsRequest{
+			UserId:   userID,
+			Page:     page,
+			PageSize: accountsPageSize,
+		})
+		if err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, resp.Accounts...)
+
+		if len(resp.Accounts) < accountsPageSize || len(accounts) >= int(resp.Total) {
+			return accounts, nil
+		}
+	}
+}
+
+// convertAmount converts amount to baseCurrency, keeping it unchanged when
+// the currency service is unavailable
+func (s *InsightService) convertAmount(ctx context.Context, amount float64, currency, baseCurrency string) float64 {
+	if amount == 0 || currency == "" || baseCurrency == "" || currency == baseCurrency || s.clients.CurrencyClient == nil {
+		return amount
+	}
+	converted, err := s.clients.CurrencyClient.ConvertAmount(ctx, &currencypb.ConvertAmountRequest{
+		Amount:       amount,
+		FromCurrency: currency,
+		ToCurrency:   baseCurrency,
+	})
+	if err != nil {
+		return amount
+	}
+	return converted.ConvertedAmount
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+)
+
+// fakeTransactions serves ListTransactions; other methods aren't used
+type fakeTransactions struct {
+	transactionspb.TransactionsServiceClient
+	transactions []*transactionspb.Transaction
+	err          error
+	request      *transactionspb.ListTransactionsRequest
+}
+
+func (f *fakeTransactions) ListTransactions(ctx context.Context, req *transactionspb.ListTransactionsRequest, opts ...grpc.CallOption) (*transactionspb.ListTransactionsResponse, error) {
+	f.request = req
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &transactionspb.ListTransactionsResponse{Transactions: f.transactions}, nil
+}
+
+// fakeAssets serves GetHoldings; other methods aren't used
+type fakeAssets struct {
+	assetspb.AssetsServiceClient
+	holdings []*assetspb.Holding
+	err      error
+}
+
+func (f *fakeAssets) GetHoldings(ctx context.Context, req *assetspb.GetHoldingsRequest, opts ...grpc.CallOption) (*assetspb.GetHoldingsResponse, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &assetspb.GetHoldingsResponse{Holdings: f.holdings}, nil
+}
+
+func TestRecentTransactions(t *testing.T) {
+	date := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	transactions := &fakeTransactions{transactions: []*transactionspb.Transaction{
+		{Id: "t1", Amount: 25, Currency: "USD", Type: transactionspb.TransactionType_TRANSACTION_TYPE_EXPENSE,
+			Category: transactionspb.TransactionCategory_TRANSACTION_CATEGORY_FOOD, Description: "Lunch", Date: timestamppb.New(date)},
+		{Id: "t2", Amount: 1000, Currency: "EUR", Type: transactionspb.TransactionType_TRANSACTION_TYPE_INCOME,
+			Category: transactionspb.TransactionCategory_TRANSACTION_CATEGORY_SALARY, CustomCategory: "Bonus", Date: timestamppb.New(date)},
+	}}
+	s := &InsightService{clients: &ServiceClients{TransactionsClient: transactions}}
+
+	got := s.recentTransactions(context.Background(), testUserID)
+	want := []models.RecentTx{
+		{ID: "t1", Description: "Lunch", Amount: -25, Currency: "USD", Category: "food", Date: date},
+		{ID: "t2", Amount: 1000, Currency: "EUR", Category: "Bonus", Date: date},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("recentTransactions =\n%+v\nwant\n%+v", got, want)
+	}
+
+	req := transactions.request
+	if req.PageSize != dashboardRecentTransactions || req.SortBy != "date" || !req.SortDesc {
+		t.Errorf("request = %+v, want the latest %d by date", req, dashboardRecentTransactions)
+	}
+}
+
+func TestTopPerformers(t *testing.T) {
+	holding := func(symbol string, percent float64) *assetspb.Holding {
+		return &assetspb.Holding{Symbol: symbol, TotalValue: 100 + percent, TotalInvested: 100, ProfitLoss: percent, ProfitLossPercent: percent}
+	}
+	assets := &fakeAssets{holdings: []*assetspb.Holding{
+		holding("FLAT", 0),
+		holding("UP5", 5),
+		holding("DOWN40", -40),
+		holding("UP50", 50),
+		holding("DOWN1", -1),
+		holding("UP20", 20),
+		holding("UP10", 10),
+		holding("DOWN2", -2),
+		holding("DOWN3", -3),
+		// Nothing invested, so no meaningful percent
+		{Symbol: "GIFT", TotalValue: 50},
+	}}
+	s := &InsightService{clients: &ServiceClients{AssetsClient: assets}}
+
+	var symbols []string
+	for _, p := range s.topPerformers(context.Background(), testUserID, "USD") {
+		symbols = append(symbols, p.Symbol)
+	}
+	// Best three gainers, then the worst three losers
+	want := []string{"UP50", "UP20", "UP10", "DOWN40", "DOWN3", "DOWN2"}
+	if !reflect.DeepEqual(symbols, want) {
+		t.Errorf("topPerformers = %v, want %v", symbols, want)
+	}
+}
+
+func TestDashboardListsDegradeWhenUnavailable(t *testing.T) {
+	down := errors.New("unavailable")
+	s := &InsightService{clients: &ServiceClients{
+		TransactionsClient: &fakeTransactions{err: down},
+		AssetsClient:       &fakeAssets{err: down},
+	}}
+	if got := s.recentTransactions(context.Background(), testUserID); len(got) != 0 {
+		t.Errorf("recentTransactions = %+v, want none", got)
+	}
+	if got := s.topPerformers(context.Background(), testUserID, "USD"); len(got) != 0 {
+		t.Errorf("topPerformers = %+v, want none", got)
+	}
+
+	// Not configured at all
+	s = &InsightService{clients: &ServiceClients{}}
+	if got := s.recentTransactions(context.Background(), testUserID); got != nil {
+		t.Errorf("recentTransactions = %+v, want nil", got)
+	}
+	if got := s.topPerformers(context.Background(), testUserID, "USD"); got != nil {
+		t.Errorf("topPerformers = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+)
+
+const (
+	// goalSavingsWindow is how many recent months set the savings rate used for projections
+	goalSavingsWindow = 3
+	// daysPerMonth is the average month length used to convert durations to months
+	daysPerMonth = 365.25 / 12
+)
+
+var (
+	ErrInvalidGoalName   = errors.New("goal name is required")
+	ErrInvalidGoalAmount = errors.New("goal target amount must be positive")
+	ErrInvalidGoalDate   = errors.New("goal target date must be in the future")
+	ErrInvalidGoalLink   = errors.New("goal can be linked to an account or an asset type, not both")
+)
+
+// CreateGoalInput holds input for creating a goal
+type CreateGoalInput struct {
+	UserID       string
+	Name         string
+	TargetAmount float64
+	Currency     string
+	TargetDate   time.Time
+	AccountID    *string
+	AssetType    string
+}
+
+// CreateGoal creates a savings goal
+func (s *InsightService) CreateGoal(ctx context.Context, input CreateGoalInput) (*models.Goal, error) {
+	if input.Currency == "" {
+		input.Currency = "USD"
+	}
+
+	goal := &models.Goal{
+		UserID:       input.UserID,
+		Name:         input.Name,
+		TargetAmount: input.TargetAmount,
+		Currency:     input.Currency,
+		TargetDate:   input.TargetDate,
+		AccountID:    input.AccountID,
+		AssetType:    input.AssetType,
+	}
+	if err := validateGoal(goal); err != nil {
+		return nil, err
+	}
+	if !goal.TargetDate.After(time.Now()) {
+		return nil, ErrInvalidGoalDate
+	}
+
+	if err := s.goalRepo.Create(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// ListGoals lists a user's goals
+func (s *InsightService) ListGoals(ctx context.Context, userID string) ([]models.Goal, error) {
+	return s.goalRepo.ListByUser(ctx, userID)
+}
+
+// GetGoal gets a user's goal
+func (s *InsightService) GetGoal(ctx context.Context, id, userID string) (*models.Goal, error) {
+	return s.goalRepo.GetByID(ctx, id, userID)
+}
+
+// UpdateGoalInput holds input for updating a goal; empty fields are unchanged
+type UpdateGoalInput struct {
+	ID           string
+	UserID       string
+	Name         string
+	TargetAmount float64
+	Currency     string
+	TargetDate   time.Time
+	AccountID    *string
+	AssetType    *string
+}
+
+// UpdateGoal updates a goal. An empty account ID or asset type unlinks it.
+func (s *InsightService) UpdateGoal(ctx context.Context, input UpdateGoalInput) (*models.Goal, error) {
+	goal, err := s.goalRepo.GetByID(ctx, input.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != "" {
+		goal.Name = input.Name
+	}
+	if input.TargetAmount != 0 {
+		goal.TargetAmount = input.TargetAmount
+	}
+	if input.Currency != "" {
+		goal.Currency = input.Currency
+	}
+	if !input.TargetDate.IsZero() {
+		if !input.TargetDate.After(time.Now()) {
+			return nil, ErrInvalidGoalDate
+		}
+		goal.TargetDate = input.TargetDate
+	}
+	if input.AccountID != nil {
+		goal.AccountID = input.AccountID
+		if *input.AccountID == "" {
+			goal.AccountID = nil
+		}
+	}
+	if input.AssetType != nil {
+		goal.AssetType = *input.AssetType
+	}
+	if err := validateGoal(goal); err != nil {
+		return nil, err
+	}
+
+	if err := s.goalRepo.Update(ctx, goal); err != nil {
+		return nil, err
+	}
+
+	return goal, nil
+}
+
+// DeleteGoal deletes a goal
+func (s *InsightService) DeleteGoal(ctx context.Context, id, userID string) error {
+	return s.goalRepo.Delete(ctx, id, userID)
+}
+
+func validateGoal(goal *models.Goal) error {
+	switch {
+	case goal.Name == "":
+		return ErrInvalidGoalName
+	case goal.TargetAmount <= 0:
+		return ErrInvalidGoalAmount
+	case goal.AccountID != nil && goal.AssetType != "":
+		return ErrInvalidGoalLink
+	}
+	return nil
+}
+
+// GetGoalProgress compares a goal's current amount with its target and
+// projects the amount at the target date from the recent savings rate
+func (s *InsightService) GetGoalProgress(ctx context.Context, id, userID string) (*models.GoalProgress, error) {
+	goal, err := s.goalRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.goalProgress(ctx, *goal, newSavingsRates(s, userID))
+}
+
+// ListGoalProgress reports progress on all of a user's goals. Goals whose
+// current amount cannot be loaded are left out.
+func (s *InsightService) ListGoalProgress(ctx context.Context, userID string) ([]models.GoalProgress, error) {
+	goals, err := s.goalRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates := newSavingsRates(s, userID)
+	progress := make([]models.GoalProgress, 0, len(goals))
+	for _, goal := range goals {
+		p, err := s.goalProgress(ctx, goal, rates)
+		if err != nil {
+			log.Printf("Failed to get progress for goal %s: %v", goal.ID, err)
+			continue
+		}
+		progress = append(progress, *p)
+	}
+	return progress, nil
+}
+
+func (s *InsightService) goalProgress(ctx context.Context, goal models.Goal, rates *savingsRates) (*models.GoalProgress, error) {
+	current, err := s.goalCurrentAmount(ctx, goal)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	// Savings are attributed to the goal as a whole even when it tracks a
+	// single account, so linked goals are projected optimistically
+	savings := rates.monthly(ctx, goal.Currency, now)
+	monthsLeft := max(0, goal.TargetDate.Sub(now).Hours()/24/daysPerMonth)
+
+	progress := &models.GoalProgress{
+		Goal:            goal,
+		CurrentAmount:   current,
+		Remaining:       max(0, goal.TargetAmount-current),
+		Percent:         min(100, max(0, current/goal.TargetAmount*100)),
+		MonthlySavings:  savings,
+		ProjectedAmount: current + savings*monthsLeft,
+	}
+	progress.OnTrack = progress.ProjectedAmount >= goal.TargetAmount
+
+	if progress.Remaining > 0 {
+		if monthsLeft > 0 {
+			progress.RequiredMonthly = progress.Remaining / monthsLeft
+		}
+		if savings > 0 {
+			projected := now.Add(time.Duration(progress.Remaining / savings * daysPerMonth * float64(24*time.Hour)))
+			progress.ProjectedDate = &projected
+		}
+	}
+
+	return progress, nil
+}
+
+// goalCurrentAmount returns the linked account's balance, the linked asset
+// type's total, or the user's net worth, in the goal's currency
+func (s *InsightService) goalCurrentAmount(ctx context.Context, goal models.Goal) (float64, error) {
+	switch {
+	case goal.AccountID != nil:
+		if s.clients.AccountsClient == nil {
+			return 0, errors.New("accounts service unavailable")
+		}
+		account, err := s.clients.AccountsClient.GetAccount(ctx, &accountspb.GetAccountRequest{
+			Id:     *goal.AccountID,
+			UserId: goal.UserID,
+		})
+		if err != nil {
+			return 0, err
+		}
+		var balance float64
+		for _, sub := range account.SubAccounts {
+			balance += s.convertAmount(ctx, sub.Balance, sub.Currency, goal.Currency)
+		}
+		return balance, nil
+
+	case goal.AssetType != "":
+		if s.clients.AccountsClient == nil {
+			return 0, errors.New("accounts service unavailable")
+		}
+		resp, err := s.clients.AccountsClient.GetUserNetWorth(ctx, &accountspb.GetUserNetWorthRequest{
+			UserId:       goal.UserID,
+			BaseCurrency: goal.Currency,
+		})
+		if err != nil {
+			return 0, err
+		}
+		return resp.ByAssetType[goal.AssetType], nil
+
+	default:
+		netWorth, err := s.GetNetWorth(ctx, goal.UserID, goal.Currency)
+		if err != nil {
+			return 0, err
+		}
+		return netWorth.TotalNetWorth, nil
+	}
+}
+
+// savingsRates caches the user's average monthly net cash flow per currency
+type savingsRates struct {
+	s      *InsightService
+	userID string
+	rates  map[string]float64
+}
+
+func newSavingsRates(s *InsightService, userID string) *savingsRates {
+	return &savingsRates{s: s, userID: userID, rates: make(map[string]float64)}
+}
+
+// monthly returns the average net cash flow per month over the savings window
+func (r *savingsRates) monthly(ctx context.Context, currency string, now time.Time) float64 {
+	if rate, ok := r.rates[currency]; ok {
+		return rate
+	}
+
+	start := now.AddDate(0, -goalSavingsWindow, 0)
+	_, _, _, net, err := r.s.GetCashFlow(ctx, r.userID, currency, "monthly", start, now)
+	if err != nil {
+		return 0
+	}
+	rate := net / (now.Sub(start).Hours() / 24 / daysPerMonth)
+	r.rates[currency] = rate
+	return rate
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+)
+
+// Granularity is the bucket size of a filled history series
+type Granularity string
+
+const (
+	GranularityDaily   Granularity = "daily"
+	GranularityWeekly  Granularity = "weekly"
+	GranularityMonthly Granularity = "monthly"
+)
+
+const (
+	// maxDailyHistorySpan is the longest range charted day by day (about 3 months)
+	maxDailyHistorySpan = 93 * 24 * time.Hour
+	// maxWeeklyHistorySpan is the longest range charted week by week
+	maxWeeklyHistorySpan = 2 * 366 * 24 * time.Hour
+)
+
+// historyGranularity picks daily points for ranges up to a quarter, weekly up
+// to two years and monthly beyond
+func historyGranularity(start, end time.Time) Granularity {
+	span := end.Sub(start)
+	switch {
+	case span <= maxDailyHistorySpan:
+		return GranularityDaily
+	case span <= maxWeeklyHistorySpan:
+		return GranularityWeekly
+	default:
+		return GranularityMonthly
+	}
+}
+
+// fillHistory turns date-sorted snapshot points into one point per bucket
+// between start and end. Each bucket carries the last value known at its end,
+// so days the snapshot job missed repeat the previous value. Buckets before
+// the first known value are left out rather than reported as zero.
+func fillHistory(points []models.TrendPoint, start, end time.Time, granularity Granularity) []models.TrendPoint {
+	if len(points) == 0 || end.Before(start) {
+		return []models.TrendPoint{}
+	}
+
+	start = bucketStart(start.UTC(), granularity)
+	end = end.UTC()
+
+	filled := make([]models.TrendPoint, 0)
+	i := 0
+	known := false
+	var value float64
+	for bucket := start; !bucket.After(end); bucket = nextBucket(bucket, granularity) {
+		bucketEnd := nextBucket(bucket, granularity)
+		for i < len(points) && points[i].Date.Before(bucketEnd) {
+			value = points[i].Value
+			known = true
+			i++
+		}
+		if known {
+			filled = append(filled, models.TrendPoint{Date: bucket, Value: value})
+		}
+	}
+	return filled
+}
+
+// bucketStart truncates t to the start of its day, ISO week or month
+func bucketStart(t time.Time, granularity Granularity) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	switch granularity {
+	case GranularityWeekly:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case GranularityMonthly:
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+// nextBucket returns the start of the bucket after the one starting at t
+func nextBucket(t time.Time, granularity Granularity) time.Time {
+	switch granularity {
+	case GranularityWeekly:
+		return t.AddDate(0, 0, 7)
+	case GranularityMonthly:
+		return t.AddDate(0, 1, 0)
+	default:
+		return t.AddDate(0, 0, 1)
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+)
+
+// day returns midnight UTC on the given day of March 2026
+func day(d int) time.Time {
+	return time.Date(2026, 3, d, 0, 0, 0, 0, time.UTC)
+}
+
+func point(t time.Time, value float64) models.TrendPoint {
+	return models.TrendPoint{Date: t, Value: value}
+}
+
+func TestFillHistoryDailyGaps(t *testing.T) {
+	// Snapshots on the 2nd, 3rd and 6th; the job missed the 4th and 5th
+	points := []models.TrendPoint{point(day(2), 100), point(day(3), 110), point(day(6).Add(5*time.Minute), 90)}
+
+	got := fillHistory(points, day(1), day(7), GranularityDaily)
+	want := []models.TrendPoint{
+		// Nothing before the first snapshot rather than a zero
+		point(day(2), 100),
+		point(day(3), 110),
+		point(day(4), 110),
+		point(day(5), 110),
+		point(day(6), 90),
+		point(day(7), 90),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fillHistory =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestFillHistoryWeeklyTakesLastValue(t *testing.T) {
+	// March 2, 2026 is a Monday
+	points := []models.TrendPoint{point(day(2), 100), point(day(4), 120), point(day(8), 130), point(day(20), 150)}
+
+	got := fillHistory(points, day(3), day(24), GranularityWeekly)
+	want := []models.TrendPoint{
+		point(day(2), 130),  // the week of the 2nd ends on the 8th's value
+		point(day(9), 130),  // no snapshots that week
+		point(day(16), 150), // the 20th
+		point(day(23), 150),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fillHistory =\n%v\nwant\n%v", got, want)
+	}
+}
+
+func TestFillHistoryEmpty(t *testing.T) {
+	if got := fillHistory(nil, day(1), day(7), GranularityDaily); len(got) != 0 {
+		t.Errorf("no snapshots: got %v, want none", got)
+	}
+	if got := fillHistory([]models.TrendPoint{point(day(2), 1)}, day(7), day(1), GranularityDaily); len(got) != 0 {
+		t.Errorf("reversed range: got %v, want none", got)
+	}
+}
+
+func TestHistoryGranularity(t *testing.T) {
+	tests := []struct {
+		span time.Duration
+		want Granularity
+	}{
+		{span: 30 * 24 * time.Hour, want: GranularityDaily},
+		{span: 365 * 24 * time.Hour, want: GranularityWeekly},
+		{span: 5 * 365 * 24 * time.Hour, want: GranularityMonthly},
+	}
+	for _, tt := range tests {
+		if got := historyGranularity(day(1), day(1).Add(tt.span)); got != tt.want {
+			t.Errorf("historyGranularity(%v) = %s, want %s", tt.span, got, tt.want)
+		}
+	}
+}
@@ -3,22 +3,28 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"log"
+	"math"
+	"sort"
+	"sync"
 	"time"
 
+	"github.com/radmickey/money-control/backend/pkg/cache"
+	"github.com/radmickey/money-control/backend/pkg/logger"
 	"github.com/radmickey/money-control/backend/services/insights/models"
 	"github.com/radmickey/money-control/backend/services/insights/repository"
-	"google.golang.org/grpc"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
 	assetspb "github.com/radmickey/money-control/backend/proto/assets"
+	authpb "github.com/radmickey/money-control/backend/proto/auth"
 	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
 )
 
 // ServiceClients holds gRPC clients for other services
 type ServiceClients struct {
+	AuthClient         authpb.AuthServiceClient
 	AccountsClient     accountspb.AccountsServiceClient
 	AssetsClient       assetspb.AssetsServiceClient
 	TransactionsClient transactionspb.TransactionsServiceClient
@@ -28,62 +34,108 @@ type ServiceClients struct {
 // InsightService handles insights business logic
 type InsightService struct {
 	snapshotRepo *repository.SnapshotRepository
+	goalRepo     *repository.GoalRepository
+	targetRepo   *repository.TargetAllocationRepository
 	clients      *ServiceClients
+	conns        *connectionManager
+	cache        *cache.Cache
+	log          *logger.Logger
+	stopChan     chan struct{}
+
+	// Background jobs, waited for by Stop
+	jobs sync.WaitGroup
 }
 
-// NewInsightService creates a new insight service
+// NewInsightService creates a new insight service connected to the other
+// services. Services with an empty URL are skipped. redisCache coordinates
+// background jobs between replicas and may be nil for a single replica.
 func NewInsightService(
 	snapshotRepo *repository.SnapshotRepository,
-	accountsURL, assetsURL, transactionsURL, currencyURL string,
+	goalRepo *repository.GoalRepository,
+	targetRepo *repository.TargetAllocationRepository,
+	authURL, accountsURL, assetsURL, transactionsURL, currencyURL string,
+	log *logger.Logger,
+	redisCache *cache.Cache,
 ) (*InsightService, error) {
 	clients := &ServiceClients{}
+	conns := newConnectionManager()
+
+	// Connect to Auth service
+	if authURL != "" {
+		conn, err := conns.dial("auth", authURL)
+		if err != nil {
+			conns.Close()
+			return nil, err
+		}
+		clients.AuthClient = authpb.NewAuthServiceClient(conn)
+	}
 
 	// Connect to Accounts service
 	if accountsURL != "" {
-		conn, err := grpc.Dial(accountsURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err == nil {
-			clients.AccountsClient = accountspb.NewAccountsServiceClient(conn)
+		conn, err := conns.dial("accounts", accountsURL)
+		if err != nil {
+			conns.Close()
+			return nil, err
 		}
+		clients.AccountsClient = accountspb.NewAccountsServiceClient(conn)
 	}
 
 	// Connect to Assets service
 	if assetsURL != "" {
-		conn, err := grpc.Dial(assetsURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err == nil {
-			clients.AssetsClient = assetspb.NewAssetsServiceClient(conn)
+		conn, err := conns.dial("assets", assetsURL)
+		if err != nil {
+			conns.Close()
+			return nil, err
 		}
+		clients.AssetsClient = assetspb.NewAssetsServiceClient(conn)
 	}
 
 	// Connect to Transactions service
 	if transactionsURL != "" {
-		conn, err := grpc.Dial(transactionsURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err == nil {
-			clients.TransactionsClient = transactionspb.NewTransactionsServiceClient(conn)
+		conn, err := conns.dial("transactions", transactionsURL)
+		if err != nil {
+			conns.Close()
+			return nil, err
 		}
+		clients.TransactionsClient = transactionspb.NewTransactionsServiceClient(conn)
 	}
 
 	// Connect to Currency service
 	if currencyURL != "" {
-		conn, err := grpc.Dial(currencyURL, grpc.WithTransportCredentials(insecure.NewCredentials()))
-		if err == nil {
-			clients.CurrencyClient = currencypb.NewCurrencyServiceClient(conn)
+		conn, err := conns.dial("currency", currencyURL)
+		if err != nil {
+			conns.Close()
+			return nil, err
 		}
+		clients.CurrencyClient = currencypb.NewCurrencyServiceClient(conn)
 	}
 
 	return &InsightService{
 		snapshotRepo: snapshotRepo,
+		goalRepo:     goalRepo,
+		targetRepo:   targetRepo,
 		clients:      clients,
+		conns:        conns,
+		cache:        redisCache,
+		log:          log,
+		stopChan:     make(chan struct{}),
 	}, nil
 }
 
+// Close releases the connections to other services
+func (s *InsightService) Close() {
+	s.conns.Close()
+}
+
 // GetNetWorth calculates current net worth
 func (s *InsightService) GetNetWorth(ctx context.Context, userID, baseCurrency string) (*models.NetWorthData, error) {
 	if baseCurrency == "" {
 		baseCurrency = "USD"
 	}
 
-	// Get current net worth from accounts service
-	var totalNetWorth float64
+	// Get current net worth from accounts service; it already subtracts
+	// liabilities from assets
+	var totalNetWorth, totalAssets, totalLiabilities float64
 	if s.clients.AccountsClient != nil {
 		resp, err := s.clients.AccountsClient.GetUserNetWorth(ctx, &accountspb.GetUserNetWorthRequest{
 			UserId:       userID,
@@ -91,6 +143,8 @@ func (s *InsightService) GetNetWorth(ctx context.Context, userID, baseCurrency s
 		})
 		if err == nil {
 			totalNetWorth = resp.TotalNetWorth
+			totalAssets = resp.TotalAssets
+			totalLiabilities = resp.TotalLiabilities
 		}
 	}
 
@@ -120,6 +174,8 @@ func (s *InsightService) GetNetWorth(ctx context.Context, userID, baseCurrency s
 	return &models.NetWorthData{
 		TotalNetWorth:    totalNetWorth,
 		Currency:         baseCurrency,
+		TotalAssets:      totalAssets,
+		TotalLiabilities: totalLiabilities,
 		Change24h:        change24h,
 		ChangePercent24h: calculatePercent(change24h, totalNetWorth-change24h),
 		Change7d:         change7d,
@@ -130,9 +186,32 @@ func (s *InsightService) GetNetWorth(ctx context.Context, userID, baseCurrency s
 	}, nil
 }
 
-// GetNetWorthHistory gets net worth history
-func (s *InsightService) GetNetWorthHistory(ctx context.Context, userID, baseCurrency, period string, startDate, endDate time.Time) ([]models.TrendPoint, error) {
-	// Calculate date range based on period if not provided
+// GetNetWorthHistory gets net worth history. Unless raw is set, days without
+// a snapshot are forward-filled from the last known value and the series is
+// bucketed to the range's granularity (daily, weekly or monthly).
+func (s *InsightService) GetNetWorthHistory(ctx context.Context, userID, baseCurrency, period string, startDate, endDate time.Time, raw bool) ([]models.TrendPoint, error) {
+	startDate, endDate = periodRange(period, startDate, endDate)
+
+	points, err := s.snapshotRepo.GetNetWorthHistory(ctx, userID, startDate, endDate)
+	if err != nil || raw {
+		return points, err
+	}
+
+	// The last snapshot before the range seeds the fill for its first days
+	seed, err := s.snapshotRepo.GetLatestBefore(ctx, userID, startDate)
+	if err != nil {
+		return nil, err
+	}
+	if seed != nil {
+		points = append([]models.TrendPoint{{Date: seed.Date, Value: seed.TotalNetWorth}}, points...)
+	}
+
+	return fillHistory(points, startDate, endDate, historyGranularity(startDate, endDate)), nil
+}
+
+// periodRange fills in a missing start date from a period shortcut (1w, 1m,
+// 3m, 6m or 1y; default 1m) and a missing end date with now
+func periodRange(period string, startDate, endDate time.Time) (time.Time, time.Time) {
 	now := time.Now()
 	if startDate.IsZero() {
 		switch period {
@@ -153,11 +232,107 @@ func (s *InsightService) GetNetWorthHistory(ctx context.Context, userID, baseCur
 	if endDate.IsZero() {
 		endDate = now
 	}
+	return startDate, endDate
+}
+
+// GetBalanceChanges compares the allocation stored on the snapshot at the
+// start of the range with the latest one, per asset type. Categories present
+// in only one snapshot count from or to zero. Changes are sorted by magnitude.
+// It returns the changes, the total change and the total change percent.
+func (s *InsightService) GetBalanceChanges(ctx context.Context, userID, period string, startDate, endDate time.Time) ([]models.BalanceChange, float64, float64, error) {
+	startDate, endDate = periodRange(period, startDate, endDate)
+
+	// Snapshots are daily, so compare against the day after each bound
+	latest, err := s.snapshotRepo.GetLatestBefore(ctx, userID, endDate.Truncate(24*time.Hour).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if latest == nil {
+		return []models.BalanceChange{}, 0, 0, nil
+	}
+
+	// Fall back to the first snapshot in range for users newer than the range
+	base, err := s.snapshotRepo.GetLatestBefore(ctx, userID, startDate.Truncate(24*time.Hour).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, 0, 0, err
+	}
+	if base == nil {
+		base, err = s.snapshotRepo.GetEarliestSince(ctx, userID, startDate)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+	}
+
+	ending := allocationByCategory(latest)
+	starting := ending
+	if base != nil && base.ID != latest.ID {
+		starting = allocationByCategory(base)
+	}
+
+	var startTotal, endTotal float64
+	changes := make([]models.BalanceChange, 0, len(ending))
+	seen := make(map[string]bool, len(ending))
+	add := func(category string) {
+		if seen[category] {
+			return
+		}
+		seen[category] = true
+
+		from, to := starting[category], ending[category]
+		name := to.Name
+		if name == "" {
+			name = from.Name
+		}
+		change := to.Value - from.Value
+		changes = append(changes, models.BalanceChange{
+			AccountID:       category,
+			AccountName:     name,
+			StartingBalance: from.Value,
+			EndingBalance:   to.Value,
+			Change:          change,
+			ChangePercent:   calculatePercent(change, from.Value),
+		})
+		startTotal += from.Value
+		endTotal += to.Value
+	}
+	for category := range ending {
+		add(category)
+	}
+	for category := range starting {
+		add(category)
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if math.Abs(changes[i].Change) != math.Abs(changes[j].Change) {
+			return math.Abs(changes[i].Change) > math.Abs(changes[j].Change)
+		}
+		return changes[i].AccountID < changes[j].AccountID
+	})
 
-	return s.snapshotRepo.GetNetWorthHistory(ctx, userID, startDate, endDate)
+	totalChange := endTotal - startTotal
+	return changes, totalChange, calculatePercent(totalChange, startTotal), nil
 }
 
-// GetAllocation gets asset allocation breakdown
+// allocationByCategory decodes a snapshot's stored allocation keyed by category
+func allocationByCategory(snapshot *models.Snapshot) map[string]models.AllocationItem {
+	var items []models.AllocationItem
+	if snapshot.AllocationData != "" {
+		if err := json.Unmarshal([]byte(snapshot.AllocationData), &items); err != nil {
+			log.Printf("Invalid allocation data on snapshot %s: %v", snapshot.ID, err)
+		}
+	}
+
+	byCategory := make(map[string]models.AllocationItem, len(items))
+	for _, item := range items {
+		existing := byCategory[item.Category]
+		item.Value += existing.Value
+		byCategory[item.Category] = item
+	}
+	return byCategory
+}
+
+// GetAllocation gets asset allocation breakdown. Percentages are of total
+// assets, since liabilities aren't part of any asset type.
 func (s *InsightService) GetAllocation(ctx context.Context, userID, baseCurrency, groupBy string) ([]models.AllocationItem, float64, error) {
 	if baseCurrency == "" {
 		baseCurrency = "USD"
@@ -173,7 +348,7 @@ func (s *InsightService) GetAllocation(ctx context.Context, userID, baseCurrency
 			BaseCurrency: baseCurrency,
 		})
 		if err == nil {
-			totalValue = resp.TotalNetWorth
+			totalValue = resp.TotalAssets
 
 			// Group by asset type
 			for assetType, value := range resp.ByAssetType {
@@ -212,6 +387,8 @@ func (s *InsightService) GetDashboardSummary(ctx context.Context, userID, baseCu
 		summary.NetWorth = netWorth.TotalNetWorth
 		summary.NetWorthChange24h = netWorth.Change24h
 		summary.NetWorthChangePercent = netWorth.ChangePercent24h
+		summary.TotalAssets = netWorth.TotalAssets
+		summary.TotalLiabilities = netWorth.TotalLiabilities
 	}
 
 	// Get allocations
@@ -221,6 +398,15 @@ func (s *InsightService) GetDashboardSummary(ctx context.Context, userID, baseCu
 	}
 	summary.TopAllocations = allocations
 
+	summary.RecentTransactions = s.recentTransactions(ctx, userID)
+	summary.TopPerformers = s.topPerformers(ctx, userID, baseCurrency)
+
+	if goals, err := s.ListGoalProgress(ctx, userID); err != nil {
+		log.Printf("Failed to get goals for user %s: %v", userID, err)
+	} else {
+		summary.Goals = goals
+	}
+
 	// Get monthly transactions summary
 	if s.clients.TransactionsClient != nil {
 		now := time.Now()
@@ -247,8 +433,6 @@ func (s *InsightService) GetDashboardSummary(ctx context.Context, userID, baseCu
 
 // GetCashFlow gets cash flow analysis
 func (s *InsightService) GetCashFlow(ctx context.Context, userID, baseCurrency, period string, startDate, endDate time.Time) ([]models.CashFlowPeriod, float64, float64, float64, error) {
-	// Implementation depends on transaction data
-	// This is a simplified version
 	now := time.Now()
 	if startDate.IsZero() {
 		startDate = now.AddDate(0, -6, 0) // Last 6 months
@@ -260,45 +444,84 @@ func (s *InsightService) GetCashFlow(ctx context.Context, userID, baseCurrency,
 	var periods []models.CashFlowPeriod
 	var totalIncome, totalExpenses, netCashFlow float64
 
-	// Generate monthly periods
-	current := startDate
-	for current.Before(endDate) {
-		monthEnd := current.AddDate(0, 1, 0)
-		if monthEnd.After(endDate) {
-			monthEnd = endDate
-		}
-
-		period := models.CashFlowPeriod{
-			Label:     current.Format("Jan 2006"),
-			StartDate: current,
-			EndDate:   monthEnd,
+	// Monthly buckets are totalled by the transactions service in one call
+	if s.clients.TransactionsClient != nil {
+		resp, err := s.clients.TransactionsClient.GetMonthlyCashFlow(ctx, &transactionspb.GetMonthlyCashFlowRequest{
+			UserId:       userID,
+			StartDate:    timestampProto(startDate),
+			EndDate:      timestampProto(endDate),
+			BaseCurrency: baseCurrency,
+		})
+		if err != nil {
+			log.Printf("Failed to get cash flow for user %s: %v", userID, err)
+		} else {
+			for _, b := range resp.Buckets {
+				periods = append(periods, models.CashFlowPeriod{
+					Label:     b.StartDate.AsTime().Format("Jan 2006"),
+					StartDate: b.StartDate.AsTime(),
+					EndDate:   b.EndDate.AsTime(),
+					Income:    b.Income,
+					Expenses:  b.Expenses,
+					Net:       b.Income - b.Expenses,
+				})
+				totalIncome += b.Income
+				totalExpenses += b.Expenses
+			}
 		}
+	}
 
-		// Get transactions for this period
-		if s.clients.TransactionsClient != nil {
-			resp, err := s.clients.TransactionsClient.GetTransactionsSummary(ctx, &transactionspb.GetTransactionsSummaryRequest{
-				UserId:       userID,
-				StartDate:    timestampProto(current),
-				EndDate:      timestampProto(monthEnd),
-				BaseCurrency: baseCurrency,
-			})
-			if err == nil {
-				period.Income = resp.TotalIncome
-				period.Expenses = resp.TotalExpenses
-				period.Net = resp.TotalIncome - resp.TotalExpenses
-				totalIncome += resp.TotalIncome
-				totalExpenses += resp.TotalExpenses
+	// Without transaction data, still report empty monthly periods
+	if periods == nil {
+		for current := startDate; current.Before(endDate); {
+			monthEnd := current.AddDate(0, 1, 0)
+			if monthEnd.After(endDate) {
+				monthEnd = endDate
 			}
+			periods = append(periods, models.CashFlowPeriod{
+				Label:     current.Format("Jan 2006"),
+				StartDate: current,
+				EndDate:   monthEnd,
+			})
+			current = monthEnd
 		}
+	}
 
-		periods = append(periods, period)
-		current = monthEnd
+	// Dividends recorded against assets count as investment income
+	for i := range periods {
+		if dividends := s.dividendIncome(ctx, userID, baseCurrency, periods[i].StartDate, periods[i].EndDate); dividends > 0 {
+			periods[i].InvestmentIncome = dividends
+			periods[i].Income += dividends
+			periods[i].Net += dividends
+			totalIncome += dividends
+		}
 	}
 
 	netCashFlow = totalIncome - totalExpenses
 	return periods, totalIncome, totalExpenses, netCashFlow, nil
 }
 
+// dividendIncome returns dividends paid in [start, end) converted to baseCurrency
+func (s *InsightService) dividendIncome(ctx context.Context, userID, baseCurrency string, start, end time.Time) float64 {
+	if s.clients.AssetsClient == nil {
+		return 0
+	}
+
+	resp, err := s.clients.AssetsClient.GetDividendIncome(ctx, &assetspb.GetDividendIncomeRequest{
+		UserId:    userID,
+		StartDate: timestampProto(start),
+		EndDate:   timestampProto(end),
+	})
+	if err != nil {
+		return 0
+	}
+
+	var total float64
+	for currency, amount := range resp.ByCurrency {
+		total += s.convertAmount(ctx, amount, currency, baseCurrency)
+	}
+	return total
+}
+
 // CreateSnapshot creates a daily snapshot
 func (s *InsightService) CreateSnapshot(ctx context.Context, userID, baseCurrency string) (*models.Snapshot, error) {
 	if baseCurrency == "" {
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	"github.com/radmickey/money-control/backend/services/insights/models"
+	"github.com/radmickey/money-control/backend/services/insights/repository"
+)
+
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+// seedSnapshot stores a snapshot whose allocation holds values by category
+func seedSnapshot(t *testing.T, repo *repository.SnapshotRepository, date time.Time, values map[string]float64) {
+	t.Helper()
+	var items []models.AllocationItem
+	var total float64
+	for category, value := range values {
+		items = append(items, models.AllocationItem{Category: category, Name: category, Value: value})
+		total += value
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.Create(context.Background(), &models.Snapshot{
+		UserID: testUserID, TotalNetWorth: total, Currency: "USD", AllocationData: string(data), Date: date,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetBalanceChanges(t *testing.T) {
+	db := databasetest.Open(t, &models.Snapshot{})
+	repo := repository.NewSnapshotRepository(db)
+	s := &InsightService{snapshotRepo: repo}
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	seedSnapshot(t, repo, start, map[string]float64{"stocks": 1000, "cash": 500, "crypto": 200})
+	seedSnapshot(t, repo, start.AddDate(0, 0, 10), map[string]float64{"stocks": 9999})
+	// Crypto was sold off and bonds bought during the month
+	seedSnapshot(t, repo, end, map[string]float64{"stocks": 1500, "cash": 400, "bonds": 300})
+	// After the range
+	seedSnapshot(t, repo, end.AddDate(0, 0, 5), map[string]float64{"stocks": 5000})
+
+	changes, total, percent, err := s.GetBalanceChanges(context.Background(), testUserID, "", start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []models.BalanceChange{
+		{AccountID: "stocks", AccountName: "stocks", StartingBalance: 1000, EndingBalance: 1500, Change: 500, ChangePercent: 50},
+		{AccountID: "bonds", AccountName: "bonds", StartingBalance: 0, EndingBalance: 300, Change: 300, ChangePercent: 0},
+		{AccountID: "crypto", AccountName: "crypto", StartingBalance: 200, EndingBalance: 0, Change: -200, ChangePercent: -100},
+		{AccountID: "cash", AccountName: "cash", StartingBalance: 500, EndingBalance: 400, Change: -100, ChangePercent: -20},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("change %d = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+
+	if total != 500 || math.Abs(percent-500.0/1700*100) > 1e-9 {
+		t.Errorf("total change %v (%v%%), want 500 (%v%%)", total, percent, 500.0/1700*100)
+	}
+}
+
+func TestGetBalanceChangesSingleSnapshot(t *testing.T) {
+	db := databasetest.Open(t, &models.Snapshot{})
+	repo := repository.NewSnapshotRepository(db)
+	s := &InsightService{snapshotRepo: repo}
+
+	// A user newer than the range is compared against their first snapshot
+	day := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	seedSnapshot(t, repo, day, map[string]float64{"cash": 250})
+
+	changes, total, _, err := s.GetBalanceChanges(context.Background(), testUserID, "", day.AddDate(0, -1, 0), day)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Change != 0 || changes[0].EndingBalance != 250 || total != 0 {
+		t.Errorf("changes = %+v, total %v; want cash unchanged at 250", changes, total)
+	}
+}
+
+func TestGetBalanceChangesNoSnapshots(t *testing.T) {
+	db := databasetest.Open(t, &models.Snapshot{})
+	s := &InsightService{snapshotRepo: repository.NewSnapshotRepository(db)}
+
+	changes, total, _, err := s.GetBalanceChanges(context.Background(), testUserID, "1m", time.Time{}, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 || total != 0 {
+		t.Errorf("changes = %+v, total %v; want none", changes, total)
+	}
+}
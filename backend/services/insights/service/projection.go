@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+)
+
+// ProjectionMethod is the trend fitted to snapshots for a projection
+type ProjectionMethod string
+
+const (
+	// ProjectionLinear fits a straight line to net worth
+	ProjectionLinear ProjectionMethod = "linear"
+	// ProjectionCAGR fits a constant growth rate (a line through log net worth)
+	ProjectionCAGR ProjectionMethod = "cagr"
+)
+
+const (
+	// minProjectionSnapshots is the least history a projection is fitted to
+	minProjectionSnapshots = 14
+	// projectionSnapshots is how many recent snapshots a projection uses
+	projectionSnapshots = 365
+	// defaultProjectionHorizon and maxProjectionHorizon are in months
+	defaultProjectionHorizon = 12
+	maxProjectionHorizon     = 120
+	// projectionZ is the normal quantile for a 95% confidence band
+	projectionZ = 1.96
+)
+
+var (
+	ErrInsufficientHistory      = fmt.Errorf("at least %d snapshots are needed for a projection", minProjectionSnapshots)
+	ErrInvalidProjectionMethod  = errors.New("method must be linear or cagr")
+	ErrInvalidProjectionHorizon = fmt.Errorf("horizon must be between 1 and %d months", maxProjectionHorizon)
+	ErrProjectionNonPositive    = errors.New("cagr projection needs a positive net worth in every snapshot")
+)
+
+// ProjectNetWorth fits a trend to the user's recent snapshots and projects
+// net worth monthly for horizon months past the latest one, with a 95%
+// prediction band
+func (s *InsightService) ProjectNetWorth(ctx context.Context, userID string, horizon int, method ProjectionMethod) (*models.NetWorthProjection, error) {
+	if method == "" {
+		method = ProjectionLinear
+	}
+	if method != ProjectionLinear && method != ProjectionCAGR {
+		return nil, ErrInvalidProjectionMethod
+	}
+	if horizon == 0 {
+		horizon = defaultProjectionHorizon
+	}
+	if horizon < 1 || horizon > maxProjectionHorizon {
+		return nil, ErrInvalidProjectionHorizon
+	}
+
+	snapshots, err := s.snapshotRepo.GetByDateRange(ctx, userID, time.Time{}, time.Time{}, projectionSnapshots)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshots) < minProjectionSnapshots {
+		return nil, ErrInsufficientHistory
+	}
+
+	// Snapshots come newest first; x is days since the oldest one
+	oldest, latest := snapshots[len(snapshots)-1], snapshots[0]
+	xs := make([]float64, len(snapshots))
+	ys := make([]float64, len(snapshots))
+	for i, snapshot := range snapshots {
+		xs[i] = snapshot.Date.Sub(oldest.Date).Hours() / 24
+		ys[i] = snapshot.TotalNetWorth
+		if method == ProjectionCAGR {
+			if snapshot.TotalNetWorth <= 0 {
+				return nil, ErrProjectionNonPositive
+			}
+			ys[i] = math.Log(snapshot.TotalNetWorth)
+		}
+	}
+
+	fit, ok := fitLinear(xs, ys)
+	if !ok {
+		return nil, ErrInsufficientHistory
+	}
+
+	projection := &models.NetWorthProjection{
+		Method:        string(method),
+		Currency:      latest.Currency,
+		Points:        make([]models.ProjectionPoint, 0, horizon),
+		SnapshotsUsed: len(snapshots),
+	}
+
+	latestX := latest.Date.Sub(oldest.Date).Hours() / 24
+	switch method {
+	case ProjectionLinear:
+		projection.MonthlyChange = fit.slope * daysPerMonth
+		if current := fit.at(latestX); current > 0 {
+			projection.AnnualGrowthRate = fit.slope * 365.25 / current * 100
+		}
+	case ProjectionCAGR:
+		projection.MonthlyChange = math.Exp(fit.at(latestX)) * (math.Exp(fit.slope*daysPerMonth) - 1)
+		projection.AnnualGrowthRate = (math.Exp(fit.slope*365.25) - 1) * 100
+	}
+
+	for i := 1; i <= horizon; i++ {
+		date := latest.Date.AddDate(0, i, 0)
+		x := date.Sub(oldest.Date).Hours() / 24
+		value, band := fit.at(x), projectionZ*fit.predictionError(x)
+
+		lower, upper := value-band, value+band
+		if method == ProjectionCAGR {
+			value, lower, upper = math.Exp(value), math.Exp(lower), math.Exp(upper)
+		}
+		projection.Points = append(projection.Points, models.ProjectionPoint{
+			Date:  date,
+			Value: value,
+			Lower: lower,
+			Upper: upper,
+		})
+	}
+
+	return projection, nil
+}
+
+// linearFit is an ordinary least squares line with what is needed for
+// prediction intervals
+type linearFit struct {
+	intercept, slope float64
+	n                int
+	meanX, sxx       float64
+	residualStdDev   float64
+}
+
+// fitLinear fits y = intercept + slope*x. It needs at least three points
+// spread over more than one x value.
+func fitLinear(xs, ys []float64) (linearFit, bool) {
+	n := len(xs)
+	if n < 3 {
+		return linearFit{}, false
+	}
+
+	var sumX, sumY float64
+	for i := range xs {
+		sumX += xs[i]
+		sumY += ys[i]
+	}
+	meanX, meanY := sumX/float64(n), sumY/float64(n)
+
+	var sxx, sxy float64
+	for i := range xs {
+		sxx += (xs[i] - meanX) * (xs[i] - meanX)
+		sxy += (xs[i] - meanX) * (ys[i] - meanY)
+	}
+	if sxx == 0 {
+		return linearFit{}, false
+	}
+
+	fit := linearFit{slope: sxy / sxx, n: n, meanX: meanX, sxx: sxx}
+	fit.intercept = meanY - fit.slope*meanX
+
+	var sse float64
+	for i := range xs {
+		r := ys[i] - fit.at(xs[i])
+		sse += r * r
+	}
+	fit.residualStdDev = math.Sqrt(sse / float64(n-2))
+
+	return fit, true
+}
+
+func (f linearFit) at(x float64) float64 {
+	return f.intercept + f.slope*x
+}
+
+// predictionError is the standard error of a new observation at x, which
+// widens with distance from the fitted data
+func (f linearFit) predictionError(x float64) float64 {
+	d := x - f.meanX
+	return f.residualStdDev * math.Sqrt(1+1/float64(f.n)+d*d/f.sxx)
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/radmickey/money-control/backend/services/insights/models"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+)
+
+const (
+	// defaultRebalanceTolerance is how many percentage points an asset type
+	// may drift from its target before a trade is suggested
+	defaultRebalanceTolerance = 5.0
+	// defaultMinRebalanceTrade is the smallest trade suggested, in base currency
+	defaultMinRebalanceTrade = 100.0
+)
+
+var (
+	ErrInvalidAssetType        = errors.New("unknown asset type")
+	ErrInvalidTargetAllocation = errors.New("target percentages must be positive and add up to 100")
+	ErrNoTargetAllocation      = errors.New("no target allocation set")
+)
+
+// GetTargetAllocation gets a user's target allocation
+func (s *InsightService) GetTargetAllocation(ctx context.Context, userID string) ([]models.TargetAllocation, error) {
+	return s.targetRepo.ListByUser(ctx, userID)
+}
+
+// SetTargetAllocation replaces a user's target allocation with percentages by
+// asset type. An empty set clears it.
+func (s *InsightService) SetTargetAllocation(ctx context.Context, userID string, targets map[string]float64) ([]models.TargetAllocation, error) {
+	allocation := make([]models.TargetAllocation, 0, len(targets))
+	var total float64
+	for assetType, percentage := range targets {
+		assetType = strings.ToLower(assetType)
+		if _, ok := accountspb.AssetType_value["ASSET_TYPE_"+strings.ToUpper(assetType)]; !ok || assetType == "unspecified" {
+			return nil, ErrInvalidAssetType
+		}
+		if percentage <= 0 || percentage > 100 {
+			return nil, ErrInvalidTargetAllocation
+		}
+		total += percentage
+		allocation = append(allocation, models.TargetAllocation{
+			UserID:     userID,
+			AssetType:  assetType,
+			Percentage: percentage,
+		})
+	}
+	if len(allocation) > 0 && math.Abs(total-100) > 0.01 {
+		return nil, ErrInvalidTargetAllocation
+	}
+
+	if err := s.targetRepo.Replace(ctx, userID, allocation); err != nil {
+		return nil, err
+	}
+	return s.targetRepo.ListByUser(ctx, userID)
+}
+
+// GetRebalancing compares the user's allocation by asset type with their
+// target and suggests buying or selling to bring types that drifted more than
+// tolerance percentage points back to target. Trades smaller than minTrade
+// are not suggested. Values are in baseCurrency.
+func (s *InsightService) GetRebalancing(ctx context.Context, userID, baseCurrency string, tolerance, minTrade float64) (*models.Rebalancing, error) {
+	if baseCurrency == "" {
+		baseCurrency = "USD"
+	}
+	if tolerance <= 0 {
+		tolerance = defaultRebalanceTolerance
+	}
+	if minTrade <= 0 {
+		minTrade = defaultMinRebalanceTrade
+	}
+
+	targets, err := s.targetRepo.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, ErrNoTargetAllocation
+	}
+
+	balances, err := s.balancesByAssetType(ctx, userID, baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Negative balances are debts, not holdings to rebalance
+	targetPercent := make(map[string]float64, len(targets))
+	for _, t := range targets {
+		targetPercent[t.AssetType] = t.Percentage
+	}
+	var total float64
+	for assetType, value := range balances {
+		if value <= 0 {
+			delete(balances, assetType)
+			continue
+		}
+		total += value
+		if _, ok := targetPercent[assetType]; !ok {
+			targetPercent[assetType] = 0
+		}
+	}
+
+	rebalancing := &models.Rebalancing{
+		Currency:   baseCurrency,
+		TotalValue: total,
+		Tolerance:  tolerance,
+		MinTrade:   minTrade,
+		Items:      make([]models.RebalanceItem, 0, len(targetPercent)),
+	}
+
+	for assetType, target := range targetPercent {
+		item := models.RebalanceItem{
+			AssetType:     assetType,
+			CurrentValue:  balances[assetType],
+			TargetPercent: target,
+			TargetValue:   total * target / 100,
+			Action:        models.RebalanceHold,
+		}
+		if total > 0 {
+			item.CurrentPercent = item.CurrentValue / total * 100
+		}
+		item.Drift = item.CurrentPercent - target
+
+		trade := item.TargetValue - item.CurrentValue
+		if math.Abs(item.Drift) > tolerance && math.Abs(trade) >= minTrade {
+			item.Action = models.RebalanceBuy
+			if trade < 0 {
+				item.Action = models.RebalanceSell
+			}
+			item.Amount = math.Abs(trade)
+			rebalancing.NeedsRebalancing = true
+		}
+
+		rebalancing.Items = append(rebalancing.Items, item)
+	}
+
+	sort.Slice(rebalancing.Items, func(i, j int) bool {
+		a, b := rebalancing.Items[i], rebalancing.Items[j]
+		if math.Abs(a.Drift) != math.Abs(b.Drift) {
+			return math.Abs(a.Drift) > math.Abs(b.Drift)
+		}
+		return a.AssetType < b.AssetType
+	})
+
+	return rebalancing, nil
+}
+
+// balancesByAssetType totals sub-account balances by asset type in
+// baseCurrency; liability accounts are left out
+func (s *InsightService) balancesByAssetType(ctx context.Context, userID, baseCurrency string) (map[string]float64, error) {
+	accounts, err := s.listAccounts(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]float64)
+	for _, account := range accounts {
+		if account.IsLiability {
+			continue
+		}
+		for _, sub := range account.SubAccounts {
+			assetType := strings.ToLower(strings.TrimPrefix(sub.AssetType.String(), "ASSET_TYPE_"))
+			balances[assetType] += s.convertAmount(ctx, sub.Balance, sub.Currency, baseCurrency)
+		}
+	}
+	return balances, nil
+}
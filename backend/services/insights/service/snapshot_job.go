@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	authpb "github.com/radmickey/money-control/backend/proto/auth"
+)
+
+const (
+	// snapshotUsersPageSize is how many users are fetched per ListUsers call
+	snapshotUsersPageSize = 200
+	// snapshotUserTimeout bounds the snapshot of a single user
+	snapshotUserTimeout = 30 * time.Second
+)
+
+var (
+	ErrAuthUnavailable = errors.New("auth service not configured")
+)
+
+// dailySnapshotsJob names the snapshot run for the job lock shared by replicas
+const dailySnapshotsJob = "daily-snapshots"
+
+// StartDailySnapshots snapshots every active user's net worth once a day at
+// the given UTC time of day ("15:04"), delayed by up to jitter so replicas and
+// restarts don't all hit the accounts service at once. With several replicas,
+// each day's run happens on only one of them.
+func (s *InsightService) StartDailySnapshots(at string, jitter time.Duration) {
+	offset, err := parseTimeOfDay(at)
+	if err != nil {
+		s.log.Warn("invalid snapshot time, using midnight UTC", "time", at, "error", err)
+	}
+
+	// Cancel an in-flight run on Stop
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-s.stopChan
+		cancel()
+	}()
+
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+
+		for {
+			timer := time.NewTimer(time.Until(nextSnapshotRun(time.Now(), offset, jitter)))
+			select {
+			case <-timer.C:
+				s.runDailySnapshotJob(ctx, offset)
+			case <-s.stopChan:
+				timer.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// runDailySnapshotJob runs today's snapshots unless another replica is
+// running them or already has
+func (s *InsightService) runDailySnapshotJob(ctx context.Context, offset time.Duration) {
+	// The day the run was scheduled for, however late jitter made it fire
+	run := time.Now().UTC().Add(-offset).Format("2006-01-02")
+
+	start := time.Now()
+	var created, failed int
+	ran, err := s.cache.RunSingleton(ctx, dailySnapshotsJob, run, 24*time.Hour, func(ctx context.Context) error {
+		var err error
+		created, failed, err = s.RunDailySnapshots(ctx)
+		if err != nil {
+			return err
+		}
+		// Don't record an interrupted run as done
+		return ctx.Err()
+	})
+	if err != nil {
+		s.log.Error("daily snapshot job failed", "created", created, "error", err)
+		return
+	}
+	if !ran {
+		s.log.Info("daily snapshot job skipped, handled by another replica", "run", run)
+		return
+	}
+	s.log.Info("daily snapshot job finished", "duration", time.Since(start).Round(time.Second), "created", created, "failed", failed)
+}
+
+// Stop stops the daily snapshot job, waiting for an in-flight run to stop
+func (s *InsightService) Stop() {
+	close(s.stopChan)
+	s.jobs.Wait()
+}
+
+// RunDailySnapshots creates today's snapshot for every active user in their
+// base currency. Snapshots are upserted per day, so reruns are safe.
+func (s *InsightService) RunDailySnapshots(ctx context.Context) (created, failed int, err error) {
+	if s.clients.AuthClient == nil {
+		return 0, 0, ErrAuthUnavailable
+	}
+
+	pageToken := ""
+	for {
+		resp, err := s.clients.AuthClient.ListUsers(ctx, &authpb.ListUsersRequest{
+			PageSize:  snapshotUsersPageSize,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			return created, failed, err
+		}
+
+		for _, user := range resp.Users {
+			select {
+			case <-s.stopChan:
+				return created, failed, nil
+			default:
+			}
+
+			userCtx, cancel := context.WithTimeout(ctx, snapshotUserTimeout)
+			_, err := s.CreateSnapshot(userCtx, user.Id, user.BaseCurrency)
+			cancel()
+			if err != nil {
+				s.log.WarnContext(ctx, "failed to create snapshot", "user_id", user.Id, "error", err)
+				failed++
+				continue
+			}
+			created++
+		}
+
+		if resp.NextPageToken == "" {
+			return created, failed, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// nextSnapshotRun returns the next time-of-day occurrence after now, plus a
+// random delay below jitter
+func nextSnapshotRun(now time.Time, offset, jitter time.Duration) time.Time {
+	now = now.UTC()
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC).Add(offset)
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	if jitter > 0 {
+		next = next.Add(time.Duration(rand.Int63n(int64(jitter))))
+	}
+	return next
+}
+
+// parseTimeOfDay converts "15:04" into an offset from midnight
+func parseTimeOfDay(value string) (time.Duration, error) {
+	if value == "" {
+		return 0, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
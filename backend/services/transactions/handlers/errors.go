@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/utils"
+	"github.com/radmickey/money-control/backend/services/transactions/repository"
+	"github.com/radmickey/money-control/backend/services/transactions/service"
+)
+
+// errorMap maps the transactions service's errors to HTTP responses
+var errorMap = utils.ErrorMap{
+	{Err: repository.ErrTransactionNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Transaction not found"},
+	{Err: repository.ErrBudgetNotFound, Status: http.StatusNotFound, Code: utils.CodeNotFound, Message: "Budget not found"},
+	{Err: repository.ErrBudgetExists, Status: http.StatusConflict, Code: utils.CodeConflict},
+	{Err: repository.ErrCursorSort, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: utils.ErrInvalidCursor, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrSubAccountNotFound, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrCurrencyMismatch, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidTransfer, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrSplitTooFewParts, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrSplitInvalidPart, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrSplitSumMismatch, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrSplitTransfer, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrImportMapping, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrImportColumn, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrExportRange, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrExportSpan, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidBudgetPeriod, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidBudgetAmount, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+	{Err: service.ErrInvalidPattern, Status: http.StatusBadRequest, Code: utils.CodeBadRequest},
+}
+
+// respondError writes err as an HTTP error response
+func respondError(c *gin.Context, err error) {
+	errorMap.Respond(c, err)
+}
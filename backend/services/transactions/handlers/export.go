@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+// exportColumns is the CSV header for transaction exports
+var exportColumns = []string{
+	"id", "date", "type", "amount", "currency", "category",
+	"custom_category", "merchant", "description", "sub_account_id",
+}
+
+// transactionWriter streams transactions in an export format
+type transactionWriter interface {
+	begin() error
+	write(transactions []models.Transaction) error
+	end() error
+}
+
+// csvTransactionWriter writes transactions as CSV rows
+type csvTransactionWriter struct {
+	w *csv.Writer
+}
+
+func newCSVTransactionWriter(w io.Writer) *csvTransactionWriter {
+	return &csvTransactionWriter{w: csv.NewWriter(w)}
+}
+
+func (cw *csvTransactionWriter) begin() error {
+	return cw.w.Write(exportColumns)
+}
+
+func (cw *csvTransactionWriter) write(transactions []models.Transaction) error {
+	for _, tx := range transactions {
+		subAccountID := ""
+		if tx.SubAccountID != nil {
+			subAccountID = *tx.SubAccountID
+		}
+		if err := cw.w.Write([]string{
+			tx.ID,
+			tx.Date.Format(time.RFC3339),
+			string(tx.Type),
+			strconv.FormatFloat(tx.Amount, 'f', -1, 64),
+			tx.Currency,
+			string(tx.Category),
+			csvSafe(tx.CustomCategory),
+			csvSafe(tx.Merchant),
+			csvSafe(tx.Description),
+			subAccountID,
+		}); err != nil {
+			return err
+		}
+	}
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+func (cw *csvTransactionWriter) end() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
+
+// csvSafe keeps free-text cells from being evaluated as spreadsheet formulas
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// jsonTransactionWriter writes transactions as a JSON array, one element at a time
+type jsonTransactionWriter struct {
+	w     io.Writer
+	first bool
+}
+
+func newJSONTransactionWriter(w io.Writer) *jsonTransactionWriter {
+	return &jsonTransactionWriter{w: w, first: true}
+}
+
+func (jw *jsonTransactionWriter) begin() error {
+	_, err := io.WriteString(jw.w, "[")
+	return err
+}
+
+func (jw *jsonTransactionWriter) write(transactions []models.Transaction) error {
+	for _, tx := range transactions {
+		data, err := json.Marshal(tx)
+		if err != nil {
+			return err
+		}
+		if !jw.first {
+			if _, err := io.WriteString(jw.w, ","); err != nil {
+				return err
+			}
+		}
+		jw.first = false
+		if _, err := jw.w.Write(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (jw *jsonTransactionWriter) end() error {
+	_, err := io.WriteString(jw.w, "]\n")
+	return err
+}
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/radmickey/money-control/backend/pkg/utils"
 	pb "github.com/radmickey/money-control/backend/proto/transactions"
 	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"github.com/radmickey/money-control/backend/services/transactions/repository"
 	"github.com/radmickey/money-control/backend/services/transactions/service"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -59,6 +62,9 @@ func (h *GRPCHandler) CreateTransaction(ctx context.Context, req *pb.CreateTrans
 		TransferToSubAccount: transferToSubAccount,
 	})
 	if err != nil {
+		if errors.Is(err, service.ErrSubAccountNotFound) || errors.Is(err, service.ErrCurrencyMismatch) || errors.Is(err, service.ErrInvalidTransfer) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to create transaction: %v", err)
 	}
 
@@ -69,7 +75,10 @@ func (h *GRPCHandler) CreateTransaction(ctx context.Context, req *pb.CreateTrans
 func (h *GRPCHandler) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.Transaction, error) {
 	tx, err := h.transactionService.GetTransaction(ctx, req.Id, req.UserId)
 	if err != nil {
-		return nil, status.Errorf(codes.NotFound, "transaction not found: %v", err)
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get transaction: %v", err)
 	}
 
 	return transactionToProto(tx), nil
@@ -77,21 +86,25 @@ func (h *GRPCHandler) GetTransaction(ctx context.Context, req *pb.GetTransaction
 
 // ListTransactions lists transactions for a user
 func (h *GRPCHandler) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
-	transactions, total, err := h.transactionService.ListTransactions(ctx, req.UserId, req.SubAccountId, int(req.Page), int(req.PageSize), req.SortBy, req.SortDesc)
+	result, err := h.transactionService.ListTransactions(ctx, req.UserId, req.SubAccountId, int(req.Page), int(req.PageSize), req.SortBy, req.SortDesc, req.Cursor)
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) || errors.Is(err, repository.ErrCursorSort) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to list transactions: %v", err)
 	}
 
-	pbTransactions := make([]*pb.Transaction, len(transactions))
-	for i, tx := range transactions {
+	pbTransactions := make([]*pb.Transaction, len(result.Items))
+	for i, tx := range result.Items {
 		pbTransactions[i] = transactionToProto(&tx)
 	}
 
 	return &pb.ListTransactionsResponse{
 		Transactions: pbTransactions,
-		Total:        int32(total),
+		Total:        int32(result.Total),
 		Page:         req.Page,
 		PageSize:     req.PageSize,
+		NextCursor:   result.NextCursor,
 	}, nil
 }
 
@@ -115,6 +128,12 @@ func (h *GRPCHandler) UpdateTransaction(ctx context.Context, req *pb.UpdateTrans
 		Currency:       req.Currency,
 	})
 	if err != nil {
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		if errors.Is(err, service.ErrSubAccountNotFound) || errors.Is(err, service.ErrCurrencyMismatch) || errors.Is(err, service.ErrInvalidTransfer) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update transaction: %v", err)
 	}
 
@@ -125,6 +144,9 @@ func (h *GRPCHandler) UpdateTransaction(ctx context.Context, req *pb.UpdateTrans
 func (h *GRPCHandler) DeleteTransaction(ctx context.Context, req *pb.DeleteTransactionRequest) (*emptypb.Empty, error) {
 	err := h.transactionService.DeleteTransaction(ctx, req.Id, req.UserId)
 	if err != nil {
+		if errors.Is(err, repository.ErrTransactionNotFound) {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to delete transaction: %v", err)
 	}
 
@@ -133,29 +155,72 @@ func (h *GRPCHandler) DeleteTransaction(ctx context.Context, req *pb.DeleteTrans
 
 // GetTransactionsByDateRange gets transactions by date range
 func (h *GRPCHandler) GetTransactionsByDateRange(ctx context.Context, req *pb.GetTransactionsByDateRangeRequest) (*pb.ListTransactionsResponse, error) {
-	// Use ListTransactions with filters
-	transactions, total, err := h.transactionService.ListTransactions(ctx, req.UserId, req.SubAccountId, int(req.Page), int(req.PageSize), "date", true)
+	var startDate, endDate time.Time
+	if req.StartDate != nil {
+		startDate = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		endDate = req.EndDate.AsTime()
+	}
+	if !startDate.IsZero() && !endDate.IsZero() && startDate.After(endDate) {
+		return nil, status.Errorf(codes.InvalidArgument, "start_date must not be after end_date")
+	}
+
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	result, err := h.transactionService.ListTransactionsByDateRange(ctx, req.UserId, startDate, endDate, req.SubAccountId, page, pageSize, req.Cursor)
 	if err != nil {
+		if errors.Is(err, utils.ErrInvalidCursor) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
 		return nil, status.Errorf(codes.Internal, "failed to get transactions: %v", err)
 	}
 
-	pbTransactions := make([]*pb.Transaction, len(transactions))
-	for i, tx := range transactions {
+	pbTransactions := make([]*pb.Transaction, len(result.Items))
+	for i, tx := range result.Items {
 		pbTransactions[i] = transactionToProto(&tx)
 	}
 
 	return &pb.ListTransactionsResponse{
 		Transactions: pbTransactions,
-		Total:        int32(total),
-		Page:         req.Page,
-		PageSize:     req.PageSize,
+		Total:        int32(result.Total),
+		Page:         int32(page),
+		PageSize:     int32(pageSize),
+		NextCursor:   result.NextCursor,
 	}, nil
 }
 
 // GetTransactionsByCategory gets transactions by category
 func (h *GRPCHandler) GetTransactionsByCategory(ctx context.Context, req *pb.GetTransactionsByCategoryRequest) (*pb.ListTransactionsResponse, error) {
-	// Use ListTransactions - category filtering would need to be added to service
-	transactions, total, err := h.transactionService.ListTransactions(ctx, req.UserId, "", int(req.Page), int(req.PageSize), "date", true)
+	if req.Category == pb.TransactionCategory_TRANSACTION_CATEGORY_UNSPECIFIED {
+		return nil, status.Errorf(codes.InvalidArgument, "category is required")
+	}
+
+	var startDate, endDate time.Time
+	if req.StartDate != nil {
+		startDate = req.StartDate.AsTime()
+	}
+	if req.EndDate != nil {
+		endDate = req.EndDate.AsTime()
+	}
+
+	page, pageSize := int(req.Page), int(req.PageSize)
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	transactions, total, err := h.transactionService.ListTransactionsByCategory(
+		ctx, req.UserId, protoToTransactionCategory(req.Category), startDate, endDate, page, pageSize,
+	)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get transactions: %v", err)
 	}
@@ -168,8 +233,8 @@ func (h *GRPCHandler) GetTransactionsByCategory(ctx context.Context, req *pb.Get
 	return &pb.ListTransactionsResponse{
 		Transactions: pbTransactions,
 		Total:        int32(total),
-		Page:         req.Page,
-		PageSize:     req.PageSize,
+		Page:         int32(page),
+		PageSize:     int32(pageSize),
 	}, nil
 }
 
@@ -183,7 +248,7 @@ func (h *GRPCHandler) GetTransactionsSummary(ctx context.Context, req *pb.GetTra
 		endDate = req.EndDate.AsTime()
 	}
 
-	summary, err := h.transactionService.GetTransactionsSummary(ctx, req.UserId, startDate, endDate)
+	summary, err := h.transactionService.GetTransactionsSummary(ctx, req.UserId, startDate, endDate, req.BaseCurrency)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get summary: %v", err)
 	}
@@ -222,6 +287,121 @@ func (h *GRPCHandler) BulkCategorize(ctx context.Context, req *pb.BulkCategorize
 }
 
 // Helper functions
+// DetectRecurring detects recurring transactions and predicts their next dates
+func (h *GRPCHandler) DetectRecurring(ctx context.Context, req *pb.DetectRecurringRequest) (*pb.DetectRecurringResponse, error) {
+	recurring, err := h.transactionService.DetectRecurring(ctx, req.UserId)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to detect recurring transactions: %v", err)
+	}
+
+	pbRecurring := make([]*pb.RecurringTransaction, len(recurring))
+	for i, r := range recurring {
+		pbRecurring[i] = &pb.RecurringTransaction{
+			Merchant:       r.Merchant,
+			Category:       transactionCategoryToProto(r.Category),
+			Type:           transactionTypeToProto(r.Type),
+			Currency:       r.Currency,
+			Cadence:        string(r.Cadence),
+			AverageAmount:  r.AverageAmount,
+			LastAmount:     r.LastAmount,
+			LastDate:       timestamppb.New(r.LastDate),
+			NextDate:       timestamppb.New(r.NextDate),
+			Occurrences:    int32(r.Occurrences),
+			Confidence:     r.Confidence,
+			TransactionIds: r.TransactionIDs,
+		}
+	}
+
+	return &pb.DetectRecurringResponse{Recurring: pbRecurring}, nil
+}
+
+// GetMonthlyCashFlow totals income and expenses per month in one query
+func (h *GRPCHandler) GetMonthlyCashFlow(ctx context.Context, req *pb.GetMonthlyCashFlowRequest) (*pb.MonthlyCashFlowResponse, error) {
+	if req.StartDate == nil || req.EndDate == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "start_date and end_date are required")
+	}
+
+	buckets, err := h.transactionService.GetMonthlyCashFlow(ctx, req.UserId, req.StartDate.AsTime(), req.EndDate.AsTime(), req.BaseCurrency)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get cash flow: %v", err)
+	}
+
+	pbBuckets := make([]*pb.CashFlowBucket, len(buckets))
+	for i, b := range buckets {
+		pbBuckets[i] = &pb.CashFlowBucket{
+			StartDate: timestamppb.New(b.StartDate),
+			EndDate:   timestamppb.New(b.EndDate),
+			Income:    b.Income,
+			Expenses:  b.Expenses,
+		}
+	}
+
+	return &pb.MonthlyCashFlowResponse{
+		Buckets:  pbBuckets,
+		Currency: req.BaseCurrency,
+	}, nil
+}
+
+// GetSpendingReport compares spending per category with the previous period
+func (h *GRPCHandler) GetSpendingReport(ctx context.Context, req *pb.GetSpendingReportRequest) (*pb.SpendingReport, error) {
+	var at time.Time
+	if req.Date != nil {
+		at = req.Date.AsTime()
+	}
+
+	report, err := h.transactionService.GetSpendingReport(ctx, req.UserId, models.BudgetPeriod(req.Period), at, req.BaseCurrency)
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidBudgetPeriod) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		return nil, status.Errorf(codes.Internal, "failed to get spending report: %v", err)
+	}
+
+	resp := &pb.SpendingReport{
+		Period:             string(report.Period),
+		Currency:           report.Currency,
+		StartDate:          timestamppb.New(report.StartDate),
+		EndDate:            timestamppb.New(report.EndDate),
+		PreviousStartDate:  timestamppb.New(report.PreviousStartDate),
+		PreviousEndDate:    timestamppb.New(report.PreviousEndDate),
+		TotalSpent:         report.TotalSpent,
+		PreviousTotalSpent: report.PreviousTotalSpent,
+		Income:             report.Income,
+		PreviousIncome:     report.PreviousIncome,
+		Categories:         categorySpendChangesToProto(report.Categories),
+		TopIncreases:       categorySpendChangesToProto(report.TopIncreases),
+		TopDecreases:       categorySpendChangesToProto(report.TopDecreases),
+	}
+	resp.PercentChange, resp.HasPercentChange = optionalFloat(report.PercentChange)
+	resp.SavingsRate, resp.HasSavingsRate = optionalFloat(report.SavingsRate)
+	resp.PreviousSavingsRate, resp.HasPreviousSavingsRate = optionalFloat(report.PreviousSavingsRate)
+	resp.SavingsRateChange, resp.HasSavingsRateChange = optionalFloat(report.SavingsRateChange)
+
+	return resp, nil
+}
+
+func categorySpendChangesToProto(changes []service.CategorySpendChange) []*pb.CategorySpendChange {
+	result := make([]*pb.CategorySpendChange, len(changes))
+	for i, c := range changes {
+		result[i] = &pb.CategorySpendChange{
+			Category: c.Category,
+			Current:  c.Current,
+			Previous: c.Previous,
+			Change:   c.Change,
+		}
+		result[i].PercentChange, result[i].HasPercentChange = optionalFloat(c.PercentChange)
+	}
+	return result
+}
+
+// optionalFloat splits an optional value into the proto value and has_ flag
+func optionalFloat(v *float64) (float64, bool) {
+	if v == nil {
+		return 0, false
+	}
+	return *v, true
+}
+
 func transactionToProto(t *models.Transaction) *pb.Transaction {
 	subAccountID := ""
 	if t.SubAccountID != nil {
@@ -233,7 +413,7 @@ func transactionToProto(t *models.Transaction) *pb.Transaction {
 		transferToSubAccount = *t.TransferToSubAccount
 	}
 
-	return &pb.Transaction{
+	tx := &pb.Transaction{
 		Id:                     t.ID,
 		UserId:                 t.UserID,
 		SubAccountId:           subAccountID,
@@ -248,7 +428,17 @@ func transactionToProto(t *models.Transaction) *pb.Transaction {
 		CreatedAt:              timestamppb.New(t.CreatedAt),
 		UpdatedAt:              timestamppb.New(t.UpdatedAt),
 		TransferToSubAccountId: transferToSubAccount,
+		OriginalCurrency:       t.OriginalCurrency,
+	}
+	if t.OriginalAmount != nil {
+		tx.OriginalAmount = *t.OriginalAmount
+	}
+	if t.TransferAmount != nil && t.TransferRate != nil {
+		tx.TransferAmount = *t.TransferAmount
+		tx.TransferCurrency = t.TransferCurrency
+		tx.TransferRate = *t.TransferRate
 	}
+	return tx
 }
 
 func transactionTypeToProto(t models.TransactionType) pb.TransactionType {
@@ -282,6 +472,20 @@ func transactionCategoryToProto(c models.TransactionCategory) pb.TransactionCate
 		return pb.TransactionCategory_TRANSACTION_CATEGORY_UTILITIES
 	case models.CategoryTransfer:
 		return pb.TransactionCategory_TRANSACTION_CATEGORY_TRANSFER
+	case models.CategoryInvestmentIncome:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_INVESTMENT_INCOME
+	case models.CategoryEducation:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_EDUCATION
+	case models.CategoryTravel:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_TRAVEL
+	case models.CategoryHousing:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_HOUSING
+	case models.CategoryInsurance:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_INSURANCE
+	case models.CategoryTaxes:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_TAXES
+	case models.CategoryGifts:
+		return pb.TransactionCategory_TRANSACTION_CATEGORY_GIFTS
 	default:
 		return pb.TransactionCategory_TRANSACTION_CATEGORY_OTHER
 	}
@@ -318,6 +522,20 @@ func protoToTransactionCategory(c pb.TransactionCategory) models.TransactionCate
 		return models.CategoryUtilities
 	case pb.TransactionCategory_TRANSACTION_CATEGORY_TRANSFER:
 		return models.CategoryTransfer
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_INVESTMENT_INCOME:
+		return models.CategoryInvestmentIncome
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_EDUCATION:
+		return models.CategoryEducation
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_TRAVEL:
+		return models.CategoryTravel
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_HOUSING:
+		return models.CategoryHousing
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_INSURANCE:
+		return models.CategoryInsurance
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_TAXES:
+		return models.CategoryTaxes
+	case pb.TransactionCategory_TRANSACTION_CATEGORY_GIFTS:
+		return models.CategoryGifts
 	default:
 		return models.CategoryOther
 	}
@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	pb "github.com/radmickey/money-control/backend/proto/transactions"
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"github.com/radmickey/money-control/backend/services/transactions/repository"
+	"github.com/radmickey/money-control/backend/services/transactions/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testUserID = "00000000-0000-0000-0000-000000000001"
+
+// newTestGRPCHandler returns a handler on a test database, which the test
+// seeds through the returned repository
+func newTestGRPCHandler(t *testing.T) (*GRPCHandler, *repository.TransactionRepository) {
+	t.Helper()
+	db := databasetest.Open(t, &models.Transaction{}, &models.TransactionSplit{})
+	repo := repository.NewTransactionRepository(db)
+	return NewGRPCHandler(service.NewTransactionService(repo, nil, nil, nil, nil, 0, nil)), repo
+}
+
+// seedTransaction stores an expense directly, bypassing balance updates
+func seedTransaction(t *testing.T, repo *repository.TransactionRepository, category models.TransactionCategory, date time.Time, splits ...models.TransactionSplit) *models.Transaction {
+	t.Helper()
+	ctx := context.Background()
+	tx := &models.Transaction{
+		UserID:   testUserID,
+		Amount:   100,
+		Currency: "USD",
+		Type:     models.TransactionTypeExpense,
+		Category: category,
+		Date:     date,
+	}
+	if err := repo.Create(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if len(splits) > 0 {
+		if err := repo.ReplaceSplits(ctx, tx, splits); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tx
+}
+
+// transactionIDs returns the IDs in a list response, in order
+func transactionIDs(resp *pb.ListTransactionsResponse) []string {
+	ids := make([]string, len(resp.Transactions))
+	for i, tx := range resp.Transactions {
+		ids[i] = tx.Id
+	}
+	return ids
+}
+
+func TestGetTransactionsByCategory(t *testing.T) {
+	h, repo := newTestGRPCHandler(t)
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	food := seedTransaction(t, repo, models.CategoryFood, now.Add(-time.Hour))
+	seedTransaction(t, repo, models.CategoryTransport, now.Add(-2*time.Hour))
+	// A split purchase counts under its parts' categories, not its own
+	split := seedTransaction(t, repo, models.CategoryShopping, now.Add(-3*time.Hour),
+		models.TransactionSplit{Amount: 60, Category: models.CategoryFood},
+		models.TransactionSplit{Amount: 40, Category: models.CategoryShopping},
+	)
+	seedTransaction(t, repo, models.CategoryFood, now.Add(-48*time.Hour))
+
+	tests := []struct {
+		name     string
+		category pb.TransactionCategory
+		want     []string
+		total    int32
+	}{
+		{name: "food", category: pb.TransactionCategory_TRANSACTION_CATEGORY_FOOD, want: []string{food.ID, split.ID}, total: 2},
+		{name: "shopping split part", category: pb.TransactionCategory_TRANSACTION_CATEGORY_SHOPPING, want: []string{split.ID}, total: 1},
+		{name: "empty category", category: pb.TransactionCategory_TRANSACTION_CATEGORY_GIFTS, want: []string{}, total: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := h.GetTransactionsByCategory(ctx, &pb.GetTransactionsByCategoryRequest{
+				UserId:    testUserID,
+				Category:  tt.category,
+				StartDate: timestamppb.New(now.Add(-24 * time.Hour)),
+				EndDate:   timestamppb.New(now),
+			})
+			if err != nil {
+				t.Fatalf("GetTransactionsByCategory: %v", err)
+			}
+			if got := transactionIDs(resp); !slices.Equal(got, tt.want) || resp.Total != tt.total {
+				t.Errorf("got %v (total %d), want %v (total %d)", got, resp.Total, tt.want, tt.total)
+			}
+		})
+	}
+}
+
+func TestGetTransactionsByCategoryRequiresCategory(t *testing.T) {
+	// Rejected before the service is consulted
+	h := NewGRPCHandler(nil)
+	_, err := h.GetTransactionsByCategory(context.Background(), &pb.GetTransactionsByCategoryRequest{UserId: testUserID})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
+
+func TestGetTransactionsByDateRange(t *testing.T) {
+	h, repo := newTestGRPCHandler(t)
+	ctx := context.Background()
+
+	start := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	beforeStart := seedTransaction(t, repo, models.CategoryFood, start.Add(-time.Second))
+	atStart := seedTransaction(t, repo, models.CategoryFood, start)
+	middle := seedTransaction(t, repo, models.CategoryFood, start.Add(10*24*time.Hour))
+	atEnd := seedTransaction(t, repo, models.CategoryFood, end)
+	afterEnd := seedTransaction(t, repo, models.CategoryFood, end.Add(time.Second))
+
+	tests := []struct {
+		name       string
+		start, end time.Time
+		want       []string
+	}{
+		// Both bounds are inclusive; results are newest first
+		{name: "bounds included", start: start, end: end, want: []string{atEnd.ID, middle.ID, atStart.ID}},
+		{name: "end just before a transaction excludes it", start: start, end: end.Add(-time.Second), want: []string{middle.ID, atStart.ID}},
+		{name: "single instant", start: end, end: end, want: []string{atEnd.ID}},
+		{name: "open start", end: start, want: []string{atStart.ID, beforeStart.ID}},
+		{name: "open end", start: end, want: []string{afterEnd.ID, atEnd.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &pb.GetTransactionsByDateRangeRequest{UserId: testUserID}
+			if !tt.start.IsZero() {
+				req.StartDate = timestamppb.New(tt.start)
+			}
+			if !tt.end.IsZero() {
+				req.EndDate = timestamppb.New(tt.end)
+			}
+
+			resp, err := h.GetTransactionsByDateRange(ctx, req)
+			if err != nil {
+				t.Fatalf("GetTransactionsByDateRange: %v", err)
+			}
+			if got := transactionIDs(resp); !slices.Equal(got, tt.want) || resp.Total != int32(len(tt.want)) {
+				t.Errorf("got %v (total %d), want %v", got, resp.Total, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetTransactionsByDateRangeRejectsReversedRange(t *testing.T) {
+	// Rejected before the service is consulted
+	h := NewGRPCHandler(nil)
+	start := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	_, err := h.GetTransactionsByDateRange(context.Background(), &pb.GetTransactionsByDateRangeRequest{
+		UserId:    testUserID,
+		StartDate: timestamppb.New(start),
+		EndDate:   timestamppb.New(start.Add(-time.Second)),
+	})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v, want InvalidArgument", err)
+	}
+}
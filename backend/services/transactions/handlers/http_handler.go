@@ -1,14 +1,17 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
+	"log"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/radmickey/money-control/backend/pkg/database"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
 	"github.com/radmickey/money-control/backend/pkg/utils"
 	"github.com/radmickey/money-control/backend/services/transactions/models"
-	"github.com/radmickey/money-control/backend/services/transactions/repository"
 	"github.com/radmickey/money-control/backend/services/transactions/service"
 )
 
@@ -21,10 +24,15 @@ func RegisterHTTPRoutes(r *gin.RouterGroup, txService *service.TransactionServic
 		transactions.POST("", h.CreateTransaction)
 		transactions.GET("", h.ListTransactions)
 		transactions.GET("/summary", h.GetTransactionsSummary)
+		transactions.GET("/recurring", h.DetectRecurring)
+		transactions.GET("/report", h.GetSpendingReport)
+		transactions.POST("/import", h.ImportTransactions)
+		transactions.GET("/export", h.ExportTransactions)
 		transactions.GET("/:id", h.GetTransaction)
 		transactions.PUT("/:id", h.UpdateTransaction)
 		transactions.DELETE("/:id", h.DeleteTransaction)
 		transactions.PATCH("/:id/category", h.CategorizeTransaction)
+		transactions.POST("/:id/split", h.SplitTransaction)
 		transactions.POST("/bulk-categorize", h.BulkCategorize)
 	}
 
@@ -34,6 +42,15 @@ func RegisterHTTPRoutes(r *gin.RouterGroup, txService *service.TransactionServic
 		rules.GET("", h.GetCategoryRules)
 		rules.DELETE("/:id", h.DeleteCategoryRule)
 	}
+
+	budgets := r.Group("/budgets")
+	{
+		budgets.POST("", h.CreateBudget)
+		budgets.GET("", h.ListBudgets)
+		budgets.GET("/status", h.GetBudgetStatus)
+		budgets.PUT("/:id", h.UpdateBudget)
+		budgets.DELETE("/:id", h.DeleteBudget)
+	}
 }
 
 // HTTPHandler handles HTTP requests
@@ -57,11 +74,15 @@ type CreateTransactionRequest struct {
 
 // CreateTransaction creates a new transaction
 func (h *HTTPHandler) CreateTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req CreateTransactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -104,7 +125,7 @@ func (h *HTTPHandler) CreateTransaction(c *gin.Context) {
 
 	tx, err := h.txService.CreateTransaction(c.Request.Context(), input)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -113,7 +134,11 @@ func (h *HTTPHandler) CreateTransaction(c *gin.Context) {
 
 // ListTransactions lists transactions
 func (h *HTTPHandler) ListTransactions(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	subAccountID := c.Query("sub_account_id")
 	page := parseIntParam(c, "page", 1)
@@ -121,46 +146,52 @@ func (h *HTTPHandler) ListTransactions(c *gin.Context) {
 	sortBy := c.Query("sort_by")
 	sortDesc := c.Query("sort_desc") == "true"
 
+	cursor := c.Query("cursor")
+
 	// Date range filters
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
 
-	var transactions []models.Transaction
-	var total int64
+	var result database.Page[models.Transaction]
 	var err error
 
 	if startDateStr != "" && endDateStr != "" {
 		startDate, _ := time.Parse("2006-01-02", startDateStr)
 		endDate, _ := time.Parse("2006-01-02", endDateStr)
-		transactions, total, err = h.txService.ListTransactionsByDateRange(
-			c.Request.Context(), userID, startDate, endDate, subAccountID, page, pageSize,
+		result, err = h.txService.ListTransactionsByDateRange(
+			c.Request.Context(), userID, startDate, endDate, subAccountID, page, pageSize, cursor,
 		)
 	} else {
-		transactions, total, err = h.txService.ListTransactions(
-			c.Request.Context(), userID, subAccountID, page, pageSize, sortBy, sortDesc,
+		result, err = h.txService.ListTransactions(
+			c.Request.Context(), userID, subAccountID, page, pageSize, sortBy, sortDesc, cursor,
 		)
 	}
 
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
-	utils.SuccessWithMeta(c, transactions, utils.PaginationMeta(page, pageSize, int(total)))
+	meta := &utils.Meta{PageSize: pageSize}
+	if cursor == "" {
+		meta = utils.PaginationMeta(page, pageSize, int(result.Total))
+	}
+	meta.NextCursor = result.NextCursor
+	utils.SuccessWithMeta(c, result.Items, meta)
 }
 
 // GetTransaction gets a transaction by ID
 func (h *HTTPHandler) GetTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	tx, err := h.txService.GetTransaction(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrTransactionNotFound {
-			utils.NotFound(c, "Transaction not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -179,12 +210,16 @@ type UpdateTransactionRequest struct {
 
 // UpdateTransaction updates a transaction
 func (h *HTTPHandler) UpdateTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req UpdateTransactionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -210,11 +245,7 @@ func (h *HTTPHandler) UpdateTransaction(c *gin.Context) {
 
 	tx, err := h.txService.UpdateTransaction(c.Request.Context(), input)
 	if err != nil {
-		if err == repository.ErrTransactionNotFound {
-			utils.NotFound(c, "Transaction not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -223,16 +254,16 @@ func (h *HTTPHandler) UpdateTransaction(c *gin.Context) {
 
 // DeleteTransaction deletes a transaction
 func (h *HTTPHandler) DeleteTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	err := h.txService.DeleteTransaction(c.Request.Context(), id, userID)
 	if err != nil {
-		if err == repository.ErrTransactionNotFound {
-			utils.NotFound(c, "Transaction not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -241,7 +272,11 @@ func (h *HTTPHandler) DeleteTransaction(c *gin.Context) {
 
 // GetTransactionsSummary gets transaction summary
 func (h *HTTPHandler) GetTransactionsSummary(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	startDateStr := c.Query("start_date")
 	endDateStr := c.Query("end_date")
@@ -254,9 +289,9 @@ func (h *HTTPHandler) GetTransactionsSummary(c *gin.Context) {
 		endDate, _ = time.Parse("2006-01-02", endDateStr)
 	}
 
-	summary, err := h.txService.GetTransactionsSummary(c.Request.Context(), userID, startDate, endDate)
+	summary, err := h.txService.GetTransactionsSummary(c.Request.Context(), userID, startDate, endDate, c.Query("currency"))
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -271,12 +306,16 @@ type CategorizeRequest struct {
 
 // CategorizeTransaction categorizes a transaction
 func (h *HTTPHandler) CategorizeTransaction(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	var req CategorizeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -285,11 +324,7 @@ func (h *HTTPHandler) CategorizeTransaction(c *gin.Context) {
 		models.TransactionCategory(req.Category), req.CustomCategory,
 	)
 	if err != nil {
-		if err == repository.ErrTransactionNotFound {
-			utils.NotFound(c, "Transaction not found")
-			return
-		}
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -304,11 +339,15 @@ type BulkCategorizeRequest struct {
 
 // BulkCategorize categorizes multiple transactions
 func (h *HTTPHandler) BulkCategorize(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req BulkCategorizeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
@@ -317,7 +356,7 @@ func (h *HTTPHandler) BulkCategorize(c *gin.Context) {
 		models.TransactionCategory(req.Category),
 	)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -327,29 +366,274 @@ func (h *HTTPHandler) BulkCategorize(c *gin.Context) {
 	})
 }
 
+// SplitPartRequest is one part of a split transaction
+type SplitPartRequest struct {
+	Amount         float64 `json:"amount" binding:"required"`
+	Category       string  `json:"category" binding:"required"`
+	CustomCategory string  `json:"custom_category"`
+	Description    string  `json:"description"`
+}
+
+// SplitTransactionRequest represents split transaction request
+type SplitTransactionRequest struct {
+	Parts []SplitPartRequest `json:"parts" binding:"dive"`
+}
+
+// SplitTransaction splits a transaction across categories
+func (h *HTTPHandler) SplitTransaction(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+	id := c.Param("id")
+
+	var req SplitTransactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	parts := make([]service.SplitPart, len(req.Parts))
+	for i, p := range req.Parts {
+		parts[i] = service.SplitPart{
+			Amount:         p.Amount,
+			Category:       models.TransactionCategory(p.Category),
+			CustomCategory: p.CustomCategory,
+			Description:    p.Description,
+		}
+	}
+
+	tx, err := h.txService.SplitTransaction(c.Request.Context(), id, userID, parts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, tx)
+}
+
+// ImportTransactions imports transactions from an uploaded CSV file. The
+// multipart form carries the file plus the column mapping.
+func (h *HTTPHandler) ImportTransactions(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	// The upload size is bounded by BodyLimitMiddleware
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			utils.PayloadTooLarge(c, fmt.Sprintf("CSV file must not exceed %d bytes", tooLarge.Limit))
+			return
+		}
+		utils.BadRequest(c, "CSV file is required")
+		return
+	}
+
+	subAccountID := c.PostForm("sub_account_id")
+	if subAccountID == "" {
+		utils.BadRequest(c, "sub_account_id is required")
+		return
+	}
+
+	opts := service.ImportOptions{
+		UserID:       userID,
+		SubAccountID: &subAccountID,
+		Currency:     c.PostForm("currency"),
+		HasHeader:    c.DefaultPostForm("has_header", "true") != "false",
+		DateFormat:   c.PostForm("date_format"),
+		Mapping: service.ColumnMapping{
+			Date:        c.PostForm("date_column"),
+			Amount:      c.PostForm("amount_column"),
+			Description: c.PostForm("description_column"),
+			Merchant:    c.PostForm("merchant_column"),
+			Category:    c.PostForm("category_column"),
+			Currency:    c.PostForm("currency_column"),
+			Type:        c.PostForm("type_column"),
+		},
+	}
+	if delimiter := []rune(c.PostForm("delimiter")); len(delimiter) > 0 {
+		opts.Delimiter = delimiter[0]
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.BadRequest(c, "Failed to read CSV file")
+		return
+	}
+	defer file.Close()
+
+	report, err := h.txService.ImportTransactions(c.Request.Context(), file, opts)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, report)
+}
+
+// ExportTransactions streams the user's transactions in a date range as CSV
+// or JSON. The end date is inclusive; start defaults to a year before end.
+func (h *HTTPHandler) ExportTransactions(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "json" {
+		utils.BadRequest(c, "format must be csv or json")
+		return
+	}
+
+	endDate := time.Now()
+	if v := c.Query("end"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			utils.BadRequest(c, "Invalid end date format")
+			return
+		}
+		endDate = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	startDate := endDate.AddDate(-1, 0, 0)
+	if v := c.Query("start"); v != "" {
+		t, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			utils.BadRequest(c, "Invalid start date format")
+			return
+		}
+		startDate = t
+	}
+
+	var writer transactionWriter
+	contentType := "text/csv; charset=utf-8"
+	if format == "json" {
+		writer = newJSONTransactionWriter(c.Writer)
+		contentType = "application/json; charset=utf-8"
+	} else {
+		writer = newCSVTransactionWriter(c.Writer)
+	}
+
+	// Headers are sent with the first page so range errors can still be reported
+	started := false
+	start := func() error {
+		if started {
+			return nil
+		}
+		started = true
+		filename := fmt.Sprintf("transactions_%s_%s.%s", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"), format)
+		c.Header("Content-Type", contentType)
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		c.Status(http.StatusOK)
+		return writer.begin()
+	}
+
+	err := h.txService.ExportTransactions(c.Request.Context(), userID, startDate, endDate, func(transactions []models.Transaction) error {
+		if err := start(); err != nil {
+			return err
+		}
+		if err := writer.write(transactions); err != nil {
+			return err
+		}
+		c.Writer.Flush()
+		return nil
+	})
+	if err != nil {
+		if !started {
+			respondError(c, err)
+			return
+		}
+		// Headers are already sent, so the export can only be cut short
+		log.Printf("Transaction export for user %s failed: %v", userID, err)
+		return
+	}
+
+	if err := start(); err != nil {
+		log.Printf("Transaction export for user %s failed: %v", userID, err)
+		return
+	}
+	if err := writer.end(); err != nil {
+		log.Printf("Transaction export for user %s failed: %v", userID, err)
+	}
+}
+
+// GetSpendingReport compares spending per category with the previous period
+func (h *HTTPHandler) GetSpendingReport(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var at time.Time
+	if v := c.Query("date"); v != "" {
+		var err error
+		at, err = time.Parse("2006-01-02", v)
+		if err != nil {
+			utils.BadRequest(c, "Invalid date format")
+			return
+		}
+	}
+
+	report, err := h.txService.GetSpendingReport(c.Request.Context(), userID, models.BudgetPeriod(c.Query("period")), at, c.Query("currency"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, report)
+}
+
+// DetectRecurring lists recurring transactions with their predicted next dates
+func (h *HTTPHandler) DetectRecurring(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	recurring, err := h.txService.DetectRecurring(c.Request.Context(), userID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, recurring)
+}
+
 // CreateCategoryRuleRequest represents create category rule request
 type CreateCategoryRuleRequest struct {
 	Pattern  string `json:"pattern" binding:"required"`
+	IsRegex  bool   `json:"is_regex"`
 	Category string `json:"category" binding:"required"`
 	Priority int    `json:"priority"`
 }
 
 // CreateCategoryRule creates a category rule
 func (h *HTTPHandler) CreateCategoryRule(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	var req CreateCategoryRuleRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		utils.BadRequest(c, err.Error())
+		utils.BindingError(c, err)
 		return
 	}
 
 	rule, err := h.txService.CreateCategoryRule(
-		c.Request.Context(), userID, req.Pattern,
+		c.Request.Context(), userID, req.Pattern, req.IsRegex,
 		models.TransactionCategory(req.Category), req.Priority,
 	)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -358,11 +642,15 @@ func (h *HTTPHandler) CreateCategoryRule(c *gin.Context) {
 
 // GetCategoryRules gets category rules
 func (h *HTTPHandler) GetCategoryRules(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 
 	rules, err := h.txService.GetCategoryRules(c.Request.Context(), userID)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
@@ -371,18 +659,147 @@ func (h *HTTPHandler) GetCategoryRules(c *gin.Context) {
 
 // DeleteCategoryRule deletes a category rule
 func (h *HTTPHandler) DeleteCategoryRule(c *gin.Context) {
-	userID := middleware.MustGetUserID(c)
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
 	id := c.Param("id")
 
 	err := h.txService.DeleteCategoryRule(c.Request.Context(), id, userID)
 	if err != nil {
-		utils.InternalError(c, err.Error())
+		respondError(c, err)
 		return
 	}
 
 	utils.NoContent(c)
 }
 
+// CreateBudgetRequest represents create budget request
+type CreateBudgetRequest struct {
+	Category  string  `json:"category" binding:"required"`
+	Period    string  `json:"period"`
+	Amount    float64 `json:"amount" binding:"required"`
+	Currency  string  `json:"currency"`
+	Carryover bool    `json:"carryover"`
+}
+
+// CreateBudget creates a category budget
+func (h *HTTPHandler) CreateBudget(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req CreateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	budget, err := h.txService.CreateBudget(c.Request.Context(), service.CreateBudgetInput{
+		UserID:    userID,
+		Category:  models.TransactionCategory(req.Category),
+		Period:    models.BudgetPeriod(req.Period),
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Carryover: req.Carryover,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Created(c, budget)
+}
+
+// ListBudgets lists budgets
+func (h *HTTPHandler) ListBudgets(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	budgets, err := h.txService.ListBudgets(c.Request.Context(), userID, models.BudgetPeriod(c.Query("period")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, budgets)
+}
+
+// UpdateBudgetRequest represents update budget request
+type UpdateBudgetRequest struct {
+	Amount    float64 `json:"amount"`
+	Currency  string  `json:"currency"`
+	Carryover *bool   `json:"carryover"`
+}
+
+// UpdateBudget updates a budget
+func (h *HTTPHandler) UpdateBudget(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	var req UpdateBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BindingError(c, err)
+		return
+	}
+
+	budget, err := h.txService.UpdateBudget(c.Request.Context(), service.UpdateBudgetInput{
+		ID:        c.Param("id"),
+		UserID:    userID,
+		Amount:    req.Amount,
+		Currency:  req.Currency,
+		Carryover: req.Carryover,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, budget)
+}
+
+// DeleteBudget deletes a budget
+func (h *HTTPHandler) DeleteBudget(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	if err := h.txService.DeleteBudget(c.Request.Context(), c.Param("id"), userID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.NoContent(c)
+}
+
+// GetBudgetStatus reports spending against each budget for the current period
+func (h *HTTPHandler) GetBudgetStatus(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		utils.Unauthorized(c, "User not found in context")
+		return
+	}
+
+	status, err := h.txService.GetBudgetStatus(c.Request.Context(), userID, models.BudgetPeriod(c.Query("period")))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	utils.Success(c, status)
+}
+
 func parseIntParam(c *gin.Context, key string, defaultVal int) int {
 	val := c.Query(key)
 	if val == "" {
@@ -395,4 +812,3 @@ func parseIntParam(c *gin.Context, key string, defaultVal int) int {
 	}
 	return i
 }
-
@@ -12,15 +12,23 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/radmickey/money-control/backend/pkg/auth"
+	pkgcache "github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/events"
+	"github.com/radmickey/money-control/backend/pkg/health"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
 	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/tracing"
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	pb "github.com/radmickey/money-control/backend/proto/transactions"
 	"github.com/radmickey/money-control/backend/services/transactions/handlers"
 	"github.com/radmickey/money-control/backend/services/transactions/models"
 	"github.com/radmickey/money-control/backend/services/transactions/repository"
 	"github.com/radmickey/money-control/backend/services/transactions/service"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -30,11 +38,27 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
+
+	// Set up tracing; a no-op unless an OTLP endpoint is configured
+	shutdownTracing, err := tracing.Init(context.Background(), tracing.Config{
+		ServiceName: "transactions-service",
+		Endpoint:    cfg.OTLPEndpoint,
+	})
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
 
 	// Connect to database
 	db, err := database.New(database.Config{
-		URL:   cfg.DatabaseURL,
-		Debug: cfg.Debug,
+		URL:             cfg.DatabaseURL,
+		MaxOpenConns:    cfg.DBMaxOpenConns,
+		MaxIdleConns:    cfg.DBMaxIdleConns,
+		ConnMaxLifetime: cfg.DBConnMaxLifetime,
+		Debug:           cfg.Debug,
 	})
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -42,16 +66,68 @@ func main() {
 	defer db.Close()
 
 	// Run migrations
-	if err := db.Migrate(&models.Transaction{}, &models.CategoryRule{}); err != nil {
+	if err := db.Migrate(&models.Transaction{}, &models.TransactionSplit{}, &models.CategoryRule{}, &models.Budget{}, &models.BalanceOutbox{}); err != nil {
 		log.Fatalf("Failed to run migrations: %v", err)
 	}
 
 	// Initialize repositories
 	txRepo := repository.NewTransactionRepository(db.DB)
 	ruleRepo := repository.NewCategoryRuleRepository(db.DB)
+	budgetRepo := repository.NewBudgetRepository(db.DB)
+
+	// Connect to Currency service for historical conversions in summaries
+	var currencyClient currencypb.CurrencyServiceClient
+	if cfg.CurrencyServiceURL != "" {
+		conn, err := grpc.Dial(cfg.CurrencyServiceURL,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+			tracing.DialOption(),
+		)
+		if err != nil {
+			log.Printf("Failed to connect to currency service: %v", err)
+		} else {
+			currencyClient = currencypb.NewCurrencyServiceClient(conn)
+		}
+	}
+
+	// Connect to Accounts service to verify transaction sub-accounts
+	var accountsClient accountspb.AccountsServiceClient
+	if cfg.AccountsServiceURL != "" {
+		conn, err := grpc.Dial(cfg.AccountsServiceURL,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+			tracing.DialOption(),
+		)
+		if err != nil {
+			log.Printf("Failed to connect to accounts service: %v", err)
+		} else {
+			accountsClient = accountspb.NewAccountsServiceClient(conn)
+		}
+	}
+
+	// Connect to Redis for publishing change events (optional)
+	var redisCache *pkgcache.Cache
+	if cfg.RedisURL != "" {
+		redisCache, err = pkgcache.New(pkgcache.Config{
+			URL:    cfg.RedisURL,
+			Prefix: "transactions",
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to connect to Redis (change events disabled): %v", err)
+		} else {
+			defer redisCache.Close()
+		}
+	}
 
 	// Initialize service
-	txService := service.NewTransactionService(txRepo, ruleRepo)
+	txService := service.NewTransactionService(
+		txRepo, ruleRepo, budgetRepo, currencyClient, accountsClient, cfg.ExportMaxSpan,
+		events.NewPublisher(redisCache, "transactions-service"),
+	)
+
+	// Retry balance adjustments the accounts service hasn't acknowledged yet
+	txService.StartBalanceOutbox(30 * time.Second)
+	defer txService.Stop()
 
 	// Initialize JWT manager for auth middleware
 	jwtManager := auth.NewJWTManager(
@@ -61,11 +137,19 @@ func main() {
 	)
 
 	// Start gRPC server
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(tracing.ServerOption(), grpc.ChainUnaryInterceptor(
+		middleware.UnaryServerRequestIDInterceptor(),
+		metrics.UnaryServerInterceptor(),
+	))
 	grpcHandler := handlers.NewGRPCHandler(txService)
 	pb.RegisterTransactionsServiceServer(grpcServer, grpcHandler)
 	reflection.Register(grpcServer)
 
+	// Health checks, served over gRPC here and on /health/details below
+	healthChecker := health.NewHealthChecker("")
+	healthChecker.Register("database", health.DatabasePoolCheck(db.Ping, db.Stats))
+	grpcHealth := health.RegisterGRPCServer(grpcServer, healthChecker)
+
 	grpcListener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
 	if err != nil {
 		log.Fatalf("Failed to listen on gRPC port: %v", err)
@@ -84,19 +168,41 @@ func main() {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery())
+	logConfig := middleware.NewLoggingConfig(cfg)
+	router.Use(middleware.RecoveryMiddleware(logConfig))
+	router.Use(metrics.Middleware())
 	router.Use(middleware.RequestIDMiddleware())
-	router.Use(middleware.LoggingMiddleware())
-	router.Use(middleware.CORS())
+	router.Use(middleware.TracingMiddleware())
+	router.Use(middleware.LoggingMiddleware(logConfig))
+	router.Use(middleware.CORS(middleware.NewCORSConfig(cfg)))
+	router.Use(middleware.BodyLimitMiddleware(middleware.BodyLimitConfig{
+		MaxBytes:     cfg.MaxBodyBytes,
+		ContentTypes: []string{"application/json"},
+		Routes: map[string]middleware.BodyLimitConfig{
+			"POST /api/v1/transactions/import": {MaxBytes: cfg.MaxUploadBytes, ContentTypes: []string{"multipart/form-data"}},
+		},
+	}))
 
 	// Health check
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok", "service": "transactions"})
 	})
 
+	// Prometheus metrics
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	// Detailed health check with connection pool stats
+	router.GET("/health/details", func(c *gin.Context) {
+		c.JSON(http.StatusOK, healthChecker.Check(c.Request.Context()))
+	})
+
 	// Protected routes
 	v1 := router.Group("/api/v1")
-	v1.Use(middleware.AuthMiddleware(jwtManager))
+	tokenValidator, err := middleware.NewTokenValidator(cfg, jwtManager, nil)
+	if err != nil {
+		log.Fatalf("Failed to create token validator: %v", err)
+	}
+	v1.Use(middleware.AuthMiddleware(tokenValidator))
 	handlers.RegisterHTTPRoutes(v1, txService)
 
 	httpServer := &http.Server{
@@ -122,6 +228,7 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
+	grpcHealth.Shutdown()
 	grpcServer.GracefulStop()
 	if err := httpServer.Shutdown(ctx); err != nil {
 		log.Printf("HTTP server shutdown error: %v", err)
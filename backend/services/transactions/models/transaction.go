@@ -44,6 +44,8 @@ type Transaction struct {
 	SubAccountID          *string             `gorm:"type:uuid;index" json:"sub_account_id,omitempty"`
 	Amount                float64             `gorm:"type:decimal(20,8);not null" json:"amount"`
 	Currency              string              `gorm:"size:3;not null;default:'USD'" json:"currency"`
+	OriginalAmount        *float64            `gorm:"type:decimal(20,8)" json:"original_amount,omitempty"`
+	OriginalCurrency      string              `gorm:"size:3" json:"original_currency,omitempty"`
 	Type                  TransactionType     `gorm:"size:20;not null" json:"type"`
 	Category              TransactionCategory `gorm:"size:50;not null" json:"category"`
 	CustomCategory        string              `gorm:"size:100" json:"custom_category,omitempty"`
@@ -51,10 +53,17 @@ type Transaction struct {
 	Merchant              string              `gorm:"size:255" json:"merchant,omitempty"`
 	Date                  time.Time           `gorm:"not null;index" json:"date"`
 	TransferToSubAccount  *string             `gorm:"type:uuid" json:"transfer_to_sub_account_id,omitempty"`
+	TransferAmount        *float64            `gorm:"type:decimal(20,8)" json:"transfer_amount,omitempty"`
+	TransferCurrency      string              `gorm:"size:3" json:"transfer_currency,omitempty"`
+	TransferRate          *float64            `gorm:"type:decimal(20,10)" json:"transfer_rate,omitempty"`
 	Metadata              *string             `gorm:"type:jsonb" json:"metadata,omitempty"`
 	IsRecurring           bool                `gorm:"default:false" json:"is_recurring"`
 	RecurringFrequency    string              `gorm:"size:20" json:"recurring_frequency,omitempty"`
 	IsPending             bool                `gorm:"default:false" json:"is_pending"`
+	// BalanceApplied is set when creating the transaction queued its effect on
+	// sub-account balances; imported rows leave it unset
+	BalanceApplied        bool                `gorm:"not null;default:false" json:"-"`
+	Splits                []TransactionSplit  `gorm:"foreignKey:TransactionID" json:"splits,omitempty"`
 	CreatedAt             time.Time           `json:"created_at"`
 	UpdatedAt             time.Time           `json:"updated_at"`
 	DeletedAt             gorm.DeletedAt      `gorm:"index" json:"-"`
@@ -65,11 +74,50 @@ func (Transaction) TableName() string {
 	return "transactions"
 }
 
+// TransactionSplit is one categorized part of a split transaction. When a
+// transaction has splits, they replace its own category in summaries.
+type TransactionSplit struct {
+	ID             string              `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TransactionID  string              `gorm:"type:uuid;not null;index" json:"transaction_id"`
+	Amount         float64             `gorm:"type:decimal(20,8);not null" json:"amount"`
+	Category       TransactionCategory `gorm:"size:50;not null;index" json:"category"`
+	CustomCategory string              `gorm:"size:100" json:"custom_category,omitempty"`
+	Description    string              `gorm:"size:500" json:"description,omitempty"`
+	CreatedAt      time.Time           `json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (TransactionSplit) TableName() string {
+	return "transaction_splits"
+}
+
+// BalanceOutbox is a sub-account balance adjustment waiting to be applied by
+// the accounts service. Entries are written in the same database transaction
+// as the change that causes them and deleted once applied; the ID doubles as
+// the idempotency key, so delivering an entry twice adjusts the balance once.
+type BalanceOutbox struct {
+	ID            string    `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	TransactionID string    `gorm:"type:uuid;not null;index" json:"transaction_id"`
+	UserID        string    `gorm:"type:uuid;not null" json:"user_id"`
+	SubAccountID  string    `gorm:"type:uuid;not null" json:"sub_account_id"`
+	Delta         float64   `gorm:"type:decimal(20,8);not null" json:"delta"`
+	Attempts      int       `gorm:"default:0" json:"attempts"`
+	LastError     string    `gorm:"size:500" json:"last_error,omitempty"`
+	NextAttemptAt time.Time `gorm:"not null;index" json:"next_attempt_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// TableName returns the table name for GORM
+func (BalanceOutbox) TableName() string {
+	return "balance_outbox"
+}
+
 // CategoryRule stores rules for auto-categorization
 type CategoryRule struct {
 	ID        string              `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
 	UserID    string              `gorm:"type:uuid;not null;index" json:"user_id"`
 	Pattern   string              `gorm:"size:255;not null" json:"pattern"`
+	IsRegex   bool                `gorm:"default:false" json:"is_regex"`
 	Category  TransactionCategory `gorm:"size:50;not null" json:"category"`
 	IsGlobal  bool                `gorm:"default:false" json:"is_global"`
 	Priority  int                 `gorm:"default:0" json:"priority"`
@@ -82,3 +130,57 @@ func (CategoryRule) TableName() string {
 	return "category_rules"
 }
 
+// BudgetPeriod is the length of a budget cycle
+type BudgetPeriod string
+
+const (
+	BudgetPeriodWeekly  BudgetPeriod = "weekly"
+	BudgetPeriodMonthly BudgetPeriod = "monthly"
+	BudgetPeriodYearly  BudgetPeriod = "yearly"
+)
+
+// Budget caps spending in a category for each period
+type Budget struct {
+	ID        string              `gorm:"type:uuid;primary_key;default:gen_random_uuid()" json:"id"`
+	UserID    string              `gorm:"type:uuid;not null;uniqueIndex:idx_budgets_user_category_period,where:deleted_at IS NULL" json:"user_id"`
+	Category  TransactionCategory `gorm:"size:50;not null;uniqueIndex:idx_budgets_user_category_period,where:deleted_at IS NULL" json:"category"`
+	Period    BudgetPeriod        `gorm:"size:20;not null;uniqueIndex:idx_budgets_user_category_period,where:deleted_at IS NULL" json:"period"`
+	Amount    float64             `gorm:"type:decimal(20,8);not null" json:"amount"`
+	Currency  string              `gorm:"size:3;not null;default:'USD'" json:"currency"`
+	Carryover bool                `gorm:"default:false" json:"carryover"`
+	CreatedAt time.Time           `json:"created_at"`
+	UpdatedAt time.Time           `json:"updated_at"`
+	DeletedAt gorm.DeletedAt      `gorm:"index" json:"-"`
+}
+
+// TableName returns the table name for GORM
+func (Budget) TableName() string {
+	return "budgets"
+}
+
+// PeriodBounds returns the [start, end) window of the period containing t
+func (p BudgetPeriod) PeriodBounds(t time.Time) (time.Time, time.Time) {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	switch p {
+	case BudgetPeriodWeekly:
+		// Weeks start on Monday
+		offset := (int(day.Weekday()) + 6) % 7
+		start := day.AddDate(0, 0, -offset)
+		return start, start.AddDate(0, 0, 7)
+	case BudgetPeriodYearly:
+		start := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(1, 0, 0)
+	default:
+		start := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+		return start, start.AddDate(0, 1, 0)
+	}
+}
+
+// IsValid reports whether p is a supported budget period
+func (p BudgetPeriod) IsValid() bool {
+	switch p {
+	case BudgetPeriodWeekly, BudgetPeriodMonthly, BudgetPeriodYearly:
+		return true
+	}
+	return false
+}
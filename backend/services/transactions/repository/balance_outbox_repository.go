@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"gorm.io/gorm"
+)
+
+// maxOutboxErrorLength bounds the stored delivery error
+const maxOutboxErrorLength = 500
+
+// Atomic runs fn with a repository bound to a database transaction, which is
+// committed if fn returns nil and rolled back otherwise
+func (r *TransactionRepository) Atomic(ctx context.Context, fn func(repo *TransactionRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		return fn(&TransactionRepository{db: db})
+	})
+}
+
+// QueueBalanceAdjustments adds entries to the balance outbox, filling in
+// their IDs. Call it through Atomic so they commit with the change causing them.
+func (r *TransactionRepository) QueueBalanceAdjustments(ctx context.Context, entries []models.BalanceOutbox) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&entries).Error
+}
+
+// DueBalanceAdjustments returns up to limit outbox entries due for delivery
+// at now, oldest first
+func (r *TransactionRepository) DueBalanceAdjustments(ctx context.Context, now time.Time, limit int) ([]models.BalanceOutbox, error) {
+	var entries []models.BalanceOutbox
+	err := r.db.WithContext(ctx).
+		Where("next_attempt_at <= ?", now).
+		Order("created_at ASC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+// DeleteBalanceAdjustment removes a delivered outbox entry
+func (r *TransactionRepository) DeleteBalanceAdjustment(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&models.BalanceOutbox{}).Error
+}
+
+// RetryBalanceAdjustment records a failed delivery and when to try again
+func (r *TransactionRepository) RetryBalanceAdjustment(ctx context.Context, id string, deliveryErr error, next time.Time) error {
+	message := deliveryErr.Error()
+	if len(message) > maxOutboxErrorLength {
+		message = message[:maxOutboxErrorLength]
+	}
+	return r.db.WithContext(ctx).Model(&models.BalanceOutbox{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"attempts":        gorm.Expr("attempts + 1"),
+			"last_error":      message,
+			"next_attempt_at": next,
+		}).Error
+}
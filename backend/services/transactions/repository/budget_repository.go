@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBudgetNotFound = errors.New("budget not found")
+	ErrBudgetExists   = errors.New("budget already exists for this category and period")
+)
+
+// BudgetRepository handles database operations for budgets
+type BudgetRepository struct {
+	db *gorm.DB
+}
+
+// NewBudgetRepository creates a new budget repository
+func NewBudgetRepository(db *gorm.DB) *BudgetRepository {
+	return &BudgetRepository{db: db}
+}
+
+// Create creates a budget; a user has at most one per category and period
+func (r *BudgetRepository) Create(ctx context.Context, budget *models.Budget) error {
+	var count int64
+	if err := r.db.WithContext(ctx).Model(&models.Budget{}).
+		Where("user_id = ? AND category = ? AND period = ?", budget.UserID, budget.Category, budget.Period).
+		Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return ErrBudgetExists
+	}
+	if err := r.db.WithContext(ctx).Create(budget).Error; err != nil {
+		// Lost a race with a concurrent create
+		if database.IsUniqueViolation(err) {
+			return ErrBudgetExists
+		}
+		return err
+	}
+	return nil
+}
+
+// GetByID finds a user's budget by ID
+func (r *BudgetRepository) GetByID(ctx context.Context, id, userID string) (*models.Budget, error) {
+	var budget models.Budget
+	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&budget).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrBudgetNotFound
+		}
+		return nil, err
+	}
+	return &budget, nil
+}
+
+// ListByUser lists a user's budgets, optionally for one period
+func (r *BudgetRepository) ListByUser(ctx context.Context, userID string, period models.BudgetPeriod) ([]models.Budget, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if period != "" {
+		query = query.Where("period = ?", period)
+	}
+
+	var budgets []models.Budget
+	if err := query.Order("category").Find(&budgets).Error; err != nil {
+		return nil, err
+	}
+	return budgets, nil
+}
+
+// Update updates a budget
+func (r *BudgetRepository) Update(ctx context.Context, budget *models.Budget) error {
+	return r.db.WithContext(ctx).Save(budget).Error
+}
+
+// Delete soft-deletes a budget
+func (r *BudgetRepository) Delete(ctx context.Context, id, userID string) error {
+	result := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).Delete(&models.Budget{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrBudgetNotFound
+	}
+	return nil
+}
@@ -3,15 +3,18 @@ package repository
 import (
 	"context"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/utils"
 	"github.com/radmickey/money-control/backend/services/transactions/models"
 	"gorm.io/gorm"
 )
 
 var (
 	ErrTransactionNotFound = errors.New("transaction not found")
+	ErrCursorSort          = errors.New("cursor pagination requires sorting by date")
 )
 
 // TransactionRepository handles database operations for transactions
@@ -29,10 +32,18 @@ func (r *TransactionRepository) Create(ctx context.Context, tx *models.Transacti
 	return r.db.WithContext(ctx).Create(tx).Error
 }
 
+// CreateBatch inserts several transactions at once
+func (r *TransactionRepository) CreateBatch(ctx context.Context, txs []models.Transaction) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).CreateInBatches(txs, 100).Error
+}
+
 // GetByID finds a transaction by ID
 func (r *TransactionRepository) GetByID(ctx context.Context, id, userID string) (*models.Transaction, error) {
 	var tx models.Transaction
-	if err := r.db.WithContext(ctx).Where("id = ? AND user_id = ?", id, userID).First(&tx).Error; err != nil {
+	if err := r.db.WithContext(ctx).Preload("Splits").Where("id = ? AND user_id = ?", id, userID).First(&tx).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, ErrTransactionNotFound
 		}
@@ -41,67 +52,194 @@ func (r *TransactionRepository) GetByID(ctx context.Context, id, userID string)
 	return &tx, nil
 }
 
-// List lists transactions for a user with pagination
-func (r *TransactionRepository) List(ctx context.Context, userID string, subAccountID string, page, pageSize int, sortBy string, sortDesc bool) ([]models.Transaction, int64, error) {
-	var transactions []models.Transaction
-	var total int64
-
+// List lists transactions for a user. Without a cursor it returns the given
+// page and the total; with one it returns the page after the cursor. Cursors
+// only work when sorting by date.
+func (r *TransactionRepository) List(ctx context.Context, userID string, subAccountID string, page, pageSize int, sortBy string, sortDesc bool, cursor string) (database.Page[models.Transaction], error) {
 	query := r.db.WithContext(ctx).Model(&models.Transaction{}).Where("user_id = ?", userID)
 
 	if subAccountID != "" {
 		query = query.Where("sub_account_id = ?", subAccountID)
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
-	}
-
 	// Sort
 	if sortBy == "" {
 		sortBy = "date"
 	}
+	if sortBy == "date" {
+		return listByDate(query, page, pageSize, sortDesc, cursor)
+	}
+	if cursor != "" {
+		return database.Page[models.Transaction]{}, ErrCursorSort
+	}
+
+	var result database.Page[models.Transaction]
+	if err := query.Count(&result.Total).Error; err != nil {
+		return database.Page[models.Transaction]{}, err
+	}
+
 	order := sortBy
 	if sortDesc {
 		order += " DESC"
 	}
 
-	if err := query.Scopes(database.Paginate(page, pageSize)).Order(order).Find(&transactions).Error; err != nil {
-		return nil, 0, err
+	if err := query.Scopes(database.Paginate(page, pageSize)).Preload("Splits").Order(order).Find(&result.Items).Error; err != nil {
+		return database.Page[models.Transaction]{}, err
 	}
 
-	return transactions, total, nil
+	return result, nil
 }
 
-// ListByDateRange lists transactions within a date range
-func (r *TransactionRepository) ListByDateRange(ctx context.Context, userID string, startDate, endDate time.Time, subAccountID string, page, pageSize int) ([]models.Transaction, int64, error) {
-	var transactions []models.Transaction
-	var total int64
+// ListByDateRange lists transactions within a date range, newest first. A
+// zero start or end leaves that side of the range open. A cursor works as
+// in List.
+func (r *TransactionRepository) ListByDateRange(ctx context.Context, userID string, startDate, endDate time.Time, subAccountID string, page, pageSize int, cursor string) (database.Page[models.Transaction], error) {
+	query := r.db.WithContext(ctx).Model(&models.Transaction{}).Where("user_id = ?", userID)
 
-	query := r.db.WithContext(ctx).Model(&models.Transaction{}).
-		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate)
+	if !startDate.IsZero() {
+		query = query.Where("date >= ?", startDate)
+	}
+	if !endDate.IsZero() {
+		query = query.Where("date <= ?", endDate)
+	}
 
 	if subAccountID != "" {
 		query = query.Where("sub_account_id = ?", subAccountID)
 	}
 
-	if err := query.Count(&total).Error; err != nil {
-		return nil, 0, err
+	return listByDate(query, page, pageSize, true, cursor)
+}
+
+// listByDate pages through query ordered by date then ID. With a cursor it
+// reads one row past the page to tell whether another page follows, instead
+// of counting every row; otherwise it uses offset pagination.
+func listByDate(query *gorm.DB, page, pageSize int, desc bool, cursor string) (database.Page[models.Transaction], error) {
+	var result database.Page[models.Transaction]
+
+	order := "date, id"
+	if desc {
+		order = "date DESC, id"
 	}
 
-	if err := query.Scopes(database.Paginate(page, pageSize)).Order("date DESC").Find(&transactions).Error; err != nil {
-		return nil, 0, err
+	if cursor == "" {
+		if err := query.Count(&result.Total).Error; err != nil {
+			return database.Page[models.Transaction]{}, err
+		}
+		if err := query.Scopes(database.Paginate(page, pageSize)).Preload("Splits").Order(order).Find(&result.Items).Error; err != nil {
+			return database.Page[models.Transaction]{}, err
+		}
+
+		offset, _ := database.PageBounds(page, pageSize)
+		if n := len(result.Items); n > 0 && int64(offset+n) < result.Total {
+			result.NextCursor = utils.EncodeCursor(result.Items[n-1].Date, result.Items[n-1].ID)
+		}
+		return result, nil
 	}
 
-	return transactions, total, nil
+	after, err := utils.DecodeCursor(cursor)
+	if err != nil {
+		return database.Page[models.Transaction]{}, err
+	}
+	if desc {
+		query = query.Where("date < ? OR (date = ? AND id > ?)", after.Date, after.Date, after.ID)
+	} else {
+		query = query.Where("date > ? OR (date = ? AND id > ?)", after.Date, after.Date, after.ID)
+	}
+
+	_, limit := database.PageBounds(1, pageSize)
+	if err := query.Limit(limit + 1).Preload("Splits").Order(order).Find(&result.Items).Error; err != nil {
+		return database.Page[models.Transaction]{}, err
+	}
+
+	if len(result.Items) > limit {
+		result.Items = result.Items[:limit]
+		last := result.Items[limit-1]
+		result.NextCursor = utils.EncodeCursor(last.Date, last.ID)
+	}
+	return result, nil
 }
 
-// ListByCategory lists transactions by category
+// ListAllByDateRange lists every transaction in a date range without pagination
+func (r *TransactionRepository) ListAllByDateRange(ctx context.Context, userID string, startDate, endDate time.Time) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	if err := r.db.WithContext(ctx).Preload("Splits").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate).
+		Order("date ASC").
+		Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// ListForDedupe lists the date, amount and merchant of a sub-account's
+// transactions in a date range, for matching against imported rows
+func (r *TransactionRepository) ListForDedupe(ctx context.Context, userID string, subAccountID *string, startDate, endDate time.Time) ([]models.Transaction, error) {
+	var transactions []models.Transaction
+	query := r.db.WithContext(ctx).
+		Select("date", "amount", "merchant", "description").
+		Where("user_id = ? AND date >= ? AND date <= ?", userID, startDate, endDate)
+	if subAccountID != nil {
+		query = query.Where("sub_account_id = ?", *subAccountID)
+	}
+	if err := query.Find(&transactions).Error; err != nil {
+		return nil, err
+	}
+	return transactions, nil
+}
+
+// BucketTotal is the income or expense total of one currency on one day
+// within a bucket
+type BucketTotal struct {
+	Bucket   int
+	Type     models.TransactionType
+	Currency string
+	Day      time.Time
+	Total    float64
+}
+
+// SumByBucket totals income and expenses per bucket, where bucket i spans
+// [boundaries[i], boundaries[i+1]) and the last bucket also includes its end.
+// Totals are split by currency and day so callers can convert at daily rates.
+func (r *TransactionRepository) SumByBucket(ctx context.Context, userID string, boundaries []time.Time) ([]BucketTotal, error) {
+	if len(boundaries) < 2 {
+		return nil, nil
+	}
+	last := len(boundaries) - 1
+
+	// width_bucket over the lower bounds puts everything from the last lower
+	// bound onward in the last bucket
+	lowerBounds := make([]interface{}, last)
+	for i := range lowerBounds {
+		lowerBounds[i] = boundaries[i]
+	}
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", last), ",")
+
+	var totals []BucketTotal
+	if err := r.db.WithContext(ctx).Model(&models.Transaction{}).
+		Select("width_bucket(date, ARRAY["+placeholders+"]::timestamptz[]) - 1 AS bucket, type, currency, date::date AS day, SUM(amount) AS total", lowerBounds...).
+		Where("user_id = ? AND type IN ? AND date >= ? AND date <= ?", userID,
+			[]models.TransactionType{models.TransactionTypeIncome, models.TransactionTypeExpense},
+			boundaries[0], boundaries[last]).
+		Group("bucket, type, currency, day").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	return totals, nil
+}
+
+// ListByCategory lists transactions by category. A split transaction is
+// listed under its parts' categories instead of its own.
 func (r *TransactionRepository) ListByCategory(ctx context.Context, userID string, category models.TransactionCategory, startDate, endDate time.Time, page, pageSize int) ([]models.Transaction, int64, error) {
 	var transactions []models.Transaction
 	var total int64
 
 	query := r.db.WithContext(ctx).Model(&models.Transaction{}).
-		Where("user_id = ? AND category = ?", userID, category)
+		Where("user_id = ?", userID).
+		Where(
+			"((category = ? AND NOT EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = transactions.id)) OR "+
+				"EXISTS (SELECT 1 FROM transaction_splits s WHERE s.transaction_id = transactions.id AND s.category = ?))",
+			category, category,
+		)
 
 	if !startDate.IsZero() {
 		query = query.Where("date >= ?", startDate)
@@ -114,16 +252,35 @@ func (r *TransactionRepository) ListByCategory(ctx context.Context, userID strin
 		return nil, 0, err
 	}
 
-	if err := query.Scopes(database.Paginate(page, pageSize)).Order("date DESC").Find(&transactions).Error; err != nil {
+	if err := query.Scopes(database.Paginate(page, pageSize)).Preload("Splits").Order("date DESC").Find(&transactions).Error; err != nil {
 		return nil, 0, err
 	}
 
 	return transactions, total, nil
 }
 
-// Update updates a transaction
+// Update updates a transaction. Splits are managed by ReplaceSplits.
 func (r *TransactionRepository) Update(ctx context.Context, tx *models.Transaction) error {
-	return r.db.WithContext(ctx).Save(tx).Error
+	return r.db.WithContext(ctx).Omit("Splits").Save(tx).Error
+}
+
+// ReplaceSplits replaces a transaction's splits; an empty slice removes them
+func (r *TransactionRepository) ReplaceSplits(ctx context.Context, tx *models.Transaction, splits []models.TransactionSplit) error {
+	return r.db.WithContext(ctx).Transaction(func(db *gorm.DB) error {
+		if err := db.Where("transaction_id = ?", tx.ID).Delete(&models.TransactionSplit{}).Error; err != nil {
+			return err
+		}
+		for i := range splits {
+			splits[i].TransactionID = tx.ID
+		}
+		if len(splits) > 0 {
+			if err := db.Create(&splits).Error; err != nil {
+				return err
+			}
+		}
+		tx.Splits = splits
+		return nil
+	})
 }
 
 // Delete soft-deletes a transaction
@@ -170,11 +327,13 @@ func (r *TransactionRepository) GetSummary(ctx context.Context, userID string, s
 		Total    float64
 	}
 
+	// Split transactions count toward their parts' categories
 	var categoryResults []CategoryResult
 	if err := r.db.WithContext(ctx).Model(&models.Transaction{}).
-		Where("user_id = ? AND type = ? AND date >= ? AND date <= ?", userID, models.TransactionTypeExpense, startDate, endDate).
-		Select("category, SUM(amount) as total").
-		Group("category").
+		Joins("LEFT JOIN transaction_splits s ON s.transaction_id = transactions.id").
+		Where("transactions.user_id = ? AND transactions.type = ? AND transactions.date >= ? AND transactions.date <= ?", userID, models.TransactionTypeExpense, startDate, endDate).
+		Select("COALESCE(s.category, transactions.category) AS category, SUM(COALESCE(s.amount, transactions.amount)) AS total").
+		Group("COALESCE(s.category, transactions.category)").
 		Scan(&categoryResults).Error; err != nil {
 		return nil, err
 	}
@@ -197,6 +356,7 @@ func (r *TransactionRepository) BulkUpdateCategory(ctx context.Context, userID s
 
 // TransactionSummary holds transaction summary data
 type TransactionSummary struct {
+	Currency         string
 	TotalIncome      float64
 	TotalExpenses    float64
 	NetFlow          float64
@@ -224,7 +384,7 @@ func (r *CategoryRuleRepository) GetByUserID(ctx context.Context, userID string)
 	var rules []models.CategoryRule
 	if err := r.db.WithContext(ctx).
 		Where("user_id = ? OR is_global = true", userID).
-		Order("priority DESC").
+		Order("priority DESC, created_at").
 		Find(&rules).Error; err != nil {
 		return nil, err
 	}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"log"
+	"sort"
+	"time"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"github.com/radmickey/money-control/backend/services/transactions/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// Outbox entries retried per worker run
+	outboxBatchSize = 100
+
+	// Delivery timeout for adjustments sent right after a change commits
+	outboxDeliveryTimeout = 5 * time.Second
+
+	// Longest wait between delivery attempts of one entry
+	maxOutboxBackoff = 10 * time.Minute
+)
+
+// balanceDeltas are balance changes keyed by sub-account ID
+type balanceDeltas map[string]float64
+
+// add records how a transaction moves its sub-accounts' balances. A sign of
+// -1 records the reverse, for a transaction being removed or replaced.
+func (d balanceDeltas) add(tx *models.Transaction, sign float64) {
+	amount := sign * tx.Amount
+	switch tx.Type {
+	case models.TransactionTypeIncome:
+		if tx.SubAccountID != nil {
+			d[*tx.SubAccountID] += amount
+		}
+	case models.TransactionTypeExpense:
+		if tx.SubAccountID != nil {
+			d[*tx.SubAccountID] -= amount
+		}
+	case models.TransactionTypeTransfer:
+		if tx.SubAccountID != nil {
+			d[*tx.SubAccountID] -= amount
+		}
+		if tx.TransferToSubAccount != nil {
+			// A cross-currency transfer credits the converted amount
+			credit := amount
+			if tx.TransferAmount != nil {
+				credit = sign * *tx.TransferAmount
+			}
+			d[*tx.TransferToSubAccount] += credit
+		}
+	}
+}
+
+// outbox turns the non-zero deltas into outbox entries for the transaction,
+// ordered by sub-account ID
+func (d balanceDeltas) outbox(tx *models.Transaction, now time.Time) []models.BalanceOutbox {
+	ids := make([]string, 0, len(d))
+	for id, delta := range d {
+		if delta != 0 {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	entries := make([]models.BalanceOutbox, len(ids))
+	for i, id := range ids {
+		entries[i] = models.BalanceOutbox{
+			TransactionID: tx.ID,
+			UserID:        tx.UserID,
+			SubAccountID:  id,
+			Delta:         d[id],
+			NextAttemptAt: now,
+		}
+	}
+	return entries
+}
+
+// applyBalances runs write, which stores the transaction change, and queues
+// the balance deltas it causes in the same database transaction, so the
+// change and its adjustments are stored together or not at all. The queued
+// adjustments are then delivered to the accounts service; any that fail stay
+// queued and are retried by the outbox worker. The transaction's ID must be
+// set once write returns.
+func (s *TransactionService) applyBalances(ctx context.Context, tx *models.Transaction, deltas balanceDeltas, write func(repo *repository.TransactionRepository) error) error {
+	if s.accountsClient == nil {
+		return write(s.txRepo)
+	}
+
+	var entries []models.BalanceOutbox
+	err := s.txRepo.Atomic(ctx, func(repo *repository.TransactionRepository) error {
+		if err := write(repo); err != nil {
+			return err
+		}
+		entries = deltas.outbox(tx, time.Now())
+		return repo.QueueBalanceAdjustments(ctx, entries)
+	})
+	if err != nil {
+		return err
+	}
+
+	// The change is stored, so delivery goes ahead even if the request is cancelled
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), outboxDeliveryTimeout)
+	defer cancel()
+	for i := range entries {
+		s.deliverQueued(ctx, &entries[i])
+	}
+	return nil
+}
+
+// deliverBalanceAdjustment applies an outbox entry, keyed by its ID so a
+// repeat delivery is a no-op. Sub-accounts that no longer exist are skipped.
+func (s *TransactionService) deliverBalanceAdjustment(ctx context.Context, entry *models.BalanceOutbox) error {
+	_, err := s.accountsClient.AdjustSubAccountBalance(ctx, &accountspb.AdjustSubAccountBalanceRequest{
+		Id:             entry.SubAccountID,
+		UserId:         entry.UserID,
+		Delta:          entry.Delta,
+		IdempotencyKey: entry.ID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+// deliverQueued delivers an outbox entry and removes it, or schedules a
+// retry if delivery fails
+func (s *TransactionService) deliverQueued(ctx context.Context, entry *models.BalanceOutbox) {
+	if err := s.deliverBalanceAdjustment(ctx, entry); err != nil {
+		log.Printf("Failed to adjust balance of sub-account %s for transaction %s (attempt %d): %v",
+			entry.SubAccountID, entry.TransactionID, entry.Attempts+1, err)
+		next := time.Now().Add(outboxBackoff(entry.Attempts))
+		if err := s.txRepo.RetryBalanceAdjustment(ctx, entry.ID, err, next); err != nil {
+			log.Printf("Failed to reschedule balance adjustment %s: %v", entry.ID, err)
+		}
+		return
+	}
+	// An entry left behind is delivered again, which the idempotency key makes harmless
+	if err := s.txRepo.DeleteBalanceAdjustment(ctx, entry.ID); err != nil {
+		log.Printf("Failed to remove delivered balance adjustment %s: %v", entry.ID, err)
+	}
+}
+
+// outboxBackoff is the wait before retrying an entry that has already
+// failed attempts times: doubling from a second, capped at maxOutboxBackoff
+func outboxBackoff(attempts int) time.Duration {
+	if attempts >= 10 {
+		return maxOutboxBackoff
+	}
+	backoff := time.Second << attempts
+	if backoff > maxOutboxBackoff {
+		return maxOutboxBackoff
+	}
+	return backoff
+}
+
+// StartBalanceOutbox retries undelivered balance adjustments every interval.
+// Several replicas may deliver the same entry; the accounts service applies
+// it once.
+func (s *TransactionService) StartBalanceOutbox(interval time.Duration) {
+	if s.accountsClient == nil {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	s.jobs.Add(1)
+	go func() {
+		defer s.jobs.Done()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), interval)
+				s.flushBalanceOutbox(ctx)
+				cancel()
+			case <-s.stopChan:
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
+// flushBalanceOutbox delivers the entries that are due
+func (s *TransactionService) flushBalanceOutbox(ctx context.Context) {
+	entries, err := s.txRepo.DueBalanceAdjustments(ctx, time.Now(), outboxBatchSize)
+	if err != nil {
+		log.Printf("Failed to load queued balance adjustments: %v", err)
+		return
+	}
+	for i := range entries {
+		if ctx.Err() != nil {
+			return
+		}
+		s.deliverQueued(ctx, &entries[i])
+	}
+}
+
+// Stop stops the outbox worker, waiting for an in-flight run to finish
+func (s *TransactionService) Stop() {
+	close(s.stopChan)
+	s.jobs.Wait()
+}
@@ -0,0 +1,377 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/database/databasetest"
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"github.com/radmickey/money-control/backend/services/transactions/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeAccounts is an accounts service holding sub-account balances. It
+// applies each idempotency key once, like the real service.
+type fakeAccounts struct {
+	accountspb.AccountsServiceClient
+
+	mu       sync.Mutex
+	currency string
+	balances map[string]float64
+	applied  map[string]bool
+	calls    int
+	// failures is the number of adjustments to fail before succeeding
+	failures int
+}
+
+func newFakeAccounts(subAccountIDs ...string) *fakeAccounts {
+	f := &fakeAccounts{currency: "USD", balances: make(map[string]float64), applied: make(map[string]bool)}
+	for _, id := range subAccountIDs {
+		f.balances[id] = 0
+	}
+	return f
+}
+
+func (f *fakeAccounts) GetSubAccount(ctx context.Context, req *accountspb.GetSubAccountRequest, opts ...grpc.CallOption) (*accountspb.SubAccount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.balances[req.Id]; !ok {
+		return nil, status.Error(codes.NotFound, "sub-account not found")
+	}
+	return &accountspb.SubAccount{Id: req.Id, Currency: f.currency}, nil
+}
+
+func (f *fakeAccounts) AdjustSubAccountBalance(ctx context.Context, req *accountspb.AdjustSubAccountBalanceRequest, opts ...grpc.CallOption) (*accountspb.SubAccount, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	if f.failures > 0 {
+		f.failures--
+		return nil, status.Error(codes.Unavailable, "accounts service unavailable")
+	}
+	if _, ok := f.balances[req.Id]; !ok {
+		return nil, status.Error(codes.NotFound, "sub-account not found")
+	}
+	if !f.applied[req.IdempotencyKey] {
+		f.applied[req.IdempotencyKey] = true
+		f.balances[req.Id] += req.Delta
+	}
+	return &accountspb.SubAccount{Id: req.Id, Balance: f.balances[req.Id]}, nil
+}
+
+func (f *fakeAccounts) balance(id string) float64 {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.balances[id]
+}
+
+func strPtr(s string) *string     { return &s }
+func floatPtr(f float64) *float64 { return &f }
+
+func TestBalanceDeltas(t *testing.T) {
+	income := &models.Transaction{Type: models.TransactionTypeIncome, Amount: 100, SubAccountID: strPtr("a")}
+	expense := &models.Transaction{Type: models.TransactionTypeExpense, Amount: 40, SubAccountID: strPtr("a")}
+	transfer := &models.Transaction{Type: models.TransactionTypeTransfer, Amount: 50, SubAccountID: strPtr("a"), TransferToSubAccount: strPtr("b")}
+	converted := &models.Transaction{Type: models.TransactionTypeTransfer, Amount: 50, SubAccountID: strPtr("a"), TransferToSubAccount: strPtr("b"), TransferAmount: floatPtr(45)}
+
+	tests := []struct {
+		name  string
+		apply func(d balanceDeltas)
+		want  balanceDeltas
+	}{
+		{"create income", func(d balanceDeltas) { d.add(income, 1) }, balanceDeltas{"a": 100}},
+		{"create expense", func(d balanceDeltas) { d.add(expense, 1) }, balanceDeltas{"a": -40}},
+		{"delete expense", func(d balanceDeltas) { d.add(expense, -1) }, balanceDeltas{"a": 40}},
+		{"update expense amount", func(d balanceDeltas) {
+			updated := *expense
+			updated.Amount = 60
+			d.add(expense, -1)
+			d.add(&updated, 1)
+		}, balanceDeltas{"a": -20}},
+		{"update expense to income", func(d balanceDeltas) {
+			updated := *expense
+			updated.Type = models.TransactionTypeIncome
+			d.add(expense, -1)
+			d.add(&updated, 1)
+		}, balanceDeltas{"a": 80}},
+		{"create transfer", func(d balanceDeltas) { d.add(transfer, 1) }, balanceDeltas{"a": -50, "b": 50}},
+		{"delete transfer", func(d balanceDeltas) { d.add(transfer, -1) }, balanceDeltas{"a": 50, "b": -50}},
+		{"create cross-currency transfer", func(d balanceDeltas) { d.add(converted, 1) }, balanceDeltas{"a": -50, "b": 45}},
+		{"delete cross-currency transfer", func(d balanceDeltas) { d.add(converted, -1) }, balanceDeltas{"a": 50, "b": -45}},
+		{"no sub-account", func(d balanceDeltas) {
+			d.add(&models.Transaction{Type: models.TransactionTypeExpense, Amount: 10}, 1)
+		}, balanceDeltas{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(balanceDeltas)
+			tt.apply(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBalanceDeltasOutboxSkipsUnchanged(t *testing.T) {
+	tx := &models.Transaction{ID: "tx", UserID: "user", Type: models.TransactionTypeExpense, Amount: 40, SubAccountID: strPtr("a")}
+	deltas := make(balanceDeltas)
+	deltas.add(tx, -1)
+	deltas.add(tx, 1)
+	deltas["b"] = 5
+
+	now := time.Now()
+	entries := deltas.outbox(tx, now)
+	want := []models.BalanceOutbox{{TransactionID: "tx", UserID: "user", SubAccountID: "b", Delta: 5, NextAttemptAt: now}}
+	if !reflect.DeepEqual(entries, want) {
+		t.Fatalf("got %+v, want %+v", entries, want)
+	}
+}
+
+func TestOutboxBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{5, 32 * time.Second},
+		{9, 512 * time.Second},
+		{10, maxOutboxBackoff},
+		{40, maxOutboxBackoff},
+	}
+	for _, tt := range tests {
+		if got := outboxBackoff(tt.attempts); got != tt.want {
+			t.Errorf("outboxBackoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestDeliverBalanceAdjustmentIsIdempotent(t *testing.T) {
+	accounts := newFakeAccounts("a")
+	s := &TransactionService{accountsClient: accounts}
+	entry := &models.BalanceOutbox{ID: "key-1", UserID: "user", SubAccountID: "a", Delta: -25}
+	ctx := context.Background()
+
+	// The first reply is lost, so the entry is delivered again
+	for i := 0; i < 2; i++ {
+		if err := s.deliverBalanceAdjustment(ctx, entry); err != nil {
+			t.Fatalf("delivery %d: %v", i+1, err)
+		}
+	}
+	if got := accounts.balance("a"); got != -25 {
+		t.Fatalf("balance = %v, want -25", got)
+	}
+}
+
+func TestDeliverBalanceAdjustmentErrors(t *testing.T) {
+	accounts := newFakeAccounts("a")
+	s := &TransactionService{accountsClient: accounts}
+	ctx := context.Background()
+
+	// A deleted sub-account has no balance left to keep in step
+	missing := &models.BalanceOutbox{ID: "key-1", UserID: "user", SubAccountID: "gone", Delta: 10}
+	if err := s.deliverBalanceAdjustment(ctx, missing); err != nil {
+		t.Fatalf("missing sub-account: %v", err)
+	}
+
+	accounts.failures = 1
+	entry := &models.BalanceOutbox{ID: "key-2", UserID: "user", SubAccountID: "a", Delta: 10}
+	if err := s.deliverBalanceAdjustment(ctx, entry); status.Code(err) != codes.Unavailable {
+		t.Fatalf("unavailable accounts service: got %v, want Unavailable", err)
+	}
+}
+
+// newOutboxTestService returns a service on a test database whose accounts
+// service holds subAccountA and subAccountB
+func newOutboxTestService(t *testing.T) (*TransactionService, *repository.TransactionRepository, *fakeAccounts) {
+	t.Helper()
+	db := databasetest.Open(t, &models.Transaction{}, &models.TransactionSplit{}, &models.BalanceOutbox{}, &models.CategoryRule{})
+	repo := repository.NewTransactionRepository(db)
+	accounts := newFakeAccounts(subAccountA, subAccountB)
+	s := NewTransactionService(repo, repository.NewCategoryRuleRepository(db), nil, nil, accounts, 0, nil)
+	return s, repo, accounts
+}
+
+const (
+	testUserID  = "00000000-0000-0000-0000-000000000001"
+	subAccountA = "00000000-0000-0000-0000-00000000000a"
+	subAccountB = "00000000-0000-0000-0000-00000000000b"
+)
+
+func queuedAdjustments(t *testing.T, repo *repository.TransactionRepository) []models.BalanceOutbox {
+	t.Helper()
+	entries, err := repo.DueBalanceAdjustments(context.Background(), time.Now().Add(time.Hour), outboxBatchSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return entries
+}
+
+func TestTransactionBalances(t *testing.T) {
+	s, repo, accounts := newOutboxTestService(t)
+	ctx := context.Background()
+
+	tx, err := s.CreateTransaction(ctx, CreateTransactionInput{
+		UserID: testUserID, SubAccountID: strPtr(subAccountA), Amount: 100,
+		Type: models.TransactionTypeIncome, Date: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got := accounts.balance(subAccountA); got != 100 {
+		t.Fatalf("after create: balance = %v, want 100", got)
+	}
+
+	if _, err := s.UpdateTransaction(ctx, UpdateTransactionInput{ID: tx.ID, UserID: testUserID, Amount: 60}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if got := accounts.balance(subAccountA); got != 60 {
+		t.Fatalf("after update: balance = %v, want 60", got)
+	}
+
+	transfer, err := s.CreateTransaction(ctx, CreateTransactionInput{
+		UserID: testUserID, SubAccountID: strPtr(subAccountA), TransferToSubAccount: strPtr(subAccountB),
+		Amount: 25, Type: models.TransactionTypeTransfer, Date: time.Now(),
+	})
+	if err != nil {
+		t.Fatalf("transfer: %v", err)
+	}
+	if a, b := accounts.balance(subAccountA), accounts.balance(subAccountB); a != 35 || b != 25 {
+		t.Fatalf("after transfer: balances = %v, %v, want 35, 25", a, b)
+	}
+
+	if err := s.DeleteTransaction(ctx, transfer.ID, testUserID); err != nil {
+		t.Fatalf("delete transfer: %v", err)
+	}
+	if err := s.DeleteTransaction(ctx, tx.ID, testUserID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if a, b := accounts.balance(subAccountA), accounts.balance(subAccountB); a != 0 || b != 0 {
+		t.Fatalf("after deletes: balances = %v, %v, want 0, 0", a, b)
+	}
+
+	if entries := queuedAdjustments(t, repo); len(entries) != 0 {
+		t.Fatalf("%d adjustments left queued, want none", len(entries))
+	}
+}
+
+func TestTransactionBalancesRetried(t *testing.T) {
+	s, repo, accounts := newOutboxTestService(t)
+	ctx := context.Background()
+
+	// The accounts service is down when the transaction is created
+	accounts.failures = 1
+	if _, err := s.CreateTransaction(ctx, CreateTransactionInput{
+		UserID: testUserID, SubAccountID: strPtr(subAccountA), Amount: 40,
+		Type: models.TransactionTypeExpense, Date: time.Now(),
+	}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if got := accounts.balance(subAccountA); got != 0 {
+		t.Fatalf("balance = %v before the retry, want 0", got)
+	}
+
+	entries := queuedAdjustments(t, repo)
+	if len(entries) != 1 || entries[0].Attempts != 1 || entries[0].LastError == "" {
+		t.Fatalf("queued = %+v, want one entry with a failed attempt", entries)
+	}
+
+	// Not due until the backoff passes
+	s.flushBalanceOutbox(ctx)
+	if got := accounts.balance(subAccountA); got != 0 {
+		t.Fatalf("balance = %v before the entry is due, want 0", got)
+	}
+
+	if err := repo.RetryBalanceAdjustment(ctx, entries[0].ID, errors.New("retry now"), time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	s.flushBalanceOutbox(ctx)
+	s.flushBalanceOutbox(ctx)
+	if got := accounts.balance(subAccountA); got != -40 {
+		t.Fatalf("balance = %v after the retry, want -40", got)
+	}
+	if entries := queuedAdjustments(t, repo); len(entries) != 0 {
+		t.Fatalf("%d adjustments left queued, want none", len(entries))
+	}
+
+	// Redelivering the same entry, as a second replica might, changes nothing
+	if err := s.deliverBalanceAdjustment(ctx, &entries[0]); err != nil {
+		t.Fatal(err)
+	}
+	if got := accounts.balance(subAccountA); got != -40 {
+		t.Fatalf("balance = %v after a repeat delivery, want -40", got)
+	}
+}
+
+func TestImportedTransactionsLeaveBalances(t *testing.T) {
+	s, repo, accounts := newOutboxTestService(t)
+	ctx := context.Background()
+
+	report, err := s.ImportTransactions(ctx, strings.NewReader("2026-03-01,-100,Coffee\n"), ImportOptions{
+		UserID:       testUserID,
+		SubAccountID: strPtr(subAccountA),
+		Mapping:      ColumnMapping{Date: "0", Amount: "1", Description: "2"},
+	})
+	if err != nil || report.Imported != 1 {
+		t.Fatalf("import: report %+v, err %v", report, err)
+	}
+
+	page, err := s.ListTransactions(ctx, testUserID, "", 1, 10, "", false, "")
+	if err != nil || len(page.Items) != 1 {
+		t.Fatalf("list: %d transactions, err %v", len(page.Items), err)
+	}
+	imported := page.Items[0]
+	if imported.BalanceApplied {
+		t.Fatal("imported transaction is marked as having a balance effect")
+	}
+
+	// Neither editing nor deleting it reverses an effect that never happened
+	if _, err := s.UpdateTransaction(ctx, UpdateTransactionInput{ID: imported.ID, UserID: testUserID, Amount: 60}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := s.DeleteTransaction(ctx, imported.ID, testUserID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if entries := queuedAdjustments(t, repo); len(entries) != 0 {
+		t.Fatalf("%d adjustments queued for an imported transaction, want none", len(entries))
+	}
+	if accounts.calls != 0 || accounts.balance(subAccountA) != 0 {
+		t.Fatalf("accounts service called %d times, balance %v; want no adjustments", accounts.calls, accounts.balance(subAccountA))
+	}
+}
+
+func TestFailedWriteQueuesNothing(t *testing.T) {
+	s, repo, accounts := newOutboxTestService(t)
+	ctx := context.Background()
+
+	err := s.DeleteTransaction(ctx, "00000000-0000-0000-0000-0000000000ff", testUserID)
+	if !errors.Is(err, repository.ErrTransactionNotFound) {
+		t.Fatalf("got %v, want ErrTransactionNotFound", err)
+	}
+
+	tx := &models.Transaction{ID: "00000000-0000-0000-0000-0000000000ff", UserID: testUserID, Type: models.TransactionTypeIncome, Amount: 10, SubAccountID: strPtr(subAccountA)}
+	deltas := make(balanceDeltas)
+	deltas.add(tx, 1)
+	writeErr := errors.New("write failed")
+	if err := s.applyBalances(ctx, tx, deltas, func(*repository.TransactionRepository) error { return writeErr }); !errors.Is(err, writeErr) {
+		t.Fatalf("got %v, want the write error", err)
+	}
+
+	if entries := queuedAdjustments(t, repo); len(entries) != 0 {
+		t.Fatalf("%d adjustments queued for a failed write, want none", len(entries))
+	}
+	if accounts.calls != 0 {
+		t.Fatalf("accounts service called %d times, want 0", accounts.calls)
+	}
+}
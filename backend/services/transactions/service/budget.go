@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"github.com/radmickey/money-control/backend/services/transactions/repository"
+)
+
+var (
+	ErrInvalidBudgetPeriod = errors.New("period must be weekly, monthly or yearly")
+	ErrInvalidBudgetAmount = errors.New("budget amount must be positive")
+)
+
+// CreateBudgetInput holds input for creating a budget
+type CreateBudgetInput struct {
+	UserID    string
+	Category  models.TransactionCategory
+	Period    models.BudgetPeriod
+	Amount    float64
+	Currency  string
+	Carryover bool
+}
+
+// CreateBudget creates a spending budget for a category
+func (s *TransactionService) CreateBudget(ctx context.Context, input CreateBudgetInput) (*models.Budget, error) {
+	if input.Period == "" {
+		input.Period = models.BudgetPeriodMonthly
+	}
+	if !input.Period.IsValid() {
+		return nil, ErrInvalidBudgetPeriod
+	}
+	if input.Amount <= 0 {
+		return nil, ErrInvalidBudgetAmount
+	}
+	if input.Currency == "" {
+		input.Currency = "USD"
+	}
+
+	budget := &models.Budget{
+		UserID:    input.UserID,
+		Category:  input.Category,
+		Period:    input.Period,
+		Amount:    input.Amount,
+		Currency:  input.Currency,
+		Carryover: input.Carryover,
+	}
+
+	if err := s.budgetRepo.Create(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// ListBudgets lists a user's budgets, optionally for one period
+func (s *TransactionService) ListBudgets(ctx context.Context, userID string, period models.BudgetPeriod) ([]models.Budget, error) {
+	return s.budgetRepo.ListByUser(ctx, userID, period)
+}
+
+// UpdateBudgetInput holds input for updating a budget
+type UpdateBudgetInput struct {
+	ID        string
+	UserID    string
+	Amount    float64
+	Currency  string
+	Carryover *bool
+}
+
+// UpdateBudget updates a budget's limit, currency or carryover setting
+func (s *TransactionService) UpdateBudget(ctx context.Context, input UpdateBudgetInput) (*models.Budget, error) {
+	budget, err := s.budgetRepo.GetByID(ctx, input.ID, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Amount < 0 {
+		return nil, ErrInvalidBudgetAmount
+	}
+	if input.Amount > 0 {
+		budget.Amount = input.Amount
+	}
+	if input.Currency != "" {
+		budget.Currency = input.Currency
+	}
+	if input.Carryover != nil {
+		budget.Carryover = *input.Carryover
+	}
+
+	if err := s.budgetRepo.Update(ctx, budget); err != nil {
+		return nil, err
+	}
+
+	return budget, nil
+}
+
+// DeleteBudget deletes a budget
+func (s *TransactionService) DeleteBudget(ctx context.Context, id, userID string) error {
+	return s.budgetRepo.Delete(ctx, id, userID)
+}
+
+// BudgetProgress reports spending against one budget in the current period
+type BudgetProgress struct {
+	BudgetID   string                     `json:"budget_id"`
+	Category   models.TransactionCategory `json:"category"`
+	Period     models.BudgetPeriod        `json:"period"`
+	Currency   string                     `json:"currency"`
+	Amount     float64                    `json:"amount"`
+	Carryover  float64                    `json:"carryover"`
+	Limit      float64                    `json:"limit"`
+	Spent      float64                    `json:"spent"`
+	Remaining  float64                    `json:"remaining"`
+	Percent    float64                    `json:"percent"`
+	Expected   float64                    `json:"expected"`
+	OverBudget bool                       `json:"over_budget"`
+	StartDate  time.Time                  `json:"start_date"`
+	EndDate    time.Time                  `json:"end_date"`
+}
+
+// BudgetStatus summarizes all budgets for the current period
+type BudgetStatus struct {
+	Budgets    []BudgetProgress `json:"budgets"`
+	OverBudget []BudgetProgress `json:"over_budget"`
+}
+
+// GetBudgetStatus reports spent, remaining and percent used for each of the
+// user's budgets in the current period. A budget created mid-period gets a
+// prorated limit; with carryover enabled, last period's unspent (or overspent)
+// amount is added to this period's limit.
+func (s *TransactionService) GetBudgetStatus(ctx context.Context, userID string, period models.BudgetPeriod) (*BudgetStatus, error) {
+	if period != "" && !period.IsValid() {
+		return nil, ErrInvalidBudgetPeriod
+	}
+
+	budgets, err := s.budgetRepo.ListByUser(ctx, userID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	spending := newSpendingLookup(s, userID)
+	status := &BudgetStatus{
+		Budgets:    make([]BudgetProgress, 0, len(budgets)),
+		OverBudget: make([]BudgetProgress, 0),
+	}
+
+	for _, budget := range budgets {
+		start, end := budget.Period.PeriodBounds(now)
+
+		spent, err := spending.spent(ctx, budget, start, end)
+		if err != nil {
+			return nil, err
+		}
+
+		limit := proratedLimit(budget, start, end)
+
+		var carryover float64
+		if budget.Carryover {
+			prevStart, _ := budget.Period.PeriodBounds(start.Add(-time.Nanosecond))
+			if budget.CreatedAt.Before(start) {
+				prevSpent, err := spending.spent(ctx, budget, prevStart, start)
+				if err != nil {
+					return nil, err
+				}
+				carryover = proratedLimit(budget, prevStart, start) - prevSpent
+			}
+		}
+		limit += carryover
+
+		progress := BudgetProgress{
+			BudgetID:   budget.ID,
+			Category:   budget.Category,
+			Period:     budget.Period,
+			Currency:   budget.Currency,
+			Amount:     budget.Amount,
+			Carryover:  carryover,
+			Limit:      limit,
+			Spent:      spent,
+			Remaining:  limit - spent,
+			Expected:   limit * elapsedFraction(budget, start, end, now),
+			OverBudget: spent > limit,
+			StartDate:  start,
+			EndDate:    end,
+		}
+		if limit > 0 {
+			progress.Percent = (spent / limit) * 100
+		}
+
+		status.Budgets = append(status.Budgets, progress)
+		if progress.OverBudget {
+			status.OverBudget = append(status.OverBudget, progress)
+		}
+	}
+
+	return status, nil
+}
+
+// proratedLimit scales the budget amount down for a period the budget only
+// partly covers because it was created mid-period
+func proratedLimit(budget models.Budget, start, end time.Time) float64 {
+	if !budget.CreatedAt.After(start) {
+		return budget.Amount
+	}
+	if !budget.CreatedAt.Before(end) {
+		return 0
+	}
+	return budget.Amount * end.Sub(budget.CreatedAt).Seconds() / end.Sub(start).Seconds()
+}
+
+// elapsedFraction is how much of the budget's active part of the period has passed
+func elapsedFraction(budget models.Budget, start, end, now time.Time) float64 {
+	if budget.CreatedAt.After(start) {
+		start = budget.CreatedAt
+	}
+	total := end.Sub(start).Seconds()
+	if total <= 0 {
+		return 1
+	}
+	return min(1, max(0, now.Sub(start).Seconds()/total))
+}
+
+// spendingLookup caches per-category spend by currency and window
+type spendingLookup struct {
+	s         *TransactionService
+	userID    string
+	summaries map[string]*repository.TransactionSummary
+}
+
+func newSpendingLookup(s *TransactionService, userID string) *spendingLookup {
+	return &spendingLookup{
+		s:         s,
+		userID:    userID,
+		summaries: make(map[string]*repository.TransactionSummary),
+	}
+}
+
+// spent returns the budget category's spend in [start, end)
+func (l *spendingLookup) spent(ctx context.Context, budget models.Budget, start, end time.Time) (float64, error) {
+	key := budget.Currency + "|" + start.Format(time.RFC3339) + "|" + end.Format(time.RFC3339)
+	summary, ok := l.summaries[key]
+	if !ok {
+		var err error
+		summary, err = l.s.GetTransactionsSummary(ctx, l.userID, start, end.Add(-time.Nanosecond), budget.Currency)
+		if err != nil {
+			return 0, err
+		}
+		l.summaries[key] = summary
+	}
+	return summary.ByCategory[string(budget.Category)], nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+// CashFlowBucket holds income and expenses for one month-long bucket
+type CashFlowBucket struct {
+	StartDate time.Time `json:"start_date"`
+	EndDate   time.Time `json:"end_date"`
+	Income    float64   `json:"income"`
+	Expenses  float64   `json:"expenses"`
+}
+
+// GetMonthlyCashFlow splits [startDate, endDate] into month-long buckets
+// counted from startDate and totals income and expenses for each in a single
+// query. When baseCurrency is set, amounts are converted at each day's rate.
+func (s *TransactionService) GetMonthlyCashFlow(ctx context.Context, userID string, startDate, endDate time.Time, baseCurrency string) ([]CashFlowBucket, error) {
+	boundaries := monthlyBoundaries(startDate, endDate)
+	if len(boundaries) < 2 {
+		return []CashFlowBucket{}, nil
+	}
+
+	buckets := make([]CashFlowBucket, len(boundaries)-1)
+	for i := range buckets {
+		buckets[i] = CashFlowBucket{StartDate: boundaries[i], EndDate: boundaries[i+1]}
+	}
+
+	totals, err := s.txRepo.SumByBucket(ctx, userID, boundaries)
+	if err != nil {
+		return nil, err
+	}
+
+	convert := baseCurrency != "" && s.currencyClient != nil
+	rates := make(map[string]float64)
+	for _, t := range totals {
+		if t.Bucket < 0 || t.Bucket >= len(buckets) {
+			continue
+		}
+
+		amount := t.Total
+		if convert {
+			rate, err := s.rateAsOf(ctx, rates, t.Currency, baseCurrency, t.Day)
+			if err != nil {
+				return nil, err
+			}
+			amount *= rate
+		}
+
+		if t.Type == models.TransactionTypeIncome {
+			buckets[t.Bucket].Income += amount
+		} else {
+			buckets[t.Bucket].Expenses += amount
+		}
+	}
+
+	return buckets, nil
+}
+
+// monthlyBoundaries returns the bucket edges from start to end, one month
+// apart, with the final edge clamped to end
+func monthlyBoundaries(start, end time.Time) []time.Time {
+	if !start.Before(end) {
+		return nil
+	}
+	boundaries := []time.Time{start}
+	for current := start; current.Before(end); {
+		next := current.AddDate(0, 1, 0)
+		if next.After(end) {
+			next = end
+		}
+		boundaries = append(boundaries, next)
+		current = next
+	}
+	return boundaries
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+func TestMonthlyBoundaries(t *testing.T) {
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+
+	want := []time.Time{start, start.AddDate(0, 1, 0), start.AddDate(0, 2, 0), end}
+	if got := monthlyBoundaries(start, end); !reflect.DeepEqual(got, want) {
+		t.Errorf("monthlyBoundaries = %v, want %v", got, want)
+	}
+	if got := monthlyBoundaries(end, start); got != nil {
+		t.Errorf("reversed range: monthlyBoundaries = %v, want nil", got)
+	}
+}
+
+// TestMonthlyCashFlowMatchesSummaries checks the single bucketed query
+// against the per-month summaries cash flow used to be built from
+func TestMonthlyCashFlowMatchesSummaries(t *testing.T) {
+	s, repo, _ := newOutboxTestService(t)
+	ctx := context.Background()
+
+	start := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 4, 15, 0, 0, 0, 0, time.UTC)
+
+	seed := []struct {
+		amount   float64
+		currency string
+		txType   models.TransactionType
+		at       time.Time
+	}{
+		{1000, "USD", models.TransactionTypeIncome, start.Add(time.Hour)},
+		{40.5, "USD", models.TransactionTypeExpense, start.AddDate(0, 0, 3)},
+		{12.25, "EUR", models.TransactionTypeExpense, start.AddDate(0, 0, 3)},
+		{60, "USD", models.TransactionTypeExpense, start.AddDate(0, 1, 0).Add(-time.Minute)},
+		{1000, "USD", models.TransactionTypeIncome, start.AddDate(0, 1, 2)},
+		{75, "EUR", models.TransactionTypeExpense, start.AddDate(0, 1, 20)},
+		{200, "USD", models.TransactionTypeIncome, start.AddDate(0, 2, 5)},
+		{19.99, "USD", models.TransactionTypeExpense, end.Add(-time.Hour)},
+		// Outside the range, and a transfer, which is neither income nor expense
+		{500, "USD", models.TransactionTypeIncome, start.Add(-time.Hour)},
+		{500, "USD", models.TransactionTypeExpense, end.Add(time.Hour)},
+		{300, "USD", models.TransactionTypeTransfer, start.AddDate(0, 1, 5)},
+	}
+	for _, tx := range seed {
+		if err := repo.Create(ctx, &models.Transaction{
+			UserID: testUserID, Amount: tx.amount, Currency: tx.currency, Type: tx.txType,
+			Category: models.CategoryOther, Date: tx.at,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buckets, err := s.GetMonthlyCashFlow(ctx, testUserID, start, end, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 3 {
+		t.Fatalf("%d buckets, want 3", len(buckets))
+	}
+
+	var income, expenses float64
+	for i, bucket := range buckets {
+		summary, err := s.GetTransactionsSummary(ctx, testUserID, bucket.StartDate, bucket.EndDate, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !closeTo(bucket.Income, summary.TotalIncome) || !closeTo(bucket.Expenses, summary.TotalExpenses) {
+			t.Errorf("bucket %d: income %v, expenses %v; summary income %v, expenses %v",
+				i, bucket.Income, bucket.Expenses, summary.TotalIncome, summary.TotalExpenses)
+		}
+		income += bucket.Income
+		expenses += bucket.Expenses
+	}
+
+	whole, err := s.GetTransactionsSummary(ctx, testUserID, start, end, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !closeTo(income, whole.TotalIncome) || !closeTo(expenses, whole.TotalExpenses) {
+		t.Errorf("bucket totals income %v, expenses %v; range summary income %v, expenses %v",
+			income, expenses, whole.TotalIncome, whole.TotalExpenses)
+	}
+}
+
+// TestMonthlyCashFlowBoundaryCountedOnce covers transactions on a bucket
+// edge, which adjacent inclusive summaries both counted
+func TestMonthlyCashFlowBoundaryCountedOnce(t *testing.T) {
+	s, repo, _ := newOutboxTestService(t)
+	ctx := context.Background()
+
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	edge := start.AddDate(0, 1, 0)
+
+	for _, at := range []time.Time{start, edge, end} {
+		if err := repo.Create(ctx, &models.Transaction{
+			UserID: testUserID, Amount: 10, Currency: "USD", Type: models.TransactionTypeExpense,
+			Category: models.CategoryOther, Date: at,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	buckets, err := s.GetMonthlyCashFlow(ctx, testUserID, start, end, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The edge opens the second bucket, which also takes the range end
+	if len(buckets) != 2 || buckets[0].Expenses != 10 || buckets[1].Expenses != 20 {
+		t.Fatalf("buckets = %+v, want expenses 10 then 20", buckets)
+	}
+}
+
+// closeTo reports whether got is within a rounding error of want
+func closeTo(got, want float64) bool {
+	return math.Abs(got-want) < 1e-6
+}
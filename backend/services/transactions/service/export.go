@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+const (
+	// defaultExportMaxSpan is used when no export span is configured
+	defaultExportMaxSpan = 5 * 365 * 24 * time.Hour
+	// exportPageSize is how many transactions are loaded per page while
+	// exporting, the most a page can hold
+	exportPageSize = 100
+)
+
+var (
+	ErrExportRange = errors.New("export start date must be before end date")
+	ErrExportSpan  = errors.New("export date range is too large")
+)
+
+// ExportTransactions pages through a user's transactions in a date range and
+// hands each page to emit, so exports never hold the full range in memory
+func (s *TransactionService) ExportTransactions(ctx context.Context, userID string, startDate, endDate time.Time, emit func([]models.Transaction) error) error {
+	if !startDate.Before(endDate) {
+		return ErrExportRange
+	}
+	if endDate.Sub(startDate) > s.exportMaxSpan {
+		return ErrExportSpan
+	}
+
+	cursor := ""
+	for {
+		page, err := s.ListTransactionsByDateRange(ctx, userID, startDate, endDate, "", 1, exportPageSize, cursor)
+		if err != nil {
+			return err
+		}
+		if len(page.Items) > 0 {
+			if err := emit(page.Items); err != nil {
+				return err
+			}
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		cursor = page.NextCursor
+	}
+}
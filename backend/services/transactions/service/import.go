@@ -0,0 +1,414 @@
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+const (
+	// importBatchSize is how many parsed rows are deduped and inserted together
+	importBatchSize = 500
+	// maxImportErrors bounds the per-row errors kept in a report
+	maxImportErrors = 100
+)
+
+var (
+	ErrImportMapping = errors.New("column mapping must include date and amount columns")
+	ErrImportColumn  = errors.New("mapped column not found in CSV header")
+)
+
+// importDateLayouts are tried in order when no date format is given
+var importDateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"01/02/2006",
+	"02.01.2006",
+}
+
+// ColumnMapping names the CSV columns holding each transaction field. Columns
+// are header names, or zero-based indexes when the file has no header.
+type ColumnMapping struct {
+	Date        string
+	Amount      string
+	Description string
+	Merchant    string
+	Category    string
+	Currency    string
+	Type        string
+}
+
+// ImportOptions configures a CSV import
+type ImportOptions struct {
+	UserID       string
+	SubAccountID *string
+	Currency     string
+	Mapping      ColumnMapping
+	HasHeader    bool
+	Delimiter    rune
+	DateFormat   string
+}
+
+// ImportRowError describes a row that could not be imported
+type ImportRowError struct {
+	Row   int    `json:"row"`
+	Error string `json:"error"`
+}
+
+// ImportReport summarizes a CSV import
+type ImportReport struct {
+	Imported int              `json:"imported"`
+	Skipped  int              `json:"skipped"`
+	Failed   int              `json:"failed"`
+	Errors   []ImportRowError `json:"errors,omitempty"`
+}
+
+func (r *ImportReport) fail(row int, err error) {
+	r.Failed++
+	if len(r.Errors) < maxImportErrors {
+		r.Errors = append(r.Errors, ImportRowError{Row: row, Error: err.Error()})
+	}
+}
+
+// columnIndexes holds resolved column positions; -1 means unmapped
+type columnIndexes struct {
+	date, amount, description, merchant, category, currency, txType int
+}
+
+// ImportTransactions reads a CSV bank statement row by row, categorizes each
+// transaction and inserts them in batches. Rows matching an existing
+// transaction (or an earlier row) by date, amount and merchant are skipped.
+// Unlike CreateTransaction, imports leave the sub-account balance alone: a
+// statement records history the balance already reflects.
+func (s *TransactionService) ImportTransactions(ctx context.Context, r io.Reader, opts ImportOptions) (*ImportReport, error) {
+	if opts.Mapping.Date == "" || opts.Mapping.Amount == "" {
+		return nil, ErrImportMapping
+	}
+
+	// The sub-account is checked once; rows in another currency are converted
+	// into its currency
+	var subAccountCurrency string
+	if opts.SubAccountID != nil {
+		currency, err := s.subAccountCurrency(ctx, opts.UserID, *opts.SubAccountID)
+		if err != nil {
+			return nil, err
+		}
+		subAccountCurrency = currency
+	}
+	if opts.Currency == "" {
+		opts.Currency = subAccountCurrency
+	}
+	if opts.Currency == "" {
+		opts.Currency = "USD"
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+
+	var header []string
+	row := 0
+	if opts.HasHeader {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return &ImportReport{}, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV header: %w", err)
+		}
+		row++
+		header = make([]string, len(record))
+		for i, name := range record {
+			header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		}
+	}
+
+	cols, err := resolveColumns(opts.Mapping, header)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rules are loaded once rather than per row
+	rules, _ := s.ruleRepo.GetByUserID(ctx, opts.UserID)
+
+	report := &ImportReport{}
+	seen := make(map[string]struct{})
+	rates := make(map[string]float64)
+	batch := make([]models.Transaction, 0, importBatchSize)
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		row++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				report.fail(row, err)
+				continue
+			}
+			return report, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+
+		tx, err := parseImportRow(record, cols, opts)
+		if err != nil {
+			report.fail(row, err)
+			continue
+		}
+		if tx.Category == "" {
+			tx.Category = s.categorize(rules, tx.Description, tx.Merchant)
+		}
+		if err := s.matchCurrency(ctx, &tx, subAccountCurrency, rates); err != nil {
+			report.fail(row, err)
+			continue
+		}
+
+		batch = append(batch, tx)
+		if len(batch) == importBatchSize {
+			if err := s.flushImportBatch(ctx, opts, batch, seen, report); err != nil {
+				return report, err
+			}
+			batch = batch[:0]
+		}
+	}
+
+	if err := s.flushImportBatch(ctx, opts, batch, seen, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// flushImportBatch drops duplicates from a batch and inserts the rest
+func (s *TransactionService) flushImportBatch(ctx context.Context, opts ImportOptions, batch []models.Transaction, seen map[string]struct{}, report *ImportReport) error {
+	if len(batch) == 0 {
+		return nil
+	}
+
+	start, end := batch[0].Date, batch[0].Date
+	for _, tx := range batch[1:] {
+		if tx.Date.Before(start) {
+			start = tx.Date
+		}
+		if tx.Date.After(end) {
+			end = tx.Date
+		}
+	}
+
+	existing, err := s.txRepo.ListForDedupe(ctx, opts.UserID, opts.SubAccountID, dayStart(start), dayStart(end).Add(24*time.Hour-time.Nanosecond))
+	if err != nil {
+		return err
+	}
+	for _, tx := range existing {
+		seen[dedupeKey(tx)] = struct{}{}
+	}
+
+	toCreate := make([]models.Transaction, 0, len(batch))
+	for _, tx := range batch {
+		key := dedupeKey(tx)
+		if _, ok := seen[key]; ok {
+			report.Skipped++
+			continue
+		}
+		seen[key] = struct{}{}
+		toCreate = append(toCreate, tx)
+	}
+
+	if err := s.txRepo.CreateBatch(ctx, toCreate); err != nil {
+		return err
+	}
+	report.Imported += len(toCreate)
+	return nil
+}
+
+// resolveColumns maps column names (or indexes) to record positions
+func resolveColumns(m ColumnMapping, header []string) (columnIndexes, error) {
+	resolve := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		want := strings.ToLower(strings.TrimSpace(name))
+		for i, h := range header {
+			if h == want {
+				return i, nil
+			}
+		}
+		if idx, err := strconv.Atoi(want); err == nil && idx >= 0 && (header == nil || idx < len(header)) {
+			return idx, nil
+		}
+		return -1, fmt.Errorf("%w: %s", ErrImportColumn, name)
+	}
+
+	var cols columnIndexes
+	fields := []struct {
+		name string
+		dst  *int
+	}{
+		{m.Date, &cols.date},
+		{m.Amount, &cols.amount},
+		{m.Description, &cols.description},
+		{m.Merchant, &cols.merchant},
+		{m.Category, &cols.category},
+		{m.Currency, &cols.currency},
+		{m.Type, &cols.txType},
+	}
+	for _, f := range fields {
+		idx, err := resolve(f.name)
+		if err != nil {
+			return cols, err
+		}
+		*f.dst = idx
+	}
+	return cols, nil
+}
+
+// parseImportRow builds a transaction from a CSV record. Negative amounts are
+// expenses and positive ones income, unless a type column says otherwise. A
+// statement only shows one side of a transfer, so transfers are imported as
+// an expense or income by their sign.
+func parseImportRow(record []string, cols columnIndexes, opts ImportOptions) (models.Transaction, error) {
+	field := func(idx int) string {
+		if idx < 0 || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+
+	date, err := parseImportDate(field(cols.date), opts.DateFormat)
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	amount, err := parseImportAmount(field(cols.amount))
+	if err != nil {
+		return models.Transaction{}, err
+	}
+
+	txType := models.TransactionTypeIncome
+	if amount < 0 {
+		txType = models.TransactionTypeExpense
+	}
+	switch strings.ToLower(field(cols.txType)) {
+	case "expense", "debit", "dr":
+		txType = models.TransactionTypeExpense
+	case "income", "credit", "cr":
+		txType = models.TransactionTypeIncome
+	}
+
+	category := models.TransactionCategory(strings.ToLower(field(cols.category)))
+	if category == "" && strings.EqualFold(field(cols.txType), "transfer") {
+		category = models.CategoryTransfer
+	}
+
+	currency := strings.ToUpper(field(cols.currency))
+	if len(currency) != 3 {
+		currency = opts.Currency
+	}
+
+	return models.Transaction{
+		UserID:       opts.UserID,
+		SubAccountID: opts.SubAccountID,
+		Amount:       math.Abs(amount),
+		Currency:     currency,
+		Type:         txType,
+		Category:     category,
+		Description:  field(cols.description),
+		Merchant:     field(cols.merchant),
+		Date:         date,
+	}, nil
+}
+
+// parseImportDate parses a date with the given layout or the common fallbacks
+func parseImportDate(value, layout string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("missing date")
+	}
+	layouts := importDateLayouts
+	if layout != "" {
+		layouts = []string{layout}
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q", value)
+}
+
+// parseImportAmount parses amounts such as "-1,234.56", "$12.00", "(45.10)" or "1.234,56"
+func parseImportAmount(value string) (float64, error) {
+	if value == "" {
+		return 0, errors.New("missing amount")
+	}
+
+	negative := strings.HasPrefix(value, "(") && strings.HasSuffix(value, ")")
+
+	// A comma after the last dot, or a lone comma followed by one or two
+	// digits, is a decimal separator ("1.234,56" or "12,5")
+	lastComma, lastDot := strings.LastIndex(value, ","), strings.LastIndex(value, ".")
+	decimals := len(strings.TrimRight(value[lastComma+1:], " )"))
+	if lastComma > lastDot && (lastDot >= 0 || strings.Count(value, ",") == 1 && decimals <= 2) {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, ",", ".", 1)
+	}
+
+	cleaned := strings.Map(func(r rune) rune {
+		if (r >= '0' && r <= '9') || r == '.' || r == '-' {
+			return r
+		}
+		return -1
+	}, value)
+
+	amount, err := strconv.ParseFloat(cleaned, 64)
+	if err != nil || amount == 0 {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	if negative {
+		amount = -math.Abs(amount)
+	}
+	return amount, nil
+}
+
+// dedupeKey identifies a transaction by day, amount in cents and merchant
+func dedupeKey(tx models.Transaction) string {
+	merchant := tx.Merchant
+	if merchant == "" {
+		merchant = tx.Description
+	}
+	return fmt.Sprintf("%s|%d|%s",
+		tx.Date.Format("2006-01-02"),
+		int64(math.Round(tx.Amount*100)),
+		strings.ToLower(strings.TrimSpace(merchant)),
+	)
+}
+
+func dayStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+func TestParseImportRowType(t *testing.T) {
+	cols := columnIndexes{date: 0, amount: 1, category: 2, txType: 3, description: -1, merchant: -1, currency: -1}
+	opts := ImportOptions{UserID: testUserID, Currency: "USD"}
+
+	tests := []struct {
+		name         string
+		record       []string
+		wantType     models.TransactionType
+		wantCategory models.TransactionCategory
+		wantAmount   float64
+	}{
+		{
+			name:       "negative amount is an expense",
+			record:     []string{"2026-03-01", "-12.50", "", ""},
+			wantType:   models.TransactionTypeExpense,
+			wantAmount: 12.50,
+		},
+		{
+			name:       "positive amount is income",
+			record:     []string{"2026-03-01", "100", "", ""},
+			wantType:   models.TransactionTypeIncome,
+			wantAmount: 100,
+		},
+		{
+			name:       "type column overrides the sign",
+			record:     []string{"2026-03-01", "40", "", "debit"},
+			wantType:   models.TransactionTypeExpense,
+			wantAmount: 40,
+		},
+		{
+			name:         "outgoing transfer is an expense",
+			record:       []string{"2026-03-01", "-250", "", "Transfer"},
+			wantType:     models.TransactionTypeExpense,
+			wantCategory: models.CategoryTransfer,
+			wantAmount:   250,
+		},
+		{
+			name:         "incoming transfer is income",
+			record:       []string{"2026-03-01", "250", "", "transfer"},
+			wantType:     models.TransactionTypeIncome,
+			wantCategory: models.CategoryTransfer,
+			wantAmount:   250,
+		},
+		{
+			name:         "transfer keeps its own category",
+			record:       []string{"2026-03-01", "-80", "Housing", "transfer"},
+			wantType:     models.TransactionTypeExpense,
+			wantCategory: models.CategoryHousing,
+			wantAmount:   80,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, err := parseImportRow(tt.record, cols, opts)
+			if err != nil {
+				t.Fatalf("parseImportRow: %v", err)
+			}
+			if tx.Type != tt.wantType || tx.Category != tt.wantCategory || tx.Amount != tt.wantAmount {
+				t.Errorf("got %s %s %v, want %s %s %v", tx.Type, tx.Category, tx.Amount, tt.wantType, tt.wantCategory, tt.wantAmount)
+			}
+			// Imported rows are never transfers, which need a destination sub-account
+			if tx.TransferToSubAccount != nil {
+				t.Errorf("TransferToSubAccount = %v, want nil", *tx.TransferToSubAccount)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+const (
+	// recurringLookbackMonths is how much history is scanned; enough for three yearly payments
+	recurringLookbackMonths = 25
+	// recurringMinOccurrences is the fewest payments that form a pattern
+	recurringMinOccurrences = 3
+	// recurringAmountTolerance is how far an amount may drift from the group's median
+	recurringAmountTolerance = 0.15
+	// recurringMinConsistency is the share of intervals that must fit the cadence
+	recurringMinConsistency = 0.75
+	// recurringMaxMissed is how many periods may pass without a payment before
+	// the pattern is considered cancelled
+	recurringMaxMissed = 2
+)
+
+// Cadence is how often a recurring transaction repeats
+type Cadence string
+
+const (
+	CadenceWeekly    Cadence = "weekly"
+	CadenceBiweekly  Cadence = "biweekly"
+	CadenceMonthly   Cadence = "monthly"
+	CadenceQuarterly Cadence = "quarterly"
+	CadenceYearly    Cadence = "yearly"
+)
+
+// cadenceSpec describes the expected gap between occurrences, in days
+type cadenceSpec struct {
+	cadence   Cadence
+	days      float64
+	tolerance float64
+}
+
+var cadences = []cadenceSpec{
+	{CadenceWeekly, 7, 1.5},
+	{CadenceBiweekly, 14, 2},
+	{CadenceMonthly, 30.4, 4},
+	{CadenceQuarterly, 91.3, 8},
+	{CadenceYearly, 365.25, 15},
+}
+
+// next returns the occurrence after t for this cadence
+func (c cadenceSpec) next(t time.Time) time.Time {
+	switch c.cadence {
+	case CadenceMonthly:
+		return t.AddDate(0, 1, 0)
+	case CadenceQuarterly:
+		return t.AddDate(0, 3, 0)
+	case CadenceYearly:
+		return t.AddDate(1, 0, 0)
+	default:
+		return t.AddDate(0, 0, int(c.days))
+	}
+}
+
+// RecurringTransaction is a detected repeating payment or income
+type RecurringTransaction struct {
+	Merchant       string                     `json:"merchant"`
+	Category       models.TransactionCategory `json:"category"`
+	Type           models.TransactionType     `json:"type"`
+	Currency       string                     `json:"currency"`
+	Cadence        Cadence                    `json:"cadence"`
+	AverageAmount  float64                    `json:"average_amount"`
+	LastAmount     float64                    `json:"last_amount"`
+	LastDate       time.Time                  `json:"last_date"`
+	NextDate       time.Time                  `json:"next_date"`
+	Occurrences    int                        `json:"occurrences"`
+	Confidence     float64                    `json:"confidence"`
+	TransactionIDs []string                   `json:"transaction_ids"`
+}
+
+// DetectRecurring finds a user's recurring transactions by grouping on
+// normalized merchant and similar amount, then checking that the gaps between
+// payments fit a weekly to yearly cadence. Missed periods and small amount
+// changes are tolerated.
+func (s *TransactionService) DetectRecurring(ctx context.Context, userID string) ([]RecurringTransaction, error) {
+	now := time.Now()
+	transactions, err := s.txRepo.ListAllByDateRange(ctx, userID, now.AddDate(0, -recurringLookbackMonths, 0), now)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string][]models.Transaction)
+	for _, tx := range transactions {
+		if tx.Type == models.TransactionTypeTransfer {
+			continue
+		}
+		key := normalizeMerchant(tx.Merchant)
+		if key == "" {
+			key = normalizeMerchant(tx.Description)
+		}
+		if key == "" {
+			continue
+		}
+		key = string(tx.Type) + "|" + tx.Currency + "|" + key
+		groups[key] = append(groups[key], tx)
+	}
+
+	var recurring []RecurringTransaction
+	for _, group := range groups {
+		for _, cluster := range clusterByAmount(group) {
+			if r, ok := detectCadence(cluster, now); ok {
+				recurring = append(recurring, r)
+			}
+		}
+	}
+
+	sort.Slice(recurring, func(i, j int) bool {
+		return recurring[i].NextDate.Before(recurring[j].NextDate)
+	})
+
+	return recurring, nil
+}
+
+// normalizeMerchant lowercases a merchant name and drops digits and
+// punctuation, so "NETFLIX.COM 8843" and "Netflix.com" group together
+func normalizeMerchant(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case unicode.IsLetter(r):
+			b.WriteRune(r)
+		case unicode.IsSpace(r):
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// clusterByAmount splits transactions into groups of similar amounts, each
+// sorted by date
+func clusterByAmount(transactions []models.Transaction) [][]models.Transaction {
+	sorted := make([]models.Transaction, len(transactions))
+	copy(sorted, transactions)
+	sort.Slice(sorted, func(i, j int) bool {
+		return math.Abs(sorted[i].Amount) < math.Abs(sorted[j].Amount)
+	})
+
+	var clusters [][]models.Transaction
+	var current []models.Transaction
+	var base float64
+	for _, tx := range sorted {
+		amount := math.Abs(tx.Amount)
+		if len(current) > 0 && amount > base*(1+2*recurringAmountTolerance) {
+			clusters = append(clusters, current)
+			current = nil
+		}
+		if len(current) == 0 {
+			base = amount
+		}
+		current = append(current, tx)
+	}
+	if len(current) > 0 {
+		clusters = append(clusters, current)
+	}
+
+	for _, cluster := range clusters {
+		sort.Slice(cluster, func(i, j int) bool {
+			return cluster[i].Date.Before(cluster[j].Date)
+		})
+	}
+	return clusters
+}
+
+// detectCadence checks whether date-sorted transactions repeat on a cadence
+func detectCadence(transactions []models.Transaction, now time.Time) (RecurringTransaction, bool) {
+	if len(transactions) < recurringMinOccurrences {
+		return RecurringTransaction{}, false
+	}
+
+	// Drop outlying amounts around the median
+	amounts := make([]float64, len(transactions))
+	for i, tx := range transactions {
+		amounts[i] = math.Abs(tx.Amount)
+	}
+	median := medianOf(amounts)
+	var kept []models.Transaction
+	for _, tx := range transactions {
+		if median == 0 || math.Abs(math.Abs(tx.Amount)-median)/median <= recurringAmountTolerance {
+			kept = append(kept, tx)
+		}
+	}
+	if len(kept) < recurringMinOccurrences {
+		return RecurringTransaction{}, false
+	}
+
+	// Several payments on the same day count once
+	var deltas []float64
+	for i := 1; i < len(kept); i++ {
+		days := kept[i].Date.Sub(kept[i-1].Date).Hours() / 24
+		if days >= 1 {
+			deltas = append(deltas, days)
+		}
+	}
+	if len(deltas) < recurringMinOccurrences-1 {
+		return RecurringTransaction{}, false
+	}
+
+	typical := medianOf(deltas)
+	for _, spec := range cadences {
+		if math.Abs(typical-spec.days) > spec.tolerance {
+			continue
+		}
+
+		// A gap spanning several periods is a missed payment, not a break
+		fitting := 0
+		for _, d := range deltas {
+			periods := math.Max(1, math.Round(d/spec.days))
+			if math.Abs(d/periods-spec.days) <= spec.tolerance {
+				fitting++
+			}
+		}
+		confidence := float64(fitting) / float64(len(deltas))
+		if confidence < recurringMinConsistency {
+			return RecurringTransaction{}, false
+		}
+
+		last := kept[len(kept)-1]
+		next := spec.next(last.Date)
+		for missed := 0; next.Before(now); missed++ {
+			if missed >= recurringMaxMissed {
+				return RecurringTransaction{}, false
+			}
+			next = spec.next(next)
+		}
+
+		var total float64
+		ids := make([]string, len(kept))
+		for i, tx := range kept {
+			total += math.Abs(tx.Amount)
+			ids[i] = tx.ID
+		}
+
+		merchant := last.Merchant
+		if merchant == "" {
+			merchant = last.Description
+		}
+
+		return RecurringTransaction{
+			Merchant:       merchant,
+			Category:       last.Category,
+			Type:           last.Type,
+			Currency:       last.Currency,
+			Cadence:        spec.cadence,
+			AverageAmount:  total / float64(len(kept)),
+			LastAmount:     math.Abs(last.Amount),
+			LastDate:       last.Date,
+			NextDate:       next,
+			Occurrences:    len(kept),
+			Confidence:     confidence,
+			TransactionIDs: ids,
+		}, true
+	}
+
+	return RecurringTransaction{}, false
+}
+
+// medianOf returns the median of values
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
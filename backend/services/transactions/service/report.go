@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+// reportTopMovers is how many categories are listed as top increases and decreases
+const reportTopMovers = 3
+
+// CategorySpendChange compares one category's spend across two periods.
+// PercentChange is nil when there was no spend in the previous period.
+type CategorySpendChange struct {
+	Category      string   `json:"category"`
+	Current       float64  `json:"current"`
+	Previous      float64  `json:"previous"`
+	Change        float64  `json:"change"`
+	PercentChange *float64 `json:"percent_change"`
+}
+
+// SpendingReport compares spending in a period with the one before it
+type SpendingReport struct {
+	Period              models.BudgetPeriod   `json:"period"`
+	Currency            string                `json:"currency"`
+	StartDate           time.Time             `json:"start_date"`
+	EndDate             time.Time             `json:"end_date"`
+	PreviousStartDate   time.Time             `json:"previous_start_date"`
+	PreviousEndDate     time.Time             `json:"previous_end_date"`
+	TotalSpent          float64               `json:"total_spent"`
+	PreviousTotalSpent  float64               `json:"previous_total_spent"`
+	PercentChange       *float64              `json:"percent_change"`
+	Income              float64               `json:"income"`
+	PreviousIncome      float64               `json:"previous_income"`
+	SavingsRate         *float64              `json:"savings_rate"`
+	PreviousSavingsRate *float64              `json:"previous_savings_rate"`
+	SavingsRateChange   *float64              `json:"savings_rate_change"`
+	Categories          []CategorySpendChange `json:"categories"`
+	TopIncreases        []CategorySpendChange `json:"top_increases"`
+	TopDecreases        []CategorySpendChange `json:"top_decreases"`
+}
+
+// GetSpendingReport compares per-category spend in the period containing at
+// with the previous period of the same length, and reports the biggest
+// movers and the change in savings rate. Percentages are omitted when the
+// base is zero, as for a user's first period.
+func (s *TransactionService) GetSpendingReport(ctx context.Context, userID string, period models.BudgetPeriod, at time.Time, baseCurrency string) (*SpendingReport, error) {
+	if period == "" {
+		period = models.BudgetPeriodMonthly
+	}
+	if !period.IsValid() {
+		return nil, ErrInvalidBudgetPeriod
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	start, end := period.PeriodBounds(at)
+	prevStart, prevEnd := period.PeriodBounds(start.Add(-time.Nanosecond))
+
+	current, err := s.GetTransactionsSummary(ctx, userID, start, end.Add(-time.Nanosecond), baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+	previous, err := s.GetTransactionsSummary(ctx, userID, prevStart, prevEnd.Add(-time.Nanosecond), baseCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SpendingReport{
+		Period:              period,
+		Currency:            baseCurrency,
+		StartDate:           start,
+		EndDate:             end,
+		PreviousStartDate:   prevStart,
+		PreviousEndDate:     prevEnd,
+		TotalSpent:          current.TotalExpenses,
+		PreviousTotalSpent:  previous.TotalExpenses,
+		PercentChange:       percentChange(current.TotalExpenses, previous.TotalExpenses),
+		Income:              current.TotalIncome,
+		PreviousIncome:      previous.TotalIncome,
+		SavingsRate:         savingsRate(current.TotalIncome, current.TotalExpenses),
+		PreviousSavingsRate: savingsRate(previous.TotalIncome, previous.TotalExpenses),
+	}
+
+	// Savings rate change is in percentage points
+	if report.SavingsRate != nil && report.PreviousSavingsRate != nil {
+		change := *report.SavingsRate - *report.PreviousSavingsRate
+		report.SavingsRateChange = &change
+	}
+
+	categories := make(map[string]struct{})
+	for c := range current.ByCategory {
+		categories[c] = struct{}{}
+	}
+	for c := range previous.ByCategory {
+		categories[c] = struct{}{}
+	}
+
+	report.Categories = make([]CategorySpendChange, 0, len(categories))
+	for c := range categories {
+		cur, prev := current.ByCategory[c], previous.ByCategory[c]
+		report.Categories = append(report.Categories, CategorySpendChange{
+			Category:      c,
+			Current:       cur,
+			Previous:      prev,
+			Change:        cur - prev,
+			PercentChange: percentChange(cur, prev),
+		})
+	}
+	sort.Slice(report.Categories, func(i, j int) bool {
+		if report.Categories[i].Current != report.Categories[j].Current {
+			return report.Categories[i].Current > report.Categories[j].Current
+		}
+		return report.Categories[i].Category < report.Categories[j].Category
+	})
+
+	movers := make([]CategorySpendChange, len(report.Categories))
+	copy(movers, report.Categories)
+	sort.SliceStable(movers, func(i, j int) bool {
+		return math.Abs(movers[i].Change) > math.Abs(movers[j].Change)
+	})
+	report.TopIncreases = make([]CategorySpendChange, 0, reportTopMovers)
+	report.TopDecreases = make([]CategorySpendChange, 0, reportTopMovers)
+	for _, m := range movers {
+		if m.Change > 0 && len(report.TopIncreases) < reportTopMovers {
+			report.TopIncreases = append(report.TopIncreases, m)
+		}
+		if m.Change < 0 && len(report.TopDecreases) < reportTopMovers {
+			report.TopDecreases = append(report.TopDecreases, m)
+		}
+	}
+
+	return report, nil
+}
+
+// percentChange returns the change from previous to current in percent, or
+// nil when previous is zero
+func percentChange(current, previous float64) *float64 {
+	if previous == 0 {
+		return nil
+	}
+	change := (current - previous) / math.Abs(previous) * 100
+	return &change
+}
+
+// savingsRate returns the share of income not spent, in percent, or nil
+// without income
+func savingsRate(income, expenses float64) *float64 {
+	if income <= 0 {
+		return nil
+	}
+	rate := (income - expenses) / income * 100
+	return &rate
+}
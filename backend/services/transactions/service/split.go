@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	"github.com/radmickey/money-control/backend/pkg/events"
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+var (
+	ErrSplitTooFewParts = errors.New("a split needs at least two parts")
+	ErrSplitInvalidPart = errors.New("each split part needs a positive amount and a category")
+	ErrSplitSumMismatch = errors.New("split parts must sum to the transaction amount")
+	ErrSplitTransfer    = errors.New("transfers cannot be split")
+)
+
+// splitSumTolerance absorbs rounding when parts are entered in cents
+const splitSumTolerance = 0.005
+
+// SplitPart is one categorized portion of a transaction
+type SplitPart struct {
+	Amount         float64
+	Category       models.TransactionCategory
+	CustomCategory string
+	Description    string
+}
+
+// SplitTransaction divides a transaction into parts with their own categories.
+// The parts must sum to the transaction amount; passing no parts removes an
+// existing split.
+func (s *TransactionService) SplitTransaction(ctx context.Context, id, userID string, parts []SplitPart) (*models.Transaction, error) {
+	tx, err := s.txRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx.Type == models.TransactionTypeTransfer {
+		return nil, ErrSplitTransfer
+	}
+	if err := validateSplit(tx.Amount, parts); err != nil {
+		return nil, err
+	}
+
+	splits := make([]models.TransactionSplit, len(parts))
+	for i, part := range parts {
+		splits[i] = models.TransactionSplit{
+			Amount:         part.Amount,
+			Category:       part.Category,
+			CustomCategory: part.CustomCategory,
+			Description:    part.Description,
+		}
+	}
+
+	if err := s.txRepo.ReplaceSplits(ctx, tx, splits); err != nil {
+		return nil, err
+	}
+
+	s.publisher.Publish(ctx, tx.UserID, events.TransactionUpdated, transactionData(tx))
+	return tx, nil
+}
+
+// validateSplit checks parts against the parent transaction amount
+func validateSplit(amount float64, parts []SplitPart) error {
+	if len(parts) == 0 {
+		return nil
+	}
+	if len(parts) < 2 {
+		return ErrSplitTooFewParts
+	}
+
+	var sum float64
+	for _, part := range parts {
+		if part.Amount <= 0 || math.IsNaN(part.Amount) || math.IsInf(part.Amount, 0) || part.Category == "" {
+			return ErrSplitInvalidPart
+		}
+		sum += part.Amount
+	}
+
+	if math.Abs(sum-amount) > splitSumTolerance {
+		return ErrSplitSumMismatch
+	}
+	return nil
+}
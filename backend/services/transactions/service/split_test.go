@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+func TestValidateSplit(t *testing.T) {
+	part := func(amount float64) SplitPart {
+		return SplitPart{Amount: amount, Category: models.CategoryFood}
+	}
+
+	tests := []struct {
+		name   string
+		amount float64
+		parts  []SplitPart
+		want   error
+	}{
+		{name: "parts sum to amount", amount: 100, parts: []SplitPart{part(60), part(40)}, want: nil},
+		{name: "cents sum to amount", amount: 10, parts: []SplitPart{part(3.33), part(3.33), part(3.34)}, want: nil},
+		{name: "within rounding tolerance", amount: 10, parts: []SplitPart{part(3.333), part(3.333), part(3.333)}, want: nil},
+		{name: "no parts removes the split", amount: 100, parts: nil, want: nil},
+		{name: "parts short of amount", amount: 100, parts: []SplitPart{part(60), part(39.99)}, want: ErrSplitSumMismatch},
+		{name: "parts over amount", amount: 100, parts: []SplitPart{part(60), part(40.01)}, want: ErrSplitSumMismatch},
+		{name: "single part", amount: 100, parts: []SplitPart{part(100)}, want: ErrSplitTooFewParts},
+		{name: "zero part", amount: 100, parts: []SplitPart{part(100), part(0)}, want: ErrSplitInvalidPart},
+		{name: "negative part", amount: 100, parts: []SplitPart{part(110), part(-10)}, want: ErrSplitInvalidPart},
+		{name: "NaN part", amount: 100, parts: []SplitPart{part(100), part(math.NaN())}, want: ErrSplitInvalidPart},
+		{name: "part without category", amount: 100, parts: []SplitPart{part(60), {Amount: 40}}, want: ErrSplitInvalidPart},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSplit(tt.amount, tt.parts); !errors.Is(err, tt.want) {
+				t.Errorf("validateSplit = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitTransaction(t *testing.T) {
+	s, repo, _ := newOutboxTestService(t)
+	ctx := context.Background()
+
+	tx := &models.Transaction{UserID: testUserID, Amount: 100, Currency: "USD", Type: models.TransactionTypeExpense, Category: models.CategoryShopping, Date: time.Now()}
+	if err := repo.Create(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	storedSplits := func() []models.TransactionSplit {
+		t.Helper()
+		stored, err := repo.GetByID(ctx, tx.ID, testUserID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stored.Splits
+	}
+
+	parts := []SplitPart{
+		{Amount: 70, Category: models.CategoryFood},
+		{Amount: 30, Category: models.CategoryShopping},
+	}
+	if _, err := s.SplitTransaction(ctx, tx.ID, testUserID, parts); err != nil {
+		t.Fatalf("split: %v", err)
+	}
+	if splits := storedSplits(); len(splits) != 2 {
+		t.Fatalf("%d splits stored, want 2", len(splits))
+	}
+
+	// A mismatched split is rejected and leaves the existing one in place
+	mismatched := []SplitPart{
+		{Amount: 70, Category: models.CategoryFood},
+		{Amount: 20, Category: models.CategoryShopping},
+	}
+	if _, err := s.SplitTransaction(ctx, tx.ID, testUserID, mismatched); !errors.Is(err, ErrSplitSumMismatch) {
+		t.Fatalf("mismatched split: err = %v, want ErrSplitSumMismatch", err)
+	}
+	splits := storedSplits()
+	var sum float64
+	for _, split := range splits {
+		sum += split.Amount
+	}
+	if len(splits) != 2 || sum != tx.Amount {
+		t.Fatalf("after a rejected split: %d splits summing to %v, want 2 summing to %v", len(splits), sum, tx.Amount)
+	}
+
+	// No parts removes the split
+	if _, err := s.SplitTransaction(ctx, tx.ID, testUserID, nil); err != nil {
+		t.Fatalf("unsplit: %v", err)
+	}
+	if splits := storedSplits(); len(splits) != 0 {
+		t.Fatalf("%d splits after unsplitting, want 0", len(splits))
+	}
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrSubAccountNotFound = errors.New("sub-account not found")
+	ErrCurrencyMismatch   = errors.New("transaction currency does not match the sub-account currency")
+	ErrInvalidTransfer    = errors.New("a transfer needs distinct source and destination sub-accounts")
+)
+
+// subAccountCurrency checks that a sub-account exists and belongs to the
+// user, and returns its currency. Without an accounts client nothing is
+// checked and the currency is empty.
+func (s *TransactionService) subAccountCurrency(ctx context.Context, userID, subAccountID string) (string, error) {
+	if s.accountsClient == nil {
+		return "", nil
+	}
+
+	sub, err := s.accountsClient.GetSubAccount(ctx, &accountspb.GetSubAccountRequest{
+		Id:     subAccountID,
+		UserId: userID,
+	})
+	if status.Code(err) == codes.NotFound {
+		return "", ErrSubAccountNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to verify sub-account: %w", err)
+	}
+	return sub.Currency, nil
+}
+
+// checkSubAccounts verifies that the transaction's sub-accounts belong to
+// its user and puts it in the sub-account's currency. A transfer into a
+// sub-account in another currency records the amount credited there.
+func (s *TransactionService) checkSubAccounts(ctx context.Context, tx *models.Transaction) error {
+	var toCurrency string
+	if tx.Type == models.TransactionTypeTransfer {
+		if tx.SubAccountID == nil || tx.TransferToSubAccount == nil || *tx.SubAccountID == *tx.TransferToSubAccount {
+			return ErrInvalidTransfer
+		}
+		currency, err := s.subAccountCurrency(ctx, tx.UserID, *tx.TransferToSubAccount)
+		if err != nil {
+			return err
+		}
+		toCurrency = currency
+	}
+	if tx.SubAccountID == nil {
+		return nil
+	}
+
+	currency, err := s.subAccountCurrency(ctx, tx.UserID, *tx.SubAccountID)
+	if err != nil {
+		return err
+	}
+	rates := make(map[string]float64)
+	if err := s.matchCurrency(ctx, tx, currency, rates); err != nil {
+		return err
+	}
+
+	tx.TransferAmount, tx.TransferCurrency, tx.TransferRate = nil, "", nil
+	if toCurrency == "" || toCurrency == tx.Currency || tx.Currency == "" {
+		return nil
+	}
+	if s.currencyClient == nil {
+		return fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, tx.Currency, toCurrency)
+	}
+
+	rate, err := s.rateAsOf(ctx, rates, tx.Currency, toCurrency, tx.Date)
+	if err != nil {
+		return err
+	}
+	amount := tx.Amount * rate
+	tx.TransferAmount = &amount
+	tx.TransferCurrency = toCurrency
+	tx.TransferRate = &rate
+	return nil
+}
+
+// matchCurrency converts a transaction into currency at the rate on its
+// date, keeping the amount as entered in OriginalAmount. A transaction
+// without a currency takes the given one.
+func (s *TransactionService) matchCurrency(ctx context.Context, tx *models.Transaction, currency string, rates map[string]float64) error {
+	if currency == "" || tx.Currency == currency {
+		return nil
+	}
+	if tx.Currency == "" {
+		tx.Currency = currency
+		return nil
+	}
+	if s.currencyClient == nil {
+		return fmt.Errorf("%w: %s vs %s", ErrCurrencyMismatch, tx.Currency, currency)
+	}
+
+	rate, err := s.rateAsOf(ctx, rates, tx.Currency, currency, tx.Date)
+	if err != nil {
+		return err
+	}
+
+	original := tx.Amount
+	tx.OriginalAmount = &original
+	tx.OriginalCurrency = tx.Currency
+	tx.Amount = original * rate
+	tx.Currency = currency
+	return nil
+}
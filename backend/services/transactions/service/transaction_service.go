@@ -2,27 +2,77 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/radmickey/money-control/backend/pkg/database"
+	"github.com/radmickey/money-control/backend/pkg/events"
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	"github.com/radmickey/money-control/backend/services/transactions/models"
 	"github.com/radmickey/money-control/backend/services/transactions/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // TransactionService handles transaction business logic
 type TransactionService struct {
-	txRepo       *repository.TransactionRepository
-	ruleRepo     *repository.CategoryRuleRepository
+	txRepo         *repository.TransactionRepository
+	ruleRepo       *repository.CategoryRuleRepository
+	budgetRepo     *repository.BudgetRepository
+	currencyClient currencypb.CurrencyServiceClient
+	accountsClient accountspb.AccountsServiceClient
+	exportMaxSpan  time.Duration
+	publisher      *events.Publisher
+	stopChan       chan struct{}
+
+	// Compiled regex rules keyed by pattern
+	ruleRegexps sync.Map
+
+	// Tracks the running outbox worker so Stop can wait for it
+	jobs sync.WaitGroup
 }
 
-// NewTransactionService creates a new transaction service
+var (
+	ErrInvalidPattern = errors.New("invalid category rule pattern")
+)
+
+// maxRulePatternLength bounds regex rule size
+const maxRulePatternLength = 255
+
+// NewTransactionService creates a new transaction service.
+// currencyClient may be nil, in which case summaries are not converted.
+// accountsClient may be nil, in which case sub-accounts are not verified
+// and balances are not adjusted.
+// exportMaxSpan bounds the date range of a single export.
+// Changes are announced through publisher.
 func NewTransactionService(
 	txRepo *repository.TransactionRepository,
 	ruleRepo *repository.CategoryRuleRepository,
+	budgetRepo *repository.BudgetRepository,
+	currencyClient currencypb.CurrencyServiceClient,
+	accountsClient accountspb.AccountsServiceClient,
+	exportMaxSpan time.Duration,
+	publisher *events.Publisher,
 ) *TransactionService {
+	if exportMaxSpan <= 0 {
+		exportMaxSpan = defaultExportMaxSpan
+	}
 	return &TransactionService{
-		txRepo:   txRepo,
-		ruleRepo: ruleRepo,
+		txRepo:         txRepo,
+		ruleRepo:       ruleRepo,
+		budgetRepo:     budgetRepo,
+		currencyClient: currencyClient,
+		accountsClient: accountsClient,
+		exportMaxSpan:  exportMaxSpan,
+		publisher:      publisher,
+		stopChan:       make(chan struct{}),
 	}
 }
 
@@ -49,10 +99,6 @@ func (s *TransactionService) CreateTransaction(ctx context.Context, input Create
 		input.Category = s.autoCategorize(ctx, input.UserID, input.Description, input.Merchant)
 	}
 
-	if input.Currency == "" {
-		input.Currency = "USD"
-	}
-
 	if input.Date.IsZero() {
 		input.Date = time.Now()
 	}
@@ -70,12 +116,26 @@ func (s *TransactionService) CreateTransaction(ctx context.Context, input Create
 		Date:                 input.Date,
 		TransferToSubAccount: input.TransferToSubAccount,
 		Metadata:             input.Metadata,
+		// Balances are only adjusted when there is an accounts service to adjust them
+		BalanceApplied: s.accountsClient != nil,
 	}
 
-	if err := s.txRepo.Create(ctx, tx); err != nil {
+	if err := s.checkSubAccounts(ctx, tx); err != nil {
 		return nil, err
 	}
+	if tx.Currency == "" {
+		tx.Currency = "USD"
+	}
 
+	deltas := make(balanceDeltas)
+	deltas.add(tx, 1)
+	if err := s.applyBalances(ctx, tx, deltas, func(repo *repository.TransactionRepository) error {
+		return repo.Create(ctx, tx)
+	}); err != nil {
+		return nil, err
+	}
+
+	s.publisher.Publish(ctx, tx.UserID, events.TransactionCreated, transactionData(tx))
 	return tx, nil
 }
 
@@ -84,26 +144,27 @@ func (s *TransactionService) GetTransaction(ctx context.Context, id, userID stri
 	return s.txRepo.GetByID(ctx, id, userID)
 }
 
-// ListTransactions lists transactions for a user
-func (s *TransactionService) ListTransactions(ctx context.Context, userID, subAccountID string, page, pageSize int, sortBy string, sortDesc bool) ([]models.Transaction, int64, error) {
+// ListTransactions lists transactions for a user, by page or after a cursor
+func (s *TransactionService) ListTransactions(ctx context.Context, userID, subAccountID string, page, pageSize int, sortBy string, sortDesc bool, cursor string) (database.Page[models.Transaction], error) {
 	if page <= 0 {
 		page = 1
 	}
 	if pageSize <= 0 {
 		pageSize = 20
 	}
-	return s.txRepo.List(ctx, userID, subAccountID, page, pageSize, sortBy, sortDesc)
+	return s.txRepo.List(ctx, userID, subAccountID, page, pageSize, sortBy, sortDesc, cursor)
 }
 
-// ListTransactionsByDateRange lists transactions in a date range
-func (s *TransactionService) ListTransactionsByDateRange(ctx context.Context, userID string, startDate, endDate time.Time, subAccountID string, page, pageSize int) ([]models.Transaction, int64, error) {
+// ListTransactionsByDateRange lists transactions in a date range, by page or
+// after a cursor
+func (s *TransactionService) ListTransactionsByDateRange(ctx context.Context, userID string, startDate, endDate time.Time, subAccountID string, page, pageSize int, cursor string) (database.Page[models.Transaction], error) {
 	if page <= 0 {
 		page = 1
 	}
 	if pageSize <= 0 {
 		pageSize = 20
 	}
-	return s.txRepo.ListByDateRange(ctx, userID, startDate, endDate, subAccountID, page, pageSize)
+	return s.txRepo.ListByDateRange(ctx, userID, startDate, endDate, subAccountID, page, pageSize, cursor)
 }
 
 // ListTransactionsByCategory lists transactions by category
@@ -138,6 +199,7 @@ func (s *TransactionService) UpdateTransaction(ctx context.Context, input Update
 		return nil, err
 	}
 
+	previous := *tx
 	if input.Amount != 0 {
 		tx.Amount = input.Amount
 	}
@@ -163,27 +225,152 @@ func (s *TransactionService) UpdateTransaction(ctx context.Context, input Update
 		tx.Currency = input.Currency
 	}
 
-	if err := s.txRepo.Update(ctx, tx); err != nil {
+	// A new amount or currency is converted afresh
+	if tx.Amount != previous.Amount || tx.Currency != previous.Currency {
+		tx.OriginalAmount = nil
+		tx.OriginalCurrency = ""
+	}
+	if tx.Amount != previous.Amount || tx.Currency != previous.Currency || tx.Type != previous.Type {
+		if err := s.checkSubAccounts(ctx, tx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Splits no longer add up once the amount changes, so they are dropped
+	clearSplits := tx.Amount != previous.Amount && len(tx.Splits) > 0
+
+	// The balance effect of the old transaction is swapped for the new one.
+	// Imported transactions never had one, so editing them leaves balances alone.
+	deltas := make(balanceDeltas)
+	if previous.BalanceApplied {
+		deltas.add(&previous, -1)
+		deltas.add(tx, 1)
+	}
+	if err := s.applyBalances(ctx, tx, deltas, func(repo *repository.TransactionRepository) error {
+		if err := repo.Update(ctx, tx); err != nil {
+			return err
+		}
+		if clearSplits {
+			return repo.ReplaceSplits(ctx, tx, nil)
+		}
+		return nil
+	}); err != nil {
 		return nil, err
 	}
 
+	s.publisher.Publish(ctx, tx.UserID, events.TransactionUpdated, transactionData(tx))
 	return tx, nil
 }
 
 // DeleteTransaction deletes a transaction
 func (s *TransactionService) DeleteTransaction(ctx context.Context, id, userID string) error {
-	return s.txRepo.Delete(ctx, id, userID)
+	tx, err := s.txRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return err
+	}
+
+	// Only an effect that was applied is reversed
+	deltas := make(balanceDeltas)
+	if tx.BalanceApplied {
+		deltas.add(tx, -1)
+	}
+	if err := s.applyBalances(ctx, tx, deltas, func(repo *repository.TransactionRepository) error {
+		return repo.Delete(ctx, id, userID)
+	}); err != nil {
+		return err
+	}
+
+	s.publisher.Publish(ctx, userID, events.TransactionDeleted, events.DeletedData{ID: id})
+	return nil
 }
 
-// GetTransactionsSummary gets transaction summary
-func (s *TransactionService) GetTransactionsSummary(ctx context.Context, userID string, startDate, endDate time.Time) (*repository.TransactionSummary, error) {
+// GetTransactionsSummary gets transaction summary. When baseCurrency is set, each
+// transaction is converted at the exchange rate in effect on its date.
+func (s *TransactionService) GetTransactionsSummary(ctx context.Context, userID string, startDate, endDate time.Time, baseCurrency string) (*repository.TransactionSummary, error) {
 	if startDate.IsZero() {
 		startDate = time.Now().AddDate(0, -1, 0) // Default to last month
 	}
 	if endDate.IsZero() {
 		endDate = time.Now()
 	}
-	return s.txRepo.GetSummary(ctx, userID, startDate, endDate)
+
+	if baseCurrency == "" || s.currencyClient == nil {
+		return s.txRepo.GetSummary(ctx, userID, startDate, endDate)
+	}
+
+	transactions, err := s.txRepo.ListAllByDateRange(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &repository.TransactionSummary{
+		Currency:         baseCurrency,
+		TransactionCount: int64(len(transactions)),
+		ByCategory:       make(map[string]float64),
+	}
+
+	// Rates are cached per currency and day, so each pair is looked up once per date
+	rates := make(map[string]float64)
+	for _, tx := range transactions {
+		if tx.Type != models.TransactionTypeIncome && tx.Type != models.TransactionTypeExpense {
+			continue
+		}
+
+		rate, err := s.rateAsOf(ctx, rates, tx.Currency, baseCurrency, tx.Date)
+		if err != nil {
+			return nil, err
+		}
+		amount := tx.Amount * rate
+
+		if tx.Type == models.TransactionTypeIncome {
+			summary.TotalIncome += amount
+		} else {
+			summary.TotalExpenses += amount
+			if len(tx.Splits) == 0 {
+				summary.ByCategory[string(tx.Category)] += amount
+			}
+			for _, split := range tx.Splits {
+				summary.ByCategory[string(split.Category)] += split.Amount * rate
+			}
+		}
+	}
+
+	summary.NetFlow = summary.TotalIncome - summary.TotalExpenses
+	return summary, nil
+}
+
+// rateAsOf returns the from->to rate on date, falling back to the live rate
+// when the currency service has no history for the pair
+func (s *TransactionService) rateAsOf(ctx context.Context, rates map[string]float64, from, to string, date time.Time) (float64, error) {
+	if from == "" || from == to {
+		return 1.0, nil
+	}
+
+	key := from + ":" + date.Format("2006-01-02")
+	if rate, ok := rates[key]; ok {
+		return rate, nil
+	}
+
+	resp, err := s.currencyClient.ConvertAsOf(ctx, &currencypb.ConvertAsOfRequest{
+		Amount:       1,
+		FromCurrency: from,
+		ToCurrency:   to,
+		Date:         timestamppb.New(date),
+	})
+	if status.Code(err) == codes.NotFound {
+		log.Printf("No historical %s/%s rate for %s, using latest rate", from, to, date.Format("2006-01-02"))
+		resp, err = s.currencyClient.ConvertAmount(ctx, &currencypb.ConvertAmountRequest{
+			Amount:       1,
+			FromCurrency: from,
+			ToCurrency:   to,
+		})
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to convert %s to %s: %w", from, to, err)
+	}
+
+	rates[key] = resp.RateUsed
+	return resp.RateUsed, nil
 }
 
 // CategorizeTransaction updates the category of a transaction
@@ -217,64 +404,141 @@ func (s *TransactionService) BulkCategorize(ctx context.Context, userID string,
 // autoCategorize attempts to categorize a transaction based on rules
 // This is a stub implementation - can be extended with ML/AI later
 func (s *TransactionService) autoCategorize(ctx context.Context, userID, description, merchant string) models.TransactionCategory {
-	// Get user rules
-	rules, err := s.ruleRepo.GetByUserID(ctx, userID)
-	if err != nil {
-		return models.CategoryOther
-	}
+	rules, _ := s.ruleRepo.GetByUserID(ctx, userID)
+	return s.categorize(rules, description, merchant)
+}
 
+// categorize matches text against preloaded user rules, then default keywords
+func (s *TransactionService) categorize(rules []models.CategoryRule, description, merchant string) models.TransactionCategory {
 	text := strings.ToLower(description + " " + merchant)
 
-	// Check rules
+	// Check user rules, highest priority first
 	for _, rule := range rules {
-		if strings.Contains(text, strings.ToLower(rule.Pattern)) {
+		if s.ruleMatches(rule, text) {
 			return rule.Category
 		}
 	}
 
-	// Default keyword matching
-	keywordCategories := map[string]models.TransactionCategory{
-		"grocery":     models.CategoryFood,
-		"restaurant":  models.CategoryFood,
-		"uber eats":   models.CategoryFood,
-		"doordash":    models.CategoryFood,
-		"uber":        models.CategoryTransport,
-		"lyft":        models.CategoryTransport,
-		"gas":         models.CategoryTransport,
-		"netflix":     models.CategoryEntertainment,
-		"spotify":     models.CategoryEntertainment,
-		"amazon":      models.CategoryShopping,
-		"walmart":     models.CategoryShopping,
-		"pharmacy":    models.CategoryHealthcare,
-		"doctor":      models.CategoryHealthcare,
-		"hospital":    models.CategoryHealthcare,
-		"electric":    models.CategoryUtilities,
-		"water":       models.CategoryUtilities,
-		"internet":    models.CategoryUtilities,
-		"rent":        models.CategoryHousing,
-		"mortgage":    models.CategoryHousing,
-		"insurance":   models.CategoryInsurance,
-		"salary":      models.CategorySalary,
-		"payroll":     models.CategorySalary,
-		"dividend":    models.CategoryInvestmentIncome,
-		"interest":    models.CategoryInvestmentIncome,
-		"tax":         models.CategoryTaxes,
-	}
-
-	for keyword, category := range keywordCategories {
-		if strings.Contains(text, keyword) {
-			return category
+	// Default keyword matching on whole words (plurals allowed), so "gas"
+	// does not match "Las Vegas"
+	for _, kw := range defaultKeywords {
+		if kw.re.MatchString(text) {
+			return kw.category
 		}
 	}
 
 	return models.CategoryOther
 }
 
-// CreateCategoryRule creates a categorization rule
-func (s *TransactionService) CreateCategoryRule(ctx context.Context, userID, pattern string, category models.TransactionCategory, priority int) (*models.CategoryRule, error) {
+// ruleMatches reports whether a category rule matches the lowercased text
+func (s *TransactionService) ruleMatches(rule models.CategoryRule, text string) bool {
+	if !rule.IsRegex {
+		return strings.Contains(text, strings.ToLower(rule.Pattern))
+	}
+
+	re, err := s.compileRule(rule.Pattern)
+	if err != nil {
+		log.Printf("Skipping invalid category rule %s: %v", rule.ID, err)
+		return false
+	}
+	return re.MatchString(text)
+}
+
+// compileRule compiles a case-insensitive rule pattern, caching the result
+func (s *TransactionService) compileRule(pattern string) (*regexp.Regexp, error) {
+	if re, ok := s.ruleRegexps.Load(pattern); ok {
+		return re.(*regexp.Regexp), nil
+	}
+
+	re, err := compileRulePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	s.ruleRegexps.Store(pattern, re)
+	return re, nil
+}
+
+// compileRulePattern validates and compiles a regex rule. Go's RE2 engine
+// matches in linear time, so only the pattern size needs bounding.
+func compileRulePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > maxRulePatternLength {
+		return nil, fmt.Errorf("%w: longer than %d characters", ErrInvalidPattern, maxRulePatternLength)
+	}
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPattern, err)
+	}
+	return re, nil
+}
+
+// keywordCategory maps a built-in keyword to its category
+type keywordCategory struct {
+	re       *regexp.Regexp
+	category models.TransactionCategory
+}
+
+// defaultKeywords are checked in order after the user's rules
+var defaultKeywords = buildKeywords([]struct {
+	keyword  string
+	category models.TransactionCategory
+}{
+	{"grocery", models.CategoryFood},
+	{"groceries", models.CategoryFood},
+	{"restaurant", models.CategoryFood},
+	{"uber eats", models.CategoryFood},
+	{"doordash", models.CategoryFood},
+	{"uber", models.CategoryTransport},
+	{"lyft", models.CategoryTransport},
+	{"gas", models.CategoryTransport},
+	{"netflix", models.CategoryEntertainment},
+	{"spotify", models.CategoryEntertainment},
+	{"amazon", models.CategoryShopping},
+	{"walmart", models.CategoryShopping},
+	{"pharmacy", models.CategoryHealthcare},
+	{"doctor", models.CategoryHealthcare},
+	{"hospital", models.CategoryHealthcare},
+	{"electric", models.CategoryUtilities},
+	{"water", models.CategoryUtilities},
+	{"internet", models.CategoryUtilities},
+	{"rent", models.CategoryHousing},
+	{"mortgage", models.CategoryHousing},
+	{"insurance", models.CategoryInsurance},
+	{"salary", models.CategorySalary},
+	{"payroll", models.CategorySalary},
+	{"dividend", models.CategoryInvestmentIncome},
+	{"interest", models.CategoryInvestmentIncome},
+	{"tax", models.CategoryTaxes},
+})
+
+func buildKeywords(entries []struct {
+	keyword  string
+	category models.TransactionCategory
+}) []keywordCategory {
+	keywords := make([]keywordCategory, len(entries))
+	for i, e := range entries {
+		keywords[i] = keywordCategory{
+			re:       regexp.MustCompile(`\b` + regexp.QuoteMeta(e.keyword) + `(s|es)?\b`),
+			category: e.category,
+		}
+	}
+	return keywords
+}
+
+// CreateCategoryRule creates a categorization rule. Regex patterns are
+// validated up front so broken rules are rejected rather than skipped.
+func (s *TransactionService) CreateCategoryRule(ctx context.Context, userID, pattern string, isRegex bool, category models.TransactionCategory, priority int) (*models.CategoryRule, error) {
+	if isRegex {
+		re, err := compileRulePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		s.ruleRegexps.Store(pattern, re)
+	}
+
 	rule := &models.CategoryRule{
 		UserID:   userID,
 		Pattern:  pattern,
+		IsRegex:  isRegex,
 		Category: category,
 		Priority: priority,
 	}
@@ -296,3 +560,18 @@ func (s *TransactionService) DeleteCategoryRule(ctx context.Context, id, userID
 	return s.ruleRepo.Delete(ctx, id, userID)
 }
 
+// transactionData builds the event payload for a transaction
+func transactionData(tx *models.Transaction) events.TransactionData {
+	data := events.TransactionData{
+		ID:       tx.ID,
+		Amount:   tx.Amount,
+		Currency: tx.Currency,
+		Type:     string(tx.Type),
+		Category: string(tx.Category),
+		Date:     tx.Date,
+	}
+	if tx.SubAccountID != nil {
+		data.SubAccountID = *tx.SubAccountID
+	}
+	return data
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/radmickey/money-control/backend/services/transactions/models"
+)
+
+func TestCategorizeRuleBoundaries(t *testing.T) {
+	regex := func(pattern string, category models.TransactionCategory) models.CategoryRule {
+		return models.CategoryRule{Pattern: pattern, IsRegex: true, Category: category}
+	}
+	substring := func(pattern string, category models.TransactionCategory) models.CategoryRule {
+		return models.CategoryRule{Pattern: pattern, Category: category}
+	}
+
+	tests := []struct {
+		name        string
+		rules       []models.CategoryRule
+		description string
+		merchant    string
+		want        models.TransactionCategory
+	}{
+		{
+			name:        "start anchor matches the description start",
+			rules:       []models.CategoryRule{regex(`^coffee\b`, models.CategoryFood)},
+			description: "Coffee beans",
+			want:        models.CategoryFood,
+		},
+		{
+			name:        "start anchor rejects a later match",
+			rules:       []models.CategoryRule{regex(`^coffee\b`, models.CategoryFood)},
+			description: "Iced coffee",
+			want:        models.CategoryOther,
+		},
+		{
+			name:        "end anchor matches the merchant end",
+			rules:       []models.CategoryRule{regex(`\bshell$`, models.CategoryTransport)},
+			description: "Fuel",
+			merchant:    "Royal Dutch Shell",
+			want:        models.CategoryTransport,
+		},
+		{
+			name:        "regex is case-insensitive",
+			rules:       []models.CategoryRule{regex(`gym|fitness`, models.CategoryHealthcare)},
+			description: "FITNESS club",
+			want:        models.CategoryHealthcare,
+		},
+		{
+			name:        "word boundary rejects a substring",
+			rules:       []models.CategoryRule{regex(`\bcat\b`, models.CategoryGifts)},
+			description: "Education fees",
+			want:        models.CategoryOther,
+		},
+		{
+			name:        "escaped special characters match literally",
+			rules:       []models.CategoryRule{regex(`c\+\+ books`, models.CategoryEducation)},
+			description: "C++ Books Ltd",
+			want:        models.CategoryEducation,
+		},
+		{
+			name:        "unescaped dot matches any character",
+			rules:       []models.CategoryRule{regex(`a.b`, models.CategoryShopping)},
+			description: "axb",
+			want:        models.CategoryShopping,
+		},
+		{
+			name:        "substring rule treats special characters literally",
+			rules:       []models.CategoryRule{substring(`a.b`, models.CategoryShopping)},
+			description: "axb",
+			want:        models.CategoryOther,
+		},
+		{
+			name:        "substring rule is case-insensitive",
+			rules:       []models.CategoryRule{substring(`A.B Store`, models.CategoryShopping)},
+			description: "a.b store #12",
+			want:        models.CategoryShopping,
+		},
+		{
+			name: "earlier rule wins",
+			rules: []models.CategoryRule{
+				regex(`^uber`, models.CategoryTravel),
+				substring("uber", models.CategoryTransport),
+			},
+			description: "Uber trip",
+			want:        models.CategoryTravel,
+		},
+		{
+			name:        "invalid regex is skipped",
+			rules:       []models.CategoryRule{regex(`(`, models.CategoryGifts), substring("gift", models.CategoryGifts)},
+			description: "Gift card",
+			want:        models.CategoryGifts,
+		},
+		{
+			name:        "rules beat default keywords",
+			rules:       []models.CategoryRule{substring("netflix", models.CategoryEducation)},
+			description: "Netflix",
+			want:        models.CategoryEducation,
+		},
+		{
+			name:        "default keyword on a word boundary",
+			description: "Gas station",
+			want:        models.CategoryTransport,
+		},
+		{
+			name:        "default keyword inside a word",
+			description: "Las Vegas",
+			want:        models.CategoryOther,
+		},
+		{
+			name:        "default keyword plural",
+			description: "Monthly taxes",
+			want:        models.CategoryTaxes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &TransactionService{}
+			if got := s.categorize(tt.rules, tt.description, tt.merchant); got != tt.want {
+				t.Errorf("categorize(%q, %q) = %s, want %s", tt.description, tt.merchant, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateCategoryRuleRejectsInvalidRegex(t *testing.T) {
+	tests := map[string]string{
+		"unclosed group":   `(groceries`,
+		"unclosed class":   `[a-`,
+		"bad repetition":   `*coffee`,
+		"too long pattern": strings.Repeat("a", maxRulePatternLength+1),
+	}
+
+	for name, pattern := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Rejected before the rule reaches the repository
+			s := &TransactionService{}
+			_, err := s.CreateCategoryRule(context.Background(), testUserID, pattern, true, models.CategoryFood, 0)
+			if !errors.Is(err, ErrInvalidPattern) {
+				t.Errorf("err = %v, want ErrInvalidPattern", err)
+			}
+		})
+	}
+}
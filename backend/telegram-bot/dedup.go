@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// updateDeduplicator remembers recently processed update IDs so that
+// webhook retries from Telegram are not handled twice. Update IDs are
+// tracked as a set rather than a high-water mark, so out-of-order
+// deliveries are still processed.
+type updateDeduplicator struct {
+	window time.Duration
+
+	mu   sync.Mutex
+	seen map[int64]time.Time // updateID -> expiry
+}
+
+func newUpdateDeduplicator(window time.Duration) *updateDeduplicator {
+	return &updateDeduplicator{
+		window: window,
+		seen:   make(map[int64]time.Time),
+	}
+}
+
+// markSeen records an update ID and reports whether it was new
+func (d *updateDeduplicator) markSeen(updateID int64) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for id, expiresAt := range d.seen {
+		if now.After(expiresAt) {
+			delete(d.seen, id)
+		}
+	}
+
+	if _, ok := d.seen[updateID]; ok {
+		return false
+	}
+	d.seen[updateID] = now.Add(d.window)
+	return true
+}
+
+// isNewUpdate reports whether the update has not been processed within the dedup window.
+// Redis is used when available so that the check holds across bot replicas.
+func (b *Bot) isNewUpdate(ctx context.Context, updateID int64) bool {
+	if b.dedup.window <= 0 {
+		return true
+	}
+
+	if b.cache != nil {
+		ok, err := b.cache.SetNX(ctx, fmt.Sprintf("update:%d", updateID), true, b.dedup.window)
+		if err == nil {
+			return ok
+		}
+		log.Printf("Failed to check update %d in Redis: %v", updateID, err)
+	}
+
+	return b.dedup.markSeen(updateID)
+}
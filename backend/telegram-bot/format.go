@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+)
+
+// Replies are sent in Telegram's HTML parse mode. Unlike legacy Markdown,
+// any text can be escaped in it, so names users chose can't break a message.
+const parseModeHTML = "HTML"
+
+// linkFirstMessage asks an unlinked chat to link an account
+const linkFirstMessage = "Please link your account first using /link &lt;email&gt;"
+
+// escapeHTML escapes characters that have meaning in Telegram's HTML mode
+func escapeHTML(s string) string {
+	return html.EscapeString(s)
+}
+
+// formatAccounts lists accounts with their balances. converted holds balances
+// in currency keyed by account ID; accounts missing from it show their own
+// currency only.
+func formatAccounts(accounts []*accountspb.Account, converted map[string]float64, currency string) string {
+	var sb strings.Builder
+	sb.WriteString("💼 <b>Your Accounts</b>\n\n")
+
+	for _, acc := range accounts {
+		icon := getAccountIcon(acc.Type.String())
+		sb.WriteString(fmt.Sprintf("%s <b>%s</b>\n", icon, escapeHTML(acc.Name)))
+		if value, ok := converted[acc.Id]; ok && acc.Currency != currency {
+			sb.WriteString(fmt.Sprintf("   Balance: %.2f %s (%.2f %s)\n\n", value, currency, acc.TotalBalance, acc.Currency))
+		} else {
+			sb.WriteString(fmt.Sprintf("   Balance: %.2f %s\n\n", acc.TotalBalance, acc.Currency))
+		}
+	}
+
+	return sb.String()
+}
+
+// formatTransactions lists transactions, newest first as given. converted
+// holds amounts in currency keyed by transaction ID.
+func formatTransactions(transactions []*transactionspb.Transaction, converted map[string]float64, currency string) string {
+	var sb strings.Builder
+	sb.WriteString("🧾 <b>Recent Transactions</b>\n\n")
+
+	for _, tx := range transactions {
+		amount, amountCurrency := tx.Amount, tx.Currency
+		if value, ok := converted[tx.Id]; ok {
+			amount, amountCurrency = value, currency
+		}
+		if tx.Type == transactionspb.TransactionType_TRANSACTION_TYPE_EXPENSE {
+			amount = -amount
+		}
+
+		merchant := tx.Merchant
+		if merchant == "" {
+			merchant = tx.Description
+		}
+
+		details := ""
+		if tx.Date != nil {
+			details = tx.Date.AsTime().Format("Jan 02")
+		}
+		if tx.CustomCategory != "" {
+			if details != "" {
+				details += " · "
+			}
+			details += escapeHTML(tx.CustomCategory)
+		}
+
+		icon := getCategoryIcon(tx.Category.String())
+		sb.WriteString(fmt.Sprintf("%s <b>%.2f %s</b> %s\n", icon, amount, amountCurrency, escapeHTML(merchant)))
+		sb.WriteString(fmt.Sprintf("   %s\n\n", details))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// telegramMarkup matches the tags and entities the bot's messages may use
+var telegramMarkup = regexp.MustCompile(`^(<b>|</b>|&lt;|&gt;|&amp;|&#34;|&#39;|&quot;)`)
+
+// checkTelegramHTML fails the test unless every < and & in s starts markup
+// Telegram's HTML mode accepts and the bold tags are balanced
+func checkTelegramHTML(t *testing.T, s string) {
+	t.Helper()
+	open := 0
+	for i := 0; i < len(s); i++ {
+		if s[i] != '<' && s[i] != '&' {
+			continue
+		}
+		markup := telegramMarkup.FindString(s[i:])
+		if markup == "" {
+			t.Fatalf("unescaped %q at %d in %q", s[i], i, s)
+		}
+		switch markup {
+		case "<b>":
+			open++
+		case "</b>":
+			open--
+		}
+		if open < 0 || open > 1 {
+			t.Fatalf("unbalanced <b> at %d in %q", i, s)
+		}
+		i += len(markup) - 1
+	}
+	if open != 0 {
+		t.Fatalf("unclosed <b> in %q", s)
+	}
+}
+
+const specialName = `Tom & Jerry's <b>*_[savings]_*</b> "fund"`
+
+func TestFormatAccountsEscapesNames(t *testing.T) {
+	accounts := []*accountspb.Account{
+		{Id: "1", Name: specialName, Type: accountspb.AccountType_ACCOUNT_TYPE_BANK, Currency: "USD", TotalBalance: 10},
+		{Id: "2", Name: "a_b*c`d", Type: accountspb.AccountType_ACCOUNT_TYPE_CASH, Currency: "EUR", TotalBalance: 5},
+	}
+	text := formatAccounts(accounts, map[string]float64{"1": 10, "2": 5.5}, "USD")
+
+	checkTelegramHTML(t, text)
+	for _, want := range []string{
+		"<b>Tom &amp; Jerry&#39;s &lt;b&gt;*_[savings]_*&lt;/b&gt; &#34;fund&#34;</b>",
+		"<b>a_b*c`d</b>",
+		"Balance: 5.50 USD (5.00 EUR)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in %q", want, text)
+		}
+	}
+}
+
+func TestFormatTransactionsEscapesNames(t *testing.T) {
+	date := timestamppb.New(time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC))
+	transactions := []*transactionspb.Transaction{
+		{
+			Id: "1", Amount: 12.5, Currency: "USD", Date: date,
+			Type:           transactionspb.TransactionType_TRANSACTION_TYPE_EXPENSE,
+			Category:       transactionspb.TransactionCategory_TRANSACTION_CATEGORY_FOOD,
+			Merchant:       specialName,
+			CustomCategory: "Snacks & <treats>_*",
+		},
+		{
+			Id: "2", Amount: 100, Currency: "USD",
+			Type:        transactionspb.TransactionType_TRANSACTION_TYPE_INCOME,
+			Description: "Refund [order #1]",
+		},
+	}
+	text := formatTransactions(transactions, nil, "USD")
+
+	checkTelegramHTML(t, text)
+	for _, want := range []string{
+		"<b>-12.50 USD</b> Tom &amp; Jerry&#39;s &lt;b&gt;",
+		"Mar 05 · Snacks &amp; &lt;treats&gt;_*",
+		"<b>100.00 USD</b> Refund [order #1]",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in %q", want, text)
+		}
+	}
+}
+
+func TestStaticMessagesAreValidHTML(t *testing.T) {
+	b := &Bot{}
+	checkTelegramHTML(t, b.handleHelp())
+	checkTelegramHTML(t, b.handleStart(&Message{From: &User{FirstName: "<Ann & Bob>"}}))
+	checkTelegramHTML(t, linkFirstMessage)
+}
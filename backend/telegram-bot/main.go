@@ -1,39 +1,94 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
 	"syscall"
+	"time"
 
+	"github.com/radmickey/money-control/backend/pkg/cache"
 	"github.com/radmickey/money-control/backend/pkg/config"
+	"github.com/radmickey/money-control/backend/pkg/converters"
+	"github.com/radmickey/money-control/backend/pkg/metrics"
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"github.com/radmickey/money-control/backend/pkg/resilience"
+	"github.com/radmickey/money-control/backend/pkg/utils"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
 
 	accountspb "github.com/radmickey/money-control/backend/proto/accounts"
+	assetspb "github.com/radmickey/money-control/backend/proto/assets"
 	authpb "github.com/radmickey/money-control/backend/proto/auth"
+	currencypb "github.com/radmickey/money-control/backend/proto/currency"
 	insightspb "github.com/radmickey/money-control/backend/proto/insights"
+	transactionspb "github.com/radmickey/money-control/backend/proto/transactions"
+)
+
+const (
+	telegramBreakerName = "telegram-api"
+	telegramAPITimeout  = 10 * time.Second
 )
 
 // Bot represents the Telegram bot
 type Bot struct {
-	token       string
-	webhookURL  string
-	authClient  authpb.AuthServiceClient
-	accClient   accountspb.AccountsServiceClient
-	insClient   insightspb.InsightsServiceClient
-	userSessions map[int64]string // chatID -> userID mapping
+	token         string
+	webhookURL    string
+	webhookSecret string
+	miniAppURL    string
+	authClient    authpb.AuthServiceClient
+	accClient     accountspb.AccountsServiceClient
+	assetsClient  assetspb.AssetsServiceClient
+	insClient     insightspb.InsightsServiceClient
+	txClient      transactionspb.TransactionsServiceClient
+	curClient     currencypb.CurrencyServiceClient
+	httpClient    *http.Client
+	cache         *cache.Cache
+	dedup         *updateDeduplicator
+	limiter       *chatRateLimiter
+
+	sessionsMu   sync.RWMutex
+	userSessions map[int64]Session // chatID -> session fallback when Redis is unavailable
 }
 
 // Update represents a Telegram update
 type Update struct {
-	UpdateID int64    `json:"update_id"`
-	Message  *Message `json:"message,omitempty"`
+	UpdateID      int64          `json:"update_id"`
+	Message       *Message       `json:"message,omitempty"`
+	CallbackQuery *CallbackQuery `json:"callback_query,omitempty"`
+}
+
+// CallbackQuery represents a Telegram callback query from an inline keyboard button
+type CallbackQuery struct {
+	ID      string   `json:"id"`
+	From    *User    `json:"from"`
+	Message *Message `json:"message,omitempty"`
+	Data    string   `json:"data,omitempty"`
+}
+
+// InlineKeyboardMarkup represents an inline keyboard attached to a message
+type InlineKeyboardMarkup struct {
+	InlineKeyboard [][]InlineKeyboardButton `json:"inline_keyboard"`
+}
+
+// InlineKeyboardButton represents a single inline keyboard button
+type InlineKeyboardButton struct {
+	Text         string `json:"text"`
+	CallbackData string `json:"callback_data,omitempty"`
+	URL          string `json:"url,omitempty"`
 }
 
 // Message represents a Telegram message
@@ -59,15 +114,45 @@ type Chat struct {
 }
 
 func main() {
-	cfg, err := config.Load()
+	cfg, err := config.LoadForService("TELEGRAM")
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		log.Fatal(err)
+	}
 
 	bot := &Bot{
-		token:        cfg.TelegramBotToken,
-		webhookURL:   cfg.TelegramWebhook,
-		userSessions: make(map[int64]string),
+		token:         cfg.TelegramBotToken,
+		webhookURL:    cfg.TelegramWebhook,
+		webhookSecret: cfg.TelegramWebhookSecret,
+		miniAppURL:    cfg.MiniAppURL,
+		httpClient:    &http.Client{Timeout: telegramAPITimeout},
+		userSessions:  make(map[int64]Session),
+		dedup:         newUpdateDeduplicator(cfg.TelegramDedupWindow),
+		limiter: newChatRateLimiter(middleware.RateLimitConfig{
+			Requests: cfg.TelegramRateLimit,
+			Window:   cfg.TelegramRateWindow,
+		}),
+	}
+
+	if bot.webhookSecret == "" {
+		log.Println("Warning: TELEGRAM_WEBHOOK_SECRET is not set, webhook requests will not be authenticated")
+	}
+
+	// Connect to Redis for persistent sessions (optional, falls back to memory)
+	if cfg.RedisURL != "" {
+		redisCache, err := cache.New(cache.Config{
+			URL:    cfg.RedisURL,
+			Prefix: "telegram",
+		})
+		if err != nil {
+			log.Printf("Warning: Failed to connect to Redis (sessions will not persist): %v", err)
+		} else {
+			defer redisCache.Close()
+			bot.cache = redisCache
+			bot.limiter.redis = middleware.NewRateLimiter(redisCache.Client(), "telegram")
+		}
 	}
 
 	// Connect to services
@@ -79,9 +164,18 @@ func main() {
 	if accConn, err := grpc.Dial(cfg.AccountsServiceURL, opts...); err == nil {
 		bot.accClient = accountspb.NewAccountsServiceClient(accConn)
 	}
+	if assetsConn, err := grpc.Dial(cfg.AssetsServiceURL, opts...); err == nil {
+		bot.assetsClient = assetspb.NewAssetsServiceClient(assetsConn)
+	}
 	if insConn, err := grpc.Dial(cfg.InsightsServiceURL, opts...); err == nil {
 		bot.insClient = insightspb.NewInsightsServiceClient(insConn)
 	}
+	if txConn, err := grpc.Dial(cfg.TransactionsServiceURL, opts...); err == nil {
+		bot.txClient = transactionspb.NewTransactionsServiceClient(txConn)
+	}
+	if curConn, err := grpc.Dial(cfg.CurrencyServiceURL, opts...); err == nil {
+		bot.curClient = currencypb.NewCurrencyServiceClient(curConn)
+	}
 
 	// Start webhook server
 	http.HandleFunc("/webhook", bot.handleWebhook)
@@ -89,6 +183,11 @@ func main() {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{"status":"ok","service":"telegram-bot"}`))
 	})
+	http.HandleFunc("/health/circuits", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resilience.GlobalManager.AllStats())
+	})
+	http.Handle("/metrics", metrics.Handler())
 
 	go func() {
 		port := os.Getenv("PORT")
@@ -115,6 +214,11 @@ func (b *Bot) handleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !b.validWebhookSecret(r) {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
 	var update Update
 	if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
 		log.Printf("Failed to decode update: %v", err)
@@ -122,13 +226,33 @@ func (b *Bot) handleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if update.Message != nil {
+	if !b.isNewUpdate(r.Context(), update.UpdateID) {
+		log.Printf("Skipping duplicate update %d", update.UpdateID)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	switch {
+	case update.Message != nil:
 		b.handleMessage(update.Message)
+	case update.CallbackQuery != nil:
+		b.handleCallbackQuery(update.CallbackQuery)
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
 
+// validWebhookSecret checks the X-Telegram-Bot-Api-Secret-Token header.
+// When no secret is configured every request is accepted (local development).
+func (b *Bot) validWebhookSecret(r *http.Request) bool {
+	if b.webhookSecret == "" {
+		return true
+	}
+
+	token := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+	return subtle.ConstantTimeCompare([]byte(token), []byte(b.webhookSecret)) == 1
+}
+
 func (b *Bot) handleMessage(msg *Message) {
 	if msg.Text == "" {
 		return
@@ -139,10 +263,19 @@ func (b *Bot) handleMessage(msg *Message) {
 		return
 	}
 
+	allowed, notify := b.allowCommand(context.Background(), msg.Chat.ID)
+	if !allowed {
+		if notify {
+			b.sendMessage(msg.Chat.ID, slowDownMessage, "")
+		}
+		return
+	}
+
 	command := strings.ToLower(parts[0])
 	args := parts[1:]
 
 	var response string
+	var keyboard *InlineKeyboardMarkup
 
 	switch command {
 	case "/start":
@@ -151,8 +284,16 @@ func (b *Bot) handleMessage(msg *Message) {
 		response = b.handleHelp()
 	case "/networth":
 		response = b.handleNetWorth(msg)
+		keyboard = netWorthKeyboard()
 	case "/accounts":
 		response = b.handleAccounts(msg)
+		keyboard = accountsKeyboard()
+	case "/transactions":
+		response = b.handleTransactions(msg)
+	case "/currency":
+		response = b.handleCurrency(msg, args)
+	case "/dashboard":
+		response, keyboard = b.handleDashboard(msg)
 	case "/addasset":
 		response = b.handleAddAsset(msg, args)
 	case "/link":
@@ -161,7 +302,59 @@ func (b *Bot) handleMessage(msg *Message) {
 		response = "Unknown command. Use /help to see available commands."
 	}
 
-	b.sendMessage(msg.Chat.ID, response)
+	b.sendMessageWithKeyboard(msg.Chat.ID, response, parseModeHTML, keyboard)
+}
+
+// Callback data values used by inline keyboard buttons
+const (
+	callbackNetWorth = "networth"
+	callbackAccounts = "accounts"
+)
+
+func (b *Bot) handleCallbackQuery(query *CallbackQuery) {
+	// Always answer so the client stops showing the loading spinner
+	defer b.answerCallbackQuery(query.ID, "")
+
+	if query.Message == nil || query.Message.Chat == nil || query.From == nil {
+		return
+	}
+
+	msg := &Message{
+		MessageID: query.Message.MessageID,
+		From:      query.From,
+		Chat:      query.Message.Chat,
+	}
+
+	switch query.Data {
+	case callbackNetWorth:
+		b.sendMessageWithKeyboard(msg.Chat.ID, b.handleNetWorth(msg), parseModeHTML, netWorthKeyboard())
+	case callbackAccounts:
+		b.sendMessageWithKeyboard(msg.Chat.ID, b.handleAccounts(msg), parseModeHTML, accountsKeyboard())
+	default:
+		log.Printf("Unknown callback data: %s", query.Data)
+	}
+}
+
+func netWorthKeyboard() *InlineKeyboardMarkup {
+	return &InlineKeyboardMarkup{
+		InlineKeyboard: [][]InlineKeyboardButton{
+			{
+				{Text: "🔄 Refresh", CallbackData: callbackNetWorth},
+				{Text: "💼 Accounts", CallbackData: callbackAccounts},
+			},
+		},
+	}
+}
+
+func accountsKeyboard() *InlineKeyboardMarkup {
+	return &InlineKeyboardMarkup{
+		InlineKeyboard: [][]InlineKeyboardButton{
+			{
+				{Text: "🔄 Refresh", CallbackData: callbackAccounts},
+				{Text: "💰 Net Worth", CallbackData: callbackNetWorth},
+			},
+		},
+	}
 }
 
 func (b *Bot) handleStart(msg *Message) string {
@@ -172,30 +365,31 @@ I'm your personal finance assistant. Here's what I can do:
 
 📊 /networth - View your total net worth
 💼 /accounts - List your accounts
-➕ /addasset <type> <symbol> <qty> - Add an asset
-🔗 /link <email> - Link your account
+➕ /addasset &lt;type&gt; &lt;symbol&gt; &lt;qty&gt; - Add an asset
+🔗 /link &lt;email&gt; - Link your account
 
-Use /help for more information.`, name)
+Use /help for more information.`, escapeHTML(name))
 }
 
 func (b *Bot) handleHelp() string {
-	return `📱 *Money Control Bot Commands*
+	return `📱 <b>Money Control Bot Commands</b>
 
-*Account Management:*
+<b>Account Management:</b>
 /networth - View total net worth
 /accounts - List all accounts
 /transactions - Recent transactions
 
-*Asset Management:*
-/addasset <type> <symbol> <qty> - Add asset
+<b>Asset Management:</b>
+/addasset &lt;type&gt; &lt;symbol&gt; &lt;qty&gt; - Add asset
   Types: stock, crypto, etf
   Example: /addasset crypto BTC 0.5
 
-*Settings:*
-/link <email> - Link your account
-/currency <code> - Set base currency
+<b>Settings:</b>
+/link &lt;email&gt; - Link your account
+/link &lt;code&gt; - Confirm link with emailed code
+/currency &lt;code&gt; - Set base currency
 
-*Quick Actions:*
+<b>Quick Actions:</b>
 /dashboard - Open Mini App
 /refresh - Refresh prices
 
@@ -203,55 +397,57 @@ For the full experience, open our Mini App! 📲`
 }
 
 func (b *Bot) handleNetWorth(msg *Message) string {
-	userID, ok := b.userSessions[msg.Chat.ID]
+	ctx := context.Background()
+	session, ok := b.loadSession(ctx, msg.Chat.ID)
 	if !ok {
-		return "Please link your account first using /link <email>"
+		return linkFirstMessage
 	}
 
 	if b.insClient == nil {
 		return "Service temporarily unavailable"
 	}
 
-	ctx := context.Background()
 	resp, err := b.insClient.GetNetWorth(ctx, &insightspb.GetNetWorthRequest{
-		UserId:       userID,
-		BaseCurrency: "USD",
+		UserId:       session.UserID,
+		BaseCurrency: session.BaseCurrency(),
 	})
 	if err != nil {
 		return "Failed to fetch net worth. Please try again."
 	}
 
+	currency := session.BaseCurrency()
+
 	change := "📈"
 	if resp.Change_24H < 0 {
 		change = "📉"
 	}
 
-	return fmt.Sprintf(`💰 *Your Net Worth*
+	return fmt.Sprintf(`💰 <b>Your Net Worth</b>
 
-Total: $%.2f %s
+Total: %.2f %s %s
 
-24h Change: $%.2f (%.2f%%)
-7d Change: $%.2f (%.2f%%)
-30d Change: $%.2f (%.2f%%)`,
-		resp.TotalNetWorth, change,
-		resp.Change_24H, resp.ChangePercent_24H,
-		resp.Change_7D, resp.ChangePercent_7D,
-		resp.Change_30D, resp.ChangePercent_30D)
+24h Change: %.2f %s (%.2f%%)
+7d Change: %.2f %s (%.2f%%)
+30d Change: %.2f %s (%.2f%%)`,
+		resp.TotalNetWorth, currency, change,
+		resp.Change_24H, currency, resp.ChangePercent_24H,
+		resp.Change_7D, currency, resp.ChangePercent_7D,
+		resp.Change_30D, currency, resp.ChangePercent_30D)
 }
 
 func (b *Bot) handleAccounts(msg *Message) string {
-	userID, ok := b.userSessions[msg.Chat.ID]
+	ctx := context.Background()
+	session, ok := b.loadSession(ctx, msg.Chat.ID)
 	if !ok {
-		return "Please link your account first using /link <email>"
+		return linkFirstMessage
 	}
 
 	if b.accClient == nil {
 		return "Service temporarily unavailable"
 	}
 
-	ctx := context.Background()
 	resp, err := b.accClient.ListAccounts(ctx, &accountspb.ListAccountsRequest{
-		UserId:   userID,
+		UserId:   session.UserID,
 		Page:     1,
 		PageSize: 10,
 	})
@@ -263,61 +459,364 @@ func (b *Bot) handleAccounts(msg *Message) string {
 		return "You don't have any accounts yet. Create one in the app!"
 	}
 
-	var sb strings.Builder
-	sb.WriteString("💼 *Your Accounts*\n\n")
+	currency := session.BaseCurrency()
+	amounts := make([]*currencypb.AmountToConvert, len(resp.Accounts))
+	for i, acc := range resp.Accounts {
+		amounts[i] = &currencypb.AmountToConvert{Id: acc.Id, Amount: acc.TotalBalance, FromCurrency: acc.Currency}
+	}
+	return formatAccounts(resp.Accounts, b.convertAmounts(ctx, amounts, currency), currency)
+}
+
+func (b *Bot) handleTransactions(msg *Message) string {
+	ctx := context.Background()
+	session, ok := b.loadSession(ctx, msg.Chat.ID)
+	if !ok {
+		return linkFirstMessage
+	}
 
-	for _, acc := range resp.Accounts {
-		icon := getAccountIcon(acc.Type.String())
-		sb.WriteString(fmt.Sprintf("%s *%s*\n", icon, acc.Name))
-		sb.WriteString(fmt.Sprintf("   Balance: $%.2f %s\n\n", acc.TotalBalance, acc.Currency))
+	if b.txClient == nil {
+		return "Service temporarily unavailable"
 	}
 
-	return sb.String()
+	resp, err := b.txClient.ListTransactions(ctx, &transactionspb.ListTransactionsRequest{
+		UserId:   session.UserID,
+		Page:     1,
+		PageSize: 10,
+		SortBy:   "date",
+		SortDesc: true,
+	})
+	if err != nil {
+		return "Failed to fetch transactions. Please try again."
+	}
+
+	if len(resp.Transactions) == 0 {
+		return "You don't have any transactions yet. Add one in the app!"
+	}
+
+	currency := session.BaseCurrency()
+	amounts := make([]*currencypb.AmountToConvert, len(resp.Transactions))
+	for i, tx := range resp.Transactions {
+		amounts[i] = &currencypb.AmountToConvert{Id: tx.Id, Amount: tx.Amount, FromCurrency: tx.Currency}
+	}
+	return formatTransactions(resp.Transactions, b.convertAmounts(ctx, amounts, currency), currency)
+}
+
+// convertAmounts converts amounts to the target currency keyed by their IDs.
+// Amounts that could not be converted are omitted from the result.
+func (b *Bot) convertAmounts(ctx context.Context, amounts []*currencypb.AmountToConvert, currency string) map[string]float64 {
+	result := make(map[string]float64)
+
+	var pending []*currencypb.AmountToConvert
+	for _, a := range amounts {
+		if a.FromCurrency == currency {
+			result[a.Id] = a.Amount
+			continue
+		}
+		pending = append(pending, a)
+	}
+	if len(pending) == 0 || b.curClient == nil {
+		return result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := b.curClient.ConvertMultipleAmounts(ctx, &currencypb.ConvertMultipleAmountsRequest{
+		Amounts:    pending,
+		ToCurrency: currency,
+	})
+	if err != nil {
+		log.Printf("Failed to convert amounts to %s: %v", currency, err)
+		return result
+	}
+
+	for _, c := range resp.Converted {
+		if c.Error != "" {
+			continue
+		}
+		result[c.Id] = c.ConvertedAmount
+	}
+	return result
+}
+
+func (b *Bot) handleCurrency(msg *Message, args []string) string {
+	ctx := context.Background()
+	session, ok := b.loadSession(ctx, msg.Chat.ID)
+	if !ok {
+		return linkFirstMessage
+	}
+
+	if len(args) < 1 {
+		return fmt.Sprintf("Your base currency is %s.\n\nUsage: /currency &lt;code&gt;\nExample: /currency EUR", session.BaseCurrency())
+	}
+
+	code := strings.ToUpper(args[0])
+	supported, err := b.supportedCurrencies(ctx)
+	if err != nil {
+		return "Service temporarily unavailable"
+	}
+	if !supported[code] {
+		return fmt.Sprintf("❌ Unknown currency: %s\n\nTry one of: USD, EUR, GBP, JPY, CHF", escapeHTML(code))
+	}
+
+	if b.authClient != nil {
+		if _, err := b.authClient.UpdateProfile(ctx, &authpb.UpdateProfileRequest{
+			UserId:       session.UserID,
+			BaseCurrency: code,
+		}); err != nil {
+			log.Printf("Failed to update base currency for user %s: %v", session.UserID, err)
+			return "Failed to update currency. Please try again."
+		}
+	}
+
+	session.Currency = code
+	if err := b.saveSession(ctx, msg.Chat.ID, session); err != nil {
+		log.Printf("Failed to persist session for chat %d: %v", msg.Chat.ID, err)
+	}
+
+	return fmt.Sprintf("✅ Base currency set to %s", code)
+}
+
+// supportedCurrencies returns the set of currency codes known to the currency service,
+// falling back to the static list when the service is not connected
+func (b *Bot) supportedCurrencies(ctx context.Context) (map[string]bool, error) {
+	supported := make(map[string]bool)
+	if b.curClient == nil {
+		for code := range utils.SupportedCurrencies {
+			supported[code] = true
+		}
+		return supported, nil
+	}
+
+	resp, err := b.curClient.ListSupportedCurrencies(ctx, &currencypb.ListSupportedCurrenciesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range resp.Currencies {
+		supported[c.Code] = true
+	}
+	return supported, nil
+}
+
+func (b *Bot) handleDashboard(msg *Message) (string, *InlineKeyboardMarkup) {
+	ctx := context.Background()
+	session, ok := b.loadSession(ctx, msg.Chat.ID)
+	if !ok {
+		return linkFirstMessage, nil
+	}
+
+	if b.miniAppURL == "" || b.authClient == nil {
+		return "Service temporarily unavailable", nil
+	}
+
+	resp, err := b.authClient.IssueTelegramLoginToken(ctx, &authpb.IssueTelegramLoginTokenRequest{
+		UserId:     session.UserID,
+		TelegramId: msg.From.ID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.PermissionDenied {
+			return "Your Telegram account is no longer linked. Please use /link &lt;email&gt; again.", nil
+		}
+		return "Failed to open dashboard. Please try again.", nil
+	}
+
+	launchURL, err := url.Parse(b.miniAppURL)
+	if err != nil {
+		log.Printf("Invalid Mini App URL %q: %v", b.miniAppURL, err)
+		return "Service temporarily unavailable", nil
+	}
+	query := launchURL.Query()
+	query.Set("token", resp.Token)
+	launchURL.RawQuery = query.Encode()
+
+	keyboard := &InlineKeyboardMarkup{
+		InlineKeyboard: [][]InlineKeyboardButton{
+			{{Text: "📲 Open Dashboard", URL: launchURL.String()}},
+		},
+	}
+
+	minutes := int(time.Until(resp.ExpiresAt.AsTime()).Round(time.Minute).Minutes())
+	return fmt.Sprintf("📊 <b>Your Dashboard</b>\n\nTap the button below to open the Mini App. The link expires in %d minutes.", minutes), keyboard
 }
 
 func (b *Bot) handleAddAsset(msg *Message, args []string) string {
-	userID, ok := b.userSessions[msg.Chat.ID]
+	ctx := context.Background()
+	session, ok := b.loadSession(ctx, msg.Chat.ID)
 	if !ok {
-		return "Please link your account first using /link <email>"
+		return linkFirstMessage
 	}
 
 	if len(args) < 3 {
-		return "Usage: /addasset <type> <symbol> <quantity>\nExample: /addasset crypto BTC 0.5"
+		return "Usage: /addasset &lt;type&gt; &lt;symbol&gt; &lt;quantity&gt;\nExample: /addasset crypto BTC 0.5"
+	}
+
+	assetType, ok := converters.LookupAssetTypeAssets(args[0])
+	if !ok {
+		return fmt.Sprintf("❌ Unknown asset type: %s\n\nSupported types: stock, crypto, etf, bond, commodity, real_estate, cash, other", escapeHTML(args[0]))
+	}
+
+	quantity, err := strconv.ParseFloat(args[2], 64)
+	if err != nil || quantity <= 0 {
+		return fmt.Sprintf("❌ Invalid quantity: %s\n\nQuantity must be a positive number, e.g. 0.5", escapeHTML(args[2]))
 	}
 
-	// This would typically create an asset through the service
-	return fmt.Sprintf("✅ Asset added successfully!\n\nType: %s\nSymbol: %s\nQuantity: %s\n\nUser: %s",
-		args[0], strings.ToUpper(args[1]), args[2], userID)
+	if b.assetsClient == nil {
+		return "Service temporarily unavailable"
+	}
+
+	asset, err := b.assetsClient.CreateAsset(ctx, &assetspb.CreateAssetRequest{
+		UserId:   session.UserID,
+		Symbol:   strings.ToUpper(args[1]),
+		Type:     assetType,
+		Quantity: quantity,
+	})
+	if err != nil {
+		return "Failed to add asset. Please try again."
+	}
+
+	return fmt.Sprintf("✅ Asset added successfully!\n\nSymbol: %s\nQuantity: %g\nPrice: %.2f %s\nValue: %.2f %s",
+		escapeHTML(asset.Symbol), asset.Quantity,
+		asset.CurrentPrice, asset.Currency,
+		asset.TotalValue, asset.Currency)
 }
 
 func (b *Bot) handleLink(msg *Message, args []string) string {
 	if len(args) < 1 {
-		return "Usage: /link <email>\nExample: /link user@example.com"
+		return "Usage: /link &lt;email&gt;\nExample: /link user@example.com"
+	}
+
+	if b.authClient == nil {
+		return "Service temporarily unavailable"
+	}
+
+	// A bare code confirms a pending link, anything with @ starts a new one
+	if !strings.Contains(args[0], "@") {
+		return b.confirmLink(msg, args[0])
 	}
 
 	email := args[0]
 
-	// In a real implementation, this would verify the email and link the account
-	// For now, we'll just store a placeholder
-	b.userSessions[msg.Chat.ID] = fmt.Sprintf("user_%d", msg.From.ID)
+	ctx := context.Background()
+	_, err := b.authClient.RequestTelegramLink(ctx, &authpb.RequestTelegramLinkRequest{
+		Email:            email,
+		TelegramId:       msg.From.ID,
+		TelegramUsername: msg.From.Username,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.NotFound:
+			return "❌ No Money Control account found for that email."
+		case codes.PermissionDenied:
+			return "❌ This account is not active."
+		case codes.ResourceExhausted:
+			return "⏳ Too many link codes requested. Please try again in an hour."
+		}
+		return "Failed to link account. Please try again."
+	}
 
-	return fmt.Sprintf("✅ Account linked successfully!\n\nEmail: %s\n\nYou can now use all bot features.", email)
+	return fmt.Sprintf("📧 We sent a one-time code to %s.\n\nSend /link &lt;code&gt; to finish linking your account.", escapeHTML(email))
 }
 
-func (b *Bot) sendMessage(chatID int64, text string) error {
-	url := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", b.token)
+func (b *Bot) confirmLink(msg *Message, code string) string {
+	ctx := context.Background()
+	user, err := b.authClient.ConfirmTelegramLink(ctx, &authpb.ConfirmTelegramLinkRequest{
+		TelegramId: msg.From.ID,
+		Code:       code,
+	})
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			return "❌ Invalid or expired code. Use /link &lt;email&gt; to request a new one."
+		case codes.AlreadyExists:
+			return "❌ This Telegram account is already linked to another user."
+		}
+		return "Failed to link account. Please try again."
+	}
 
+	session := &Session{UserID: user.Id, Currency: user.BaseCurrency}
+	if err := b.saveSession(ctx, msg.Chat.ID, session); err != nil {
+		log.Printf("Failed to persist session for chat %d: %v", msg.Chat.ID, err)
+	}
+
+	return fmt.Sprintf("✅ Account linked successfully!\n\nEmail: %s\n\nYou can now use all bot features.", escapeHTML(user.Email))
+}
+
+func (b *Bot) sendMessage(chatID int64, text, parseMode string) error {
+	return b.sendMessageWithKeyboard(chatID, text, parseMode, nil)
+}
+
+func (b *Bot) sendMessageWithKeyboard(chatID int64, text, parseMode string, keyboard *InlineKeyboardMarkup) error {
+	payload := map[string]interface{}{
+		"chat_id": chatID,
+		"text":    text,
+	}
+	if parseMode != "" {
+		payload["parse_mode"] = parseMode
+	}
+	if keyboard != nil {
+		payload["reply_markup"] = keyboard
+	}
+
+	return b.callAPI("sendMessage", payload)
+}
+
+func (b *Bot) answerCallbackQuery(callbackQueryID, text string) error {
 	payload := map[string]interface{}{
-		"chat_id":    chatID,
-		"text":       text,
-		"parse_mode": "Markdown",
+		"callback_query_id": callbackQueryID,
+	}
+	if text != "" {
+		payload["text"] = text
 	}
 
-	data, _ := json.Marshal(payload)
-	_, err := http.Post(url, "application/json", strings.NewReader(string(data)))
+	return b.callAPI("answerCallbackQuery", payload)
+}
+
+// callAPI invokes a Telegram Bot API method through the circuit breaker.
+// Calls are dropped (and logged) while the breaker is open so webhook handling never blocks.
+func (b *Bot) callAPI(method string, payload map[string]interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), telegramAPITimeout)
+	defer cancel()
+
+	err := resilience.Execute(ctx, telegramBreakerName, func(ctx context.Context) error {
+		return b.postAPI(ctx, method, payload)
+	})
+	if errors.Is(err, resilience.ErrCircuitOpen) {
+		log.Printf("Telegram API circuit open, dropping %s call", method)
+		return nil
+	}
+	if err != nil {
+		log.Printf("Telegram API %s call failed: %v", method, err)
+	}
 	return err
 }
 
+func (b *Bot) postAPI(ctx context.Context, method string, payload map[string]interface{}) error {
+	url := fmt.Sprintf("https://api.telegram.org/bot%s/%s", b.token, method)
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Only server-side failures and throttling count against the breaker
+	if resp.StatusCode >= http.StatusInternalServerError || resp.StatusCode == http.StatusTooManyRequests {
+		return fmt.Errorf("telegram api returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
 func getAccountIcon(accountType string) string {
 	icons := map[string]string{
 		"ACCOUNT_TYPE_BANK":        "🏦",
@@ -333,3 +832,26 @@ func getAccountIcon(accountType string) string {
 	return "📁"
 }
 
+func getCategoryIcon(category string) string {
+	icons := map[string]string{
+		"TRANSACTION_CATEGORY_SALARY":            "💼",
+		"TRANSACTION_CATEGORY_INVESTMENT_INCOME": "📈",
+		"TRANSACTION_CATEGORY_FOOD":              "🍔",
+		"TRANSACTION_CATEGORY_TRANSPORT":         "🚗",
+		"TRANSACTION_CATEGORY_UTILITIES":         "💡",
+		"TRANSACTION_CATEGORY_ENTERTAINMENT":     "🎬",
+		"TRANSACTION_CATEGORY_SHOPPING":          "🛍",
+		"TRANSACTION_CATEGORY_HEALTHCARE":        "🏥",
+		"TRANSACTION_CATEGORY_EDUCATION":         "🎓",
+		"TRANSACTION_CATEGORY_TRAVEL":            "✈️",
+		"TRANSACTION_CATEGORY_HOUSING":           "🏠",
+		"TRANSACTION_CATEGORY_INSURANCE":         "🛡",
+		"TRANSACTION_CATEGORY_TAXES":             "🧾",
+		"TRANSACTION_CATEGORY_GIFTS":             "🎁",
+		"TRANSACTION_CATEGORY_TRANSFER":          "🔁",
+	}
+	if icon, ok := icons[category]; ok {
+		return icon
+	}
+	return "💸"
+}
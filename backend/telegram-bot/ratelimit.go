@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+	"golang.org/x/time/rate"
+)
+
+const slowDownMessage = "⏳ Slow down! You're sending commands too quickly. Please wait a moment and try again."
+
+// chatRateLimiter limits how many commands each chat may send per window.
+// Without Redis each chat gets an in-memory token bucket holding Requests
+// commands and refilling over Window, so a burst is allowed but a steady
+// stream is held to the configured rate.
+type chatRateLimiter struct {
+	config middleware.RateLimitConfig
+	redis  *middleware.RateLimiter
+
+	mu      sync.Mutex
+	buckets map[int64]*chatBucket
+}
+
+type chatBucket struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+	notified bool
+}
+
+func newChatRateLimiter(config middleware.RateLimitConfig) *chatRateLimiter {
+	return &chatRateLimiter{
+		config:  config,
+		buckets: make(map[int64]*chatBucket),
+	}
+}
+
+// allowLocal takes a token from the chat's bucket and reports whether the
+// command is allowed and, if not, whether this is the first rejection since
+// the chat was last allowed a command
+func (l *chatRateLimiter) allowLocal(chatID int64, now time.Time) (bool, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// A bucket left idle for a whole window has refilled, so it can be
+	// dropped and recreated full
+	for id, b := range l.buckets {
+		if now.Sub(b.lastSeen) > l.config.Window {
+			delete(l.buckets, id)
+		}
+	}
+
+	b, ok := l.buckets[chatID]
+	if !ok {
+		b = &chatBucket{
+			limiter: rate.NewLimiter(rate.Every(l.config.Window/time.Duration(l.config.Requests)), l.config.Requests),
+		}
+		l.buckets[chatID] = b
+	}
+	b.lastSeen = now
+
+	if b.limiter.AllowN(now, 1) {
+		b.notified = false
+		return true, false
+	}
+
+	notify := !b.notified
+	b.notified = true
+	return false, notify
+}
+
+// allowCommand reports whether a chat may run another command and whether
+// the "slow down" notice should be sent. Redis is used when available so the
+// limit holds across bot replicas; on Redis errors the command is allowed.
+func (b *Bot) allowCommand(ctx context.Context, chatID int64) (bool, bool) {
+	l := b.limiter
+	if l == nil || l.config.Requests <= 0 || l.config.Window <= 0 {
+		return true, false
+	}
+
+	if l.redis == nil || b.cache == nil {
+		return l.allowLocal(chatID, time.Now())
+	}
+
+	key := fmt.Sprintf("telegram:ratelimit:chat:%d", chatID)
+	allowed, _, _, err := l.redis.Allow(ctx, key, l.config)
+	if err != nil {
+		log.Printf("Failed to check rate limit for chat %d: %v", chatID, err)
+		return true, false
+	}
+	if allowed {
+		return true, false
+	}
+
+	notify, err := b.cache.SetNX(ctx, fmt.Sprintf("ratelimit:notified:%d", chatID), true, l.config.Window)
+	if err != nil {
+		log.Printf("Failed to record rate limit notice for chat %d: %v", chatID, err)
+		return false, false
+	}
+	return false, notify
+}
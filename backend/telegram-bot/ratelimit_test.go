@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/middleware"
+)
+
+func TestChatRateLimiterTokenBucket(t *testing.T) {
+	// 3 commands per minute: a burst of 3, then one token every 20s
+	l := newChatRateLimiter(middleware.RateLimitConfig{Requests: 3, Window: time.Minute})
+	start := time.Now()
+
+	type step struct {
+		after      time.Duration
+		wantAllow  bool
+		wantNotify bool
+	}
+	steps := []step{
+		{after: 0, wantAllow: true},
+		{after: 0, wantAllow: true},
+		{after: 0, wantAllow: true},
+		// The burst is spent; only the first rejection is announced
+		{after: 0, wantAllow: false, wantNotify: true},
+		{after: 10 * time.Second, wantAllow: false},
+		// One token has refilled, not a whole new window's worth
+		{after: 20 * time.Second, wantAllow: true},
+		{after: 20 * time.Second, wantAllow: false, wantNotify: true},
+		{after: 40 * time.Second, wantAllow: true},
+		// A fixed window would allow a fresh burst at the minute mark
+		{after: time.Minute, wantAllow: true},
+		{after: time.Minute, wantAllow: false, wantNotify: true},
+	}
+
+	for i, s := range steps {
+		allowed, notify := l.allowLocal(42, start.Add(s.after))
+		if allowed != s.wantAllow || notify != s.wantNotify {
+			t.Errorf("step %d (+%s): allowed, notify = %t, %t; want %t, %t", i, s.after, allowed, notify, s.wantAllow, s.wantNotify)
+		}
+	}
+}
+
+func TestChatRateLimiterPerChat(t *testing.T) {
+	l := newChatRateLimiter(middleware.RateLimitConfig{Requests: 1, Window: time.Minute})
+	now := time.Now()
+
+	if allowed, _ := l.allowLocal(1, now); !allowed {
+		t.Fatal("first command from chat 1 rejected")
+	}
+	if allowed, _ := l.allowLocal(1, now); allowed {
+		t.Fatal("second command from chat 1 allowed")
+	}
+	if allowed, _ := l.allowLocal(2, now); !allowed {
+		t.Fatal("chat 2 limited by chat 1's commands")
+	}
+}
+
+func TestChatRateLimiterDropsIdleBuckets(t *testing.T) {
+	l := newChatRateLimiter(middleware.RateLimitConfig{Requests: 2, Window: time.Minute})
+	now := time.Now()
+
+	l.allowLocal(1, now)
+	l.allowLocal(1, now)
+	l.allowLocal(2, now.Add(2*time.Minute))
+
+	if _, ok := l.buckets[1]; ok {
+		t.Error("idle bucket for chat 1 was kept")
+	}
+	// A dropped bucket comes back full
+	for i := 0; i < 2; i++ {
+		if allowed, _ := l.allowLocal(1, now.Add(2*time.Minute)); !allowed {
+			t.Fatalf("command %d after the idle period rejected", i+1)
+		}
+	}
+}
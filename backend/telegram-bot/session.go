@@ -0,0 +1,69 @@
+package main
+
+import (
+	"context"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/radmickey/money-control/backend/pkg/cache"
+)
+
+const (
+	sessionTTL      = 30 * 24 * time.Hour
+	defaultCurrency = "USD"
+)
+
+// Session holds per-chat state for a linked Telegram user
+type Session struct {
+	UserID   string `json:"user_id"`
+	Currency string `json:"currency,omitempty"`
+}
+
+// BaseCurrency returns the chat's preferred currency, defaulting to USD
+func (s *Session) BaseCurrency() string {
+	if s.Currency == "" {
+		return defaultCurrency
+	}
+	return s.Currency
+}
+
+// loadSession returns the session for a chat, refreshing its TTL.
+// Falls back to the in-memory map when Redis is not configured or unavailable.
+func (b *Bot) loadSession(ctx context.Context, chatID int64) (*Session, bool) {
+	if b.cache != nil {
+		key := sessionKey(chatID)
+		var session Session
+		if err := b.cache.Get(ctx, key, &session); err == nil && session.UserID != "" {
+			if err := b.cache.Expire(ctx, key, sessionTTL); err != nil {
+				log.Printf("Failed to refresh session TTL for chat %d: %v", chatID, err)
+			}
+			return &session, true
+		}
+	}
+
+	b.sessionsMu.RLock()
+	defer b.sessionsMu.RUnlock()
+
+	session, ok := b.userSessions[chatID]
+	if !ok {
+		return nil, false
+	}
+	return &session, true
+}
+
+// saveSession stores the session for a chat in Redis and in memory
+func (b *Bot) saveSession(ctx context.Context, chatID int64, session *Session) error {
+	b.sessionsMu.Lock()
+	b.userSessions[chatID] = *session
+	b.sessionsMu.Unlock()
+
+	if b.cache == nil {
+		return nil
+	}
+	return b.cache.Set(ctx, sessionKey(chatID), session, sessionTTL)
+}
+
+func sessionKey(chatID int64) string {
+	return cache.UserSessionKey(strconv.FormatInt(chatID, 10))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// postUpdate sends an update with no message to the bot's webhook, setting
+// the secret header unless secret is empty
+func postUpdate(bot *Bot, updateID, secret string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(`{"update_id":`+updateID+`}`))
+	if secret != "" {
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	}
+	w := httptest.NewRecorder()
+	bot.handleWebhook(w, req)
+	return w.Code
+}
+
+func TestWebhookSecret(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		sent       string
+		want       int
+	}{
+		{name: "unset accepts without header", configured: "", sent: "", want: http.StatusOK},
+		{name: "unset accepts any header", configured: "", sent: "anything", want: http.StatusOK},
+		{name: "set accepts matching header", configured: "s3cret", sent: "s3cret", want: http.StatusOK},
+		{name: "set rejects missing header", configured: "s3cret", sent: "", want: http.StatusUnauthorized},
+		{name: "set rejects wrong header", configured: "s3cret", sent: "s3crex", want: http.StatusUnauthorized},
+		{name: "set rejects prefix", configured: "s3cret", sent: "s3c", want: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bot := &Bot{webhookSecret: tt.configured, dedup: newUpdateDeduplicator(time.Minute)}
+			if got := postUpdate(bot, "1", tt.sent); got != tt.want {
+				t.Errorf("status = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhookRejectedUpdateNotMarkedSeen(t *testing.T) {
+	bot := &Bot{webhookSecret: "s3cret", dedup: newUpdateDeduplicator(time.Minute)}
+
+	if got := postUpdate(bot, "7", "wrong"); got != http.StatusUnauthorized {
+		t.Fatalf("forged update: status = %d, want 401", got)
+	}
+	// A forged update must not make Telegram's real delivery look like a duplicate
+	if !bot.dedup.markSeen(7) {
+		t.Error("rejected update was recorded as seen")
+	}
+}